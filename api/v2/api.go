@@ -57,6 +57,7 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 	changefeedGroup.POST("/:changefeed_id/resume", coordinatorMiddleware, authenticateMiddleware, api.resumeChangefeed)
 	changefeedGroup.POST("/:changefeed_id/pause", coordinatorMiddleware, authenticateMiddleware, api.pauseChangefeed)
 	changefeedGroup.DELETE("/:changefeed_id", coordinatorMiddleware, authenticateMiddleware, api.deleteChangefeed)
+	changefeedGroup.PUT("/:changefeed_id/bdr_role", coordinatorMiddleware, authenticateMiddleware, api.setBDRRole)
 
 	// internal APIs
 	changefeedGroup.POST("/:changefeed_id/move_table", authenticateMiddleware, api.moveTable)
@@ -67,6 +68,8 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 	captureGroup := v2.Group("/captures")
 	captureGroup.Use(coordinatorMiddleware)
 	captureGroup.GET("", api.listCaptures)
+	captureGroup.PUT("/:capture_id/drain", authenticateMiddleware, api.drainCapture)
+	captureGroup.DELETE("/:capture_id/drain", authenticateMiddleware, api.undrainCapture)
 
 	verifyTableGroup := v2.Group("/verify_table")
 	verifyTableGroup.POST("", api.verifyTable)
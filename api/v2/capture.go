@@ -18,7 +18,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/server/watcher"
+	"github.com/pingcap/tiflow/cdc/api"
 )
 
 // listCaptures lists all captures
@@ -53,3 +56,65 @@ func (h *OpenAPIV2) listCaptures(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, resp)
 }
+
+// drainCapture drains a capture
+// @Summary Drain a capture
+// @Description Mark the capture unschedulable and move all the maintainers and dispatchers away from it.
+// @Description The api can be called repeatedly to query the progress, the capture is drained when the remaining counts are zero.
+// @Description The changefeeds which have not reported their status after the drain request are counted as unknown.
+// @Tags capture,v2
+// @Produce json
+// @Param capture_id path string true "capture_id"
+// @Success 200 {object} DrainCaptureResponse
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/captures/{capture_id}/drain [put]
+func (h *OpenAPIV2) drainCapture(c *gin.Context) {
+	captureID := c.Param(api.APIOpVarCaptureID)
+	if captureID == "" {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("capture_id is required"))
+		return
+	}
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	progress, err := coordinator.DrainNode(c.Request.Context(), node.ID(captureID))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &DrainCaptureResponse{
+		RemainingMaintainers: progress.RemainingMaintainers,
+		RemainingDispatchers: progress.RemainingDispatchers,
+		UnknownChangefeeds:   progress.UnknownChangefeeds,
+	})
+}
+
+// undrainCapture undrains a capture
+// @Summary Undrain a capture
+// @Description Clear the unschedulable mark of the capture, so the tasks can be scheduled to it again.
+// @Description The tasks moved away from the capture are not moved back until the balance scheduler moves them.
+// @Tags capture,v2
+// @Produce json
+// @Param capture_id path string true "capture_id"
+// @Success 200 {object} EmptyResponse
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/captures/{capture_id}/drain [delete]
+func (h *OpenAPIV2) undrainCapture(c *gin.Context) {
+	captureID := c.Param(api.APIOpVarCaptureID)
+	if captureID == "" {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("capture_id is required"))
+		return
+	}
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := coordinator.UndrainNode(c.Request.Context(), node.ID(captureID)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
@@ -20,6 +20,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -94,7 +95,33 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 		return
 	}
 
-	ts, logical, err := h.server.GetPdClient().GetTS(ctx)
+	// The changefeed replicates from the default upstream unless the pd addresses are specified.
+	pdClient := h.server.GetPdClient()
+	var upstreamInfo *config.UpstreamInfo
+	if len(cfg.PDAddrs) > 0 {
+		timeoutCtx, cancel := context.WithTimeout(ctx, getPDClientTimeout)
+		upstreamPDClient, err := getPDClient(timeoutCtx, cfg.PDAddrs, cfg.PDConfig.toCredential())
+		cancel()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		defer upstreamPDClient.Close()
+		upstreamID := upstreamPDClient.GetClusterID(ctx)
+		if upstreamID != pdClient.GetClusterID(ctx) {
+			pdClient = upstreamPDClient
+			upstreamInfo = &config.UpstreamInfo{
+				ID:            upstreamID,
+				PDEndpoints:   strings.Join(cfg.PDAddrs, ","),
+				KeyPath:       cfg.KeyPath,
+				CertPath:      cfg.CertPath,
+				CAPath:        cfg.CAPath,
+				CertAllowedCN: cfg.CertAllowedCN,
+			}
+		}
+	}
+
+	ts, logical, err := pdClient.GetTS(ctx)
 	if err != nil {
 		_ = c.Error(errors.ErrPDEtcdAPIError.GenWithStackByArgs("fail to get ts from pd client"))
 		return
@@ -113,7 +140,7 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 	createGcServiceID := h.server.GetEtcdClient().GetGCServiceID()
 	if err = gc.EnsureChangefeedStartTsSafety(
 		ctx,
-		h.server.GetPdClient(),
+		pdClient,
 		createGcServiceID,
 		gc.EnsureGCServiceCreating,
 		changefeedID,
@@ -133,6 +160,11 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 		return
 	}
 
+	if err := cfg.Priority.Validate(); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+
 	// fill replicaConfig
 	replicaCfg := cfg.ReplicaConfig.ToInternalReplicaConfig()
 	// verify replicaConfig
@@ -147,9 +179,9 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 		return
 	}
 
-	pdClient := h.server.GetPdClient()
 	info := &config.ChangeFeedInfo{
 		UpstreamID:     pdClient.GetClusterID(ctx),
+		UpstreamInfo:   upstreamInfo,
 		ChangefeedID:   changefeedID,
 		SinkURI:        cfg.SinkURI,
 		CreateTime:     time.Now(),
@@ -159,6 +191,8 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 		State:          model.StateNormal,
 		CreatorVersion: version.ReleaseVersion,
 		Epoch:          owner.GenerateChangefeedEpoch(ctx, pdClient),
+		Priority:       cfg.Priority,
+		NodeAffinity:   cfg.NodeAffinity,
 	}
 
 	// verify sinkURI
@@ -345,6 +379,8 @@ func toAPIModel(
 		TaskStatus:     taskStatus,
 		MaintainerAddr: status.GetMaintainerAddr(),
 		GID:            info.ChangefeedID.ID(),
+		Priority:       info.Priority,
+		NodeAffinity:   info.NodeAffinity,
 	}
 	return apiInfoModel
 }
@@ -486,9 +522,21 @@ func (h *OpenAPIV2) resumeChangefeed(c *gin.Context) {
 		newCheckpointTs = cfg.OverwriteCheckpointTs
 	}
 
+	// the gc safepoint must be ensured in the upstream the changefeed replicates from
+	pdClient := h.server.GetPdClient()
+	if cfInfo.UpstreamInfo != nil {
+		upstreamPDClient, err := getUpstreamPDClient(ctx, cfInfo.UpstreamInfo)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		defer upstreamPDClient.Close()
+		pdClient = upstreamPDClient
+	}
+
 	if err := verifyResumeChangefeedConfig(
 		ctx,
-		h.server.GetPdClient(),
+		pdClient,
 		h.server.GetEtcdClient().GetEnsureGCServiceID(gc.EnsureGCServiceResuming),
 		cfInfo.ChangefeedID,
 		newCheckpointTs); err != nil {
@@ -502,7 +550,7 @@ func (h *OpenAPIV2) resumeChangefeed(c *gin.Context) {
 		}
 		err := gc.UndoEnsureChangefeedStartTsSafety(
 			ctx,
-			h.server.GetPdClient(),
+			pdClient,
 			h.server.GetEtcdClient().GetEnsureGCServiceID(gc.EnsureGCServiceResuming),
 			cfInfo.ChangefeedID,
 		)
@@ -526,6 +574,8 @@ func (h *OpenAPIV2) resumeChangefeed(c *gin.Context) {
 // Can only update a changefeed's: TargetTs, SinkURI,
 // ReplicaConfig, PDAddrs, CAPath, CertPath, KeyPath,
 // SyncPointEnabled, SyncPointInterval
+// A running changefeed can only update its hot-reloadable config,
+// see config.ChangeFeedInfo.IsHotReloadable for details.
 // UpdateChangefeed updates a changefeed
 // @Summary Update a changefeed
 // @Description Update a changefeed
@@ -558,12 +608,17 @@ func (h *OpenAPIV2) updateChangefeed(c *gin.Context) {
 		return
 	}
 
+	// A running changefeed can only be updated with the hot-reloadable config,
+	// the changes are pushed to the running dispatchers by the maintainer.
+	running := false
 	switch oldCfInfo.State {
 	case model.StateStopped, model.StateFailed:
+	case model.StateNormal, model.StateWarning:
+		running = true
 	default:
 		_ = c.Error(
 			errors.ErrChangefeedUpdateRefused.GenWithStackByArgs(
-				"can only update changefeed config when it is stopped or failed",
+				"can only update changefeed config when it is running, stopped or failed",
 			),
 		)
 		return
@@ -575,39 +630,63 @@ func (h *OpenAPIV2) updateChangefeed(c *gin.Context) {
 		return
 	}
 
+	newCfInfo, err := oldCfInfo.Clone()
+	if err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
 	if updateCfConfig.TargetTs != 0 {
-		if updateCfConfig.TargetTs <= oldCfInfo.StartTs {
+		if updateCfConfig.TargetTs <= newCfInfo.StartTs {
 			_ = c.Error(errors.ErrChangefeedUpdateRefused.GenWithStack(
 				"can not update target_ts:%d less than start_ts:%d",
-				updateCfConfig.TargetTs, oldCfInfo.StartTs))
+				updateCfConfig.TargetTs, newCfInfo.StartTs))
 			return
 		}
-		oldCfInfo.TargetTs = updateCfConfig.TargetTs
+		newCfInfo.TargetTs = updateCfConfig.TargetTs
 	}
 	if updateCfConfig.ReplicaConfig != nil {
-		oldCfInfo.Config = updateCfConfig.ReplicaConfig.ToInternalReplicaConfig()
+		newCfInfo.Config = updateCfConfig.ReplicaConfig.ToInternalReplicaConfig()
 	}
 	if updateCfConfig.SinkURI != "" {
-		oldCfInfo.SinkURI = updateCfConfig.SinkURI
+		newCfInfo.SinkURI = updateCfConfig.SinkURI
+	}
+	if updateCfConfig.Priority != "" {
+		if err := updateCfConfig.Priority.Validate(); err != nil {
+			_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+			return
+		}
+		newCfInfo.Priority = updateCfConfig.Priority
+	}
+	if updateCfConfig.NodeAffinity != nil {
+		newCfInfo.NodeAffinity = updateCfConfig.NodeAffinity
 	}
 	if updateCfConfig.StartTs != 0 {
 		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("start_ts can not be updated"))
 		return
 	}
+	if running && !oldCfInfo.IsHotReloadable(newCfInfo) {
+		_ = c.Error(
+			errors.ErrChangefeedUpdateRefused.GenWithStackByArgs(
+				"only table filter rules, event filters, ignore txn start ts, column selectors, dispatchers, " +
+					"mysql worker count, memory quota and bdr role can be updated when the changefeed is running, please pause it first",
+			),
+		)
+		return
+	}
 	// verify replicaConfig
-	sinkURIParsed, err := url.Parse(oldCfInfo.SinkURI)
+	sinkURIParsed, err := url.Parse(newCfInfo.SinkURI)
 	if err != nil {
 		_ = c.Error(errors.WrapError(errors.ErrSinkURIInvalid, err))
 		return
 	}
-	err = oldCfInfo.Config.ValidateAndAdjust(sinkURIParsed)
+	err = newCfInfo.Config.ValidateAndAdjust(sinkURIParsed)
 	if err != nil {
 		_ = c.Error(errors.WrapError(errors.ErrInvalidReplicaConfig, err))
 		return
 	}
 
 	// verify changefeed filter
-	_, err = filter.NewFilter(oldCfInfo.Config.Filter, "", oldCfInfo.Config.CaseSensitive, oldCfInfo.Config.ForceReplicate)
+	_, err = filter.NewFilter(newCfInfo.Config.Filter, "", newCfInfo.Config.CaseSensitive, newCfInfo.Config.ForceReplicate)
 	if err != nil {
 		_ = c.Error(errors.ErrChangefeedUpdateRefused.
 			GenWithStackByArgs(errors.Cause(err).Error()))
@@ -616,18 +695,83 @@ func (h *OpenAPIV2) updateChangefeed(c *gin.Context) {
 
 	// verify sink
 	tempChangefeedID := common.NewChangeFeedIDWithName("sink-uri-verify-changefeed-id")
-	err = sink.VerifySink(ctx, oldCfInfo.ToChangefeedConfig(), tempChangefeedID)
+	err = sink.VerifySink(ctx, newCfInfo.ToChangefeedConfig(), tempChangefeedID)
 	if err != nil {
 		_ = c.Error(errors.WrapError(errors.ErrSinkURIInvalid, err))
 		return
 	}
 
-	if err := coordinator.UpdateChangefeed(ctx, oldCfInfo); err != nil {
+	if err := coordinator.UpdateChangefeed(ctx, newCfInfo); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toAPIModel(newCfInfo, status, nil))
+}
+
+// setBDRRole sets the bdr role of a changefeed
+// @Summary Set the bdr role of a changefeed
+// @Description Set the bdr role of a changefeed, the role can be updated when the changefeed is running
+// @Tags changefeed,v2
+// @Accept json
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param namespace query string false "default"
+// @Param bdrRoleConfig body BDRRoleConfig true "bdr role config"
+// @Success 200 {object} ChangeFeedInfo
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/bdr_role [put]
+func (h *OpenAPIV2) setBDRRole(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), getNamespaceValueWithDefault(c))
+	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedDisplayName.Name))
+		return
+	}
+	roleConfig := &BDRRoleConfig{}
+	if err := c.BindJSON(roleConfig); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	role := config.BDRRole(roleConfig.BDRRole)
+	if err := role.Validate(); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	oldCfInfo, status, err := coordinator.GetChangefeed(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if !util.GetOrZero(oldCfInfo.Config.BDRMode) {
+		_ = c.Error(errors.ErrChangefeedUpdateRefused.GenWithStackByArgs(
+			"bdr role can only be set when the bdr mode is enabled"))
+		return
+	}
+
+	newCfInfo, err := oldCfInfo.Clone()
+	if err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	newCfInfo.Config.BDRRole = role
+	if err := coordinator.UpdateChangefeed(ctx, newCfInfo); err != nil {
 		_ = c.Error(err)
 		return
 	}
+	log.Info("set bdr role of changefeed",
+		zap.String("changefeed", changefeedDisplayName.Name),
+		zap.String("bdrRole", string(role)))
 
-	c.JSON(http.StatusOK, toAPIModel(oldCfInfo, status, nil))
+	c.JSON(http.StatusOK, toAPIModel(newCfInfo, status, nil))
 }
 
 // verifyResumeChangefeedConfig verifies the changefeed config before resuming a changefeed
@@ -19,12 +19,18 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/httputil"
+	"github.com/pingcap/tiflow/pkg/security"
+	pd "github.com/tikv/pd/client"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
 )
 
 func getChangeFeed(host, cfName string) (ChangeFeedInfo, error) {
@@ -79,3 +85,52 @@ func getChangeFeed(host, cfName string) (ChangeFeedInfo, error) {
 
 	return cfInfo, nil
 }
+
+// getPDClientTimeout is the timeout to connect to the pd of an upstream
+const getPDClientTimeout = 30 * time.Second
+
+// getPDClient returns a PDClient given the PD cluster addresses and a credential
+func getPDClient(
+	ctx context.Context,
+	pdAddrs []string,
+	credential *security.Credential,
+) (pd.Client, error) {
+	grpcTLSOption, err := credential.ToGRPCDialOption()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	pdClient, err := pd.NewClientWithContext(
+		ctx, pdAddrs, credential.PDSecurityOption(),
+		pd.WithGRPCDialOptions(
+			grpcTLSOption,
+			grpc.WithBlock(),
+			grpc.WithConnectParams(grpc.ConnectParams{
+				Backoff: backoff.Config{
+					BaseDelay:  time.Second,
+					Multiplier: 1.1,
+					Jitter:     0.1,
+					MaxDelay:   3 * time.Second,
+				},
+				MinConnectTimeout: 3 * time.Second,
+			}),
+		),
+		pd.WithForwardingOption(config.EnablePDForwarding))
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrAPIGetPDClientFailed, errors.Trace(err))
+	}
+	return pdClient, nil
+}
+
+// getUpstreamPDClient returns a PDClient of the upstream described by info,
+// the caller should close the client after use.
+func getUpstreamPDClient(ctx context.Context, info *config.UpstreamInfo) (pd.Client, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, getPDClientTimeout)
+	defer cancel()
+	return getPDClient(timeoutCtx, strings.Split(info.PDEndpoints, ","), &security.Credential{
+		CAPath:        info.CAPath,
+		CertPath:      info.CertPath,
+		KeyPath:       info.KeyPath,
+		CertAllowedCN: info.CertAllowedCN,
+	})
+}
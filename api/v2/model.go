@@ -82,6 +82,11 @@ type ResumeChangefeedConfig struct {
 	OverwriteCheckpointTs uint64 `json:"overwrite_checkpoint_ts"`
 }
 
+// BDRRoleConfig is used by set bdr role api
+type BDRRoleConfig struct {
+	BDRRole string `json:"bdr_role"`
+}
+
 // PDConfig is a configuration used to connect to pd
 type PDConfig struct {
 	PDAddrs       []string `json:"pd_addrs,omitempty"`
@@ -140,6 +145,10 @@ type ChangefeedConfig struct {
 	TargetTs      uint64         `json:"target_ts"`
 	SinkURI       string         `json:"sink_uri"`
 	ReplicaConfig *ReplicaConfig `json:"replica_config"`
+	// Priority is the scheduling priority of the changefeed, one of normal and high.
+	Priority config.ChangefeedPriority `json:"priority,omitempty"`
+	// NodeAffinity is the labels of the nodes the changefeed prefers to run on.
+	NodeAffinity map[string]string `json:"node_affinity,omitempty"`
 	PDConfig
 }
 
@@ -192,6 +201,7 @@ type ReplicaConfig struct {
 	EnableSyncPoint       *bool  `json:"enable_sync_point,omitempty"`
 	EnableTableMonitor    *bool  `json:"enable_table_monitor,omitempty"`
 	BDRMode               *bool  `json:"bdr_mode,omitempty"`
+	BDRRole               string `json:"bdr_role,omitempty"`
 
 	SyncPointInterval  *JSONDuration `json:"sync_point_interval,omitempty" swaggertype:"string"`
 	SyncPointRetention *JSONDuration `json:"sync_point_retention,omitempty" swaggertype:"string"`
@@ -232,6 +242,7 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 		res.SyncPointRetention = &c.SyncPointRetention.duration
 	}
 	res.BDRMode = c.BDRMode
+	res.BDRRole = config.BDRRole(c.BDRRole)
 
 	if c.Filter != nil {
 		var efs []*config.EventFilterRule
@@ -546,6 +557,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 		EnableSyncPoint:       cloned.EnableSyncPoint,
 		EnableTableMonitor:    cloned.EnableTableMonitor,
 		BDRMode:               cloned.BDRMode,
+		BDRRole:               string(cloned.BDRRole),
 	}
 
 	if cloned.SyncPointInterval != nil {
@@ -1090,6 +1102,9 @@ type ChangeFeedInfo struct {
 
 	GID            common.GID `json:"gid"`
 	MaintainerAddr string     `json:"maintainer_addr,omitempty"`
+
+	Priority     config.ChangefeedPriority `json:"priority,omitempty"`
+	NodeAffinity map[string]string         `json:"node_affinity,omitempty"`
 }
 
 // SyncedStatus describes the detail of a changefeed's synced status
@@ -1185,6 +1200,16 @@ type Capture struct {
 	ClusterID     string `json:"cluster_id"`
 }
 
+// DrainCaptureResponse is the response of drain capture api,
+// the capture is drained when all the counts are zero.
+type DrainCaptureResponse struct {
+	RemainingMaintainers int `json:"remaining_maintainers"`
+	RemainingDispatchers int `json:"remaining_dispatchers"`
+	// UnknownChangefeeds is the number of changefeeds which have not reported
+	// their status after the drain request, their dispatchers are not counted.
+	UnknownChangefeeds int `json:"unknown_changefeeds"`
+}
+
 // CodecConfig represents a MQ codec configuration
 type CodecConfig struct {
 	EnableTiDBExtension            *bool   `json:"enable_tidb_extension,omitempty"`
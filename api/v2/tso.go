@@ -14,16 +14,38 @@
 package v2
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/pingcap/ticdc/pkg/errors"
 )
 
 // QueryTso request and returns a TSO from PD
 func (h *OpenAPIV2) QueryTso(c *gin.Context) {
 	ctx := c.Request.Context()
+	upstreamConfig := &UpstreamConfig{}
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(upstreamConfig); err != nil {
+			_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+			return
+		}
+	}
+
 	resp := &Tso{}
 	client := h.server.GetPdClient()
+	// query the tso from the specified upstream if the pd addresses are given
+	if len(upstreamConfig.PDAddrs) > 0 {
+		timeoutCtx, cancel := context.WithTimeout(ctx, getPDClientTimeout)
+		upstreamPDClient, err := getPDClient(timeoutCtx, upstreamConfig.PDAddrs, upstreamConfig.toCredential())
+		cancel()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		defer upstreamPDClient.Close()
+		client = upstreamPDClient
+	}
 	timestamp, logicalTime, err := client.GetTS(ctx)
 	if err != nil {
 		_ = c.Error(err)
@@ -56,6 +56,7 @@ func NewCmdCli() *cobra.Command {
 	cmds.AddCommand(newCmdCapture(f))
 	cmds.AddCommand(newCmdTso(f))
 	cmds.AddCommand(newCmdUnsafe(f))
+	cmds.AddCommand(newCmdBDR(f))
 
 	return cmds
 }
@@ -0,0 +1,94 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"github.com/pingcap/ticdc/cmd/cdc/factory"
+	apiv2client "github.com/pingcap/ticdc/pkg/api/v2"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// setBDRRoleOptions defines flags for the `cli bdr set-role` command.
+type setBDRRoleOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	changefeedID string
+	namespace    string
+	role         string
+}
+
+// newSetBDRRoleOptions creates new options for the `cli bdr set-role` command.
+func newSetBDRRoleOptions() *setBDRRoleOptions {
+	return &setBDRRoleOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *setBDRRoleOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().StringVar(&o.role, "role", "", "BDR role of the upstream cluster, one of none, primary and secondary")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+	_ = cmd.MarkPersistentFlagRequired("role")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *setBDRRoleOptions) complete(f factory.Factory) error {
+	apiClient, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+
+	o.apiClient = apiClient
+	return nil
+}
+
+// validate checks that the provided role is valid.
+func (o *setBDRRoleOptions) validate() error {
+	return config.BDRRole(o.role).Validate()
+}
+
+// run the `cli bdr set-role` command.
+func (o *setBDRRoleOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	_, err := o.apiClient.Changefeeds().SetBDRRole(ctx, o.namespace, o.changefeedID, o.role)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("Set the bdr role of changefeed %s to %s successfully\n", o.changefeedID, o.role)
+	return nil
+}
+
+// newCmdSetBDRRole creates the `cli bdr set-role` command.
+func newCmdSetBDRRole(f factory.Factory) *cobra.Command {
+	o := newSetBDRRoleOptions()
+
+	command := &cobra.Command{
+		Use:   "set-role",
+		Short: "Set the bdr role of a replication task (changefeed)",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.validate())
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"time"
+
+	"github.com/pingcap/ticdc/cmd/cdc/factory"
+	apiv2client "github.com/pingcap/ticdc/pkg/api/v2"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// drainCapturePollInterval is the interval to poll the remaining workload
+// of the draining capture.
+const drainCapturePollInterval = time.Second
+
+// drainCaptureOptions defines flags for the `cli capture drain` command.
+type drainCaptureOptions struct {
+	apiv2Client apiv2client.APIV2Interface
+
+	captureID string
+}
+
+// newDrainCaptureOptions creates new drainCaptureOptions for the `cli capture drain` command.
+func newDrainCaptureOptions() *drainCaptureOptions {
+	return &drainCaptureOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *drainCaptureOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&o.captureID, "capture-id", "", "ID of the capture to drain")
+	_ = cmd.MarkPersistentFlagRequired("capture-id")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *drainCaptureOptions) complete(f factory.Factory) error {
+	apiv2Client, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiv2Client = apiv2Client
+	return nil
+}
+
+// run runs the `cli capture drain` command. It keeps draining the capture
+// until no maintainer or dispatcher is left on it.
+func (o *drainCaptureOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	ticker := time.NewTicker(drainCapturePollInterval)
+	defer ticker.Stop()
+	for {
+		resp, err := o.apiv2Client.Captures().Drain(ctx, o.captureID)
+		if err != nil {
+			return err
+		}
+		if resp.RemainingMaintainers == 0 && resp.RemainingDispatchers == 0 && resp.UnknownChangefeeds == 0 {
+			cmd.Printf("Capture %s is drained\n", o.captureID)
+			return nil
+		}
+		cmd.Printf("Draining capture %s, remaining maintainers: %d, remaining dispatchers: %d, "+
+			"changefeeds not reported yet: %d\n",
+			o.captureID, resp.RemainingMaintainers, resp.RemainingDispatchers, resp.UnknownChangefeeds)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// newCmdDrainCapture creates the `cli capture drain` command.
+func newCmdDrainCapture(f factory.Factory) *cobra.Command {
+	o := newDrainCaptureOptions()
+
+	command := &cobra.Command{
+		Use:   "drain",
+		Short: "Drain a capture by moving all its workload to other captures",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+	o.addFlags(command)
+
+	return command
+}
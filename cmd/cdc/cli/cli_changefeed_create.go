@@ -45,6 +45,8 @@ type changefeedCommonOptions struct {
 	configFile     string
 	sortEngine     string
 	sortDir        string
+	priority       string
+	nodeAffinity   map[string]string
 
 	upstreamPDAddrs  string
 	upstreamCaPath   string
@@ -66,6 +68,10 @@ func (o *changefeedCommonOptions) addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVar(&o.configFile, "config", "", "Path of the configuration file")
 	cmd.PersistentFlags().StringVar(&o.sortEngine, "sort-engine", model.SortUnified, "sort engine used for data sort")
 	cmd.PersistentFlags().StringVar(&o.sortDir, "sort-dir", "", "directory used for data sort")
+	cmd.PersistentFlags().StringVar(&o.priority, "priority", "",
+		"Scheduling priority of the changefeed, one of normal and high")
+	cmd.PersistentFlags().StringToStringVar(&o.nodeAffinity, "node-affinity", nil,
+		"Labels of the nodes the changefeed prefers to run on, e.g. zone=z1,disk=ssd")
 	cmd.PersistentFlags().StringVar(&o.schemaRegistry, "schema-registry", "",
 		"Avro Schema Registry URI")
 	cmd.PersistentFlags().StringVar(&o.upstreamPDAddrs, "upstream-pd", "",
@@ -79,11 +85,6 @@ func (o *changefeedCommonOptions) addFlags(cmd *cobra.Command) {
 	_ = cmd.PersistentFlags().MarkHidden("sort-dir")
 	// we don't support specify these flags below when cdc version >= 6.2.0
 	_ = cmd.PersistentFlags().MarkHidden("sort-engine")
-	// we don't support specify there flags below when cdc version <= 6.3.0
-	_ = cmd.PersistentFlags().MarkHidden("upstream-pd")
-	_ = cmd.PersistentFlags().MarkHidden("upstream-ca")
-	_ = cmd.PersistentFlags().MarkHidden("upstream-cert")
-	_ = cmd.PersistentFlags().MarkHidden("upstream-key")
 }
 
 // strictDecodeConfig do strictDecodeFile check and only verify the rules for now.
@@ -221,6 +222,8 @@ func (o *createChangefeedOptions) getChangefeedConfig() *v2.ChangefeedConfig {
 		TargetTs:      o.commonChangefeedOptions.targetTs,
 		SinkURI:       o.commonChangefeedOptions.sinkURI,
 		ReplicaConfig: replicaConfig,
+		Priority:      config.ChangefeedPriority(o.commonChangefeedOptions.priority),
+		NodeAffinity:  o.commonChangefeedOptions.nodeAffinity,
 		PDConfig:      upstreamConfig.PDConfig,
 	}
 }
@@ -21,6 +21,7 @@ import (
 	v2 "github.com/pingcap/ticdc/api/v2"
 	"github.com/pingcap/ticdc/cmd/cdc/factory"
 	apiv2client "github.com/pingcap/ticdc/pkg/api/v2"
+	"github.com/pingcap/ticdc/pkg/config"
 	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
 	"github.com/pingcap/tiflow/pkg/cmd/util"
 	putil "github.com/pingcap/tiflow/pkg/util"
@@ -50,6 +51,11 @@ func newUpdateChangefeedOptions(commonChangefeedOptions *changefeedCommonOptions
 // flags related to template printing to it.
 func (o *updateChangefeedOptions) addFlags(cmd *cobra.Command) {
 	o.commonChangefeedOptions.addFlags(cmd)
+	// the upstream of a changefeed can only be specified when it's created
+	_ = cmd.PersistentFlags().MarkHidden("upstream-pd")
+	_ = cmd.PersistentFlags().MarkHidden("upstream-ca")
+	_ = cmd.PersistentFlags().MarkHidden("upstream-cert")
+	_ = cmd.PersistentFlags().MarkHidden("upstream-key")
 	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
 	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
 	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
@@ -63,6 +69,8 @@ func (o *updateChangefeedOptions) getChangefeedConfig(cmd *cobra.Command,
 		TargetTs:      info.TargetTs,
 		SinkURI:       info.SinkURI,
 		ReplicaConfig: replicaConfig,
+		Priority:      info.Priority,
+		NodeAffinity:  info.NodeAffinity,
 	}
 	cmd.Flags().Visit(func(flag *pflag.Flag) {
 		switch flag.Name {
@@ -166,6 +174,10 @@ func (o *updateChangefeedOptions) applyChanges(oldInfo *v2.ChangeFeedInfo,
 			newInfo.Config = v2.ToAPIReplicaConfig(cfg)
 		case "schema-registry":
 			newInfo.Config.Sink.SchemaRegistry = putil.AddressOf(o.commonChangefeedOptions.schemaRegistry)
+		case "priority":
+			newInfo.Priority = config.ChangefeedPriority(o.commonChangefeedOptions.priority)
+		case "node-affinity":
+			newInfo.NodeAffinity = o.commonChangefeedOptions.nodeAffinity
 		case "sort-engine":
 		case "sort-dir":
 			log.Warn("this flag cannot be updated and will be ignored", zap.String("flagName", flag.Name))
@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/cmd/cdc/cli"
+	"github.com/pingcap/ticdc/cmd/cdc/redo"
 	"github.com/pingcap/ticdc/cmd/cdc/server"
 	"github.com/pingcap/ticdc/cmd/cdc/version"
 	"github.com/pingcap/ticdc/pkg/config"
@@ -45,6 +46,7 @@ func NewCmd() *cobra.Command {
 func addNewArchCommandTo(cmd *cobra.Command) {
 	cmd.AddCommand(server.NewCmdServer())
 	cmd.AddCommand(cli.NewCmdCli())
+	cmd.AddCommand(redo.NewCmdRedo())
 	cmd.AddCommand(version.NewCmdVersion())
 }
 
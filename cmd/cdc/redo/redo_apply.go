@@ -0,0 +1,89 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	"net/url"
+
+	"github.com/pingcap/ticdc/pkg/applier"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// newRedoApplier creates the applier of the `redo apply` command, it's replaced in test.
+var newRedoApplier = applier.NewRedoApplier
+
+// applyOptions defines flags for the `redo apply` command.
+type applyOptions struct {
+	*options
+	sinkURI string
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to the downstream to it.
+func (o *applyOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.sinkURI, "sink-uri", "", "target database sink-uri, only mysql-compatible database is supported")
+	// the possible error returned from MarkFlagRequired is `no such flag`
+	cmd.MarkFlagRequired("sink-uri") //nolint:errcheck
+}
+
+// complete adapts from the command line args to the data required.
+func (o *applyOptions) complete() error {
+	sinkURI, err := url.Parse(o.sinkURI)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	switch sinkURI.Scheme {
+	case "mysql", "mysql+ssl", "tidb", "tidb+ssl":
+	default:
+		return cerror.ErrSinkURIInvalid.GenWithStack(
+			"the redo logs can only be applied to a mysql-compatible database, but got %s", sinkURI.Scheme)
+	}
+	return nil
+}
+
+// run runs the `redo apply` command.
+func (o *applyOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+
+	ap := newRedoApplier(&applier.RedoApplierConfig{
+		Storage: o.storage,
+		SinkURI: o.sinkURI,
+	})
+	if err := ap.Apply(ctx); err != nil {
+		return err
+	}
+	cmd.Println("Apply redo log successfully")
+	return nil
+}
+
+// newCmdApply creates the `redo apply` command.
+func newCmdApply(opt *options) *cobra.Command {
+	o := &applyOptions{options: opt}
+
+	command := &cobra.Command{
+		Use:   "apply",
+		Short: "Apply redo logs in target sink",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete())
+			util.CheckErr(o.run(cmd))
+		},
+	}
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	"github.com/pingcap/ticdc/pkg/applier"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// runMeta runs the `redo meta` command.
+func (o *options) runMeta(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+
+	ap := applier.NewRedoApplier(&applier.RedoApplierConfig{Storage: o.storage})
+	checkpointTs, resolvedTs, err := ap.ReadMeta(ctx)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("checkpoint-ts:%d, resolved-ts:%d\n", checkpointTs, resolvedTs)
+	return nil
+}
+
+// newCmdMeta creates the `redo meta` command.
+func newCmdMeta(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "meta",
+		Short: "Read the meta of the redo logs",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.runMeta(cmd))
+		},
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/ticdc/pkg/applier"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/redo"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	predo "github.com/pingcap/tiflow/pkg/redo"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedoMeta(t *testing.T) {
+	ctx := context.Background()
+	cmdcontext.SetDefaultContext(ctx)
+	uri := fmt.Sprintf("file://%s", t.TempDir())
+	extStorage, err := redo.NewExternalStorage(ctx, uri)
+	require.NoError(t, err)
+	defer extStorage.Close()
+
+	// the metas written by different writers are merged, every writer records the
+	// global redo resolvedTs known by it, so the maximum one is reported.
+	changefeedID := common.NewChangefeedID4Test("test", "redo-meta")
+	for i, meta := range []*redo.LogMeta{
+		{CheckpointTs: 100, ResolvedTs: 150},
+		{CheckpointTs: 120, ResolvedTs: 130},
+	} {
+		data, err := meta.Marshal()
+		require.NoError(t, err)
+		name := redo.GetMetaFileName(fmt.Sprintf("w%d", i), changefeedID, "uuid")
+		require.NoError(t, extStorage.WriteFile(ctx, name, data))
+	}
+
+	o := &options{storage: uri}
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	require.NoError(t, o.runMeta(cmd))
+	require.Equal(t, "checkpoint-ts:100, resolved-ts:150\n", out.String())
+
+	// no meta is found in an empty storage.
+	o = &options{storage: fmt.Sprintf("file://%s", t.TempDir())}
+	require.Error(t, o.runMeta(cmd))
+}
+
+func TestRedoApplyComplete(t *testing.T) {
+	o := &applyOptions{options: &options{}}
+	for _, uri := range []string{"mysql://root@127.0.0.1:3306/", "tidb://root@127.0.0.1:4000/"} {
+		o.sinkURI = uri
+		require.NoError(t, o.complete())
+	}
+	o.sinkURI = "kafka://127.0.0.1:9092/topic"
+	require.Error(t, o.complete())
+}
+
+func TestRedoApply(t *testing.T) {
+	ctx := context.Background()
+	cmdcontext.SetDefaultContext(ctx)
+	uri := fmt.Sprintf("file://%s", t.TempDir())
+	extStorage, err := redo.NewExternalStorage(ctx, uri)
+	require.NoError(t, err)
+	defer extStorage.Close()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32))")
+	require.NotNil(t, job)
+
+	changefeedID := common.NewChangefeedID4Test("test", "redo-apply")
+	rowWriter := redo.NewFileWriter("w", changefeedID, predo.RedoRowLogFileType, extStorage, 1, "")
+	defer rowWriter.Close()
+	dml1 := helper.DML2Event("test", "t", "insert into t values (1, 'a')")
+	dml1.CommitTs = 110
+	// the event after the resolvedTs is not applied.
+	dml2 := helper.DML2Event("test", "t", "insert into t values (2, 'b')")
+	dml2.CommitTs = 200
+	require.NoError(t, rowWriter.WriteLogs(ctx, []*redo.RedoLog{
+		redo.NewRedoLogFromDMLEvent(dml1),
+		redo.NewRedoLogFromDMLEvent(dml2),
+	}))
+	meta := &redo.LogMeta{CheckpointTs: 100, ResolvedTs: 150}
+	data, err := meta.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, extStorage.WriteFile(ctx, redo.GetMetaFileName("w", changefeedID, "uuid"), data))
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	originNewRedoApplier := newRedoApplier
+	newRedoApplier = func(cfg *applier.RedoApplierConfig) *applier.RedoApplier {
+		return applier.NewRedoApplierForTest(cfg, db)
+	}
+	defer func() { newRedoApplier = originNewRedoApplier }()
+
+	// the events are written in safe mode.
+	mock.ExpectBegin()
+	mock.ExpectExec("REPLACE INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "a").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	o := &applyOptions{
+		options: &options{storage: uri},
+		sinkURI: "mysql://root@127.0.0.1:3306/",
+	}
+	require.NoError(t, o.complete())
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	require.NoError(t, o.run(cmd))
+	require.Equal(t, "Apply redo log successfully\n", out.String())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -93,6 +93,9 @@ func newConsumer(ctx context.Context, o *option) *consumer {
 		// Whether we store offsets automatically.
 		"enable.auto.offset.store": false,
 		"enable.auto.commit":       false,
+		// Only read the messages of committed transactions,
+		// it's required by the kafka sink with the table level transaction atomicity.
+		"isolation.level": "read_committed",
 	}
 	if len(o.ca) != 0 {
 		_ = configMap.SetKey("security.protocol", "SSL")
@@ -123,6 +126,7 @@ func (c *consumer) Consume(ctx context.Context) {
 		if err := c.client.Close(); err != nil {
 			log.Panic("close kafka consumer failed", zap.Error(err))
 		}
+		c.writer.Close()
 	}()
 	for {
 		select {
@@ -16,51 +16,48 @@ package main
 import (
 	"context"
 	"database/sql"
-	"errors"
+	"fmt"
 	"math"
+	"net/url"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/eventrouter"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
-	"github.com/pingcap/ticdc/pkg/spanz"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/codec/canal"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/sink/codec/open"
+	"github.com/pingcap/ticdc/pkg/sink/codec/simple"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
-	"github.com/pingcap/tiflow/cdc/sink/ddlsink"
-	ddlsinkfactory "github.com/pingcap/tiflow/cdc/sink/ddlsink/factory"
-	eventsinkfactory "github.com/pingcap/tiflow/cdc/sink/dmlsink/factory"
-	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher"
-	"github.com/pingcap/tiflow/cdc/sink/tablesink"
-	"github.com/pingcap/tiflow/pkg/config"
-	"github.com/pingcap/tiflow/pkg/sink/codec"
-	"github.com/pingcap/tiflow/pkg/sink/codec/avro"
-	"github.com/pingcap/tiflow/pkg/sink/codec/canal"
-	"github.com/pingcap/tiflow/pkg/sink/codec/open"
-	"github.com/pingcap/tiflow/pkg/sink/codec/simple"
 	"go.uber.org/zap"
 )
 
+// defaultMaxBatchEvents is the max number of dml events flushed to the downstream in one batch.
+const defaultMaxBatchEvents = 256
+
 // NewDecoder will create a new event decoder
-func NewDecoder(ctx context.Context, option *option, upstreamTiDB *sql.DB) (codec.RowEventDecoder, error) {
+func NewDecoder(ctx context.Context, option *option, upstreamTiDB *sql.DB) (common.RowEventDecoder, error) {
 	var (
-		decoder codec.RowEventDecoder
+		decoder common.RowEventDecoder
 		err     error
 	)
 	switch option.protocol {
 	case config.ProtocolOpen, config.ProtocolDefault:
-		decoder, err = open.NewBatchDecoder(ctx, option.codecConfig, upstreamTiDB)
+		decoder = open.NewBatchDecoder(option.codecConfig)
 	case config.ProtocolCanalJSON:
-		decoder, err = canal.NewBatchDecoder(ctx, option.codecConfig, upstreamTiDB)
-	case config.ProtocolAvro:
-		schemaM, err := avro.NewConfluentSchemaManager(ctx, option.schemaRegistryURI, nil)
-		if err != nil {
-			return decoder, cerror.Trace(err)
-		}
-		decoder = avro.NewDecoder(option.codecConfig, schemaM, option.topic, upstreamTiDB)
+		decoder, err = canal.NewBatchDecoder(option.codecConfig, nil)
 	case config.ProtocolSimple:
 		decoder, err = simple.NewDecoder(ctx, option.codecConfig, upstreamTiDB)
 	default:
-		log.Panic("Protocol not supported", zap.Any("Protocol", option.protocol))
+		log.Panic("Protocol not supported yet", zap.Any("Protocol", option.protocol))
 	}
 	if err != nil {
 		return nil, cerror.Trace(err)
@@ -73,17 +70,22 @@ type partitionProgress struct {
 	watermark       uint64
 	watermarkOffset kafka.Offset
 
-	tableSinkMap map[model.TableID]tablesink.TableSink
-	eventGroups  map[model.TableID]*eventsGroup
-	decoder      codec.RowEventDecoder
+	eventGroups map[int64]*eventsGroup
+	// resolvedEvents are the events whose commitTs is not greater than the partition watermark,
+	// they are flushed to the downstream once the watermark of all partitions reaches them.
+	resolvedEvents []*commonEvent.DMLEvent
+	decoder        common.RowEventDecoder
+	writer         *mysql.MysqlWriter
 }
 
-func newPartitionProgress(partition int32, decoder codec.RowEventDecoder) *partitionProgress {
+func newPartitionProgress(
+	partition int32, decoder common.RowEventDecoder, writer *mysql.MysqlWriter,
+) *partitionProgress {
 	return &partitionProgress{
-		partition:    partition,
-		eventGroups:  make(map[model.TableID]*eventsGroup),
-		tableSinkMap: make(map[model.TableID]tablesink.TableSink),
-		decoder:      decoder,
+		partition:   partition,
+		eventGroups: make(map[int64]*eventsGroup),
+		decoder:     decoder,
+		writer:      writer,
 	}
 }
 
@@ -115,42 +117,74 @@ func (p *partitionProgress) loadWatermark() uint64 {
 type writer struct {
 	option *option
 
-	ddlList            []*model.DDLEvent
-	ddlWithMaxCommitTs *model.DDLEvent
-	ddlSink            ddlsink.Sink
+	ddlList            []*commonEvent.DDLEvent
+	ddlWithMaxCommitTs *commonEvent.DDLEvent
+	ddlWriter          *mysql.MysqlWriter
 
-	// sinkFactory is used to create table sink for each table.
-	sinkFactory *eventsinkfactory.SinkFactory
-	progresses  []*partitionProgress
+	progresses []*partitionProgress
+	db         *sql.DB
 
-	eventRouter *dispatcher.EventRouter
+	eventRouter      *eventrouter.EventRouter
+	tableIDGenerator *fakeTableIDGenerator
 }
 
 func newWriter(ctx context.Context, o *option) *writer {
 	w := &writer{
 		option:     o,
 		progresses: make([]*partitionProgress, o.partitionNum),
+		tableIDGenerator: &fakeTableIDGenerator{
+			tableIDs: make(map[string]int64),
+		},
 	}
 	var (
-		db  *sql.DB
-		err error
+		upstreamTiDB *sql.DB
+		err          error
 	)
 	if o.upstreamTiDBDSN != "" {
-		db, err = openDB(ctx, o.upstreamTiDBDSN)
+		upstreamTiDB, err = openDB(ctx, o.upstreamTiDBDSN)
 		if err != nil {
 			log.Panic("cannot open the upstream TiDB, handle key only enabled",
 				zap.String("dsn", o.upstreamTiDBDSN))
 		}
 	}
+
+	serverCfg := config.GetGlobalServerConfig().Clone()
+	serverCfg.TZ = o.timezone
+	config.StoreGlobalServerConfig(serverCfg)
+
+	changefeedID := commonType.NewChangeFeedIDWithName("kafka-consumer")
+	sinkURI, err := url.Parse(o.downstreamURI)
+	if err != nil {
+		log.Panic("invalid downstream-uri", zap.String("downstreamURI", o.downstreamURI), zap.Error(err))
+	}
+	cfg, db, err := mysql.NewMysqlConfigAndDB(ctx, changefeedID, sinkURI, &config.ChangefeedConfig{
+		SinkURI:    o.downstreamURI,
+		SinkConfig: o.replicaConfig.Sink,
+	})
+	if err != nil {
+		log.Panic("cannot connect to the downstream", zap.Error(err))
+	}
+	// The messages may be consumed again after the consumer restarts,
+	// so the events must be written in safe mode.
+	cfg.SafeMode = true
+	cfg.SkipDDLTs = true
+	w.db = db
+	newMysqlWriter := func() *mysql.MysqlWriter {
+		statistics := metrics.NewStatistics(changefeedID, "KafkaConsumer")
+		return mysql.NewMysqlWriter(ctx, db, cfg, changefeedID, statistics, mysql.ShouldFormatVectorType(db, cfg))
+	}
+	w.ddlWriter = newMysqlWriter()
+
 	for i := 0; i < int(o.partitionNum); i++ {
-		decoder, err := NewDecoder(ctx, o, db)
+		decoder, err := NewDecoder(ctx, o, upstreamTiDB)
 		if err != nil {
 			log.Panic("cannot create the decoder", zap.Error(err))
 		}
-		w.progresses[i] = newPartitionProgress(int32(i), decoder)
+		// each partition is flushed concurrently, so it has its own writer.
+		w.progresses[i] = newPartitionProgress(int32(i), decoder, newMysqlWriter())
 	}
 
-	eventRouter, err := dispatcher.NewEventRouter(o.replicaConfig, o.protocol, o.topic, "kafka")
+	eventRouter, err := eventrouter.NewEventRouter(o.replicaConfig.Sink, o.protocol, o.topic, "kafka")
 	if err != nil {
 		log.Panic("initialize the event router failed",
 			zap.Any("protocol", o.protocol), zap.Any("topic", o.topic),
@@ -159,41 +193,28 @@ func newWriter(ctx context.Context, o *option) *writer {
 	w.eventRouter = eventRouter
 	log.Info("event router created", zap.Any("protocol", o.protocol),
 		zap.Any("topic", o.topic), zap.Any("dispatcherRules", o.replicaConfig.Sink.DispatchRules))
+	return w
+}
 
-	config.GetGlobalServerConfig().TZ = o.timezone
-	errChan := make(chan error, 1)
-	changefeed := model.DefaultChangeFeedID("kafka-consumer")
-	f, err := eventsinkfactory.New(ctx, changefeed, o.downstreamURI, o.replicaConfig, errChan, nil)
-	if err != nil {
-		log.Panic("cannot create the event sink factory", zap.Error(err))
+// Close closes all writers and the downstream connection.
+func (w *writer) Close() {
+	for _, p := range w.progresses {
+		p.writer.Close()
 	}
-	w.sinkFactory = f
-
-	go func() {
-		err := <-errChan
-		if !errors.Is(cerror.Cause(err), context.Canceled) {
-			log.Error("error on running consumer", zap.Error(err))
-		} else {
-			log.Info("consumer exited")
-		}
-	}()
-
-	ddlSink, err := ddlsinkfactory.New(ctx, changefeed, o.downstreamURI, o.replicaConfig)
-	if err != nil {
-		log.Panic("cannot create the ddl sink factory", zap.Error(err))
+	w.ddlWriter.Close()
+	if err := w.db.Close(); err != nil {
+		log.Warn("close the downstream db failed", zap.Error(err))
 	}
-	w.ddlSink = ddlSink
-	return w
 }
 
 // append DDL wait to be handled, only consider the constraint among DDLs.
 // for DDL a / b received in the order, a.CommitTs < b.CommitTs should be true.
-func (w *writer) appendDDL(ddl *model.DDLEvent, offset kafka.Offset) {
+func (w *writer) appendDDL(ddl *commonEvent.DDLEvent, offset kafka.Offset) {
 	// DDL CommitTs fallback, just crash it to indicate the bug.
-	if w.ddlWithMaxCommitTs != nil && ddl.CommitTs < w.ddlWithMaxCommitTs.CommitTs {
+	if w.ddlWithMaxCommitTs != nil && ddl.FinishedTs < w.ddlWithMaxCommitTs.FinishedTs {
 		log.Warn("DDL CommitTs < maxCommitTsDDL.CommitTs",
-			zap.Uint64("commitTs", ddl.CommitTs),
-			zap.Uint64("maxCommitTs", w.ddlWithMaxCommitTs.CommitTs),
+			zap.Uint64("commitTs", ddl.FinishedTs),
+			zap.Uint64("maxCommitTs", w.ddlWithMaxCommitTs.FinishedTs),
 			zap.String("DDL", ddl.Query))
 		return
 	}
@@ -203,16 +224,16 @@ func (w *writer) appendDDL(ddl *model.DDLEvent, offset kafka.Offset) {
 	// the current DDL and the DDL with max CommitTs.
 	if ddl == w.ddlWithMaxCommitTs {
 		log.Warn("ignore redundant DDL, the DDL is equal to ddlWithMaxCommitTs",
-			zap.Uint64("commitTs", ddl.CommitTs), zap.String("DDL", ddl.Query))
+			zap.Uint64("commitTs", ddl.FinishedTs), zap.String("DDL", ddl.Query))
 		return
 	}
 
 	w.ddlList = append(w.ddlList, ddl)
 	w.ddlWithMaxCommitTs = ddl
-	log.Info("DDL message received", zap.Any("offset", offset), zap.Uint64("commitTs", ddl.CommitTs), zap.String("DDL", ddl.Query))
+	log.Info("DDL message received", zap.Any("offset", offset), zap.Uint64("commitTs", ddl.FinishedTs), zap.String("DDL", ddl.Query))
 }
 
-func (w *writer) getFrontDDL() *model.DDLEvent {
+func (w *writer) getFrontDDL() *commonEvent.DDLEvent {
 	if len(w.ddlList) > 0 {
 		return w.ddlList[0]
 	}
@@ -252,24 +273,24 @@ func (w *writer) forEachPartition(fn func(p *partitionProgress)) {
 // Write will synchronously write data downstream
 func (w *writer) Write(ctx context.Context, messageType model.MessageType) bool {
 	watermark := w.getMinWatermark()
-	var todoDDL *model.DDLEvent
+	var todoDDL *commonEvent.DDLEvent
 	for {
 		todoDDL = w.getFrontDDL()
 		// watermark is the min value for all partitions,
 		// the DDL only executed by the first partition, other partitions may be slow
 		// so that the watermark can be smaller than the DDL's commitTs,
 		// which means some DML events may not be consumed yet, so cannot execute the DDL right now.
-		if todoDDL == nil || todoDDL.CommitTs > watermark {
+		if todoDDL == nil || todoDDL.FinishedTs > watermark {
 			break
 		}
 		// flush DMLs
 		w.forEachPartition(func(sink *partitionProgress) {
-			syncFlushRowChangedEvents(ctx, sink, todoDDL.CommitTs)
+			syncFlushRowChangedEvents(ctx, sink, todoDDL.FinishedTs)
 		})
 		// DDL can be executed, do it first.
-		if err := w.ddlSink.WriteDDLEvent(ctx, todoDDL); err != nil {
+		if err := w.ddlWriter.FlushDDLEvent(todoDDL); err != nil {
 			log.Panic("write DDL event failed", zap.Error(err),
-				zap.String("DDL", todoDDL.Query), zap.Uint64("commitTs", todoDDL.CommitTs))
+				zap.String("DDL", todoDDL.Query), zap.Uint64("commitTs", todoDDL.FinishedTs))
 		}
 		w.popDDL()
 	}
@@ -284,7 +305,7 @@ func (w *writer) Write(ctx context.Context, messageType model.MessageType) bool
 	if messageType == model.MessageTypeDDL && todoDDL != nil {
 		log.Info("DDL event will be flushed in the future",
 			zap.Uint64("watermark", watermark),
-			zap.Uint64("CommitTs", todoDDL.CommitTs),
+			zap.Uint64("CommitTs", todoDDL.FinishedTs),
 			zap.String("Query", todoDDL.Query))
 		return false
 	}
@@ -346,9 +367,10 @@ func (w *writer) WriteMessage(ctx context.Context, message *kafka.Message) bool
 			if dec, ok := progress.decoder.(*simple.Decoder); ok {
 				cachedEvents := dec.GetCachedEvents()
 				for _, row := range cachedEvents {
+					w.assignTableID(row)
 					w.checkPartition(row, partition, message.TopicPartition.Offset)
 					log.Info("simple protocol cached event resolved, append to the group",
-						zap.Int64("tableID", row.GetTableID()), zap.Uint64("commitTs", row.CommitTs),
+						zap.Int64("tableID", row.PhysicalTableID), zap.Uint64("commitTs", row.CommitTs),
 						zap.Int32("partition", partition), zap.Any("offset", offset))
 					w.appendRow2Group(row, progress, offset)
 				}
@@ -364,7 +386,7 @@ func (w *writer) WriteMessage(ctx context.Context, message *kafka.Message) bool
 			}
 			needFlush = true
 		case model.MessageTypeRow:
-			row, err := progress.decoder.NextRowChangedEvent()
+			row, err := progress.decoder.NextDMLEvent()
 			if err != nil {
 				log.Panic("decode message value failed",
 					zap.Int32("partition", partition), zap.Any("offset", offset),
@@ -376,6 +398,7 @@ func (w *writer) WriteMessage(ctx context.Context, message *kafka.Message) bool
 			if w.option.protocol == config.ProtocolSimple && row == nil {
 				continue
 			}
+			w.assignTableID(row)
 			w.checkPartition(row, partition, message.TopicPartition.Offset)
 			w.appendRow2Group(row, progress, offset)
 		case model.MessageTypeResolved:
@@ -409,48 +432,54 @@ func (w *writer) WriteMessage(ctx context.Context, message *kafka.Message) bool
 }
 
 func (w *writer) resolveRowChangedEvents(progress *partitionProgress, newWatermark uint64) {
-	for tableID, group := range progress.eventGroups {
+	for _, group := range progress.eventGroups {
 		events := group.Resolve(newWatermark)
-		if len(events) == 0 {
-			continue
-		}
-		tableSink, ok := progress.tableSinkMap[tableID]
-		if !ok {
-			tableSink = w.sinkFactory.CreateTableSinkForConsumer(
-				model.DefaultChangeFeedID("kafka-consumer"),
-				spanz.TableIDToComparableSpan(tableID),
-				events[0].CommitTs,
-			)
-			progress.tableSinkMap[tableID] = tableSink
-		}
-		tableSink.AppendRowChangedEvents(events...)
+		progress.resolvedEvents = append(progress.resolvedEvents, events...)
 	}
 }
 
-func (w *writer) checkPartition(row *model.RowChangedEvent, partition int32, offset kafka.Offset) {
-	target, _, err := w.eventRouter.GetPartitionForRowChange(row, w.option.partitionNum)
-	if err != nil {
-		log.Panic("cannot calculate partition for the row changed event",
-			zap.Int32("partition", partition), zap.Any("offset", offset),
-			zap.Int32("partitionNum", w.option.partitionNum), zap.Int64("tableID", row.GetTableID()),
-			zap.Error(err), zap.Any("event", row))
-	}
-	if partition != target {
-		log.Panic("RowChangedEvent dispatched to wrong partition",
-			zap.Int32("partition", partition), zap.Int32("expected", target),
-			zap.Int32("partitionNum", w.option.partitionNum), zap.Any("offset", offset),
-			zap.Int64("tableID", row.GetTableID()), zap.Any("row", row),
-		)
+// assignTableID sets a fake table id for the event if the protocol does not carry it,
+// the events of one table are grouped by the table id.
+func (w *writer) assignTableID(row *commonEvent.DMLEvent) {
+	if row.PhysicalTableID != 0 {
+		return
 	}
+	row.PhysicalTableID = w.tableIDGenerator.generateFakeTableID(
+		row.TableInfo.GetSchemaName(), row.TableInfo.GetTableName(), 0)
 }
 
-func (w *writer) appendRow2Group(row *model.RowChangedEvent, progress *partitionProgress, offset kafka.Offset) {
+func (w *writer) checkPartition(row *commonEvent.DMLEvent, partition int32, offset kafka.Offset) {
+	generator := w.eventRouter.GetPartitionGenerator(row.TableInfo)
+	for {
+		change, ok := row.GetNextRow()
+		if !ok {
+			break
+		}
+		target, _, err := generator.GeneratePartitionIndexAndKey(&change, w.option.partitionNum, row.TableInfo, row.CommitTs)
+		if err != nil {
+			log.Panic("cannot calculate partition for the row changed event",
+				zap.Int32("partition", partition), zap.Any("offset", offset),
+				zap.Int32("partitionNum", w.option.partitionNum), zap.Int64("tableID", row.PhysicalTableID),
+				zap.Error(err), zap.Any("event", row))
+		}
+		if partition != target {
+			log.Panic("RowChangedEvent dispatched to wrong partition",
+				zap.Int32("partition", partition), zap.Int32("expected", target),
+				zap.Int32("partitionNum", w.option.partitionNum), zap.Any("offset", offset),
+				zap.Int64("tableID", row.PhysicalTableID), zap.Any("row", row),
+			)
+		}
+	}
+	row.Rewind()
+}
+
+func (w *writer) appendRow2Group(row *commonEvent.DMLEvent, progress *partitionProgress, offset kafka.Offset) {
 	// if the kafka cluster is normal, this should not hit.
 	// else if the cluster is abnormal, the consumer may consume old message, then cause the watermark fallback.
 	watermark := progress.loadWatermark()
 	partition := progress.partition
 
-	tableID := row.GetTableID()
+	tableID := row.PhysicalTableID
 	group := progress.eventGroups[tableID]
 	if group == nil {
 		group = NewEventsGroup(partition, tableID)
@@ -462,7 +491,6 @@ func (w *writer) appendRow2Group(row *model.RowChangedEvent, progress *partition
 			zap.Uint64("commitTs", row.CommitTs), zap.Any("offset", offset),
 			zap.Uint64("watermark", watermark), zap.Any("watermarkOffset", progress.watermarkOffset),
 			zap.String("schema", row.TableInfo.GetSchemaName()), zap.String("table", row.TableInfo.GetTableName()),
-			zap.Any("columns", row.Columns), zap.Any("preColumns", row.PreColumns),
 			zap.String("protocol", w.option.protocol.String()), zap.Bool("IsPartition", row.TableInfo.TableName.IsPartition))
 		return
 	}
@@ -474,8 +502,7 @@ func (w *writer) appendRow2Group(row *model.RowChangedEvent, progress *partition
 	case config.ProtocolSimple, config.ProtocolOpen, config.ProtocolCanalJSON:
 		// simple protocol set the table id for all row message, it can be known which table the row message belongs to,
 		// also consider the table partition.
-		// open protocol set the partition table id if the table is partitioned.
-		// for normal table, the table id is generated by the fake table id generator by using schema and table name.
+		// for other protocols, the table id is generated by the fake table id generator by using schema and table name.
 		// so one event group for one normal table or one table partition, replayed messages can be ignored.
 		log.Warn("RowChangedEvent fallback row, since less than the group high watermark, ignore it",
 			zap.Int64("tableID", tableID), zap.Int32("partition", partition),
@@ -483,7 +510,6 @@ func (w *writer) appendRow2Group(row *model.RowChangedEvent, progress *partition
 			zap.Uint64("highWatermark", group.highWatermark),
 			zap.Any("partitionWatermark", watermark), zap.Any("watermarkOffset", progress.watermarkOffset),
 			zap.String("schema", row.TableInfo.GetSchemaName()), zap.String("table", row.TableInfo.GetTableName()),
-			zap.Any("columns", row.Columns), zap.Any("preColumns", row.PreColumns),
 			zap.String("protocol", w.option.protocol.String()), zap.Bool("IsPartition", row.TableInfo.TableName.IsPartition))
 		return
 	default:
@@ -494,33 +520,72 @@ func (w *writer) appendRow2Group(row *model.RowChangedEvent, progress *partition
 		zap.Uint64("highWatermark", group.highWatermark),
 		zap.Any("partitionWatermark", watermark), zap.Any("watermarkOffset", progress.watermarkOffset),
 		zap.String("schema", row.TableInfo.GetSchemaName()), zap.String("table", row.TableInfo.GetTableName()),
-		zap.Any("columns", row.Columns), zap.Any("preColumns", row.PreColumns),
 		zap.String("protocol", w.option.protocol.String()))
 	group.Append(row, offset)
 }
 
+// syncFlushRowChangedEvents writes the resolved events whose commitTs is not greater than
+// the watermark to the downstream, the events of one transaction are never split into batches.
 func syncFlushRowChangedEvents(ctx context.Context, progress *partitionProgress, watermark uint64) {
-	resolvedTs := model.NewResolvedTs(watermark)
-	for {
-		select {
-		case <-ctx.Done():
-			log.Warn("sync flush row changed event canceled", zap.Error(ctx.Err()))
-			return
-		default:
-		}
-		flushedResolvedTs := true
-		for _, tableSink := range progress.tableSinkMap {
-			if err := tableSink.UpdateResolvedTs(resolvedTs); err != nil {
-				log.Panic("Failed to update resolved ts", zap.Error(err))
-			}
-			if tableSink.GetCheckpointTs().Less(resolvedTs) {
-				flushedResolvedTs = false
-			}
+	if ctx.Err() != nil {
+		log.Warn("sync flush row changed event canceled", zap.Error(ctx.Err()))
+		return
+	}
+	var (
+		events   []*commonEvent.DMLEvent
+		remained = progress.resolvedEvents[:0]
+	)
+	for _, event := range progress.resolvedEvents {
+		if event.CommitTs <= watermark {
+			events = append(events, event)
+		} else {
+			remained = append(remained, event)
 		}
-		if flushedResolvedTs {
-			return
+	}
+	progress.resolvedEvents = remained
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CommitTs < events[j].CommitTs
+	})
+
+	start := 0
+	for i := range events {
+		if i-start >= defaultMaxBatchEvents && events[i-1].CommitTs != events[i].CommitTs {
+			flushDMLEvents(progress, events[start:i])
+			start = i
 		}
 	}
+	if start < len(events) {
+		flushDMLEvents(progress, events[start:])
+	}
+}
+
+func flushDMLEvents(progress *partitionProgress, events []*commonEvent.DMLEvent) {
+	if err := progress.writer.Flush(events); err != nil {
+		log.Panic("write DML events failed", zap.Int32("partition", progress.partition),
+			zap.Int("count", len(events)), zap.Uint64("firstCommitTs", events[0].CommitTs),
+			zap.Uint64("lastCommitTs", events[len(events)-1].CommitTs), zap.Error(err))
+	}
+}
+
+type fakeTableIDGenerator struct {
+	tableIDs       map[string]int64
+	currentTableID int64
+	mu             sync.Mutex
+}
+
+func (g *fakeTableIDGenerator) generateFakeTableID(schema, table string, partition int64) int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	key := commonType.QuoteSchema(schema, table)
+	if partition != 0 {
+		key = fmt.Sprintf("%s.`%d`", key, partition)
+	}
+	if tableID, ok := g.tableIDs[key]; ok {
+		return tableID
+	}
+	g.currentTableID++
+	g.tableIDs[key] = g.currentTableID
+	return g.currentTableID
 }
 
 func openDB(ctx context.Context, dsn string) (*sql.DB, error) {
@@ -15,9 +15,11 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"math"
 	"net/http"
 	"net/url"
 	"os"
@@ -30,24 +32,23 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
-	"github.com/pingcap/ticdc/pkg/spanz"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/logger"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/ticdc/pkg/sink/codec/canal"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/sink/codec/csv"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
+	sinkutil "github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tiflow/cdc/model"
-	"github.com/pingcap/tiflow/cdc/sink/ddlsink"
-	ddlfactory "github.com/pingcap/tiflow/cdc/sink/ddlsink/factory"
-	dmlfactory "github.com/pingcap/tiflow/cdc/sink/dmlsink/factory"
-	"github.com/pingcap/tiflow/cdc/sink/tablesink"
-	sinkutil "github.com/pingcap/tiflow/cdc/sink/util"
 	"github.com/pingcap/tiflow/pkg/cmd/util"
-	"github.com/pingcap/tiflow/pkg/config"
-	"github.com/pingcap/tiflow/pkg/logutil"
-	"github.com/pingcap/tiflow/pkg/quotes"
 	psink "github.com/pingcap/tiflow/pkg/sink"
-	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
-	"github.com/pingcap/tiflow/pkg/sink/codec"
-	"github.com/pingcap/tiflow/pkg/sink/codec/canal"
-	"github.com/pingcap/tiflow/pkg/sink/codec/common"
-	"github.com/pingcap/tiflow/pkg/sink/codec/csv"
 	putil "github.com/pingcap/tiflow/pkg/util"
 	"github.com/pingcap/tiflow/pkg/version"
 	"go.uber.org/zap"
@@ -68,8 +69,9 @@ var (
 
 const (
 	defaultChangefeedName         = "storage-consumer"
-	defaultFlushWaitDuration      = 200 * time.Millisecond
 	fakePartitionNumForSchemaFile = -1
+	// defaultMaxBatchEvents is the max number of dml events flushed to the downstream in one batch.
+	defaultMaxBatchEvents = 256
 )
 
 func init() {
@@ -86,7 +88,7 @@ func init() {
 	flag.StringVar(&timezone, "tz", "System", "Specify time zone of storage consumer")
 	flag.Parse()
 
-	err := logutil.InitLogger(&logutil.Config{
+	err := logger.InitLogger(&logger.Config{
 		Level: logLevel,
 		File:  logFile,
 	})
@@ -115,8 +117,8 @@ type fileIndexRange struct {
 }
 
 type consumer struct {
-	sinkFactory     *dmlfactory.SinkFactory
-	ddlSink         ddlsink.Sink
+	writer          *mysql.MysqlWriter
+	db              *sql.DB
 	replicationCfg  *config.ReplicaConfig
 	codecCfg        *common.Config
 	externalStorage storage.ExternalStorage
@@ -124,13 +126,10 @@ type consumer struct {
 	// tableDMLIdxMap maintains a map of <dmlPathKey, max file index>
 	tableDMLIdxMap map[cloudstorage.DmlPathKey]uint64
 	// tableTsMap maintains a map of <TableID, max commit ts>
-	tableTsMap map[model.TableID]model.ResolvedTs
+	tableTsMap map[int64]uint64
 	// tableDefMap maintains a map of <`schema`.`table`, tableDef slice sorted by TableVersion>
-	tableDefMap map[string]map[uint64]*cloudstorage.TableDefinition
-	// tableSinkMap maintains a map of <TableID, TableSink>
-	tableSinkMap     map[model.TableID]tablesink.TableSink
+	tableDefMap      map[string]map[uint64]*cloudstorage.TableDefinition
 	tableIDGenerator *fakeTableIDGenerator
-	errCh            chan error
 }
 
 func newConsumer(ctx context.Context) (*consumer, error) {
@@ -171,13 +170,20 @@ func newConsumer(ctx context.Context) (*consumer, error) {
 		return nil, err
 	}
 
-	codecConfig := common.NewConfig(protocol)
-	err = codecConfig.Apply(upstreamURI, replicaConfig)
+	changefeedID := commonType.NewChangeFeedIDWithName(defaultChangefeedName)
+	// the last param maxMsgBytes is mainly to limit the size of a single message for
+	// batch protocols in mq scenario. In cloud storage sink, we just set it to max int.
+	codecConfig, err := sinkutil.GetEncoderConfig(changefeedID, upstreamURI, protocol, replicaConfig.Sink, math.MaxInt)
 	if err != nil {
 		return nil, err
 	}
+	if protocol == config.ProtocolCanalJSON {
+		// Always enable tidb extension for canal-json protocol
+		// because we need to get the commit ts from the extension field.
+		codecConfig.EnableTiDBExtension = true
+	}
 
-	extension := sinkutil.GetFileExtension(protocol)
+	extension := helper.GetFileExtension(protocol)
 
 	storage, err := putil.GetExternalStorageFromURI(ctx, upstreamURIStr)
 	if err != nil {
@@ -185,46 +191,60 @@ func newConsumer(ctx context.Context) (*consumer, error) {
 		return nil, err
 	}
 
-	errCh := make(chan error, 1)
-	stdCtx := ctx
-	sinkFactory, err := dmlfactory.New(
-		stdCtx,
-		model.DefaultChangeFeedID(defaultChangefeedName),
-		downstreamURIStr,
-		replicaConfig,
-		errCh,
-		nil,
-	)
+	writer, db, err := newMysqlWriter(ctx, changefeedID, replicaConfig)
 	if err != nil {
-		log.Error("failed to create event sink factory", zap.Error(err))
-		return nil, err
-	}
-
-	ddlSink, err := ddlfactory.New(ctx, model.DefaultChangeFeedID(defaultChangefeedName),
-		downstreamURIStr, replicaConfig)
-	if err != nil {
-		log.Error("failed to create ddl sink", zap.Error(err))
+		log.Error("failed to create mysql writer", zap.Error(err))
+		storage.Close()
 		return nil, err
 	}
 
 	return &consumer{
-		sinkFactory:     sinkFactory,
-		ddlSink:         ddlSink,
+		writer:          writer,
+		db:              db,
 		replicationCfg:  replicaConfig,
 		codecCfg:        codecConfig,
 		externalStorage: storage,
 		fileExtension:   extension,
-		errCh:           errCh,
 		tableDMLIdxMap:  make(map[cloudstorage.DmlPathKey]uint64),
-		tableTsMap:      make(map[model.TableID]model.ResolvedTs),
+		tableTsMap:      make(map[int64]uint64),
 		tableDefMap:     make(map[string]map[uint64]*cloudstorage.TableDefinition),
-		tableSinkMap:    make(map[model.TableID]tablesink.TableSink),
 		tableIDGenerator: &fakeTableIDGenerator{
 			tableIDs: make(map[string]int64),
 		},
 	}, nil
 }
 
+func newMysqlWriter(
+	ctx context.Context, changefeedID commonType.ChangeFeedID, replicaConfig *config.ReplicaConfig,
+) (*mysql.MysqlWriter, *sql.DB, error) {
+	sinkURI, err := url.Parse(downstreamURIStr)
+	if err != nil {
+		return nil, nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	cfConfig := &config.ChangefeedConfig{
+		SinkURI:    downstreamURIStr,
+		SinkConfig: replicaConfig.Sink,
+	}
+	cfg, db, err := mysql.NewMysqlConfigAndDB(ctx, changefeedID, sinkURI, cfConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The files may be consumed again after the consumer restarts, and the update events
+	// are restored as insert events if the old value is not output,
+	// so the events must be written in safe mode.
+	cfg.SafeMode = true
+	cfg.SkipDDLTs = true
+	statistics := metrics.NewStatistics(changefeedID, "StorageConsumer")
+	writer := mysql.NewMysqlWriter(ctx, db, cfg, changefeedID, statistics, mysql.ShouldFormatVectorType(db, cfg))
+	return writer, db, nil
+}
+
+func (c *consumer) close() {
+	c.writer.Close()
+	c.db.Close()
+	c.externalStorage.Close()
+}
+
 // map1 - map2
 func diffDMLMaps(
 	map1, map2 map[cloudstorage.DmlPathKey]uint64,
@@ -287,47 +307,44 @@ func (c *consumer) getNewFiles(
 	return tableDMLMap, err
 }
 
-// emitDMLEvents decodes RowChangedEvents from file content and emit them.
-func (c *consumer) emitDMLEvents(
+// decodeDMLEvents decodes DMLEvents from file content.
+func (c *consumer) decodeDMLEvents(
 	ctx context.Context, tableID int64,
 	tableDetail cloudstorage.TableDefinition,
 	pathKey cloudstorage.DmlPathKey,
 	content []byte,
-) error {
-	var (
-		decoder codec.RowEventDecoder
-		err     error
-	)
+) ([]*commonEvent.DMLEvent, error) {
+	var decoder common.RowEventDecoder
 
 	tableInfo, err := tableDetail.ToTableInfo()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	switch c.codecCfg.Protocol {
 	case config.ProtocolCsv:
 		decoder, err = csv.NewBatchDecoder(ctx, c.codecCfg, tableInfo, content)
 		if err != nil {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 	case config.ProtocolCanalJSON:
-		// Always enable tidb extension for canal-json protocol
-		// because we need to get the commit ts from the extension field.
-		c.codecCfg.EnableTiDBExtension = true
-		decoder, err = canal.NewBatchDecoder(ctx, c.codecCfg, nil)
+		decoder, err = canal.NewBatchDecoder(c.codecCfg, tableInfo)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 		err = decoder.AddKeyValue(nil, content)
 		if err != nil {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 	}
 
 	cnt := 0
-	filteredCnt := 0
+	events := make([]*commonEvent.DMLEvent, 0)
 	for {
 		tp, hasNext, err := decoder.HasNext()
 		if err != nil {
 			log.Error("failed to decode message", zap.Error(err))
-			return err
+			return nil, err
 		}
 		if !hasNext {
 			break
@@ -335,72 +352,51 @@ func (c *consumer) emitDMLEvents(
 		cnt++
 
 		if tp == model.MessageTypeRow {
-			row, err := decoder.NextRowChangedEvent()
+			event, err := decoder.NextDMLEvent()
 			if err != nil {
-				log.Error("failed to get next row changed event", zap.Error(err))
-				return errors.Trace(err)
-			}
-
-			if _, ok := c.tableSinkMap[tableID]; !ok {
-				c.tableSinkMap[tableID] = c.sinkFactory.CreateTableSinkForConsumer(
-					model.DefaultChangeFeedID(defaultChangefeedName),
-					spanz.TableIDToComparableSpan(tableID),
-					row.CommitTs)
+				log.Error("failed to get next dml event", zap.Error(err))
+				return nil, errors.Trace(err)
 			}
 
-			_, ok := c.tableTsMap[tableID]
-			if !ok || row.CommitTs > c.tableTsMap[tableID].Ts {
-				c.tableTsMap[tableID] = model.ResolvedTs{
-					Mode:    model.BatchResolvedMode,
-					Ts:      row.CommitTs,
-					BatchID: 1,
-				}
-			} else if row.CommitTs == c.tableTsMap[tableID].Ts {
-				c.tableTsMap[tableID] = c.tableTsMap[tableID].AdvanceBatch()
-			} else {
-				log.Warn("row changed event commit ts fallback, ignore",
-					zap.Uint64("commitTs", row.CommitTs),
-					zap.Any("tableMaxCommitTs", c.tableTsMap[tableID]),
-					zap.Any("row", row),
+			maxCommitTs, ok := c.tableTsMap[tableID]
+			if ok && event.CommitTs < maxCommitTs {
+				log.Warn("dml event commit ts fallback, ignore",
+					zap.Uint64("commitTs", event.CommitTs),
+					zap.Uint64("tableMaxCommitTs", maxCommitTs),
+					zap.Any("event", event),
 				)
 				continue
 			}
-			row.PhysicalTableID = tableID
-			c.tableSinkMap[tableID].AppendRowChangedEvents(row)
-			filteredCnt++
+			c.tableTsMap[tableID] = event.CommitTs
+			event.PhysicalTableID = tableID
+			events = append(events, event)
 		}
 	}
 	log.Info("decode success", zap.String("schema", pathKey.Schema),
 		zap.String("table", pathKey.Table),
 		zap.Uint64("version", pathKey.TableVersion),
 		zap.Int("decodeRowsCnt", cnt),
-		zap.Int("filteredRowsCnt", filteredCnt))
+		zap.Int("filteredRowsCnt", len(events)))
 
-	return err
+	return events, nil
 }
 
-func (c *consumer) waitTableFlushComplete(ctx context.Context, tableID model.TableID) error {
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case err := <-c.errCh:
-			return err
-		default:
-		}
-
-		resolvedTs := c.tableTsMap[tableID]
-		err := c.tableSinkMap[tableID].UpdateResolvedTs(resolvedTs)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		checkpoint := c.tableSinkMap[tableID].GetCheckpointTs()
-		if checkpoint.Equal(resolvedTs) {
-			c.tableTsMap[tableID] = resolvedTs.AdvanceBatch()
-			return nil
+// flushDMLEvents writes the dml events to the downstream in batches,
+// and the events with the same commitTs are always in the same batch.
+func (c *consumer) flushDMLEvents(events []*commonEvent.DMLEvent) error {
+	start := 0
+	for i := range events {
+		if i-start >= defaultMaxBatchEvents && events[i-1].CommitTs != events[i].CommitTs {
+			if err := c.writer.Flush(events[start:i]); err != nil {
+				return errors.Trace(err)
+			}
+			start = i
 		}
-		time.Sleep(defaultFlushWaitDuration)
 	}
+	if start == len(events) {
+		return nil
+	}
+	return errors.Trace(c.writer.Flush(events[start:]))
 }
 
 func (c *consumer) syncExecDMLEvents(
@@ -417,22 +413,12 @@ func (c *consumer) syncExecDMLEvents(
 	}
 	tableID := c.tableIDGenerator.generateFakeTableID(
 		key.Schema, key.Table, key.PartitionNum)
-	err = c.emitDMLEvents(ctx, tableID, tableDef, key, content)
-	if err != nil {
-		return errors.Trace(err)
-	}
-
-	resolvedTs := c.tableTsMap[tableID]
-	err = c.tableSinkMap[tableID].UpdateResolvedTs(resolvedTs)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	err = c.waitTableFlushComplete(ctx, tableID)
+	events, err := c.decodeDMLEvents(ctx, tableID, tableDef, key, content)
 	if err != nil {
 		return errors.Trace(err)
 	}
 
-	return nil
+	return c.flushDMLEvents(events)
 }
 
 func (c *consumer) parseDMLFilePath(_ context.Context, path string) error {
@@ -573,7 +559,7 @@ func (c *consumer) handleNewFiles(
 			if err != nil {
 				return err
 			}
-			if err := c.ddlSink.WriteDDLEvent(ctx, ddlEvent); err != nil {
+			if err := c.writer.FlushDDLEvent(ddlEvent); err != nil {
 				return errors.Trace(err)
 			}
 			// TODO: need to cleanup tableDefMap in the future.
@@ -598,8 +584,6 @@ func (c *consumer) run(ctx context.Context) error {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case err := <-c.errCh:
-			return err
 		case <-ticker.C:
 		}
 
@@ -625,7 +609,7 @@ type fakeTableIDGenerator struct {
 func (g *fakeTableIDGenerator) generateFakeTableID(schema, table string, partition int64) int64 {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	key := quotes.QuoteSchema(schema, table)
+	key := commonType.QuoteSchema(schema, table)
 	if partition != 0 {
 		key = fmt.Sprintf("%s.`%d`", key, partition)
 	}
@@ -658,7 +642,7 @@ func main() {
 	deferFunc := func() int {
 		stop()
 		if consumer != nil {
-			consumer.sinkFactory.Close()
+			consumer.close()
 		}
 		if err != nil && err != context.Canceled {
 			return 1
@@ -17,7 +17,9 @@ import (
 	"encoding/json"
 	"net/url"
 	"sync"
+	"time"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
@@ -37,16 +39,23 @@ type Changefeed struct {
 	info     *atomic.Pointer[config.ChangeFeedInfo]
 	isMQSink bool
 	isNew    bool // only true when the changfeed is newly created or resumed by overwriteCheckpointTs
+	// groupID is decided by the priority of the changefeed, the priority can not be
+	// changed on the fly, so it's fixed once the changefeed is created.
+	groupID replica.GroupID
 
 	// nodeIDMu protects nodeID
 	nodeIDMu sync.Mutex
 	nodeID   node.ID
 
-	configBytes []byte
+	// configBytes is the json encoded info, it's updated with the info
+	configBytes *atomic.Pointer[[]byte]
 	// it's saved to the backend db
 	lastSavedCheckpointTs *atomic.Uint64
 	// the heartbeatpb.MaintainerStatus is read only
 	status *atomic.Pointer[heartbeatpb.MaintainerStatus]
+	// warning is the latest warning reported by the maintainer, it's kept out of
+	// the info so that updating it never races with the updates of the info.
+	warning *atomic.Pointer[model.RunningError]
 
 	backoff *Backoff
 }
@@ -71,10 +80,11 @@ func NewChangefeed(cfID common.ChangeFeedID,
 	res := &Changefeed{
 		ID:                    cfID,
 		info:                  atomic.NewPointer(info),
-		configBytes:           bytes,
+		configBytes:           atomic.NewPointer(&bytes),
 		lastSavedCheckpointTs: atomic.NewUint64(checkpointTs),
 		isMQSink:              sink.IsMQScheme(uri.Scheme),
 		isNew:                 isNew,
+		groupID:               replica.DefaultGroupID,
 		// Initialize the status
 		status: atomic.NewPointer(
 			&heartbeatpb.MaintainerStatus{
@@ -82,8 +92,12 @@ func NewChangefeed(cfID common.ChangeFeedID,
 				CheckpointTs: checkpointTs,
 				FeedState:    string(info.State),
 			}),
+		warning: atomic.NewPointer[model.RunningError](nil),
 		backoff: NewBackoff(cfID, *info.Config.ChangefeedErrorStuckDuration, checkpointTs),
 	}
+	if info.IsHighPriority() {
+		res.groupID = replica.GenGroupID(replica.GroupHighPriority, 0)
+	}
 	// Must set retrying to true when the changefeed is in warning state.
 	if info.State == model.StateWarning {
 		res.backoff.retrying.Store(true)
@@ -105,6 +119,18 @@ func (c *Changefeed) SetInfo(info *config.ChangeFeedInfo) {
 	c.info.Store(info)
 }
 
+// UpdateInfo replaces the info of the changefeed with the hot-reloaded one,
+// the following add maintainer requests will carry the new config.
+func (c *Changefeed) UpdateInfo(info *config.ChangeFeedInfo) error {
+	bytes, err := json.Marshal(info)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.info.Store(info)
+	c.configBytes.Store(&bytes)
+	return nil
+}
+
 func (c *Changefeed) StartFinished() {
 	c.backoff.StartFinished()
 }
@@ -126,8 +152,23 @@ func (c *Changefeed) GetID() common.ChangeFeedID {
 }
 
 func (c *Changefeed) GetGroupID() replica.GroupID {
-	// currently we only have one scheduler group for changefeed
-	return replica.DefaultGroupID
+	return c.groupID
+}
+
+// HasNodeAffinity returns true if the maintainer prefers to run on the nodes with some specific labels.
+func (c *Changefeed) HasNodeAffinity() bool {
+	return len(c.GetInfo().NodeAffinity) > 0
+}
+
+// MatchNodeLabels returns true if the node with the labels satisfies the node affinity of the changefeed.
+func (c *Changefeed) MatchNodeLabels(labels map[string]string) bool {
+	return c.GetInfo().MatchNodeLabels(labels)
+}
+
+// GetLoad returns the write throughput of the changefeed reported by the maintainer,
+// it's used by the load balance strategy.
+func (c *Changefeed) GetLoad() float64 {
+	return float64(c.GetStatus().GetEventSizePerSecond())
 }
 
 func (c *Changefeed) ShouldRun() bool {
@@ -139,6 +180,7 @@ func (c *Changefeed) UpdateStatus(newStatus *heartbeatpb.MaintainerStatus) (bool
 
 	if newStatus != nil && newStatus.CheckpointTs >= old.CheckpointTs {
 		c.status.Store(newStatus)
+		c.updateWarning(newStatus.Warning)
 		if old.BootstrapDone != newStatus.BootstrapDone {
 			log.Info("Received changefeed status with bootstrapDone",
 				zap.String("changefeed", c.ID.String()),
@@ -157,6 +199,50 @@ func (c *Changefeed) UpdateStatus(newStatus *heartbeatpb.MaintainerStatus) (bool
 	return false, model.StateNormal, nil
 }
 
+// updateWarning records the warning reported by the maintainer.
+// Unlike the running error, the warning doesn't change the state of the changefeed,
+// so the changefeed is not restarted by the backoff.
+func (c *Changefeed) updateWarning(warning *heartbeatpb.RunningError) {
+	old := c.warning.Load()
+	if warning == nil {
+		if old != nil {
+			c.warning.Store(nil)
+		}
+		return
+	}
+	if old != nil && old.Code == warning.Code && old.Message == warning.Message {
+		return
+	}
+	log.Warn("changefeed reports a warning",
+		zap.String("changefeed", c.ID.String()),
+		zap.String("code", warning.Code),
+		zap.String("message", warning.Message))
+	c.warning.Store(&model.RunningError{
+		Time:    time.Now(),
+		Addr:    warning.Node,
+		Code:    warning.Code,
+		Message: warning.Message,
+	})
+}
+
+// GetWarning returns the latest warning reported by the maintainer, nil if there is none.
+func (c *Changefeed) GetWarning() *model.RunningError {
+	return c.warning.Load()
+}
+
+// GetInfoWithWarning returns the info of the changefeed with the latest warning filled,
+// it's used by the API and the returned info must not be modified.
+func (c *Changefeed) GetInfoWithWarning() *config.ChangeFeedInfo {
+	info := c.GetInfo()
+	warning := c.GetWarning()
+	if warning == nil {
+		return info
+	}
+	withWarning := *info
+	withWarning.Warning = warning
+	return &withWarning
+}
+
 func (c *Changefeed) ForceUpdateStatus(newStatus *heartbeatpb.MaintainerStatus) (bool, model.FeedState, *heartbeatpb.RunningError) {
 	c.status.Store(newStatus)
 	return c.backoff.CheckStatus(newStatus)
@@ -226,11 +312,20 @@ func (c *Changefeed) NewAddMaintainerMessage(server node.ID) *messaging.TargetMe
 		&heartbeatpb.AddMaintainerRequest{
 			Id:              c.ID.ToPB(),
 			CheckpointTs:    c.GetStatus().CheckpointTs,
-			Config:          c.configBytes,
+			Config:          *c.configBytes.Load(),
 			IsNewChangefeed: c.isNew,
 		})
 }
 
+func (c *Changefeed) NewUpdateMaintainerConfigMessage(server node.ID) *messaging.TargetMessage {
+	return messaging.NewSingleTargetMessage(server,
+		messaging.MaintainerManagerTopic,
+		&heartbeatpb.UpdateMaintainerConfigRequest{
+			Id:     c.ID.ToPB(),
+			Config: *c.configBytes.Load(),
+		})
+}
+
 func (c *Changefeed) NewRemoveMaintainerMessage(server node.ID, caseCade, removed bool) *messaging.TargetMessage {
 	return RemoveMaintainerMessage(c.ID, server, caseCade, removed)
 }
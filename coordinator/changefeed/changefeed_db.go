@@ -253,7 +253,7 @@ func (db *ChangefeedDB) MarkMaintainerScheduling(cf *Changefeed) {
 	db.MarkSchedulingWithoutLock(cf)
 }
 
-// CalculateGCSafepoint calculates the minimum checkpointTs of all changefeeds that replicating the upstream TiDB cluster.
+// CalculateGCSafepoint calculates the minimum checkpointTs of all changefeeds that replicating the default upstream TiDB cluster.
 func (db *ChangefeedDB) CalculateGCSafepoint() uint64 {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -262,7 +262,7 @@ func (db *ChangefeedDB) CalculateGCSafepoint() uint64 {
 
 	for _, cf := range db.changefeeds {
 		info := cf.GetInfo()
-		if info == nil || !info.NeedBlockGC() {
+		if info == nil || !info.NeedBlockGC() || info.GetNonDefaultUpstreamID() != 0 {
 			continue
 		}
 		checkpointTs := cf.GetLastSavedCheckPointTs()
@@ -273,6 +273,44 @@ func (db *ChangefeedDB) CalculateGCSafepoint() uint64 {
 	return minCpts
 }
 
+// UpstreamGCSafepoint is the minimum checkpointTs of the changefeeds that replicating an upstream TiDB cluster.
+type UpstreamGCSafepoint struct {
+	Info *config.UpstreamInfo
+	// MinCheckpointTs is math.MaxUint64 if none of the changefeeds blocks the gc.
+	MinCheckpointTs uint64
+	// RefCount is the number of the changefeeds replicating from the upstream.
+	RefCount int
+}
+
+// CalculateUpstreamGCSafepoints calculates the minimum checkpointTs and the number of the changefeeds
+// of each upstream TiDB cluster other than the default one, the key is the upstream ID.
+func (db *ChangefeedDB) CalculateUpstreamGCSafepoints() map[uint64]*UpstreamGCSafepoint {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	res := make(map[uint64]*UpstreamGCSafepoint)
+	for _, cf := range db.changefeeds {
+		info := cf.GetInfo()
+		if info == nil || info.GetNonDefaultUpstreamID() == 0 {
+			continue
+		}
+		safepoint, ok := res[info.UpstreamID]
+		if !ok {
+			safepoint = &UpstreamGCSafepoint{Info: info.UpstreamInfo, MinCheckpointTs: math.MaxUint64}
+			res[info.UpstreamID] = safepoint
+		}
+		safepoint.RefCount++
+		if !info.NeedBlockGC() {
+			continue
+		}
+		checkpointTs := cf.GetLastSavedCheckPointTs()
+		if safepoint.MinCheckpointTs > checkpointTs {
+			safepoint.MinCheckpointTs = checkpointTs
+		}
+	}
+	return res
+}
+
 // ReplaceStoppedChangefeed updates the stopped changefeed
 func (db *ChangefeedDB) ReplaceStoppedChangefeed(cf *config.ChangeFeedInfo) {
 	db.lock.Lock()
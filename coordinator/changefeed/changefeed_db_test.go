@@ -327,3 +327,50 @@ func TestCalculateGCSafepoint(t *testing.T) {
 	db.AddStoppedChangefeed(cf5)
 	require.Equal(t, uint64(7), db.CalculateGCSafepoint())
 }
+
+func TestCalculateUpstreamGCSafepoints(t *testing.T) {
+	db := NewChangefeedDB(1216)
+	require.Empty(t, db.CalculateUpstreamGCSafepoints())
+
+	upstreamInfo := &config.UpstreamInfo{ID: 2, PDEndpoints: "http://127.0.0.1:2379"}
+	newChangefeed := func(upstreamInfo *config.UpstreamInfo, checkpointTs uint64) {
+		cfID := common.NewChangeFeedIDWithName("test")
+		info := &config.ChangeFeedInfo{
+			ChangefeedID: cfID,
+			Config:       config.GetDefaultReplicaConfig(),
+			State:        model.StateStopped,
+			UpstreamInfo: upstreamInfo,
+		}
+		if upstreamInfo != nil {
+			info.UpstreamID = upstreamInfo.ID
+		}
+		db.AddStoppedChangefeed(NewChangefeed(cfID, info, checkpointTs, true))
+	}
+
+	newChangefeed(nil, 5)
+	newChangefeed(upstreamInfo, 11)
+	newChangefeed(upstreamInfo, 9)
+
+	// the changefeeds of other upstreams don't block the gc of the default upstream
+	require.Equal(t, uint64(5), db.CalculateGCSafepoint())
+	safepoints := db.CalculateUpstreamGCSafepoints()
+	require.Len(t, safepoints, 1)
+	require.Equal(t, uint64(9), safepoints[2].MinCheckpointTs)
+	require.Equal(t, upstreamInfo, safepoints[2].Info)
+	require.Equal(t, 2, safepoints[2].RefCount)
+
+	// the finished changefeed still references the upstream, but doesn't block its gc
+	finishedUpstreamInfo := &config.UpstreamInfo{ID: 3, PDEndpoints: "http://127.0.0.1:2380"}
+	cfID := common.NewChangeFeedIDWithName("finished")
+	db.AddStoppedChangefeed(NewChangefeed(cfID, &config.ChangeFeedInfo{
+		ChangefeedID: cfID,
+		Config:       config.GetDefaultReplicaConfig(),
+		State:        model.StateFinished,
+		UpstreamID:   finishedUpstreamInfo.ID,
+		UpstreamInfo: finishedUpstreamInfo,
+	}, 3, true))
+	safepoints = db.CalculateUpstreamGCSafepoints()
+	require.Len(t, safepoints, 2)
+	require.Equal(t, uint64(math.MaxUint64), safepoints[3].MinCheckpointTs)
+	require.Equal(t, 1, safepoints[3].RefCount)
+}
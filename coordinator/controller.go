@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/scheduler"
+	"github.com/pingcap/ticdc/pkg/server"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/ticdc/utils/chann"
 	"github.com/pingcap/ticdc/utils/threadpool"
@@ -95,12 +96,8 @@ func NewController(
 	nodeManager := appcontext.GetService[*watcher.NodeManager](watcher.NodeManagerName)
 	oc := operator.NewOperatorController(mc, selfNode, changefeedDB, backend, nodeManager, batchSize)
 	c := &Controller{
-		version:      version,
-		bootstrapped: atomic.NewBool(false),
-		scheduler: scheduler.NewController(map[string]scheduler.Scheduler{
-			scheduler.BasicScheduler:   scheduler.NewBasicScheduler(selfNode.ID.String(), batchSize, oc, changefeedDB, nodeManager, oc.NewAddMaintainerOperator),
-			scheduler.BalanceScheduler: scheduler.NewBalanceScheduler(selfNode.ID.String(), batchSize, oc, changefeedDB, nodeManager, balanceInterval, oc.NewMoveMaintainerOperator),
-		}),
+		version:             version,
+		bootstrapped:        atomic.NewBool(false),
 		eventCh:             eventCh,
 		operatorController:  oc,
 		messageCenter:       mc,
@@ -113,6 +110,11 @@ func NewController(
 		stateChangedCh:      stateChangedCh,
 		lastPrintStatusTime: time.Now(),
 	}
+	c.scheduler = scheduler.NewController(map[string]scheduler.Scheduler{
+		scheduler.BasicScheduler:   scheduler.NewBasicScheduler(selfNode.ID.String(), batchSize, oc, changefeedDB, nodeManager, oc.NewAddMaintainerOperator),
+		scheduler.BalanceScheduler: scheduler.NewBalanceScheduler(selfNode.ID.String(), batchSize, oc, changefeedDB, nodeManager, balanceInterval, c, oc.NewMoveMaintainerOperator),
+		scheduler.DrainScheduler:   scheduler.NewDrainScheduler(selfNode.ID.String(), batchSize, oc, changefeedDB, nodeManager, oc.NewMoveMaintainerOperator),
+	})
 	c.bootstrapper = bootstrap.NewBootstrapper[heartbeatpb.CoordinatorBootstrapResponse]("coordinator", c.newBootstrapMessage)
 	// init bootstrapper nodes
 	nodes := c.nodeManager.GetAliveNodes()
@@ -198,9 +200,19 @@ func (c *Controller) onNodeChanged() {
 
 	activeNodes := c.nodeManager.GetAliveNodes()
 	newNodes := make([]*node.Info, 0, len(activeNodes))
+	var restartedNodes []node.ID
 	for id, n := range activeNodes {
-		if _, ok := currentNodes[id]; !ok {
+		status, ok := currentNodes[id]
+		if !ok {
 			newNodes = append(newNodes, n)
+			continue
+		}
+		if status.IsRestarted(n) {
+			// the node is restarted with the same id, all states on it are lost,
+			// so clean up the states and bootstrap it again as a new node.
+			restartedNodes = append(restartedNodes, id)
+			newNodes = append(newNodes, n)
+			c.RemoveNode(id)
 		}
 	}
 	var removedNodes []node.ID
@@ -212,8 +224,13 @@ func (c *Controller) onNodeChanged() {
 	}
 	log.Info("node changed",
 		zap.Int("new", len(newNodes)),
-		zap.Int("removed", len(removedNodes)))
+		zap.Int("removed", len(removedNodes)),
+		zap.Int("restarted", len(restartedNodes)))
 	c.sendMessages(c.bootstrapper.HandleNewNodes(newNodes))
+	if len(newNodes) > 0 && len(c.nodeManager.GetUnschedulableNodes()) > 0 {
+		// the new nodes need to know the draining nodes
+		c.broadcastUnschedulableNodes()
+	}
 	cachedResponse := c.bootstrapper.HandleRemoveNodes(removedNodes)
 	if cachedResponse != nil {
 		log.Info("bootstrap done after removed some nodes")
@@ -230,10 +247,10 @@ func (c *Controller) sendMessages(msgs []*messaging.TargetMessage) {
 func (c *Controller) onMaintainerBootstrapResponse(msg *messaging.TargetMessage) {
 	log.Info("received maintainer bootstrap response",
 		zap.Any("server", msg.From))
-	cachedResp := c.bootstrapper.HandleBootstrapResponse(
-		msg.From,
-		msg.Message[0].(*heartbeatpb.CoordinatorBootstrapResponse),
-	)
+	resp := msg.Message[0].(*heartbeatpb.CoordinatorBootstrapResponse)
+	// the labels are used to match the node affinity of the changefeeds
+	c.nodeManager.SetNodeLabels(msg.From, node.ParseLabels(resp.NodeLabels))
+	cachedResp := c.bootstrapper.HandleBootstrapResponse(msg.From, resp)
 	c.onBootstrapDone(cachedResp)
 }
 
@@ -501,6 +518,11 @@ func (c *Controller) UpdateChangefeed(ctx context.Context, change *config.Change
 	if cf == nil {
 		return errors.New("changefeed not found")
 	}
+	switch cf.GetInfo().State {
+	case model.StateNormal, model.StateWarning:
+		return c.updateRunningChangefeed(ctx, cf, change)
+	default:
+	}
 	if err := c.backend.UpdateChangefeed(ctx, change, cf.GetStatus().CheckpointTs, config.ProgressStopping); err != nil {
 		return errors.Trace(err)
 	}
@@ -508,6 +530,31 @@ func (c *Controller) UpdateChangefeed(ctx context.Context, change *config.Change
 	return nil
 }
 
+// updateRunningChangefeed persists the hot-reloadable config of a running changefeed,
+// and pushes it to the maintainer if the changefeed is scheduled.
+// If the maintainer is not scheduled yet, the new config is carried by the add maintainer request.
+func (c *Controller) updateRunningChangefeed(ctx context.Context, cf *changefeed.Changefeed, change *config.ChangeFeedInfo) error {
+	if err := c.backend.UpdateChangefeed(ctx, change, cf.GetStatus().CheckpointTs, config.ProgressNone); err != nil {
+		return errors.Trace(err)
+	}
+	if err := cf.UpdateInfo(change); err != nil {
+		return errors.Trace(err)
+	}
+	if nodeID := cf.GetNodeID(); nodeID != "" {
+		if err := c.messageCenter.SendCommand(cf.NewUpdateMaintainerConfigMessage(nodeID)); err != nil {
+			log.Warn("send update maintainer config request failed",
+				zap.String("changefeed", cf.ID.String()),
+				zap.Stringer("node", nodeID),
+				zap.Error(err))
+			return errors.Trace(err)
+		}
+	}
+	log.Info("update running changefeed config",
+		zap.String("changefeed", cf.ID.String()),
+		zap.String("info", change.String()))
+	return nil
+}
+
 func (c *Controller) ListChangefeeds(_ context.Context) ([]*config.ChangeFeedInfo, []*config.ChangeFeedStatus, error) {
 	c.apiLock.RLock()
 	defer c.apiLock.RUnlock()
@@ -516,7 +563,7 @@ func (c *Controller) ListChangefeeds(_ context.Context) ([]*config.ChangeFeedInf
 	infos := make([]*config.ChangeFeedInfo, 0, len(cfs))
 	statuses := make([]*config.ChangeFeedStatus, 0, len(cfs))
 	for _, cf := range cfs {
-		infos = append(infos, cf.GetInfo())
+		infos = append(infos, cf.GetInfoWithWarning())
 		statuses = append(statuses, &config.ChangeFeedStatus{CheckpointTs: cf.GetStatus().CheckpointTs})
 	}
 	return infos, statuses, nil
@@ -546,7 +593,95 @@ func (c *Controller) GetChangefeed(
 	}
 	status := &config.ChangeFeedStatus{CheckpointTs: cf.GetStatus().CheckpointTs}
 	status.SetMaintainerAddr(maintainerAddr)
-	return cf.GetInfo(), status, nil
+	return cf.GetInfoWithWarning(), status, nil
+}
+
+// DrainNode marks the node as unschedulable, the drain scheduler moves all the maintainers away from it,
+// and the maintainers move all the dispatchers away from it.
+// It's safe to call it repeatedly to query the drain progress.
+func (c *Controller) DrainNode(_ context.Context, id node.ID) (*server.DrainProgress, error) {
+	c.apiLock.RLock()
+	defer c.apiLock.RUnlock()
+
+	if !c.bootstrapped.Load() {
+		return nil, errors.New("not initialized, wait a moment")
+	}
+	if !c.nodeManager.SetNodeUnschedulable(id) {
+		return nil, errors.ErrCaptureNotExist.GenWithStackByArgs(id)
+	}
+	if len(c.nodeManager.GetSchedulableNodes()) == 0 {
+		log.Warn("all nodes are unschedulable, the tasks can not be drained",
+			zap.Stringer("node", id))
+	}
+	c.broadcastUnschedulableNodes()
+
+	progress := c.getDrainProgress(id)
+	log.Info("drain node",
+		zap.Stringer("node", id),
+		zap.Int("remainingMaintainers", progress.RemainingMaintainers),
+		zap.Int("remainingDispatchers", progress.RemainingDispatchers),
+		zap.Int("unknownChangefeeds", progress.UnknownChangefeeds))
+	return progress, nil
+}
+
+// getDrainProgress counts the tasks left on the draining node. The draining dispatchers are
+// counted by the maintainers, a status is stale if it's produced before the latest drain
+// request, so the dispatchers of the changefeed are unknown until a fresh status is reported.
+func (c *Controller) getDrainProgress(id node.ID) *server.DrainProgress {
+	_, epoch := c.nodeManager.GetUnschedulableNodesWithEpoch()
+	progress := &server.DrainProgress{
+		RemainingMaintainers: len(c.changefeedDB.GetByNodeID(id)),
+	}
+	for _, cf := range c.changefeedDB.GetAllChangefeeds() {
+		if cf.GetNodeID() == "" {
+			// the changefeed is stopped, no dispatcher is running
+			continue
+		}
+		status := cf.GetStatus()
+		if status == nil || status.DrainEpoch != epoch {
+			progress.UnknownChangefeeds++
+			continue
+		}
+		progress.RemainingDispatchers += int(status.DrainingDispatcherCount)
+	}
+	return progress
+}
+
+// UndrainNode clears the unschedulable mark of the node, the tasks can be scheduled to it again.
+// It's a no-op if the node is not being drained.
+func (c *Controller) UndrainNode(_ context.Context, id node.ID) error {
+	c.apiLock.RLock()
+	defer c.apiLock.RUnlock()
+
+	if !c.bootstrapped.Load() {
+		return errors.New("not initialized, wait a moment")
+	}
+	if !c.nodeManager.SetNodeSchedulable(id) {
+		log.Info("node is not being drained, ignore the undrain request",
+			zap.Stringer("node", id))
+		return nil
+	}
+	c.broadcastUnschedulableNodes()
+	log.Info("undrain node", zap.Stringer("node", id))
+	return nil
+}
+
+// broadcastUnschedulableNodes sends the draining nodes to all the nodes,
+// so the maintainers on them can move the dispatchers away from the draining nodes.
+// It's sent even if no node is draining, to clear the marks after a node is undrained.
+func (c *Controller) broadcastUnschedulableNodes() {
+	drainingNodes, epoch := c.nodeManager.GetUnschedulableNodesWithEpoch()
+	nodeIDs := make([]string, 0, len(drainingNodes))
+	for _, id := range drainingNodes {
+		nodeIDs = append(nodeIDs, id.String())
+	}
+	msgs := make([]*messaging.TargetMessage, 0, len(c.nodeManager.GetAliveNodes()))
+	for id := range c.nodeManager.GetAliveNodes() {
+		msgs = append(msgs, messaging.NewSingleTargetMessage(id,
+			messaging.MaintainerManagerTopic,
+			&heartbeatpb.DrainNodeRequest{NodeIds: nodeIDs, Epoch: epoch}))
+	}
+	c.sendMessages(msgs)
 }
 
 // GetTask queries a task by channgefeed ID, return nil if not found
@@ -554,6 +689,25 @@ func (c *Controller) GetTask(id common.ChangeFeedID) *changefeed.Changefeed {
 	return c.changefeedDB.GetByID(id)
 }
 
+// GetNodeLoads returns the runtime load of the nodes reported by the maintainers running on them,
+// the heaviest one is taken if several maintainers are on the same node. It implements
+// scheduler.NodeLoadProvider.
+func (c *Controller) GetNodeLoads() map[node.ID]scheduler.NodeLoad {
+	loads := make(map[node.ID]scheduler.NodeLoad)
+	for _, cf := range c.changefeedDB.GetReplicating() {
+		status := cf.GetStatus()
+		if status == nil {
+			continue
+		}
+		id := cf.GetNodeID()
+		load := loads[id]
+		load.MemoryUsageRatio = max(load.MemoryUsageRatio, float64(status.MemoryUsageRatio))
+		load.SinkLag = max(load.SinkLag, time.Duration(status.SinkLagMs)*time.Millisecond)
+		loads[id] = load
+	}
+	return loads
+}
+
 // RemoveNode is called when a node is removed
 func (c *Controller) RemoveNode(id node.ID) {
 	c.operatorController.OnNodeRemoved(id)
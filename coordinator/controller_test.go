@@ -15,16 +15,21 @@ package coordinator
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pingcap/ticdc/coordinator/changefeed"
 	mock_changefeed "github.com/pingcap/ticdc/coordinator/changefeed/mock"
 	"github.com/pingcap/ticdc/coordinator/operator"
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/stretchr/testify/require"
@@ -162,6 +167,157 @@ func TestGetChangefeed(t *testing.T) {
 	require.True(t, errors.ErrChangeFeedNotExists.Equal(err))
 }
 
+func TestChangefeedBlockedByBDRRoleStaysNormal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	backend := mock_changefeed.NewMockBackend(ctrl)
+	changefeedDB := changefeed.NewChangefeedDB(1216)
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	stateChangedCh := make(chan *ChangefeedStateChangeEvent, 16)
+	controller := &Controller{
+		backend:        backend,
+		changefeedDB:   changefeedDB,
+		stateChangedCh: stateChangedCh,
+		operatorController: operator.NewOperatorController(nil, node.NewInfo("node1", ""),
+			changefeedDB, backend, nodeManager, 10),
+	}
+	cfID := common.NewChangeFeedIDWithName("test")
+	cf := changefeed.NewChangefeed(cfID, &config.ChangeFeedInfo{
+		ChangefeedID: cfID,
+		Config:       config.GetDefaultReplicaConfig(),
+		State:        model.StateNormal,
+		SinkURI:      "mysql://127.0.0.1:3306",
+	}, 10, true)
+	changefeedDB.AddReplicatingMaintainer(cf, "node1")
+
+	newStatus := func(checkpointTs uint64, warning *heartbeatpb.RunningError) []*heartbeatpb.MaintainerStatus {
+		return []*heartbeatpb.MaintainerStatus{{
+			ChangefeedID:  cfID.ToPB(),
+			State:         heartbeatpb.ComponentState_Working,
+			CheckpointTs:  checkpointTs,
+			BootstrapDone: true,
+			Warning:       warning,
+		}}
+	}
+	controller.HandleStatus("node1", newStatus(10, nil))
+	event := <-stateChangedCh
+	require.Equal(t, model.StateNormal, event.State)
+
+	// the ddl is blocked in the secondary cluster, the maintainer keeps reporting the
+	// warning while the checkpoint is stuck, but the changefeed is never restarted.
+	warning := &heartbeatpb.RunningError{
+		Node:    "node1",
+		Code:    string(errors.ErrBDRUnsafeDDLBlocked.RFCCode()),
+		Message: errors.ErrBDRUnsafeDDLBlocked.GenWithStackByArgs("drop column", 20, config.BDRRoleSecondary).Error(),
+	}
+	for i := 0; i < 10; i++ {
+		controller.HandleStatus("node1", newStatus(10, warning))
+	}
+	require.Len(t, stateChangedCh, 0)
+	info := changefeedDB.GetByID(cfID).GetInfo()
+	require.Equal(t, model.StateNormal, info.State)
+	require.Nil(t, info.Error)
+	require.Nil(t, info.Warning)
+	require.NotNil(t, cf.GetWarning())
+	require.Equal(t, warning.Code, cf.GetWarning().Code)
+	require.Equal(t, warning.Code, cf.GetInfoWithWarning().Warning.Code)
+	require.True(t, cf.ShouldRun())
+
+	// the info updated when the warning is reported is not overwritten by the warning
+	newInfo, err := info.Clone()
+	require.NoError(t, err)
+	newInfo.Config.BDRRole = config.BDRRolePrimary
+	require.NoError(t, cf.UpdateInfo(newInfo))
+	warning.Message = "another warning"
+	controller.HandleStatus("node1", newStatus(10, warning))
+	require.Same(t, newInfo, cf.GetInfo())
+	require.Equal(t, warning.Message, cf.GetWarning().Message)
+
+	// the role is changed, the ddl goes on and the warning is cleared
+	controller.HandleStatus("node1", newStatus(30, nil))
+	require.Len(t, stateChangedCh, 0)
+	require.Equal(t, model.StateNormal, cf.GetInfo().State)
+	require.Nil(t, cf.GetWarning())
+	require.Same(t, newInfo, cf.GetInfoWithWarning())
+}
+
+func TestDrainAndUndrainNode(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	backend := mock_changefeed.NewMockBackend(ctrl)
+	changefeedDB := changefeed.NewChangefeedDB(1216)
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	nodeManager.GetAliveNodes()["node2"] = &node.Info{ID: "node2"}
+	controller := &Controller{
+		backend:       backend,
+		changefeedDB:  changefeedDB,
+		nodeManager:   nodeManager,
+		bootstrapped:  atomic.NewBool(true),
+		messageCenter: messaging.NewMessageCenter(ctx, "node1", 0, config.NewDefaultMessageCenterConfig(), nil),
+	}
+	var cfs []*changefeed.Changefeed
+	for i, nodeID := range []node.ID{"node1", "node2"} {
+		cfID := common.NewChangeFeedIDWithName(fmt.Sprintf("test%d", i))
+		cf := changefeed.NewChangefeed(cfID, &config.ChangeFeedInfo{
+			ChangefeedID: cfID,
+			Config:       config.GetDefaultReplicaConfig(),
+			State:        model.StateNormal,
+			SinkURI:      "mysql://127.0.0.1:3306",
+		}, 10, true)
+		changefeedDB.AddReplicatingMaintainer(cf, nodeID)
+		cfs = append(cfs, cf)
+	}
+
+	// the statuses reported before the drain request are unknown
+	progress, err := controller.DrainNode(ctx, "node1")
+	require.NoError(t, err)
+	require.Equal(t, 1, progress.RemainingMaintainers)
+	require.Equal(t, 0, progress.RemainingDispatchers)
+	require.Equal(t, 2, progress.UnknownChangefeeds)
+	require.False(t, progress.Drained())
+
+	_, epoch := nodeManager.GetUnschedulableNodesWithEpoch()
+	require.NotZero(t, epoch)
+	for i, cf := range cfs {
+		cf.UpdateStatus(&heartbeatpb.MaintainerStatus{
+			CheckpointTs:            10,
+			DrainingDispatcherCount: uint32(i + 1),
+			DrainEpoch:              epoch,
+		})
+	}
+	progress, err = controller.DrainNode(ctx, "node1")
+	require.NoError(t, err)
+	require.Equal(t, 3, progress.RemainingDispatchers)
+	require.Equal(t, 0, progress.UnknownChangefeeds)
+	_, newEpoch := nodeManager.GetUnschedulableNodesWithEpoch()
+	require.Equal(t, epoch, newEpoch)
+
+	// the maintainer and dispatchers are moved away from the node
+	changefeedDB.BindChangefeedToNode("node1", "node2", cfs[0])
+	changefeedDB.MarkMaintainerReplicating(cfs[0])
+	for _, cf := range cfs {
+		cf.UpdateStatus(&heartbeatpb.MaintainerStatus{CheckpointTs: 10, DrainEpoch: epoch})
+	}
+	progress, err = controller.DrainNode(ctx, "node1")
+	require.NoError(t, err)
+	require.True(t, progress.Drained())
+
+	// undrain the node, the epoch is bumped
+	require.NoError(t, controller.UndrainNode(ctx, "node1"))
+	require.False(t, nodeManager.IsNodeUnschedulable("node1"))
+	require.Len(t, nodeManager.GetSchedulableNodes(), 2)
+	_, newEpoch = nodeManager.GetUnschedulableNodesWithEpoch()
+	require.Greater(t, newEpoch, epoch)
+	// undrain is idempotent
+	require.NoError(t, controller.UndrainNode(ctx, "node1"))
+
+	// drain it again, the statuses of the previous drain are stale
+	progress, err = controller.DrainNode(ctx, "node1")
+	require.NoError(t, err)
+	require.Equal(t, 2, progress.UnknownChangefeeds)
+}
+
 func TestRemoveChangefeed(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	backend := mock_changefeed.NewMockBackend(ctrl)
@@ -294,3 +450,79 @@ func TestCreateChangefeed(t *testing.T) {
 	}
 	require.NotNil(t, controller.CreateChangefeed(context.Background(), cf2Config))
 }
+
+func TestScheduleWithPriorityAndNodeAffinity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	backend := mock_changefeed.NewMockBackend(ctrl)
+	changefeedDB := changefeed.NewChangefeedDB(1216)
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	for _, id := range []node.ID{"node1", "node2", "node3"} {
+		nodeManager.GetAliveNodes()[id] = &node.Info{ID: id}
+	}
+	nodeManager.SetNodeLabels("node3", map[string]string{"zone": "z1"})
+	oc := operator.NewOperatorController(nil, node.NewInfo("node1", ""), changefeedDB, backend, nodeManager, 10)
+
+	newChangefeed := func(name string, priority config.ChangefeedPriority, affinity map[string]string) *changefeed.Changefeed {
+		cfID := common.NewChangeFeedIDWithName(name)
+		return changefeed.NewChangefeed(cfID, &config.ChangeFeedInfo{
+			ChangefeedID: cfID,
+			Config:       config.GetDefaultReplicaConfig(),
+			State:        model.StateNormal,
+			SinkURI:      "mysql://127.0.0.1:3306",
+			Priority:     priority,
+			NodeAffinity: affinity,
+		}, 1, true)
+	}
+	affinity := map[string]string{"zone": "z1"}
+	normal1 := newChangefeed("normal1", config.ChangefeedPriorityNormal, nil)
+	normal2 := newChangefeed("normal2", config.ChangefeedPriorityNormal, nil)
+	high1 := newChangefeed("high1", config.ChangefeedPriorityHigh, affinity)
+	high2 := newChangefeed("high2", config.ChangefeedPriorityHigh, affinity)
+	changefeedDB.AddAbsentChangefeed(normal1, normal2, high1, high2)
+
+	// the high priority changefeeds are scheduled first, and only to the node matching the affinity
+	scheduler.NewBasicScheduler("test", 2, oc, changefeedDB, nodeManager, oc.NewAddMaintainerOperator).Execute()
+	require.Equal(t, node.ID("node3"), high1.GetNodeID())
+	require.Equal(t, node.ID("node3"), high2.GetNodeID())
+	require.Equal(t, node.ID(""), normal1.GetNodeID())
+	require.Equal(t, node.ID(""), normal2.GetNodeID())
+
+	// a replicating changefeed placed on a node not matching its affinity is moved back
+	changefeedDB = changefeed.NewChangefeedDB(1216)
+	oc = operator.NewOperatorController(nil, node.NewInfo("node1", ""), changefeedDB, backend, nodeManager, 10)
+	pinned := newChangefeed("pinned", config.ChangefeedPriorityNormal, affinity)
+	changefeedDB.AddReplicatingMaintainer(pinned, "node1")
+	scheduler.NewBalanceScheduler("test", 10, oc, changefeedDB, nodeManager, 0, nil, oc.NewMoveMaintainerOperator).Execute()
+	op := oc.GetOperator(pinned.ID)
+	require.NotNil(t, op)
+	require.Contains(t, op.AffectedNodes(), node.ID("node3"))
+}
+
+func TestGetNodeLoads(t *testing.T) {
+	changefeedDB := changefeed.NewChangefeedDB(1216)
+	controller := &Controller{changefeedDB: changefeedDB}
+	addChangefeed := func(name string, nodeID node.ID, status *heartbeatpb.MaintainerStatus) {
+		cfID := common.NewChangeFeedIDWithName(name)
+		cf := changefeed.NewChangefeed(cfID, &config.ChangeFeedInfo{
+			ChangefeedID: cfID,
+			Config:       config.GetDefaultReplicaConfig(),
+			State:        model.StateNormal,
+			SinkURI:      "mysql://127.0.0.1:3306",
+		}, 10, true)
+		status.ChangefeedID = cfID.ToPB()
+		status.CheckpointTs = 10
+		cf.UpdateStatus(status)
+		changefeedDB.AddReplicatingMaintainer(cf, nodeID)
+	}
+	addChangefeed("cf1", "node1", &heartbeatpb.MaintainerStatus{MemoryUsageRatio: 0.2, SinkLagMs: 3000})
+	addChangefeed("cf2", "node1", &heartbeatpb.MaintainerStatus{MemoryUsageRatio: 0.5, SinkLagMs: 1000})
+	addChangefeed("cf3", "node2", &heartbeatpb.MaintainerStatus{MemoryUsageRatio: 0.1})
+
+	// the heaviest load reported by the maintainers on the same node is taken
+	loads := controller.GetNodeLoads()
+	require.Len(t, loads, 2)
+	require.InDelta(t, 0.5, loads["node1"].MemoryUsageRatio, 1e-6)
+	require.Equal(t, 3*time.Second, loads["node1"].SinkLag)
+	require.InDelta(t, 0.1, loads["node2"].MemoryUsageRatio, 1e-6)
+	require.Equal(t, time.Duration(0), loads["node2"].SinkLag)
+}
@@ -63,6 +63,8 @@ type coordinator struct {
 	gcManager gc.Manager
 	pdClient  pd.Client
 	pdClock   pdutil.Clock
+	// upstreamGCManagers keeps the gc safepoints of the upstreams other than the default one.
+	upstreamGCManagers *upstreamGCManagers
 
 	eventCh             *chann.DrainableChann[*Event]
 	updatedChangefeedCh chan map[common.ChangeFeedID]*changefeed.Changefeed
@@ -89,6 +91,7 @@ func New(node *node.Info,
 		gcServiceID:         gcServiceID,
 		lastTickTime:        time.Now(),
 		gcManager:           gc.NewManager(gcServiceID, pdClient, pdClock),
+		upstreamGCManagers:  newUpstreamGCManagers(gcServiceID),
 		eventCh:             chann.NewAutoDrainChann[*Event](),
 		pdClient:            pdClient,
 		pdClock:             pdClock,
@@ -230,13 +233,24 @@ func (c *coordinator) handleStateChangedEvent(ctx context.Context, event *Change
 		log.Info("changefeed is resumed or created successfully, try to delete its gc safepoint",
 			zap.String("changefeed", event.ChangefeedID.String()))
 		// We need to clean its gc safepoint when changefeed is resumed or created
+		pdClient := c.pdClient
+		if cfInfo.UpstreamInfo != nil {
+			_, upstreamPDClient, ok := c.upstreamGCManagers.get(cfInfo.UpstreamInfo)
+			if !ok {
+				log.Warn("upstream is not ready, skip deleting the gc safepoint of the changefeed",
+					zap.String("changefeed", event.ChangefeedID.String()),
+					zap.Uint64("upstreamID", cfInfo.UpstreamID))
+				return nil
+			}
+			pdClient = upstreamPDClient
+		}
 		gcServiceID := c.getEnsureGCServiceID(gc.EnsureGCServiceCreating)
-		err := gc.UndoEnsureChangefeedStartTsSafety(ctx, c.pdClient, gcServiceID, event.ChangefeedID)
+		err := gc.UndoEnsureChangefeedStartTsSafety(ctx, pdClient, gcServiceID, event.ChangefeedID)
 		if err != nil {
 			log.Warn("failed to delete create changefeed gc safepoint", zap.Error(err))
 		}
 		gcServiceID = c.getEnsureGCServiceID(gc.EnsureGCServiceResuming)
-		err = gc.UndoEnsureChangefeedStartTsSafety(ctx, c.pdClient, gcServiceID, event.ChangefeedID)
+		err = gc.UndoEnsureChangefeedStartTsSafety(ctx, pdClient, gcServiceID, event.ChangefeedID)
 		if err != nil {
 			log.Warn("failed to delete resume changefeed gc safepoint", zap.Error(err))
 		}
@@ -246,8 +260,17 @@ func (c *coordinator) handleStateChangedEvent(ctx context.Context, event *Change
 }
 
 // checkStaleCheckpointTs checks if the checkpointTs is stale, if it is, it will send a state change event to the stateChangedCh
-func (c *coordinator) checkStaleCheckpointTs(ctx context.Context, id common.ChangeFeedID, reportedCheckpointTs uint64) {
-	err := c.gcManager.CheckStaleCheckpointTs(ctx, id, reportedCheckpointTs)
+func (c *coordinator) checkStaleCheckpointTs(ctx context.Context, cf *changefeed.Changefeed, reportedCheckpointTs uint64) {
+	id := cf.ID
+	gcManager := c.gcManager
+	if info := cf.GetInfo(); info != nil && info.UpstreamInfo != nil {
+		upstreamGCManager, _, ok := c.upstreamGCManagers.get(info.UpstreamInfo)
+		if !ok {
+			return
+		}
+		gcManager = upstreamGCManager
+	}
+	err := gcManager.CheckStaleCheckpointTs(ctx, id, reportedCheckpointTs)
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	if err != nil {
@@ -281,7 +304,7 @@ func (c *coordinator) saveCheckpointTs(ctx context.Context, cfs map[common.Chang
 		reportedCheckpointTs := upCf.GetStatus().CheckpointTs
 		if upCf.GetLastSavedCheckPointTs() < reportedCheckpointTs {
 			statusMap[upCf.ID] = reportedCheckpointTs
-			c.checkStaleCheckpointTs(ctx, upCf.ID, reportedCheckpointTs)
+			c.checkStaleCheckpointTs(ctx, upCf, reportedCheckpointTs)
 		}
 	}
 	if len(statusMap) == 0 {
@@ -332,6 +355,14 @@ func (c *coordinator) UpdateChangefeed(ctx context.Context, change *config.Chang
 	return c.controller.UpdateChangefeed(ctx, change)
 }
 
+func (c *coordinator) DrainNode(ctx context.Context, id node.ID) (*server.DrainProgress, error) {
+	return c.controller.DrainNode(ctx, id)
+}
+
+func (c *coordinator) UndrainNode(ctx context.Context, id node.ID) error {
+	return c.controller.UndrainNode(ctx, id)
+}
+
 func (c *coordinator) ListChangefeeds(ctx context.Context) ([]*config.ChangeFeedInfo, []*config.ChangeFeedStatus, error) {
 	return c.controller.ListChangefeeds(ctx)
 }
@@ -354,6 +385,7 @@ func (c *coordinator) AsyncStop() {
 		c.controller.Stop()
 		c.taskScheduler.Stop()
 		c.eventCh.CloseAndDrain()
+		c.upstreamGCManagers.close()
 		c.cancel()
 	}
 }
@@ -381,7 +413,12 @@ func (c *coordinator) updateGCSafepoint(
 	// (checkpointTs - 1) from TiKV, so (checkpointTs - 1) should be an upper
 	// bound for the GC safepoint.
 	gcSafepointUpperBound := minCheckpointTs - 1
+	// Each upstream is updated independently, a failure of one doesn't block the others.
 	err := c.gcManager.TryUpdateGCSafePoint(ctx, gcSafepointUpperBound, false)
+	upstreamErr := c.upstreamGCManagers.updateGCSafepoints(ctx, c.controller.changefeedDB.CalculateUpstreamGCSafepoints())
+	if err == nil {
+		err = upstreamErr
+	}
 	return errors.Trace(err)
 }
 
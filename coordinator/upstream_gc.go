@@ -0,0 +1,133 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/coordinator/changefeed"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/txnutil/gc"
+	"github.com/pingcap/ticdc/pkg/upstream"
+	pd "github.com/tikv/pd/client"
+	"go.uber.org/zap"
+)
+
+// upstreamGCManagers keeps the gc managers of the upstreams other than the default one.
+// The upstreams are connected asynchronously, an upstream is skipped until it's ready.
+// An upstream is disconnected when no changefeed replicates from it.
+type upstreamGCManagers struct {
+	gcServiceID     string
+	upstreamManager *upstream.Manager
+
+	mu sync.Mutex
+	// upstreamID -> gc manager
+	managers map[uint64]gc.Manager
+	// upstreams are the ids of the upstreams added to the upstream manager
+	upstreams map[uint64]struct{}
+}
+
+func newUpstreamGCManagers(gcServiceID string) *upstreamGCManagers {
+	return &upstreamGCManagers{
+		gcServiceID:     gcServiceID,
+		upstreamManager: upstream.NewManager(context.Background()),
+		managers:        make(map[uint64]gc.Manager),
+		upstreams:       make(map[uint64]struct{}),
+	}
+}
+
+// get returns the gc manager and the pd client of the upstream,
+// it returns false if the upstream is not ready yet.
+func (u *upstreamGCManagers) get(info *config.UpstreamInfo) (gc.Manager, pd.Client, bool) {
+	u.mu.Lock()
+	u.upstreams[info.ID] = struct{}{}
+	u.mu.Unlock()
+	up := u.upstreamManager.AddUpstream(&upstream.UpstreamInfo{
+		ID:            info.ID,
+		PDEndpoints:   info.PDEndpoints,
+		KeyPath:       info.KeyPath,
+		CertPath:      info.CertPath,
+		CAPath:        info.CAPath,
+		CertAllowedCN: info.CertAllowedCN,
+	})
+	if err := up.Error(); err != nil {
+		log.Warn("upstream initialization failed, retry later",
+			zap.Uint64("upstreamID", info.ID), zap.Error(err))
+		u.upstreamManager.Remove(info.ID)
+		return nil, nil, false
+	}
+	if !up.IsNormal() {
+		return nil, nil, false
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	m, ok := u.managers[info.ID]
+	if !ok {
+		m = gc.NewManager(u.gcServiceID, up.PDClient, up.PDClock)
+		u.managers[info.ID] = m
+	}
+	return m, up.PDClient, true
+}
+
+// updateGCSafepoints updates the gc safepoint of each upstream other than the default one,
+// and releases the upstreams which are not referenced by any changefeed.
+func (u *upstreamGCManagers) updateGCSafepoints(ctx context.Context, safepoints map[uint64]*changefeed.UpstreamGCSafepoint) error {
+	u.releaseUnreferenced(safepoints)
+	var lastErr error
+	for id, safepoint := range safepoints {
+		if safepoint.MinCheckpointTs == math.MaxUint64 {
+			// none of the changefeeds blocks the gc
+			continue
+		}
+		m, _, ok := u.get(safepoint.Info)
+		if !ok {
+			continue
+		}
+		// the same as the default upstream, (checkpointTs - 1) is the upper bound of the gc safepoint.
+		if err := m.TryUpdateGCSafePoint(ctx, safepoint.MinCheckpointTs-1, false); err != nil {
+			log.Warn("update upstream gc safepoint failed",
+				zap.Uint64("upstreamID", id), zap.Error(err))
+			lastErr = err
+		}
+	}
+	return errors.Trace(lastErr)
+}
+
+// releaseUnreferenced disconnects the upstreams whose reference count drops to zero.
+func (u *upstreamGCManagers) releaseUnreferenced(safepoints map[uint64]*changefeed.UpstreamGCSafepoint) {
+	var unreferenced []uint64
+	u.mu.Lock()
+	for id := range u.upstreams {
+		if safepoint, ok := safepoints[id]; !ok || safepoint.RefCount == 0 {
+			unreferenced = append(unreferenced, id)
+			delete(u.upstreams, id)
+			delete(u.managers, id)
+		}
+	}
+	u.mu.Unlock()
+	for _, id := range unreferenced {
+		u.upstreamManager.Remove(id)
+		log.Info("no changefeed replicates from the upstream, release its gc manager",
+			zap.Uint64("upstreamID", id))
+	}
+}
+
+func (u *upstreamGCManagers) close() {
+	u.upstreamManager.Close()
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/pingcap/ticdc/coordinator/changefeed"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpstreamGCManagersReleaseUnreferenced(t *testing.T) {
+	u := newUpstreamGCManagers("test-gc-service")
+	defer u.close()
+
+	info := &config.UpstreamInfo{ID: 2, PDEndpoints: "http://127.0.0.1:1"}
+	_, _, ok := u.get(info)
+	require.False(t, ok)
+	_, ok = u.upstreamManager.Get(2)
+	require.True(t, ok)
+
+	// the upstream is kept while a changefeed references it, even if the changefeed doesn't block the gc
+	err := u.updateGCSafepoints(context.Background(), map[uint64]*changefeed.UpstreamGCSafepoint{
+		2: {Info: info, MinCheckpointTs: math.MaxUint64, RefCount: 1},
+	})
+	require.NoError(t, err)
+	_, ok = u.upstreamManager.Get(2)
+	require.True(t, ok)
+
+	// the upstream is released when no changefeed references it
+	err = u.updateGCSafepoints(context.Background(), map[uint64]*changefeed.UpstreamGCSafepoint{})
+	require.NoError(t, err)
+	_, ok = u.upstreamManager.Get(2)
+	require.False(t, ok)
+}
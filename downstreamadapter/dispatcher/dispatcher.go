@@ -15,6 +15,7 @@ package dispatcher
 
 import (
 	"math/rand"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -27,8 +28,11 @@ import (
 	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/ticdc/pkg/spanz"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"go.uber.org/zap"
 )
 
@@ -37,12 +41,16 @@ type EventDispatcher interface {
 	GetId() common.DispatcherID
 	GetStartTs() uint64
 	GetChangefeedID() common.ChangeFeedID
+	GetUpstreamID() uint64
+	GetBDRMode() bool
+	IsHighPriority() bool
 	GetTableSpan() *heartbeatpb.TableSpan
 	GetFilterConfig() *eventpb.FilterConfig
 	EnableSyncPoint() bool
 	GetSyncPointInterval() time.Duration
 	GetStartTsIsSyncpoint() bool
 	GetResolvedTs() uint64
+	GetCheckpointTs() uint64
 	SetInitialTableInfo(tableInfo *common.TableInfo)
 	HandleEvents(events []DispatcherEvent, wakeCallback func()) (block bool)
 }
@@ -95,16 +103,39 @@ type Dispatcher struct {
 	// So we need to use `Replace` to avoid duplicate key error.
 	// Table Trigger Event Dispatcher doesn't need this, because it doesn't deal with dml events.
 	creationPDTs uint64
+	// dmlAppliedTs is set when the dml checkpoint is enabled for mysql-class sink,
+	// the dml events whose commitTs is less than it have been written to downstream, so they are skipped.
+	dmlAppliedTs uint64
 	// componentStatus is the status of the dispatcher, such as working, removing, stopped.
 	componentStatus *ComponentStateWithMutex
-	// the config of filter
-	filterConfig *eventpb.FilterConfig
+	// the config of filter, it can be updated when the changefeed config is hot reloaded.
+	filterConfig atomic.Pointer[eventpb.FilterConfig]
+	// configBarrier is not nil when the changefeed config is hot reloaded,
+	// and the dispatcher has not passed the barrier of the new config yet.
+	configBarrierMu sync.Mutex
+	configBarrier   *configBarrier
+	// upstreamID is the cluster ID of the upstream the dispatcher reads from,
+	// it's 0 for the default upstream.
+	upstreamID uint64
+	// bdrMode is true if the changefeed is in BDR mode,
+	// the events written by TiCDC itself are filtered out by TiKV.
+	bdrMode bool
+	// highPriority is true if the changefeed is latency critical,
+	// the event service prefers the scan tasks of the dispatcher.
+	highPriority bool
+	// bdrRole is the role of the upstream cluster in BDR mode, it's updated when the config is hot-reloaded.
+	// The ddls not safe in BDR mode are skipped in the primary cluster and blocked in the secondary cluster.
+	bdrRole atomic.Value
 
 	// tableInfo is the latest table info of the dispatcher's corresponding table.
 	tableInfo *common.TableInfo
 
 	// shared by the event dispatcher manager
 	sink sink.Sink
+	// redoSink is nil if redo log is not enabled.
+	// If it's not nil, the events are written to the redo log before they are sent to the sink.
+	// shared by the event dispatcher manager
+	redoSink *sink.RedoSink
 
 	// blockStatusesChan use to collector block status of ddl/sync point event to Maintainer
 	// shared by the event dispatcher manager
@@ -125,6 +156,12 @@ type Dispatcher struct {
 	// blockEventStatus is used to store the current pending ddl/sync point event and its block status.
 	blockEventStatus BlockEventStatus
 
+	// redoBlockEvent is the block event waiting for the previous events to be flushed to the downstream
+	// and the global redo resolvedTs to reach its commitTs, it's only used when redo log is enabled.
+	redoBlockEventMu       sync.Mutex
+	redoBlockEvent         commonEvent.BlockEvent
+	redoBlockEventResolved bool
+
 	// tableProgress is used to calculate the checkpointTs of the dispatcher
 	tableProgress *TableProgress
 
@@ -151,6 +188,7 @@ func NewDispatcher(
 	id common.DispatcherID,
 	tableSpan *heartbeatpb.TableSpan,
 	sink sink.Sink,
+	redoSink *sink.RedoSink,
 	startTs uint64,
 	blockStatusesChan chan *heartbeatpb.TableSpanBlockStatus,
 	schemaID int64,
@@ -158,6 +196,9 @@ func NewDispatcher(
 	syncPointConfig *syncpoint.SyncPointConfig,
 	startTsIsSyncpoint bool,
 	filterConfig *eventpb.FilterConfig,
+	upstreamID uint64,
+	bdrMode bool,
+	highPriority bool,
 	currentPdTs uint64,
 	errCh chan error,
 ) *Dispatcher {
@@ -166,13 +207,13 @@ func NewDispatcher(
 		id:                    id,
 		tableSpan:             tableSpan,
 		sink:                  sink,
+		redoSink:              redoSink,
 		startTs:               startTs,
 		startTsIsSyncpoint:    startTsIsSyncpoint,
 		blockStatusesChan:     blockStatusesChan,
 		syncPointConfig:       syncPointConfig,
 		componentStatus:       newComponentStateWithMutex(heartbeatpb.ComponentState_Working),
 		resolvedTs:            startTs,
-		filterConfig:          filterConfig,
 		isRemoving:            atomic.Bool{},
 		blockEventStatus:      BlockEventStatus{blockPendingEvent: nil},
 		tableProgress:         NewTableProgress(),
@@ -181,8 +222,16 @@ func NewDispatcher(
 		resendTaskMap:         newResendTaskMap(),
 		creationPDTs:          currentPdTs,
 		errCh:                 errCh,
+		upstreamID:            upstreamID,
+		bdrMode:               bdrMode,
+		highPriority:          highPriority,
 	}
 
+	dispatcher.filterConfig.Store(filterConfig)
+
+	if redoSink != nil {
+		redoSink.AddDispatcher(id, startTs)
+	}
 	dispatcher.addToStatusDynamicStream()
 
 	return dispatcher
@@ -223,8 +272,9 @@ func (d *Dispatcher) HandleDispatcherStatus(dispatcherStatus *heartbeatpb.Dispat
 	ack := dispatcherStatus.GetAck()
 	if ack != nil {
 		identifier := BlockEventIdentifier{
-			CommitTs:    ack.CommitTs,
-			IsSyncPoint: ack.IsSyncPoint,
+			CommitTs:        ack.CommitTs,
+			IsSyncPoint:     ack.IsSyncPoint,
+			IsConfigBarrier: ack.IsConfigBarrier,
 		}
 		d.cancelResendTask(identifier)
 	}
@@ -276,10 +326,11 @@ func (d *Dispatcher) HandleDispatcherStatus(dispatcherStatus *heartbeatpb.Dispat
 		d.blockStatusesChan <- &heartbeatpb.TableSpanBlockStatus{
 			ID: d.id.ToPB(),
 			State: &heartbeatpb.State{
-				IsBlocked:   true,
-				BlockTs:     dispatcherStatus.GetAction().CommitTs,
-				IsSyncPoint: dispatcherStatus.GetAction().IsSyncPoint,
-				Stage:       heartbeatpb.BlockStage_DONE,
+				IsBlocked:       true,
+				BlockTs:         dispatcherStatus.GetAction().CommitTs,
+				IsSyncPoint:     dispatcherStatus.GetAction().IsSyncPoint,
+				IsConfigBarrier: dispatcherStatus.GetAction().IsConfigBarrier,
+				Stage:           heartbeatpb.BlockStage_DONE,
 			},
 		}
 	}
@@ -294,6 +345,18 @@ func (d *Dispatcher) HandleDispatcherStatus(dispatcherStatus *heartbeatpb.Dispat
 func (d *Dispatcher) HandleEvents(dispatcherEvents []DispatcherEvent, wakeCallback func()) (block bool) {
 	// Only return false when all events are resolvedTs Event.
 	block = false
+	configBarrierTs := d.getConfigBarrierTs()
+	hasDML := false
+	if d.redoSink != nil {
+		// The dml events are sent to the sink only after the global redo resolvedTs reaches them,
+		// which needs the dispatcher to handle the following resolvedTs events.
+		// So the dynamic stream is woken after the dml events are persisted in the redo log.
+		defer func() {
+			if hasDML {
+				d.redoSink.AddFlushCallback(wakeCallback)
+			}
+		}()
+	}
 	// Dispatcher is ready, handle the events
 	for _, dispatcherEvent := range dispatcherEvents {
 		log.Debug("dispatcher receive all event",
@@ -316,15 +379,39 @@ func (d *Dispatcher) HandleEvents(dispatcherEvents []DispatcherEvent, wakeCallba
 			continue
 		}
 
+		// The events after the config barrier must be handled by the new config,
+		// so the dispatcher drops them and blocks at the barrier until the maintainer makes
+		// all the dispatchers pass it. The dropped events are received again after that.
+		if configBarrierTs != 0 && event.GetCommitTs() > configBarrierTs {
+			if block {
+				// Block at the barrier after the previous dml events are flushed.
+				return block
+			}
+			d.blockAtConfigBarrier(configBarrierTs, wakeCallback)
+			return true
+		}
+
 		switch event.GetType() {
 		case commonEvent.TypeResolvedEvent:
-			atomic.StoreUint64(&d.resolvedTs, event.(commonEvent.ResolvedEvent).ResolvedTs)
+			resolvedTs := event.(commonEvent.ResolvedEvent).ResolvedTs
+			atomic.StoreUint64(&d.resolvedTs, resolvedTs)
+			if d.redoSink != nil {
+				d.redoSink.AddResolvedTs(d.id, resolvedTs)
+			}
 		case commonEvent.TypeDMLEvent:
 			dml := event.(*commonEvent.DMLEvent)
 			if dml.Len() == 0 {
 				return block
 			}
+			if dml.CommitTs < d.dmlAppliedTs {
+				log.Debug("dml event has been written to downstream, skip it",
+					zap.Stringer("dispatcher", d.id),
+					zap.Uint64("commitTs", dml.CommitTs),
+					zap.Uint64("dmlAppliedTs", d.dmlAppliedTs))
+				continue
+			}
 			block = true
+			hasDML = true
 			dml.ReplicatingTs = d.creationPDTs
 			dml.AssembleRows(d.tableInfo)
 			dml.AddPostFlushFunc(func() {
@@ -332,6 +419,10 @@ func (d *Dispatcher) HandleEvents(dispatcherEvents []DispatcherEvent, wakeCallba
 				// thus, we use tableProgress.Empty() to ensure these events are flushed to downstream completely
 				// and wake dynamic stream to handle the next events.
 				if d.tableProgress.Empty() {
+					if d.redoSink != nil {
+						d.tryDealWithRedoBlockEvent()
+						return
+					}
 					wakeCallback()
 				}
 			})
@@ -372,7 +463,7 @@ func (d *Dispatcher) HandleEvents(dispatcherEvents []DispatcherEvent, wakeCallba
 				}
 				wakeCallback()
 			})
-			d.dealWithBlockEvent(ddl)
+			d.handleBlockEvent(ddl)
 		case commonEvent.TypeSyncPointEvent:
 			if len(dispatcherEvents) != 1 {
 				log.Panic("sync point event should only be singly handled", zap.Any("dispatcherID", d.id))
@@ -386,7 +477,7 @@ func (d *Dispatcher) HandleEvents(dispatcherEvents []DispatcherEvent, wakeCallba
 			syncPoint.AddPostFlushFunc(func() {
 				wakeCallback()
 			})
-			d.dealWithBlockEvent(syncPoint)
+			d.handleBlockEvent(syncPoint)
 		case commonEvent.TypeHandshakeEvent:
 			log.Warn("Receive handshake event unexpectedly",
 				zap.Stringer("dispatcher", d.id), zap.Any("event", event))
@@ -402,10 +493,31 @@ func (d *Dispatcher) HandleEvents(dispatcherEvents []DispatcherEvent, wakeCallba
 
 func (d *Dispatcher) AddDMLEventToSink(event *commonEvent.DMLEvent) {
 	d.tableProgress.Add(event)
+	if d.redoSink != nil {
+		// the event is sent to the sink after it's persisted in the redo log.
+		d.redoSink.AddDMLEvent(event, func() {
+			d.sink.AddDMLEvent(event)
+		})
+		return
+	}
 	d.sink.AddDMLEvent(event)
 }
 
 func (d *Dispatcher) AddBlockEventToSink(event commonEvent.BlockEvent) error {
+	if event.GetType() == commonEvent.TypeConfigBarrierEvent {
+		// There is nothing to write to the downstream for the config barrier event.
+		d.PassBlockEventToSink(event)
+		return nil
+	}
+	if d.GetBDRRole() == config.BDRRolePrimary && isBDRUnsafeDDL(event) {
+		log.Warn("skip the ddl which is not safe in the primary cluster of bdr mode",
+			zap.Stringer("changefeedID", d.changefeedID),
+			zap.Stringer("dispatcherID", d.id),
+			zap.String("query", event.(*commonEvent.DDLEvent).Query),
+			zap.Uint64("commitTs", event.GetCommitTs()))
+		d.PassBlockEventToSink(event)
+		return nil
+	}
 	d.tableProgress.Add(event)
 	return d.sink.WriteBlockEvent(event)
 }
@@ -415,6 +527,15 @@ func (d *Dispatcher) PassBlockEventToSink(event commonEvent.BlockEvent) {
 	d.sink.PassBlockEvent(event)
 }
 
+// SetDMLCheckpoint sets the dml progress recorded in the downstream.
+// It must be called before the dispatcher receives any events.
+// The dml events whose commitTs is less than appliedTs are skipped,
+// and the events whose commitTs is larger than replicatingTs are written without safe mode.
+func (d *Dispatcher) SetDMLCheckpoint(appliedTs, replicatingTs uint64) {
+	d.dmlAppliedTs = appliedTs
+	d.creationPDTs = replicatingTs
+}
+
 func (d *Dispatcher) SetInitialTableInfo(tableInfo *common.TableInfo) {
 	if tableInfo == nil {
 		return
@@ -431,6 +552,20 @@ func isCompleteSpan(tableSpan *heartbeatpb.TableSpan) bool {
 	return false
 }
 
+// isBDRUnsafeDDL returns true if the event is a ddl which is not safe to be replicated in BDR mode.
+func isBDRUnsafeDDL(event commonEvent.BlockEvent) bool {
+	ddl, ok := event.(*commonEvent.DDLEvent)
+	return ok && !filter.IsBDRSafeDDL(timodel.ActionType(ddl.Type))
+}
+
+// getDDLType returns the action type of the ddl event, it returns 0 for the sync point event.
+func getDDLType(event commonEvent.BlockEvent) int32 {
+	if ddl, ok := event.(*commonEvent.DDLEvent); ok {
+		return int32(ddl.Type)
+	}
+	return 0
+}
+
 // shouldBlock check whether the event should be blocked(to wait maintainer response)
 // For the ddl event with more than one blockedTable, it should block.
 // For the ddl event with only one blockedTable, it should block only if the table is not complete span.
@@ -451,11 +586,15 @@ func (d *Dispatcher) shouldBlock(event commonEvent.BlockEvent) bool {
 				// if the table is split, even the blockTable only itself, it should block
 				return true
 			}
+			if d.GetBDRRole() == config.BDRRoleSecondary && isBDRUnsafeDDL(ddlEvent) {
+				// the maintainer blocks the unsafe ddl in the secondary cluster
+				return true
+			}
 			return false
 		case commonEvent.InfluenceTypeDB, commonEvent.InfluenceTypeAll:
 			return true
 		}
-	case commonEvent.TypeSyncPointEvent:
+	case commonEvent.TypeSyncPointEvent, commonEvent.TypeConfigBarrierEvent:
 		return true
 	default:
 		log.Error("invalid event type", zap.Any("eventType", event.GetType()))
@@ -463,6 +602,88 @@ func (d *Dispatcher) shouldBlock(event commonEvent.BlockEvent) bool {
 	return false
 }
 
+// handleBlockEvent deals with the block event when all the previous events are flushed to the downstream.
+// If redo log is enabled, the previous dml events may still wait for the global redo resolvedTs,
+// so the block event is written to the redo log first, and it's dealt with after the global redo
+// resolvedTs reaches it and the previous events are flushed to the downstream.
+func (d *Dispatcher) handleBlockEvent(event commonEvent.BlockEvent) {
+	if d.redoSink == nil {
+		d.dealWithBlockEvent(event)
+		return
+	}
+	if d.mayWriteBlockEvent(event) {
+		if err := d.redoSink.WriteBlockEvent(event); err != nil {
+			select {
+			case d.errCh <- err:
+			default:
+				log.Error("error channel is full, discard error",
+					zap.Any("changefeedID", d.changefeedID.String()),
+					zap.Any("dispatcherID", d.id.String()),
+					zap.Error(err))
+			}
+			return
+		}
+	}
+	// all the events of the dispatcher before the block event are written to the redo log.
+	d.redoSink.AddResolvedTs(d.id, event.GetCommitTs())
+
+	d.redoBlockEventMu.Lock()
+	d.redoBlockEvent = event
+	d.redoBlockEventResolved = false
+	d.redoBlockEventMu.Unlock()
+	d.redoSink.AddReleaseCallback(event.GetCommitTs(), func() {
+		d.redoBlockEventMu.Lock()
+		if d.redoBlockEvent == event {
+			d.redoBlockEventResolved = true
+		}
+		d.redoBlockEventMu.Unlock()
+		d.tryDealWithRedoBlockEvent()
+	})
+}
+
+// tryDealWithRedoBlockEvent deals with the pending redo block event if the global redo resolvedTs
+// reaches it and all the previous events are flushed to the downstream.
+func (d *Dispatcher) tryDealWithRedoBlockEvent() {
+	d.redoBlockEventMu.Lock()
+	event := d.redoBlockEvent
+	if event == nil || !d.redoBlockEventResolved || !d.tableProgress.Empty() || d.isRemoving.Load() {
+		d.redoBlockEventMu.Unlock()
+		return
+	}
+	d.redoBlockEvent = nil
+	d.redoBlockEventResolved = false
+	d.redoBlockEventMu.Unlock()
+	// the ddl may be executed in the downstream synchronously,
+	// don't block the redo sink or the sink which calls the callback.
+	go d.dealWithBlockEvent(event)
+}
+
+// mayWriteBlockEvent returns true if the dispatcher may be chosen to write the block event to the downstream,
+// in which case the event is written to the redo log by it.
+func (d *Dispatcher) mayWriteBlockEvent(event commonEvent.BlockEvent) bool {
+	if event.GetType() != commonEvent.TypeDDLEvent {
+		return false
+	}
+	if d.GetBDRRole() == config.BDRRolePrimary && isBDRUnsafeDDL(event) {
+		return false
+	}
+	if !d.shouldBlock(event) || d.IsTableTriggerEventDispatcher() {
+		return true
+	}
+	// the maintainer chooses the table trigger event dispatcher to write the db-level and all-level ddls,
+	// and the ddls involve the table trigger event dispatcher.
+	blockedTables := event.GetBlockedTables()
+	if blockedTables.InfluenceType != commonEvent.InfluenceTypeNormal {
+		return false
+	}
+	for _, tableID := range blockedTables.TableIDs {
+		if tableID == heartbeatpb.DDLSpan.TableID {
+			return false
+		}
+	}
+	return true
+}
+
 // 1.If the event is a single table DDL, it will be added to the sink for writing to downstream.
 // If the ddl leads to add new tables or drop tables, it should send heartbeat to maintainer
 // 2. If the event is a multi-table DDL / sync point Event, it will generate a TableSpanBlockStatus message with ddl info to send to maintainer.
@@ -533,11 +754,14 @@ func (d *Dispatcher) dealWithBlockEvent(event commonEvent.BlockEvent) {
 				UpdatedSchemas:    commonEvent.ToSchemaIDChangePB(event.GetUpdatedSchemas()), // only exists for rename table and rename tables
 				IsSyncPoint:       event.GetType() == commonEvent.TypeSyncPointEvent,         // sync point event must should block
 				Stage:             heartbeatpb.BlockStage_WAITING,
+				DDLType:           getDDLType(event),
+				IsConfigBarrier:   event.GetType() == commonEvent.TypeConfigBarrierEvent,
 			},
 		}
 		identifier := BlockEventIdentifier{
-			CommitTs:    event.GetCommitTs(),
-			IsSyncPoint: event.GetType() == commonEvent.TypeSyncPointEvent,
+			CommitTs:        event.GetCommitTs(),
+			IsSyncPoint:     event.GetType() == commonEvent.TypeSyncPointEvent,
+			IsConfigBarrier: event.GetType() == commonEvent.TypeConfigBarrierEvent,
 		}
 		d.resendTaskMap.Set(identifier, newResendTask(message, d, nil))
 		d.blockStatusesChan <- message
@@ -624,8 +848,98 @@ func (d *Dispatcher) EnableSyncPoint() bool {
 	return d.syncPointConfig != nil
 }
 
+func (d *Dispatcher) GetUpstreamID() uint64 {
+	return d.upstreamID
+}
+
+func (d *Dispatcher) GetBDRMode() bool {
+	return d.bdrMode
+}
+
+func (d *Dispatcher) IsHighPriority() bool {
+	return d.highPriority
+}
+
 func (d *Dispatcher) GetFilterConfig() *eventpb.FilterConfig {
-	return d.filterConfig
+	return d.filterConfig.Load()
+}
+
+// configBarrier is the barrier to switch the dispatcher to the hot-reloaded changefeed config.
+type configBarrier struct {
+	barrierTs    uint64
+	filterConfig *eventpb.FilterConfig
+	// onPass is called after the dispatcher passes the barrier,
+	// and before it handles the events after the barrier.
+	onPass func(d *Dispatcher, barrierTs uint64)
+}
+
+// SetConfigBarrier makes the dispatcher block at barrierTs, and use the new filter config
+// for the events whose commitTs is larger than barrierTs after it passes the barrier.
+// If there is a pending barrier already, the barrier ts is kept and only the new filter config is used.
+func (d *Dispatcher) SetConfigBarrier(barrierTs uint64, filterConfig *eventpb.FilterConfig, onPass func(d *Dispatcher, barrierTs uint64)) {
+	d.configBarrierMu.Lock()
+	defer d.configBarrierMu.Unlock()
+	if d.configBarrier != nil {
+		barrierTs = d.configBarrier.barrierTs
+	}
+	d.configBarrier = &configBarrier{
+		barrierTs:    barrierTs,
+		filterConfig: filterConfig,
+		onPass:       onPass,
+	}
+}
+
+func (d *Dispatcher) getConfigBarrierTs() uint64 {
+	d.configBarrierMu.Lock()
+	defer d.configBarrierMu.Unlock()
+	if d.configBarrier == nil {
+		return 0
+	}
+	return d.configBarrier.barrierTs
+}
+
+func (d *Dispatcher) blockAtConfigBarrier(barrierTs uint64, wakeCallback func()) {
+	log.Info("dispatcher block at config barrier",
+		zap.Stringer("dispatcher", d.id),
+		zap.Uint64("barrierTs", barrierTs),
+		zap.Uint64("resolvedTs", d.GetResolvedTs()))
+	event := &commonEvent.ConfigBarrierEvent{
+		DispatcherID: d.id,
+		CommitTs:     barrierTs,
+	}
+	event.AddPostFlushFunc(func() {
+		d.passConfigBarrier(barrierTs)
+		wakeCallback()
+	})
+	d.handleBlockEvent(event)
+}
+
+func (d *Dispatcher) passConfigBarrier(barrierTs uint64) {
+	d.configBarrierMu.Lock()
+	barrier := d.configBarrier
+	if barrier == nil || barrier.barrierTs != barrierTs {
+		d.configBarrierMu.Unlock()
+		return
+	}
+	d.configBarrier = nil
+	d.configBarrierMu.Unlock()
+
+	d.filterConfig.Store(barrier.filterConfig)
+	log.Info("dispatcher pass config barrier",
+		zap.Stringer("dispatcher", d.id),
+		zap.Uint64("barrierTs", barrierTs))
+	if barrier.onPass != nil {
+		barrier.onPass(d, barrierTs)
+	}
+}
+
+func (d *Dispatcher) GetBDRRole() config.BDRRole {
+	role, _ := d.bdrRole.Load().(config.BDRRole)
+	return role
+}
+
+func (d *Dispatcher) SetBDRRole(role config.BDRRole) {
+	d.bdrRole.Store(role)
 }
 
 func (d *Dispatcher) GetSyncPointInterval() time.Duration {
@@ -643,6 +957,9 @@ func (d *Dispatcher) Remove() {
 	log.Info("table event dispatcher component status changed to stopping",
 		zap.String("table", d.tableSpan.String()))
 	d.isRemoving.Store(true)
+	if d.redoSink != nil {
+		d.redoSink.RemoveDispatcher(d.id)
+	}
 
 	dispatcherStatusDS := GetDispatcherStatusDynamicStream()
 	err := dispatcherStatusDS.RemovePath(d.id)
@@ -663,7 +980,7 @@ func (d *Dispatcher) addToStatusDynamicStream() {
 func (d *Dispatcher) TryClose() (w heartbeatpb.Watermark, ok bool) {
 	// If sink is normal(not meet error), we need to wait all the events in sink to flushed downstream successfully.
 	// If sink is not normal, we can close the dispatcher immediately.
-	if !d.sink.IsNormal() || d.tableProgress.Empty() {
+	if !d.sink.IsNormal() || (d.redoSink != nil && !d.redoSink.IsNormal()) || d.tableProgress.Empty() {
 		w.CheckpointTs = d.GetCheckpointTs()
 		w.ResolvedTs = d.GetResolvedTs()
 
@@ -708,6 +1025,7 @@ func (d *Dispatcher) GetBlockEventStatus() *heartbeatpb.State {
 		UpdatedSchemas:    commonEvent.ToSchemaIDChangePB(pendingEvent.GetUpdatedSchemas()), // only exists for rename table and rename tables
 		IsSyncPoint:       pendingEvent.GetType() == commonEvent.TypeSyncPointEvent,         // sync point event must should block
 		Stage:             blockStage,
+		IsConfigBarrier:   pendingEvent.GetType() == commonEvent.TypeConfigBarrierEvent,
 	}
 }
 
@@ -15,14 +15,19 @@ package dispatcher
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/pingcap/ticdc/downstreamadapter/sink"
 	"github.com/pingcap/ticdc/downstreamadapter/syncpoint"
+	"github.com/pingcap/ticdc/eventpb"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/node"
 	sinkutil "github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/ticdc/pkg/spanz"
@@ -32,12 +37,15 @@ import (
 // TODO: Merge this file into dispatcher_test.go after refactoring the dispatcher test.
 
 type mockSink struct {
+	mu       sync.Mutex
 	dmls     []*commonEvent.DMLEvent
 	isNormal bool
 	sinkType common.SinkType
 }
 
 func (s *mockSink) AddDMLEvent(event *commonEvent.DMLEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.dmls = append(s.dmls, event)
 }
 
@@ -56,6 +64,10 @@ func (s *mockSink) AddCheckpointTs(ts uint64) {
 func (s *mockSink) SetTableSchemaStore(tableSchemaStore *sinkutil.TableSchemaStore) {
 }
 
+func (s *mockSink) UpdateConfig(*config.ChangefeedConfig, uint64) error {
+	return nil
+}
+
 func (s *mockSink) Close(bool) {}
 
 func (s *mockSink) Run(context.Context) error {
@@ -71,10 +83,19 @@ func (s *mockSink) IsNormal() bool {
 }
 
 func (s *mockSink) flushDMLs() {
-	for _, dml := range s.dmls {
+	s.mu.Lock()
+	dmls := s.dmls
+	s.dmls = make([]*commonEvent.DMLEvent, 0)
+	s.mu.Unlock()
+	for _, dml := range dmls {
 		dml.PostFlush()
 	}
-	s.dmls = make([]*commonEvent.DMLEvent, 0)
+}
+
+func (s *mockSink) dmlCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.dmls)
 }
 
 func newMockSink(sinkType common.SinkType) *mockSink {
@@ -106,11 +127,16 @@ func getUncompleteTableSpan() *heartbeatpb.TableSpan {
 }
 
 func newDispatcherForTest(sink sink.Sink, tableSpan *heartbeatpb.TableSpan) *Dispatcher {
+	return newDispatcherWithRedoForTest(sink, nil, tableSpan)
+}
+
+func newDispatcherWithRedoForTest(sink sink.Sink, redoSink *sink.RedoSink, tableSpan *heartbeatpb.TableSpan) *Dispatcher {
 	return NewDispatcher(
 		common.NewChangefeedID(),
 		common.NewDispatcherID(),
 		tableSpan,
 		sink,
+		redoSink,
 		common.Ts(0), // startTs
 		make(chan *heartbeatpb.TableSpanBlockStatus, 128),
 		1, // schemaID
@@ -121,6 +147,9 @@ func newDispatcherForTest(sink sink.Sink, tableSpan *heartbeatpb.TableSpan) *Dis
 		}, // syncPointConfig
 		false,
 		nil,          // filterConfig
+		0,            // upstreamID
+		false,        // bdrMode
+		false,        // highPriority
 		common.Ts(0), // pdTs
 		make(chan error, 1),
 	)
@@ -708,3 +737,192 @@ func TestDispatcherClose(t *testing.T) {
 		require.Equal(t, uint64(0), watermark.ResolvedTs)
 	}
 }
+
+// ensure the dml events which have been written to downstream are skipped
+func TestDispatcherSkipAppliedDMLEvents(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	ddlJob := helper.DDL2Job("create table t(id int primary key, v int)")
+	require.NotNil(t, ddlJob)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values(1, 1)")
+	require.NotNil(t, dmlEvent)
+	dmlEvent.CommitTs = 2
+	dmlEvent.Length = 1
+	dmlEvent2 := helper.DML2Event("test", "t", "insert into t values(2, 2)")
+	require.NotNil(t, dmlEvent2)
+	dmlEvent2.CommitTs = 3
+	dmlEvent2.Length = 1
+
+	sink := newMockSink(common.MysqlSinkType)
+	dispatcher := newDispatcherForTest(sink, getCompleteTableSpan())
+	dispatcher.SetInitialTableInfo(dmlEvent.TableInfo)
+	dispatcher.SetDMLCheckpoint(3, 5)
+
+	nodeID := node.NewID()
+	block := dispatcher.HandleEvents([]DispatcherEvent{
+		NewDispatcherEvent(&nodeID, dmlEvent),
+		NewDispatcherEvent(&nodeID, dmlEvent2),
+	}, callback)
+	require.True(t, block)
+	// the event whose commitTs equals to the applied ts may be a part of a transaction, so it's not skipped.
+	require.Len(t, sink.dmls, 1)
+	require.Equal(t, uint64(3), sink.dmls[0].CommitTs)
+	require.Equal(t, uint64(5), sink.dmls[0].ReplicatingTs)
+}
+
+// test the dispatcher blocks at the config barrier until the maintainer acks it,
+// and switches to the new filter config after passing it.
+func TestDispatcherConfigBarrier(t *testing.T) {
+	count = 0
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	ddlJob := helper.DDL2Job("create table t(id int primary key, v int)")
+	require.NotNil(t, ddlJob)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values(1, 1)")
+	require.NotNil(t, dmlEvent)
+	dmlEvent.CommitTs = 2
+	dmlEvent.Length = 1
+
+	sink := newMockSink(common.MysqlSinkType)
+	dispatcher := newDispatcherForTest(sink, getCompleteTableSpan())
+	dispatcher.SetInitialTableInfo(dmlEvent.TableInfo)
+
+	var passedTs uint64
+	filterConfig := &eventpb.FilterConfig{CaseSensitive: true}
+	dispatcher.SetConfigBarrier(5, filterConfig, func(_ *Dispatcher, barrierTs uint64) {
+		passedTs = barrierTs
+	})
+	// a later barrier doesn't move the pending one
+	dispatcher.SetConfigBarrier(8, filterConfig, func(_ *Dispatcher, barrierTs uint64) {
+		passedTs = barrierTs
+	})
+
+	// the events before the barrier are not blocked by it
+	nodeID := node.NewID()
+	block := dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, dmlEvent)}, callback)
+	require.True(t, block)
+	require.Len(t, sink.dmls, 1)
+	sink.flushDMLs()
+	require.Equal(t, 1, count)
+
+	// the event after the barrier blocks the dispatcher
+	resolvedEvent := commonEvent.ResolvedEvent{ResolvedTs: 7}
+	block = dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, resolvedEvent)}, callback)
+	require.True(t, block)
+	require.Equal(t, uint64(0), dispatcher.GetResolvedTs())
+	require.NotNil(t, dispatcher.blockEventStatus.blockPendingEvent)
+	require.Equal(t, heartbeatpb.BlockStage_WAITING, dispatcher.blockEventStatus.blockStage)
+
+	blockStatus := <-dispatcher.blockStatusesChan
+	require.True(t, blockStatus.State.IsBlocked)
+	require.True(t, blockStatus.State.IsConfigBarrier)
+	require.Equal(t, uint64(5), blockStatus.State.BlockTs)
+	require.Equal(t, heartbeatpb.BlockStage_WAITING, blockStatus.State.Stage)
+
+	// the maintainer selects the dispatcher to write the barrier
+	dispatcher.HandleDispatcherStatus(&heartbeatpb.DispatcherStatus{
+		Action: &heartbeatpb.DispatcherAction{
+			Action:          heartbeatpb.Action_Write,
+			CommitTs:        5,
+			IsConfigBarrier: true,
+		},
+	})
+	require.Equal(t, uint64(5), passedTs)
+	require.Equal(t, 2, count)
+	require.Equal(t, uint64(0), dispatcher.getConfigBarrierTs())
+	require.Equal(t, filterConfig, dispatcher.GetFilterConfig())
+	require.Nil(t, dispatcher.blockEventStatus.blockPendingEvent)
+
+	blockStatus = <-dispatcher.blockStatusesChan
+	require.True(t, blockStatus.State.IsConfigBarrier)
+	require.Equal(t, heartbeatpb.BlockStage_DONE, blockStatus.State.Stage)
+
+	// the events are not blocked anymore after passing the barrier
+	block = dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, resolvedEvent)}, callback)
+	require.False(t, block)
+	require.Equal(t, uint64(7), dispatcher.GetResolvedTs())
+}
+
+// test the events are written to the sink only after the global redo resolvedTs reaches them,
+// when the redo progress of the tables are different.
+func TestDispatcherWaitGlobalRedoResolvedTs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table t1(id int primary key, v int)")
+	helper.DDL2Job("create table t2(id int primary key, v int)")
+	dmlEvent := helper.DML2Event("test", "t1", "insert into t1 values(1, 1)")
+	dmlEvent.CommitTs = 10
+	dmlEvent.Length = 1
+	tableInfo2 := helper.GetTableInfo(helper.DDL2Job("create table t3(id int primary key)"))
+
+	redoSink, err := sink.NewRedoSink(ctx, common.NewChangefeedID(), &config.ConsistentConfig{
+		Level:                 "eventual",
+		MaxLogSize:            1,
+		MetaFlushIntervalInMs: 50,
+		Storage:               fmt.Sprintf("file://%s", t.TempDir()),
+	}, 0)
+	require.NoError(t, err)
+	go redoSink.Run(ctx)
+	defer redoSink.Close(false)
+
+	mockSink := newMockSink(common.MysqlSinkType)
+	dispatcher1 := newDispatcherWithRedoForTest(mockSink, redoSink, getCompleteTableSpanWithTableID(1))
+	dispatcher1.SetInitialTableInfo(dmlEvent.TableInfo)
+	dispatcher2 := newDispatcherWithRedoForTest(mockSink, redoSink, getCompleteTableSpanWithTableID(2))
+	// updateGlobalResolvedTs does what the maintainer does.
+	updateGlobalResolvedTs := func(expected uint64) {
+		require.Eventually(t, func() bool {
+			resolvedTs, ok := redoSink.GetResolvedTs()
+			return ok && resolvedTs == expected
+		}, 5*time.Second, 10*time.Millisecond)
+		redoSink.UpdateGlobalResolvedTs(expected)
+	}
+
+	var woken atomic.Int32
+	wake := func() { woken.Add(1) }
+	nodeID := node.NewID()
+	// the redo log of table 1 is ahead of table 2.
+	block := dispatcher1.HandleEvents([]DispatcherEvent{
+		NewDispatcherEvent(&nodeID, dmlEvent),
+		NewDispatcherEvent(&nodeID, commonEvent.ResolvedEvent{ResolvedTs: 20}),
+	}, wake)
+	require.True(t, block)
+	// the dispatcher is woken after the dml event is persisted in the redo log.
+	require.Eventually(t, func() bool { return woken.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+	dispatcher2.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, commonEvent.ResolvedEvent{ResolvedTs: 5})}, wake)
+	updateGlobalResolvedTs(5)
+	time.Sleep(200 * time.Millisecond)
+	require.Equal(t, 0, mockSink.dmlCount())
+
+	// the ddl of table 2 waits for the global redo resolvedTs too.
+	ddlEvent := &commonEvent.DDLEvent{
+		FinishedTs: 15,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{2},
+		},
+		TableInfo: tableInfo2,
+	}
+	block = dispatcher2.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, ddlEvent)}, wake)
+	require.True(t, block)
+	require.Equal(t, int32(1), woken.Load())
+
+	updateGlobalResolvedTs(15)
+	require.Eventually(t, func() bool { return mockSink.dmlCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	// the ddl is written after the previous events are flushed.
+	require.Eventually(t, func() bool { return woken.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+	mockSink.flushDMLs()
+	checkpointTs, isEmpty := dispatcher1.tableProgress.GetCheckpointTs()
+	require.True(t, isEmpty)
+	require.Equal(t, uint64(9), checkpointTs)
+}
@@ -63,11 +63,12 @@ func (r *ResendTaskMap) Len() int {
 	return len(r.m)
 }
 
-// Considering the sync point event and ddl event may have the same commitTs,
+// Considering the sync point event, config barrier event and ddl event may have the same commitTs,
 // we need to distinguish them.
 type BlockEventIdentifier struct {
-	CommitTs    uint64
-	IsSyncPoint bool
+	CommitTs        uint64
+	IsSyncPoint     bool
+	IsConfigBarrier bool
 }
 
 type BlockEventStatus struct {
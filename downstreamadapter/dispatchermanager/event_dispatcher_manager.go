@@ -15,6 +15,8 @@ package dispatchermanager
 
 import (
 	"context"
+	"math"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -27,6 +29,7 @@ import (
 	"github.com/pingcap/ticdc/downstreamadapter/syncpoint"
 	"github.com/pingcap/ticdc/eventpb"
 	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/upstreamstore"
 	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
@@ -35,6 +38,8 @@ import (
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
+	"github.com/pingcap/tiflow/pkg/redo"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
@@ -61,11 +66,20 @@ type EventDispatcherManager struct {
 	maintainerID      node.ID
 
 	pdClock pdutil.Clock
+	// upstreamID is the id of the non-default upstream whose stores are
+	// acquired by the manager, it's 0 for the default upstream.
+	upstreamID uint64
 
+	// configMutex protects config, filterConfig, pendingConfig and configBarrierTs,
+	// they can be updated by UpdateConfig when the changefeed config is hot-reloaded.
+	configMutex  sync.RWMutex
 	config       *config.ChangefeedConfig
 	filterConfig *eventpb.FilterConfig
+	// pendingConfig is the hot-reloaded config which has not taken effect yet,
+	// it takes effect when the dispatchers pass the config barrier at configBarrierTs.
+	pendingConfig   *config.ChangefeedConfig
+	configBarrierTs uint64
 	// only not nil when enable sync point
-	// TODO: changefeed update config
 	syncPointConfig *syncpoint.SyncPointConfig
 
 	// tableTriggerEventDispatcher is a special dispatcher, that is responsible for helping handling ddl events.
@@ -95,6 +109,9 @@ type EventDispatcherManager struct {
 
 	// sink is used to send all the events to the downstream.
 	sink sink.Sink
+	// redoSink is used to write all the events to the redo log before they are sent to the sink.
+	// it's nil if redo log is not enabled.
+	redoSink *sink.RedoSink
 
 	latestWatermark Watermark
 
@@ -125,22 +142,59 @@ func NewEventDispatcherManager(
 	startTs uint64,
 	maintainerID node.ID,
 	newChangefeed bool,
-) (*EventDispatcherManager, uint64, error) {
+) (_ *EventDispatcherManager, _ uint64, err error) {
 	failpoint.Inject("NewEventDispatcherManagerDelay", nil)
 
 	ctx, cancel := context.WithCancel(context.Background())
-	pdClock := appcontext.GetService[pdutil.Clock](appcontext.DefaultPDClock)
+	var (
+		upstreamID           uint64
+		upstreamStoreManager *upstreamstore.Manager
+	)
+	// release the resources acquired so far if the manager fails to be created
+	defer func() {
+		if err != nil {
+			cancel()
+			if upstreamID != 0 {
+				upstreamStoreManager.ReleaseStores(upstreamID)
+			}
+		}
+	}()
+	// The dispatchers read events from the local event service,
+	// so make sure the log service of the upstream is running on this node.
+	// In BDR mode, the source ID of the upstream is also needed by the mysql sink
+	// to tag its writes, so that they are not replicated back to the upstream.
+	if cfConfig.UpstreamInfo != nil || cfConfig.BDRMode {
+		upstreamStoreManager = appcontext.GetService[*upstreamstore.Manager](appcontext.UpstreamStoreManager)
+		var stores *upstreamstore.Stores
+		stores, err = upstreamStoreManager.GetOrAddStores(ctx, cfConfig.UpstreamInfo)
+		if err != nil {
+			return nil, 0, errors.Trace(err)
+		}
+		if cfConfig.UpstreamInfo != nil {
+			upstreamID = cfConfig.UpstreamInfo.ID
+		}
+		if cfConfig.BDRMode {
+			var sourceID uint64
+			sourceID, err = pdutil.GetSourceID(ctx, stores.PDClient)
+			if err != nil {
+				return nil, 0, errors.Trace(err)
+			}
+			cfConfig.SinkConfig.TiDBSourceID = sourceID
+		}
+	}
+	pdClock := appcontext.GetService[pdutil.Clock](appcontext.PDClockName(cfConfig.UpstreamID))
 	manager := &EventDispatcherManager{
 		dispatcherMap:                          newDispatcherMap(),
 		changefeedID:                           changefeedID,
 		maintainerID:                           maintainerID,
 		pdClock:                                pdClock,
+		upstreamID:                             upstreamID,
 		statusesChan:                           make(chan TableSpanStatusWithSeq, 8192),
 		blockStatusesChan:                      make(chan *heartbeatpb.TableSpanBlockStatus, 1024*1024),
 		errCh:                                  make(chan error, 1),
 		cancel:                                 cancel,
 		config:                                 cfConfig,
-		filterConfig:                           &eventpb.FilterConfig{CaseSensitive: cfConfig.CaseSensitive, ForceReplicate: cfConfig.ForceReplicate, FilterConfig: toFilterConfigPB(cfConfig.Filter)},
+		filterConfig:                           newFilterConfigPB(cfConfig),
 		schemaIDToDispatchers:                  dispatcher.NewSchemaIDToDispatchers(),
 		latestWatermark:                        NewWatermark(startTs),
 		metricTableTriggerEventDispatcherCount: metrics.TableTriggerEventDispatcherGauge.WithLabelValues(changefeedID.Namespace(), changefeedID.Name()),
@@ -161,11 +215,17 @@ func NewEventDispatcherManager(
 		}
 	}
 
-	var err error
 	manager.sink, err = sink.NewSink(ctx, manager.config, manager.changefeedID)
 	if err != nil {
 		return nil, 0, errors.Trace(err)
 	}
+	if cfConfig.Consistent != nil && redo.IsConsistentEnabled(cfConfig.Consistent.Level) {
+		manager.redoSink, err = sink.NewRedoSink(ctx, manager.changefeedID, cfConfig.Consistent, startTs)
+		if err != nil {
+			manager.sink.Close(false)
+			return nil, 0, errors.Trace(err)
+		}
+	}
 
 	// Register Event Dispatcher Manager in HeartBeatCollector,
 	// which is responsible for communication with the maintainer.
@@ -186,7 +246,7 @@ func NewEventDispatcherManager(
 	manager.wg.Add(1)
 	go func() {
 		defer manager.wg.Done()
-		err = manager.sink.Run(ctx)
+		err := manager.sink.Run(ctx)
 		if err != nil && !errors.Is(errors.Cause(err), context.Canceled) {
 			select {
 			case <-ctx.Done():
@@ -201,6 +261,26 @@ func NewEventDispatcherManager(
 		}
 	}()
 
+	if manager.redoSink != nil {
+		manager.wg.Add(1)
+		go func() {
+			defer manager.wg.Done()
+			err := manager.redoSink.Run(ctx)
+			if err != nil && !errors.Is(errors.Cause(err), context.Canceled) {
+				select {
+				case <-ctx.Done():
+					return
+				case manager.errCh <- err:
+				default:
+					log.Error("error channel is full, discard error",
+						zap.Stringer("changefeedID", changefeedID),
+						zap.Error(err),
+					)
+				}
+			}
+		}()
+	}
+
 	// collect errors from error channel
 	manager.wg.Add(1)
 	go func() {
@@ -305,8 +385,14 @@ func (e *EventDispatcherManager) close(removeChangefeed bool) {
 	}
 
 	e.sink.Close(removeChangefeed)
+	if e.redoSink != nil {
+		e.redoSink.Close(removeChangefeed)
+	}
 	e.cancel()
 	e.wg.Wait()
+	if e.upstreamID != 0 {
+		appcontext.GetService[*upstreamstore.Manager](appcontext.UpstreamStoreManager).ReleaseStores(e.upstreamID)
+	}
 
 	metrics.TableTriggerEventDispatcherGauge.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
 	metrics.EventDispatcherGauge.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
@@ -359,7 +445,10 @@ func (e *EventDispatcherManager) InitalizeTableTriggerEventDispatcher(schemaInfo
 		return errors.Trace(err)
 	}
 	// table trigger event dispatcher can register to event collector to receive events after finish the initial table schema store from the maintainer.
-	appcontext.GetService[*eventcollector.EventCollector](appcontext.EventCollector).AddDispatcher(e.tableTriggerEventDispatcher, int(e.config.MemoryQuota))
+	e.configMutex.RLock()
+	memoryQuota := e.config.MemoryQuota
+	e.configMutex.RUnlock()
+	appcontext.GetService[*eventcollector.EventCollector](appcontext.EventCollector).AddDispatcher(e.tableTriggerEventDispatcher, int(memoryQuota))
 
 	// when sink is not mysql-class, table trigger event dispatcher need to receive the checkpointTs message from maintainer.
 	if e.sink.SinkType() != common.MysqlSinkType {
@@ -424,10 +513,34 @@ func (e *EventDispatcherManager) newDispatchers(infos []dispatcherCreateInfo, re
 		newStartTsList = startTsList
 	}
 
+	// When the dml checkpoint is enabled, the dispatchers skip the dml events which have been written to downstream.
+	var dmlCheckpoints []mysql.DMLCheckpoint
+	if e.sink.SinkType() == common.MysqlSinkType {
+		dmlCheckpoints, err = e.sink.(*sink.MysqlSink).GetDMLCheckpoints(dispatcherIds, tableSpans, pdTsList)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	// The dispatchers start after the config barrier use the pending config directly,
+	// so it must take effect before they are created.
+	e.configMutex.Lock()
+	if e.pendingConfig != nil && uint64(slices.Max(newStartTsList)) >= e.configBarrierTs {
+		err = e.applyPendingConfig()
+	}
+	e.configMutex.Unlock()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Hold the read lock until all the dispatchers are added to dispatcherMap,
+	// so a concurrent UpdateConfig either applies to them or is seen by them.
+	e.configMutex.RLock()
+	defer e.configMutex.RUnlock()
 	for idx, id := range dispatcherIds {
 		d := dispatcher.NewDispatcher(
 			e.changefeedID,
-			id, tableSpans[idx], e.sink,
+			id, tableSpans[idx], e.sink, e.redoSink,
 			uint64(newStartTsList[idx]),
 			e.blockStatusesChan,
 			schemaIds[idx],
@@ -435,8 +548,18 @@ func (e *EventDispatcherManager) newDispatchers(infos []dispatcherCreateInfo, re
 			e.syncPointConfig,
 			startTsIsSyncpointList[idx],
 			e.filterConfig,
+			e.config.UpstreamID,
+			e.config.BDRMode,
+			e.config.Priority == config.ChangefeedPriorityHigh,
 			pdTsList[idx],
 			e.errCh)
+		d.SetBDRRole(e.config.BDRRole)
+		if e.pendingConfig != nil && uint64(newStartTsList[idx]) < e.configBarrierTs {
+			d.SetConfigBarrier(e.configBarrierTs, newFilterConfigPB(e.pendingConfig), e.onConfigBarrierPassed)
+		}
+		if dmlCheckpoints != nil {
+			d.SetDMLCheckpoint(dmlCheckpoints[idx].AppliedTs, dmlCheckpoints[idx].ReplicatingTs)
+		}
 
 		if e.heartBeatTask == nil {
 			e.heartBeatTask = newHeartBeatTask(e)
@@ -616,6 +739,7 @@ func (e *EventDispatcherManager) collectComponentStatusWhenChanged(ctx context.C
 			message.ChangefeedID = e.changefeedID.ToPB()
 			message.Statuses = statusMessage
 			message.Watermark = newWatermark
+			message.RedoResolvedTs = e.getRedoResolvedTs()
 			e.heartbeatRequestQueue.Enqueue(&HeartBeatRequestWithTargetID{TargetID: e.GetMaintainerID(), Request: &message})
 		}
 	}
@@ -634,9 +758,10 @@ func (e *EventDispatcherManager) collectComponentStatusWhenChanged(ctx context.C
 // Returns a HeartBeatRequest containing the aggregated information.
 func (e *EventDispatcherManager) aggregateDispatcherHeartbeats(needCompleteStatus bool) *heartbeatpb.HeartBeatRequest {
 	message := heartbeatpb.HeartBeatRequest{
-		ChangefeedID:    e.changefeedID.ToPB(),
-		CompeleteStatus: needCompleteStatus,
-		Watermark:       heartbeatpb.NewMaxWatermark(),
+		ChangefeedID:     e.changefeedID.ToPB(),
+		CompeleteStatus:  needCompleteStatus,
+		Watermark:        heartbeatpb.NewMaxWatermark(),
+		MemoryUsageRatio: float32(appcontext.GetService[*eventcollector.EventCollector](appcontext.EventCollector).GetMemoryUsageRatio()),
 	}
 
 	toRemoveDispatcherIDs := make([]common.DispatcherID, 0)
@@ -675,7 +800,13 @@ func (e *EventDispatcherManager) aggregateDispatcherHeartbeats(needCompleteStatu
 		}
 	})
 	message.Watermark.Seq = seq
+	message.RedoResolvedTs = e.getRedoResolvedTs()
 	e.latestWatermark.Set(message.Watermark)
+	// all the events before the checkpointTs are flushed to the downstream,
+	// so the redo logs before it are not needed anymore.
+	if e.redoSink != nil && message.Watermark.CheckpointTs != math.MaxUint64 {
+		e.redoSink.AddCheckpointTs(message.Watermark.CheckpointTs)
+	}
 
 	// if the event dispatcher manager is closing, we don't to remove the stopped dispatchers.
 	if !e.closing.Load() {
@@ -696,6 +827,24 @@ func (e *EventDispatcherManager) aggregateDispatcherHeartbeats(needCompleteStatu
 	return &message
 }
 
+// getRedoResolvedTs returns the min redo resolvedTs of the dispatchers,
+// it's 0 if redo log is not enabled or there is no dispatcher.
+func (e *EventDispatcherManager) getRedoResolvedTs() uint64 {
+	if e.redoSink == nil {
+		return 0
+	}
+	resolvedTs, _ := e.redoSink.GetResolvedTs()
+	return resolvedTs
+}
+
+// UpdateRedoResolvedTs updates the global redo resolvedTs of the changefeed calculated by the maintainer.
+func (e *EventDispatcherManager) UpdateRedoResolvedTs(resolvedTs uint64) {
+	if e.redoSink == nil || resolvedTs == 0 {
+		return
+	}
+	e.redoSink.UpdateGlobalResolvedTs(resolvedTs)
+}
+
 func (e *EventDispatcherManager) removeDispatcher(id common.DispatcherID) {
 	dispatcher, ok := e.dispatcherMap.Get(id)
 	if ok {
@@ -738,6 +887,92 @@ func (e *EventDispatcherManager) cleanDispatcher(id common.DispatcherID, schemaI
 	)
 }
 
+// UpdateConfig applies the hot-reloadable part of the changefeed config to the running dispatchers.
+// The memory quota and bdr role take effect at once. The other configs take effect after all the
+// dispatchers of the changefeed pass the config barrier at barrierTs, so the events before barrierTs
+// are handled by the old config and the events after it are handled by the new config.
+// If a config barrier is pending, the new config takes effect at the pending barrier instead.
+func (e *EventDispatcherManager) UpdateConfig(cfConfig *config.ChangefeedConfig, barrierTs uint64) error {
+	e.configMutex.Lock()
+	defer e.configMutex.Unlock()
+
+	if e.pendingConfig == nil && barrierTs <= e.configBarrierTs {
+		// The request is resent by the maintainer, and the config has taken effect.
+		return nil
+	}
+
+	if cfConfig.MemoryQuota != e.config.MemoryQuota {
+		appcontext.GetService[*eventcollector.EventCollector](appcontext.EventCollector).
+			SetChangefeedMemoryQuota(e.changefeedID, int(cfConfig.MemoryQuota))
+	}
+	if cfConfig.BDRRole != e.config.BDRRole {
+		e.dispatcherMap.ForEach(func(id common.DispatcherID, d *dispatcher.Dispatcher) {
+			d.SetBDRRole(cfConfig.BDRRole)
+		})
+	}
+	current := *e.config
+	current.MemoryQuota = cfConfig.MemoryQuota
+	current.BDRRole = cfConfig.BDRRole
+	e.config = &current
+
+	if e.pendingConfig == nil {
+		e.configBarrierTs = barrierTs
+	}
+	e.pendingConfig = cfConfig
+	filterConfig := newFilterConfigPB(cfConfig)
+	e.dispatcherMap.ForEach(func(id common.DispatcherID, d *dispatcher.Dispatcher) {
+		d.SetConfigBarrier(e.configBarrierTs, filterConfig, e.onConfigBarrierPassed)
+	})
+
+	log.Info("event dispatcher manager receive new config",
+		zap.Stringer("changefeedID", e.changefeedID),
+		zap.Uint64("barrierTs", barrierTs),
+		zap.Uint64("configBarrierTs", e.configBarrierTs),
+		zap.Uint64("memoryQuota", cfConfig.MemoryQuota),
+		zap.String("bdrRole", string(cfConfig.BDRRole)))
+	return nil
+}
+
+// onConfigBarrierPassed is called when a dispatcher passes the config barrier.
+// The pending config takes effect when the first dispatcher passes the barrier,
+// at that time all the dispatchers have flushed the events before the barrier.
+func (e *EventDispatcherManager) onConfigBarrierPassed(d *dispatcher.Dispatcher, barrierTs uint64) {
+	e.configMutex.Lock()
+	var err error
+	if e.pendingConfig != nil && e.configBarrierTs == barrierTs {
+		err = e.applyPendingConfig()
+	}
+	e.configMutex.Unlock()
+	if err != nil {
+		select {
+		case e.errCh <- err:
+		default:
+			log.Error("error channel is full, discard error",
+				zap.Stringer("changefeedID", e.changefeedID),
+				zap.Error(err))
+		}
+		return
+	}
+	// The events after the barrier are received again with the new filter config.
+	appcontext.GetService[*eventcollector.EventCollector](appcontext.EventCollector).
+		ResetDispatcher(d, max(barrierTs, d.GetResolvedTs()))
+}
+
+// applyPendingConfig makes the pending config take effect, it must be called with configMutex locked.
+func (e *EventDispatcherManager) applyPendingConfig() error {
+	cfConfig := e.pendingConfig
+	e.pendingConfig = nil
+	e.config = cfConfig
+	e.filterConfig = newFilterConfigPB(cfConfig)
+	if err := e.sink.UpdateConfig(cfConfig, e.configBarrierTs); err != nil {
+		return errors.Trace(err)
+	}
+	log.Info("event dispatcher manager config updated",
+		zap.Stringer("changefeedID", e.changefeedID),
+		zap.Uint64("barrierTs", e.configBarrierTs))
+	return nil
+}
+
 func (e *EventDispatcherManager) GetDispatcherMap() *DispatcherMap {
 	return e.dispatcherMap
 }
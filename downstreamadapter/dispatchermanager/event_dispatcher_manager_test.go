@@ -0,0 +1,53 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatchermanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/ticdc/logservice/upstreamstore"
+	"github.com/pingcap/ticdc/pkg/common"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEventDispatcherManagerReleaseStoresOnError(t *testing.T) {
+	upstreamStoreManager := upstreamstore.NewManager(context.Background(), t.TempDir(), &upstreamstore.Stores{UpstreamID: 100})
+	defer upstreamStoreManager.Close(context.Background())
+	appcontext.SetService(appcontext.UpstreamStoreManager, upstreamStoreManager)
+	upstreamStoreManager.AddStoresForTest(&upstreamstore.Stores{UpstreamID: 200, PDClock: pdutil.NewClock4Test()}, 0)
+
+	cfConfig := &config.ChangefeedConfig{
+		ChangefeedID: common.NewChangeFeedIDWithName("test"),
+		SinkURI:      "unknown://127.0.0.1",
+		UpstreamID:   200,
+		UpstreamInfo: &config.UpstreamInfo{ID: 200},
+		Filter:       config.GetDefaultReplicaConfig().Filter,
+		SinkConfig:   config.GetDefaultReplicaConfig().Sink,
+	}
+	// the sink fails to be created after the stores of the upstream are acquired
+	_, _, err := NewEventDispatcherManager(cfConfig.ChangefeedID, cfConfig, nil, 1, node.ID("node1"), true)
+	require.Error(t, err)
+
+	// the reference is released, so the stores are removed with the pd clock of the upstream
+	require.Equal(t, 0, upstreamStoreManager.GetRefCountForTest(200))
+	_, ok := upstreamStoreManager.GetStores(200)
+	require.False(t, ok)
+	_, ok = appcontext.TryGetService[pdutil.Clock](appcontext.PDClockName(200))
+	require.False(t, ok)
+}
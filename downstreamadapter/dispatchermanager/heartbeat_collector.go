@@ -37,7 +37,7 @@ Sending messages include:
  2. BlockStatusRequest: the info about block events
 
 Receiving messages include:
- 1. HeartBeatResponse: the ack and actions for block events(Need a better name)
+ 1. HeartBeatResponse: the ack and actions for block events and the global redo resolvedTs(Need a better name)
  2. SchedulerDispatcherRequest: ask for create or remove a dispatcher
  3. CheckpointTsMessage: the latest checkpoint ts of the changefeed, it only for the MQ-class Sink
 
@@ -104,6 +104,14 @@ func (d *DispatcherMap) ForEach(fn func(id common.DispatcherID, dispatcher *disp
 	return seq
 }
 
+func newFilterConfigPB(cfConfig *config.ChangefeedConfig) *eventpb.FilterConfig {
+	return &eventpb.FilterConfig{
+		CaseSensitive:  cfConfig.CaseSensitive,
+		ForceReplicate: cfConfig.ForceReplicate,
+		FilterConfig:   toFilterConfigPB(cfConfig.Filter),
+	}
+}
+
 func toFilterConfigPB(filter *config.FilterConfig) *eventpb.InnerFilterConfig {
 	filterConfig := &eventpb.InnerFilterConfig{
 		Rules:            filter.Rules,
@@ -338,6 +346,7 @@ func (h *HeartBeatResponseHandler) Handle(eventDispatcherManager *EventDispatche
 		panic("invalid response count")
 	}
 	heartbeatResponse := resps[0]
+	eventDispatcherManager.UpdateRedoResolvedTs(heartbeatResponse.GetRedoResolvedTs())
 	dispatcherStatuses := heartbeatResponse.GetDispatcherStatuses()
 	for _, dispatcherStatus := range dispatcherStatuses {
 		influencedDispatchersType := dispatcherStatus.InfluencedDispatchers.InfluenceType
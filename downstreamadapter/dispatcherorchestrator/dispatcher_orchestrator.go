@@ -62,6 +62,8 @@ func (m *DispatcherOrchestrator) RecvMaintainerRequest(
 		return m.handlePostBootstrapRequest(msg.From, req)
 	case *heartbeatpb.MaintainerCloseRequest:
 		return m.handleCloseRequest(msg.From, req)
+	case *heartbeatpb.MaintainerUpdateConfigRequest:
+		return m.handleUpdateConfigRequest(req)
 	default:
 		log.Panic("unknown message type", zap.Any("message", msg.Message))
 	}
@@ -225,6 +227,33 @@ func (m *DispatcherOrchestrator) handleCloseRequest(
 	return m.sendResponse(from, messaging.MaintainerTopic, response)
 }
 
+// handleUpdateConfigRequest applies the hot-reloaded changefeed config to the
+// dispatcher manager of the changefeed, the new config takes effect for the
+// events whose commitTs is larger than the barrier ts.
+func (m *DispatcherOrchestrator) handleUpdateConfigRequest(
+	req *heartbeatpb.MaintainerUpdateConfigRequest,
+) error {
+	cfId := common.NewChangefeedIDFromPB(req.ChangefeedID)
+	manager, ok := m.dispatcherManagers[cfId]
+	if !ok {
+		log.Warn("dispatcher manager not found, ignore the update config request",
+			zap.String("changefeed", cfId.Name()))
+		return nil
+	}
+	cfConfig := &config.ChangefeedConfig{}
+	if err := json.Unmarshal(req.Config, cfConfig); err != nil {
+		log.Error("failed to unmarshal changefeed config",
+			zap.String("changefeedID", cfId.Name()), zap.Error(err))
+		return err
+	}
+	if err := manager.UpdateConfig(cfConfig, req.BarrierTs); err != nil {
+		log.Error("failed to update changefeed config",
+			zap.String("changefeedID", cfId.Name()), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 func createBootstrapResponse(
 	changefeedID *heartbeatpb.ChangefeedID,
 	manager *dispatchermanager.EventDispatcherManager,
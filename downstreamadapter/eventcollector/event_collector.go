@@ -15,6 +15,7 @@ package eventcollector
 
 import (
 	"context"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -39,6 +40,8 @@ import (
 
 const (
 	receiveChanSize = 1024 * 8
+	// dispatcherHeartbeatInterval is the interval to report the progress of dispatchers to event services.
+	dispatcherHeartbeatInterval = time.Second
 )
 
 var (
@@ -96,6 +99,12 @@ type EventCollector struct {
 		id node.ID
 	}
 
+	// memoryUsage is the memory usage of the dynamic stream, it's refreshed with the metrics.
+	memoryUsage struct {
+		used atomic.Int64
+		max  atomic.Int64
+	}
+
 	wg     sync.WaitGroup
 	cancel context.CancelFunc
 
@@ -154,6 +163,12 @@ func (c *EventCollector) Run(ctx context.Context) {
 		c.processLogCoordinatorRequest(ctx)
 	}()
 
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.sendDispatcherHeartbeat(ctx)
+	}()
+
 	c.wg.Add(1)
 	go func() {
 		defer c.wg.Done()
@@ -195,6 +210,12 @@ func (c *EventCollector) AddDispatcher(target dispatcher.EventDispatcher, memory
 		ActionType: eventpb.ActionType_ACTION_TYPE_REGISTER,
 	})
 
+	// The log coordinator only knows the event stores of the default upstream
+	// whose subscriptions don't filter the events written by TiCDC, so the dispatchers
+	// of other upstreams or in BDR mode always read from the local event service.
+	if target.GetUpstreamID() != 0 || target.GetBDRMode() {
+		return
+	}
 	c.logCoordinatorRequestChan.In() <- &logservicepb.ReusableEventServiceRequest{
 		ID:      target.GetId().ToPB(),
 		Span:    target.GetTableSpan(),
@@ -221,6 +242,26 @@ func (c *EventCollector) RemoveDispatcher(target *dispatcher.Dispatcher) {
 	}
 }
 
+// ResetDispatcher makes the event service send the events after startTs to the dispatcher again,
+// it's used when the filter config of the dispatcher is changed.
+// The dispatcher must be blocked when it's called, so no events are handled concurrently.
+func (c *EventCollector) ResetDispatcher(target dispatcher.EventDispatcher, startTs uint64) {
+	value, ok := c.dispatcherMap.Load(target.GetId())
+	if !ok {
+		return
+	}
+	stat := value.(*dispatcherStat)
+	stat.eventServiceInfo.RLock()
+	defer stat.eventServiceInfo.RUnlock()
+	stat.sentCommitTs.Store(startTs)
+	c.resetDispatcher(stat)
+}
+
+// SetChangefeedMemoryQuota updates the memory quota of all the dispatchers of the changefeed.
+func (c *EventCollector) SetChangefeedMemoryQuota(changefeedID common.ChangeFeedID, memoryQuota int) {
+	c.ds.SetAreaSettings(changefeedID.ID(), dynstream.NewAreaSettingsWithMaxPendingSize(memoryQuota))
+}
+
 func (c *EventCollector) WakeDispatcher(dispatcherID common.DispatcherID) {
 	c.ds.Wake(dispatcherID)
 }
@@ -321,10 +362,13 @@ func (c *EventCollector) mustSendDispatcherRequest(target node.ID, topic string,
 			DispatcherId: req.Dispatcher.GetId().ToPB(),
 			ActionType:   req.ActionType,
 			// ServerId is the id of the request sender.
-			ServerId:  c.serverId.String(),
-			TableSpan: req.Dispatcher.GetTableSpan(),
-			StartTs:   req.StartTs,
-			OnlyReuse: req.OnlyUse,
+			ServerId:     c.serverId.String(),
+			TableSpan:    req.Dispatcher.GetTableSpan(),
+			StartTs:      req.StartTs,
+			OnlyReuse:    req.OnlyUse,
+			ClusterId:    req.Dispatcher.GetUpstreamID(),
+			BdrMode:      req.Dispatcher.GetBDRMode(),
+			HighPriority: req.Dispatcher.IsHighPriority(),
 		},
 	}
 
@@ -430,6 +474,54 @@ func (c *EventCollector) runProcessMessage(ctx context.Context, inCh <-chan *mes
 	}
 }
 
+// sendDispatcherHeartbeat reports the checkpointTs of the dispatchers to the event services periodically,
+// so that the event stores can delete the data which is not needed by the dispatchers anymore.
+func (c *EventCollector) sendDispatcherHeartbeat(ctx context.Context) {
+	ticker := time.NewTicker(dispatcherHeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			heartbeats := make(map[node.ID]*eventpb.DispatcherHeartbeat)
+			addProgress := func(serverID node.ID, progress *eventpb.DispatcherProgress) {
+				heartbeat, ok := heartbeats[serverID]
+				if !ok {
+					heartbeat = &eventpb.DispatcherHeartbeat{}
+					heartbeats[serverID] = heartbeat
+				}
+				heartbeat.DispatcherProgresses = append(heartbeat.DispatcherProgresses, progress)
+			}
+			c.dispatcherMap.Range(func(_, value any) bool {
+				stat := value.(*dispatcherStat)
+				progress := &eventpb.DispatcherProgress{
+					DispatcherId: stat.dispatcherID.ToPB(),
+					CheckpointTs: stat.target.GetCheckpointTs(),
+				}
+				// the dispatcher is always registered to the local event service,
+				// and it may read from a remote event service at the same time.
+				addProgress(c.serverId, progress)
+				stat.eventServiceInfo.RLock()
+				serverID := stat.eventServiceInfo.serverID
+				stat.eventServiceInfo.RUnlock()
+				if serverID != "" && serverID != c.serverId {
+					addProgress(serverID, progress)
+				}
+				return true
+			})
+			for serverID, heartbeat := range heartbeats {
+				msg := messaging.NewSingleTargetMessage(serverID, eventServiceTopic, heartbeat)
+				// it's ok to drop the heartbeat, the next one will carry the latest progress.
+				if err := c.mc.SendEvent(msg); err != nil {
+					log.Debug("send dispatcher heartbeat failed",
+						zap.Stringer("target", serverID), zap.Error(err))
+				}
+			}
+		}
+	}
+}
+
 func (c *EventCollector) updateMetrics(ctx context.Context) {
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
@@ -443,10 +535,27 @@ func (c *EventCollector) updateMetrics(ctx context.Context) {
 			metricsDSPendingQueueLen.Set(float64(dsMetrics.PendingQueueLen))
 			metricsDSUsedMemoryUsage.Set(float64(dsMetrics.MemoryControl.UsedMemory))
 			metricsDSMaxMemoryUsage.Set(float64(dsMetrics.MemoryControl.MaxMemory))
+			c.memoryUsage.used.Store(dsMetrics.MemoryControl.UsedMemory)
+			c.memoryUsage.max.Store(dsMetrics.MemoryControl.MaxMemory)
+			for i, streamMetrics := range dsMetrics.Streams {
+				stream := strconv.Itoa(i)
+				metrics.DynamicStreamStreamMemoryUsage.WithLabelValues("event-collector", stream).Set(float64(streamMetrics.UsedMemory))
+				metrics.DynamicStreamStreamPendingQueueLen.WithLabelValues("event-collector", stream).Set(float64(streamMetrics.PendingQueueLen))
+			}
 		}
 	}
 }
 
+// GetMemoryUsageRatio returns the used ratio of the memory quota of all dispatchers,
+// it's reported to the maintainers to weigh the load of the node.
+func (c *EventCollector) GetMemoryUsageRatio() float64 {
+	maxMemory := c.memoryUsage.max.Load()
+	if maxMemory <= 0 {
+		return 0
+	}
+	return float64(c.memoryUsage.used.Load()) / float64(maxMemory)
+}
+
 // dispatcherStat is a helper struct to manage the state of a dispatcher.
 type dispatcherStat struct {
 	dispatcherID common.DispatcherID
@@ -78,13 +78,17 @@ func (h *EventsHandler) Handle(stat *dispatcherStat, events ...dispatcher.Dispat
 	// note: TypeDMLEvent and TypeResolvedEvent can be in the same batch, so we should handle them together.
 	case commonEvent.TypeDMLEvent,
 		commonEvent.TypeResolvedEvent:
-		validEventStart := 0
+		validEvents := make([]dispatcher.DispatcherEvent, 0, len(events))
 		for _, event := range events {
 			if stat.shouldIgnoreDataEvent(event, h.eventCollector) {
 				continue
 			}
+			validEvents = append(validEvents, event)
 		}
-		return stat.target.HandleEvents(events[validEventStart:], func() { h.eventCollector.WakeDispatcher(stat.dispatcherID) })
+		if len(validEvents) == 0 {
+			return false
+		}
+		return stat.target.HandleEvents(validEvents, func() { h.eventCollector.WakeDispatcher(stat.dispatcherID) })
 	case commonEvent.TypeDDLEvent,
 		commonEvent.TypeSyncPointEvent:
 		if stat.shouldIgnoreDataEvent(events[0], h.eventCollector) {
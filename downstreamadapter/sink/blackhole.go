@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	"go.uber.org/zap"
 )
@@ -40,6 +41,10 @@ func (s *BlackHoleSink) SinkType() common.SinkType {
 	return common.BlackHoleSinkType
 }
 
+func (s *BlackHoleSink) UpdateConfig(_ *config.ChangefeedConfig, _ uint64) error {
+	return nil
+}
+
 func (s *BlackHoleSink) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore) {
 }
 
@@ -0,0 +1,214 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper"
+	"github.com/pingcap/ticdc/downstreamadapter/worker"
+	"github.com/pingcap/ticdc/pkg/common"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	putil "github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// CloudStorageSink is responsible for writing data to the cloud storage.
+// The DML events are written as data files of the specific protocol,
+// and the DDL events are written as schema files.
+type CloudStorageSink struct {
+	changefeedID common.ChangeFeedID
+	scheme       string
+	storage      storage.ExternalStorage
+
+	dmlWorker *worker.CloudStorageDMLWorker
+	ddlWorker *worker.CloudStorageDDLWorker
+
+	statistics *metrics.Statistics
+
+	// isNormal means the sink does not meet error.
+	// if sink is normal, isNormal is 1, otherwise is 0
+	isNormal uint32
+	ctx      context.Context
+}
+
+func (s *CloudStorageSink) SinkType() common.SinkType {
+	return common.CloudStorageSinkType
+}
+
+func verifyCloudStorageSink(
+	ctx context.Context, changefeedID common.ChangeFeedID, sinkURI *url.URL, sinkConfig *config.SinkConfig,
+) error {
+	var (
+		protocol config.Protocol
+		storage  storage.ExternalStorage
+		err      error
+	)
+	cfg := cloudstorage.NewConfig()
+	if err = cfg.Apply(sinkURI, sinkConfig); err != nil {
+		return err
+	}
+	if protocol, err = helper.GetProtocol(putil.GetOrZero(sinkConfig.Protocol)); err != nil {
+		return err
+	}
+	if _, err = util.GetEncoderConfig(changefeedID, sinkURI, protocol, sinkConfig, math.MaxInt); err != nil {
+		return err
+	}
+	if storage, err = putil.GetExternalStorageFromURI(ctx, sinkURI.String()); err != nil {
+		return err
+	}
+	storage.Close()
+	return nil
+}
+
+func newCloudStorageSink(
+	ctx context.Context, changefeedID common.ChangeFeedID, sinkURI *url.URL, sinkConfig *config.SinkConfig,
+) (*CloudStorageSink, error) {
+	// create cloud storage config and then apply the params of sinkURI to it.
+	cfg := cloudstorage.NewConfig()
+	err := cfg.Apply(sinkURI, sinkConfig)
+	if err != nil {
+		return nil, err
+	}
+	// fetch protocol from replicaConfig defined by changefeed config file.
+	protocol, err := helper.GetProtocol(
+		putil.GetOrZero(sinkConfig.Protocol),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// get cloud storage file extension according to the specific protocol.
+	ext := helper.GetFileExtension(protocol)
+	// the last param maxMsgBytes is mainly to limit the size of a single message for
+	// batch protocols in mq scenario. In cloud storage sink, we just set it to max int.
+	encoderConfig, err := util.GetEncoderConfig(changefeedID, sinkURI, protocol, sinkConfig, math.MaxInt)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storage, err := putil.GetExternalStorageFromURI(ctx, sinkURI.String())
+	if err != nil {
+		return nil, err
+	}
+
+	statistics := metrics.NewStatistics(changefeedID, "CloudStorageSink")
+	pdClock := appcontext.GetService[pdutil.Clock](appcontext.DefaultPDClock)
+	dmlWorker, err := worker.NewCloudStorageDMLWorker(
+		changefeedID, storage, cfg, encoderConfig, ext, pdClock, statistics)
+	if err != nil {
+		storage.Close()
+		return nil, errors.Trace(err)
+	}
+	ddlWorker := worker.NewCloudStorageDDLWorker(changefeedID, sinkURI, cfg, storage, statistics)
+	sink := &CloudStorageSink{
+		changefeedID: changefeedID,
+		scheme:       strings.ToLower(sinkURI.Scheme),
+		storage:      storage,
+		dmlWorker:    dmlWorker,
+		ddlWorker:    ddlWorker,
+		statistics:   statistics,
+		isNormal:     1,
+		ctx:          ctx,
+	}
+	return sink, nil
+}
+
+func (s *CloudStorageSink) Run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return s.dmlWorker.Run(ctx)
+	})
+	g.Go(func() error {
+		return s.ddlWorker.Run(ctx)
+	})
+	err := g.Wait()
+	atomic.StoreUint32(&s.isNormal, 0)
+	return errors.Trace(err)
+}
+
+func (s *CloudStorageSink) IsNormal() bool {
+	return atomic.LoadUint32(&s.isNormal) == 1
+}
+
+func (s *CloudStorageSink) AddDMLEvent(event *commonEvent.DMLEvent) {
+	s.dmlWorker.AddDMLEvent(event)
+}
+
+func (s *CloudStorageSink) PassBlockEvent(event commonEvent.BlockEvent) {
+	event.PostFlush()
+}
+
+func (s *CloudStorageSink) WriteBlockEvent(event commonEvent.BlockEvent) error {
+	switch v := event.(type) {
+	case *commonEvent.DDLEvent:
+		if v.TiDBOnly {
+			// run callback directly and return
+			v.PostFlush()
+			return nil
+		}
+		err := s.ddlWorker.WriteBlockEvent(s.ctx, v)
+		if err != nil {
+			atomic.StoreUint32(&s.isNormal, 0)
+			return errors.Trace(err)
+		}
+	case *commonEvent.SyncPointEvent:
+		log.Error("CloudStorageSink doesn't support Sync Point Event",
+			zap.String("namespace", s.changefeedID.Namespace()),
+			zap.String("changefeed", s.changefeedID.Name()),
+			zap.Any("event", event))
+	default:
+		log.Error("CloudStorageSink doesn't support this type of block event",
+			zap.String("namespace", s.changefeedID.Namespace()),
+			zap.String("changefeed", s.changefeedID.Name()),
+			zap.Any("eventType", event.GetType()))
+	}
+	return nil
+}
+
+func (s *CloudStorageSink) AddCheckpointTs(ts uint64) {
+	s.ddlWorker.AddCheckpoint(ts)
+}
+
+// UpdateConfig is a no-op for cloud storage sink, the hot-reloadable config doesn't affect it.
+func (s *CloudStorageSink) UpdateConfig(_ *config.ChangefeedConfig, _ uint64) error {
+	return nil
+}
+
+func (s *CloudStorageSink) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore) {
+	s.ddlWorker.SetTableSchemaStore(tableSchemaStore)
+}
+
+func (s *CloudStorageSink) Close(_ bool) {
+	s.dmlWorker.Close()
+	s.ddlWorker.Close()
+	if s.statistics != nil {
+		s.statistics.Close()
+	}
+	if s.storage != nil {
+		s.storage.Close()
+	}
+}
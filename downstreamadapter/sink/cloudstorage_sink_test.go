@@ -0,0 +1,129 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newCloudStorageSinkForTest(
+	ctx context.Context, t *testing.T, parentDir string, protocol string,
+) *CloudStorageSink {
+	uri := fmt.Sprintf("file:///%s?flush-interval=2s", parentDir)
+	sinkURI, err := url.Parse(uri)
+	require.NoError(t, err)
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.Protocol = util.AddressOf(protocol)
+	replicaConfig.Sink.DateSeparator = util.AddressOf(config.DateSeparatorNone.String())
+	replicaConfig.Sink.Terminator = util.AddressOf("\n")
+	appcontext.SetService(appcontext.DefaultPDClock, pdutil.NewClock4Test())
+
+	changefeedID := common.NewChangefeedID4Test("test", "test")
+	sink, err := newCloudStorageSink(ctx, changefeedID, sinkURI, replicaConfig.Sink)
+	require.NoError(t, err)
+	go sink.Run(ctx)
+	return sink
+}
+
+func TestCloudStorageSinkWriteEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	parentDir := t.TempDir()
+	sink := newCloudStorageSinkForTest(ctx, t, parentDir, config.ProtocolCsv.String())
+	defer sink.Close(false)
+	require.Equal(t, common.CloudStorageSinkType, sink.SinkType())
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+	tableInfo := helper.GetTableInfo(job)
+
+	var count atomic.Int64
+	ddlEvent := &commonEvent.DDLEvent{
+		Query:      job.Query,
+		Type:       byte(job.Type),
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		TableInfo:  tableInfo,
+		FinishedTs: 100,
+		PostTxnFlushed: []func(){
+			func() { count.Add(1) },
+		},
+	}
+	require.NoError(t, sink.WriteBlockEvent(ddlEvent))
+	require.Equal(t, int64(1), count.Load())
+
+	// the schema file of the ddl is written directly.
+	var def cloudstorage.TableDefinition
+	def.FromDDLEvent(ddlEvent, false)
+	schemaPath, err := def.GenerateSchemaFilePath()
+	require.NoError(t, err)
+	require.FileExists(t, path.Join(parentDir, schemaPath))
+
+	dmlEvent := helper.DML2Event("test", "t",
+		"insert into t values (1, 'a')",
+		"insert into t values (2, 'b')")
+	dmlEvent.CommitTs = 200
+	dmlEvent.PostTxnFlushed = []func(){
+		func() { count.Add(1) },
+	}
+	sink.AddDMLEvent(dmlEvent)
+	require.Eventually(t, func() bool {
+		return count.Load() == 2
+	}, 10*time.Second, 100*time.Millisecond)
+
+	// the schema file written by the ddl is reused, so the data files are
+	// placed in the directory of the ddl's table version.
+	tableDir := path.Join(parentDir, "test", "t", "100")
+	content, err := os.ReadFile(path.Join(tableDir, "CDC00000000000000000001.csv"))
+	require.NoError(t, err)
+	require.Equal(t, "\"I\",\"t\",\"test\",1,\"a\"\n\"I\",\"t\",\"test\",2,\"b\"\n", string(content))
+
+	content, err = os.ReadFile(path.Join(tableDir, "meta", "CDC.index"))
+	require.NoError(t, err)
+	require.Equal(t, "CDC00000000000000000001.csv\n", string(content))
+
+	files, err := filepath.Glob(path.Join(parentDir, "test", "t", "meta", "schema_*.json"))
+	require.NoError(t, err)
+	require.Equal(t, []string{path.Join(parentDir, schemaPath)}, files)
+
+	// the checkpoint ts is written at most once every two seconds.
+	require.Eventually(t, func() bool {
+		sink.AddCheckpointTs(300)
+		content, err := os.ReadFile(path.Join(parentDir, "metadata"))
+		return err == nil && string(content) == `{"checkpoint-ts":300}`
+	}, 10*time.Second, 500*time.Millisecond)
+}
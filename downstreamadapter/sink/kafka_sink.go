@@ -36,6 +36,8 @@ import (
 
 type KafkaSink struct {
 	changefeedID common.ChangeFeedID
+	// sinkURI is used to rebuild the event router when the changefeed config is updated.
+	sinkURI *url.URL
 
 	dmlWorker *worker.KafkaDMLWorker
 	ddlWorker *worker.KafkaDDLWorker
@@ -89,7 +91,10 @@ func newKafkaSink(
 	if err != nil {
 		return nil, errors.WrapError(errors.ErrKafkaNewProducer, err)
 	}
-	dmlProducer := producer.NewKafkaDMLProducer(changefeedID, asyncProducer)
+	var dmlProducer producer.DMLProducer = producer.NewKafkaDMLProducer(changefeedID, asyncProducer)
+	if txnProducer, ok := asyncProducer.(kafka.TxnAsyncProducer); ok {
+		dmlProducer = producer.NewKafkaTxnDMLProducer(changefeedID, txnProducer)
+	}
 	dmlWorker := worker.NewKafkaDMLWorker(
 		changefeedID,
 		protocol,
@@ -116,6 +121,7 @@ func newKafkaSink(
 
 	sink := &KafkaSink{
 		changefeedID:     changefeedID,
+		sinkURI:          sinkURI,
 		dmlWorker:        dmlWorker,
 		ddlWorker:        ddlWorker,
 		adminClient:      kafkaComponent.AdminClient,
@@ -187,6 +193,18 @@ func (s *KafkaSink) AddCheckpointTs(ts uint64) {
 	s.ddlWorker.AddCheckpoint(ts)
 }
 
+// UpdateConfig rebuilds the event router and the column selectors from the new sink config,
+// they take effect for the events whose commitTs is larger than barrierTs.
+func (s *KafkaSink) UpdateConfig(cfConfig *config.ChangefeedConfig, barrierTs uint64) error {
+	eventRouter, columnSelector, err := worker.GetMQRoutingComponent(s.sinkURI, cfConfig.SinkConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.dmlWorker.UpdateRouting(eventRouter, columnSelector, barrierTs)
+	s.ddlWorker.UpdateEventRouter(eventRouter, barrierTs)
+	return nil
+}
+
 func (s *KafkaSink) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore) {
 	s.ddlWorker.SetTableSchemaStore(tableSchemaStore)
 }
@@ -251,6 +269,7 @@ func newKafkaSinkForTest() (*KafkaSink, producer.DMLProducer, producer.DDLProduc
 
 	sink := &KafkaSink{
 		changefeedID:     changefeedID,
+		sinkURI:          sinkURI,
 		dmlWorker:        dmlWorker,
 		ddlWorker:        ddlWorker,
 		adminClient:      kafkaComponent.AdminClient,
@@ -17,12 +17,14 @@ import (
 	"context"
 	"database/sql"
 	"net/url"
+	"sync"
 	"sync/atomic"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/downstreamadapter/worker"
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
@@ -43,13 +45,30 @@ const (
 type MysqlSink struct {
 	changefeedID common.ChangeFeedID
 
-	ddlWorker   *worker.MysqlDDLWorker
+	ddlWorker *worker.MysqlDDLWorker
+	// workerMu protects dmlWorker and workerCount, which are rebuilt
+	// when the worker count is hot-reloaded.
+	workerMu    sync.RWMutex
 	dmlWorker   []*worker.MysqlDMLWorker
 	workerCount int
+	// workersDrained is notified by Run after all the dml workers are drained,
+	// and workersRebuilt is notified by UpdateConfig after the new workers are created.
+	workersDrained chan struct{}
+	workersRebuilt chan struct{}
+	// runExited is closed when Run returns.
+	runExited chan struct{}
+
+	ctx              context.Context
+	cfg              *mysql.MysqlConfig
+	sinkURI          *url.URL
+	formatVectorType bool
 
 	db         *sql.DB
 	statistics *metrics.Statistics
 
+	// enableDMLCheckpoint indicates whether the dml progress of each dispatcher is recorded in the downstream.
+	enableDMLCheckpoint bool
+
 	isNormal uint32 // if sink is normal, isNormal is 1, otherwise is 0
 }
 
@@ -72,7 +91,6 @@ func verifyMySQLSink(
 func newMySQLSink(
 	ctx context.Context,
 	changefeedID common.ChangeFeedID,
-	workerCount int,
 	config *config.ChangefeedConfig,
 	sinkURI *url.URL,
 ) (*MysqlSink, error) {
@@ -80,7 +98,9 @@ func newMySQLSink(
 	if err != nil {
 		return nil, err
 	}
-	return newMysqlSinkWithDBAndConfig(ctx, changefeedID, workerCount, cfg, db), nil
+	sink := newMysqlSinkWithDBAndConfig(ctx, changefeedID, cfg.WorkerCount, cfg, db)
+	sink.sinkURI = sinkURI
+	return sink, nil
 }
 
 func newMysqlSinkWithDBAndConfig(
@@ -94,29 +114,65 @@ func newMysqlSinkWithDBAndConfig(
 	mysqlSink := &MysqlSink{
 		changefeedID: changefeedID,
 		db:           db,
-		dmlWorker:    make([]*worker.MysqlDMLWorker, workerCount),
-		workerCount:  workerCount,
 		statistics:   stat,
 		isNormal:     1,
+
+		workersDrained: make(chan struct{}),
+		workersRebuilt: make(chan struct{}),
+		runExited:      make(chan struct{}),
+
+		ctx: ctx,
+		cfg: cfg,
+
+		enableDMLCheckpoint: cfg.EnableDMLCheckpoint,
 	}
 	formatVectorType := mysql.ShouldFormatVectorType(db, cfg)
-	for i := 0; i < workerCount; i++ {
-		mysqlSink.dmlWorker[i] = worker.NewMysqlDMLWorker(ctx, db, cfg, i, changefeedID, stat, formatVectorType)
-	}
+	mysqlSink.formatVectorType = formatVectorType
+	mysqlSink.dmlWorker = mysqlSink.newDMLWorkers(workerCount)
+	mysqlSink.workerCount = workerCount
 	mysqlSink.ddlWorker = worker.NewMysqlDDLWorker(ctx, db, cfg, changefeedID, stat, formatVectorType)
 	return mysqlSink
 }
 
+func (s *MysqlSink) newDMLWorkers(workerCount int) []*worker.MysqlDMLWorker {
+	workers := make([]*worker.MysqlDMLWorker, workerCount)
+	for i := 0; i < workerCount; i++ {
+		workers[i] = worker.NewMysqlDMLWorker(s.ctx, s.db, s.cfg, i, s.changefeedID, s.statistics, s.formatVectorType)
+	}
+	return workers
+}
+
 func (s *MysqlSink) Run(ctx context.Context) error {
-	g, ctx := errgroup.WithContext(ctx)
-	for i := 0; i < s.workerCount; i++ {
-		g.Go(func() error {
-			return s.dmlWorker[i].Run(ctx)
-		})
+	defer close(s.runExited)
+	for {
+		s.workerMu.RLock()
+		workers := s.dmlWorker
+		s.workerMu.RUnlock()
+
+		g, gctx := errgroup.WithContext(ctx)
+		for _, w := range workers {
+			g.Go(func() error {
+				return w.Run(gctx)
+			})
+		}
+		if err := g.Wait(); err != nil {
+			atomic.StoreUint32(&s.isNormal, 0)
+			return errors.Trace(err)
+		}
+		// All the workers are drained by UpdateConfig, wait for the new workers.
+		select {
+		case <-ctx.Done():
+			atomic.StoreUint32(&s.isNormal, 0)
+			return errors.Trace(ctx.Err())
+		case s.workersDrained <- struct{}{}:
+		}
+		select {
+		case <-ctx.Done():
+			atomic.StoreUint32(&s.isNormal, 0)
+			return errors.Trace(ctx.Err())
+		case <-s.workersRebuilt:
+		}
 	}
-	err := g.Wait()
-	atomic.StoreUint32(&s.isNormal, 0)
-	return errors.Trace(err)
 }
 
 func (s *MysqlSink) IsNormal() bool {
@@ -128,6 +184,51 @@ func (s *MysqlSink) SinkType() common.SinkType {
 	return common.MysqlSinkType
 }
 
+// UpdateConfig applies the hot-reloaded dml worker count. It's called at the config barrier,
+// the dml events are dispatched to the workers by table, so the old workers are drained
+// before the new workers are created, to avoid reordering the events of a table.
+func (s *MysqlSink) UpdateConfig(cfConfig *config.ChangefeedConfig, barrierTs uint64) error {
+	if s.sinkURI == nil {
+		return nil
+	}
+	workerCount, err := mysql.GetWorkerCount(s.sinkURI, cfConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	s.workerMu.Lock()
+	defer s.workerMu.Unlock()
+	if workerCount == s.workerCount {
+		return nil
+	}
+	for _, w := range s.dmlWorker {
+		w.CloseEventChan()
+	}
+	select {
+	case <-s.runExited:
+		return errors.Trace(context.Canceled)
+	case <-s.workersDrained:
+	}
+	for _, w := range s.dmlWorker {
+		w.Close()
+	}
+	s.db.SetMaxIdleConns(workerCount + 1)
+	s.db.SetMaxOpenConns(workerCount + 1)
+	s.dmlWorker = s.newDMLWorkers(workerCount)
+	log.Info("mysql sink dml worker count updated",
+		zap.String("changefeed", s.changefeedID.String()),
+		zap.Uint64("barrierTs", barrierTs),
+		zap.Int("oldWorkerCount", s.workerCount),
+		zap.Int("newWorkerCount", workerCount))
+	s.workerCount = workerCount
+	select {
+	case <-s.runExited:
+		return errors.Trace(context.Canceled)
+	case s.workersRebuilt <- struct{}{}:
+	}
+	return nil
+}
+
 func (s *MysqlSink) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore) {
 	s.ddlWorker.SetTableSchemaStore(tableSchemaStore)
 }
@@ -136,6 +237,8 @@ func (s *MysqlSink) AddDMLEvent(event *commonEvent.DMLEvent) {
 	// Considering that the parity of tableID is not necessarily even,
 	// directly dividing by the number of buckets may cause unevenness between buckets.
 	// Therefore, we first take the modulus of the prime number and then take the modulus of the bucket.
+	s.workerMu.RLock()
+	defer s.workerMu.RUnlock()
 	index := int64(event.PhysicalTableID) % prime % int64(s.workerCount)
 	s.dmlWorker[index].AddDMLEvent(event)
 }
@@ -179,6 +282,24 @@ func (s *MysqlSink) GetStartTsList(
 	return startTsList, isSyncpointList, nil
 }
 
+// GetDMLCheckpoints returns the dml checkpoint recorded in the downstream for each dispatcher.
+// It returns nil if the dml checkpoint is not enabled.
+func (s *MysqlSink) GetDMLCheckpoints(
+	dispatcherIDs []common.DispatcherID,
+	tableSpans []*heartbeatpb.TableSpan,
+	replicatingTsList []uint64,
+) ([]mysql.DMLCheckpoint, error) {
+	if !s.enableDMLCheckpoint {
+		return nil, nil
+	}
+	checkpoints, err := s.ddlWorker.GetDMLCheckpoints(dispatcherIDs, tableSpans, replicatingTsList)
+	if err != nil {
+		atomic.StoreUint32(&s.isNormal, 0)
+		return nil, err
+	}
+	return checkpoints, nil
+}
+
 func (s *MysqlSink) Close(removeChangefeed bool) {
 	// when remove the changefeed, we need to remove the ddl ts item in the ddl worker
 	if removeChangefeed {
@@ -187,9 +308,11 @@ func (s *MysqlSink) Close(removeChangefeed bool) {
 				zap.Any("changefeed", s.changefeedID.String()), zap.Error(err))
 		}
 	}
-	for i := 0; i < s.workerCount; i++ {
-		s.dmlWorker[i].Close()
+	s.workerMu.RLock()
+	for _, w := range s.dmlWorker {
+		w.Close()
 	}
+	s.workerMu.RUnlock()
 
 	s.ddlWorker.Close()
 
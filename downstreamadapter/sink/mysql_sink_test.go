@@ -15,12 +15,14 @@ package sink
 
 import (
 	"errors"
+	"net/url"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/stretchr/testify/require"
 )
 
@@ -104,7 +106,7 @@ func TestMysqlSinkBasicFunctionality(t *testing.T) {
 	mock.ExpectCommit()
 
 	mock.ExpectBegin()
-	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?),(?,?)").
 		WithArgs(1, "test", 2, "test2").
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
@@ -144,7 +146,7 @@ func TestMysqlSinkMeetsDMLError(t *testing.T) {
 	dmlEvent.CommitTs = 2
 
 	mock.ExpectBegin()
-	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?),(?,?)").
 		WithArgs(1, "test", 2, "test2").
 		WillReturnError(errors.New("connect: connection refused"))
 	mock.ExpectRollback()
@@ -205,3 +207,60 @@ func TestMysqlSinkMeetsDDLError(t *testing.T) {
 
 	require.Equal(t, sink.IsNormal(), false)
 }
+
+// Test the dml workers are drained and rebuilt when the worker count is hot-reloaded
+func TestMysqlSinkUpdateWorkerCount(t *testing.T) {
+	sink, mock := MysqlSinkForTest()
+	sinkURI, err := url.Parse("mysql://127.0.0.1:3306/")
+	require.NoError(t, err)
+	sink.sinkURI = sinkURI
+
+	count.Store(0)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	createTableSQL := "create table t (id int primary key, name varchar(32));"
+	job := helper.DDL2Job(createTableSQL)
+	require.NotNil(t, job)
+
+	workerCount := 4
+	cfConfig := &config.ChangefeedConfig{
+		SinkConfig: &config.SinkConfig{
+			MySQLConfig: &config.MySQLConfig{WorkerCount: &workerCount},
+		},
+	}
+	err = sink.UpdateConfig(cfConfig, 1)
+	require.NoError(t, err)
+	require.Equal(t, 4, sink.workerCount)
+	require.Len(t, sink.dmlWorker, 4)
+	require.True(t, sink.IsNormal())
+
+	// the same worker count doesn't rebuild the workers
+	workers := sink.dmlWorker
+	err = sink.UpdateConfig(cfConfig, 2)
+	require.NoError(t, err)
+	require.Equal(t, workers, sink.dmlWorker)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')")
+	dmlEvent.PostTxnFlushed = []func(){
+		func() { count.Add(1) },
+	}
+	dmlEvent.CommitTs = 3
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "test").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	sink.AddDMLEvent(dmlEvent)
+	require.Eventually(t, func() bool {
+		return count.Load() == 1
+	}, 5*time.Second, 100*time.Millisecond)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err)
+	require.True(t, sink.IsNormal())
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"net/url"
+	"sync/atomic"
+
+	pulsarClient "github.com/apache/pulsar-client-go/pulsar"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/topicmanager"
+	"github.com/pingcap/ticdc/downstreamadapter/worker"
+	"github.com/pingcap/ticdc/downstreamadapter/worker/producer"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/pulsar"
+	"github.com/pingcap/ticdc/pkg/sink/util"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+type PulsarSink struct {
+	changefeedID common.ChangeFeedID
+	// sinkURI is used to rebuild the event router when the changefeed config is updated.
+	sinkURI *url.URL
+
+	dmlWorker *worker.PulsarDMLWorker
+	ddlWorker *worker.PulsarDDLWorker
+
+	// the module used by dmlWorker and ddlWorker
+	// PulsarSink need to close it when Close() is called
+	topicManager topicmanager.TopicManager
+	// client is shared by the producers of dmlWorker and ddlWorker,
+	// PulsarSink owns it and closes it after both workers are closed.
+	client     pulsarClient.Client
+	statistics *metrics.Statistics
+
+	// isNormal means the sink does not meet error.
+	// if sink is normal, isNormal is 1, otherwise is 0
+	isNormal uint32
+	ctx      context.Context
+}
+
+func (s *PulsarSink) SinkType() common.SinkType {
+	return common.PulsarSinkType
+}
+
+func verifyPulsarSink(ctx context.Context, changefeedID common.ChangeFeedID, uri *url.URL, sinkConfig *config.SinkConfig) error {
+	components, _, err := worker.GetPulsarSinkComponent(ctx, changefeedID, uri, sinkConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	components.TopicManager.Close()
+	components.EncoderGroup.Close()
+	components.Encoder.Clean()
+	if components.Client != nil {
+		components.Client.Close()
+	}
+	return nil
+}
+
+func newPulsarSink(
+	ctx context.Context, changefeedID common.ChangeFeedID, sinkURI *url.URL, sinkConfig *config.SinkConfig,
+) (*PulsarSink, error) {
+	return newPulsarSinkWithFactory(ctx, changefeedID, sinkURI, sinkConfig, pulsar.NewCreatorFactory)
+}
+
+func newPulsarSinkWithFactory(
+	ctx context.Context, changefeedID common.ChangeFeedID, sinkURI *url.URL, sinkConfig *config.SinkConfig,
+	factoryCreator pulsar.FactoryCreator,
+) (*PulsarSink, error) {
+	pulsarComponent, protocol, err := worker.GetPulsarSinkComponentWithFactory(ctx, changefeedID, sinkURI, sinkConfig, factoryCreator)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// We must close the components when this func return cause by an error
+	// otherwise they will never be closed and lead to a goroutine leak.
+	// The producers share the client but never close it, so it is closed only here.
+	defer func() {
+		if err == nil {
+			return
+		}
+		pulsarComponent.TopicManager.Close()
+		pulsarComponent.EncoderGroup.Close()
+		pulsarComponent.Encoder.Clean()
+		if pulsarComponent.Client != nil {
+			pulsarComponent.Client.Close()
+			pulsarComponent.Client = nil
+		}
+	}()
+
+	statistics := metrics.NewStatistics(changefeedID, "PulsarSink")
+	dmlProducer, err := producer.NewPulsarDMLProducer(changefeedID, pulsarComponent.Client, pulsarComponent.Config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dmlWorker := worker.NewPulsarDMLWorker(
+		changefeedID,
+		protocol,
+		dmlProducer,
+		pulsarComponent.EncoderGroup,
+		pulsarComponent.ColumnSelector,
+		pulsarComponent.EventRouter,
+		pulsarComponent.TopicManager,
+		statistics)
+
+	ddlProducer, err := producer.NewPulsarDDLProducer(changefeedID, pulsarComponent.Client, pulsarComponent.Config)
+	if err != nil {
+		dmlProducer.Close()
+		return nil, errors.Trace(err)
+	}
+	ddlWorker := worker.NewPulsarDDLWorker(
+		changefeedID,
+		ddlProducer,
+		pulsarComponent.Encoder,
+		pulsarComponent.EventRouter,
+		statistics)
+
+	sink := &PulsarSink{
+		changefeedID: changefeedID,
+		sinkURI:      sinkURI,
+		dmlWorker:    dmlWorker,
+		ddlWorker:    ddlWorker,
+		topicManager: pulsarComponent.TopicManager,
+		client:       pulsarComponent.Client,
+		statistics:   statistics,
+		isNormal:     1,
+		ctx:          ctx,
+	}
+	return sink, nil
+}
+
+func (s *PulsarSink) Run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return s.dmlWorker.Run(ctx)
+	})
+	g.Go(func() error {
+		return s.ddlWorker.Run(ctx)
+	})
+	err := g.Wait()
+	atomic.StoreUint32(&s.isNormal, 0)
+	return errors.Trace(err)
+}
+
+func (s *PulsarSink) IsNormal() bool {
+	return atomic.LoadUint32(&s.isNormal) == 1
+}
+
+func (s *PulsarSink) AddDMLEvent(event *commonEvent.DMLEvent) {
+	s.dmlWorker.AddDMLEvent(event)
+}
+
+func (s *PulsarSink) PassBlockEvent(event commonEvent.BlockEvent) {
+	event.PostFlush()
+}
+
+func (s *PulsarSink) WriteBlockEvent(event commonEvent.BlockEvent) error {
+	switch v := event.(type) {
+	case *commonEvent.DDLEvent:
+		if v.TiDBOnly {
+			// run callback directly and return
+			v.PostFlush()
+			return nil
+		}
+		err := s.ddlWorker.WriteBlockEvent(s.ctx, v)
+		if err != nil {
+			atomic.StoreUint32(&s.isNormal, 0)
+			return errors.Trace(err)
+		}
+	case *commonEvent.SyncPointEvent:
+		log.Error("PulsarSink doesn't support Sync Point Event",
+			zap.String("namespace", s.changefeedID.Namespace()),
+			zap.String("changefeed", s.changefeedID.Name()),
+			zap.Any("event", event))
+	default:
+		log.Error("PulsarSink doesn't support this type of block event",
+			zap.String("namespace", s.changefeedID.Namespace()),
+			zap.String("changefeed", s.changefeedID.Name()),
+			zap.Any("eventType", event.GetType()))
+	}
+	return nil
+}
+
+func (s *PulsarSink) AddCheckpointTs(ts uint64) {
+	s.ddlWorker.AddCheckpoint(ts)
+}
+
+// UpdateConfig rebuilds the event router and the column selectors from the new sink config,
+// they take effect for the events whose commitTs is larger than barrierTs.
+func (s *PulsarSink) UpdateConfig(cfConfig *config.ChangefeedConfig, barrierTs uint64) error {
+	eventRouter, columnSelector, err := worker.GetMQRoutingComponent(s.sinkURI, cfConfig.SinkConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.dmlWorker.UpdateRouting(eventRouter, columnSelector, barrierTs)
+	s.ddlWorker.UpdateEventRouter(eventRouter, barrierTs)
+	return nil
+}
+
+func (s *PulsarSink) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore) {
+	s.ddlWorker.SetTableSchemaStore(tableSchemaStore)
+}
+
+func (s *PulsarSink) Close(_ bool) {
+	s.ddlWorker.Close()
+	s.dmlWorker.Close()
+	s.topicManager.Close()
+	if s.client != nil {
+		s.client.Close()
+	}
+	s.statistics.Close()
+}
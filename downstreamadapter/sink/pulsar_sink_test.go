@@ -0,0 +1,204 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pulsarClient "github.com/apache/pulsar-client-go/pulsar"
+	"github.com/pingcap/ticdc/downstreamadapter/worker"
+	"github.com/pingcap/ticdc/downstreamadapter/worker/producer"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/pulsar"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPulsarSinkBasicFunctionality(t *testing.T) {
+	sink, dmlProducer, ddlProducer, err := newPulsarSinkForTest()
+	require.NoError(t, err)
+
+	count.Store(0)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	createTableSQL := "create table t (id int primary key, name varchar(32));"
+	job := helper.DDL2Job(createTableSQL)
+	require.NotNil(t, job)
+
+	ddlEvent := &commonEvent.DDLEvent{
+		Query:      job.Query,
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		FinishedTs: 1,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{0},
+		},
+		NeedAddedTables: []commonEvent.Table{{TableID: 1, SchemaID: 1}},
+		PostTxnFlushed: []func(){
+			func() { count.Add(1) },
+		},
+	}
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')", "insert into t values (2, 'test2');")
+	dmlEvent.PostTxnFlushed = []func(){
+		func() { count.Add(1) },
+	}
+	dmlEvent.CommitTs = 2
+
+	err = sink.WriteBlockEvent(ddlEvent)
+	require.NoError(t, err)
+
+	sink.AddDMLEvent(dmlEvent)
+	time.Sleep(1 * time.Second)
+
+	// canal-json encodes each row into a single message.
+	require.Len(t, dmlProducer.(*producer.MockProducer).GetAllEvents(), 2)
+	require.Len(t, ddlProducer.(*producer.MockProducer).GetAllEvents(), 1)
+	for _, message := range dmlProducer.(*producer.MockProducer).GetAllEvents() {
+		require.NotNil(t, message.PartitionKey)
+	}
+
+	require.Equal(t, count.Load(), int64(2))
+}
+
+func newPulsarSinkForTest() (*PulsarSink, producer.DMLProducer, producer.DDLProducer, error) {
+	ctx := context.Background()
+	changefeedID := common.NewChangefeedID4Test("test", "test")
+	canalJSONProtocol := "canal-json"
+	sinkConfig := &config.SinkConfig{Protocol: &canalJSONProtocol}
+	sinkURI, err := url.Parse("pulsar://127.0.0.1:6650/persistent://public/default/test?protocol=canal-json")
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	statistics := metrics.NewStatistics(changefeedID, "PulsarSink")
+	pulsarComponent, protocol, err := worker.GetPulsarSinkComponentForTest(ctx, changefeedID, sinkURI, sinkConfig)
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+
+	dmlMockProducer := producer.NewMockDMLProducer()
+	dmlWorker := worker.NewPulsarDMLWorker(
+		changefeedID,
+		protocol,
+		dmlMockProducer,
+		pulsarComponent.EncoderGroup,
+		pulsarComponent.ColumnSelector,
+		pulsarComponent.EventRouter,
+		pulsarComponent.TopicManager,
+		statistics)
+
+	ddlMockProducer := producer.NewMockDDLProducer()
+	ddlWorker := worker.NewPulsarDDLWorker(
+		changefeedID,
+		ddlMockProducer,
+		pulsarComponent.Encoder,
+		pulsarComponent.EventRouter,
+		statistics)
+
+	sink := &PulsarSink{
+		changefeedID: changefeedID,
+		sinkURI:      sinkURI,
+		dmlWorker:    dmlWorker,
+		ddlWorker:    ddlWorker,
+		topicManager: pulsarComponent.TopicManager,
+		statistics:   statistics,
+		isNormal:     1,
+		ctx:          ctx,
+	}
+	go sink.Run(ctx)
+	return sink, dmlMockProducer, ddlMockProducer, nil
+}
+
+// closeOnceClient is a pulsar client which panics when it is closed twice,
+// just like the real client does.
+type closeOnceClient struct {
+	pulsarClient.Client
+	closed atomic.Int32
+	// failCreateProducerAt makes the n-th CreateProducer call fail, 0 means never fail.
+	failCreateProducerAt int32
+	createProducerCount  atomic.Int32
+}
+
+func (c *closeOnceClient) CreateProducer(_ pulsarClient.ProducerOptions) (pulsarClient.Producer, error) {
+	if c.createProducerCount.Add(1) == c.failCreateProducerAt {
+		return nil, errors.New("create producer failed")
+	}
+	return &mockPulsarProducer{}, nil
+}
+
+func (c *closeOnceClient) Close() {
+	if c.closed.Add(1) > 1 {
+		panic("close of closed channel")
+	}
+}
+
+type mockPulsarProducer struct {
+	pulsarClient.Producer
+}
+
+func (p *mockPulsarProducer) Close() {}
+
+func TestPulsarSinkCloseClientOnce(t *testing.T) {
+	changefeedID := common.NewChangefeedID4Test("test", "test")
+	newSinkConfig := func() *config.SinkConfig {
+		canalJSONProtocol := "canal-json"
+		return &config.SinkConfig{Protocol: &canalJSONProtocol}
+	}
+	sinkURI, err := url.Parse("pulsar://127.0.0.1:6650/persistent://public/default/test?protocol=canal-json")
+	require.NoError(t, err)
+
+	newFactory := func(client *closeOnceClient) pulsar.FactoryCreator {
+		return func(*config.PulsarConfig, common.ChangeFeedID, *config.SinkConfig) (pulsarClient.Client, error) {
+			return client, nil
+		}
+	}
+
+	// the client is closed once when the sink is closed normally
+	client := &closeOnceClient{}
+	sinkConfig := newSinkConfig()
+	sink, err := newPulsarSinkWithFactory(context.Background(), changefeedID, sinkURI, sinkConfig, newFactory(client))
+	require.NoError(t, err)
+	require.Nil(t, sinkConfig.PulsarConfig)
+	sink.Close(false)
+	require.Equal(t, int32(1), client.closed.Load())
+
+	// the client is closed once when creating the dml or ddl producer fails
+	for _, failAt := range []int32{1, 2} {
+		client = &closeOnceClient{failCreateProducerAt: failAt}
+		_, err = newPulsarSinkWithFactory(context.Background(), changefeedID, sinkURI, newSinkConfig(), newFactory(client))
+		require.Error(t, err)
+		require.Equal(t, int32(1), client.closed.Load())
+	}
+
+	// the client is closed by the helper and not returned when building the components fails
+	client = &closeOnceClient{}
+	noTopicURI, err := url.Parse("pulsar://127.0.0.1:6650/?protocol=canal-json")
+	require.NoError(t, err)
+	components, _, err := worker.GetPulsarSinkComponentWithFactory(
+		context.Background(), changefeedID, noTopicURI, newSinkConfig(), newFactory(client))
+	require.Error(t, err)
+	require.Nil(t, components.Client)
+	require.Equal(t, int32(1), client.closed.Load())
+}
@@ -0,0 +1,302 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/worker"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/redo"
+	"github.com/pingcap/ticdc/utils/heap"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	predo "github.com/pingcap/tiflow/pkg/redo"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// closeRedoSinkTimeout is the timeout to clean the redo files when the changefeed is removed.
+const closeRedoSinkTimeout = 30 * time.Second
+
+// RedoSink writes the events of the dispatchers to the redo log before
+// they are sent to the real sink, which makes it possible to restore the
+// downstream to a consistent snapshot after a disaster.
+// It's not a Sink, the dispatchers use it beside the real sink.
+//
+// An event is released to the real sink only after the global redo resolvedTs
+// of the changefeed in the flushed redo meta reaches its commitTs, that is,
+// all the events of all the tables before it are persisted in the redo log.
+// Otherwise the downstream may be ahead of the redo log for some tables
+// and can't be restored to a consistent snapshot.
+type RedoSink struct {
+	changefeedID common.ChangeFeedID
+	// writerID identifies the files written by this redo sink.
+	writerID string
+	storage  storage.ExternalStorage
+
+	tracker    *redo.ResolvedTsTracker
+	dmlWriter  *redo.FileWriter
+	dmlWorker  *worker.RedoDMLWorker
+	metaWorker *worker.RedoMetaWorker
+
+	// ddlMu makes the ddl writes serial, ddls are rare so they are written synchronously.
+	ddlMu     sync.Mutex
+	ddlWriter *redo.FileWriter
+
+	// releaseMu protects the events waiting for the global redo resolvedTs.
+	releaseMu sync.Mutex
+	// flushedResolvedTs is the resolvedTs of the latest flushed redo meta.
+	flushedResolvedTs uint64
+	pendingReleases   *heap.Heap[*pendingRelease]
+	releaseSeq        uint64
+	releaseCh         chan struct{}
+
+	// isNormal means the sink does not meet error.
+	// if sink is normal, isNormal is 1, otherwise is 0
+	isNormal uint32
+	ctx      context.Context
+}
+
+// NewRedoSink creates a redo sink writing to the storage in the consistent config.
+// startTs is the checkpointTs of the changefeed when the sink is created.
+func NewRedoSink(
+	ctx context.Context, changefeedID common.ChangeFeedID, cfg *config.ConsistentConfig, startTs uint64,
+) (*RedoSink, error) {
+	if cfg == nil || !predo.IsConsistentEnabled(cfg.Level) {
+		return nil, errors.ErrRedoConfigInvalid.GenWithStack("redo log is not enabled")
+	}
+	extStorage, err := redo.NewExternalStorage(ctx, cfg.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	writerID := uuid.NewString()
+	tracker := redo.NewResolvedTsTracker()
+	dmlWriter := redo.NewFileWriter(
+		writerID, changefeedID, predo.RedoRowLogFileType, extStorage, cfg.MaxLogSize, cfg.Compression)
+	ddlWriter := redo.NewFileWriter(
+		writerID, changefeedID, predo.RedoDDLLogFileType, extStorage, cfg.MaxLogSize, cfg.Compression)
+	s := &RedoSink{
+		changefeedID:      changefeedID,
+		writerID:          writerID,
+		storage:           extStorage,
+		tracker:           tracker,
+		dmlWriter:         dmlWriter,
+		dmlWorker:         worker.NewRedoDMLWorker(changefeedID, dmlWriter, tracker),
+		ddlWriter:         ddlWriter,
+		flushedResolvedTs: startTs,
+		pendingReleases:   heap.NewHeap[*pendingRelease](),
+		releaseCh:         make(chan struct{}, 1),
+		isNormal:          1,
+		ctx:               ctx,
+	}
+	s.metaWorker = worker.NewRedoMetaWorker(writerID, changefeedID, extStorage,
+		[]*redo.FileWriter{dmlWriter, ddlWriter}, startTs, cfg.Compression, cfg.MetaFlushIntervalInMs,
+		s.onMetaFlushed)
+	log.Info("redo sink created",
+		zap.String("namespace", changefeedID.Namespace()),
+		zap.String("changefeed", changefeedID.Name()),
+		zap.String("writerID", writerID),
+		zap.String("storage", extStorage.URI()))
+	return s, nil
+}
+
+func (s *RedoSink) Run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return s.dmlWorker.Run(ctx)
+	})
+	g.Go(func() error {
+		return s.metaWorker.Run(ctx)
+	})
+	g.Go(func() error {
+		return s.runRelease(ctx)
+	})
+	err := g.Wait()
+	atomic.StoreUint32(&s.isNormal, 0)
+	return errors.Trace(err)
+}
+
+func (s *RedoSink) IsNormal() bool {
+	return atomic.LoadUint32(&s.isNormal) == 1
+}
+
+// AddDispatcher starts tracking the redo resolvedTs of the dispatcher.
+func (s *RedoSink) AddDispatcher(id common.DispatcherID, startTs uint64) {
+	s.tracker.Add(id, startTs)
+}
+
+// RemoveDispatcher stops tracking the redo resolvedTs of the dispatcher.
+func (s *RedoSink) RemoveDispatcher(id common.DispatcherID) {
+	s.tracker.Remove(id)
+}
+
+// AddDMLEvent writes the event to the redo log asynchronously, the callback is called
+// after the event is persisted and the global redo resolvedTs reaches its commitTs.
+func (s *RedoSink) AddDMLEvent(event *commonEvent.DMLEvent, callback func()) {
+	s.dmlWorker.AddDMLEvent(event, func() {
+		s.AddReleaseCallback(event.CommitTs, callback)
+	})
+}
+
+// AddFlushCallback adds a callback which is called after all the previous
+// events are persisted in the redo log.
+func (s *RedoSink) AddFlushCallback(callback func()) {
+	s.dmlWorker.AddCallback(callback)
+}
+
+// AddReleaseCallback adds a callback which is called after the global redo resolvedTs
+// in the flushed redo meta reaches commitTs.
+// The callbacks are called in the order of commitTs, and in the order they are added for the same commitTs.
+func (s *RedoSink) AddReleaseCallback(commitTs uint64, callback func()) {
+	s.releaseMu.Lock()
+	s.releaseSeq++
+	s.pendingReleases.AddOrUpdate(&pendingRelease{commitTs: commitTs, seq: s.releaseSeq, callback: callback})
+	ready := commitTs <= s.flushedResolvedTs
+	s.releaseMu.Unlock()
+	if ready {
+		s.notifyRelease()
+	}
+}
+
+// GetResolvedTs returns the min redo resolvedTs of the dispatchers in the redo sink,
+// it returns false if there is no dispatcher.
+func (s *RedoSink) GetResolvedTs() (uint64, bool) {
+	return s.tracker.Min()
+}
+
+// UpdateGlobalResolvedTs updates the global redo resolvedTs of the changefeed calculated by the maintainer,
+// it's written to the redo meta and the events before it are released after the meta is flushed.
+func (s *RedoSink) UpdateGlobalResolvedTs(resolvedTs uint64) {
+	s.metaWorker.AddResolvedTs(resolvedTs)
+}
+
+func (s *RedoSink) onMetaFlushed(resolvedTs uint64) {
+	s.releaseMu.Lock()
+	if resolvedTs <= s.flushedResolvedTs {
+		s.releaseMu.Unlock()
+		return
+	}
+	s.flushedResolvedTs = resolvedTs
+	s.releaseMu.Unlock()
+	s.notifyRelease()
+}
+
+func (s *RedoSink) notifyRelease() {
+	select {
+	case s.releaseCh <- struct{}{}:
+	default:
+	}
+}
+
+// runRelease calls the callbacks whose commitTs is reached by the flushed global redo resolvedTs.
+func (s *RedoSink) runRelease(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-s.releaseCh:
+		}
+		for _, callback := range s.popReleased() {
+			callback()
+		}
+	}
+}
+
+func (s *RedoSink) popReleased() []func() {
+	s.releaseMu.Lock()
+	defer s.releaseMu.Unlock()
+	var callbacks []func()
+	for {
+		top, ok := s.pendingReleases.PeekTop()
+		if !ok || top.commitTs > s.flushedResolvedTs {
+			return callbacks
+		}
+		s.pendingReleases.PopTop()
+		callbacks = append(callbacks, top.callback)
+	}
+}
+
+// AddResolvedTs advances the redo resolvedTs of the dispatcher
+// after all the previous events of the dispatcher are persisted.
+func (s *RedoSink) AddResolvedTs(id common.DispatcherID, resolvedTs uint64) {
+	s.dmlWorker.AddResolvedTs(id, resolvedTs)
+}
+
+// WriteBlockEvent writes the ddl event to the redo log synchronously.
+// The reader removes the duplicated ddls, so it's safe to write the ddl by several dispatchers.
+// Sync point events are not recorded in the redo log.
+func (s *RedoSink) WriteBlockEvent(event commonEvent.BlockEvent) error {
+	ddl, ok := event.(*commonEvent.DDLEvent)
+	if !ok || ddl.TiDBOnly {
+		return nil
+	}
+	s.ddlMu.Lock()
+	defer s.ddlMu.Unlock()
+	err := s.ddlWriter.WriteLogs(s.ctx, []*redo.RedoLog{redo.NewRedoLogFromDDLEvent(ddl)})
+	if err != nil {
+		atomic.StoreUint32(&s.isNormal, 0)
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// AddCheckpointTs updates the checkpointTs recorded in the redo meta.
+func (s *RedoSink) AddCheckpointTs(ts uint64) {
+	s.metaWorker.AddCheckpointTs(ts)
+}
+
+// Close stops the redo sink.
+// If the changefeed is removed, all the redo files written by the sink are removed.
+func (s *RedoSink) Close(removeChangefeed bool) {
+	s.dmlWorker.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), closeRedoSinkTimeout)
+	defer cancel()
+	s.metaWorker.Close(ctx, removeChangefeed)
+	s.dmlWriter.Close()
+	s.ddlWriter.Close()
+	s.storage.Close()
+	log.Info("redo sink closed",
+		zap.String("namespace", s.changefeedID.Namespace()),
+		zap.String("changefeed", s.changefeedID.Name()),
+		zap.String("writerID", s.writerID),
+		zap.Bool("removeChangefeed", removeChangefeed))
+}
+
+// pendingRelease is a callback waiting for the global redo resolvedTs to reach commitTs.
+type pendingRelease struct {
+	commitTs  uint64
+	seq       uint64
+	callback  func()
+	heapIndex int
+}
+
+func (r *pendingRelease) SetHeapIndex(index int) { r.heapIndex = index }
+
+func (r *pendingRelease) GetHeapIndex() int { return r.heapIndex }
+
+func (r *pendingRelease) LessThan(other *pendingRelease) bool {
+	if r.commitTs != other.commitTs {
+		return r.commitTs < other.commitTs
+	}
+	return r.seq < other.seq
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/redo"
+	"github.com/pingcap/tiflow/pkg/compression"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedoSinkWriteEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	cfg := &config.ConsistentConfig{
+		Level:                 "eventual",
+		MaxLogSize:            1,
+		MetaFlushIntervalInMs: 50,
+		Storage:               fmt.Sprintf("file://%s", dir),
+		Compression:           compression.LZ4,
+	}
+	changefeedID := common.NewChangefeedID4Test("test", "redo")
+	sink, err := NewRedoSink(ctx, changefeedID, cfg, 100)
+	require.NoError(t, err)
+	go sink.Run(ctx)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	dispatcherID := common.NewDispatcherID()
+	sink.AddDispatcher(dispatcherID, 100)
+
+	ddlEvent := &commonEvent.DDLEvent{
+		Query:      job.Query,
+		Type:       byte(job.Type),
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		TableInfo:  helper.GetTableInfo(job),
+		FinishedTs: 105,
+	}
+	require.NoError(t, sink.WriteBlockEvent(ddlEvent))
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'a')", "insert into t values (2, 'b')")
+	dmlEvent.DispatcherID = dispatcherID
+	dmlEvent.CommitTs = 110
+	var flushed atomic.Bool
+	sink.AddDMLEvent(dmlEvent, func() { flushed.Store(true) })
+	sink.AddResolvedTs(dispatcherID, 120)
+	require.Eventually(t, func() bool {
+		resolvedTs, ok := sink.GetResolvedTs()
+		return ok && resolvedTs == 120
+	}, 5*time.Second, 10*time.Millisecond)
+	sink.UpdateGlobalResolvedTs(120)
+	require.Eventually(t, flushed.Load, 5*time.Second, 10*time.Millisecond)
+
+	// the meta is flushed after the global resolvedTs is advanced.
+	var reader *redo.LogReader
+	require.Eventually(t, func() bool {
+		reader, err = redo.NewLogReader(ctx, sink.storage)
+		if err != nil {
+			return false
+		}
+		_, resolvedTs := reader.ReadMeta()
+		return resolvedTs == 120
+	}, 5*time.Second, 50*time.Millisecond)
+	checkpointTs, _ := reader.ReadMeta()
+	require.Equal(t, uint64(100), checkpointTs)
+
+	logs, err := reader.ReadLogs(ctx)
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	require.Equal(t, redo.RedoLogTypeDDL, logs[0].Type)
+	require.Equal(t, job.Query, logs[0].DDL.Query)
+	require.Equal(t, redo.RedoLogTypeRow, logs[1].Type)
+	require.Equal(t, int32(2), logs[1].DML.Len())
+
+	// all the redo files are removed when the changefeed is removed.
+	sink.Close(true)
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestRedoSinkReleaseEventsByGlobalResolvedTs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := &config.ConsistentConfig{
+		Level:                 "eventual",
+		MaxLogSize:            1,
+		MetaFlushIntervalInMs: 50,
+		Storage:               fmt.Sprintf("file://%s", t.TempDir()),
+	}
+	changefeedID := common.NewChangefeedID4Test("test", "redo")
+	sink, err := NewRedoSink(ctx, changefeedID, cfg, 100)
+	require.NoError(t, err)
+	go sink.Run(ctx)
+	defer sink.Close(false)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table t1 (id int primary key)")
+	helper.DDL2Job("create table t2 (id int primary key)")
+
+	// the redo log of t1 is ahead of t2.
+	dispatcher1, dispatcher2 := common.NewDispatcherID(), common.NewDispatcherID()
+	sink.AddDispatcher(dispatcher1, 100)
+	sink.AddDispatcher(dispatcher2, 100)
+
+	dml1 := helper.DML2Event("test", "t1", "insert into t1 values (1)")
+	dml1.DispatcherID = dispatcher1
+	dml1.CommitTs = 110
+	var released1 atomic.Bool
+	sink.AddDMLEvent(dml1, func() { released1.Store(true) })
+	sink.AddResolvedTs(dispatcher1, 130)
+
+	dml2 := helper.DML2Event("test", "t2", "insert into t2 values (1)")
+	dml2.DispatcherID = dispatcher2
+	dml2.CommitTs = 105
+	var released2 atomic.Bool
+	sink.AddDMLEvent(dml2, func() { released2.Store(true) })
+	sink.AddResolvedTs(dispatcher2, 106)
+
+	var released3 atomic.Bool
+	sink.AddReleaseCallback(120, func() { released3.Store(true) })
+
+	// the global resolvedTs is limited by t2.
+	require.Eventually(t, func() bool {
+		resolvedTs, ok := sink.GetResolvedTs()
+		return ok && resolvedTs == 106
+	}, 5*time.Second, 10*time.Millisecond)
+	sink.UpdateGlobalResolvedTs(106)
+	require.Eventually(t, released2.Load, 5*time.Second, 10*time.Millisecond)
+	// the event of t1 is persisted, but it's not released until the global resolvedTs reaches it.
+	time.Sleep(200 * time.Millisecond)
+	require.False(t, released1.Load())
+	require.False(t, released3.Load())
+
+	sink.AddResolvedTs(dispatcher2, 115)
+	require.Eventually(t, func() bool {
+		resolvedTs, ok := sink.GetResolvedTs()
+		return ok && resolvedTs == 115
+	}, 5*time.Second, 10*time.Millisecond)
+	sink.UpdateGlobalResolvedTs(115)
+	require.Eventually(t, released1.Load, 5*time.Second, 10*time.Millisecond)
+	require.False(t, released3.Load())
+
+	// the redo meta records the global resolvedTs.
+	reader, err := redo.NewLogReader(ctx, sink.storage)
+	require.NoError(t, err)
+	_, resolvedTs := reader.ReadMeta()
+	require.Equal(t, uint64(115), resolvedTs)
+
+	sink.UpdateGlobalResolvedTs(120)
+	require.Eventually(t, released3.Load, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import "sync"
+
+// barrierValue holds a value that is switched to a new one at a barrier ts.
+// It's used to hot-reload the sink components, such as the event router and the column selectors,
+// without breaking the events which are already in flight:
+// the events whose commitTs is not larger than barrierTs still use the current value,
+// and the others use the next value.
+type barrierValue[T any] struct {
+	mu        sync.RWMutex
+	current   T
+	next      T
+	hasNext   bool
+	barrierTs uint64
+}
+
+func newBarrierValue[T any](v T) *barrierValue[T] {
+	return &barrierValue[T]{current: v}
+}
+
+// get returns the value used by the event with the given commitTs.
+func (b *barrierValue[T]) get(commitTs uint64) T {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.hasNext && commitTs > b.barrierTs {
+		return b.next
+	}
+	return b.current
+}
+
+// update sets the value used by the events whose commitTs is larger than barrierTs.
+// The barrier of the previous update is considered passed when a new update comes,
+// because the barrier ts is always a little ahead of the time the update is issued.
+func (b *barrierValue[T]) update(v T, barrierTs uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.hasNext {
+		b.current = b.next
+	}
+	b.next = v
+	b.hasNext = true
+	b.barrierTs = barrierTs
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBarrierValue(t *testing.T) {
+	t.Parallel()
+
+	v := newBarrierValue("a")
+	require.Equal(t, "a", v.get(100))
+
+	// The events after the barrier use the next value.
+	v.update("b", 100)
+	require.Equal(t, "a", v.get(99))
+	require.Equal(t, "a", v.get(100))
+	require.Equal(t, "b", v.get(101))
+
+	// The previous next value becomes the current one when a new update comes.
+	v.update("c", 200)
+	require.Equal(t, "b", v.get(100))
+	require.Equal(t, "b", v.get(200))
+	require.Equal(t, "c", v.get(201))
+}
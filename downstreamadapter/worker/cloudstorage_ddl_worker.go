@@ -0,0 +1,298 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/robfig/cron"
+	"go.uber.org/zap"
+)
+
+// checkpointFileName is the name of the file recording the checkpoint ts of the changefeed.
+const checkpointFileName = "metadata"
+
+// CloudStorageDDLWorker writes the schema files of DDL events and the
+// checkpoint file to the cloud storage.
+type CloudStorageDDLWorker struct {
+	changefeedID commonType.ChangeFeedID
+	sinkURI      *url.URL
+	statistics   *metrics.Statistics
+	storage      storage.ExternalStorage
+	config       *cloudstorage.Config
+	cron         *cron.Cron
+
+	checkpointTsChan         chan uint64
+	lastCheckpointTs         atomic.Uint64
+	lastSendCheckpointTsTime time.Time
+	tableSchemaStore         *util.TableSchemaStore
+
+	cleanupJobs []func() /* only for test */
+}
+
+// NewCloudStorageDDLWorker return a ddl worker instance.
+func NewCloudStorageDDLWorker(
+	changefeedID commonType.ChangeFeedID,
+	sinkURI *url.URL,
+	config *cloudstorage.Config,
+	storage storage.ExternalStorage,
+	statistics *metrics.Statistics,
+) *CloudStorageDDLWorker {
+	return &CloudStorageDDLWorker{
+		changefeedID:             changefeedID,
+		sinkURI:                  sinkURI,
+		config:                   config,
+		storage:                  storage,
+		statistics:               statistics,
+		checkpointTsChan:         make(chan uint64, 16),
+		lastSendCheckpointTsTime: time.Now(),
+	}
+}
+
+// Run writes the checkpoint ts periodically and cleans up the expired files.
+func (w *CloudStorageDDLWorker) Run(ctx context.Context) error {
+	if err := w.initCron(ctx); err != nil {
+		return errors.Trace(err)
+	}
+	// Note: It is intended to run the cleanup goroutine in the background.
+	// we don't wait for it to finish since the gourotine would be stuck if
+	// the downstream is abnormal, especially when the downstream is a nfs.
+	go w.bgCleanup(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case ts, ok := <-w.checkpointTsChan:
+			if !ok {
+				log.Warn("cloud storage sink checkpoint ts channel closed",
+					zap.String("namespace", w.changefeedID.Namespace()),
+					zap.String("changefeed", w.changefeedID.Name()))
+				return nil
+			}
+			if err := w.writeCheckpointTs(ctx, ts); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// AddCheckpoint adds a checkpoint ts to be written.
+func (w *CloudStorageDDLWorker) AddCheckpoint(ts uint64) {
+	w.checkpointTsChan <- ts
+}
+
+func (w *CloudStorageDDLWorker) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore) {
+	w.tableSchemaStore = tableSchemaStore
+}
+
+// WriteBlockEvent writes the schema files of the ddl event to the cloud storage.
+func (w *CloudStorageDDLWorker) WriteBlockEvent(ctx context.Context, event *commonEvent.DDLEvent) error {
+	for _, e := range event.GetEvents() {
+		var def cloudstorage.TableDefinition
+		def.FromDDLEvent(e, w.config.OutputColumnID)
+		if err := w.writeFile(ctx, e, def); err != nil {
+			return errors.Trace(err)
+		}
+
+		if timodel.ActionType(e.Type) == timodel.ActionExchangeTablePartition {
+			// For exchange partition, we need to write the schema of the source table.
+			for _, tableInfo := range e.MultipleTableInfos {
+				if tableInfo == nil || tableInfo == e.TableInfo {
+					continue
+				}
+				var sourceTableDef cloudstorage.TableDefinition
+				sourceTableDef.FromTableInfo(tableInfo, e.FinishedTs, w.config.OutputColumnID)
+				if err := w.writeFile(ctx, e, sourceTableDef); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+	}
+	// after flush all the ddl event, we call the callback function.
+	event.PostFlush()
+	return nil
+}
+
+func (w *CloudStorageDDLWorker) writeFile(
+	ctx context.Context, event *commonEvent.DDLEvent, def cloudstorage.TableDefinition,
+) error {
+	encodedDef, err := def.MarshalWithQuery()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	path, err := def.GenerateSchemaFilePath()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	log.Debug("write ddl event to external storage",
+		zap.String("path", path), zap.Any("ddl", event.Query))
+	return w.statistics.RecordDDLExecution(func() error {
+		return w.storage.WriteFile(ctx, path, encodedDef)
+	})
+}
+
+func (w *CloudStorageDDLWorker) writeCheckpointTs(ctx context.Context, ts uint64) error {
+	if time.Since(w.lastSendCheckpointTsTime) < 2*time.Second {
+		log.Debug("skip write checkpoint ts to external storage",
+			zap.Any("changefeedID", w.changefeedID),
+			zap.Uint64("ts", ts))
+		return nil
+	}
+
+	defer func() {
+		w.lastSendCheckpointTsTime = time.Now()
+		w.lastCheckpointTs.Store(ts)
+	}()
+	ckpt, err := json.Marshal(map[string]uint64{"checkpoint-ts": ts})
+	if err != nil {
+		return errors.WrapError(errors.ErrStorageSinkInvalidConfig, err)
+	}
+	err = w.storage.WriteFile(ctx, checkpointFileName, ckpt)
+	return errors.Trace(err)
+}
+
+func (w *CloudStorageDDLWorker) initCron(ctx context.Context) (err error) {
+	cleanupJobs := w.cleanupJobs
+	if cleanupJobs == nil {
+		cleanupJobs = w.genCleanupJob(ctx)
+	}
+
+	w.cron = cron.New()
+	for _, job := range cleanupJobs {
+		err = w.cron.AddFunc(w.config.FileCleanupCronSpec, job)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *CloudStorageDDLWorker) bgCleanup(ctx context.Context) {
+	if w.config.DateSeparator != config.DateSeparatorDay.String() || w.config.FileExpirationDays <= 0 {
+		log.Info("skip cleanup expired files for storage sink",
+			zap.String("namespace", w.changefeedID.Namespace()),
+			zap.String("changefeed", w.changefeedID.Name()),
+			zap.String("dateSeparator", w.config.DateSeparator),
+			zap.Int("expiredFileTTL", w.config.FileExpirationDays))
+		return
+	}
+
+	w.cron.Start()
+	defer w.cron.Stop()
+	log.Info("start schedule cleanup expired files for storage sink",
+		zap.String("namespace", w.changefeedID.Namespace()),
+		zap.String("changefeed", w.changefeedID.Name()),
+		zap.String("dateSeparator", w.config.DateSeparator),
+		zap.Int("expiredFileTTL", w.config.FileExpirationDays))
+
+	// wait for the context done
+	<-ctx.Done()
+	log.Info("stop schedule cleanup expired files for storage sink",
+		zap.String("namespace", w.changefeedID.Namespace()),
+		zap.String("changefeed", w.changefeedID.Name()),
+		zap.Error(ctx.Err()))
+}
+
+func (w *CloudStorageDDLWorker) genCleanupJob(ctx context.Context) []func() {
+	var ret []func()
+
+	isLocal := w.sinkURI.Scheme == "file" || w.sinkURI.Scheme == "local" || w.sinkURI.Scheme == ""
+	var isRemoveEmptyDirsRunning atomic.Bool
+	if isLocal {
+		ret = append(ret, func() {
+			if !isRemoveEmptyDirsRunning.CompareAndSwap(false, true) {
+				log.Warn("remove empty dirs is already running, skip this round",
+					zap.String("namespace", w.changefeedID.Namespace()),
+					zap.String("changefeed", w.changefeedID.Name()))
+				return
+			}
+			defer isRemoveEmptyDirsRunning.Store(false)
+
+			checkpointTs := w.lastCheckpointTs.Load()
+			start := time.Now()
+			cnt, err := cloudstorage.RemoveEmptyDirs(ctx, w.changefeedID, w.sinkURI.Path)
+			if err != nil {
+				log.Error("failed to remove empty dirs",
+					zap.String("namespace", w.changefeedID.Namespace()),
+					zap.String("changefeed", w.changefeedID.Name()),
+					zap.Uint64("checkpointTs", checkpointTs),
+					zap.Duration("cost", time.Since(start)),
+					zap.Error(err),
+				)
+				return
+			}
+			log.Info("remove empty dirs",
+				zap.String("namespace", w.changefeedID.Namespace()),
+				zap.String("changefeed", w.changefeedID.Name()),
+				zap.Uint64("checkpointTs", checkpointTs),
+				zap.Uint64("count", cnt),
+				zap.Duration("cost", time.Since(start)))
+		})
+	}
+
+	var isCleanupRunning atomic.Bool
+	ret = append(ret, func() {
+		if !isCleanupRunning.CompareAndSwap(false, true) {
+			log.Warn("cleanup expired files is already running, skip this round",
+				zap.String("namespace", w.changefeedID.Namespace()),
+				zap.String("changefeed", w.changefeedID.Name()))
+			return
+		}
+
+		defer isCleanupRunning.Store(false)
+		start := time.Now()
+		checkpointTs := w.lastCheckpointTs.Load()
+		cnt, err := cloudstorage.RemoveExpiredFiles(ctx, w.changefeedID, w.storage, w.config, checkpointTs)
+		if err != nil {
+			log.Error("failed to remove expired files",
+				zap.String("namespace", w.changefeedID.Namespace()),
+				zap.String("changefeed", w.changefeedID.Name()),
+				zap.Uint64("checkpointTs", checkpointTs),
+				zap.Duration("cost", time.Since(start)),
+				zap.Error(err),
+			)
+			return
+		}
+		log.Info("remove expired files",
+			zap.String("namespace", w.changefeedID.Namespace()),
+			zap.String("changefeed", w.changefeedID.Name()),
+			zap.Uint64("checkpointTs", checkpointTs),
+			zap.Uint64("count", cnt),
+			zap.Duration("cost", time.Since(start)))
+	})
+	return ret
+}
+
+// Close closes the worker.
+func (w *CloudStorageDDLWorker) Close() {
+	if w.cron != nil {
+		w.cron.Stop()
+	}
+}
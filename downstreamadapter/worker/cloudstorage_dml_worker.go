@@ -0,0 +1,165 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/worker/writer"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/ticdc/pkg/sink/codec"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/utils/chann"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultEncodingConcurrency = 8
+	defaultChannelSize         = 1024
+)
+
+// CloudStorageDMLWorker is used to write the DML events to the cloud storage.
+// The data flow is as follows: **data** -> encodingWorkers -> defragmenter -> writers -> external storage
+// The defragmenter will defragment the out-of-order encoded messages and sends encoded
+// messages to individual writers.
+// The writers will write the encoded messages to external storage in parallel between different tables.
+type CloudStorageDMLWorker struct {
+	changefeedID commonType.ChangeFeedID
+	// last sequence number
+	lastSeqNum uint64
+	// encodingWorkers defines a group of workers for encoding events.
+	encodingWorkers []*writer.EncodingWorker
+	// defragmenter is used to defragment the out-of-order encoded messages and
+	// sends encoded messages to individual writers.
+	defragmenter *writer.Defragmenter
+	// writers defines a group of workers for writing events to external storage.
+	writers []*writer.Writer
+	// msgCh is a channel to hold EventFragment.
+	// The caller of AddDMLEvent will write EventFragment to msgCh and
+	// the encodingWorkers will read EventFragment from msgCh to encode events.
+	msgCh *chann.DrainableChann[writer.EventFragment]
+
+	statistics *metrics.Statistics
+}
+
+// NewCloudStorageDMLWorker creates a dml worker for cloud storage sink.
+func NewCloudStorageDMLWorker(
+	changefeedID commonType.ChangeFeedID,
+	storage storage.ExternalStorage,
+	config *cloudstorage.Config,
+	encoderConfig *common.Config,
+	extension string,
+	pdClock pdutil.Clock,
+	statistics *metrics.Statistics,
+) (*CloudStorageDMLWorker, error) {
+	w := &CloudStorageDMLWorker{
+		changefeedID:    changefeedID,
+		encodingWorkers: make([]*writer.EncodingWorker, defaultEncodingConcurrency),
+		writers:         make([]*writer.Writer, config.WorkerCount),
+		msgCh:           chann.NewAutoDrainChann[writer.EventFragment](),
+		statistics:      statistics,
+	}
+	encodedOutCh := make(chan writer.EventFragment, defaultChannelSize)
+	workerChannels := make([]*chann.DrainableChann[writer.EventFragment], config.WorkerCount)
+	// create a group of encoding workers.
+	for i := 0; i < defaultEncodingConcurrency; i++ {
+		encoder, err := codec.NewTxnEventEncoder(encoderConfig)
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrStorageSinkInvalidConfig, err)
+		}
+		w.encodingWorkers[i] = writer.NewEncodingWorker(i, changefeedID, encoder, w.msgCh.Out(), encodedOutCh)
+	}
+
+	// create a group of writers.
+	for i := 0; i < config.WorkerCount; i++ {
+		inputCh := chann.NewAutoDrainChann[writer.EventFragment]()
+		w.writers[i] = writer.NewWriter(i, changefeedID, storage, config, extension,
+			inputCh, pdClock, statistics)
+		workerChannels[i] = inputCh
+	}
+
+	// create defragmenter.
+	// The defragmenter is used to defragment the out-of-order encoded messages from encoding workers and
+	// sends encoded messages to related writers in order. Messages of the same table will be sent to
+	// the same writer.
+	w.defragmenter = writer.NewDefragmenter(encodedOutCh, workerChannels)
+	return w, nil
+}
+
+// Run starts the encoding workers, the defragmenter and the writers.
+func (w *CloudStorageDMLWorker) Run(ctx context.Context) error {
+	eg, ctx := errgroup.WithContext(ctx)
+
+	for i := 0; i < len(w.encodingWorkers); i++ {
+		encodingWorker := w.encodingWorkers[i]
+		eg.Go(func() error {
+			return encodingWorker.Run(ctx)
+		})
+	}
+
+	eg.Go(func() error {
+		return w.defragmenter.Run(ctx)
+	})
+
+	for i := 0; i < len(w.writers); i++ {
+		worker := w.writers[i]
+		eg.Go(func() error {
+			return worker.Run(ctx)
+		})
+	}
+
+	log.Info("cloud storage dml worker started",
+		zap.String("namespace", w.changefeedID.Namespace()),
+		zap.String("changefeed", w.changefeedID.Name()),
+		zap.Int("workerCount", len(w.writers)))
+
+	return eg.Wait()
+}
+
+// AddDMLEvent adds a DML event to the worker.
+func (w *CloudStorageDMLWorker) AddDMLEvent(event *commonEvent.DMLEvent) {
+	tbl := cloudstorage.VersionedTableName{
+		TableNameWithPhysicTableID: commonType.TableName{
+			Schema:      event.TableInfo.GetSchemaName(),
+			Table:       event.TableInfo.GetTableName(),
+			TableID:     event.PhysicalTableID,
+			IsPartition: event.TableInfo.IsPartitionTable(),
+		},
+		TableInfoVersion: event.TableInfoVersion,
+	}
+	seq := atomic.AddUint64(&w.lastSeqNum, 1)
+	// emit a EventFragment encoupled with a sequence number starting from one.
+	w.msgCh.In() <- writer.NewEventFragment(seq, tbl, event)
+}
+
+// Close closes the worker.
+func (w *CloudStorageDMLWorker) Close() {
+	for _, encodingWorker := range w.encodingWorkers {
+		encodingWorker.Close()
+	}
+
+	for _, worker := range w.writers {
+		worker.Close()
+	}
+	w.msgCh.CloseAndDrain()
+}
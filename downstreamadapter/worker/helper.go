@@ -17,6 +17,8 @@ import (
 	"context"
 	"net/url"
 
+	pulsarClient "github.com/apache/pulsar-client-go/pulsar"
+	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/helper"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/eventrouter"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/topicmanager"
@@ -28,9 +30,11 @@ import (
 	"github.com/pingcap/ticdc/pkg/sink/codec/common"
 	"github.com/pingcap/ticdc/pkg/sink/kafka"
 	v2 "github.com/pingcap/ticdc/pkg/sink/kafka/v2"
+	"github.com/pingcap/ticdc/pkg/sink/pulsar"
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/tidb/br/pkg/utils"
 	"github.com/pingcap/tiflow/pkg/sink"
+	"go.uber.org/zap"
 )
 
 type KafkaComponent struct {
@@ -144,3 +148,137 @@ func GetKafkaSinkComponentForTest(
 ) (KafkaComponent, config.Protocol, error) {
 	return getKafkaSinkComponentWithFactory(ctx, changefeedID, sinkURI, sinkConfig, kafka.NewMockFactory)
 }
+
+type PulsarComponent struct {
+	Config         *config.PulsarConfig
+	EncoderGroup   codec.EncoderGroup
+	Encoder        common.EventEncoder
+	ColumnSelector *columnselector.ColumnSelectors
+	EventRouter    *eventrouter.EventRouter
+	TopicManager   topicmanager.TopicManager
+	Client         pulsarClient.Client
+}
+
+// isPulsarSupportedProtocols returns whether the protocol is supported by pulsar.
+func isPulsarSupportedProtocols(p config.Protocol) bool {
+	return p == config.ProtocolCanalJSON
+}
+
+// GetPulsarSinkComponentWithFactory creates the components of the pulsar sink,
+// the pulsar client is created by the factoryCreator.
+func GetPulsarSinkComponentWithFactory(ctx context.Context,
+	changefeedID commonType.ChangeFeedID,
+	sinkURI *url.URL,
+	sinkConfig *config.SinkConfig,
+	factoryCreator pulsar.FactoryCreator,
+) (pulsarComponent PulsarComponent, protocol config.Protocol, err error) {
+	protocol, err = helper.GetProtocol(utils.GetOrZero(sinkConfig.Protocol))
+	if err != nil {
+		return pulsarComponent, config.ProtocolUnknown, errors.Trace(err)
+	}
+	if !isPulsarSupportedProtocols(protocol) {
+		return pulsarComponent, protocol, errors.ErrSinkURIInvalid.
+			GenWithStackByArgs("unsupported protocol, " +
+				"pulsar sink currently only support these protocols: [canal-json]")
+	}
+
+	pulsarComponent.Config, err = pulsar.NewPulsarConfig(sinkURI, sinkConfig.PulsarConfig)
+	if err != nil {
+		return pulsarComponent, protocol, errors.WrapError(errors.ErrPulsarInvalidConfig, err)
+	}
+
+	pulsarComponent.Client, err = factoryCreator(pulsarComponent.Config, changefeedID, sinkConfig)
+	if err != nil {
+		log.Error("pulsar client create failed",
+			zap.String("namespace", changefeedID.Namespace()),
+			zap.String("changefeed", changefeedID.Name()),
+			zap.Error(err))
+		return pulsarComponent, protocol, errors.WrapError(errors.ErrPulsarNewClient, err)
+	}
+
+	// We must close the client when this func return cause by an error
+	// otherwise the client will never be closed and lead to a goroutine leak.
+	// The client is reset so that the caller does not close it again.
+	defer func() {
+		if err != nil && pulsarComponent.Client != nil {
+			pulsarComponent.Client.Close()
+			pulsarComponent.Client = nil
+		}
+	}()
+
+	topic, err := helper.GetTopic(sinkURI)
+	if err != nil {
+		return pulsarComponent, protocol, errors.Trace(err)
+	}
+	pulsarComponent.TopicManager = topicmanager.NewPulsarTopicManager(pulsarComponent.Config, pulsarComponent.Client)
+
+	scheme := sink.GetScheme(sinkURI)
+	pulsarComponent.EventRouter, err = eventrouter.NewEventRouter(sinkConfig, protocol, topic, scheme)
+	if err != nil {
+		return pulsarComponent, protocol, errors.Trace(err)
+	}
+
+	pulsarComponent.ColumnSelector, err = columnselector.NewColumnSelectors(sinkConfig)
+	if err != nil {
+		return pulsarComponent, protocol, errors.Trace(err)
+	}
+
+	encoderConfig, err := util.GetEncoderConfig(changefeedID, sinkURI, protocol, sinkConfig, config.DefaultMaxMessageBytes)
+	if err != nil {
+		return pulsarComponent, protocol, errors.Trace(err)
+	}
+
+	pulsarComponent.EncoderGroup, err = codec.NewEncoderGroup(ctx, sinkConfig, encoderConfig, changefeedID)
+	if err != nil {
+		return pulsarComponent, protocol, errors.Trace(err)
+	}
+
+	pulsarComponent.Encoder, err = codec.NewEventEncoder(ctx, encoderConfig)
+	if err != nil {
+		return pulsarComponent, protocol, errors.Trace(err)
+	}
+	return pulsarComponent, protocol, nil
+}
+
+func GetPulsarSinkComponent(
+	ctx context.Context,
+	changefeedID commonType.ChangeFeedID,
+	sinkURI *url.URL,
+	sinkConfig *config.SinkConfig,
+) (PulsarComponent, config.Protocol, error) {
+	return GetPulsarSinkComponentWithFactory(ctx, changefeedID, sinkURI, sinkConfig, pulsar.NewCreatorFactory)
+}
+
+func GetPulsarSinkComponentForTest(
+	ctx context.Context,
+	changefeedID commonType.ChangeFeedID,
+	sinkURI *url.URL,
+	sinkConfig *config.SinkConfig,
+) (PulsarComponent, config.Protocol, error) {
+	return GetPulsarSinkComponentWithFactory(ctx, changefeedID, sinkURI, sinkConfig, pulsar.NewMockCreatorFactory)
+}
+
+// GetMQRoutingComponent builds the event router and the column selectors from the sink config.
+// It's used to rebuild them when the changefeed config is updated.
+func GetMQRoutingComponent(
+	sinkURI *url.URL,
+	sinkConfig *config.SinkConfig,
+) (*eventrouter.EventRouter, *columnselector.ColumnSelectors, error) {
+	protocol, err := helper.GetProtocol(utils.GetOrZero(sinkConfig.Protocol))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	topic, err := helper.GetTopic(sinkURI)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	eventRouter, err := eventrouter.NewEventRouter(sinkConfig, protocol, topic, sink.GetScheme(sinkURI))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	columnSelector, err := columnselector.NewColumnSelectors(sinkConfig)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return eventRouter, columnSelector, nil
+}
@@ -39,7 +39,7 @@ type KafkaDDLWorker struct {
 	checkpointTsChan chan uint64
 	encoder          common.EventEncoder
 	// eventRouter used to route events to the right topic and partition.
-	eventRouter *eventrouter.EventRouter
+	eventRouter *barrierValue[*eventrouter.EventRouter]
 	// topicManager used to manage topics.
 	// It is also responsible for creating topics.
 	topicManager topicmanager.TopicManager
@@ -87,7 +87,7 @@ func NewKafkaDDLWorker(
 		changeFeedID:     id,
 		encoder:          encoder,
 		producer:         producer,
-		eventRouter:      eventRouter,
+		eventRouter:      newBarrierValue(eventRouter),
 		topicManager:     topicManager,
 		statistics:       statistics,
 		partitionRule:    getDDLDispatchRule(protocol),
@@ -103,6 +103,11 @@ func (w *KafkaDDLWorker) AddCheckpoint(ts uint64) {
 	w.checkpointTsChan <- ts
 }
 
+// UpdateEventRouter sets the event router used by the ddl events whose finishedTs is larger than barrierTs.
+func (w *KafkaDDLWorker) UpdateEventRouter(eventRouter *eventrouter.EventRouter, barrierTs uint64) {
+	w.eventRouter.update(eventRouter, barrierTs)
+}
+
 func (w *KafkaDDLWorker) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore) {
 	w.tableSchemaStore = tableSchemaStore
 }
@@ -113,7 +118,11 @@ func (w *KafkaDDLWorker) WriteBlockEvent(ctx context.Context, event *event.DDLEv
 		if err != nil {
 			return errors.Trace(err)
 		}
-		topic := w.eventRouter.GetTopicForDDL(e)
+		// some protocols don't send ddl events, such as avro.
+		if message == nil {
+			continue
+		}
+		topic := w.eventRouter.get(e.FinishedTs).GetTopicForDDL(e)
 
 		if w.partitionRule == PartitionAll {
 			partitionNum, err := w.topicManager.GetPartitionNum(ctx, topic)
@@ -177,7 +186,7 @@ func (w *KafkaDDLWorker) encodeAndSendCheckpointEvents(ctx context.Context) erro
 			// we need to send checkpoint ts to the default topic.
 			// This will be compatible with the old behavior.
 			if len(tableNames) == 0 {
-				topic := w.eventRouter.GetDefaultTopic()
+				topic := w.eventRouter.get(ts).GetDefaultTopic()
 				partitionNum, err = w.topicManager.GetPartitionNum(ctx, topic)
 				if err != nil {
 					return errors.Trace(err)
@@ -189,7 +198,7 @@ func (w *KafkaDDLWorker) encodeAndSendCheckpointEvents(ctx context.Context) erro
 					return errors.Trace(err)
 				}
 			} else {
-				topics := w.eventRouter.GetActiveTopics(tableNames)
+				topics := w.eventRouter.get(ts).GetActiveTopics(tableNames)
 				for _, topic := range topics {
 					partitionNum, err = w.topicManager.GetPartitionNum(ctx, topic)
 					if err != nil {
@@ -50,9 +50,9 @@ type KafkaDMLWorker struct {
 	eventChan chan *commonEvent.DMLEvent
 	rowChan   chan *commonEvent.MQRowEvent
 
-	columnSelector *columnselector.ColumnSelectors
+	columnSelector *barrierValue[*columnselector.ColumnSelectors]
 	// eventRouter used to route events to the right topic and partition.
-	eventRouter *eventrouter.EventRouter
+	eventRouter *barrierValue[*eventrouter.EventRouter]
 	// topicManager used to manage topics.
 	// It is also responsible for creating topics.
 	topicManager topicmanager.TopicManager
@@ -60,6 +60,9 @@ type KafkaDMLWorker struct {
 
 	// producer is used to send the messages to the Kafka broker.
 	producer producer.DMLProducer
+	// txnProducer is not nil if the messages of each upstream transaction
+	// are sent in one kafka transaction.
+	txnProducer producer.TxnDMLProducer
 
 	// statistics is used to record DML metrics.
 	statistics *metrics.Statistics
@@ -69,23 +72,26 @@ type KafkaDMLWorker struct {
 func NewKafkaDMLWorker(
 	id common.ChangeFeedID,
 	protocol config.Protocol,
-	producer producer.DMLProducer,
+	dmlProducer producer.DMLProducer,
 	encoderGroup codec.EncoderGroup,
 	columnSelector *columnselector.ColumnSelectors,
 	eventRouter *eventrouter.EventRouter,
 	topicManager topicmanager.TopicManager,
 	statistics *metrics.Statistics,
 ) *KafkaDMLWorker {
+	// The messages are sent in kafka transactions if the producer supports it.
+	txnProducer, _ := dmlProducer.(producer.TxnDMLProducer)
 	return &KafkaDMLWorker{
 		changeFeedID:   id,
 		protocol:       protocol,
 		eventChan:      make(chan *commonEvent.DMLEvent, 32),
 		rowChan:        make(chan *commonEvent.MQRowEvent, 32),
 		encoderGroup:   encoderGroup,
-		columnSelector: columnSelector,
-		eventRouter:    eventRouter,
+		columnSelector: newBarrierValue(columnSelector),
+		eventRouter:    newBarrierValue(eventRouter),
 		topicManager:   topicManager,
-		producer:       producer,
+		producer:       dmlProducer,
+		txnProducer:    txnProducer,
 		statistics:     statistics,
 	}
 }
@@ -104,12 +110,16 @@ func (w *KafkaDMLWorker) Run(ctx context.Context) error {
 		return w.encoderGroup.Run(ctx)
 	})
 
-	g.Go(func() error {
-		if w.protocol.IsBatchEncode() {
-			return w.batchEncodeRun(ctx)
-		}
-		return w.nonBatchEncodeRun(ctx)
-	})
+	// In transactional mode, the events are added to the encoder group
+	// by calculateKeyPartitions directly to keep the transaction boundary.
+	if w.txnProducer == nil {
+		g.Go(func() error {
+			if w.protocol.IsBatchEncode() {
+				return w.batchEncodeRun(ctx)
+			}
+			return w.nonBatchEncodeRun(ctx)
+		})
+	}
 
 	g.Go(func() error {
 		return w.sendMessages(ctx)
@@ -123,13 +133,14 @@ func (w *KafkaDMLWorker) calculateKeyPartitions(ctx context.Context) error {
 		case <-ctx.Done():
 			return errors.Trace(ctx.Err())
 		case event := <-w.eventChan:
-			topic := w.eventRouter.GetTopicForRowChange(event.TableInfo)
+			eventRouter := w.eventRouter.get(event.CommitTs)
+			topic := eventRouter.GetTopicForRowChange(event.TableInfo)
 			partitionNum, err := w.topicManager.GetPartitionNum(ctx, topic)
 			if err != nil {
 				return errors.Trace(err)
 			}
-			partitionGenerator := w.eventRouter.GetPartitionGenerator(event.TableInfo)
-			selector := w.columnSelector.GetSelector(event.TableInfo.TableName.Schema, event.TableInfo.TableName.Table)
+			partitionGenerator := eventRouter.GetPartitionGenerator(event.TableInfo)
+			selector := w.columnSelector.get(event.CommitTs).GetSelector(event.TableInfo.TableName.Schema, event.TableInfo.TableName.Table)
 			toRowCallback := func(postTxnFlushed []func(), totalCount uint64) func() {
 				var calledCount atomic.Uint64
 				// The callback of the last row will trigger the callback of the txn.
@@ -145,6 +156,7 @@ func (w *KafkaDMLWorker) calculateKeyPartitions(ctx context.Context) error {
 			rowsCount := uint64(event.Len())
 			rowCallback := toRowCallback(event.PostTxnFlushed, rowsCount)
 
+			var txnRows []*commonEvent.MQRowEvent
 			for {
 				row, ok := event.GetNextRow()
 				if !ok {
@@ -171,12 +183,48 @@ func (w *KafkaDMLWorker) calculateKeyPartitions(ctx context.Context) error {
 						ColumnSelector: selector,
 					},
 				}
+				if w.txnProducer != nil {
+					txnRows = append(txnRows, mqEvent)
+					continue
+				}
 				w.addMQRowEvent(mqEvent)
 			}
+			if w.txnProducer != nil {
+				if err = w.addTxnEvents(ctx, txnRows); err != nil {
+					return errors.Trace(err)
+				}
+			}
 		}
 	}
 }
 
+// addTxnEvents adds all the rows of one upstream transaction to the encoder group,
+// and marks the end of the transaction, so that they are sent in one kafka transaction.
+func (w *KafkaDMLWorker) addTxnEvents(ctx context.Context, rows []*commonEvent.MQRowEvent) error {
+	// Group rows by its TopicPartitionKey and keep the order of the first appearance,
+	// so the rows in the same partition are sent in order.
+	keys := make([]model.TopicPartitionKey, 0)
+	groupedRows := make(map[model.TopicPartitionKey][]*commonEvent.RowEvent)
+	for _, row := range rows {
+		if _, ok := groupedRows[row.Key]; !ok {
+			keys = append(keys, row.Key)
+		}
+		groupedRows[row.Key] = append(groupedRows[row.Key], &row.RowEvent)
+	}
+	for _, key := range keys {
+		if err := w.encoderGroup.AddEvents(ctx, key, groupedRows[key]...); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return w.encoderGroup.EndTxn(ctx)
+}
+
+// UpdateRouting sets the event router and the column selectors used by the events whose commitTs is larger than barrierTs.
+func (w *KafkaDMLWorker) UpdateRouting(eventRouter *eventrouter.EventRouter, columnSelector *columnselector.ColumnSelectors, barrierTs uint64) {
+	w.eventRouter.update(eventRouter, barrierTs)
+	w.columnSelector.update(columnSelector, barrierTs)
+}
+
 func (w *KafkaDMLWorker) AddDMLEvent(event *commonEvent.DMLEvent) {
 	w.eventChan <- event
 }
@@ -319,7 +367,23 @@ func (w *KafkaDMLWorker) sendMessages(ctx context.Context) error {
 	metricSendMessageDuration := metrics.WorkerSendMessageDuration.WithLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
 	defer metrics.WorkerSendMessageDuration.DeleteLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
 
-	var err error
+	var (
+		err   error
+		inTxn bool
+		// txnCallbacks are the callbacks of the messages in the ongoing transaction,
+		// they are called after the transaction is committed.
+		txnCallbacks []func()
+	)
+	defer func() {
+		if inTxn {
+			if abortErr := w.txnProducer.AbortTxn(); abortErr != nil {
+				log.Warn("abort kafka transaction failed",
+					zap.String("namespace", w.changeFeedID.Namespace()),
+					zap.String("changefeed", w.changeFeedID.Name()),
+					zap.Error(abortErr))
+			}
+		}
+	}()
 	outCh := w.encoderGroup.Output()
 	for {
 		select {
@@ -335,7 +399,31 @@ func (w *KafkaDMLWorker) sendMessages(ctx context.Context) error {
 			if err = future.Ready(ctx); err != nil {
 				return errors.Trace(err)
 			}
+			if future.TxnEnd {
+				if !inTxn {
+					continue
+				}
+				inTxn = false
+				if err = w.txnProducer.CommitTxn(); err != nil {
+					return errors.Trace(err)
+				}
+				for _, callback := range txnCallbacks {
+					callback()
+				}
+				txnCallbacks = txnCallbacks[:0]
+				continue
+			}
+			if w.txnProducer != nil && !inTxn && len(future.Messages) != 0 {
+				if err = w.txnProducer.BeginTxn(); err != nil {
+					return errors.Trace(err)
+				}
+				inTxn = true
+			}
 			for _, message := range future.Messages {
+				if w.txnProducer != nil && message.Callback != nil {
+					txnCallbacks = append(txnCallbacks, message.Callback)
+					message.Callback = nil
+				}
 				start := time.Now()
 				if err = w.statistics.RecordBatchExecution(func() (int, int64, error) {
 					if err = w.producer.AsyncSendMessage(
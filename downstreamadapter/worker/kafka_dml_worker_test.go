@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -33,6 +34,10 @@ import (
 var count int
 
 func kafkaDMLWorkerForTest(t *testing.T) *KafkaDMLWorker {
+	return kafkaDMLWorkerWithProducerForTest(t, producer.NewMockDMLProducer())
+}
+
+func kafkaDMLWorkerWithProducerForTest(t *testing.T, dmlProducer producer.DMLProducer) *KafkaDMLWorker {
 	ctx := context.Background()
 	changefeedID := common.NewChangefeedID4Test("test", "test")
 	openProtocol := "open-protocol"
@@ -48,9 +53,7 @@ func kafkaDMLWorkerForTest(t *testing.T) *KafkaDMLWorker {
 	require.NoError(t, err)
 
 	statistics := metrics.NewStatistics(changefeedID, "KafkaSink")
-	dmlMockProducer := producer.NewMockDMLProducer()
-
-	dmlWorker := NewKafkaDMLWorker(changefeedID, protocol, dmlMockProducer,
+	dmlWorker := NewKafkaDMLWorker(changefeedID, protocol, dmlProducer,
 		kafkaComponent.EncoderGroup, kafkaComponent.ColumnSelector,
 		kafkaComponent.EventRouter, kafkaComponent.TopicManager,
 		statistics)
@@ -89,3 +92,40 @@ func TestWriteEvents(t *testing.T) {
 	require.Equal(t, count, 1)
 	cancel()
 }
+
+func TestWriteEventsInTransaction(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	createTableSQL := "create table t (id int primary key, name varchar(32));"
+	job := helper.DDL2Job(createTableSQL)
+	require.NotNil(t, job)
+
+	var flushed atomic.Int64
+	dmlEvent1 := helper.DML2Event("test", "t", "insert into t values (1, 'test')", "insert into t values (2, 'test2');")
+	dmlEvent1.PostTxnFlushed = []func(){func() { flushed.Add(1) }}
+	dmlEvent1.CommitTs = 2
+	dmlEvent2 := helper.DML2Event("test", "t", "insert into t values (3, 'test3')")
+	dmlEvent2.PostTxnFlushed = []func(){func() { flushed.Add(1) }}
+	dmlEvent2.CommitTs = 3
+
+	txnProducer := producer.NewMockTxnDMLProducer()
+	dmlWorker := kafkaDMLWorkerWithProducerForTest(t, txnProducer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		err := dmlWorker.Run(ctx)
+		require.True(t, errors.Is(err, context.Canceled))
+	}()
+	dmlWorker.AddDMLEvent(dmlEvent1)
+	dmlWorker.AddDMLEvent(dmlEvent2)
+
+	require.Eventually(t, func() bool {
+		return flushed.Load() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	// Each upstream transaction is committed in its own kafka transaction.
+	require.Equal(t, []int{2, 1}, txnProducer.GetCommittedTxns())
+	require.Len(t, txnProducer.GetAllEvents(), 3)
+}
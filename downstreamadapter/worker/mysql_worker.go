@@ -21,6 +21,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/metrics"
@@ -62,6 +63,8 @@ func (w *MysqlDMLWorker) GetEventChan() chan *commonEvent.DMLEvent {
 	return w.eventChan
 }
 
+// Run flushes the dml events received from the event channel, it returns nil
+// after the event channel is closed and all the received events are flushed.
 func (w *MysqlDMLWorker) Run(ctx context.Context) error {
 	namespace := w.changefeedID.Namespace()
 	changefeed := w.changefeedID.Name()
@@ -81,10 +84,14 @@ func (w *MysqlDMLWorker) Run(ctx context.Context) error {
 	rows := 0
 	for {
 		needFlush := false
+		closed := false
 		select {
 		case <-ctx.Done():
 			return errors.Trace(ctx.Err())
-		case txnEvent := <-w.eventChan:
+		case txnEvent, ok := <-w.eventChan:
+			if !ok {
+				return nil
+			}
 			events = append(events, txnEvent)
 			rows += int(txnEvent.Len())
 			if rows > w.maxRows {
@@ -94,7 +101,12 @@ func (w *MysqlDMLWorker) Run(ctx context.Context) error {
 				delay := time.NewTimer(10 * time.Millisecond)
 				for !needFlush {
 					select {
-					case txnEvent := <-w.eventChan:
+					case txnEvent, ok := <-w.eventChan:
+						if !ok {
+							closed = true
+							needFlush = true
+							break
+						}
 						workerHandledRows.Add(float64(txnEvent.Len()))
 						events = append(events, txnEvent)
 						rows += int(txnEvent.Len())
@@ -126,6 +138,9 @@ func (w *MysqlDMLWorker) Run(ctx context.Context) error {
 			totalStart = time.Now()
 			events = events[:0]
 			rows = 0
+			if closed {
+				return nil
+			}
 		}
 	}
 }
@@ -134,6 +149,12 @@ func (w *MysqlDMLWorker) Close() {
 	w.mysqlWriter.Close()
 }
 
+// CloseEventChan stops the worker from receiving new events, the worker
+// returns from Run after the received events are flushed.
+func (w *MysqlDMLWorker) CloseEventChan() {
+	close(w.eventChan)
+}
+
 func (w *MysqlDMLWorker) AddDMLEvent(event *commonEvent.DMLEvent) {
 	w.eventChan <- event
 }
@@ -178,6 +199,15 @@ func (w *MysqlDDLWorker) GetStartTsList(tableIds []int64, startTsList []int64) (
 	return resTs, isSyncpointList, nil
 }
 
+// GetDMLCheckpoints returns the dml checkpoint recorded in the downstream for each dispatcher.
+func (w *MysqlDDLWorker) GetDMLCheckpoints(
+	dispatcherIDs []common.DispatcherID,
+	tableSpans []*heartbeatpb.TableSpan,
+	replicatingTsList []uint64,
+) ([]mysql.DMLCheckpoint, error) {
+	return w.mysqlWriter.GetDMLCheckpoints(dispatcherIDs, tableSpans, replicatingTsList)
+}
+
 func (w *MysqlDDLWorker) WriteBlockEvent(event commonEvent.BlockEvent) error {
 	switch event.GetType() {
 	case commonEvent.TypeDDLEvent:
@@ -37,6 +37,17 @@ type DMLProducer interface {
 	Close()
 }
 
+// TxnDMLProducer is the DMLProducer which sends messages in transactions.
+type TxnDMLProducer interface {
+	DMLProducer
+	// BeginTxn begins a transaction, the messages sent after it belong to the transaction.
+	BeginTxn() error
+	// CommitTxn commits the transaction, it returns after all messages in the transaction are flushed.
+	CommitTxn() error
+	// AbortTxn aborts the transaction.
+	AbortTxn() error
+}
+
 // kafkaDMLProducer is used to send messages to kafka.
 type KafkaDMLProducer struct {
 	// id indicates which processor (changefeed) this sink belongs to.
@@ -104,3 +115,47 @@ func (k *KafkaDMLProducer) Close() {
 	k.asyncProducer.Close()
 	k.closed = true
 }
+
+// KafkaTxnDMLProducer is used to send messages to kafka in kafka transactions.
+type KafkaTxnDMLProducer struct {
+	*KafkaDMLProducer
+	txnProducer kafka.TxnAsyncProducer
+}
+
+// NewKafkaTxnDMLProducer creates a new kafka producer which sends messages in transactions.
+func NewKafkaTxnDMLProducer(
+	changefeedID commonType.ChangeFeedID,
+	asyncProducer kafka.TxnAsyncProducer,
+) *KafkaTxnDMLProducer {
+	return &KafkaTxnDMLProducer{
+		KafkaDMLProducer: NewKafkaDMLProducer(changefeedID, asyncProducer),
+		txnProducer:      asyncProducer,
+	}
+}
+
+func (k *KafkaTxnDMLProducer) BeginTxn() error {
+	k.closedMu.RLock()
+	defer k.closedMu.RUnlock()
+	if k.closed {
+		return cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
+	}
+	return k.txnProducer.BeginTxn()
+}
+
+func (k *KafkaTxnDMLProducer) CommitTxn() error {
+	k.closedMu.RLock()
+	defer k.closedMu.RUnlock()
+	if k.closed {
+		return cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
+	}
+	return k.txnProducer.CommitTxn()
+}
+
+func (k *KafkaTxnDMLProducer) AbortTxn() error {
+	k.closedMu.RLock()
+	defer k.closedMu.RUnlock()
+	if k.closed {
+		return cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
+	}
+	return k.txnProducer.AbortTxn()
+}
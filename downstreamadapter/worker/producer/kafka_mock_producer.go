@@ -27,6 +27,14 @@ func NewMockDMLProducer() DMLProducer {
 	}
 }
 
+func NewMockTxnDMLProducer() *MockTxnProducer {
+	return &MockTxnProducer{
+		MockProducer: MockProducer{
+			events: make(map[string][]*common.Message),
+		},
+	}
+}
+
 func NewMockDDLProducer() DDLProducer {
 	return &MockProducer{
 		events: make(map[string][]*common.Message),
@@ -52,7 +60,9 @@ func (m *MockProducer) AsyncSendMessage(_ context.Context, topic string,
 	}
 	m.events[key] = append(m.events[key], message)
 
-	message.Callback()
+	if message.Callback != nil {
+		message.Callback()
+	}
 
 	return nil
 }
@@ -116,3 +126,64 @@ func (m *MockProducer) SyncSendMessage(_ context.Context, topic string,
 
 	return nil
 }
+
+// MockTxnProducer is a mock transactional producer for test.
+type MockTxnProducer struct {
+	MockProducer
+
+	inTxn   bool
+	pending int
+	// committedTxns records the message count of each committed transaction.
+	committedTxns []int
+}
+
+// AsyncSendMessage appends a message to the mock producer, it must be called in a transaction.
+func (m *MockTxnProducer) AsyncSendMessage(ctx context.Context, topic string,
+	partition int32, message *common.Message,
+) error {
+	m.mu.Lock()
+	if !m.inTxn {
+		m.mu.Unlock()
+		return fmt.Errorf("send message out of transaction")
+	}
+	m.pending++
+	m.mu.Unlock()
+	return m.MockProducer.AsyncSendMessage(ctx, topic, partition, message)
+}
+
+func (m *MockTxnProducer) BeginTxn() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.inTxn {
+		return fmt.Errorf("transaction already begun")
+	}
+	m.inTxn = true
+	return nil
+}
+
+func (m *MockTxnProducer) CommitTxn() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.inTxn {
+		return fmt.Errorf("transaction not begun")
+	}
+	m.committedTxns = append(m.committedTxns, m.pending)
+	m.inTxn = false
+	m.pending = 0
+	return nil
+}
+
+func (m *MockTxnProducer) AbortTxn() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inTxn = false
+	m.pending = 0
+	return nil
+}
+
+// GetCommittedTxns returns the message count of each committed transaction.
+func (m *MockTxnProducer) GetCommittedTxns() []int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]int(nil), m.committedTxns...)
+}
@@ -0,0 +1,220 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package producer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"go.uber.org/zap"
+)
+
+// Assert DDLProducer implementation
+var _ DDLProducer = (*pulsarDDLProducer)(nil)
+
+// pulsarDDLProducer is used to send messages to pulsar synchronously.
+type pulsarDDLProducer struct {
+	// id indicates this sink belongs to which processor(changefeed).
+	id      commonType.ChangeFeedID
+	client  pulsar.Client
+	pConfig *config.PulsarConfig
+	// producers is used to send messages to pulsar, one producer per topic.
+	producers *lru.Cache
+	// closedMu is used to protect `closed`.
+	// We need to ensure that closed producers are never written to.
+	closedMu sync.RWMutex
+	// closed is used to indicate whether the producer is closed.
+	// We also use it to guard against double closes.
+	closed bool
+}
+
+// NewPulsarDDLProducer creates a pulsar producer for replicating DDL.
+func NewPulsarDDLProducer(
+	changefeedID commonType.ChangeFeedID,
+	client pulsar.Client,
+	pulsarConfig *config.PulsarConfig,
+) (DDLProducer, error) {
+	log.Info("Starting pulsar DDL producer ...",
+		zap.String("namespace", changefeedID.Namespace()),
+		zap.String("changefeed", changefeedID.Name()))
+
+	if pulsarConfig == nil {
+		return nil, cerror.ErrPulsarInvalidConfig.
+			GenWithStackByArgs("pulsar config is empty")
+	}
+	topicName := pulsarConfig.GetDefaultTopicName()
+	defaultProducer, err := newPulsarProducer(pulsarConfig, client, topicName)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+
+	producers, err := newPulsarProducerCache(pulsarConfig)
+	if err != nil {
+		defaultProducer.Close()
+		return nil, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+	producers.Add(topicName, defaultProducer)
+	return &pulsarDDLProducer{
+		id:        changefeedID,
+		client:    client,
+		pConfig:   pulsarConfig,
+		producers: producers,
+	}, nil
+}
+
+// SyncBroadcastMessage sends the message to the topic.
+// Pulsar consumers consume all partitions of a topic, so totalPartitionsNum is not used.
+func (p *pulsarDDLProducer) SyncBroadcastMessage(ctx context.Context, topic string,
+	totalPartitionsNum int32, message *common.Message,
+) error {
+	return p.SyncSendMessage(ctx, topic, totalPartitionsNum, message)
+}
+
+// SyncSendMessage sends the message to the topic synchronously.
+// The partition is chosen by pulsar according to the partition key, so partitionNum is not used.
+func (p *pulsarDDLProducer) SyncSendMessage(ctx context.Context, topic string,
+	partitionNum int32, message *common.Message,
+) error {
+	p.closedMu.RLock()
+	defer p.closedMu.RUnlock()
+
+	if p.closed {
+		return cerror.ErrPulsarProducerClosed.GenWithStackByArgs()
+	}
+
+	producer, err := getOrCreatePulsarProducer(p.producers, p.pConfig, p.client, topic)
+	if err != nil {
+		log.Error("pulsar DDL producer get producer by topic failed",
+			zap.String("namespace", p.id.Namespace()),
+			zap.String("changefeed", p.id.Name()),
+			zap.String("topic", topic),
+			zap.Error(err))
+		return errors.Trace(err)
+	}
+
+	data := &pulsar.ProducerMessage{
+		Payload: message.Value,
+		Key:     message.GetPartitionKey(),
+	}
+	mID, err := producer.Send(ctx, data)
+	if err != nil {
+		log.Error("pulsar DDL producer send message failed",
+			zap.String("namespace", p.id.Namespace()),
+			zap.String("changefeed", p.id.Name()),
+			zap.String("topic", topic),
+			zap.Error(err))
+		return cerror.WrapError(cerror.ErrPulsarSendMessage, err)
+	}
+
+	log.Debug("pulsar DDL producer send message success",
+		zap.Any("messageID", mID), zap.String("topic", topic))
+	return nil
+}
+
+// Close closes all the producers.
+// The client is shared with the DML producer, it is closed by the sink which owns it.
+func (p *pulsarDDLProducer) Close() {
+	p.closedMu.Lock()
+	defer p.closedMu.Unlock()
+	if p.closed {
+		log.Warn("Pulsar DDL producer already closed",
+			zap.String("namespace", p.id.Namespace()),
+			zap.String("changefeed", p.id.Name()))
+		return
+	}
+	p.closed = true
+	for _, topic := range p.producers.Keys() {
+		// the evict callback closes the producer
+		p.producers.Remove(topic)
+	}
+}
+
+// newPulsarProducerCache creates a lru cache which closes the evicted pulsar producers.
+func newPulsarProducerCache(pConfig *config.PulsarConfig) (*lru.Cache, error) {
+	producerCacheSize := config.DefaultPulsarProducerCacheSize
+	if pConfig.PulsarProducerCacheSize != nil {
+		producerCacheSize = int(*pConfig.PulsarProducerCacheSize)
+	}
+	return lru.NewWithEvict(producerCacheSize, func(_ interface{}, value interface{}) {
+		// this is called when lru removes the producer or evicts it automatically
+		producer, ok := value.(pulsar.Producer)
+		if ok && producer != nil {
+			producer.Close()
+		}
+	})
+}
+
+// getOrCreatePulsarProducer returns the producer of the topic from the cache,
+// and creates one if it does not exist.
+func getOrCreatePulsarProducer(
+	producers *lru.Cache,
+	pConfig *config.PulsarConfig,
+	client pulsar.Client,
+	topicName string,
+) (pulsar.Producer, error) {
+	if target, ok := producers.Get(topicName); ok {
+		if producer, ok := target.(pulsar.Producer); ok && producer != nil {
+			return producer, nil
+		}
+	}
+	producer, err := newPulsarProducer(pConfig, client, topicName)
+	if err != nil {
+		return nil, err
+	}
+	producers.Add(topicName, producer)
+	return producer, nil
+}
+
+// newPulsarProducer creates a pulsar producer.
+// One topic is used by one producer.
+func newPulsarProducer(
+	pConfig *config.PulsarConfig,
+	client pulsar.Client,
+	topicName string,
+) (pulsar.Producer, error) {
+	maxReconnectToBroker := uint(config.DefaultMaxReconnectToPulsarBroker)
+	option := pulsar.ProducerOptions{
+		Topic:                topicName,
+		MaxReconnectToBroker: &maxReconnectToBroker,
+	}
+	if pConfig.BatchingMaxMessages != nil {
+		option.BatchingMaxMessages = *pConfig.BatchingMaxMessages
+	}
+	if pConfig.BatchingMaxPublishDelay != nil {
+		option.BatchingMaxPublishDelay = pConfig.BatchingMaxPublishDelay.Duration()
+	}
+	if pConfig.CompressionType != nil {
+		option.CompressionType = pConfig.CompressionType.Value()
+		option.CompressionLevel = pulsar.Default
+	}
+	if pConfig.SendTimeout != nil {
+		option.SendTimeout = pConfig.SendTimeout.Duration()
+	}
+
+	producer, err := client.CreateProducer(option)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("create pulsar producer success", zap.String("topic", topicName))
+	return producer, nil
+}
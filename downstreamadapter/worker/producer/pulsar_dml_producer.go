@@ -0,0 +1,202 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package producer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"go.uber.org/zap"
+)
+
+var _ DMLProducer = (*pulsarDMLProducer)(nil)
+
+// pulsarDMLProducer is used to send messages to pulsar.
+type pulsarDMLProducer struct {
+	// id indicates which processor (changefeed) this sink belongs to.
+	id commonType.ChangeFeedID
+	// client is used to create the producers of new topics,
+	// it is owned and closed by the sink.
+	client pulsar.Client
+	// producers is used to send messages to pulsar.
+	// One topic only use one producer, so we want to have many topics but use less memory,
+	// lru is a good idea to solve this question.
+	producers *lru.Cache
+
+	// closedMu is used to protect `closed`.
+	// We need to ensure that closed producers are never written to.
+	closedMu sync.RWMutex
+	// closed is used to indicate whether the producer is closed.
+	// We also use it to guard against double closes.
+	closed bool
+
+	// errChan is used to report the error of the async send callbacks.
+	errChan chan error
+
+	pConfig *config.PulsarConfig
+}
+
+// NewPulsarDMLProducer creates a new pulsar producer.
+func NewPulsarDMLProducer(
+	changefeedID commonType.ChangeFeedID,
+	client pulsar.Client,
+	pulsarConfig *config.PulsarConfig,
+) (DMLProducer, error) {
+	log.Info("Creating pulsar DML producer ...",
+		zap.String("namespace", changefeedID.Namespace()),
+		zap.String("changefeed", changefeedID.Name()))
+	start := time.Now()
+
+	if pulsarConfig == nil {
+		log.Error("new pulsar DML producer fail, sink pulsar config is empty")
+		return nil, cerror.ErrPulsarInvalidConfig.
+			GenWithStackByArgs("pulsar config is empty")
+	}
+
+	defaultTopicName := pulsarConfig.GetDefaultTopicName()
+	defaultProducer, err := newPulsarProducer(pulsarConfig, client, defaultTopicName)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+
+	producers, err := newPulsarProducerCache(pulsarConfig)
+	if err != nil {
+		defaultProducer.Close()
+		return nil, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+	producers.Add(defaultTopicName, defaultProducer)
+
+	p := &pulsarDMLProducer{
+		id:        changefeedID,
+		client:    client,
+		producers: producers,
+		pConfig:   pulsarConfig,
+		closed:    false,
+		errChan:   make(chan error, 1),
+	}
+	log.Info("Pulsar DML producer created",
+		zap.String("namespace", changefeedID.Namespace()),
+		zap.String("changefeed", changefeedID.Name()),
+		zap.Duration("duration", time.Since(start)))
+	return p, nil
+}
+
+// Run waits for the error reported by the async send callbacks.
+func (p *pulsarDMLProducer) Run(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-p.errChan:
+		return errors.Trace(err)
+	}
+}
+
+// AsyncSendMessage sends a message asynchronously.
+func (p *pulsarDMLProducer) AsyncSendMessage(
+	ctx context.Context, topic string,
+	partition int32, message *common.Message,
+) error {
+	// We have to hold the lock to avoid writing to a closed producer.
+	// Close may be blocked for a long time.
+	p.closedMu.RLock()
+	defer p.closedMu.RUnlock()
+
+	// If producers are closed, we should skip the message and return an error.
+	if p.closed {
+		return cerror.ErrPulsarProducerClosed.GenWithStackByArgs()
+	}
+	data := &pulsar.ProducerMessage{
+		Payload: message.Value,
+		Key:     message.GetPartitionKey(),
+	}
+
+	producer, err := p.getProducerByTopic(topic)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	producer.SendAsync(ctx, data,
+		func(id pulsar.MessageID, m *pulsar.ProducerMessage, err error) {
+			if err != nil {
+				e := cerror.WrapError(cerror.ErrPulsarAsyncSendMessage, err)
+				log.Error("Pulsar DML producer async send error",
+					zap.String("namespace", p.id.Namespace()),
+					zap.String("changefeed", p.id.Name()),
+					zap.Int("messageSize", len(m.Payload)),
+					zap.String("topic", topic),
+					zap.Error(err))
+				// use this select to avoid send error to a closed channel
+				// the ctx will always be called before the errChan is closed
+				select {
+				case <-ctx.Done():
+					return
+				case p.errChan <- e:
+				default:
+					log.Warn("Error channel is full in pulsar DML producer",
+						zap.String("namespace", p.id.Namespace()),
+						zap.String("changefeed", p.id.Name()),
+						zap.Error(e))
+				}
+				return
+			}
+			if message.Callback != nil {
+				message.Callback()
+			}
+		})
+	return nil
+}
+
+// Close closes all the producers.
+// The client is shared with the DDL producer, it is closed by the sink which owns it.
+func (p *pulsarDMLProducer) Close() {
+	// We have to hold the lock to synchronize closing with writing.
+	p.closedMu.Lock()
+	defer p.closedMu.Unlock()
+	// If the producer has already been closed, we should skip this close operation.
+	if p.closed {
+		// We need to guard against double closing the clients,
+		// which could lead to panic.
+		log.Warn("Pulsar DML producer already closed",
+			zap.String("namespace", p.id.Namespace()),
+			zap.String("changefeed", p.id.Name()))
+		return
+	}
+	p.closed = true
+	start := time.Now()
+	for _, topic := range p.producers.Keys() {
+		// the evict callback closes the producer
+		p.producers.Remove(topic)
+		topicName, _ := topic.(string)
+		log.Info("Async client closed in pulsar DML producer",
+			zap.Duration("duration", time.Since(start)),
+			zap.String("namespace", p.id.Namespace()),
+			zap.String("changefeed", p.id.Name()),
+			zap.String("topic", topicName))
+	}
+}
+
+// getProducerByTopic get producer by topicName,
+// if not exist, it will create a producer with topicName, and set in LRU cache.
+func (p *pulsarDMLProducer) getProducerByTopic(topicName string) (pulsar.Producer, error) {
+	return getOrCreatePulsarProducer(p.producers, p.pConfig, p.client, topicName)
+}
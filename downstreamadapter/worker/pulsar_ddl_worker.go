@@ -0,0 +1,163 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/eventrouter"
+	"github.com/pingcap/ticdc/downstreamadapter/worker/producer"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/sink/util"
+	"go.uber.org/zap"
+)
+
+// PulsarDDLWorker handle DDL and checkpoint event
+type PulsarDDLWorker struct {
+	// changeFeedID indicates this sink belongs to which processor(changefeed).
+	changeFeedID commonType.ChangeFeedID
+
+	checkpointTsChan chan uint64
+	encoder          common.EventEncoder
+	// eventRouter used to route events to the right topic.
+	eventRouter *barrierValue[*eventrouter.EventRouter]
+
+	// producer is used to send the messages to the Pulsar broker.
+	producer producer.DDLProducer
+
+	tableSchemaStore *util.TableSchemaStore
+
+	statistics *metrics.Statistics
+}
+
+// NewPulsarDDLWorker return a ddl worker instance.
+func NewPulsarDDLWorker(
+	id commonType.ChangeFeedID,
+	producer producer.DDLProducer,
+	encoder common.EventEncoder,
+	eventRouter *eventrouter.EventRouter,
+	statistics *metrics.Statistics,
+) *PulsarDDLWorker {
+	return &PulsarDDLWorker{
+		changeFeedID:     id,
+		encoder:          encoder,
+		producer:         producer,
+		eventRouter:      newBarrierValue(eventRouter),
+		statistics:       statistics,
+		checkpointTsChan: make(chan uint64, 16),
+	}
+}
+
+func (w *PulsarDDLWorker) Run(ctx context.Context) error {
+	return w.encodeAndSendCheckpointEvents(ctx)
+}
+
+func (w *PulsarDDLWorker) AddCheckpoint(ts uint64) {
+	w.checkpointTsChan <- ts
+}
+
+// UpdateEventRouter sets the event router used by the ddl events whose finishedTs is larger than barrierTs.
+func (w *PulsarDDLWorker) UpdateEventRouter(eventRouter *eventrouter.EventRouter, barrierTs uint64) {
+	w.eventRouter.update(eventRouter, barrierTs)
+}
+
+func (w *PulsarDDLWorker) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore) {
+	w.tableSchemaStore = tableSchemaStore
+}
+
+func (w *PulsarDDLWorker) WriteBlockEvent(ctx context.Context, event *event.DDLEvent) error {
+	for _, e := range event.GetEvents() {
+		message, err := w.encoder.EncodeDDLEvent(e)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// some protocols don't send ddl events, such as avro.
+		if message == nil {
+			continue
+		}
+		topic := w.eventRouter.get(e.FinishedTs).GetTopicForDDL(e)
+		// Pulsar consumers read all the partitions of a topic,
+		// so it is enough to send the DDL message once.
+		err = w.statistics.RecordDDLExecution(func() error {
+			return w.producer.SyncSendMessage(ctx, topic, 0, message)
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	// after flush all the ddl event, we call the callback function.
+	event.PostFlush()
+	return nil
+}
+
+func (w *PulsarDDLWorker) encodeAndSendCheckpointEvents(ctx context.Context) error {
+	checkpointTsMessageDuration := metrics.CheckpointTsMessageDuration.WithLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
+	checkpointTsMessageCount := metrics.CheckpointTsMessageCount.WithLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
+
+	defer func() {
+		metrics.CheckpointTsMessageDuration.DeleteLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
+		metrics.CheckpointTsMessageCount.DeleteLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case ts, ok := <-w.checkpointTsChan:
+			if !ok {
+				log.Warn("Pulsar sink flush worker channel closed",
+					zap.String("namespace", w.changeFeedID.Namespace()),
+					zap.String("changefeed", w.changeFeedID.Name()))
+				return nil
+			}
+			start := time.Now()
+
+			msg, err := w.encoder.EncodeCheckpointEvent(ts)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if msg == nil {
+				continue
+			}
+
+			var topics []string
+			tableNames := w.tableSchemaStore.GetAllTableNames(ts)
+			// NOTICE: When there are no tables to replicate,
+			// we need to send checkpoint ts to the default topic.
+			if len(tableNames) == 0 {
+				topics = []string{w.eventRouter.get(ts).GetDefaultTopic()}
+			} else {
+				topics = w.eventRouter.get(ts).GetActiveTopics(tableNames)
+			}
+			for _, topic := range topics {
+				if err = w.producer.SyncBroadcastMessage(ctx, topic, 1, msg); err != nil {
+					return errors.Trace(err)
+				}
+			}
+
+			checkpointTsMessageCount.Inc()
+			checkpointTsMessageDuration.Observe(time.Since(start).Seconds())
+		}
+	}
+}
+
+func (w *PulsarDDLWorker) Close() {
+	w.producer.Close()
+}
@@ -0,0 +1,253 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/eventrouter"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/topicmanager"
+	"github.com/pingcap/ticdc/downstreamadapter/worker/producer"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/codec"
+	"github.com/pingcap/tiflow/cdc/model"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// PulsarDMLWorker worker will send messages to the DML producer.
+// Pulsar only supports non-batch protocols, so each row is added to the encoder group
+// as soon as its topic and partition key are calculated.
+type PulsarDMLWorker struct {
+	changeFeedID common.ChangeFeedID
+	protocol     config.Protocol
+
+	eventChan chan *commonEvent.DMLEvent
+	rowChan   chan *commonEvent.MQRowEvent
+
+	columnSelector *barrierValue[*columnselector.ColumnSelectors]
+	// eventRouter used to route events to the right topic and partition.
+	eventRouter *barrierValue[*eventrouter.EventRouter]
+	// topicManager used to manage topics.
+	topicManager topicmanager.TopicManager
+	encoderGroup codec.EncoderGroup
+
+	// producer is used to send the messages to the Pulsar broker.
+	producer producer.DMLProducer
+
+	// statistics is used to record DML metrics.
+	statistics *metrics.Statistics
+}
+
+// NewPulsarDMLWorker creates a dml flush worker for pulsar
+func NewPulsarDMLWorker(
+	id common.ChangeFeedID,
+	protocol config.Protocol,
+	producer producer.DMLProducer,
+	encoderGroup codec.EncoderGroup,
+	columnSelector *columnselector.ColumnSelectors,
+	eventRouter *eventrouter.EventRouter,
+	topicManager topicmanager.TopicManager,
+	statistics *metrics.Statistics,
+) *PulsarDMLWorker {
+	return &PulsarDMLWorker{
+		changeFeedID:   id,
+		protocol:       protocol,
+		eventChan:      make(chan *commonEvent.DMLEvent, 32),
+		rowChan:        make(chan *commonEvent.MQRowEvent, 32),
+		encoderGroup:   encoderGroup,
+		columnSelector: newBarrierValue(columnSelector),
+		eventRouter:    newBarrierValue(eventRouter),
+		topicManager:   topicManager,
+		producer:       producer,
+		statistics:     statistics,
+	}
+}
+
+func (w *PulsarDMLWorker) Run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return w.producer.Run(ctx)
+	})
+
+	g.Go(func() error {
+		return w.calculateKeyPartitions(ctx)
+	})
+
+	g.Go(func() error {
+		return w.encoderGroup.Run(ctx)
+	})
+
+	g.Go(func() error {
+		return w.encodeRun(ctx)
+	})
+
+	g.Go(func() error {
+		return w.sendMessages(ctx)
+	})
+	return g.Wait()
+}
+
+func (w *PulsarDMLWorker) calculateKeyPartitions(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case event := <-w.eventChan:
+			eventRouter := w.eventRouter.get(event.CommitTs)
+			topic := eventRouter.GetTopicForRowChange(event.TableInfo)
+			partitionNum, err := w.topicManager.GetPartitionNum(ctx, topic)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			partitionGenerator := eventRouter.GetPartitionGenerator(event.TableInfo)
+			selector := w.columnSelector.get(event.CommitTs).GetSelector(event.TableInfo.TableName.Schema, event.TableInfo.TableName.Table)
+
+			rowsCount := uint64(event.Len())
+			postTxnFlushed := event.PostTxnFlushed
+			var calledCount atomic.Uint64
+			// The callback of the last row will trigger the callback of the txn.
+			rowCallback := func() {
+				if calledCount.Inc() == rowsCount {
+					for _, callback := range postTxnFlushed {
+						callback()
+					}
+				}
+			}
+
+			for {
+				row, ok := event.GetNextRow()
+				if !ok {
+					break
+				}
+
+				index, key, err := partitionGenerator.GeneratePartitionIndexAndKey(&row, partitionNum, event.TableInfo, event.CommitTs)
+				if err != nil {
+					return errors.Trace(err)
+				}
+
+				mqEvent := &commonEvent.MQRowEvent{
+					Key: model.TopicPartitionKey{
+						Topic:          topic,
+						Partition:      index,
+						PartitionKey:   key,
+						TotalPartition: partitionNum,
+					},
+					RowEvent: commonEvent.RowEvent{
+						TableInfo:      event.TableInfo,
+						CommitTs:       event.CommitTs,
+						Event:          row,
+						Callback:       rowCallback,
+						ColumnSelector: selector,
+					},
+				}
+				select {
+				case <-ctx.Done():
+					return errors.Trace(ctx.Err())
+				case w.rowChan <- mqEvent:
+				}
+			}
+		}
+	}
+}
+
+// UpdateRouting sets the event router and the column selectors used by the events whose commitTs is larger than barrierTs.
+func (w *PulsarDMLWorker) UpdateRouting(eventRouter *eventrouter.EventRouter, columnSelector *columnselector.ColumnSelectors, barrierTs uint64) {
+	w.eventRouter.update(eventRouter, barrierTs)
+	w.columnSelector.update(columnSelector, barrierTs)
+}
+
+func (w *PulsarDMLWorker) AddDMLEvent(event *commonEvent.DMLEvent) {
+	w.eventChan <- event
+}
+
+// encodeRun add events to the encoder group immediately.
+func (w *PulsarDMLWorker) encodeRun(ctx context.Context) error {
+	log.Info("Pulsar sink worker started",
+		zap.String("namespace", w.changeFeedID.Namespace()),
+		zap.String("changefeed", w.changeFeedID.Name()),
+		zap.String("protocol", w.protocol.String()),
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case event, ok := <-w.rowChan:
+			if !ok {
+				log.Warn("Pulsar sink flush worker channel closed",
+					zap.String("namespace", w.changeFeedID.Namespace()),
+					zap.String("changefeed", w.changeFeedID.Name()))
+				return nil
+			}
+			if err := w.encoderGroup.AddEvents(ctx, event.Key, &event.RowEvent); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (w *PulsarDMLWorker) sendMessages(ctx context.Context) error {
+	metricSendMessageDuration := metrics.WorkerSendMessageDuration.WithLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
+	defer metrics.WorkerSendMessageDuration.DeleteLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
+
+	var err error
+	outCh := w.encoderGroup.Output()
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case future, ok := <-outCh:
+			if !ok {
+				log.Warn("Pulsar sink encoder's output channel closed",
+					zap.String("namespace", w.changeFeedID.Namespace()),
+					zap.String("changefeed", w.changeFeedID.Name()))
+				return nil
+			}
+			if err = future.Ready(ctx); err != nil {
+				return errors.Trace(err)
+			}
+			partitionKey := future.Key.PartitionKey
+			for _, message := range future.Messages {
+				// pulsar routes the message to the partition by the partition key.
+				message.PartitionKey = &partitionKey
+				start := time.Now()
+				if err = w.statistics.RecordBatchExecution(func() (int, int64, error) {
+					if err = w.producer.AsyncSendMessage(
+						ctx,
+						future.Key.Topic,
+						future.Key.Partition,
+						message); err != nil {
+						return 0, 0, err
+					}
+					return message.GetRowsCount(), int64(message.Length()), nil
+				}); err != nil {
+					return errors.Trace(err)
+				}
+				metricSendMessageDuration.Observe(time.Since(start).Seconds())
+			}
+		}
+	}
+}
+
+func (w *PulsarDMLWorker) Close() {
+	w.producer.Close()
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/redo"
+	"github.com/pingcap/ticdc/utils/chann"
+	"go.uber.org/zap"
+)
+
+// defaultRedoBatchSize is the max number of tasks handled in one flush.
+const defaultRedoBatchSize = 4096
+
+// redoDMLTask is either a DML event to be written to the redo log,
+// a resolvedTs of a dispatcher which is reached after all the previous events are written,
+// or a callback which is called after all the previous events are written.
+type redoDMLTask struct {
+	dispatcherID commonType.DispatcherID
+	event        *commonEvent.DMLEvent
+	// callback is called after the event or all the previous events are persisted in the redo log.
+	callback   func()
+	resolvedTs uint64
+}
+
+// RedoDMLWorker writes DML events to the redo log.
+// The events are written in group commit mode: all the pending events are flushed
+// in one batch, and the callbacks of the events are called after the batch is persisted.
+// Because the tasks are handled in order, when a resolvedTs task is handled,
+// all the previous events of the dispatcher are persisted, so the redo resolvedTs
+// of the dispatcher can be advanced.
+type RedoDMLWorker struct {
+	changefeedID commonType.ChangeFeedID
+	writer       *redo.FileWriter
+	tracker      *redo.ResolvedTsTracker
+	inputCh      *chann.DrainableChann[redoDMLTask]
+}
+
+// NewRedoDMLWorker creates a RedoDMLWorker.
+func NewRedoDMLWorker(
+	changefeedID commonType.ChangeFeedID,
+	writer *redo.FileWriter,
+	tracker *redo.ResolvedTsTracker,
+) *RedoDMLWorker {
+	return &RedoDMLWorker{
+		changefeedID: changefeedID,
+		writer:       writer,
+		tracker:      tracker,
+		inputCh:      chann.NewAutoDrainChann[redoDMLTask](),
+	}
+}
+
+// Run writes the events to the redo log until the context is canceled or meets error.
+func (w *RedoDMLWorker) Run(ctx context.Context) error {
+	log.Info("redo dml worker started",
+		zap.String("namespace", w.changefeedID.Namespace()),
+		zap.String("changefeed", w.changefeedID.Name()))
+
+	tasks := make([]redoDMLTask, 0, defaultRedoBatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case task, ok := <-w.inputCh.Out():
+			if !ok {
+				return nil
+			}
+			tasks = append(tasks[:0], task)
+		}
+		// collect all the pending tasks
+	collect:
+		for len(tasks) < defaultRedoBatchSize {
+			select {
+			case task, ok := <-w.inputCh.Out():
+				if !ok {
+					break collect
+				}
+				tasks = append(tasks, task)
+			default:
+				break collect
+			}
+		}
+		if err := w.flush(ctx, tasks); err != nil {
+			log.Error("redo dml worker flush failed",
+				zap.String("namespace", w.changefeedID.Namespace()),
+				zap.String("changefeed", w.changefeedID.Name()),
+				zap.Error(err))
+			return errors.Trace(err)
+		}
+	}
+}
+
+func (w *RedoDMLWorker) flush(ctx context.Context, tasks []redoDMLTask) error {
+	logs := make([]*redo.RedoLog, 0, len(tasks))
+	for _, task := range tasks {
+		if task.event != nil {
+			logs = append(logs, redo.NewRedoLogFromDMLEvent(task.event))
+		}
+	}
+	if err := w.writer.WriteLogs(ctx, logs); err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if task.callback != nil {
+			task.callback()
+			continue
+		}
+		w.tracker.Update(task.dispatcherID, task.resolvedTs)
+	}
+	return nil
+}
+
+// AddDMLEvent adds a DML event to the worker, the callback is called after the event is persisted.
+func (w *RedoDMLWorker) AddDMLEvent(event *commonEvent.DMLEvent, callback func()) {
+	w.inputCh.In() <- redoDMLTask{dispatcherID: event.DispatcherID, event: event, callback: callback}
+}
+
+// AddCallback adds a callback to the worker, it's called after all the previous events are persisted.
+func (w *RedoDMLWorker) AddCallback(callback func()) {
+	w.inputCh.In() <- redoDMLTask{callback: callback}
+}
+
+// AddResolvedTs adds a resolvedTs of the dispatcher to the worker.
+func (w *RedoDMLWorker) AddResolvedTs(dispatcherID commonType.DispatcherID, resolvedTs uint64) {
+	w.inputCh.In() <- redoDMLTask{dispatcherID: dispatcherID, resolvedTs: resolvedTs}
+}
+
+// Close closes the input channel of the worker.
+func (w *RedoDMLWorker) Close() {
+	w.inputCh.CloseAndDrain()
+}
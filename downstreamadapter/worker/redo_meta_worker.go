@@ -0,0 +1,194 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/redo"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	predo "github.com/pingcap/tiflow/pkg/redo"
+	"go.uber.org/zap"
+)
+
+// RedoMetaWorker periodically persists the redo meta of a redo writer,
+// and removes the log files which are no longer needed.
+// The resolvedTs in the meta is the global redo resolvedTs of the changefeed calculated
+// by the maintainer, and the checkpointTs in the meta is the checkpointTs of the dispatchers
+// in the downstream.
+type RedoMetaWorker struct {
+	writerID      string
+	changefeedID  commonType.ChangeFeedID
+	storage       storage.ExternalStorage
+	writers       []*redo.FileWriter
+	compression   string
+	flushInterval time.Duration
+	// onFlushed is called with the resolvedTs of the meta after it's persisted.
+	onFlushed func(resolvedTs uint64)
+
+	checkpointTs atomic.Uint64
+	resolvedTs   atomic.Uint64
+	// metaFile is the name of the latest meta file.
+	metaFile string
+	lastMeta redo.LogMeta
+}
+
+// NewRedoMetaWorker creates a RedoMetaWorker.
+func NewRedoMetaWorker(
+	writerID string,
+	changefeedID commonType.ChangeFeedID,
+	extStorage storage.ExternalStorage,
+	writers []*redo.FileWriter,
+	startTs uint64,
+	compressionType string,
+	flushIntervalInMs int64,
+	onFlushed func(resolvedTs uint64),
+) *RedoMetaWorker {
+	if flushIntervalInMs <= 0 {
+		flushIntervalInMs = predo.DefaultMetaFlushIntervalInMs
+	}
+	w := &RedoMetaWorker{
+		writerID:      writerID,
+		changefeedID:  changefeedID,
+		storage:       extStorage,
+		writers:       writers,
+		compression:   compressionType,
+		flushInterval: time.Duration(flushIntervalInMs) * time.Millisecond,
+		onFlushed:     onFlushed,
+	}
+	w.checkpointTs.Store(startTs)
+	w.resolvedTs.Store(startTs)
+	return w
+}
+
+// Run flushes the meta and removes the stale log files until the context is canceled.
+func (w *RedoMetaWorker) Run(ctx context.Context) error {
+	log.Info("redo meta worker started",
+		zap.String("namespace", w.changefeedID.Namespace()),
+		zap.String("changefeed", w.changefeedID.Name()),
+		zap.String("writerID", w.writerID))
+
+	flushTicker := time.NewTicker(w.flushInterval)
+	defer flushTicker.Stop()
+	gcTicker := time.NewTicker(time.Duration(predo.DefaultGCIntervalInMs) * time.Millisecond)
+	defer gcTicker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-flushTicker.C:
+			if err := w.FlushMeta(ctx); err != nil {
+				return errors.Trace(err)
+			}
+		case <-gcTicker.C:
+			checkpointTs := w.checkpointTs.Load()
+			for _, writer := range w.writers {
+				if err := writer.GC(ctx, checkpointTs); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+	}
+}
+
+// AddCheckpointTs updates the checkpointTs of the dispatchers in the downstream.
+func (w *RedoMetaWorker) AddCheckpointTs(checkpointTs uint64) {
+	advanceTs(&w.checkpointTs, checkpointTs)
+}
+
+// AddResolvedTs updates the global redo resolvedTs of the changefeed.
+func (w *RedoMetaWorker) AddResolvedTs(resolvedTs uint64) {
+	advanceTs(&w.resolvedTs, resolvedTs)
+}
+
+func advanceTs(ts *atomic.Uint64, newTs uint64) {
+	for {
+		old := ts.Load()
+		if newTs <= old || ts.CompareAndSwap(old, newTs) {
+			return
+		}
+	}
+}
+
+// FlushMeta writes the latest meta to the storage if it's changed.
+// The old meta file is removed after the new one is written.
+func (w *RedoMetaWorker) FlushMeta(ctx context.Context) error {
+	checkpointTs := w.checkpointTs.Load()
+	resolvedTs := max(w.resolvedTs.Load(), checkpointTs)
+	meta := redo.LogMeta{
+		CheckpointTs: checkpointTs,
+		ResolvedTs:   resolvedTs,
+		Compression:  w.compression,
+	}
+	if w.metaFile != "" && meta == w.lastMeta {
+		return nil
+	}
+
+	data, err := meta.Marshal()
+	if err != nil {
+		return err
+	}
+	name := redo.GetMetaFileName(w.writerID, w.changefeedID, uuid.NewString())
+	if err = w.storage.WriteFile(ctx, name, data); err != nil {
+		return errors.WrapError(errors.ErrRedoFileOp, err)
+	}
+	if w.metaFile != "" {
+		if err = w.storage.DeleteFile(ctx, w.metaFile); err != nil {
+			return errors.WrapError(errors.ErrRedoFileOp, err)
+		}
+	}
+	w.metaFile = name
+	w.lastMeta = meta
+	metrics.RedoResolvedTsGauge.
+		WithLabelValues(w.changefeedID.Namespace(), w.changefeedID.Name()).
+		Set(float64(resolvedTs))
+	if w.onFlushed != nil {
+		w.onFlushed(resolvedTs)
+	}
+	return nil
+}
+
+// Close cleans the metrics of the worker.
+// If the changefeed is removed, the meta and all the log files are removed too.
+func (w *RedoMetaWorker) Close(ctx context.Context, removeChangefeed bool) {
+	metrics.RedoResolvedTsGauge.DeleteLabelValues(w.changefeedID.Namespace(), w.changefeedID.Name())
+	if !removeChangefeed {
+		return
+	}
+	if w.metaFile != "" {
+		if err := w.storage.DeleteFile(ctx, w.metaFile); err != nil {
+			log.Warn("failed to remove redo meta file",
+				zap.String("namespace", w.changefeedID.Namespace()),
+				zap.String("changefeed", w.changefeedID.Name()),
+				zap.String("file", w.metaFile),
+				zap.Error(err))
+		}
+		w.metaFile = ""
+	}
+	for _, writer := range w.writers {
+		if err := writer.RemoveAll(ctx); err != nil {
+			log.Warn("failed to remove redo log files",
+				zap.String("namespace", w.changefeedID.Namespace()),
+				zap.String("changefeed", w.changefeedID.Name()),
+				zap.Error(err))
+		}
+	}
+}
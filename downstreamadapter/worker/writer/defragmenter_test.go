@@ -0,0 +1,58 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/ticdc/utils/chann"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefragmenter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	inputCh := make(chan EventFragment, 100)
+	outputCh := chann.NewAutoDrainChann[EventFragment]()
+	defrag := NewDefragmenter(inputCh, []*chann.DrainableChann[EventFragment]{outputCh})
+	go defrag.Run(ctx)
+
+	table := cloudstorage.VersionedTableName{
+		TableNameWithPhysicTableID: commonType.TableName{
+			Schema:  "test",
+			Table:   "t",
+			TableID: 100,
+		},
+		TableInfoVersion: 99,
+	}
+	seqs := make([]uint64, 0, 100)
+	for i := 1; i <= 100; i++ {
+		seqs = append(seqs, uint64(i))
+	}
+	rand.Shuffle(len(seqs), func(i, j int) { seqs[i], seqs[j] = seqs[j], seqs[i] })
+	for _, seq := range seqs {
+		inputCh <- NewEventFragment(seq, table, nil)
+	}
+
+	for i := 1; i <= 100; i++ {
+		frag := <-outputCh.Out()
+		require.Equal(t, uint64(i), frag.seqNumber)
+		require.Equal(t, table, frag.versionedTable)
+	}
+}
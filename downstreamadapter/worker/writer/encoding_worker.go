@@ -0,0 +1,102 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"go.uber.org/zap"
+)
+
+// EncodingWorker denotes the worker responsible for encoding DMLEvents
+// to messages formatted in the specific protocol.
+type EncodingWorker struct {
+	id           int
+	changeFeedID commonType.ChangeFeedID
+	encoder      common.TxnEventEncoder
+	isClosed     uint64
+	inputCh      <-chan EventFragment
+	outputCh     chan<- EventFragment
+}
+
+// NewEncodingWorker creates an EncodingWorker.
+func NewEncodingWorker(
+	workerID int,
+	changefeedID commonType.ChangeFeedID,
+	encoder common.TxnEventEncoder,
+	inputCh <-chan EventFragment,
+	outputCh chan<- EventFragment,
+) *EncodingWorker {
+	return &EncodingWorker{
+		id:           workerID,
+		changeFeedID: changefeedID,
+		encoder:      encoder,
+		inputCh:      inputCh,
+		outputCh:     outputCh,
+	}
+}
+
+// Run encodes the fragments from the input channel and sends them to the output channel.
+func (w *EncodingWorker) Run(ctx context.Context) error {
+	log.Debug("encoding worker started", zap.Int("workerID", w.id),
+		zap.String("namespace", w.changeFeedID.Namespace()),
+		zap.String("changefeed", w.changeFeedID.Name()))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case frag, ok := <-w.inputCh:
+			if !ok || atomic.LoadUint64(&w.isClosed) == 1 {
+				return nil
+			}
+			err := w.encodeEvents(ctx, frag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (w *EncodingWorker) encodeEvents(ctx context.Context, frag EventFragment) error {
+	err := w.encoder.AppendTxnEvent(frag.event)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	frag.encodedMsgs = w.encoder.Build()
+	if len(frag.encodedMsgs) == 0 {
+		// the event contains no rows, keep its callback so that
+		// it is flushed in order with other events of the table.
+		msg := common.NewMsg(nil, nil)
+		msg.Callback = frag.event.PostFlush
+		frag.encodedMsgs = []*common.Message{msg}
+	}
+
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case w.outputCh <- frag:
+	}
+	return nil
+}
+
+// Close closes the EncodingWorker.
+func (w *EncodingWorker) Close() {
+	atomic.StoreUint64(&w.isClosed, 1)
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"context"
+	"testing"
+
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/utils/chann"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/stretchr/testify/require"
+)
+
+// failedCloseStorage creates the file writers whose Close fails,
+// just like a multipart upload which fails to complete.
+type failedCloseStorage struct {
+	storage.ExternalStorage
+}
+
+func (s *failedCloseStorage) Create(
+	_ context.Context, _ string, _ *storage.WriterOption,
+) (storage.ExternalFileWriter, error) {
+	return &failedCloseWriter{}, nil
+}
+
+type failedCloseWriter struct{}
+
+func (w *failedCloseWriter) Write(_ context.Context, p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (w *failedCloseWriter) Close(_ context.Context) error {
+	return errors.New("complete multipart upload failed")
+}
+
+func TestWriteDataFileCloseFailed(t *testing.T) {
+	changefeedID := commonType.NewChangefeedID4Test("test", "test")
+	cfg := cloudstorage.NewConfig()
+	cfg.FlushConcurrency = 4
+	w := NewWriter(1, changefeedID, &failedCloseStorage{}, cfg, ".json",
+		chann.NewAutoDrainChann[EventFragment](), pdutil.NewClock4Test(),
+		metrics.NewStatistics(changefeedID, "CloudStorageSink"))
+
+	flushed := false
+	msg := common.NewMsg(nil, []byte("data"))
+	msg.Callback = func() { flushed = true }
+	task := &singleTableTask{
+		size:      4,
+		tableInfo: commonType.NewTableInfo(1, "test", "t", 100, false, nil),
+		msgs:      []*common.Message{msg},
+	}
+	err := w.writeDataFile(context.Background(), "test/t/CDC000001.json", task)
+	require.ErrorContains(t, err, "complete multipart upload failed")
+	require.False(t, flushed)
+}
@@ -594,6 +594,15 @@ type RegisterDispatcherRequest struct {
 	SyncPointTs       uint64                    `protobuf:"varint,9,opt,name=sync_point_ts,json=syncPointTs,proto3" json:"sync_point_ts,omitempty"`
 	SyncPointInterval uint64                    `protobuf:"varint,10,opt,name=sync_point_interval,json=syncPointInterval,proto3" json:"sync_point_interval,omitempty"`
 	OnlyReuse         bool                      `protobuf:"varint,11,opt,name=only_reuse,json=onlyReuse,proto3" json:"only_reuse,omitempty"`
+	// cluster_id is the cluster ID of the upstream the dispatcher reads from,
+	// it's 0 for the default upstream.
+	ClusterId uint64 `protobuf:"varint,12,opt,name=cluster_id,json=clusterId,proto3" json:"cluster_id,omitempty"`
+	// bdr_mode is true if the changefeed is in BDR mode, the events written by
+	// TiCDC itself are filtered out by TiKV to avoid replication loops.
+	BdrMode bool `protobuf:"varint,13,opt,name=bdr_mode,json=bdrMode,proto3" json:"bdr_mode,omitempty"`
+	// high_priority is true if the changefeed is latency critical, the scan tasks
+	// of the dispatcher are preferred by the event service.
+	HighPriority bool `protobuf:"varint,14,opt,name=high_priority,json=highPriority,proto3" json:"high_priority,omitempty"`
 }
 
 func (m *RegisterDispatcherRequest) Reset()         { *m = RegisterDispatcherRequest{} }
@@ -706,6 +715,127 @@ func (m *RegisterDispatcherRequest) GetOnlyReuse() bool {
 	return false
 }
 
+func (m *RegisterDispatcherRequest) GetClusterId() uint64 {
+	if m != nil {
+		return m.ClusterId
+	}
+	return 0
+}
+
+func (m *RegisterDispatcherRequest) GetBdrMode() bool {
+	if m != nil {
+		return m.BdrMode
+	}
+	return false
+}
+
+func (m *RegisterDispatcherRequest) GetHighPriority() bool {
+	if m != nil {
+		return m.HighPriority
+	}
+	return false
+}
+
+type DispatcherProgress struct {
+	DispatcherId *heartbeatpb.DispatcherID `protobuf:"bytes,1,opt,name=dispatcher_id,json=dispatcherId,proto3" json:"dispatcher_id,omitempty"`
+	// checkpoint_ts is the checkpoint ts of the dispatcher,
+	// the events <= checkpoint_ts are not needed by the dispatcher anymore.
+	CheckpointTs uint64 `protobuf:"varint,2,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
+}
+
+func (m *DispatcherProgress) Reset()         { *m = DispatcherProgress{} }
+func (m *DispatcherProgress) String() string { return proto.CompactTextString(m) }
+func (*DispatcherProgress) ProtoMessage()    {}
+func (*DispatcherProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d7fb2554dfcf7f7d, []int{9}
+}
+func (m *DispatcherProgress) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DispatcherProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DispatcherProgress.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DispatcherProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DispatcherProgress.Merge(m, src)
+}
+func (m *DispatcherProgress) XXX_Size() int {
+	return m.Size()
+}
+func (m *DispatcherProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_DispatcherProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DispatcherProgress proto.InternalMessageInfo
+
+func (m *DispatcherProgress) GetDispatcherId() *heartbeatpb.DispatcherID {
+	if m != nil {
+		return m.DispatcherId
+	}
+	return nil
+}
+
+func (m *DispatcherProgress) GetCheckpointTs() uint64 {
+	if m != nil {
+		return m.CheckpointTs
+	}
+	return 0
+}
+
+// DispatcherHeartbeat is sent from the event collector to the event service periodically,
+// to report the progress of the dispatchers reading from the event service.
+type DispatcherHeartbeat struct {
+	DispatcherProgresses []*DispatcherProgress `protobuf:"bytes,1,rep,name=dispatcher_progresses,json=dispatcherProgresses,proto3" json:"dispatcher_progresses,omitempty"`
+}
+
+func (m *DispatcherHeartbeat) Reset()         { *m = DispatcherHeartbeat{} }
+func (m *DispatcherHeartbeat) String() string { return proto.CompactTextString(m) }
+func (*DispatcherHeartbeat) ProtoMessage()    {}
+func (*DispatcherHeartbeat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d7fb2554dfcf7f7d, []int{10}
+}
+func (m *DispatcherHeartbeat) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DispatcherHeartbeat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DispatcherHeartbeat.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DispatcherHeartbeat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DispatcherHeartbeat.Merge(m, src)
+}
+func (m *DispatcherHeartbeat) XXX_Size() int {
+	return m.Size()
+}
+func (m *DispatcherHeartbeat) XXX_DiscardUnknown() {
+	xxx_messageInfo_DispatcherHeartbeat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DispatcherHeartbeat proto.InternalMessageInfo
+
+func (m *DispatcherHeartbeat) GetDispatcherProgresses() []*DispatcherProgress {
+	if m != nil {
+		return m.DispatcherProgresses
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("eventpb.OpType", OpType_name, OpType_value)
 	proto.RegisterEnum("eventpb.ActionType", ActionType_name, ActionType_value)
@@ -718,76 +848,85 @@ func init() {
 	proto.RegisterType((*TableInfo)(nil), "eventpb.TableInfo")
 	proto.RegisterType((*EventFeed)(nil), "eventpb.EventFeed")
 	proto.RegisterType((*RegisterDispatcherRequest)(nil), "eventpb.RegisterDispatcherRequest")
+	proto.RegisterType((*DispatcherProgress)(nil), "eventpb.DispatcherProgress")
+	proto.RegisterType((*DispatcherHeartbeat)(nil), "eventpb.DispatcherHeartbeat")
 }
 
 func init() { proto.RegisterFile("eventpb/event.proto", fileDescriptor_d7fb2554dfcf7f7d) }
 
 var fileDescriptor_d7fb2554dfcf7f7d = []byte{
-	// 1021 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x5d, 0x6f, 0xe3, 0x44,
-	0x17, 0xae, 0x93, 0x34, 0x1f, 0x27, 0xe9, 0xae, 0x3b, 0xdd, 0xee, 0xeb, 0xb6, 0xbb, 0x79, 0xb3,
-	0x11, 0x5a, 0x85, 0x4a, 0xa4, 0x50, 0x40, 0x48, 0x2b, 0x54, 0xa9, 0xb4, 0xee, 0xe2, 0x8b, 0x6d,
-	0xab, 0x89, 0xbb, 0x12, 0xdc, 0x58, 0xae, 0x7d, 0x92, 0x1a, 0xdc, 0xb1, 0xeb, 0x99, 0x64, 0x9b,
-	0x7f, 0xc1, 0x15, 0x37, 0xf0, 0x83, 0xb8, 0xdc, 0x4b, 0xee, 0x40, 0xad, 0x04, 0x7f, 0x03, 0x79,
-	0xc6, 0x71, 0x9c, 0x06, 0x90, 0xb8, 0xca, 0xcc, 0x79, 0x9e, 0x33, 0xe7, 0x39, 0x5f, 0x0e, 0x6c,
-	0xe0, 0x04, 0x99, 0x88, 0x2f, 0xf7, 0xe4, 0x6f, 0x3f, 0x4e, 0x22, 0x11, 0x91, 0x5a, 0x66, 0xdc,
-	0xde, 0xb9, 0x42, 0x37, 0x11, 0x97, 0xe8, 0xa6, 0x8c, 0xfc, 0xac, 0x58, 0xdd, 0xdf, 0x4a, 0xf0,
-	0xd8, 0x4c, 0x89, 0x27, 0x41, 0x28, 0x30, 0xa1, 0xe3, 0x10, 0x89, 0x01, 0xb5, 0x6b, 0x57, 0x78,
-	0x57, 0x98, 0x18, 0x5a, 0xa7, 0xdc, 0x6b, 0xd0, 0xd9, 0x95, 0xbc, 0x80, 0x56, 0x30, 0x62, 0x51,
-	0x82, 0x8e, 0x7c, 0xdc, 0x28, 0x49, 0xb8, 0xa9, 0x6c, 0xf2, 0x19, 0xf2, 0x1c, 0x20, 0xa3, 0xf0,
-	0x9b, 0xd0, 0x28, 0x4b, 0x42, 0x43, 0x59, 0x06, 0x37, 0x21, 0xf9, 0x02, 0x8c, 0x0c, 0x0e, 0x18,
-	0xc7, 0x44, 0x38, 0x13, 0x37, 0x1c, 0xa3, 0x83, 0xb7, 0x71, 0x62, 0x54, 0x3a, 0x5a, 0xaf, 0x41,
-	0x37, 0x15, 0x6e, 0x49, 0xf8, 0x6d, 0x8a, 0x9a, 0xb7, 0x71, 0x42, 0x0e, 0xe0, 0x59, 0xe6, 0x38,
-	0x8e, 0x7d, 0x57, 0xa0, 0xc3, 0xf0, 0x5d, 0xd1, 0x79, 0x55, 0x3a, 0x67, 0x8f, 0x5f, 0x48, 0xca,
-	0x29, 0xbe, 0xfb, 0x17, 0xff, 0x28, 0xf4, 0x8b, 0xfe, 0xd5, 0x65, 0xff, 0xb3, 0xd0, 0x9f, 0xfb,
-	0xcf, 0x85, 0xfb, 0x18, 0xa2, 0xc0, 0xa2, 0x6f, 0xad, 0x28, 0xfc, 0x58, 0xc2, 0xb9, 0x63, 0xf7,
-	0x47, 0x0d, 0xd6, 0x2d, 0xc6, 0x30, 0x51, 0x15, 0x3e, 0x8a, 0xd8, 0x30, 0x18, 0x91, 0x27, 0xb0,
-	0x9a, 0x8c, 0x43, 0xe4, 0x59, 0x85, 0xd5, 0x85, 0x7c, 0x04, 0x1b, 0x59, 0x10, 0x71, 0xcb, 0x1c,
-	0x2e, 0xdc, 0x44, 0x38, 0x82, 0xcb, 0x32, 0x57, 0xa8, 0xae, 0x20, 0xfb, 0x96, 0x0d, 0x52, 0xc0,
-	0xe6, 0xe4, 0x4b, 0x68, 0x15, 0x7a, 0xc7, 0x65, 0xb5, 0x9b, 0xfb, 0x46, 0x3f, 0xeb, 0x7c, 0xff,
-	0x41, 0x63, 0xe9, 0x02, 0xbb, 0xfb, 0xb3, 0x06, 0xad, 0x05, 0x4d, 0x1f, 0xc0, 0x9a, 0xe7, 0x72,
-	0x1c, 0x20, 0xe3, 0x81, 0x08, 0x26, 0x68, 0x68, 0x1d, 0xad, 0x57, 0xa7, 0x8b, 0x46, 0xf2, 0x12,
-	0x1e, 0x0d, 0xa3, 0xc4, 0x43, 0x8a, 0x71, 0x18, 0x78, 0xae, 0x40, 0xa3, 0x24, 0x69, 0x0f, 0xac,
-	0xe4, 0x00, 0x5a, 0xc3, 0xc2, 0xeb, 0x46, 0xb9, 0xa3, 0xf5, 0x9a, 0xfb, 0xdb, 0xb9, 0xb8, 0xa5,
-	0x9a, 0xd0, 0x05, 0x7e, 0xb7, 0x05, 0x40, 0x91, 0x47, 0xe1, 0x04, 0x7d, 0x9b, 0x77, 0xc7, 0xb0,
-	0xaa, 0xe6, 0x4b, 0x87, 0xf2, 0xf7, 0x38, 0x95, 0xd2, 0x5a, 0x34, 0x3d, 0xa6, 0xa5, 0x94, 0xbd,
-	0x90, 0x3a, 0x5a, 0x54, 0x5d, 0xc8, 0x36, 0xd4, 0x67, 0xfd, 0x93, 0xa1, 0x5b, 0x34, 0xbf, 0x93,
-	0x1e, 0xd4, 0xa2, 0xd8, 0x11, 0xd3, 0x18, 0xe5, 0xcc, 0x3d, 0xda, 0x7f, 0x9c, 0xab, 0x3a, 0x8b,
-	0xed, 0x69, 0x8c, 0xb4, 0x1a, 0xc9, 0xdf, 0xee, 0x77, 0x50, 0xb7, 0x6f, 0x99, 0x8a, 0xfc, 0x12,
-	0xaa, 0x92, 0xa5, 0x7a, 0xd6, 0xdc, 0x7f, 0xb4, 0x58, 0x67, 0x9a, 0xa1, 0x64, 0x07, 0x1a, 0x5e,
-	0x74, 0x7d, 0x1d, 0x64, 0xad, 0xd3, 0x7a, 0x15, 0x5a, 0x57, 0x06, 0x9b, 0x93, 0x2d, 0xa8, 0xe7,
-	0x6d, 0x2d, 0x4b, 0xac, 0xc6, 0x55, 0x37, 0xbb, 0x4d, 0x68, 0xd8, 0xee, 0x65, 0x88, 0x16, 0x1b,
-	0x46, 0xdd, 0x3f, 0x35, 0x68, 0xa8, 0x6e, 0x21, 0xfa, 0xe4, 0x63, 0x80, 0x74, 0x20, 0x16, 0xc2,
-	0xaf, 0xe7, 0xe1, 0x67, 0x0a, 0x69, 0x43, 0x64, 0x27, 0x4e, 0xfe, 0x0f, 0xcd, 0x24, 0xab, 0xde,
-	0x5c, 0x06, 0x24, 0x79, 0x41, 0xc9, 0x01, 0xac, 0xf9, 0x01, 0x8f, 0xd5, 0x62, 0x3b, 0x81, 0x9f,
-	0xf5, 0x67, 0xab, 0x5f, 0xf8, 0x5a, 0xf4, 0x8f, 0x73, 0x86, 0x75, 0x4c, 0x5b, 0x73, 0xbe, 0xe5,
-	0xcb, 0x01, 0x76, 0x45, 0x10, 0xc9, 0x0a, 0x96, 0xa8, 0xba, 0x90, 0x4f, 0x00, 0x44, 0x9a, 0x83,
-	0x13, 0xb0, 0x61, 0x24, 0x77, 0xb2, 0xb9, 0x4f, 0xe6, 0x42, 0x67, 0xe9, 0xd1, 0x86, 0xc8, 0x33,
-	0xfd, 0xa9, 0x02, 0x5b, 0x14, 0x47, 0x01, 0x17, 0x98, 0xcc, 0xe3, 0x51, 0xbc, 0x19, 0x23, 0x17,
-	0xa9, 0x4c, 0xef, 0xca, 0x65, 0x23, 0x1c, 0x22, 0xfa, 0xa9, 0x4c, 0xed, 0x6f, 0x64, 0x1e, 0xe5,
-	0x8c, 0x54, 0xe6, 0x9c, 0x6f, 0xf9, 0xcb, 0x69, 0x96, 0xfe, 0x5b, 0x9a, 0x9f, 0xcf, 0x12, 0xe2,
-	0xb1, 0xcb, 0xb2, 0x1a, 0x3d, 0x5d, 0x70, 0x96, 0x49, 0x0d, 0x62, 0x97, 0x65, 0x49, 0xa5, 0xc7,
-	0x85, 0x36, 0x57, 0x16, 0xda, 0x9c, 0x8e, 0x07, 0xc7, 0x64, 0xa2, 0xd4, 0xa8, 0xaf, 0x56, 0x5d,
-	0x19, 0x2c, 0x9f, 0x7c, 0x06, 0x4d, 0xd7, 0x13, 0x41, 0xc4, 0xd4, 0x74, 0x56, 0xe5, 0x74, 0x6e,
-	0xe4, 0x05, 0x3c, 0x94, 0x98, 0x9c, 0x50, 0x70, 0xf3, 0x33, 0x79, 0x05, 0x6b, 0x6a, 0x75, 0x1c,
-	0x4f, 0xed, 0x5a, 0x4d, 0xea, 0xdc, 0xcc, 0xfd, 0xfe, 0x79, 0xcd, 0xc8, 0x2e, 0xac, 0x23, 0x53,
-	0x19, 0x4e, 0x99, 0xe7, 0xc4, 0x51, 0xc0, 0x84, 0x51, 0x97, 0x1b, 0xfd, 0x58, 0x01, 0x83, 0x29,
-	0xf3, 0xce, 0x53, 0x33, 0xe9, 0xc2, 0xda, 0x9c, 0x94, 0xa6, 0xd6, 0x90, 0xa9, 0x35, 0xf9, 0x8c,
-	0x61, 0x73, 0xd2, 0x87, 0x8d, 0x02, 0x27, 0x60, 0x02, 0x93, 0x89, 0x1b, 0x1a, 0x20, 0x99, 0xeb,
-	0x39, 0xd3, 0xca, 0x80, 0xf4, 0xff, 0x22, 0x62, 0xe1, 0xd4, 0x49, 0x70, 0xcc, 0xd1, 0x68, 0xca,
-	0xc0, 0x8d, 0xd4, 0x42, 0x53, 0xc3, 0xee, 0x87, 0x50, 0x55, 0x2b, 0x49, 0xd6, 0xa0, 0xa1, 0x4e,
-	0xe7, 0x63, 0xa1, 0xaf, 0x10, 0x1d, 0x5a, 0xea, 0xaa, 0xbe, 0xb7, 0xba, 0xb6, 0xfb, 0x87, 0x06,
-	0x30, 0x2f, 0x10, 0xd9, 0x81, 0xff, 0x1d, 0x1e, 0xd9, 0xd6, 0xd9, 0xa9, 0x63, 0x7f, 0x73, 0x6e,
-	0x3a, 0x17, 0xa7, 0x83, 0x73, 0xf3, 0xc8, 0x3a, 0xb1, 0xcc, 0x63, 0x7d, 0x85, 0x18, 0xf0, 0xa4,
-	0x08, 0x52, 0xf3, 0xb5, 0x35, 0xb0, 0x4d, 0xaa, 0x6b, 0xe4, 0x29, 0x90, 0x45, 0xe4, 0xcd, 0xd9,
-	0x5b, 0x53, 0x2f, 0x91, 0x4d, 0x58, 0x2f, 0xda, 0xcf, 0x0f, 0x2f, 0x06, 0xa6, 0x5e, 0x5e, 0xa6,
-	0x0f, 0x2e, 0xde, 0x98, 0x7a, 0xe5, 0x21, 0x9d, 0x9a, 0x03, 0xd3, 0xd6, 0x57, 0x49, 0x07, 0x9e,
-	0x2d, 0xbd, 0xe2, 0x1c, 0x7d, 0x7d, 0x78, 0xfa, 0xda, 0x3c, 0x31, 0xcd, 0x63, 0xbd, 0x4a, 0x5e,
-	0xc0, 0xf3, 0xe5, 0x07, 0x8b, 0x94, 0xda, 0x57, 0xaf, 0x7e, 0xb9, 0x6b, 0x6b, 0xef, 0xef, 0xda,
-	0xda, 0xef, 0x77, 0x6d, 0xed, 0x87, 0xfb, 0xf6, 0xca, 0xfb, 0xfb, 0xf6, 0xca, 0xaf, 0xf7, 0xed,
-	0x95, 0x6f, 0x3b, 0xa3, 0x40, 0x5c, 0x8d, 0x2f, 0xfb, 0x5e, 0x74, 0xbd, 0x17, 0x07, 0x6c, 0xe4,
-	0xb9, 0xf1, 0x9e, 0x08, 0x3c, 0xdf, 0xdb, 0xcb, 0x26, 0xe1, 0xb2, 0x2a, 0xff, 0xf6, 0x3f, 0xfd,
-	0x2b, 0x00, 0x00, 0xff, 0xff, 0x40, 0x60, 0xc1, 0x74, 0x33, 0x08, 0x00, 0x00,
+	// 1136 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0x4d, 0x6f, 0xdb, 0x46,
+	0x13, 0x36, 0xfd, 0xa1, 0x8f, 0x11, 0xe5, 0xc8, 0xeb, 0x38, 0x2f, 0x13, 0x27, 0x7e, 0x1d, 0xa5,
+	0x08, 0xdc, 0x00, 0x95, 0x5b, 0xb7, 0x45, 0x81, 0xa0, 0x08, 0xe0, 0xda, 0x4c, 0xc2, 0x43, 0x6c,
+	0x61, 0x45, 0x07, 0x68, 0x2f, 0x04, 0x45, 0x8e, 0x65, 0x36, 0xf4, 0x92, 0xd9, 0x5d, 0x29, 0xd6,
+	0xbf, 0xe8, 0xa9, 0xa7, 0xfe, 0xa0, 0x1e, 0x73, 0xec, 0xad, 0x45, 0x02, 0xb4, 0xff, 0xa1, 0xa7,
+	0x82, 0xbb, 0x14, 0x49, 0x59, 0x69, 0x81, 0x9c, 0xbc, 0x3b, 0xcf, 0x33, 0xb3, 0xcf, 0x7c, 0x51,
+	0x86, 0x4d, 0x9c, 0x20, 0x93, 0xe9, 0x70, 0x5f, 0xfd, 0xed, 0xa5, 0x3c, 0x91, 0x09, 0xa9, 0xe7,
+	0xc6, 0x3b, 0xdb, 0x17, 0xe8, 0x73, 0x39, 0x44, 0x3f, 0x63, 0x14, 0x67, 0xcd, 0xea, 0xfe, 0xbe,
+	0x0c, 0x37, 0xec, 0x8c, 0xf8, 0x34, 0x8a, 0x25, 0x72, 0x3a, 0x8e, 0x91, 0x58, 0x50, 0xbf, 0xf4,
+	0x65, 0x70, 0x81, 0xdc, 0x32, 0x76, 0x57, 0xf6, 0x9a, 0x74, 0x76, 0x25, 0xf7, 0xc1, 0x8c, 0x46,
+	0x2c, 0xe1, 0xe8, 0xa9, 0xe0, 0xd6, 0xb2, 0x82, 0x5b, 0xda, 0xa6, 0xc2, 0x90, 0x7b, 0x00, 0x39,
+	0x45, 0xbc, 0x8e, 0xad, 0x15, 0x45, 0x68, 0x6a, 0xcb, 0xe0, 0x75, 0x4c, 0xbe, 0x01, 0x2b, 0x87,
+	0x23, 0x26, 0x90, 0x4b, 0x6f, 0xe2, 0xc7, 0x63, 0xf4, 0xf0, 0x2a, 0xe5, 0xd6, 0xea, 0xae, 0xb1,
+	0xd7, 0xa4, 0x5b, 0x1a, 0x77, 0x14, 0xfc, 0x32, 0x43, 0xed, 0xab, 0x94, 0x93, 0x27, 0x70, 0x37,
+	0x77, 0x1c, 0xa7, 0xa1, 0x2f, 0xd1, 0x63, 0xf8, 0xa6, 0xea, 0xbc, 0xa6, 0x9c, 0xf3, 0xe0, 0x67,
+	0x8a, 0x72, 0x82, 0x6f, 0xfe, 0xc3, 0x3f, 0x89, 0xc3, 0xaa, 0x7f, 0x6d, 0xd1, 0xff, 0x34, 0x0e,
+	0x4b, 0xff, 0x52, 0x78, 0x88, 0x31, 0x4a, 0xac, 0xfa, 0xd6, 0xab, 0xc2, 0x8f, 0x15, 0x5c, 0x38,
+	0x76, 0x7f, 0x36, 0x60, 0xc3, 0x61, 0x0c, 0xb9, 0xae, 0xf0, 0x51, 0xc2, 0xce, 0xa3, 0x11, 0xb9,
+	0x09, 0x6b, 0x7c, 0x1c, 0xa3, 0xc8, 0x2b, 0xac, 0x2f, 0xe4, 0x33, 0xd8, 0xcc, 0x1f, 0x91, 0x57,
+	0xcc, 0x13, 0xd2, 0xe7, 0xd2, 0x93, 0x42, 0x95, 0x79, 0x95, 0x76, 0x34, 0xe4, 0x5e, 0xb1, 0x41,
+	0x06, 0xb8, 0x82, 0x7c, 0x0b, 0x66, 0xa5, 0x77, 0x42, 0x55, 0xbb, 0x75, 0x60, 0xf5, 0xf2, 0xce,
+	0xf7, 0xae, 0x35, 0x96, 0xce, 0xb1, 0xbb, 0xbf, 0x18, 0x60, 0xce, 0x69, 0xfa, 0x04, 0xda, 0x81,
+	0x2f, 0x70, 0x80, 0x4c, 0x44, 0x32, 0x9a, 0xa0, 0x65, 0xec, 0x1a, 0x7b, 0x0d, 0x3a, 0x6f, 0x24,
+	0x0f, 0x61, 0xfd, 0x3c, 0xe1, 0x01, 0x52, 0x4c, 0xe3, 0x28, 0xf0, 0x25, 0x5a, 0xcb, 0x8a, 0x76,
+	0xcd, 0x4a, 0x9e, 0x80, 0x79, 0x5e, 0x89, 0x6e, 0xad, 0xec, 0x1a, 0x7b, 0xad, 0x83, 0x3b, 0x85,
+	0xb8, 0x85, 0x9a, 0xd0, 0x39, 0x7e, 0xd7, 0x04, 0xa0, 0x28, 0x92, 0x78, 0x82, 0xa1, 0x2b, 0xba,
+	0x63, 0x58, 0xd3, 0xf3, 0xd5, 0x81, 0x95, 0x57, 0x38, 0x55, 0xd2, 0x4c, 0x9a, 0x1d, 0xb3, 0x52,
+	0xaa, 0x5e, 0x28, 0x1d, 0x26, 0xd5, 0x17, 0x72, 0x07, 0x1a, 0xb3, 0xfe, 0xa9, 0xa7, 0x4d, 0x5a,
+	0xdc, 0xc9, 0x1e, 0xd4, 0x93, 0xd4, 0x93, 0xd3, 0x14, 0xd5, 0xcc, 0xad, 0x1f, 0xdc, 0x28, 0x54,
+	0x9d, 0xa6, 0xee, 0x34, 0x45, 0x5a, 0x4b, 0xd4, 0xdf, 0xee, 0x8f, 0xd0, 0x70, 0xaf, 0x98, 0x7e,
+	0xf9, 0x21, 0xd4, 0x14, 0x4b, 0xf7, 0xac, 0x75, 0xb0, 0x3e, 0x5f, 0x67, 0x9a, 0xa3, 0x64, 0x1b,
+	0x9a, 0x41, 0x72, 0x79, 0x19, 0xe5, 0xad, 0x33, 0xf6, 0x56, 0x69, 0x43, 0x1b, 0x5c, 0x41, 0x6e,
+	0x43, 0xa3, 0x68, 0xeb, 0x8a, 0xc2, 0xea, 0x42, 0x77, 0xb3, 0xdb, 0x82, 0xa6, 0xeb, 0x0f, 0x63,
+	0x74, 0xd8, 0x79, 0xd2, 0xfd, 0xcb, 0x80, 0xa6, 0xee, 0x16, 0x62, 0x48, 0x3e, 0x07, 0xc8, 0x06,
+	0x62, 0xee, 0xf9, 0x8d, 0xe2, 0xf9, 0x99, 0x42, 0xda, 0x94, 0xf9, 0x49, 0x90, 0xff, 0x43, 0x8b,
+	0xe7, 0xd5, 0x2b, 0x65, 0x00, 0x2f, 0x0a, 0x4a, 0x9e, 0x40, 0x3b, 0x8c, 0x44, 0xaa, 0x17, 0xdb,
+	0x8b, 0xc2, 0xbc, 0x3f, 0xb7, 0x7b, 0x95, 0xaf, 0x45, 0xef, 0xb8, 0x60, 0x38, 0xc7, 0xd4, 0x2c,
+	0xf9, 0x4e, 0xa8, 0x06, 0xd8, 0x97, 0x51, 0xa2, 0x2a, 0xb8, 0x4c, 0xf5, 0x85, 0x7c, 0x01, 0x20,
+	0xb3, 0x1c, 0xbc, 0x88, 0x9d, 0x27, 0x6a, 0x27, 0x5b, 0x07, 0xa4, 0x14, 0x3a, 0x4b, 0x8f, 0x36,
+	0x65, 0x91, 0xe9, 0xdf, 0xab, 0x70, 0x9b, 0xe2, 0x28, 0x12, 0x12, 0x79, 0xf9, 0x1e, 0xc5, 0xd7,
+	0x63, 0x14, 0x32, 0x93, 0x19, 0x5c, 0xf8, 0x6c, 0x84, 0xe7, 0x88, 0x61, 0x26, 0xd3, 0xf8, 0x80,
+	0xcc, 0xa3, 0x82, 0x91, 0xc9, 0x2c, 0xf9, 0x4e, 0xb8, 0x98, 0xe6, 0xf2, 0xc7, 0xa5, 0xf9, 0xf5,
+	0x2c, 0x21, 0x91, 0xfa, 0x2c, 0xaf, 0xd1, 0xad, 0x39, 0x67, 0x95, 0xd4, 0x20, 0xf5, 0x59, 0x9e,
+	0x54, 0x76, 0x9c, 0x6b, 0xf3, 0xea, 0x5c, 0x9b, 0xb3, 0xf1, 0x10, 0xc8, 0x27, 0x5a, 0x8d, 0xfe,
+	0x6a, 0x35, 0xb4, 0xc1, 0x09, 0xc9, 0x57, 0xd0, 0xf2, 0x03, 0x19, 0x25, 0x4c, 0x4f, 0x67, 0x4d,
+	0x4d, 0xe7, 0x66, 0x51, 0xc0, 0x43, 0x85, 0xa9, 0x09, 0x05, 0xbf, 0x38, 0x93, 0xc7, 0xd0, 0xd6,
+	0xab, 0xe3, 0x05, 0x7a, 0xd7, 0xea, 0x4a, 0xe7, 0x56, 0xe1, 0xf7, 0xef, 0x6b, 0x46, 0x1e, 0xc1,
+	0x06, 0x32, 0x9d, 0xe1, 0x94, 0x05, 0x5e, 0x9a, 0x44, 0x4c, 0x5a, 0x0d, 0xb5, 0xd1, 0x37, 0x34,
+	0x30, 0x98, 0xb2, 0xa0, 0x9f, 0x99, 0x49, 0x17, 0xda, 0x25, 0x29, 0x4b, 0xad, 0xa9, 0x52, 0x6b,
+	0x89, 0x19, 0xc3, 0x15, 0xa4, 0x07, 0x9b, 0x15, 0x4e, 0xc4, 0x24, 0xf2, 0x89, 0x1f, 0x5b, 0xa0,
+	0x98, 0x1b, 0x05, 0xd3, 0xc9, 0x81, 0xec, 0xf7, 0x22, 0x61, 0xf1, 0xd4, 0xe3, 0x38, 0x16, 0x68,
+	0xb5, 0xd4, 0xc3, 0xcd, 0xcc, 0x42, 0x33, 0x43, 0x06, 0x07, 0xf1, 0x58, 0x48, 0x5d, 0x2e, 0x53,
+	0x45, 0x69, 0xe6, 0x16, 0x27, 0xcc, 0xea, 0x3c, 0x0c, 0xb9, 0x77, 0x99, 0x84, 0x68, 0xb5, 0x95,
+	0x6f, 0x7d, 0x18, 0xf2, 0x17, 0x49, 0x88, 0xe4, 0x01, 0xb4, 0x2f, 0xa2, 0xd1, 0x85, 0x97, 0xf2,
+	0x28, 0xe1, 0x91, 0x9c, 0x5a, 0xeb, 0x0a, 0x37, 0x33, 0x63, 0x3f, 0xb7, 0x75, 0xa7, 0x40, 0xca,
+	0xe6, 0xf7, 0x79, 0x32, 0xe2, 0x28, 0x3e, 0xb0, 0x1b, 0xc6, 0xc7, 0x0d, 0xcd, 0x83, 0x6c, 0x68,
+	0x31, 0x78, 0x55, 0xd4, 0x49, 0xaf, 0x9f, 0x59, 0x1a, 0x5d, 0xd1, 0x1d, 0xc1, 0x66, 0x19, 0xe2,
+	0xf9, 0x2c, 0x30, 0xe9, 0xc3, 0x56, 0xe5, 0xed, 0x34, 0x97, 0x84, 0xb3, 0xad, 0xdf, 0x2e, 0x7a,
+	0xba, 0xa8, 0x9b, 0xde, 0x0c, 0x17, 0x6c, 0x28, 0x1e, 0x7d, 0x0a, 0x35, 0xfd, 0x55, 0x23, 0x6d,
+	0x68, 0xea, 0x53, 0x7f, 0x2c, 0x3b, 0x4b, 0xa4, 0x03, 0xa6, 0xbe, 0xea, 0x9f, 0xac, 0x8e, 0xf1,
+	0xe8, 0x4f, 0x03, 0xa0, 0x9c, 0x31, 0xb2, 0x0d, 0xff, 0x3b, 0x3c, 0x72, 0x9d, 0xd3, 0x13, 0xcf,
+	0xfd, 0xbe, 0x6f, 0x7b, 0x67, 0x27, 0x83, 0xbe, 0x7d, 0xe4, 0x3c, 0x75, 0xec, 0xe3, 0xce, 0x12,
+	0xb1, 0xe0, 0x66, 0x15, 0xa4, 0xf6, 0x33, 0x67, 0xe0, 0xda, 0xb4, 0x63, 0x90, 0x5b, 0x40, 0xe6,
+	0x91, 0x17, 0xa7, 0x2f, 0xed, 0xce, 0x32, 0xd9, 0x82, 0x8d, 0xaa, 0xbd, 0x7f, 0x78, 0x36, 0xb0,
+	0x3b, 0x2b, 0x8b, 0xf4, 0xc1, 0xd9, 0x0b, 0xbb, 0xb3, 0x7a, 0x9d, 0x4e, 0xed, 0x81, 0xed, 0x76,
+	0xd6, 0xc8, 0x2e, 0xdc, 0x5d, 0x88, 0xe2, 0x1d, 0x3d, 0x3f, 0x3c, 0x79, 0x66, 0x3f, 0xb5, 0xed,
+	0xe3, 0x4e, 0x8d, 0xdc, 0x87, 0x7b, 0x8b, 0x01, 0xab, 0x94, 0xfa, 0x77, 0x8f, 0x7f, 0x7d, 0xb7,
+	0x63, 0xbc, 0x7d, 0xb7, 0x63, 0xfc, 0xf1, 0x6e, 0xc7, 0xf8, 0xe9, 0xfd, 0xce, 0xd2, 0xdb, 0xf7,
+	0x3b, 0x4b, 0xbf, 0xbd, 0xdf, 0x59, 0xfa, 0x61, 0x77, 0x14, 0xc9, 0x8b, 0xf1, 0xb0, 0x17, 0x24,
+	0x97, 0xfb, 0x69, 0xc4, 0x46, 0x81, 0x9f, 0xee, 0xcb, 0x28, 0x08, 0x83, 0xfd, 0xbc, 0xf0, 0xc3,
+	0x9a, 0xfa, 0xcf, 0xe9, 0xcb, 0x7f, 0x06, 0x00, 0xd7, 0x59, 0xbe, 0xa6, 0x76, 0x09, 0x00, 0x00,
 }
 
 func (m *EventFilterRule) Marshal() (dAtA []byte, err error) {
@@ -1221,6 +1360,31 @@ func (m *RegisterDispatcherRequest) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	_ = i
 	var l int
 	_ = l
+	if m.HighPriority {
+		i--
+		if m.HighPriority {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.BdrMode {
+		i--
+		if m.BdrMode {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.ClusterId != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.ClusterId))
+		i--
+		dAtA[i] = 0x60
+	}
 	if m.OnlyReuse {
 		i--
 		if m.OnlyReuse {
@@ -1319,6 +1483,83 @@ func (m *RegisterDispatcherRequest) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	return len(dAtA) - i, nil
 }
 
+func (m *DispatcherProgress) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DispatcherProgress) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DispatcherProgress) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.CheckpointTs != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.CheckpointTs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.DispatcherId != nil {
+		{
+			size, err := m.DispatcherId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvent(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DispatcherHeartbeat) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DispatcherHeartbeat) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DispatcherHeartbeat) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.DispatcherProgresses) > 0 {
+		for iNdEx := len(m.DispatcherProgresses) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DispatcherProgresses[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintEvent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintEvent(dAtA []byte, offset int, v uint64) int {
 	offset -= sovEvent(v)
 	base := offset
@@ -1556,6 +1797,46 @@ func (m *RegisterDispatcherRequest) Size() (n int) {
 	if m.OnlyReuse {
 		n += 2
 	}
+	if m.ClusterId != 0 {
+		n += 1 + sovEvent(uint64(m.ClusterId))
+	}
+	if m.BdrMode {
+		n += 2
+	}
+	if m.HighPriority {
+		n += 2
+	}
+	return n
+}
+
+func (m *DispatcherProgress) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DispatcherId != nil {
+		l = m.DispatcherId.Size()
+		n += 1 + l + sovEvent(uint64(l))
+	}
+	if m.CheckpointTs != 0 {
+		n += 1 + sovEvent(uint64(m.CheckpointTs))
+	}
+	return n
+}
+
+func (m *DispatcherHeartbeat) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.DispatcherProgresses) > 0 {
+		for _, e := range m.DispatcherProgresses {
+			l = e.Size()
+			n += 1 + l + sovEvent(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -3057,6 +3338,254 @@ func (m *RegisterDispatcherRequest) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.OnlyReuse = bool(v != 0)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterId", wireType)
+			}
+			m.ClusterId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ClusterId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BdrMode", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BdrMode = bool(v != 0)
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HighPriority", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HighPriority = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DispatcherProgress) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DispatcherProgress: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DispatcherProgress: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DispatcherId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DispatcherId == nil {
+				m.DispatcherId = &heartbeatpb.DispatcherID{}
+			}
+			if err := m.DispatcherId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
+			}
+			m.CheckpointTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CheckpointTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DispatcherHeartbeat) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DispatcherHeartbeat: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DispatcherHeartbeat: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DispatcherProgresses", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DispatcherProgresses = append(m.DispatcherProgresses, &DispatcherProgress{})
+			if err := m.DispatcherProgresses[len(m.DispatcherProgresses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvent(dAtA[iNdEx:])
@@ -226,6 +226,10 @@ type HeartBeatRequest struct {
 	Statuses        []*TableSpanStatus `protobuf:"bytes,3,rep,name=statuses,proto3" json:"statuses,omitempty"`
 	CompeleteStatus bool               `protobuf:"varint,4,opt,name=compeleteStatus,proto3" json:"compeleteStatus,omitempty"`
 	Err             *RunningError      `protobuf:"bytes,5,opt,name=err,proto3" json:"err,omitempty"`
+	// the used ratio of the event collector memory quota on the node
+	MemoryUsageRatio float32 `protobuf:"fixed32,6,opt,name=memory_usage_ratio,json=memoryUsageRatio,proto3" json:"memory_usage_ratio,omitempty"`
+	// the min redo resolvedTs of the dispatchers on the node, 0 if redo log is not enabled
+	RedoResolvedTs uint64 `protobuf:"varint,7,opt,name=redo_resolved_ts,json=redoResolvedTs,proto3" json:"redo_resolved_ts,omitempty"`
 }
 
 func (m *HeartBeatRequest) Reset()         { *m = HeartBeatRequest{} }
@@ -296,6 +300,20 @@ func (m *HeartBeatRequest) GetErr() *RunningError {
 	return nil
 }
 
+func (m *HeartBeatRequest) GetMemoryUsageRatio() float32 {
+	if m != nil {
+		return m.MemoryUsageRatio
+	}
+	return 0
+}
+
+func (m *HeartBeatRequest) GetRedoResolvedTs() uint64 {
+	if m != nil {
+		return m.RedoResolvedTs
+	}
+	return 0
+}
+
 type Watermark struct {
 	CheckpointTs uint64 `protobuf:"varint,1,opt,name=checkpointTs,proto3" json:"checkpointTs,omitempty"`
 	ResolvedTs   uint64 `protobuf:"varint,2,opt,name=resolvedTs,proto3" json:"resolvedTs,omitempty"`
@@ -357,9 +375,10 @@ func (m *Watermark) GetSeq() uint64 {
 }
 
 type DispatcherAction struct {
-	Action      Action `protobuf:"varint,1,opt,name=action,proto3,enum=heartbeatpb.Action" json:"action,omitempty"`
-	CommitTs    uint64 `protobuf:"varint,2,opt,name=CommitTs,proto3" json:"CommitTs,omitempty"`
-	IsSyncPoint bool   `protobuf:"varint,3,opt,name=IsSyncPoint,proto3" json:"IsSyncPoint,omitempty"`
+	Action          Action `protobuf:"varint,1,opt,name=action,proto3,enum=heartbeatpb.Action" json:"action,omitempty"`
+	CommitTs        uint64 `protobuf:"varint,2,opt,name=CommitTs,proto3" json:"CommitTs,omitempty"`
+	IsSyncPoint     bool   `protobuf:"varint,3,opt,name=IsSyncPoint,proto3" json:"IsSyncPoint,omitempty"`
+	IsConfigBarrier bool   `protobuf:"varint,4,opt,name=IsConfigBarrier,proto3" json:"IsConfigBarrier,omitempty"`
 }
 
 func (m *DispatcherAction) Reset()         { *m = DispatcherAction{} }
@@ -416,9 +435,17 @@ func (m *DispatcherAction) GetIsSyncPoint() bool {
 	return false
 }
 
+func (m *DispatcherAction) GetIsConfigBarrier() bool {
+	if m != nil {
+		return m.IsConfigBarrier
+	}
+	return false
+}
+
 type ACK struct {
-	CommitTs    uint64 `protobuf:"varint,1,opt,name=CommitTs,proto3" json:"CommitTs,omitempty"`
-	IsSyncPoint bool   `protobuf:"varint,2,opt,name=IsSyncPoint,proto3" json:"IsSyncPoint,omitempty"`
+	CommitTs        uint64 `protobuf:"varint,1,opt,name=CommitTs,proto3" json:"CommitTs,omitempty"`
+	IsSyncPoint     bool   `protobuf:"varint,2,opt,name=IsSyncPoint,proto3" json:"IsSyncPoint,omitempty"`
+	IsConfigBarrier bool   `protobuf:"varint,3,opt,name=IsConfigBarrier,proto3" json:"IsConfigBarrier,omitempty"`
 }
 
 func (m *ACK) Reset()         { *m = ACK{} }
@@ -468,6 +495,13 @@ func (m *ACK) GetIsSyncPoint() bool {
 	return false
 }
 
+func (m *ACK) GetIsConfigBarrier() bool {
+	if m != nil {
+		return m.IsConfigBarrier
+	}
+	return false
+}
+
 type InfluencedDispatchers struct {
 	InfluenceType InfluenceType `protobuf:"varint,1,opt,name=InfluenceType,proto3,enum=heartbeatpb.InfluenceType" json:"InfluenceType,omitempty"`
 	// only exist when type is normal
@@ -602,6 +636,9 @@ func (m *DispatcherStatus) GetAck() *ACK {
 type HeartBeatResponse struct {
 	ChangefeedID       *ChangefeedID       `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	DispatcherStatuses []*DispatcherStatus `protobuf:"bytes,2,rep,name=dispatcherStatuses,proto3" json:"dispatcherStatuses,omitempty"`
+	// the global redo resolvedTs of the changefeed, all the events whose commitTs
+	// is not larger than it have been persisted in the redo log.
+	RedoResolvedTs uint64 `protobuf:"varint,3,opt,name=redo_resolved_ts,json=redoResolvedTs,proto3" json:"redo_resolved_ts,omitempty"`
 }
 
 func (m *HeartBeatResponse) Reset()         { *m = HeartBeatResponse{} }
@@ -651,6 +688,13 @@ func (m *HeartBeatResponse) GetDispatcherStatuses() []*DispatcherStatus {
 	return nil
 }
 
+func (m *HeartBeatResponse) GetRedoResolvedTs() uint64 {
+	if m != nil {
+		return m.RedoResolvedTs
+	}
+	return 0
+}
+
 type CheckpointTsMessage struct {
 	ChangefeedID *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	CheckpointTs uint64        `protobuf:"varint,2,opt,name=checkpointTs,proto3" json:"checkpointTs,omitempty"`
@@ -891,6 +935,19 @@ type MaintainerStatus struct {
 	CheckpointTs  uint64          `protobuf:"varint,4,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
 	Err           []*RunningError `protobuf:"bytes,5,rep,name=err,proto3" json:"err,omitempty"`
 	BootstrapDone bool            `protobuf:"varint,6,opt,name=bootstrap_done,json=bootstrapDone,proto3" json:"bootstrap_done,omitempty"`
+	// the number of dispatchers on the draining nodes
+	DrainingDispatcherCount uint32 `protobuf:"varint,7,opt,name=draining_dispatcher_count,json=drainingDispatcherCount,proto3" json:"draining_dispatcher_count,omitempty"`
+	// the sum of the write throughput of all dispatchers of the changefeed
+	EventSizePerSecond float32 `protobuf:"fixed32,8,opt,name=event_size_per_second,json=eventSizePerSecond,proto3" json:"event_size_per_second,omitempty"`
+	// warning is reported when the changefeed is blocked on purpose,
+	// it doesn't make the changefeed restart like err does.
+	Warning *RunningError `protobuf:"bytes,9,opt,name=warning,proto3" json:"warning,omitempty"`
+	// the drain epoch of the draining nodes which the draining_dispatcher_count is counted with
+	DrainEpoch uint64 `protobuf:"varint,10,opt,name=drain_epoch,json=drainEpoch,proto3" json:"drain_epoch,omitempty"`
+	// the used ratio of the event collector memory quota on the maintainer node
+	MemoryUsageRatio float32 `protobuf:"fixed32,11,opt,name=memory_usage_ratio,json=memoryUsageRatio,proto3" json:"memory_usage_ratio,omitempty"`
+	// the checkpoint lag of the dispatchers on the maintainer node, in milliseconds
+	SinkLagMs uint64 `protobuf:"varint,12,opt,name=sink_lag_ms,json=sinkLagMs,proto3" json:"sink_lag_ms,omitempty"`
 }
 
 func (m *MaintainerStatus) Reset()         { *m = MaintainerStatus{} }
@@ -968,6 +1025,48 @@ func (m *MaintainerStatus) GetBootstrapDone() bool {
 	return false
 }
 
+func (m *MaintainerStatus) GetDrainingDispatcherCount() uint32 {
+	if m != nil {
+		return m.DrainingDispatcherCount
+	}
+	return 0
+}
+
+func (m *MaintainerStatus) GetEventSizePerSecond() float32 {
+	if m != nil {
+		return m.EventSizePerSecond
+	}
+	return 0
+}
+
+func (m *MaintainerStatus) GetWarning() *RunningError {
+	if m != nil {
+		return m.Warning
+	}
+	return nil
+}
+
+func (m *MaintainerStatus) GetDrainEpoch() uint64 {
+	if m != nil {
+		return m.DrainEpoch
+	}
+	return 0
+}
+
+func (m *MaintainerStatus) GetMemoryUsageRatio() float32 {
+	if m != nil {
+		return m.MemoryUsageRatio
+	}
+	return 0
+}
+
+func (m *MaintainerStatus) GetSinkLagMs() uint64 {
+	if m != nil {
+		return m.SinkLagMs
+	}
+	return 0
+}
+
 type CoordinatorBootstrapRequest struct {
 	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
 }
@@ -1013,7 +1112,8 @@ func (m *CoordinatorBootstrapRequest) GetVersion() int64 {
 }
 
 type CoordinatorBootstrapResponse struct {
-	Statuses []*MaintainerStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	Statuses   []*MaintainerStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	NodeLabels []string            `protobuf:"bytes,2,rep,name=node_labels,json=nodeLabels,proto3" json:"node_labels,omitempty"`
 }
 
 func (m *CoordinatorBootstrapResponse) Reset()         { *m = CoordinatorBootstrapResponse{} }
@@ -1056,6 +1156,13 @@ func (m *CoordinatorBootstrapResponse) GetStatuses() []*MaintainerStatus {
 	return nil
 }
 
+func (m *CoordinatorBootstrapResponse) GetNodeLabels() []string {
+	if m != nil {
+		return m.NodeLabels
+	}
+	return nil
+}
+
 type AddMaintainerRequest struct {
 	Id              *ChangefeedID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Config          []byte        `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
@@ -1124,6 +1231,60 @@ func (m *AddMaintainerRequest) GetIsNewChangefeed() bool {
 	return false
 }
 
+// UpdateMaintainerConfigRequest is sent by the coordinator to the maintainer
+// when the config of a running changefeed is updated.
+type UpdateMaintainerConfigRequest struct {
+	Id     *ChangefeedID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Config []byte        `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+}
+
+func (m *UpdateMaintainerConfigRequest) Reset()         { *m = UpdateMaintainerConfigRequest{} }
+func (m *UpdateMaintainerConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateMaintainerConfigRequest) ProtoMessage()    {}
+func (*UpdateMaintainerConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{16}
+}
+func (m *UpdateMaintainerConfigRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpdateMaintainerConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpdateMaintainerConfigRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UpdateMaintainerConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateMaintainerConfigRequest.Merge(m, src)
+}
+func (m *UpdateMaintainerConfigRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdateMaintainerConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateMaintainerConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateMaintainerConfigRequest proto.InternalMessageInfo
+
+func (m *UpdateMaintainerConfigRequest) GetId() *ChangefeedID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *UpdateMaintainerConfigRequest) GetConfig() []byte {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
 type RemoveMaintainerRequest struct {
 	Id      *ChangefeedID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Cascade bool          `protobuf:"varint,2,opt,name=cascade,proto3" json:"cascade,omitempty"`
@@ -1134,7 +1295,7 @@ func (m *RemoveMaintainerRequest) Reset()         { *m = RemoveMaintainerRequest
 func (m *RemoveMaintainerRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveMaintainerRequest) ProtoMessage()    {}
 func (*RemoveMaintainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{16}
+	return fileDescriptor_6d584080fdadb670, []int{17}
 }
 func (m *RemoveMaintainerRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1196,7 +1357,7 @@ func (m *MaintainerBootstrapRequest) Reset()         { *m = MaintainerBootstrapR
 func (m *MaintainerBootstrapRequest) String() string { return proto.CompactTextString(m) }
 func (*MaintainerBootstrapRequest) ProtoMessage()    {}
 func (*MaintainerBootstrapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{17}
+	return fileDescriptor_6d584080fdadb670, []int{18}
 }
 func (m *MaintainerBootstrapRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1279,7 +1440,7 @@ func (m *MaintainerBootstrapResponse) Reset()         { *m = MaintainerBootstrap
 func (m *MaintainerBootstrapResponse) String() string { return proto.CompactTextString(m) }
 func (*MaintainerBootstrapResponse) ProtoMessage()    {}
 func (*MaintainerBootstrapResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{18}
+	return fileDescriptor_6d584080fdadb670, []int{19}
 }
 func (m *MaintainerBootstrapResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1346,7 +1507,7 @@ func (m *MaintainerPostBootstrapRequest) Reset()         { *m = MaintainerPostBo
 func (m *MaintainerPostBootstrapRequest) String() string { return proto.CompactTextString(m) }
 func (*MaintainerPostBootstrapRequest) ProtoMessage()    {}
 func (*MaintainerPostBootstrapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{19}
+	return fileDescriptor_6d584080fdadb670, []int{20}
 }
 func (m *MaintainerPostBootstrapRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1406,7 +1567,7 @@ func (m *MaintainerPostBootstrapResponse) Reset()         { *m = MaintainerPostB
 func (m *MaintainerPostBootstrapResponse) String() string { return proto.CompactTextString(m) }
 func (*MaintainerPostBootstrapResponse) ProtoMessage()    {}
 func (*MaintainerPostBootstrapResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{20}
+	return fileDescriptor_6d584080fdadb670, []int{21}
 }
 func (m *MaintainerPostBootstrapResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1466,7 +1627,7 @@ func (m *SchemaInfo) Reset()         { *m = SchemaInfo{} }
 func (m *SchemaInfo) String() string { return proto.CompactTextString(m) }
 func (*SchemaInfo) ProtoMessage()    {}
 func (*SchemaInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{21}
+	return fileDescriptor_6d584080fdadb670, []int{22}
 }
 func (m *SchemaInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1525,7 +1686,7 @@ func (m *TableInfo) Reset()         { *m = TableInfo{} }
 func (m *TableInfo) String() string { return proto.CompactTextString(m) }
 func (*TableInfo) ProtoMessage()    {}
 func (*TableInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{22}
+	return fileDescriptor_6d584080fdadb670, []int{23}
 }
 func (m *TableInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1581,7 +1742,7 @@ func (m *BootstrapTableSpan) Reset()         { *m = BootstrapTableSpan{} }
 func (m *BootstrapTableSpan) String() string { return proto.CompactTextString(m) }
 func (*BootstrapTableSpan) ProtoMessage()    {}
 func (*BootstrapTableSpan) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{23}
+	return fileDescriptor_6d584080fdadb670, []int{24}
 }
 func (m *BootstrapTableSpan) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1652,6 +1813,69 @@ func (m *BootstrapTableSpan) GetBlockState() *State {
 	return nil
 }
 
+// MaintainerUpdateConfigRequest is sent by the maintainer to all the dispatcher managers
+// to apply the hot-reloadable changefeed config. The new config only takes effect
+// for the events whose commitTs is larger than the barrier_ts.
+type MaintainerUpdateConfigRequest struct {
+	ChangefeedID *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
+	Config       []byte        `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	BarrierTs    uint64        `protobuf:"varint,3,opt,name=barrier_ts,json=barrierTs,proto3" json:"barrier_ts,omitempty"`
+}
+
+func (m *MaintainerUpdateConfigRequest) Reset()         { *m = MaintainerUpdateConfigRequest{} }
+func (m *MaintainerUpdateConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*MaintainerUpdateConfigRequest) ProtoMessage()    {}
+func (*MaintainerUpdateConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{25}
+}
+func (m *MaintainerUpdateConfigRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MaintainerUpdateConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MaintainerUpdateConfigRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MaintainerUpdateConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MaintainerUpdateConfigRequest.Merge(m, src)
+}
+func (m *MaintainerUpdateConfigRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *MaintainerUpdateConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MaintainerUpdateConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MaintainerUpdateConfigRequest proto.InternalMessageInfo
+
+func (m *MaintainerUpdateConfigRequest) GetChangefeedID() *ChangefeedID {
+	if m != nil {
+		return m.ChangefeedID
+	}
+	return nil
+}
+
+func (m *MaintainerUpdateConfigRequest) GetConfig() []byte {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *MaintainerUpdateConfigRequest) GetBarrierTs() uint64 {
+	if m != nil {
+		return m.BarrierTs
+	}
+	return 0
+}
+
 type MaintainerCloseRequest struct {
 	ChangefeedID *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	// true when remove changefeed, false when pause the changefeed.
@@ -1662,7 +1886,7 @@ func (m *MaintainerCloseRequest) Reset()         { *m = MaintainerCloseRequest{}
 func (m *MaintainerCloseRequest) String() string { return proto.CompactTextString(m) }
 func (*MaintainerCloseRequest) ProtoMessage()    {}
 func (*MaintainerCloseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{24}
+	return fileDescriptor_6d584080fdadb670, []int{26}
 }
 func (m *MaintainerCloseRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1714,7 +1938,7 @@ func (m *MaintainerCloseResponse) Reset()         { *m = MaintainerCloseResponse
 func (m *MaintainerCloseResponse) String() string { return proto.CompactTextString(m) }
 func (*MaintainerCloseResponse) ProtoMessage()    {}
 func (*MaintainerCloseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{25}
+	return fileDescriptor_6d584080fdadb670, []int{27}
 }
 func (m *MaintainerCloseResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1769,7 +1993,7 @@ func (m *InfluencedTables) Reset()         { *m = InfluencedTables{} }
 func (m *InfluencedTables) String() string { return proto.CompactTextString(m) }
 func (*InfluencedTables) ProtoMessage()    {}
 func (*InfluencedTables) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{26}
+	return fileDescriptor_6d584080fdadb670, []int{28}
 }
 func (m *InfluencedTables) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1828,7 +2052,7 @@ func (m *Table) Reset()         { *m = Table{} }
 func (m *Table) String() string { return proto.CompactTextString(m) }
 func (*Table) ProtoMessage()    {}
 func (*Table) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{27}
+	return fileDescriptor_6d584080fdadb670, []int{29}
 }
 func (m *Table) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1881,7 +2105,7 @@ func (m *SchemaIDChange) Reset()         { *m = SchemaIDChange{} }
 func (m *SchemaIDChange) String() string { return proto.CompactTextString(m) }
 func (*SchemaIDChange) ProtoMessage()    {}
 func (*SchemaIDChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{28}
+	return fileDescriptor_6d584080fdadb670, []int{30}
 }
 func (m *SchemaIDChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1940,13 +2164,15 @@ type State struct {
 	UpdatedSchemas    []*SchemaIDChange `protobuf:"bytes,6,rep,name=UpdatedSchemas,proto3" json:"UpdatedSchemas,omitempty"`
 	IsSyncPoint       bool              `protobuf:"varint,7,opt,name=IsSyncPoint,proto3" json:"IsSyncPoint,omitempty"`
 	Stage             BlockStage        `protobuf:"varint,8,opt,name=stage,proto3,enum=heartbeatpb.BlockStage" json:"stage,omitempty"`
+	DDLType           int32             `protobuf:"varint,9,opt,name=DDLType,proto3" json:"DDLType,omitempty"`
+	IsConfigBarrier   bool              `protobuf:"varint,10,opt,name=IsConfigBarrier,proto3" json:"IsConfigBarrier,omitempty"`
 }
 
 func (m *State) Reset()         { *m = State{} }
 func (m *State) String() string { return proto.CompactTextString(m) }
 func (*State) ProtoMessage()    {}
 func (*State) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{29}
+	return fileDescriptor_6d584080fdadb670, []int{31}
 }
 func (m *State) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2031,6 +2257,20 @@ func (m *State) GetStage() BlockStage {
 	return BlockStage_NONE
 }
 
+func (m *State) GetDDLType() int32 {
+	if m != nil {
+		return m.DDLType
+	}
+	return 0
+}
+
+func (m *State) GetIsConfigBarrier() bool {
+	if m != nil {
+		return m.IsConfigBarrier
+	}
+	return false
+}
+
 type TableSpanBlockStatus struct {
 	ID    *DispatcherID `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
 	State *State        `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
@@ -2040,7 +2280,7 @@ func (m *TableSpanBlockStatus) Reset()         { *m = TableSpanBlockStatus{} }
 func (m *TableSpanBlockStatus) String() string { return proto.CompactTextString(m) }
 func (*TableSpanBlockStatus) ProtoMessage()    {}
 func (*TableSpanBlockStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{30}
+	return fileDescriptor_6d584080fdadb670, []int{32}
 }
 func (m *TableSpanBlockStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2094,7 +2334,7 @@ func (m *TableSpanStatus) Reset()         { *m = TableSpanStatus{} }
 func (m *TableSpanStatus) String() string { return proto.CompactTextString(m) }
 func (*TableSpanStatus) ProtoMessage()    {}
 func (*TableSpanStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{31}
+	return fileDescriptor_6d584080fdadb670, []int{33}
 }
 func (m *TableSpanStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2160,7 +2400,7 @@ func (m *BlockStatusRequest) Reset()         { *m = BlockStatusRequest{} }
 func (m *BlockStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*BlockStatusRequest) ProtoMessage()    {}
 func (*BlockStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{32}
+	return fileDescriptor_6d584080fdadb670, []int{34}
 }
 func (m *BlockStatusRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2214,7 +2454,7 @@ func (m *RunningError) Reset()         { *m = RunningError{} }
 func (m *RunningError) String() string { return proto.CompactTextString(m) }
 func (*RunningError) ProtoMessage()    {}
 func (*RunningError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{33}
+	return fileDescriptor_6d584080fdadb670, []int{35}
 }
 func (m *RunningError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2280,7 +2520,7 @@ func (m *DispatcherID) Reset()         { *m = DispatcherID{} }
 func (m *DispatcherID) String() string { return proto.CompactTextString(m) }
 func (*DispatcherID) ProtoMessage()    {}
 func (*DispatcherID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{34}
+	return fileDescriptor_6d584080fdadb670, []int{36}
 }
 func (m *DispatcherID) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2334,7 +2574,7 @@ func (m *ChangefeedID) Reset()         { *m = ChangefeedID{} }
 func (m *ChangefeedID) String() string { return proto.CompactTextString(m) }
 func (*ChangefeedID) ProtoMessage()    {}
 func (*ChangefeedID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{35}
+	return fileDescriptor_6d584080fdadb670, []int{37}
 }
 func (m *ChangefeedID) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2391,6 +2631,61 @@ func (m *ChangefeedID) GetNamespace() string {
 	return ""
 }
 
+// DrainNodeRequest is sent by the coordinator to all the nodes,
+// it carries the full set of nodes which are being drained.
+type DrainNodeRequest struct {
+	NodeIds []string `protobuf:"bytes,1,rep,name=node_ids,json=nodeIds,proto3" json:"node_ids,omitempty"`
+	// epoch is bumped every time the nodes are drained or undrained
+	Epoch uint64 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+}
+
+func (m *DrainNodeRequest) Reset()         { *m = DrainNodeRequest{} }
+func (m *DrainNodeRequest) String() string { return proto.CompactTextString(m) }
+func (*DrainNodeRequest) ProtoMessage()    {}
+func (*DrainNodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{38}
+}
+func (m *DrainNodeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DrainNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DrainNodeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DrainNodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainNodeRequest.Merge(m, src)
+}
+func (m *DrainNodeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DrainNodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainNodeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainNodeRequest proto.InternalMessageInfo
+
+func (m *DrainNodeRequest) GetNodeIds() []string {
+	if m != nil {
+		return m.NodeIds
+	}
+	return nil
+}
+
+func (m *DrainNodeRequest) GetEpoch() uint64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("heartbeatpb.Action", Action_name, Action_value)
 	proto.RegisterEnum("heartbeatpb.ScheduleAction", ScheduleAction_name, ScheduleAction_value)
@@ -2413,6 +2708,7 @@ func init() {
 	proto.RegisterType((*CoordinatorBootstrapRequest)(nil), "heartbeatpb.CoordinatorBootstrapRequest")
 	proto.RegisterType((*CoordinatorBootstrapResponse)(nil), "heartbeatpb.CoordinatorBootstrapResponse")
 	proto.RegisterType((*AddMaintainerRequest)(nil), "heartbeatpb.AddMaintainerRequest")
+	proto.RegisterType((*UpdateMaintainerConfigRequest)(nil), "heartbeatpb.UpdateMaintainerConfigRequest")
 	proto.RegisterType((*RemoveMaintainerRequest)(nil), "heartbeatpb.RemoveMaintainerRequest")
 	proto.RegisterType((*MaintainerBootstrapRequest)(nil), "heartbeatpb.MaintainerBootstrapRequest")
 	proto.RegisterType((*MaintainerBootstrapResponse)(nil), "heartbeatpb.MaintainerBootstrapResponse")
@@ -2421,6 +2717,7 @@ func init() {
 	proto.RegisterType((*SchemaInfo)(nil), "heartbeatpb.SchemaInfo")
 	proto.RegisterType((*TableInfo)(nil), "heartbeatpb.TableInfo")
 	proto.RegisterType((*BootstrapTableSpan)(nil), "heartbeatpb.BootstrapTableSpan")
+	proto.RegisterType((*MaintainerUpdateConfigRequest)(nil), "heartbeatpb.MaintainerUpdateConfigRequest")
 	proto.RegisterType((*MaintainerCloseRequest)(nil), "heartbeatpb.MaintainerCloseRequest")
 	proto.RegisterType((*MaintainerCloseResponse)(nil), "heartbeatpb.MaintainerCloseResponse")
 	proto.RegisterType((*InfluencedTables)(nil), "heartbeatpb.InfluencedTables")
@@ -2433,127 +2730,146 @@ func init() {
 	proto.RegisterType((*RunningError)(nil), "heartbeatpb.RunningError")
 	proto.RegisterType((*DispatcherID)(nil), "heartbeatpb.DispatcherID")
 	proto.RegisterType((*ChangefeedID)(nil), "heartbeatpb.ChangefeedID")
+	proto.RegisterType((*DrainNodeRequest)(nil), "heartbeatpb.DrainNodeRequest")
 }
 
 func init() { proto.RegisterFile("heartbeatpb/heartbeat.proto", fileDescriptor_6d584080fdadb670) }
 
 var fileDescriptor_6d584080fdadb670 = []byte{
-	// 1838 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x19, 0x4b, 0x6f, 0x23, 0x49,
-	0x39, 0xdd, 0x6d, 0x3b, 0xf6, 0xe7, 0x24, 0xd3, 0x53, 0xb3, 0x33, 0xe3, 0xc9, 0xc3, 0x9b, 0x2d,
-	0x40, 0x0a, 0x59, 0x48, 0x34, 0xd9, 0x1d, 0x2d, 0x20, 0x96, 0x25, 0xb1, 0xc3, 0xae, 0x15, 0x8d,
-	0x37, 0x2a, 0x07, 0x0d, 0xcb, 0xc5, 0x6a, 0x77, 0x57, 0x9c, 0x56, 0xec, 0xee, 0x9e, 0xae, 0x76,
-	0x92, 0x59, 0x89, 0x13, 0x57, 0x0e, 0x1c, 0x39, 0xac, 0x84, 0xf6, 0x08, 0x7f, 0x04, 0x8e, 0x73,
-	0x02, 0x0e, 0x1c, 0xd0, 0x8c, 0xf8, 0x03, 0x5c, 0xb8, 0xa2, 0xaa, 0xee, 0xea, 0x97, 0xdb, 0x49,
-	0x46, 0xb1, 0x38, 0xb9, 0xea, 0xab, 0xef, 0x55, 0xdf, 0xbb, 0xda, 0xb0, 0x76, 0x46, 0x0d, 0x3f,
-	0x18, 0x50, 0x23, 0xf0, 0x06, 0xbb, 0xf1, 0x7a, 0xc7, 0xf3, 0xdd, 0xc0, 0x45, 0xf5, 0xd4, 0x21,
-	0xfe, 0x0a, 0x6a, 0x27, 0xc6, 0x60, 0x44, 0x7b, 0x9e, 0xe1, 0xa0, 0x06, 0x2c, 0x8a, 0x4d, 0xa7,
-	0xdd, 0x50, 0x36, 0x95, 0x2d, 0x8d, 0xc8, 0x2d, 0x5a, 0x85, 0x6a, 0x2f, 0x30, 0xfc, 0xe0, 0x88,
-	0xbe, 0x6a, 0xa8, 0x9b, 0xca, 0xd6, 0x12, 0x89, 0xf7, 0xe8, 0x11, 0x54, 0x0e, 0x1d, 0x8b, 0x9f,
-	0x68, 0xe2, 0x24, 0xda, 0xe1, 0x3f, 0xa8, 0xa0, 0x7f, 0xc1, 0x45, 0x1d, 0x50, 0x23, 0x20, 0xf4,
-	0xe5, 0x84, 0xb2, 0x00, 0x7d, 0x0a, 0x4b, 0xe6, 0x99, 0xe1, 0x0c, 0xe9, 0x29, 0xa5, 0x56, 0x24,
-	0xa7, 0xbe, 0xf7, 0x64, 0x27, 0xa5, 0xd3, 0x4e, 0x2b, 0x85, 0x40, 0x32, 0xe8, 0xe8, 0x63, 0xa8,
-	0x5d, 0x1a, 0x01, 0xf5, 0xc7, 0x86, 0x7f, 0x2e, 0x14, 0xa9, 0xef, 0x3d, 0xca, 0xd0, 0xbe, 0x90,
-	0xa7, 0x24, 0x41, 0x44, 0x3f, 0x82, 0x2a, 0x0b, 0x8c, 0x60, 0xc2, 0x28, 0x6b, 0x68, 0x9b, 0xda,
-	0x56, 0x7d, 0x6f, 0x3d, 0x43, 0x14, 0x5b, 0xa0, 0x27, 0xb0, 0x48, 0x8c, 0x8d, 0xb6, 0xe0, 0x9e,
-	0xe9, 0x8e, 0x3d, 0x3a, 0xa2, 0x01, 0x0d, 0x0f, 0x1b, 0xa5, 0x4d, 0x65, 0xab, 0x4a, 0xf2, 0x60,
-	0xf4, 0x21, 0x68, 0xd4, 0xf7, 0x1b, 0xe5, 0x82, 0xfb, 0x90, 0x89, 0xe3, 0xd8, 0xce, 0xf0, 0xd0,
-	0xf7, 0x5d, 0x9f, 0x70, 0x2c, 0x6c, 0x40, 0x2d, 0x56, 0x14, 0x61, 0x6e, 0x12, 0x6a, 0x9e, 0x7b,
-	0xae, 0xed, 0x04, 0x27, 0x4c, 0x98, 0xa4, 0x44, 0x32, 0x30, 0xd4, 0x04, 0xf0, 0x29, 0x73, 0x47,
-	0x17, 0xd4, 0x3a, 0x61, 0xe2, 0xe2, 0x25, 0x92, 0x82, 0x20, 0x1d, 0x34, 0x46, 0x5f, 0x0a, 0x07,
-	0x94, 0x08, 0x5f, 0xe2, 0xdf, 0x80, 0xde, 0xb6, 0x99, 0x67, 0x04, 0xe6, 0x19, 0xf5, 0xf7, 0xcd,
-	0xc0, 0x76, 0x1d, 0xf4, 0x21, 0x54, 0x0c, 0xb1, 0x12, 0x32, 0x56, 0xf6, 0x1e, 0x64, 0xd4, 0x0c,
-	0x91, 0x48, 0x84, 0xc2, 0x5d, 0xde, 0x72, 0xc7, 0x63, 0x3b, 0x88, 0x05, 0xc6, 0x7b, 0xb4, 0x09,
-	0xf5, 0x0e, 0xeb, 0xbd, 0x72, 0xcc, 0x63, 0xae, 0x9f, 0x10, 0x5b, 0x25, 0x69, 0x10, 0x6e, 0x81,
-	0xb6, 0xdf, 0x3a, 0xca, 0x30, 0x51, 0xae, 0x67, 0xa2, 0x4e, 0x33, 0xf9, 0xad, 0x0a, 0x0f, 0x3b,
-	0xce, 0xe9, 0x68, 0x42, 0x1d, 0x93, 0x5a, 0xc9, 0x75, 0x18, 0xfa, 0x39, 0x2c, 0xc7, 0x07, 0x27,
-	0xaf, 0x3c, 0x1a, 0x5d, 0x68, 0x35, 0x73, 0xa1, 0x0c, 0x06, 0xc9, 0x12, 0xa0, 0xcf, 0x60, 0x39,
-	0x61, 0xd8, 0x69, 0xf3, 0x3b, 0x6a, 0x53, 0x9e, 0x4b, 0x63, 0x90, 0x2c, 0xbe, 0x48, 0x09, 0xf3,
-	0x8c, 0x8e, 0x8d, 0x4e, 0x5b, 0x18, 0x40, 0x23, 0xf1, 0x1e, 0x1d, 0xc1, 0x03, 0x7a, 0x65, 0x8e,
-	0x26, 0x16, 0x4d, 0xd1, 0x58, 0x22, 0x74, 0xae, 0x15, 0x51, 0x44, 0x85, 0xff, 0xa2, 0xa4, 0x5d,
-	0x19, 0x85, 0xdb, 0xaf, 0xe0, 0xa1, 0x5d, 0x64, 0x99, 0x28, 0xa1, 0x70, 0xb1, 0x21, 0xd2, 0x98,
-	0xa4, 0x98, 0x01, 0x7a, 0x16, 0x07, 0x49, 0x98, 0x5f, 0x1b, 0x33, 0xd4, 0xcd, 0x85, 0x0b, 0x06,
-	0xcd, 0x30, 0xcf, 0x85, 0x25, 0xea, 0x7b, 0x7a, 0x36, 0xb0, 0x5a, 0x47, 0x84, 0x1f, 0xe2, 0x6f,
-	0x15, 0xb8, 0x9f, 0xaa, 0x08, 0xcc, 0x73, 0x1d, 0x46, 0xef, 0x5a, 0x12, 0x9e, 0x03, 0xb2, 0x72,
-	0xd6, 0xa1, 0xd2, 0x9b, 0xb3, 0x74, 0x8f, 0xf2, 0xbc, 0x80, 0x10, 0x5f, 0xc1, 0x83, 0x56, 0x2a,
-	0xf3, 0x9e, 0x53, 0xc6, 0x8c, 0xe1, 0x9d, 0x95, 0xcc, 0xe7, 0xb8, 0x3a, 0x9d, 0xe3, 0xf8, 0xef,
-	0x19, 0x3f, 0xb7, 0x5c, 0xe7, 0xd4, 0x1e, 0xa2, 0x6d, 0x28, 0x31, 0xcf, 0x70, 0x22, 0x79, 0x8f,
-	0x8a, 0xcb, 0x16, 0x11, 0x38, 0xbc, 0x7c, 0x33, 0x5e, 0x94, 0x63, 0xfe, 0x72, 0xcb, 0xb5, 0xb7,
-	0x52, 0x71, 0x16, 0x79, 0xe9, 0x9a, 0x40, 0xcc, 0xa0, 0xf3, 0x50, 0x67, 0x32, 0xd4, 0x4b, 0x61,
-	0xa8, 0xcb, 0x3d, 0xc2, 0xb0, 0x6c, 0x4e, 0x7c, 0x9f, 0x3a, 0x41, 0xdf, 0xb3, 0xfa, 0x01, 0x13,
-	0x15, 0xb0, 0x44, 0xea, 0x11, 0xf0, 0xd8, 0x3a, 0x61, 0xf8, 0x6f, 0x0a, 0x3c, 0xe1, 0xb9, 0x61,
-	0x4d, 0x46, 0xa9, 0xd0, 0x9e, 0x53, 0x4b, 0x78, 0x06, 0x15, 0x53, 0xd8, 0xea, 0x86, 0x78, 0x0d,
-	0x0d, 0x4a, 0x22, 0x64, 0xd4, 0x82, 0x15, 0x16, 0xa9, 0x14, 0x46, 0xb2, 0x30, 0xca, 0xca, 0xde,
-	0x5a, 0x86, 0xbc, 0x97, 0x41, 0x21, 0x39, 0x12, 0x7c, 0x0c, 0x0f, 0x9e, 0x1b, 0xb6, 0x13, 0x18,
-	0xb6, 0x43, 0xfd, 0x2f, 0x24, 0x1d, 0xfa, 0x71, 0xaa, 0xdf, 0x28, 0x05, 0x81, 0x98, 0xd0, 0xe4,
-	0x1b, 0x0e, 0xfe, 0x46, 0x05, 0x3d, 0x7f, 0x7c, 0x57, 0x0b, 0x6d, 0x00, 0xf0, 0x55, 0x9f, 0x0b,
-	0xa1, 0xc2, 0x4a, 0x35, 0x52, 0xe3, 0x10, 0xce, 0x9e, 0xa2, 0xa7, 0x50, 0x0e, 0x4f, 0x8a, 0x0c,
-	0xd0, 0x72, 0xc7, 0x9e, 0xeb, 0x50, 0x27, 0x10, 0xb8, 0x24, 0xc4, 0x44, 0xdf, 0x81, 0xe5, 0x24,
-	0x74, 0xb9, 0xd3, 0x4b, 0x05, 0x3d, 0x2b, 0xee, 0x88, 0xda, 0xcd, 0x1d, 0x11, 0x7d, 0x0f, 0x56,
-	0x06, 0xae, 0x1b, 0xb0, 0xc0, 0x37, 0xbc, 0xbe, 0xe5, 0x3a, 0xb4, 0x51, 0x11, 0xfd, 0x60, 0x39,
-	0x86, 0xb6, 0x5d, 0x87, 0xe2, 0x4f, 0x60, 0xad, 0xe5, 0xba, 0xbe, 0x65, 0x3b, 0x46, 0xe0, 0xfa,
-	0x07, 0xf2, 0x4c, 0x86, 0x52, 0x03, 0x16, 0x2f, 0xa8, 0xcf, 0x64, 0x87, 0xd3, 0x88, 0xdc, 0xe2,
-	0xaf, 0x60, 0xbd, 0x98, 0x30, 0x2a, 0x42, 0x77, 0x70, 0xd9, 0x9f, 0x15, 0x78, 0x6f, 0xdf, 0xb2,
-	0x12, 0x0c, 0xa9, 0xcd, 0xf7, 0x41, 0xb5, 0xad, 0x9b, 0x9d, 0xa5, 0xda, 0x16, 0x9f, 0xa1, 0x52,
-	0x41, 0xbc, 0x14, 0x47, 0xe9, 0x94, 0xa1, 0xb5, 0x02, 0x43, 0x6f, 0xc3, 0x7d, 0x9b, 0xf5, 0x1d,
-	0x7a, 0xd9, 0x4f, 0xdc, 0x2e, 0xc7, 0x14, 0x9b, 0x75, 0xe9, 0x65, 0x22, 0x0e, 0x5f, 0xc1, 0x63,
-	0x42, 0xc7, 0xee, 0x05, 0xbd, 0x93, 0xba, 0x0d, 0x58, 0x34, 0x0d, 0x66, 0x1a, 0x16, 0x8d, 0xda,
-	0xb6, 0xdc, 0xf2, 0x13, 0x5f, 0xf0, 0xb7, 0xa2, 0xa9, 0x40, 0x6e, 0xf1, 0x1f, 0x55, 0x58, 0x4d,
-	0x84, 0x4e, 0xb9, 0xee, 0x8e, 0x31, 0x3e, 0xcb, 0x80, 0x4f, 0x84, 0x5f, 0xfd, 0x94, 0xed, 0xe2,
-	0xa2, 0x68, 0xc2, 0x07, 0x01, 0xaf, 0xa0, 0xfd, 0xc0, 0xb7, 0x87, 0x43, 0xea, 0xf7, 0xe9, 0x05,
-	0xaf, 0x62, 0x49, 0xe5, 0xeb, 0xdb, 0xb7, 0x68, 0xd9, 0x1b, 0x82, 0xc7, 0x49, 0xc8, 0xe2, 0x90,
-	0x73, 0x48, 0x37, 0xef, 0x62, 0xdf, 0x94, 0x8b, 0x7d, 0xf3, 0x6f, 0x05, 0xd6, 0x0a, 0x2d, 0x34,
-	0x9f, 0x46, 0xf9, 0x0c, 0xca, 0xbc, 0x4d, 0xc8, 0xde, 0xf8, 0x7e, 0x86, 0x2e, 0x96, 0x96, 0x34,
-	0x95, 0x10, 0x5b, 0xa6, 0xb1, 0x76, 0x9b, 0xc1, 0xf6, 0x56, 0x85, 0x01, 0xff, 0x57, 0x81, 0x66,
-	0x72, 0xcf, 0x63, 0x97, 0x05, 0xf3, 0x8e, 0x86, 0x5b, 0xb9, 0x56, 0xbd, 0xa3, 0x6b, 0x9f, 0xc2,
-	0x62, 0xd8, 0x05, 0xe5, 0xa3, 0xe2, 0xf1, 0x54, 0xeb, 0x18, 0x1b, 0x1d, 0xe7, 0xd4, 0x25, 0x12,
-	0x0f, 0xff, 0x47, 0x81, 0xf7, 0x67, 0xde, 0x7c, 0x3e, 0x5e, 0xfe, 0xbf, 0x5c, 0xfd, 0x5d, 0x62,
-	0x02, 0x5f, 0x01, 0x24, 0xb6, 0xc8, 0x8c, 0xcd, 0x4a, 0x6e, 0x6c, 0x6e, 0x4a, 0xcc, 0xae, 0x31,
-	0x96, 0x8d, 0x2a, 0x05, 0x41, 0x3b, 0x50, 0x11, 0xe1, 0x29, 0x0d, 0x5e, 0x30, 0x0e, 0x09, 0x7b,
-	0x47, 0x58, 0xb8, 0x15, 0x3d, 0x6e, 0x85, 0xe0, 0xd9, 0x8f, 0xdb, 0xf5, 0x08, 0x2d, 0x25, 0x35,
-	0x01, 0xe0, 0x3f, 0xa9, 0x80, 0xa6, 0xb3, 0x83, 0x57, 0xcb, 0x19, 0xce, 0xc9, 0x18, 0x52, 0x8d,
-	0x1e, 0xcf, 0xf2, 0xca, 0x6a, 0xee, 0xca, 0x72, 0xbe, 0xd3, 0x6e, 0x31, 0xdf, 0xfd, 0x02, 0x74,
-	0x53, 0xb6, 0xe3, 0x3e, 0x4b, 0x5e, 0xa3, 0x37, 0xf4, 0xec, 0x7b, 0x66, 0x7a, 0x3f, 0x61, 0xd3,
-	0x49, 0x5a, 0x2e, 0x68, 0x2a, 0x1f, 0x41, 0x7d, 0x30, 0x72, 0xcd, 0xf3, 0x68, 0x6a, 0xa8, 0x08,
-	0xfd, 0x50, 0x36, 0xc2, 0x05, 0x7b, 0x10, 0x68, 0x62, 0x8d, 0x5f, 0xc2, 0xa3, 0x24, 0xbc, 0x5b,
-	0x23, 0x97, 0xd1, 0x39, 0x25, 0x74, 0xaa, 0xad, 0xa8, 0xd9, 0xb6, 0xe2, 0xc3, 0xe3, 0x29, 0x91,
-	0xf3, 0xc9, 0x24, 0x3e, 0x4e, 0x4f, 0x4c, 0x93, 0x32, 0x26, 0x65, 0x46, 0x5b, 0xfc, 0x3b, 0x05,
-	0xf4, 0xe4, 0x4d, 0x15, 0x06, 0xdb, 0x1c, 0x9e, 0xa4, 0xab, 0x50, 0x8d, 0x42, 0x32, 0xac, 0xd1,
-	0x1a, 0x89, 0xf7, 0xd7, 0xbd, 0x36, 0xf1, 0xa7, 0x50, 0x16, 0x78, 0x37, 0x7c, 0xbf, 0x99, 0x11,
-	0x82, 0xd8, 0x81, 0x15, 0xb9, 0x0e, 0xad, 0x71, 0x0d, 0x9f, 0x4d, 0xa8, 0x7f, 0x39, 0xb2, 0x72,
-	0xac, 0xd2, 0x20, 0x8e, 0xd1, 0xa5, 0x97, 0x39, 0x5d, 0xd3, 0x20, 0xfc, 0xad, 0x06, 0xe5, 0x70,
-	0xf2, 0x5c, 0x87, 0x5a, 0x87, 0x1d, 0xf0, 0xf0, 0xa1, 0xe1, 0xe0, 0x51, 0x25, 0x09, 0x80, 0x6b,
-	0x21, 0x96, 0xc9, 0x73, 0x26, 0xda, 0xa2, 0xcf, 0xa0, 0x1e, 0x2e, 0x65, 0x31, 0x98, 0x9e, 0xfb,
-	0xf3, 0xee, 0x21, 0x69, 0x0a, 0x74, 0x04, 0xf7, 0xbb, 0x94, 0x5a, 0x6d, 0xdf, 0xf5, 0x3c, 0x89,
-	0x11, 0xb5, 0xfa, 0x1b, 0xd8, 0x4c, 0xd3, 0xa1, 0x9f, 0xc2, 0x3d, 0x0e, 0xdc, 0xb7, 0xac, 0x98,
-	0x55, 0x38, 0xf3, 0xa2, 0xe9, 0x6c, 0x26, 0x79, 0x54, 0xfe, 0x0e, 0xf9, 0xa5, 0x67, 0x19, 0x01,
-	0x8d, 0x4c, 0xc8, 0x1a, 0x15, 0x41, 0xbc, 0x56, 0xd4, 0x4c, 0x22, 0x07, 0x91, 0x1c, 0x49, 0xfe,
-	0x53, 0xca, 0xe2, 0xd4, 0xa7, 0x14, 0xf4, 0x43, 0x31, 0xe4, 0x0f, 0x69, 0xa3, 0x2a, 0xa2, 0x32,
-	0xdb, 0xaa, 0x0e, 0xa2, 0x0c, 0x1e, 0x86, 0x03, 0xfe, 0x90, 0xe2, 0x73, 0x78, 0x2f, 0xae, 0x3e,
-	0xf2, 0x94, 0x97, 0x8e, 0x77, 0xa8, 0x7a, 0x5b, 0xf2, 0x59, 0xa1, 0xce, 0x2c, 0x1d, 0x21, 0x02,
-	0xfe, 0xa7, 0x02, 0xf7, 0x72, 0x9f, 0xe0, 0xde, 0x45, 0x50, 0x51, 0x59, 0x54, 0xe7, 0x51, 0x16,
-	0x8b, 0x66, 0xed, 0xa7, 0xf0, 0x30, 0x6c, 0xa8, 0xcc, 0xfe, 0x9a, 0xf6, 0x3d, 0xea, 0xf7, 0x19,
-	0x35, 0x5d, 0x27, 0x1c, 0x14, 0x55, 0x82, 0xc4, 0x61, 0xcf, 0xfe, 0x9a, 0x1e, 0x53, 0xbf, 0x27,
-	0x4e, 0xf0, 0x37, 0x0a, 0xa0, 0x94, 0x0d, 0xe7, 0x54, 0x11, 0x3f, 0x87, 0xe5, 0x41, 0xc2, 0x34,
-	0xfe, 0xe2, 0xf1, 0x41, 0x71, 0x07, 0x49, 0xcb, 0xcf, 0xd2, 0x61, 0x0b, 0x96, 0xd2, 0x3d, 0x1b,
-	0x21, 0x28, 0x05, 0xf6, 0x38, 0x2c, 0x5f, 0x35, 0x22, 0xd6, 0x1c, 0xe6, 0xb8, 0x96, 0x6c, 0x8e,
-	0x62, 0xcd, 0x61, 0x26, 0x87, 0x69, 0x21, 0x8c, 0xaf, 0x79, 0xca, 0x8e, 0xc3, 0x0f, 0x26, 0xc2,
-	0x1e, 0x35, 0x22, 0xb7, 0xf8, 0x63, 0x58, 0x4a, 0x3b, 0x8e, 0x53, 0x9f, 0xd9, 0xc3, 0xb3, 0xe8,
-	0xa3, 0xa0, 0x58, 0x23, 0x1d, 0xb4, 0x91, 0x7b, 0x19, 0x25, 0x3b, 0x5f, 0xe2, 0x53, 0x58, 0x4a,
-	0x9b, 0xe0, 0x76, 0x54, 0x42, 0x5b, 0xde, 0xca, 0x23, 0xcd, 0xf8, 0x9a, 0x97, 0x1a, 0xfe, 0xcb,
-	0x3c, 0xc3, 0x94, 0xba, 0x25, 0x80, 0xed, 0x0d, 0xa8, 0x44, 0x9f, 0x48, 0x6b, 0x50, 0x7e, 0xe1,
-	0xdb, 0x01, 0xd5, 0x17, 0x50, 0x15, 0x4a, 0xc7, 0x06, 0x63, 0xba, 0xb2, 0xbd, 0x15, 0x56, 0xc8,
-	0xe4, 0xe1, 0x8f, 0x00, 0x2a, 0x2d, 0x9f, 0x1a, 0x02, 0x0f, 0xa0, 0x12, 0x3e, 0xa9, 0x74, 0x65,
-	0xfb, 0x27, 0x00, 0x49, 0x32, 0x71, 0x0e, 0xdd, 0x2f, 0xbb, 0x87, 0xfa, 0x02, 0xaa, 0xc3, 0xe2,
-	0x8b, 0xfd, 0xce, 0x49, 0xa7, 0xfb, 0xb9, 0xae, 0x88, 0x0d, 0x09, 0x37, 0x2a, 0xc7, 0x69, 0x73,
-	0x1c, 0x6d, 0xfb, 0x07, 0xb9, 0x06, 0x82, 0x16, 0x41, 0xdb, 0x1f, 0x8d, 0xf4, 0x05, 0x54, 0x01,
-	0xb5, 0x7d, 0xa0, 0x2b, 0x5c, 0x52, 0xd7, 0xf5, 0xc7, 0xc6, 0x48, 0x57, 0xb7, 0x3f, 0x81, 0x95,
-	0x6c, 0x40, 0x0b, 0xb6, 0xae, 0x7f, 0x6e, 0x3b, 0xc3, 0x50, 0x60, 0x2f, 0x10, 0x55, 0x2a, 0x14,
-	0x18, 0x6a, 0x68, 0xe9, 0xea, 0xc1, 0xcf, 0xfe, 0xfa, 0xa6, 0xa9, 0xbc, 0x7e, 0xd3, 0x54, 0xfe,
-	0xf5, 0xa6, 0xa9, 0xfc, 0xfe, 0x6d, 0x73, 0xe1, 0xf5, 0xdb, 0xe6, 0xc2, 0x3f, 0xde, 0x36, 0x17,
-	0x7e, 0xfd, 0xdd, 0xa1, 0x1d, 0x9c, 0x4d, 0x06, 0x3b, 0xa6, 0x3b, 0xde, 0xf5, 0x6c, 0x67, 0x68,
-	0x1a, 0xde, 0x6e, 0x60, 0x9b, 0x96, 0xb9, 0x9b, 0x8a, 0xa9, 0x41, 0x45, 0xfc, 0x8b, 0xf0, 0xd1,
-	0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0x1f, 0x8f, 0xc0, 0xf4, 0x64, 0x18, 0x00, 0x00,
+	// 2118 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x19, 0x4d, 0x73, 0x1b, 0x49,
+	0xd5, 0x33, 0xa3, 0xcf, 0x27, 0xdb, 0x51, 0x3a, 0x5f, 0xca, 0x87, 0x15, 0xef, 0x00, 0x55, 0xc2,
+	0xbb, 0x24, 0x15, 0x67, 0x53, 0x0b, 0x5b, 0x2c, 0x8b, 0x2d, 0x85, 0x5d, 0x95, 0x13, 0xaf, 0xab,
+	0xed, 0xad, 0x00, 0x97, 0xa9, 0xd6, 0x4c, 0x5b, 0x9e, 0xb2, 0x34, 0x33, 0xe9, 0x1e, 0xc5, 0x49,
+	0xae, 0x5c, 0x39, 0x70, 0xe1, 0x46, 0x15, 0x47, 0x0a, 0x2e, 0xfc, 0x0c, 0xa8, 0xe2, 0xb2, 0x27,
+	0xe0, 0xc0, 0x81, 0x4a, 0x6a, 0xef, 0x14, 0x17, 0xae, 0x54, 0x77, 0xcf, 0xa7, 0x34, 0xb2, 0x1d,
+	0x2c, 0xf6, 0xa4, 0x7e, 0xaf, 0xdf, 0xeb, 0xf7, 0xe6, 0xf5, 0xfb, 0x6c, 0xc1, 0xed, 0x23, 0x4a,
+	0x58, 0x38, 0xa0, 0x24, 0x0c, 0x06, 0xf7, 0x93, 0xf5, 0xbd, 0x80, 0xf9, 0xa1, 0x8f, 0x1a, 0x99,
+	0x4d, 0xf3, 0x67, 0x50, 0x3f, 0x20, 0x83, 0x11, 0xdd, 0x0f, 0x88, 0x87, 0x5a, 0x50, 0x95, 0x40,
+	0xbf, 0xd7, 0xd2, 0xd6, 0xb5, 0x8e, 0x81, 0x63, 0x10, 0xdd, 0x82, 0xda, 0x7e, 0x48, 0x58, 0xb8,
+	0x43, 0x5f, 0xb5, 0xf4, 0x75, 0xad, 0xb3, 0x8c, 0x13, 0x18, 0x5d, 0x87, 0xca, 0x63, 0xcf, 0x11,
+	0x3b, 0x86, 0xdc, 0x89, 0x20, 0xf3, 0x5f, 0x3a, 0x34, 0x3f, 0x17, 0xa2, 0xb6, 0x29, 0x09, 0x31,
+	0x7d, 0x3e, 0xa1, 0x3c, 0x44, 0x9f, 0xc0, 0xb2, 0x7d, 0x44, 0xbc, 0x21, 0x3d, 0xa4, 0xd4, 0x89,
+	0xe4, 0x34, 0x36, 0x6f, 0xde, 0xcb, 0xe8, 0x74, 0xaf, 0x9b, 0x21, 0xc0, 0x39, 0x72, 0xf4, 0x21,
+	0xd4, 0x4f, 0x48, 0x48, 0xd9, 0x98, 0xb0, 0x63, 0xa9, 0x48, 0x63, 0xf3, 0x7a, 0x8e, 0xf7, 0x59,
+	0xbc, 0x8b, 0x53, 0x42, 0xf4, 0x7d, 0xa8, 0xf1, 0x90, 0x84, 0x13, 0x4e, 0x79, 0xcb, 0x58, 0x37,
+	0x3a, 0x8d, 0xcd, 0x3b, 0x39, 0xa6, 0xc4, 0x02, 0xfb, 0x92, 0x0a, 0x27, 0xd4, 0xa8, 0x03, 0x97,
+	0x6c, 0x7f, 0x1c, 0xd0, 0x11, 0x0d, 0xa9, 0xda, 0x6c, 0x95, 0xd6, 0xb5, 0x4e, 0x0d, 0x4f, 0xa3,
+	0xd1, 0xfb, 0x60, 0x50, 0xc6, 0x5a, 0xe5, 0x82, 0xef, 0xc1, 0x13, 0xcf, 0x73, 0xbd, 0xe1, 0x63,
+	0xc6, 0x7c, 0x86, 0x05, 0x15, 0xfa, 0x00, 0xd0, 0x98, 0x8e, 0x7d, 0xf6, 0xca, 0x9a, 0x70, 0x32,
+	0xa4, 0x16, 0x23, 0xa1, 0xeb, 0xb7, 0x2a, 0xeb, 0x5a, 0x47, 0xc7, 0x4d, 0xb5, 0xf3, 0xa5, 0xd8,
+	0xc0, 0x02, 0x8f, 0x3a, 0xd0, 0x64, 0xd4, 0xf1, 0x2d, 0x46, 0xb9, 0x3f, 0x7a, 0x41, 0x1d, 0x2b,
+	0xe4, 0xad, 0xea, 0xba, 0xd6, 0x29, 0xe1, 0x55, 0x81, 0xc7, 0x11, 0xfa, 0x80, 0x9b, 0x04, 0xea,
+	0x89, 0x01, 0x90, 0x29, 0x4c, 0x4d, 0xed, 0xe3, 0xc0, 0x77, 0xbd, 0xf0, 0x80, 0x4b, 0x53, 0x97,
+	0x70, 0x0e, 0x87, 0xda, 0x00, 0x2c, 0x61, 0x97, 0x06, 0x2d, 0xe1, 0x0c, 0x06, 0x35, 0xc1, 0xe0,
+	0xf4, 0xb9, 0xbc, 0xd8, 0x12, 0x16, 0x4b, 0xf3, 0x77, 0x1a, 0x34, 0x7b, 0x2e, 0x0f, 0x48, 0x68,
+	0x1f, 0x51, 0xb6, 0x65, 0x87, 0xae, 0xef, 0xa1, 0xf7, 0xa1, 0x42, 0xe4, 0x4a, 0x0a, 0x59, 0xdd,
+	0xbc, 0x92, 0xfb, 0x7e, 0x45, 0x84, 0x23, 0x12, 0xe1, 0x4b, 0x5d, 0x7f, 0x3c, 0x76, 0xc3, 0x44,
+	0x62, 0x02, 0xa3, 0x75, 0x68, 0xf4, 0xf9, 0xfe, 0x2b, 0xcf, 0xde, 0x13, 0x0a, 0x4a, 0xb9, 0x35,
+	0x9c, 0x45, 0x89, 0x1b, 0xe9, 0xf3, 0xae, 0xef, 0x1d, 0xba, 0xc3, 0x6d, 0xc2, 0x98, 0x4b, 0x59,
+	0x7c, 0x23, 0x53, 0x68, 0x73, 0x0c, 0xc6, 0x56, 0x77, 0x27, 0x27, 0x4e, 0x3b, 0x5d, 0x9c, 0x7e,
+	0x2e, 0x71, 0x46, 0xb1, 0xb8, 0x5f, 0xe8, 0x70, 0xad, 0xef, 0x1d, 0x8e, 0x26, 0xd4, 0xb3, 0xa9,
+	0x93, 0x9a, 0x88, 0xa3, 0x1f, 0xc3, 0x4a, 0xb2, 0x71, 0xf0, 0x2a, 0xa0, 0x91, 0x91, 0x6e, 0xe5,
+	0x8c, 0x94, 0xa3, 0xc0, 0x79, 0x06, 0xf4, 0x29, 0xac, 0xa4, 0x07, 0xf6, 0x7b, 0xc2, 0x6e, 0xc6,
+	0x8c, 0x9b, 0x65, 0x29, 0x70, 0x9e, 0x5e, 0xc6, 0xaf, 0x7d, 0x44, 0xc7, 0xa4, 0xdf, 0x93, 0xfa,
+	0x1b, 0x38, 0x81, 0xd1, 0x0e, 0x5c, 0xa1, 0x2f, 0xed, 0xd1, 0xc4, 0xa1, 0x19, 0x1e, 0x47, 0x5a,
+	0xf5, 0x54, 0x11, 0x45, 0x5c, 0xe6, 0x9f, 0x72, 0xee, 0x11, 0xc5, 0xc6, 0x4f, 0xe1, 0x9a, 0x5b,
+	0x64, 0x99, 0x28, 0xfa, 0xcd, 0x62, 0x43, 0x64, 0x29, 0x71, 0xf1, 0x01, 0xe8, 0x51, 0xe2, 0x78,
+	0x2a, 0x19, 0xac, 0xcd, 0x51, 0x77, 0xca, 0x05, 0x4d, 0x30, 0x88, 0x7d, 0x2c, 0x2d, 0xd1, 0xd8,
+	0x6c, 0xe6, 0x9d, 0xb5, 0xbb, 0x83, 0xc5, 0xa6, 0xf9, 0x17, 0x0d, 0x2e, 0x67, 0xd2, 0x17, 0x0f,
+	0x7c, 0x8f, 0xd3, 0x8b, 0xe6, 0xaf, 0xa7, 0x80, 0x9c, 0x29, 0xeb, 0xd0, 0xf8, 0x36, 0xe7, 0xe9,
+	0x1e, 0x25, 0xa5, 0x02, 0xc6, 0xc2, 0xcc, 0x60, 0x14, 0x66, 0x86, 0x97, 0x70, 0xa5, 0x9b, 0x09,
+	0xfc, 0xa7, 0x94, 0x8b, 0xfc, 0x72, 0xd1, 0xcf, 0x99, 0x4e, 0x31, 0xfa, 0x6c, 0x8a, 0x31, 0xff,
+	0x96, 0xf3, 0x08, 0x15, 0x33, 0x68, 0x03, 0x4a, 0x3c, 0x20, 0x5e, 0x4b, 0x2b, 0x48, 0xe1, 0x49,
+	0x36, 0xc6, 0x25, 0x1e, 0x55, 0x25, 0x2e, 0x6a, 0x4d, 0x72, 0x7e, 0x0c, 0x0a, 0xed, 0x9d, 0x8c,
+	0x47, 0xb6, 0x8c, 0x02, 0xed, 0x73, 0x2e, 0x9b, 0x23, 0x17, 0x41, 0xc1, 0xe3, 0xa0, 0x28, 0xa9,
+	0xa0, 0x88, 0x61, 0x64, 0xc2, 0x8a, 0x3d, 0x61, 0x8c, 0x7a, 0xa1, 0x15, 0x48, 0xb3, 0x96, 0xa5,
+	0xe8, 0x46, 0x84, 0xdc, 0x13, 0x36, 0xfd, 0xab, 0x06, 0x37, 0x45, 0x14, 0x39, 0x93, 0x51, 0x26,
+	0x08, 0x16, 0x54, 0xe9, 0x1e, 0x41, 0xc5, 0x96, 0xb6, 0x3a, 0xc3, 0xb3, 0x95, 0x41, 0x71, 0x44,
+	0x8c, 0xba, 0xb0, 0xca, 0x23, 0x95, 0x94, 0xcf, 0x4b, 0xa3, 0xac, 0x6e, 0xde, 0xce, 0xb1, 0xef,
+	0xe7, 0x48, 0xf0, 0x14, 0x8b, 0xb9, 0x07, 0x57, 0x9e, 0x12, 0xd7, 0x0b, 0x89, 0xeb, 0x51, 0xf6,
+	0x79, 0xcc, 0x87, 0x7e, 0x90, 0x29, 0xa3, 0x5a, 0x81, 0xcb, 0xa6, 0x3c, 0xd3, 0x75, 0xd4, 0xfc,
+	0x63, 0x09, 0x9a, 0xd3, 0xdb, 0x17, 0xb5, 0xd0, 0x1a, 0x80, 0x58, 0x59, 0x42, 0x08, 0x95, 0x56,
+	0xaa, 0xe3, 0xba, 0xc0, 0x88, 0xe3, 0x29, 0x7a, 0x00, 0x65, 0xb5, 0x53, 0x64, 0x80, 0xae, 0x3f,
+	0x0e, 0x7c, 0x8f, 0x7a, 0xa1, 0xa4, 0xc5, 0x8a, 0x12, 0x7d, 0x0b, 0x56, 0x52, 0xd7, 0x15, 0x97,
+	0x5e, 0x2a, 0x28, 0x99, 0x49, 0xa1, 0x37, 0xce, 0x51, 0xe8, 0xbf, 0x03, 0xab, 0x03, 0xdf, 0x0f,
+	0x79, 0xc8, 0x48, 0x60, 0x39, 0xbe, 0x47, 0x65, 0x91, 0xaf, 0xe1, 0x95, 0x04, 0xdb, 0xf3, 0x3d,
+	0x8a, 0x3e, 0x86, 0x9b, 0x0e, 0x23, 0xae, 0x60, 0xb6, 0x52, 0x17, 0xb5, 0x6c, 0x7f, 0xe2, 0x85,
+	0xb2, 0xd4, 0xaf, 0xe0, 0x1b, 0x31, 0x41, 0xf6, 0xea, 0x27, 0x5e, 0x88, 0x1e, 0xc0, 0x35, 0xfa,
+	0x42, 0xf8, 0x29, 0x77, 0x5f, 0x53, 0x2b, 0xa0, 0xcc, 0xe2, 0xd4, 0xf6, 0x3d, 0xa7, 0x55, 0x93,
+	0xed, 0x04, 0x92, 0x9b, 0xfb, 0xee, 0x6b, 0xba, 0x47, 0xd9, 0xbe, 0xdc, 0x41, 0x0f, 0xa1, 0x7a,
+	0x42, 0x98, 0x38, 0xac, 0x55, 0x3f, 0xab, 0x5f, 0x89, 0x29, 0xd1, 0x5d, 0x68, 0x48, 0x15, 0x2c,
+	0x1a, 0xf8, 0xf6, 0x51, 0x0b, 0x54, 0xaf, 0x20, 0x51, 0x8f, 0x05, 0x66, 0x4e, 0x53, 0xd3, 0x98,
+	0xd3, 0xd4, 0xb4, 0xa1, 0xc1, 0x5d, 0xef, 0xd8, 0x1a, 0x91, 0xa1, 0x35, 0xe6, 0xad, 0x65, 0x79,
+	0x5c, 0x5d, 0xa0, 0x9e, 0x90, 0xe1, 0x53, 0x6e, 0x7e, 0x04, 0xb7, 0xbb, 0xbe, 0xcf, 0x1c, 0xd7,
+	0x23, 0xa1, 0xcf, 0xb6, 0x63, 0x73, 0xc5, 0xd1, 0xd5, 0x82, 0xea, 0x0b, 0xca, 0x78, 0xdc, 0x72,
+	0x18, 0x38, 0x06, 0xcd, 0xd7, 0x70, 0xa7, 0x98, 0x31, 0xca, 0xe0, 0xff, 0xbb, 0x17, 0x0b, 0x13,
+	0x78, 0xbe, 0x43, 0xad, 0x11, 0x19, 0xd0, 0x91, 0x4a, 0xdb, 0x75, 0x0c, 0x02, 0xf5, 0x44, 0x62,
+	0xcc, 0x3f, 0x68, 0x70, 0x75, 0xcb, 0x71, 0xd2, 0x23, 0x62, 0x75, 0xbf, 0x0b, 0xba, 0xeb, 0x9c,
+	0xed, 0xe0, 0xba, 0xeb, 0x88, 0x76, 0x3a, 0x13, 0xf8, 0xcb, 0x49, 0x64, 0xcf, 0x38, 0xa7, 0x51,
+	0xe0, 0x9c, 0x1b, 0x70, 0xd9, 0xe5, 0x96, 0x47, 0x4f, 0xac, 0x34, 0x54, 0xe2, 0xfe, 0xc8, 0xe5,
+	0xbb, 0xf4, 0x24, 0x15, 0x67, 0x0e, 0x60, 0xed, 0xcb, 0xc0, 0x21, 0x21, 0x4d, 0xd5, 0x8d, 0x92,
+	0xc9, 0xc2, 0x94, 0x36, 0x5f, 0xc2, 0x0d, 0x4c, 0xc7, 0xfe, 0x0b, 0x7a, 0x21, 0x93, 0xb4, 0xa0,
+	0x6a, 0x13, 0x6e, 0x13, 0x87, 0x46, 0x2d, 0x5a, 0x0c, 0x8a, 0x1d, 0x26, 0xcf, 0x77, 0xa2, 0xb6,
+	0x2c, 0x06, 0xcd, 0xdf, 0xea, 0x70, 0x2b, 0x15, 0x3a, 0xe3, 0x3f, 0x17, 0xcc, 0x3d, 0xf3, 0x2e,
+	0xe9, 0xa6, 0x74, 0x2e, 0x96, 0xb9, 0x9f, 0xa4, 0x58, 0xd9, 0xf0, 0x5e, 0x28, 0x2a, 0x9b, 0x15,
+	0x32, 0x77, 0x38, 0xa4, 0xcc, 0x52, 0x51, 0x9b, 0x09, 0x77, 0xf7, 0x1c, 0x4d, 0xd7, 0x9a, 0x3c,
+	0xe3, 0x40, 0x1d, 0xf1, 0x58, 0x9c, 0x90, 0xd9, 0x76, 0x8a, 0xef, 0xbf, 0x5c, 0x7c, 0xff, 0x5f,
+	0x6b, 0x70, 0xbb, 0xd0, 0x42, 0x8b, 0x69, 0x75, 0x1e, 0x41, 0x59, 0x94, 0xef, 0xb8, 0xbb, 0xb9,
+	0x9b, 0xe3, 0x4b, 0xa4, 0xa5, 0xc5, 0x5e, 0x51, 0xc7, 0xe9, 0xd5, 0x38, 0xd7, 0x1c, 0x75, 0x9e,
+	0x84, 0x6d, 0xfe, 0x47, 0x83, 0x76, 0xfa, 0x9d, 0x7b, 0x3e, 0x0f, 0x17, 0xed, 0x0d, 0xe7, 0xba,
+	0x5a, 0xfd, 0x82, 0x57, 0xfb, 0x00, 0xaa, 0xaa, 0x3b, 0x89, 0x67, 0xd8, 0x1b, 0x33, 0x25, 0x7d,
+	0x4c, 0xfa, 0xde, 0xa1, 0x8f, 0x63, 0x3a, 0xf3, 0xdf, 0x1a, 0xdc, 0x9d, 0xfb, 0xe5, 0x8b, 0xb9,
+	0xe5, 0x6f, 0xe4, 0xd3, 0xdf, 0xc5, 0x27, 0xcc, 0x97, 0x00, 0xa9, 0x2d, 0x72, 0x83, 0x8f, 0x36,
+	0x35, 0xf8, 0xb4, 0x63, 0xca, 0x5d, 0x32, 0x8e, 0x1b, 0x88, 0x0c, 0x06, 0xdd, 0x83, 0x8a, 0x74,
+	0xcf, 0xd8, 0xe0, 0x05, 0x6d, 0xaa, 0xb4, 0x77, 0x44, 0x65, 0x76, 0xa1, 0x9e, 0x20, 0x4f, 0x79,
+	0x4b, 0xb9, 0x13, 0x91, 0x65, 0xa4, 0xa6, 0x08, 0xf3, 0xf7, 0x3a, 0xa0, 0xd9, 0xe8, 0x10, 0xd9,
+	0x72, 0xce, 0xe5, 0xe4, 0x0c, 0xa9, 0x47, 0x6f, 0x35, 0xf1, 0x27, 0xeb, 0x53, 0x9f, 0x1c, 0xf7,
+	0xdd, 0xc6, 0x39, 0xfa, 0xee, 0x9f, 0x40, 0xd3, 0x8e, 0xdb, 0x24, 0x8b, 0xa7, 0x8f, 0x1f, 0x67,
+	0xf4, 0x52, 0x97, 0xec, 0x2c, 0x3c, 0xe1, 0xb3, 0x41, 0x5a, 0x2e, 0x28, 0x5c, 0x0f, 0xa1, 0x31,
+	0x18, 0xf9, 0xf6, 0x71, 0xd4, 0xcd, 0x55, 0xa4, 0x7e, 0x28, 0xef, 0xe1, 0xf2, 0x78, 0x90, 0x64,
+	0x72, 0x6d, 0xfe, 0x5a, 0x83, 0xb5, 0xd4, 0xbf, 0x55, 0x31, 0xcb, 0x97, 0xb0, 0xff, 0x53, 0x9a,
+	0x5f, 0x03, 0x18, 0xa8, 0xb1, 0x3f, 0x4d, 0xf4, 0xf5, 0x08, 0x73, 0xc0, 0xcd, 0xe7, 0x70, 0x3d,
+	0x53, 0x53, 0x47, 0x3e, 0xa7, 0x0b, 0xd2, 0x27, 0x53, 0xee, 0xf4, 0x7c, 0xb9, 0x63, 0x70, 0x63,
+	0x46, 0xe4, 0x62, 0x22, 0x5c, 0x8c, 0x5f, 0x13, 0xdb, 0xa6, 0x9c, 0xc7, 0x32, 0x23, 0xd0, 0xfc,
+	0xa5, 0x06, 0xcd, 0x74, 0x5a, 0x57, 0x41, 0xb0, 0x80, 0xc7, 0x8e, 0x5b, 0x50, 0x8b, 0x42, 0x45,
+	0xd5, 0x0e, 0x03, 0x27, 0xf0, 0x69, 0xef, 0x18, 0xe6, 0x27, 0x50, 0x96, 0x74, 0x67, 0x3c, 0x63,
+	0xce, 0x09, 0x0d, 0xd3, 0x83, 0xd5, 0x78, 0xad, 0xac, 0x71, 0xca, 0x39, 0xeb, 0xd0, 0xf8, 0x62,
+	0xe4, 0x4c, 0x1d, 0x95, 0x45, 0x09, 0x8a, 0x5d, 0x7a, 0x32, 0xa5, 0x6b, 0x16, 0x65, 0x7e, 0x6d,
+	0x40, 0x59, 0x4d, 0x2a, 0x77, 0xa0, 0xde, 0xe7, 0xdb, 0xc2, 0xad, 0xa9, 0x6a, 0x88, 0x6a, 0x38,
+	0x45, 0x08, 0x2d, 0xe4, 0x32, 0x1d, 0x7f, 0x23, 0x10, 0x7d, 0x0a, 0x0d, 0xb5, 0x8c, 0x93, 0xd4,
+	0xec, 0x9c, 0x38, 0x7d, 0x3d, 0x38, 0xcb, 0x81, 0x76, 0xe0, 0xf2, 0x2e, 0xa5, 0x4e, 0x8f, 0xf9,
+	0x41, 0x10, 0x53, 0xb4, 0x4a, 0xe7, 0x39, 0x66, 0x96, 0x0f, 0xfd, 0x10, 0x2e, 0x09, 0xe4, 0x96,
+	0xe3, 0x24, 0x47, 0xa9, 0x19, 0x09, 0xcd, 0x66, 0x19, 0x3c, 0x4d, 0x2a, 0xe6, 0x56, 0x15, 0xbf,
+	0x91, 0x09, 0x79, 0xab, 0x22, 0x99, 0x6f, 0x17, 0x15, 0xb9, 0xe8, 0x82, 0xf0, 0x14, 0xcb, 0xf4,
+	0x73, 0x5e, 0x75, 0xf6, 0x39, 0xef, 0x7b, 0x72, 0x28, 0x1c, 0x52, 0x39, 0x1c, 0xad, 0x4e, 0x95,
+	0xd0, 0xed, 0x28, 0xb3, 0x0c, 0xd5, 0x40, 0xa8, 0x3c, 0xa0, 0xd7, 0x7b, 0x22, 0xdd, 0x58, 0x0c,
+	0x4a, 0x65, 0x1c, 0x83, 0x45, 0xef, 0x82, 0x50, 0xfc, 0x2e, 0x78, 0x0c, 0x57, 0x93, 0xcc, 0x1a,
+	0x4b, 0x10, 0x69, 0xf1, 0x1d, 0x32, 0x7a, 0x27, 0x1e, 0x65, 0xf5, 0xb9, 0x69, 0x51, 0x11, 0x98,
+	0xff, 0xd0, 0xe0, 0xd2, 0xd4, 0x6b, 0xf6, 0xbb, 0x08, 0x2a, 0x4a, 0xf9, 0xfa, 0x22, 0x52, 0x7e,
+	0xd1, 0xac, 0x32, 0x77, 0x70, 0x2d, 0xcd, 0x1b, 0x5c, 0xcd, 0xdf, 0x68, 0x80, 0x32, 0x36, 0x5c,
+	0x50, 0x56, 0xfd, 0x0c, 0x56, 0x06, 0xe9, 0xa1, 0xc9, 0x7b, 0xdc, 0x7b, 0xc5, 0xd5, 0x31, 0x2b,
+	0x3f, 0xcf, 0x67, 0x3a, 0xb0, 0x9c, 0xed, 0x47, 0x10, 0x82, 0x52, 0xe8, 0x8e, 0x55, 0x0a, 0xac,
+	0x63, 0xb9, 0x16, 0x38, 0x31, 0x30, 0x46, 0x85, 0x5f, 0xae, 0x05, 0xce, 0x16, 0x38, 0x43, 0xe1,
+	0xc4, 0x5a, 0xb8, 0xde, 0x58, 0x3d, 0xd2, 0x49, 0x7b, 0xd4, 0x71, 0x0c, 0x9a, 0x1f, 0xc2, 0x72,
+	0xf6, 0xe2, 0x04, 0xf7, 0x91, 0x3b, 0x3c, 0x8a, 0x1e, 0xb7, 0xe5, 0x5a, 0xbc, 0xdb, 0x8f, 0xfc,
+	0x93, 0x28, 0x61, 0x88, 0xa5, 0x79, 0x08, 0xcb, 0x59, 0x13, 0x9c, 0x8f, 0x4b, 0x6a, 0x4b, 0xc6,
+	0x89, 0x66, 0x62, 0x2d, 0xd2, 0x95, 0xf8, 0xe5, 0x01, 0xb1, 0x63, 0xdd, 0x52, 0x84, 0xd9, 0x85,
+	0x66, 0x4f, 0xbc, 0x09, 0xec, 0xfa, 0x4e, 0x52, 0xf5, 0x6e, 0x42, 0x4d, 0xce, 0xcd, 0xae, 0xa3,
+	0x46, 0xee, 0x3a, 0xae, 0x0a, 0xb8, 0xef, 0x70, 0x74, 0x15, 0xca, 0xea, 0x3d, 0x41, 0x09, 0x55,
+	0xc0, 0xc6, 0x1a, 0x54, 0xa2, 0x7f, 0x16, 0xea, 0x50, 0x7e, 0xc6, 0xdc, 0x90, 0x36, 0x97, 0x50,
+	0x0d, 0x4a, 0x7b, 0x84, 0xf3, 0xa6, 0xb6, 0xd1, 0x51, 0xa9, 0x3a, 0x7d, 0xb1, 0x42, 0x00, 0x95,
+	0x2e, 0xa3, 0x44, 0xd2, 0x01, 0x54, 0xd4, 0xcc, 0xd9, 0xd4, 0x36, 0x3e, 0x06, 0x48, 0xa3, 0x5a,
+	0x9c, 0xb0, 0xfb, 0xc5, 0xee, 0xe3, 0xe6, 0x12, 0x6a, 0x40, 0xf5, 0xd9, 0x56, 0xff, 0xa0, 0xbf,
+	0xfb, 0x59, 0x53, 0x93, 0x00, 0x56, 0x80, 0x2e, 0x68, 0x7a, 0x82, 0xc6, 0xd8, 0xf8, 0x60, 0xaa,
+	0x92, 0xa1, 0x2a, 0x18, 0x5b, 0xa3, 0x51, 0x73, 0x09, 0x55, 0x40, 0xef, 0x6d, 0x37, 0x35, 0x21,
+	0x69, 0xd7, 0x67, 0x63, 0x32, 0x6a, 0xea, 0x1b, 0x1f, 0xc1, 0x6a, 0x3e, 0x2a, 0xe4, 0xb1, 0x3e,
+	0x3b, 0x76, 0xbd, 0xa1, 0x12, 0xb8, 0x1f, 0xca, 0x74, 0xa9, 0x04, 0x2a, 0x0d, 0x9d, 0xa6, 0xbe,
+	0xfd, 0xa3, 0x3f, 0xbf, 0x69, 0x6b, 0x5f, 0xbd, 0x69, 0x6b, 0xff, 0x7c, 0xd3, 0xd6, 0x7e, 0xf5,
+	0xb6, 0xbd, 0xf4, 0xd5, 0xdb, 0xf6, 0xd2, 0xdf, 0xdf, 0xb6, 0x97, 0x7e, 0xfe, 0xed, 0xa1, 0x1b,
+	0x1e, 0x4d, 0x06, 0xf7, 0x6c, 0x7f, 0x7c, 0x3f, 0x70, 0xbd, 0xa1, 0x4d, 0x82, 0xfb, 0xa1, 0x6b,
+	0x3b, 0xf6, 0xfd, 0x8c, 0x63, 0x0e, 0x2a, 0xf2, 0x5f, 0xbd, 0x87, 0xff, 0x1d, 0x00, 0xd4, 0x8f,
+	0x1a, 0x8f, 0xf4, 0x1b, 0x00, 0x00,
 }
 
 func (m *TableSpan) Marshal() (dAtA []byte, err error) {
@@ -2618,6 +2934,17 @@ func (m *HeartBeatRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.RedoResolvedTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.RedoResolvedTs))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.MemoryUsageRatio != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.MemoryUsageRatio))))
+		i--
+		dAtA[i] = 0x35
+	}
 	if m.Err != nil {
 		{
 			size, err := m.Err.MarshalToSizedBuffer(dAtA[:i])
@@ -2739,6 +3066,16 @@ func (m *DispatcherAction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.IsConfigBarrier {
+		i--
+		if m.IsConfigBarrier {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.IsSyncPoint {
 		i--
 		if m.IsSyncPoint {
@@ -2782,6 +3119,16 @@ func (m *ACK) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.IsConfigBarrier {
+		i--
+		if m.IsConfigBarrier {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.IsSyncPoint {
 		i--
 		if m.IsSyncPoint {
@@ -2938,6 +3285,11 @@ func (m *HeartBeatResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.RedoResolvedTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.RedoResolvedTs))
+		i--
+		dAtA[i] = 0x18
+	}
 	if len(m.DispatcherStatuses) > 0 {
 		for iNdEx := len(m.DispatcherStatuses) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -3178,23 +3530,62 @@ func (m *MaintainerStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	if m.BootstrapDone {
+	if m.SinkLagMs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.SinkLagMs))
 		i--
-		if m.BootstrapDone {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
+		dAtA[i] = 0x60
+	}
+	if m.MemoryUsageRatio != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.MemoryUsageRatio))))
 		i--
-		dAtA[i] = 0x30
+		dAtA[i] = 0x5d
 	}
-	if len(m.Err) > 0 {
-		for iNdEx := len(m.Err) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Err[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
+	if m.DrainEpoch != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.DrainEpoch))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.Warning != nil {
+		{
+			size, err := m.Warning.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.EventSizePerSecond != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.EventSizePerSecond))))
+		i--
+		dAtA[i] = 0x45
+	}
+	if m.DrainingDispatcherCount != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.DrainingDispatcherCount))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.BootstrapDone {
+		i--
+		if m.BootstrapDone {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Err) > 0 {
+		for iNdEx := len(m.Err) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Err[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
 				i -= size
 				i = encodeVarintHeartbeat(dAtA, i, uint64(size))
 			}
@@ -3282,6 +3673,15 @@ func (m *CoordinatorBootstrapResponse) MarshalToSizedBuffer(dAtA []byte) (int, e
 	_ = i
 	var l int
 	_ = l
+	if len(m.NodeLabels) > 0 {
+		for iNdEx := len(m.NodeLabels) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.NodeLabels[iNdEx])
+			copy(dAtA[i:], m.NodeLabels[iNdEx])
+			i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.NodeLabels[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
 	if len(m.Statuses) > 0 {
 		for iNdEx := len(m.Statuses) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -3356,6 +3756,48 @@ func (m *AddMaintainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *UpdateMaintainerConfigRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateMaintainerConfigRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdateMaintainerConfigRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Config) > 0 {
+		i -= len(m.Config)
+		copy(dAtA[i:], m.Config)
+		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.Config)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != nil {
+		{
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *RemoveMaintainerRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3824,6 +4266,53 @@ func (m *BootstrapTableSpan) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *MaintainerUpdateConfigRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MaintainerUpdateConfigRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MaintainerUpdateConfigRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.BarrierTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.BarrierTs))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Config) > 0 {
+		i -= len(m.Config)
+		copy(dAtA[i:], m.Config)
+		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.Config)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ChangefeedID != nil {
+		{
+			size, err := m.ChangefeedID.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *MaintainerCloseRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3940,21 +4429,21 @@ func (m *InfluencedTables) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x18
 	}
 	if len(m.TableIDs) > 0 {
-		dAtA32 := make([]byte, len(m.TableIDs)*10)
-		var j31 int
+		dAtA34 := make([]byte, len(m.TableIDs)*10)
+		var j33 int
 		for _, num1 := range m.TableIDs {
 			num := uint64(num1)
 			for num >= 1<<7 {
-				dAtA32[j31] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA34[j33] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j31++
+				j33++
 			}
-			dAtA32[j31] = uint8(num)
-			j31++
+			dAtA34[j33] = uint8(num)
+			j33++
 		}
-		i -= j31
-		copy(dAtA[i:], dAtA32[:j31])
-		i = encodeVarintHeartbeat(dAtA, i, uint64(j31))
+		i -= j33
+		copy(dAtA[i:], dAtA34[:j33])
+		i = encodeVarintHeartbeat(dAtA, i, uint64(j33))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -4057,6 +4546,21 @@ func (m *State) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.IsConfigBarrier {
+		i--
+		if m.IsConfigBarrier {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.DDLType != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.DDLType))
+		i--
+		dAtA[i] = 0x48
+	}
 	if m.Stage != 0 {
 		i = encodeVarintHeartbeat(dAtA, i, uint64(m.Stage))
 		i--
@@ -4420,6 +4924,43 @@ func (m *ChangefeedID) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *DrainNodeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DrainNodeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DrainNodeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Epoch != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.Epoch))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.NodeIds) > 0 {
+		for iNdEx := len(m.NodeIds) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.NodeIds[iNdEx])
+			copy(dAtA[i:], m.NodeIds[iNdEx])
+			i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.NodeIds[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintHeartbeat(dAtA []byte, offset int, v uint64) int {
 	offset -= sovHeartbeat(v)
 	base := offset
@@ -4478,6 +5019,12 @@ func (m *HeartBeatRequest) Size() (n int) {
 		l = m.Err.Size()
 		n += 1 + l + sovHeartbeat(uint64(l))
 	}
+	if m.MemoryUsageRatio != 0 {
+		n += 5
+	}
+	if m.RedoResolvedTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.RedoResolvedTs))
+	}
 	return n
 }
 
@@ -4514,6 +5061,9 @@ func (m *DispatcherAction) Size() (n int) {
 	if m.IsSyncPoint {
 		n += 2
 	}
+	if m.IsConfigBarrier {
+		n += 2
+	}
 	return n
 }
 
@@ -4529,6 +5079,9 @@ func (m *ACK) Size() (n int) {
 	if m.IsSyncPoint {
 		n += 2
 	}
+	if m.IsConfigBarrier {
+		n += 2
+	}
 	return n
 }
 
@@ -4594,6 +5147,9 @@ func (m *HeartBeatResponse) Size() (n int) {
 			n += 1 + l + sovHeartbeat(uint64(l))
 		}
 	}
+	if m.RedoResolvedTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.RedoResolvedTs))
+	}
 	return n
 }
 
@@ -4703,6 +5259,25 @@ func (m *MaintainerStatus) Size() (n int) {
 	if m.BootstrapDone {
 		n += 2
 	}
+	if m.DrainingDispatcherCount != 0 {
+		n += 1 + sovHeartbeat(uint64(m.DrainingDispatcherCount))
+	}
+	if m.EventSizePerSecond != 0 {
+		n += 5
+	}
+	if m.Warning != nil {
+		l = m.Warning.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.DrainEpoch != 0 {
+		n += 1 + sovHeartbeat(uint64(m.DrainEpoch))
+	}
+	if m.MemoryUsageRatio != 0 {
+		n += 5
+	}
+	if m.SinkLagMs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.SinkLagMs))
+	}
 	return n
 }
 
@@ -4730,6 +5305,12 @@ func (m *CoordinatorBootstrapResponse) Size() (n int) {
 			n += 1 + l + sovHeartbeat(uint64(l))
 		}
 	}
+	if len(m.NodeLabels) > 0 {
+		for _, s := range m.NodeLabels {
+			l = len(s)
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -4756,6 +5337,23 @@ func (m *AddMaintainerRequest) Size() (n int) {
 	return n
 }
 
+func (m *UpdateMaintainerConfigRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	l = len(m.Config)
+	if l > 0 {
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	return n
+}
+
 func (m *RemoveMaintainerRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -4940,6 +5538,26 @@ func (m *BootstrapTableSpan) Size() (n int) {
 	return n
 }
 
+func (m *MaintainerUpdateConfigRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChangefeedID != nil {
+		l = m.ChangefeedID.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	l = len(m.Config)
+	if l > 0 {
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.BarrierTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.BarrierTs))
+	}
+	return n
+}
+
 func (m *MaintainerCloseRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -5065,6 +5683,12 @@ func (m *State) Size() (n int) {
 	if m.Stage != 0 {
 		n += 1 + sovHeartbeat(uint64(m.Stage))
 	}
+	if m.DDLType != 0 {
+		n += 1 + sovHeartbeat(uint64(m.DDLType))
+	}
+	if m.IsConfigBarrier {
+		n += 2
+	}
 	return n
 }
 
@@ -5189,6 +5813,24 @@ func (m *ChangefeedID) Size() (n int) {
 	return n
 }
 
+func (m *DrainNodeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.NodeIds) > 0 {
+		for _, s := range m.NodeIds {
+			l = len(s)
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
+	if m.Epoch != 0 {
+		n += 1 + sovHeartbeat(uint64(m.Epoch))
+	}
+	return n
+}
+
 func sovHeartbeat(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -5523,6 +6165,36 @@ func (m *HeartBeatRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryUsageRatio", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+			m.MemoryUsageRatio = float32(math.Float32frombits(v))
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RedoResolvedTs", wireType)
+			}
+			m.RedoResolvedTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RedoResolvedTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -5738,11 +6410,31 @@ func (m *DispatcherAction) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.IsSyncPoint = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipHeartbeat(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsConfigBarrier", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsConfigBarrier = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
 			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthHeartbeat
@@ -5827,6 +6519,26 @@ func (m *ACK) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.IsSyncPoint = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsConfigBarrier", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsConfigBarrier = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -6263,6 +6975,25 @@ func (m *HeartBeatResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RedoResolvedTs", wireType)
+			}
+			m.RedoResolvedTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RedoResolvedTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -6982,6 +7713,121 @@ func (m *MaintainerStatus) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.BootstrapDone = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DrainingDispatcherCount", wireType)
+			}
+			m.DrainingDispatcherCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DrainingDispatcherCount |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventSizePerSecond", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+			m.EventSizePerSecond = float32(math.Float32frombits(v))
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Warning", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Warning == nil {
+				m.Warning = &RunningError{}
+			}
+			if err := m.Warning.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DrainEpoch", wireType)
+			}
+			m.DrainEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DrainEpoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryUsageRatio", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+			m.MemoryUsageRatio = float32(math.Float32frombits(v))
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SinkLagMs", wireType)
+			}
+			m.SinkLagMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SinkLagMs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -7135,6 +7981,38 @@ func (m *CoordinatorBootstrapResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeLabels", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeLabels = append(m.NodeLabels, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -7315,6 +8193,126 @@ func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *UpdateMaintainerConfigRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateMaintainerConfigRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateMaintainerConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &ChangefeedID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Config", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Config = append(m.Config[:0], dAtA[iNdEx:postIndex]...)
+			if m.Config == nil {
+				m.Config = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -8303,16 +9301,227 @@ func (m *TableInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TableID |= int64(b&0x7F) << shift
+				m.TableID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TableName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BootstrapTableSpan: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BootstrapTableSpan: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ID == nil {
+				m.ID = &DispatcherID{}
+			}
+			if err := m.ID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchemaID", wireType)
+			}
+			m.SchemaID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SchemaID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Span", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Span == nil {
+				m.Span = &TableSpan{}
+			}
+			if err := m.Span.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ComponentStatus", wireType)
+			}
+			m.ComponentStatus = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ComponentStatus |= ComponentState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
+			}
+			m.CheckpointTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CheckpointTs |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TableName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockState", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8322,23 +9531,27 @@ func (m *TableInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthHeartbeat
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeartbeat
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TableName = string(dAtA[iNdEx:postIndex])
+			if m.BlockState == nil {
+				m.BlockState = &State{}
+			}
+			if err := m.BlockState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -8361,7 +9574,7 @@ func (m *TableInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
+func (m *MaintainerUpdateConfigRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8384,15 +9597,15 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BootstrapTableSpan: wiretype end group for non-group")
+			return fmt.Errorf("proto: MaintainerUpdateConfigRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BootstrapTableSpan: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MaintainerUpdateConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8419,37 +9632,18 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ID == nil {
-				m.ID = &DispatcherID{}
+			if m.ChangefeedID == nil {
+				m.ChangefeedID = &ChangefeedID{}
 			}
-			if err := m.ID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SchemaID", wireType)
-			}
-			m.SchemaID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeartbeat
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.SchemaID |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Span", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Config", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8459,71 +9653,31 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthHeartbeat
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeartbeat
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Span == nil {
-				m.Span = &TableSpan{}
-			}
-			if err := m.Span.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Config = append(m.Config[:0], dAtA[iNdEx:postIndex]...)
+			if m.Config == nil {
+				m.Config = []byte{}
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ComponentStatus", wireType)
-			}
-			m.ComponentStatus = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeartbeat
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ComponentStatus |= ComponentState(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
-			}
-			m.CheckpointTs = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeartbeat
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.CheckpointTs |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockState", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BarrierTs", wireType)
 			}
-			var msglen int
+			m.BarrierTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -8533,28 +9687,11 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.BarrierTs |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.BlockState == nil {
-				m.BlockState = &State{}
-			}
-			if err := m.BlockState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -9394,6 +10531,45 @@ func (m *State) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DDLType", wireType)
+			}
+			m.DDLType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DDLType |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsConfigBarrier", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsConfigBarrier = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -10210,6 +11386,107 @@ func (m *ChangefeedID) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *DrainNodeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DrainNodeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DrainNodeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeIds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeIds = append(m.NodeIds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
+			}
+			m.Epoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Epoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipHeartbeat(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
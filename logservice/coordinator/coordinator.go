@@ -15,6 +15,7 @@ package logcoordinator
 
 import (
 	"context"
+	"net"
 	"sort"
 	"sync"
 	"time"
@@ -26,7 +27,6 @@ import (
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
-	"github.com/pingcap/ticdc/pkg/spanz"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/tiflow/pkg/chann"
 	"go.uber.org/zap"
@@ -185,19 +185,25 @@ func (c *logCoordinator) updateEventStoreState(nodeId node.ID, state *logservice
 	c.eventStoreStates.m[nodeId] = eventStoreState
 }
 
-// getCandidateNode return all nodes(exclude the request node) which may contain data for `span` from `startTs`,
-// and the return slice should be sorted by resolvedTs(largest first).
+// getCandidateNodes return all nodes(exclude the request node) which have a subscription covering
+// the whole `span` from `startTs`, since a remote subscription can only be reused by a dispatcher
+// whose span is a sub span of it. Nodes on the same host as the request node are preferred,
+// and then the nodes are sorted by resolvedTs(largest first).
+//
+// Subscriptions which only partially overlap with `span` are not reused. To reuse them, a dispatcher
+// would read the intersection from the remote event service and the rest from a local subscription,
+// and the event collector would have to merge the two event streams by commitTs, which is not supported.
 func (c *logCoordinator) getCandidateNodes(requestNodeID node.ID, span *heartbeatpb.TableSpan, startTs uint64) []string {
 	c.eventStoreStates.RLock()
 	defer c.eventStoreStates.RUnlock()
 
-	// TODO: support incomplete span
-	if !isCompleteSpan(span) {
-		return nil
-	}
+	c.nodes.RLock()
+	defer c.nodes.RUnlock()
+	requestHost := c.getNodeHost(requestNodeID)
 
 	type candidateNode struct {
 		nodeID     node.ID
+		sameHost   bool
 		resolvedTs uint64
 	}
 	var candidates []candidateNode
@@ -209,28 +215,37 @@ func (c *logCoordinator) getCandidateNodes(requestNodeID node.ID, span *heartbea
 		if !ok {
 			continue
 		}
-		// Find the maximum resolvedTs for the current nodeID
+		// Find the maximum resolvedTs of the subscriptions covering the span for the current nodeID
 		var maxResolvedTs uint64
 		found := false
 		for _, subscriptionState := range subscriptionStates {
-			if subscriptionState.checkpointTs <= startTs {
-				if !found || subscriptionState.resolvedTs > maxResolvedTs {
-					maxResolvedTs = subscriptionState.resolvedTs
-					found = true
-				}
+			if subscriptionState.checkpointTs > startTs || subscriptionState.resolvedTs < startTs {
+				continue
+			}
+			if !common.IsSubSpan(*span, *subscriptionState.span) {
+				continue
+			}
+			if !found || subscriptionState.resolvedTs > maxResolvedTs {
+				maxResolvedTs = subscriptionState.resolvedTs
+				found = true
 			}
 		}
 
 		// If a valid subscription with checkpointTs <= startTs was found, add to candidates
 		if found {
+			host := c.getNodeHost(nodeID)
 			candidates = append(candidates, candidateNode{
 				nodeID:     nodeID,
+				sameHost:   requestHost != "" && host == requestHost,
 				resolvedTs: maxResolvedTs,
 			})
 		}
 	}
 
 	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].sameHost != candidates[j].sameHost {
+			return candidates[i].sameHost
+		}
 		return candidates[i].resolvedTs > candidates[j].resolvedTs
 	})
 
@@ -242,10 +257,17 @@ func (c *logCoordinator) getCandidateNodes(requestNodeID node.ID, span *heartbea
 	return candidateNodes
 }
 
-func isCompleteSpan(tableSpan *heartbeatpb.TableSpan) bool {
-	startKey, endKey := spanz.GetTableRange(tableSpan.TableID)
-	if spanz.StartCompare(startKey, tableSpan.StartKey) == 0 && spanz.EndCompare(endKey, tableSpan.EndKey) == 0 {
-		return true
+// getNodeHost returns the host part of the node's advertise address,
+// it returns empty string if the node is unknown.
+// Note: c.nodes must be locked before calling this function.
+func (c *logCoordinator) getNodeHost(nodeID node.ID) string {
+	info, ok := c.nodes.m[nodeID]
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(info.AdvertiseAddr)
+	if err != nil {
+		return info.AdvertiseAddr
 	}
-	return false
+	return host
 }
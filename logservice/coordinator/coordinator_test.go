@@ -182,3 +182,76 @@ func TestGetCandidateNodes(t *testing.T) {
 		assert.Equal(t, []string{nodeID2.String(), nodeID1.String()}, nodes)
 	}
 }
+
+func TestGetCandidateNodesForIncompleteSpan(t *testing.T) {
+	coordinator := &logCoordinator{}
+
+	nodeID1 := node.ID("node-1")
+	nodeID2 := node.ID("node-2")
+	nodeID3 := node.ID("node-3")
+	nodeID4 := node.ID("node-4")
+	coordinator.nodes.m = map[node.ID]*node.Info{
+		nodeID1: {ID: nodeID1, AdvertiseAddr: "10.0.0.1:8300"},
+		nodeID2: {ID: nodeID2, AdvertiseAddr: "10.0.0.2:8300"},
+		nodeID3: {ID: nodeID3, AdvertiseAddr: "10.0.0.3:8300"},
+		nodeID4: {ID: nodeID4, AdvertiseAddr: "10.0.0.1:8301"},
+	}
+
+	tableID := int64(100)
+	totalSpan := &heartbeatpb.TableSpan{TableID: tableID}
+	totalSpan.StartKey, totalSpan.EndKey = spanz.GetTableRange(tableID)
+	splitKey := append(append([]byte{}, totalSpan.StartKey...), 'm')
+	leftSpan := &heartbeatpb.TableSpan{TableID: tableID, StartKey: totalSpan.StartKey, EndKey: splitKey}
+	rightSpan := &heartbeatpb.TableSpan{TableID: tableID, StartKey: splitKey, EndKey: totalSpan.EndKey}
+
+	coordinator.eventStoreStates.m = map[node.ID]*eventStoreState{
+		// node2 has a subscription which is a superset of leftSpan
+		nodeID2: {
+			subscriptionStates: map[int64]subscriptionStates{
+				tableID: {{subID: 1, span: totalSpan, checkpointTs: 90, resolvedTs: 200}},
+			},
+		},
+		// node3 has subscriptions which only partially overlap with totalSpan
+		nodeID3: {
+			subscriptionStates: map[int64]subscriptionStates{
+				tableID: {
+					{subID: 1, span: leftSpan, checkpointTs: 90, resolvedTs: 300},
+					{subID: 2, span: rightSpan, checkpointTs: 90, resolvedTs: 300},
+				},
+			},
+		},
+		// node4 is on the same host as node1
+		nodeID4: {
+			subscriptionStates: map[int64]subscriptionStates{
+				tableID: {{subID: 1, span: totalSpan, checkpointTs: 90, resolvedTs: 150}},
+			},
+		},
+	}
+
+	// nodes on the same host are preferred
+	{
+		nodes := coordinator.getCandidateNodes(nodeID1, leftSpan, uint64(100))
+		assert.Equal(t, []string{nodeID4.String(), nodeID3.String(), nodeID2.String()}, nodes)
+	}
+	// nodes whose subscriptions only partially overlap with the span are not candidates
+	{
+		nodes := coordinator.getCandidateNodes(nodeID1, totalSpan, uint64(100))
+		assert.Equal(t, []string{nodeID4.String(), nodeID2.String()}, nodes)
+	}
+	// without locality, sorted by resolvedTs
+	{
+		nodes := coordinator.getCandidateNodes(nodeID3, leftSpan, uint64(100))
+		assert.Equal(t, []string{nodeID2.String(), nodeID4.String()}, nodes)
+	}
+	// startTs is larger than the resolvedTs of node4
+	{
+		nodes := coordinator.getCandidateNodes(nodeID1, totalSpan, uint64(180))
+		assert.Equal(t, []string{nodeID2.String()}, nodes)
+	}
+	// no subscription overlaps with the span
+	{
+		otherSpan := &heartbeatpb.TableSpan{TableID: tableID, StartKey: totalSpan.EndKey, EndKey: append(append([]byte{}, totalSpan.EndKey...), 'z')}
+		nodes := coordinator.getCandidateNodes(nodeID1, otherSpan, uint64(100))
+		assert.Empty(t, nodes)
+	}
+}
@@ -17,7 +17,6 @@ import (
 	"context"
 	"fmt"
 	"math"
-	"os"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -69,11 +68,13 @@ type EventStore interface {
 		startTS uint64,
 		notifier ResolvedTsNotifier,
 		onlyReuse bool,
+		bdrMode bool,
 	) (bool, error)
 
 	UnregisterDispatcher(dispatcherID common.DispatcherID) error
 
-	// TODO: Implement this after checkpointTs is correctly reported by the downstream dispatcher.
+	// UpdateDispatcherCheckpointTs updates the checkpointTs of the dispatcher,
+	// the data <= the min checkpointTs of all dispatchers sharing the subscription is deleted.
 	UpdateDispatcherCheckpointTs(dispatcherID common.DispatcherID, checkpointTs uint64) error
 
 	GetDispatcherDMLEventState(dispatcherID common.DispatcherID) (bool, DMLEventState)
@@ -109,7 +110,11 @@ type dispatcherStat struct {
 type subscriptionStat struct {
 	subID logpuller.SubscriptionID
 
-	tableID int64
+	tableID   int64
+	tableSpan *heartbeatpb.TableSpan
+	// bdrMode is true if the events written by TiCDC itself are filtered out by TiKV,
+	// so the subscription can only be shared by the dispatchers in BDR mode.
+	bdrMode bool
 
 	// dispatchers depend on this subscription
 	dispatchers struct {
@@ -128,6 +133,12 @@ type subscriptionStat struct {
 	maxEventCommitTs atomic.Uint64
 }
 
+// retainedSubscription is a subscription persisted before restart which is not reused yet.
+type retainedSubscription struct {
+	persistedSubscription
+	dbIndex int
+}
+
 type eventWithCallback struct {
 	subID    logpuller.SubscriptionID
 	tableID  int64
@@ -147,6 +158,8 @@ type eventStore struct {
 	pdClock   pdutil.Clock
 	subClient *logpuller.SubscriptionClient
 
+	dbPath string
+
 	dbs            []*pebble.DB
 	chs            []*chann.UnlimitedChannel[eventWithCallback, uint64]
 	writeTaskPools []*writeTaskPool
@@ -170,6 +183,8 @@ type eventStore struct {
 		// table id -> dispatcher ids
 		// use table id as the key is to share data between spans not completely the same in the future.
 		tableToDispatchers map[int64]map[common.DispatcherID]bool
+		// table id -> subscriptions persisted before restart which can be reused by new dispatchers
+		retainedSubscriptions map[int64][]*retainedSubscription
 	}
 
 	encoder *zstd.Encoder
@@ -181,6 +196,10 @@ const (
 	dbCount             = 8
 	writeWorkerNumPerDB = 2
 
+	// retainedSubscriptionTTL is how long the subscriptions persisted before restart wait to be reused,
+	// the data of them is deleted after that.
+	retainedSubscriptionTTL = 5 * time.Minute
+
 	// Pebble options
 	targetMemoryLimit = 2 << 30   // 2GB
 	memTableSize      = 256 << 20 // 256MB
@@ -194,13 +213,34 @@ func New(
 	subClient *logpuller.SubscriptionClient,
 	pdClock pdutil.Clock,
 ) EventStore {
+	store := newEventStore(root, subClient, pdClock)
+
+	// recv and handle messages
+	messageCenter := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
+	store.messageCenter = messageCenter
+	messageCenter.RegisterHandler(messaging.EventStoreTopic, store.handleMessage)
+
+	return store
+}
+
+// NewForUpstream creates an event store for an upstream other than the default one.
+// It doesn't report its state to the log coordinator,
+// which only schedules the subscriptions of the default upstream.
+func NewForUpstream(
+	root string,
+	subClient *logpuller.SubscriptionClient,
+	pdClock pdutil.Clock,
+) EventStore {
+	return newEventStore(root, subClient, pdClock)
+}
+
+func newEventStore(
+	root string,
+	subClient *logpuller.SubscriptionClient,
+	pdClock pdutil.Clock,
+) *eventStore {
 	dbPath := fmt.Sprintf("%s/%s", root, dataDir)
 
-	// FIXME: avoid remove
-	err := os.RemoveAll(dbPath)
-	if err != nil {
-		log.Panic("fail to remove path")
-	}
 	// Create the zstd encoder
 	encoder, err := zstd.NewWriter(nil)
 	if err != nil {
@@ -214,6 +254,7 @@ func New(
 	store := &eventStore{
 		pdClock:   pdClock,
 		subClient: subClient,
+		dbPath:    dbPath,
 
 		dbs:            make([]*pebble.DB, 0, dbCount),
 		chs:            make([]*chann.UnlimitedChannel[eventWithCallback, uint64], 0, dbCount),
@@ -223,6 +264,10 @@ func New(
 		encoder:   encoder,
 		decoder:   decoder,
 	}
+	store.dispatcherMeta.dispatcherStats = make(map[common.DispatcherID]*dispatcherStat)
+	store.dispatcherMeta.subscriptionStats = make(map[logpuller.SubscriptionID]*subscriptionStat)
+	store.dispatcherMeta.tableToDispatchers = make(map[int64]map[common.DispatcherID]bool)
+	store.dispatcherMeta.retainedSubscriptions = make(map[int64][]*retainedSubscription)
 
 	// TODO: update pebble options
 	for i := 0; i < dbCount; i++ {
@@ -231,22 +276,41 @@ func New(
 		if err != nil {
 			log.Fatal("open db failed", zap.Error(err))
 		}
+		store.loadRetainedSubscriptions(i, db)
 		store.dbs = append(store.dbs, db)
 		store.chs = append(store.chs, chann.NewUnlimitedChannel[eventWithCallback, uint64](nil, eventWithCallbackSizer))
 		store.writeTaskPools = append(store.writeTaskPools, newWriteTaskPool(store, store.dbs[i], store.chs[i], writeWorkerNumPerDB))
 	}
-	store.dispatcherMeta.dispatcherStats = make(map[common.DispatcherID]*dispatcherStat)
-	store.dispatcherMeta.subscriptionStats = make(map[logpuller.SubscriptionID]*subscriptionStat)
-	store.dispatcherMeta.tableToDispatchers = make(map[int64]map[common.DispatcherID]bool)
-
-	// recv and handle messages
-	messageCenter := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
-	store.messageCenter = messageCenter
-	messageCenter.RegisterHandler(messaging.EventStoreTopic, store.handleMessage)
-
 	return store
 }
 
+// loadRetainedSubscriptions loads the subscriptions persisted in db before restart,
+// and removes all other data in db.
+func (e *eventStore) loadRetainedSubscriptions(dbIndex int, db *pebble.DB) {
+	_, subs, err := readMeta(db)
+	if err != nil {
+		log.Warn("read event store meta failed, discard all data",
+			zap.Int("dbIndex", dbIndex), zap.Error(err))
+		subs = nil
+	}
+	if err := cleanObsoleteData(db, subs); err != nil {
+		log.Panic("clean obsolete data failed", zap.Int("dbIndex", dbIndex), zap.Error(err))
+	}
+	for _, sub := range subs {
+		// make sure the retained subscription id is not allocated to other subscriptions
+		e.subClient.ReserveSubscriptionID(logpuller.SubscriptionID(sub.SubID))
+		e.dispatcherMeta.retainedSubscriptions[sub.Span.TableID] = append(
+			e.dispatcherMeta.retainedSubscriptions[sub.Span.TableID],
+			&retainedSubscription{persistedSubscription: sub, dbIndex: dbIndex})
+		log.Info("load retained subscription",
+			zap.Int("dbIndex", dbIndex),
+			zap.Uint64("subID", sub.SubID),
+			zap.String("span", sub.Span.String()),
+			zap.Uint64("checkpointTs", sub.CheckpointTs),
+			zap.Uint64("resolvedTs", sub.ResolvedTs))
+	}
+}
+
 func newPebbleOptions() *pebble.Options {
 	opts := &pebble.Options{
 		// Disable WAL to improve performance
@@ -363,7 +427,7 @@ func (e *eventStore) Run(ctx context.Context) error {
 
 	// TODO: manage gcManager exit
 	eg.Go(func() error {
-		return e.gcManager.run(ctx, e.deleteEvents)
+		return e.gcManager.run(ctx, e.deleteEvents, e.compactEvents)
 	})
 
 	eg.Go(func() error {
@@ -371,9 +435,16 @@ func (e *eventStore) Run(ctx context.Context) error {
 	})
 
 	eg.Go(func() error {
-		return e.uploadStatePeriodically(ctx)
+		return e.cleanRetainedSubscriptions(ctx)
 	})
 
+	// messageCenter is nil if the store doesn't report its state to the log coordinator.
+	if e.messageCenter != nil {
+		eg.Go(func() error {
+			return e.uploadStatePeriodically(ctx)
+		})
+	}
+
 	return eg.Wait()
 }
 
@@ -381,6 +452,8 @@ func (e *eventStore) Close(ctx context.Context) error {
 	log.Info("event store start to close")
 	defer log.Info("event store closed")
 
+	e.persistMeta()
+
 	log.Info("closing pebble db")
 	for _, db := range e.dbs {
 		if err := db.Close(); err != nil {
@@ -398,11 +471,13 @@ func (e *eventStore) RegisterDispatcher(
 	startTs uint64,
 	notifier ResolvedTsNotifier,
 	onlyReuse bool,
+	bdrMode bool,
 ) (bool, error) {
 	log.Info("register dispatcher",
 		zap.Any("dispatcherID", dispatcherID),
 		zap.String("span", tableSpan.String()),
-		zap.Uint64("startTs", startTs))
+		zap.Uint64("startTs", startTs),
+		zap.Bool("bdrMode", bdrMode))
 
 	start := time.Now()
 	defer func() {
@@ -426,11 +501,14 @@ func (e *eventStore) RegisterDispatcher(
 			if !ok {
 				log.Panic("should not happen")
 			}
-			if candidateDispatcher.tableSpan.Equal(tableSpan) {
-				subscriptionStat, ok := e.dispatcherMeta.subscriptionStats[candidateDispatcher.subID]
-				if !ok {
-					log.Panic("should not happen")
-				}
+			subscriptionStat, ok := e.dispatcherMeta.subscriptionStats[candidateDispatcher.subID]
+			if !ok {
+				log.Panic("should not happen")
+			}
+			// the subscription can be shared if its span covers the span of the new dispatcher
+			// and it filters the events written by TiCDC in the same way,
+			// events out of the dispatcher's span will be filtered when reading.
+			if subscriptionStat.bdrMode == bdrMode && common.IsSubSpan(*tableSpan, *subscriptionStat.tableSpan) {
 				// check whether startTs is in the range [checkpointTs, resolvedTs]
 				// for `[checkpointTs`: because we want data > startTs, so data <= checkpointTs == startTs deleted is ok.
 				// for `resolvedTs]`: startTs == resolvedTs is a special case that no resolved ts has been recieved, so it is ok.
@@ -460,26 +538,44 @@ func (e *eventStore) RegisterDispatcher(
 	}
 
 	// cannot share data from existing subscription, create a new subscription
-
-	// TODO: hash span is only needed when we need to reuse data after restart
-	// (if we finally decide not to reuse data after restart, use round robin instead)
-	// But if we need to share data for sub span, we need hash table id instead.
-	chIndex := common.HashTableSpan(tableSpan, len(e.chs))
-	stat.subID = e.subClient.AllocSubscriptionID()
 	subStat := &subscriptionStat{
-		subID:   stat.subID,
-		tableID: tableSpan.TableID,
-		dbIndex: chIndex,
-		eventCh: e.chs[chIndex],
+		tableID:   tableSpan.TableID,
+		tableSpan: tableSpan,
+		bdrMode:   bdrMode,
 	}
+	// the ts to pull data from upstream
+	pullStartTs := startTs
 
 	e.dispatcherMeta.Lock()
+	if retained := e.takeRetainedSubscription(tableSpan, startTs, bdrMode); retained != nil {
+		// the data in range (checkpointTs, resolvedTs] is persisted before restart,
+		// so just pull data after resolvedTs from upstream.
+		subStat.subID = logpuller.SubscriptionID(retained.SubID)
+		subStat.dbIndex = retained.dbIndex
+		subStat.checkpointTs.Store(retained.CheckpointTs)
+		subStat.resolvedTs.Store(retained.ResolvedTs)
+		subStat.maxEventCommitTs.Store(retained.MaxEventCommitTs)
+		pullStartTs = retained.ResolvedTs
+		log.Info("reuse subscription retained before restart",
+			zap.Any("dispatcherID", dispatcherID),
+			zap.Uint64("subID", retained.SubID),
+			zap.Uint64("checkpointTs", retained.CheckpointTs),
+			zap.Uint64("resolvedTs", retained.ResolvedTs),
+			zap.Uint64("startTs", startTs))
+	} else {
+		// TODO: if we need to share data for sub span, we need hash table id instead.
+		subStat.subID = e.subClient.AllocSubscriptionID()
+		subStat.dbIndex = common.HashTableSpan(tableSpan, len(e.chs))
+		subStat.checkpointTs.Store(startTs)
+		subStat.resolvedTs.Store(startTs)
+		subStat.maxEventCommitTs.Store(startTs)
+	}
+	subStat.eventCh = e.chs[subStat.dbIndex]
+	stat.subID = subStat.subID
+
 	e.dispatcherMeta.dispatcherStats[dispatcherID] = stat
 	subStat.dispatchers.notifiers = make(map[common.DispatcherID]ResolvedTsNotifier)
 	subStat.dispatchers.notifiers[dispatcherID] = notifier
-	subStat.checkpointTs.Store(startTs)
-	subStat.resolvedTs.Store(startTs)
-	subStat.maxEventCommitTs.Store(startTs)
 	e.dispatcherMeta.subscriptionStats[stat.subID] = subStat
 
 	dispatchersForSameTable, ok := e.dispatcherMeta.tableToDispatchers[tableSpan.TableID]
@@ -524,11 +620,89 @@ func (e *eventStore) RegisterDispatcher(
 		}
 	}
 	// Note: don't hold any lock when call Subscribe
-	e.subClient.Subscribe(stat.subID, *tableSpan, startTs, consumeKVEvents, advanceResolvedTs, 600)
+	e.subClient.Subscribe(stat.subID, *tableSpan, pullStartTs, consumeKVEvents, advanceResolvedTs, 600, bdrMode)
 	metrics.EventStoreSubscriptionGauge.Inc()
 	return true, nil
 }
 
+// takeRetainedSubscription returns and removes the retained subscription which has the same span
+// and bdr mode and contains the data after startTs, it returns nil if there is no such subscription.
+// Must be called with dispatcherMeta locked.
+func (e *eventStore) takeRetainedSubscription(tableSpan *heartbeatpb.TableSpan, startTs uint64, bdrMode bool) *retainedSubscription {
+	subs := e.dispatcherMeta.retainedSubscriptions[tableSpan.TableID]
+	for i, sub := range subs {
+		if !sub.Span.Equal(tableSpan) || sub.BDRMode != bdrMode || startTs < sub.CheckpointTs || startTs > sub.ResolvedTs {
+			continue
+		}
+		subs = append(subs[:i], subs[i+1:]...)
+		if len(subs) == 0 {
+			delete(e.dispatcherMeta.retainedSubscriptions, tableSpan.TableID)
+		} else {
+			e.dispatcherMeta.retainedSubscriptions[tableSpan.TableID] = subs
+		}
+		return sub
+	}
+	return nil
+}
+
+// cleanRetainedSubscriptions deletes the data of the retained subscriptions
+// which are not reused in retainedSubscriptionTTL.
+func (e *eventStore) cleanRetainedSubscriptions(ctx context.Context) error {
+	timer := time.NewTimer(retainedSubscriptionTTL)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-timer.C:
+	}
+	e.dispatcherMeta.Lock()
+	defer e.dispatcherMeta.Unlock()
+	for tableID, subs := range e.dispatcherMeta.retainedSubscriptions {
+		for _, sub := range subs {
+			e.gcManager.addGCItem(sub.dbIndex, sub.SubID, tableID, 0, math.MaxUint64)
+			log.Info("delete data of retained subscription which is not reused",
+				zap.Uint64("subID", sub.SubID),
+				zap.String("span", sub.Span.String()))
+		}
+	}
+	e.dispatcherMeta.retainedSubscriptions = make(map[int64][]*retainedSubscription)
+	return nil
+}
+
+// persistMeta persists the node id and the state of all subscriptions,
+// so that the data can be reused after restart.
+func (e *eventStore) persistMeta() {
+	subsByDB := make([][]persistedSubscription, len(e.dbs))
+	e.dispatcherMeta.RLock()
+	for _, subStat := range e.dispatcherMeta.subscriptionStats {
+		subsByDB[subStat.dbIndex] = append(subsByDB[subStat.dbIndex], persistedSubscription{
+			SubID:            uint64(subStat.subID),
+			Span:             subStat.tableSpan,
+			CheckpointTs:     subStat.checkpointTs.Load(),
+			ResolvedTs:       subStat.resolvedTs.Load(),
+			MaxEventCommitTs: subStat.maxEventCommitTs.Load(),
+			BDRMode:          subStat.bdrMode,
+		})
+	}
+	for _, subs := range e.dispatcherMeta.retainedSubscriptions {
+		for _, sub := range subs {
+			subsByDB[sub.dbIndex] = append(subsByDB[sub.dbIndex], sub.persistedSubscription)
+		}
+	}
+	e.dispatcherMeta.RUnlock()
+
+	nodeID := node.ID(appcontext.GetID())
+	for i, db := range e.dbs {
+		if err := writeMeta(db, nodeID, subsByDB[i]); err != nil {
+			log.Warn("persist event store meta failed, the data can not be reused after restart",
+				zap.Int("dbIndex", i), zap.Error(err))
+			continue
+		}
+		log.Info("event store meta persisted",
+			zap.Int("dbIndex", i), zap.Int("subscriptionCount", len(subsByDB[i])))
+	}
+}
+
 func (e *eventStore) UnregisterDispatcher(dispatcherID common.DispatcherID) error {
 	log.Info("unregister dispatcher", zap.Stringer("dispatcherID", dispatcherID))
 	defer func() {
@@ -555,6 +729,8 @@ func (e *eventStore) UnregisterDispatcher(dispatcherID common.DispatcherID) erro
 		delete(e.dispatcherMeta.subscriptionStats, subID)
 		// TODO: do we need unlock before puller.Unsubscribe?
 		e.subClient.Unsubscribe(subID)
+		// the data of the subscription is not needed by any dispatcher
+		e.gcManager.addGCItem(subscriptionStat.dbIndex, uint64(subID), tableID, 0, math.MaxUint64)
 		metrics.EventStoreSubscriptionGauge.Dec()
 	}
 	subscriptionStat.dispatchers.Unlock()
@@ -576,45 +752,45 @@ func (e *eventStore) UpdateDispatcherCheckpointTs(
 	dispatcherID common.DispatcherID,
 	checkpointTs uint64,
 ) error {
-	// e.dispatcherMeta.RLock()
-	// defer e.dispatcherMeta.RUnlock()
-	// if stat, ok := e.dispatcherMeta.dispatcherStats[dispatcherID]; ok {
-	// 	stat.checkpointTs = checkpointTs
-	// 	subscriptionStat := e.dispatcherMeta.subscriptionStats[stat.subID]
-	// 	// calculate the new checkpoint ts of the subscription
-	// 	newCheckpointTs := uint64(0)
-	// 	for dispatcherID := range subscriptionStat.ids {
-	// 		dispatcherStat := e.dispatcherMeta.dispatcherStats[dispatcherID]
-	// 		if newCheckpointTs == 0 || dispatcherStat.checkpointTs < newCheckpointTs {
-	// 			newCheckpointTs = dispatcherStat.checkpointTs
-	// 		}
-	// 	}
-	// 	if newCheckpointTs == 0 {
-	// 		return nil
-	// 	}
-	// 	if newCheckpointTs < subscriptionStat.checkpointTs {
-	// 		log.Panic("should not happen",
-	// 			zap.Uint64("newCheckpointTs", newCheckpointTs),
-	// 			zap.Uint64("oldCheckpointTs", subscriptionStat.checkpointTs))
-	// 	}
-	// 	if subscriptionStat.checkpointTs < newCheckpointTs {
-	// 		e.gcManager.addGCItem(
-	// 			subscriptionStat.chIndex,
-	// 			subscriptionStat.uniqueKeyID,
-	// 			stat.tableSpan.TableID,
-	// 			subscriptionStat.checkpointTs,
-	// 			newCheckpointTs,
-	// 		)
-	// 		if log.GetLevel() <= zap.DebugLevel {
-	// 			log.Debug("update checkpoint ts",
-	// 				zap.Any("dispatcherID", dispatcherID),
-	// 				zap.Uint64("subID", uint64(stat.subID)),
-	// 				zap.Uint64("newCheckpointTs", newCheckpointTs),
-	// 				zap.Uint64("oldCheckpointTs", subscriptionStat.checkpointTs))
-	// 			subscriptionStat.checkpointTs = newCheckpointTs
-	// 		}
-	// 	}
-	// }
+	e.dispatcherMeta.Lock()
+	defer e.dispatcherMeta.Unlock()
+	stat, ok := e.dispatcherMeta.dispatcherStats[dispatcherID]
+	if !ok || checkpointTs <= stat.checkpointTs {
+		return nil
+	}
+	stat.checkpointTs = checkpointTs
+	subStat := e.dispatcherMeta.subscriptionStats[stat.subID]
+	// calculate the new checkpoint ts of the subscription
+	newCheckpointTs := uint64(0)
+	subStat.dispatchers.Lock()
+	for id := range subStat.dispatchers.notifiers {
+		dispatcherStat := e.dispatcherMeta.dispatcherStats[id]
+		if newCheckpointTs == 0 || dispatcherStat.checkpointTs < newCheckpointTs {
+			newCheckpointTs = dispatcherStat.checkpointTs
+		}
+	}
+	subStat.dispatchers.Unlock()
+	// the data which is not persisted yet can not be deleted
+	newCheckpointTs = min(newCheckpointTs, subStat.resolvedTs.Load())
+	oldCheckpointTs := subStat.checkpointTs.Load()
+	if newCheckpointTs <= oldCheckpointTs {
+		return nil
+	}
+	e.gcManager.addGCItem(
+		subStat.dbIndex,
+		uint64(subStat.subID),
+		subStat.tableID,
+		oldCheckpointTs,
+		newCheckpointTs,
+	)
+	subStat.checkpointTs.Store(newCheckpointTs)
+	if log.GetLevel() <= zap.DebugLevel {
+		log.Debug("update subscription checkpoint ts",
+			zap.Any("dispatcherID", dispatcherID),
+			zap.Uint64("subID", uint64(subStat.subID)),
+			zap.Uint64("newCheckpointTs", newCheckpointTs),
+			zap.Uint64("oldCheckpointTs", oldCheckpointTs))
+	}
 	return nil
 }
 
@@ -670,8 +846,16 @@ func (e *eventStore) GetIterator(dispatcherID common.DispatcherID, dataRange com
 	metricEventStoreFirstReadDurationHistogram.Observe(time.Since(startTime).Seconds())
 	metrics.EventStoreScanRequestsCount.Inc()
 
+	// the subscription may be shared with dispatchers of a larger span,
+	// so only the events in the dispatcher's span should be returned.
+	var filterSpan *heartbeatpb.TableSpan
+	if !stat.tableSpan.Equal(subscriptionStat.tableSpan) {
+		filterSpan = stat.tableSpan
+	}
+
 	return &eventStoreIter{
 		tableID:      stat.tableSpan.TableID,
+		filterSpan:   filterSpan,
 		innerIter:    iter,
 		prevStartTs:  0,
 		prevCommitTs: 0,
@@ -696,6 +880,10 @@ func (e *eventStore) updateMetrics(ctx context.Context) error {
 }
 
 func (e *eventStore) updateMetricsOnce() {
+	for i, db := range e.dbs {
+		path := fmt.Sprintf("%s/%d", e.dbPath, i)
+		metrics.EventStoreOnDiskDataSizeGauge.WithLabelValues(path).Set(float64(db.Metrics().DiskSpaceUsage()))
+	}
 	pdTime := e.pdClock.CurrentTime()
 	pdPhyTs := oracle.GetPhysical(pdTime)
 	minResolvedTs := uint64(0)
@@ -752,16 +940,26 @@ func (e *eventStore) writeEvents(db *pebble.DB, events []eventWithCallback) erro
 	return err
 }
 
-func (e *eventStore) deleteEvents(dbIndex int, uniqueKeyID uint64, tableID int64, startTs uint64, endTs uint64) error {
-	db := e.dbs[dbIndex]
-	start := EncodeKeyPrefix(uniqueKeyID, tableID, startTs)
-	end := EncodeKeyPrefix(uniqueKeyID, tableID, endTs)
+func (e *eventStore) deleteEvents(dbIndex int, ranges []gcRangeItem) error {
+	batch := e.dbs[dbIndex].NewBatch()
+	for _, r := range ranges {
+		start, end := r.keyRange()
+		if err := batch.DeleteRange(start, end, pebble.NoSync); err != nil {
+			return err
+		}
+	}
+	return batch.Commit(pebble.NoSync)
+}
 
-	return db.DeleteRange(start, end, pebble.NoSync)
+func (e *eventStore) compactEvents(dbIndex int, r gcRangeItem) error {
+	start, end := r.keyRange()
+	return e.dbs[dbIndex].Compact(start, end, false)
 }
 
 type eventStoreIter struct {
-	tableID      common.TableID
+	tableID common.TableID
+	// events out of filterSpan are skipped, nil means no need to filter
+	filterSpan   *heartbeatpb.TableSpan
 	innerIter    *pebble.Iterator
 	prevStartTs  uint64
 	prevCommitTs uint64
@@ -779,18 +977,24 @@ func (iter *eventStoreIter) Next() (*common.RawKVEntry, bool, error) {
 		log.Panic("iter is nil")
 	}
 
-	if !iter.innerIter.Valid() {
-		return nil, false, nil
-	}
-
-	value := iter.innerIter.Value()
-	decompressedValue, err := iter.decoder.DecodeAll(value, nil)
-	if err != nil {
-		log.Panic("failed to decompress value", zap.Error(err))
+	var rawKV *common.RawKVEntry
+	for {
+		if !iter.innerIter.Valid() {
+			return nil, false, nil
+		}
+		value := iter.innerIter.Value()
+		decompressedValue, err := iter.decoder.DecodeAll(value, nil)
+		if err != nil {
+			log.Panic("failed to decompress value", zap.Error(err))
+		}
+		metrics.EventStoreScanBytes.Add(float64(len(decompressedValue)))
+		rawKV = &common.RawKVEntry{}
+		rawKV.Decode(decompressedValue)
+		if iter.filterSpan == nil || common.KeyInSpan(common.ToComparableKey(rawKV.Key), *iter.filterSpan) {
+			break
+		}
+		iter.innerIter.Next()
 	}
-	metrics.EventStoreScanBytes.Add(float64(len(decompressedValue)))
-	rawKV := &common.RawKVEntry{}
-	rawKV.Decode(decompressedValue)
 	isNewTxn := false
 	if iter.prevCommitTs == 0 || (rawKV.StartTs != iter.prevStartTs || rawKV.CRTs != iter.prevCommitTs) {
 		isNewTxn = true
@@ -850,7 +1054,9 @@ func (e *eventStore) uploadStatePeriodically(ctx context.Context) error {
 					dispatcherStat := e.dispatcherMeta.dispatcherStats[dispatcherID]
 					subID := dispatcherStat.subID
 					subStat := e.dispatcherMeta.subscriptionStats[subID]
-					if _, ok := subIDs[subID]; ok {
+					// the dispatchers in BDR mode don't ask the log coordinator for remote event stores,
+					// so only report the subscriptions that don't filter the events written by TiCDC.
+					if _, ok := subIDs[subID]; ok || subStat.bdrMode {
 						continue
 					}
 					subStates = append(subStates, &logservicepb.SubscriptionState{
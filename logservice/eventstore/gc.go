@@ -15,6 +15,7 @@ package eventstore
 
 import (
 	"context"
+	"math"
 	"sync"
 	"time"
 
@@ -23,23 +24,51 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	gcInterval = 20 * time.Millisecond
+	// compaction is expensive, so the deleted ranges are compacted in a much larger interval.
+	compactInterval = 10 * time.Minute
+)
+
 type gcRangeItem struct {
 	dbIndex     int
 	uniqueKeyID uint64
 	tableID     int64
-	// TODO: startCommitTS may be not needed now(just use 0 for every delete range maybe ok),
-	// but after split table range, it may be essential?
+	// the data in range (startTs, endTs] is deleted
 	startTs uint64
 	endTs   uint64
 }
 
+// keyRange returns the key range [start, end) of the data in range (startTs, endTs].
+func (r gcRangeItem) keyRange() ([]byte, []byte) {
+	start := EncodeKeyPrefix(r.uniqueKeyID, r.tableID, r.startTs+1)
+	if r.endTs == math.MaxUint64 {
+		// all data of the table in the subscription
+		return start, EncodeKeyPrefix(r.uniqueKeyID, r.tableID+1, 0)
+	}
+	return start, EncodeKeyPrefix(r.uniqueKeyID, r.tableID, r.endTs+1)
+}
+
+// compactKey identifies the data of a table in a subscription.
+type compactKey struct {
+	dbIndex     int
+	uniqueKeyID uint64
+	tableID     int64
+}
+
 type gcManager struct {
 	mu     sync.Mutex
 	ranges []gcRangeItem
+
+	// the deleted ranges wait to be compacted, only accessed in run.
+	// the ranges of the same subscription are merged into one.
+	toCompact map[compactKey]gcRangeItem
 }
 
 func newGCManager() *gcManager {
-	return &gcManager{}
+	return &gcManager{
+		toCompact: make(map[compactKey]gcRangeItem),
+	}
 }
 
 // add an item to delete the data in range (startTS, endTS] for `tableID` with `uniqueID`.
@@ -63,29 +92,78 @@ func (d *gcManager) fetchAllGCItems() []gcRangeItem {
 	return ranges
 }
 
-type deleteFunc func(dbIndex int, uniqueKeyID uint64, tableID int64, startCommitTS uint64, endCommitTS uint64) error
+// addGCItemsBack adds the items which fail to be deleted back, they will be retried later.
+func (d *gcManager) addGCItemsBack(items []gcRangeItem) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.ranges = append(items, d.ranges...)
+}
 
-func (d *gcManager) run(ctx context.Context, deleteDataRange deleteFunc) error {
-	ticker := time.NewTicker(20 * time.Millisecond)
+// deleteFunc deletes all ranges of a db in a batch.
+type deleteFunc func(dbIndex int, ranges []gcRangeItem) error
+
+// compactFunc compacts the range of a db.
+type compactFunc func(dbIndex int, r gcRangeItem) error
+
+func (d *gcManager) run(ctx context.Context, deleteDataRanges deleteFunc, compactDataRange compactFunc) error {
+	ticker := time.NewTicker(gcInterval)
+	defer ticker.Stop()
+	compactTicker := time.NewTicker(compactInterval)
+	defer compactTicker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
 		case <-ticker.C:
-			ranges := d.fetchAllGCItems()
-			if len(ranges) == 0 {
-				continue
-			}
-			for _, r := range ranges {
-				// TODO: delete in batch?
-				err := deleteDataRange(r.dbIndex, r.uniqueKeyID, r.tableID, r.startTs, r.endTs)
-				if err != nil {
-					// TODO: add the data range back?
-					log.Fatal("delete fail", zap.Error(err))
-					return err
-				}
+			d.doGC(deleteDataRanges)
+		case <-compactTicker.C:
+			d.doCompact(compactDataRange)
+		}
+	}
+}
+
+func (d *gcManager) doGC(deleteDataRanges deleteFunc) {
+	ranges := d.fetchAllGCItems()
+	if len(ranges) == 0 {
+		return
+	}
+	rangesByDB := make(map[int][]gcRangeItem)
+	for _, r := range ranges {
+		rangesByDB[r.dbIndex] = append(rangesByDB[r.dbIndex], r)
+	}
+	for dbIndex, items := range rangesByDB {
+		if err := deleteDataRanges(dbIndex, items); err != nil {
+			log.Warn("delete data ranges failed, retry later",
+				zap.Int("dbIndex", dbIndex),
+				zap.Int("rangeCount", len(items)),
+				zap.Error(err))
+			d.addGCItemsBack(items)
+			continue
+		}
+		metrics.EventStoreDeleteRangeCount.Add(float64(len(items)))
+		for _, item := range items {
+			key := compactKey{dbIndex: item.dbIndex, uniqueKeyID: item.uniqueKeyID, tableID: item.tableID}
+			if old, ok := d.toCompact[key]; ok {
+				item.startTs = min(item.startTs, old.startTs)
+				item.endTs = max(item.endTs, old.endTs)
 			}
-			metrics.EventStoreDeleteRangeCount.Add(float64(len(ranges)))
+			d.toCompact[key] = item
+		}
+	}
+}
+
+func (d *gcManager) doCompact(compactDataRange compactFunc) {
+	for key, r := range d.toCompact {
+		if err := compactDataRange(r.dbIndex, r); err != nil {
+			// keep the range and retry in the next round
+			log.Warn("compact data range failed, retry later",
+				zap.Int("dbIndex", r.dbIndex),
+				zap.Uint64("uniqueKeyID", r.uniqueKeyID),
+				zap.Int64("tableID", r.tableID),
+				zap.Error(err))
+			continue
 		}
+		delete(d.toCompact, key)
+		metrics.EventStoreCompactRangeCount.Inc()
 	}
 }
@@ -0,0 +1,73 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGCManagerDeleteAndCompact(t *testing.T) {
+	m := newGCManager()
+	m.addGCItem(0, 1, 100, 0, 10)
+	m.addGCItem(1, 2, 101, 0, 10)
+	m.addGCItem(0, 1, 100, 10, 20)
+
+	deleted := make(map[int][]gcRangeItem)
+	failDB := 1
+	deleteFn := func(dbIndex int, ranges []gcRangeItem) error {
+		if dbIndex == failDB {
+			return errors.New("injected error")
+		}
+		deleted[dbIndex] = append(deleted[dbIndex], ranges...)
+		return nil
+	}
+	// the ranges of the same db are deleted in a batch, and the failed ones are retried later
+	m.doGC(deleteFn)
+	require.Len(t, deleted[0], 2)
+	require.Len(t, deleted[1], 0)
+	failDB = -1
+	m.doGC(deleteFn)
+	require.Len(t, deleted[1], 1)
+	require.Empty(t, m.fetchAllGCItems())
+
+	// the deleted ranges of the same subscription are merged before compaction
+	compacted := make([]gcRangeItem, 0)
+	m.doCompact(func(dbIndex int, r gcRangeItem) error {
+		compacted = append(compacted, r)
+		return nil
+	})
+	require.Len(t, compacted, 2)
+	for _, r := range compacted {
+		if r.dbIndex == 0 {
+			require.Equal(t, uint64(0), r.startTs)
+			require.Equal(t, uint64(20), r.endTs)
+		}
+	}
+	require.Empty(t, m.toCompact)
+}
+
+func TestGCRangeItemKeyRange(t *testing.T) {
+	start, end := gcRangeItem{uniqueKeyID: 1, tableID: 100, startTs: 10, endTs: 20}.keyRange()
+	require.Equal(t, EncodeKeyPrefix(1, 100, 11), start)
+	require.Equal(t, EncodeKeyPrefix(1, 100, 21), end)
+
+	// delete all data of the table in the subscription
+	start, end = gcRangeItem{uniqueKeyID: 1, tableID: 100, startTs: 0, endTs: math.MaxUint64}.keyRange()
+	require.Equal(t, EncodeKeyPrefix(1, 100, 1), start)
+	require.Equal(t, EncodeKeyPrefix(1, 101, 0), end)
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/node"
+	"go.uber.org/zap"
+)
+
+// Meta data format:
+//
+//	{0}{"n"} -> node id
+//	{0}{"s"}{sub_id} -> subscription meta in json
+//
+// All event keys start with the subscription id, and subscription ids are allocated from 1,
+// so the meta keys which start with subscription id 0 never conflict with event keys.
+//
+// The meta is only written when the event store is closed gracefully, after all data is flushed to disk.
+// It is removed as soon as it is loaded after restart, so the data on disk is discarded
+// if the store exits abnormally in the next run.
+const (
+	metaSubID           = uint64(0)
+	nodeIDMetaKey       = "n"
+	subscriptionMetaKey = "s"
+)
+
+// persistedSubscription is the meta of a subscription persisted in the db.
+// The data of the subscription in the range (CheckpointTs, ResolvedTs] is complete on disk.
+type persistedSubscription struct {
+	SubID            uint64                 `json:"sub-id"`
+	Span             *heartbeatpb.TableSpan `json:"span"`
+	CheckpointTs     uint64                 `json:"checkpoint-ts"`
+	ResolvedTs       uint64                 `json:"resolved-ts"`
+	MaxEventCommitTs uint64                 `json:"max-event-commit-ts"`
+	BDRMode          bool                   `json:"bdr-mode"`
+}
+
+func subIDPrefix(subID uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, subID)
+	return buf
+}
+
+func encodeNodeIDMetaKey() []byte {
+	return append(subIDPrefix(metaSubID), nodeIDMetaKey...)
+}
+
+func encodeSubscriptionMetaKey(subID uint64) []byte {
+	buf := append(subIDPrefix(metaSubID), subscriptionMetaKey...)
+	return append(buf, subIDPrefix(subID)...)
+}
+
+// writeMeta persists the node id and the subscriptions to db and flushes db to disk.
+func writeMeta(db *pebble.DB, nodeID node.ID, subs []persistedSubscription) error {
+	batch := db.NewBatch()
+	if nodeID != "" {
+		if err := batch.Set(encodeNodeIDMetaKey(), []byte(nodeID), pebble.NoSync); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, sub := range subs {
+		value, err := json.Marshal(sub)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := batch.Set(encodeSubscriptionMetaKey(sub.SubID), value, pebble.NoSync); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := batch.Commit(pebble.NoSync); err != nil {
+		return errors.Trace(err)
+	}
+	// wal is disabled, flush the memtables to make sure the data and the meta are persisted.
+	return errors.Trace(db.Flush())
+}
+
+// readMeta reads the node id and the subscriptions persisted in db.
+func readMeta(db *pebble.DB) (node.ID, []persistedSubscription, error) {
+	var nodeID node.ID
+	value, closer, err := db.Get(encodeNodeIDMetaKey())
+	if err == nil {
+		nodeID = node.ID(bytes.Clone(value))
+		closer.Close()
+	} else if err != pebble.ErrNotFound {
+		return "", nil, errors.Trace(err)
+	}
+
+	prefix := append(subIDPrefix(metaSubID), subscriptionMetaKey...)
+	iter, err := db.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: append(subIDPrefix(metaSubID), subscriptionMetaKey[0]+1),
+	})
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	defer iter.Close()
+	var subs []persistedSubscription
+	for iter.First(); iter.Valid(); iter.Next() {
+		var sub persistedSubscription
+		if err := json.Unmarshal(iter.Value(), &sub); err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool {
+		return subs[i].SubID < subs[j].SubID
+	})
+	return nodeID, subs, nil
+}
+
+// cleanObsoleteData removes the meta and the data of all subscriptions except the retained ones,
+// and flushes db to disk. retained must be sorted by SubID.
+func cleanObsoleteData(db *pebble.DB, retained []persistedSubscription) error {
+	// the meta keys are in the range of subscription id 0, so they are removed too.
+	start := metaSubID
+	for _, sub := range retained {
+		if start < sub.SubID {
+			if err := db.DeleteRange(subIDPrefix(start), subIDPrefix(sub.SubID), pebble.NoSync); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		start = sub.SubID + 1
+	}
+	if err := db.DeleteRange(subIDPrefix(start), subIDPrefix(math.MaxUint64), pebble.NoSync); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(db.Flush())
+}
+
+// LoadNodeID returns the node id persisted by the event store under root when it was closed,
+// it returns an empty id if there is no data can be reused after restart.
+func LoadNodeID(root string) node.ID {
+	path := fmt.Sprintf("%s/%s/%d", root, dataDir, 0)
+	db, err := pebble.Open(path, &pebble.Options{ReadOnly: true})
+	if err != nil {
+		log.Info("no event store data found, skip loading node id",
+			zap.String("path", path), zap.Error(err))
+		return ""
+	}
+	defer db.Close()
+	value, closer, err := db.Get(encodeNodeIDMetaKey())
+	if err != nil {
+		if err != pebble.ErrNotFound {
+			log.Warn("read node id from event store failed", zap.String("path", path), zap.Error(err))
+		}
+		return ""
+	}
+	defer closer.Close()
+	return node.ID(bytes.Clone(value))
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestEvents(t *testing.T, db *pebble.DB, subID uint64, tableID int64, commitTs ...uint64) {
+	for _, ts := range commitTs {
+		kv := &common.RawKVEntry{OpType: common.OpTypePut, Key: []byte("k"), Value: []byte("v"), StartTs: ts - 1, CRTs: ts}
+		require.NoError(t, db.Set(EncodeKey(subID, tableID, kv), kv.Encode(), pebble.NoSync))
+	}
+}
+
+func countTestEvents(t *testing.T, db *pebble.DB, subID uint64, tableID int64) int {
+	iter, err := db.NewIter(&pebble.IterOptions{
+		LowerBound: EncodeKeyPrefix(subID, tableID, 0),
+		UpperBound: EncodeKeyPrefix(subID, tableID+1, 0),
+	})
+	require.NoError(t, err)
+	defer iter.Close()
+	count := 0
+	for iter.First(); iter.Valid(); iter.Next() {
+		count++
+	}
+	return count
+}
+
+func TestPersistAndLoadMeta(t *testing.T) {
+	root := t.TempDir()
+	path := fmt.Sprintf("%s/%s/%d", root, dataDir, 0)
+	db, err := pebble.Open(path, newPebbleOptions())
+	require.NoError(t, err)
+
+	span := &heartbeatpb.TableSpan{TableID: 100, StartKey: []byte("a"), EndKey: []byte("b")}
+	writeTestEvents(t, db, 1, 100, 10, 20)
+	writeTestEvents(t, db, 2, 100, 10, 20, 30)
+	writeTestEvents(t, db, 3, 101, 10)
+	subs := []persistedSubscription{{
+		SubID:            2,
+		Span:             span,
+		CheckpointTs:     5,
+		ResolvedTs:       30,
+		MaxEventCommitTs: 30,
+		BDRMode:          true,
+	}}
+	require.NoError(t, writeMeta(db, "node-1", subs))
+	require.NoError(t, db.Close())
+
+	require.Equal(t, "node-1", LoadNodeID(root).String())
+
+	db, err = pebble.Open(path, newPebbleOptions())
+	require.NoError(t, err)
+	nodeID, loaded, err := readMeta(db)
+	require.NoError(t, err)
+	require.Equal(t, "node-1", nodeID.String())
+	require.Len(t, loaded, 1)
+	require.Equal(t, uint64(2), loaded[0].SubID)
+	require.True(t, span.Equal(loaded[0].Span))
+	require.Equal(t, uint64(5), loaded[0].CheckpointTs)
+	require.Equal(t, uint64(30), loaded[0].ResolvedTs)
+	require.True(t, loaded[0].BDRMode)
+
+	// only the data of the retained subscription is kept, and the meta is removed
+	require.NoError(t, cleanObsoleteData(db, loaded))
+	require.Equal(t, 0, countTestEvents(t, db, 1, 100))
+	require.Equal(t, 3, countTestEvents(t, db, 2, 100))
+	require.Equal(t, 0, countTestEvents(t, db, 3, 101))
+	nodeID, loaded, err = readMeta(db)
+	require.NoError(t, err)
+	require.Empty(t, nodeID)
+	require.Empty(t, loaded)
+	require.NoError(t, db.Close())
+
+	// the data can not be reused if the store is not closed gracefully
+	require.Empty(t, LoadNodeID(root))
+	require.Empty(t, LoadNodeID(t.TempDir()))
+}
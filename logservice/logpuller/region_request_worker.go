@@ -372,7 +372,7 @@ func (s *regionRequestWorker) createRegionRequest(region regionInfo) *cdcpb.Chan
 		StartKey:     region.span.StartKey,
 		EndKey:       region.span.EndKey,
 		ExtraOp:      kvrpcpb.ExtraOp_ReadOldValue,
-		FilterLoop:   s.client.filterLoop,
+		FilterLoop:   region.subscribedSpan.filterLoop,
 	}
 }
 
@@ -16,7 +16,11 @@ package logpuller
 import (
 	"testing"
 
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/logpuller/regionlock"
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/tikv"
 )
 
 func TestRegionStatesOperation(t *testing.T) {
@@ -38,3 +42,20 @@ func TestRegionStatesOperation(t *testing.T) {
 	require.Nil(t, worker.getRegionState(1, 2))
 	require.Equal(t, 0, len(worker.requestedRegions.subscriptions))
 }
+
+func TestCreateRegionRequestFilterLoop(t *testing.T) {
+	client := &SubscriptionClient{}
+	worker := &regionRequestWorker{client: client}
+	rpcCtx := &tikv.RPCContext{Meta: &metapb.Region{RegionEpoch: &metapb.RegionEpoch{}}}
+	span := heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("b")}
+	for _, filterLoop := range []bool{false, true} {
+		subSpan := &subscribedSpan{subID: 1, span: span, filterLoop: filterLoop}
+		region := newRegionInfo(tikv.NewRegionVerID(1, 1, 1), span, rpcCtx, subSpan)
+		region.lockedRangeState = &regionlock.LockedRangeState{}
+		region.lockedRangeState.ResolvedTs.Store(100)
+
+		req := worker.createRegionRequest(region)
+		require.Equal(t, filterLoop, req.FilterLoop)
+		require.Equal(t, uint64(100), req.CheckpointTs)
+	}
+}
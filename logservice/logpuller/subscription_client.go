@@ -120,6 +120,10 @@ type subscribedSpan struct {
 
 	advanceInterval int64
 
+	// filterLoop is true if the events written by TiCDC itself should be filtered out by TiKV,
+	// it's used to avoid replication loops in BDR mode.
+	filterLoop bool
+
 	kvEventsCache []common.RawKVEntry
 
 	// To handle span removing.
@@ -164,10 +168,9 @@ type sharedClientMetrics struct {
 // SubscriptionClient is used to subscribe events of table ranges from TiKV.
 // All exported Methods are thread-safe.
 type SubscriptionClient struct {
-	config     *SubscriptionClientConfig
-	metrics    sharedClientMetrics
-	clusterID  uint64
-	filterLoop bool
+	config    *SubscriptionClientConfig
+	metrics   sharedClientMetrics
+	clusterID uint64
 
 	pd           pd.Client
 	regionCache  *tikv.RegionCache
@@ -208,8 +211,7 @@ func NewSubscriptionClient(
 	credential *security.Credential,
 ) *SubscriptionClient {
 	subClient := &SubscriptionClient{
-		config:     config,
-		filterLoop: false, // FIXME
+		config: config,
 
 		pd:           pd,
 		regionCache:  regionCache,
@@ -249,6 +251,17 @@ func (s *SubscriptionClient) AllocSubscriptionID() SubscriptionID {
 	return SubscriptionID(subscriptionIDGen.Add(1))
 }
 
+// ReserveSubscriptionID makes sure the ids allocated later are larger than id,
+// it's used to avoid reusing the subscription ids persisted before restart.
+func (s *SubscriptionClient) ReserveSubscriptionID(id SubscriptionID) {
+	for {
+		current := subscriptionIDGen.Load()
+		if current >= uint64(id) || subscriptionIDGen.CompareAndSwap(current, uint64(id)) {
+			return
+		}
+	}
+}
+
 func (s *SubscriptionClient) initMetrics() {
 	// TODO: fix metrics
 	s.metrics.batchResolvedSize = metrics.BatchResolvedEventSize.WithLabelValues("event-store")
@@ -282,6 +295,7 @@ func (s *SubscriptionClient) updateMetrics(ctx context.Context) error {
 // It new a subscribedSpan and store it in `s.totalSpans`,
 // and send a rangeTask to `s.rangeTaskCh`.
 // The rangeTask will be handled in `handleRangeTasks` goroutine.
+// If filterLoop is true, TiKV filters out the events written by TiCDC itself.
 func (s *SubscriptionClient) Subscribe(
 	subID SubscriptionID,
 	span heartbeatpb.TableSpan,
@@ -289,6 +303,7 @@ func (s *SubscriptionClient) Subscribe(
 	consumeKVEvents func(raw []common.RawKVEntry, wakeCallback func()) bool,
 	advanceResolvedTs func(ts uint64),
 	advanceInterval int64,
+	filterLoop bool,
 ) {
 	if span.TableID == 0 {
 		log.Panic("subscription client subscribe with zero TableID")
@@ -296,14 +311,15 @@ func (s *SubscriptionClient) Subscribe(
 	}
 	log.Info("subscribes span",
 		zap.Uint64("subscriptionID", uint64(subID)),
-		zap.String("span", span.String()))
+		zap.String("span", span.String()),
+		zap.Bool("filterLoop", filterLoop))
 	defer func() {
 		log.Info("subscribes span done",
 			zap.Uint64("subscriptionID", uint64(subID)),
 			zap.String("span", span.String()))
 	}()
 
-	rt := s.newSubscribedSpan(subID, span, startTs, consumeKVEvents, advanceResolvedTs, advanceInterval)
+	rt := s.newSubscribedSpan(subID, span, startTs, consumeKVEvents, advanceResolvedTs, advanceInterval, filterLoop)
 	s.totalSpans.Lock()
 	s.totalSpans.spanMap[subID] = rt
 	s.totalSpans.Unlock()
@@ -873,6 +889,7 @@ func (s *SubscriptionClient) newSubscribedSpan(
 	consumeKVEvents func(raw []common.RawKVEntry, wakeCallback func()) bool,
 	advanceResolvedTs func(ts uint64),
 	advanceInterval int64,
+	filterLoop bool,
 ) *subscribedSpan {
 	rangeLock := regionlock.NewRangeLock(uint64(subID), span.StartKey, span.EndKey, startTs)
 
@@ -885,6 +902,7 @@ func (s *SubscriptionClient) newSubscribedSpan(
 		consumeKVEvents:   consumeKVEvents,
 		advanceResolvedTs: advanceResolvedTs,
 		advanceInterval:   advanceInterval,
+		filterLoop:        filterLoop,
 	}
 	rt.initialized.Store(false)
 	rt.resolvedTsUpdated.Store(time.Now().Unix())
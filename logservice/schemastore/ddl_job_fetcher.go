@@ -77,7 +77,7 @@ func newDDLJobFetcher(
 		advanceSubSpanResolvedTs := func(ts uint64) {
 			ddlJobFetcher.tryAdvanceResolvedTs(subID, ts)
 		}
-		subClient.Subscribe(subID, span, startTs, ddlJobFetcher.input, advanceSubSpanResolvedTs, 0)
+		subClient.Subscribe(subID, span, startTs, ddlJobFetcher.input, advanceSubSpanResolvedTs, 0, false)
 	}
 
 	return ddlJobFetcher
@@ -0,0 +1,369 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upstreamstore
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/logservice/eventstore"
+	"github.com/pingcap/ticdc/logservice/logpuller"
+	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/logservice/txnutil"
+	"github.com/pingcap/ticdc/pkg/common"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/pingcap/ticdc/pkg/upstream"
+	"github.com/tikv/client-go/v2/tikv"
+	pd "github.com/tikv/pd/client"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// upstreamDataDir is the directory under the data dir which holds
+// the data of the upstreams other than the default one.
+const upstreamDataDir = "upstream"
+
+// waitUpstreamReadyTimeout is the max time to wait for the connection to a new upstream.
+const waitUpstreamReadyTimeout = time.Minute
+
+// Stores holds the clients and the log service modules of an upstream TiDB cluster.
+type Stores struct {
+	UpstreamID  uint64
+	PDClient    pd.Client
+	PDAPIClient pdutil.PDAPIClient
+	RegionCache *tikv.RegionCache
+	PDClock     pdutil.Clock
+
+	SubscriptionClient *logpuller.SubscriptionClient
+	SchemaStore        schemastore.SchemaStore
+	EventStore         eventstore.EventStore
+
+	// refCount is the number of the maintainers and dispatcher managers using the stores,
+	// the stores are closed when it drops to zero.
+	refCount int
+	// cancel stops the modules of the stores.
+	cancel context.CancelFunc
+}
+
+func (s *Stores) modules() []common.SubModule {
+	modules := make([]common.SubModule, 0, 3)
+	if s.SubscriptionClient != nil {
+		modules = append(modules, s.SubscriptionClient)
+	}
+	if s.SchemaStore != nil {
+		modules = append(modules, s.SchemaStore)
+	}
+	if s.EventStore != nil {
+		modules = append(modules, s.EventStore)
+	}
+	return modules
+}
+
+// Manager manages the Stores of all upstreams the changefeeds on this node replicate from.
+// The default upstream, aka the TiDB cluster that the TiCDC cluster is deployed with,
+// is created by the server, the others are created lazily when they are first used.
+type Manager struct {
+	dataDir         string
+	upstreamManager *upstream.Manager
+	defaultStores   *Stores
+
+	mu sync.Mutex
+	// upstreamID -> stores of upstreams other than the default one
+	stores map[uint64]*Stores
+	// upstreamID -> the channel closed when the removed stores are closed,
+	// the upstream can't be added again until its old stores are closed.
+	removing map[uint64]chan struct{}
+	// upstreamID -> the channel closed when the creation of the stores finishes,
+	// the stores are created without holding the lock, and only by one caller at a time.
+	creating map[uint64]chan struct{}
+	// releaseHandlers are called with the stores released by ReleaseStores before they are closed,
+	// the modules caching the stores use them to drop the cache.
+	releaseHandlers []func(stores *Stores)
+	// eg and ctx are set when the manager starts to run,
+	// modules of the stores created before that are started in Run.
+	eg  *errgroup.Group
+	ctx context.Context
+}
+
+// NewManager creates a new Manager, defaultStores is the stores of the default upstream.
+func NewManager(ctx context.Context, dataDir string, defaultStores *Stores) *Manager {
+	return &Manager{
+		dataDir:         dataDir,
+		upstreamManager: upstream.NewManager(ctx),
+		defaultStores:   defaultStores,
+		stores:          make(map[uint64]*Stores),
+		removing:        make(map[uint64]chan struct{}),
+		creating:        make(map[uint64]chan struct{}),
+	}
+}
+
+func (m *Manager) Name() string {
+	return appcontext.UpstreamStoreManager
+}
+
+func (m *Manager) Run(ctx context.Context) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	m.mu.Lock()
+	m.eg, m.ctx = eg, ctx
+	for _, stores := range m.stores {
+		m.runStores(stores)
+	}
+	m.mu.Unlock()
+
+	eg.Go(func() error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	return eg.Wait()
+}
+
+func (m *Manager) Close(ctx context.Context) error {
+	m.mu.Lock()
+	toClose := make([]*Stores, 0, len(m.stores))
+	for id, stores := range m.stores {
+		toClose = append(toClose, stores)
+		delete(m.stores, id)
+	}
+	m.mu.Unlock()
+	for _, stores := range toClose {
+		closeStores(ctx, stores)
+	}
+	m.upstreamManager.Close()
+	return nil
+}
+
+// GetStores returns the stores of the upstream, upstreamID is 0 for the default upstream.
+func (m *Manager) GetStores(upstreamID uint64) (*Stores, bool) {
+	if upstreamID == 0 || upstreamID == m.defaultStores.UpstreamID {
+		return m.defaultStores, true
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stores, ok := m.stores[upstreamID]
+	return stores, ok
+}
+
+// GetOrAddStores returns the stores of the upstream described by info,
+// info is nil for the default upstream. If the upstream is not used by this node yet,
+// it connects to the upstream and starts a puller, a schema store and an event store for it.
+// Each successful call with a non-nil info must be paired with a ReleaseStores call.
+func (m *Manager) GetOrAddStores(ctx context.Context, info *config.UpstreamInfo) (*Stores, error) {
+	if info == nil || info.ID == m.defaultStores.UpstreamID {
+		return m.defaultStores, nil
+	}
+	var created chan struct{}
+	for {
+		m.mu.Lock()
+		if stores, ok := m.stores[info.ID]; ok {
+			stores.refCount++
+			m.mu.Unlock()
+			return stores, nil
+		}
+		wait, ok := m.removing[info.ID]
+		if !ok {
+			wait, ok = m.creating[info.ID]
+		}
+		if !ok {
+			created = make(chan struct{})
+			m.creating[info.ID] = created
+			m.mu.Unlock()
+			break
+		}
+		m.mu.Unlock()
+		// wait for the old stores of the upstream to be closed,
+		// or the stores being created by another caller.
+		select {
+		case <-ctx.Done():
+			return nil, errors.Trace(ctx.Err())
+		case <-wait:
+		}
+	}
+
+	stores, err := m.createStores(ctx, info)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.creating, info.ID)
+	close(created)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	m.stores[stores.UpstreamID] = stores
+	appcontext.SetService(appcontext.PDClockName(stores.UpstreamID), stores.PDClock)
+	if m.eg != nil {
+		m.runStores(stores)
+	}
+	return stores, nil
+}
+
+// createStores connects to the upstream and creates its stores,
+// it must be called by the only caller which registers the upstream in m.creating.
+func (m *Manager) createStores(ctx context.Context, info *config.UpstreamInfo) (*Stores, error) {
+	up := m.upstreamManager.AddUpstream(&upstream.UpstreamInfo{
+		ID:            info.ID,
+		PDEndpoints:   info.PDEndpoints,
+		KeyPath:       info.KeyPath,
+		CertPath:      info.CertPath,
+		CAPath:        info.CAPath,
+		CertAllowedCN: info.CertAllowedCN,
+	})
+	waitCtx, cancel := context.WithTimeout(ctx, waitUpstreamReadyTimeout)
+	defer cancel()
+	if err := up.WaitReady(waitCtx); err != nil {
+		// remove the upstream so that it's initialized again in the next call
+		m.upstreamManager.Remove(info.ID)
+		return nil, errors.Trace(err)
+	}
+
+	pdAPIClient, err := pdutil.NewPDAPIClient(up.PDClient, up.SecurityConfig)
+	if err != nil {
+		m.upstreamManager.Remove(info.ID)
+		return nil, errors.Trace(err)
+	}
+	dataDir := filepath.Join(m.dataDir, upstreamDataDir, strconv.FormatUint(up.ID, 10))
+	subClient := logpuller.NewSubscriptionClient(
+		&logpuller.SubscriptionClientConfig{
+			RegionRequestWorkerPerStore: 16,
+		}, up.PDClient, up.RegionCache, up.PDClock,
+		txnutil.NewLockerResolver(up.KVStorage.(tikv.Storage)), up.SecurityConfig,
+	)
+	stores := &Stores{
+		UpstreamID:         up.ID,
+		PDClient:           up.PDClient,
+		PDAPIClient:        pdAPIClient,
+		RegionCache:        up.RegionCache,
+		PDClock:            up.PDClock,
+		SubscriptionClient: subClient,
+		SchemaStore:        schemastore.New(ctx, dataDir, subClient, up.PDClient, up.PDClock, up.KVStorage),
+		EventStore:         eventstore.NewForUpstream(dataDir, subClient, up.PDClock),
+		refCount:           1,
+	}
+	log.Info("upstream stores added",
+		zap.Uint64("upstreamID", up.ID),
+		zap.Strings("pdEndpoints", up.PdEndpoints),
+		zap.String("dataDir", dataDir))
+	return stores, nil
+}
+
+// RegisterReleaseHandler registers a handler which is called with the stores
+// of an upstream when they are released and about to be closed.
+func (m *Manager) RegisterReleaseHandler(handler func(stores *Stores)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.releaseHandlers = append(m.releaseHandlers, handler)
+}
+
+// ReleaseStores releases a reference of the stores acquired by GetOrAddStores,
+// the stores are closed and the upstream is disconnected when no one uses it.
+func (m *Manager) ReleaseStores(upstreamID uint64) {
+	if upstreamID == 0 || upstreamID == m.defaultStores.UpstreamID {
+		return
+	}
+	m.mu.Lock()
+	stores, ok := m.stores[upstreamID]
+	if !ok {
+		m.mu.Unlock()
+		log.Warn("release unknown upstream stores", zap.Uint64("upstreamID", upstreamID))
+		return
+	}
+	stores.refCount--
+	if stores.refCount > 0 {
+		m.mu.Unlock()
+		return
+	}
+	delete(m.stores, upstreamID)
+	removed := make(chan struct{})
+	m.removing[upstreamID] = removed
+	appcontext.RemoveService(appcontext.PDClockName(upstreamID))
+	handlers := m.releaseHandlers
+	m.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(stores)
+	}
+	// the modules may take a while to stop, close them without holding the lock
+	closeStores(context.Background(), stores)
+	m.upstreamManager.Remove(upstreamID)
+
+	m.mu.Lock()
+	delete(m.removing, upstreamID)
+	close(removed)
+	m.mu.Unlock()
+	log.Info("upstream stores removed", zap.Uint64("upstreamID", upstreamID))
+}
+
+// runStores must be called with m.mu held.
+func (m *Manager) runStores(stores *Stores) {
+	ctx, cancel := context.WithCancel(m.ctx)
+	stores.cancel = cancel
+	for _, module := range stores.modules() {
+		module := module
+		m.eg.Go(func() error {
+			log.Info("starting upstream module",
+				zap.Uint64("upstreamID", stores.UpstreamID),
+				zap.String("module", module.Name()))
+			err := module.Run(ctx)
+			if ctx.Err() != nil && m.ctx.Err() == nil {
+				// the stores are removed, don't stop the other upstreams
+				return nil
+			}
+			return err
+		})
+	}
+}
+
+// closeStores stops the modules of the stores, the stores must be removed from the manager first.
+func closeStores(ctx context.Context, stores *Stores) {
+	if stores.cancel != nil {
+		stores.cancel()
+	}
+	for _, module := range stores.modules() {
+		if err := module.Close(ctx); err != nil {
+			log.Warn("failed to close upstream module",
+				zap.Uint64("upstreamID", stores.UpstreamID),
+				zap.String("module", module.Name()),
+				zap.Error(err))
+		}
+	}
+	if stores.PDAPIClient != nil {
+		stores.PDAPIClient.Close()
+	}
+}
+
+// AddStoresForTest adds the stores of an upstream with the given reference count,
+// it's only used in tests.
+func (m *Manager) AddStoresForTest(stores *Stores, refCount int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stores.refCount = refCount
+	m.stores[stores.UpstreamID] = stores
+	appcontext.SetService(appcontext.PDClockName(stores.UpstreamID), stores.PDClock)
+}
+
+// GetRefCountForTest returns the reference count of the stores of the upstream,
+// it's 0 if the stores are removed. It's only used in tests.
+func (m *Manager) GetRefCountForTest(upstreamID uint64) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if stores, ok := m.stores[upstreamID]; ok {
+		return stores.refCount
+	}
+	return 0
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upstreamstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDefaultStores(t *testing.T) {
+	defaultStores := &Stores{UpstreamID: 100}
+	m := NewManager(context.Background(), t.TempDir(), defaultStores)
+	defer m.Close(context.Background())
+
+	// both 0 and the cluster ID of the default upstream refer to the default upstream
+	stores, ok := m.GetStores(0)
+	require.True(t, ok)
+	require.Same(t, defaultStores, stores)
+	stores, ok = m.GetStores(100)
+	require.True(t, ok)
+	require.Same(t, defaultStores, stores)
+
+	stores, err := m.GetOrAddStores(context.Background(), nil)
+	require.NoError(t, err)
+	require.Same(t, defaultStores, stores)
+
+	_, ok = m.GetStores(200)
+	require.False(t, ok)
+}
+
+func TestStoresReferenceCount(t *testing.T) {
+	defaultStores := &Stores{UpstreamID: 100}
+	m := NewManager(context.Background(), t.TempDir(), defaultStores)
+	defer m.upstreamManager.Close()
+
+	stores := &Stores{UpstreamID: 200, refCount: 1}
+	m.stores[200] = stores
+
+	// the existing stores are shared by the callers
+	got, err := m.GetOrAddStores(context.Background(), &config.UpstreamInfo{ID: 200})
+	require.NoError(t, err)
+	require.Same(t, stores, got)
+	require.Equal(t, 2, stores.refCount)
+
+	m.ReleaseStores(200)
+	require.Equal(t, 1, stores.refCount)
+	_, ok := m.GetStores(200)
+	require.True(t, ok)
+
+	// the default upstream is not reference counted
+	got, err = m.GetOrAddStores(context.Background(), &config.UpstreamInfo{ID: 100})
+	require.NoError(t, err)
+	require.Same(t, defaultStores, got)
+	m.ReleaseStores(100)
+	m.ReleaseStores(0)
+	_, ok = m.GetStores(100)
+	require.True(t, ok)
+}
+
+func TestWaitForCreatingStores(t *testing.T) {
+	defaultStores := &Stores{UpstreamID: 100}
+	m := NewManager(context.Background(), t.TempDir(), defaultStores)
+	defer m.upstreamManager.Close()
+
+	// another caller is creating the stores of the upstream
+	created := make(chan struct{})
+	m.creating[200] = created
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := m.GetOrAddStores(ctx, &config.UpstreamInfo{ID: 200})
+	require.ErrorIs(t, err, context.Canceled)
+
+	type result struct {
+		stores *Stores
+		err    error
+	}
+	resultCh := make(chan result, 1)
+	go func() {
+		stores, err := m.GetOrAddStores(context.Background(), &config.UpstreamInfo{ID: 200})
+		resultCh <- result{stores: stores, err: err}
+	}()
+	select {
+	case <-resultCh:
+		require.FailNow(t, "the stores should not be returned before they are created")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// the waiting caller shares the stores once they are created
+	stores := &Stores{UpstreamID: 200, refCount: 1}
+	m.mu.Lock()
+	m.stores[200] = stores
+	delete(m.creating, 200)
+	close(created)
+	m.mu.Unlock()
+
+	res := <-resultCh
+	require.NoError(t, res.err)
+	require.Same(t, stores, res.stores)
+	require.Equal(t, 2, stores.refCount)
+}
@@ -20,8 +20,12 @@ import (
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/maintainer/range_checker"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"go.uber.org/zap"
 )
 
@@ -39,6 +43,13 @@ type Barrier struct {
 	blockedEvents     *BlockedEventMap
 	controller        *Controller
 	splitTableEnabled bool
+
+	// bdrRole is the role of the upstream cluster in bdr mode,
+	// the secondary cluster blocks the ddl which is not safe to replicate concurrently.
+	bdrRole config.BDRRole
+	// warningHandler reports the ddl blocked by the bdr role to the coordinator,
+	// it's called with nil when the ddl is no longer blocked.
+	warningHandler func(error)
 }
 
 type BlockedEventMap struct {
@@ -82,10 +93,12 @@ func (b *BlockedEventMap) Delete(key eventKey) {
 }
 
 // eventKey is the key of the block event,
-// the ddl and sync point are identified by the blockTs and isSyncPoint since they can share the same blockTs
+// the ddl, sync point and config barrier are identified by the blockTs, isSyncPoint and isConfigBarrier
+// since they can share the same blockTs
 type eventKey struct {
-	blockTs     uint64
-	isSyncPoint bool
+	blockTs         uint64
+	isSyncPoint     bool
+	isConfigBarrier bool
 }
 
 // NewBarrier create a new barrier for the changefeed
@@ -97,6 +110,22 @@ func NewBarrier(controller *Controller, splitTableEnabled bool) *Barrier {
 	}
 }
 
+// SetBDRRole updates the bdr role of the changefeed, the blocked ddl will be
+// re-evaluated when the dispatcher resends it.
+func (b *Barrier) SetBDRRole(role config.BDRRole) {
+	b.bdrRole = role
+	if role != config.BDRRoleSecondary && b.warningHandler != nil {
+		b.warningHandler(nil)
+	}
+}
+
+// SetWarningHandler sets the handler used to report the ddl blocked by the bdr role.
+// The blocked ddl is a warning instead of an error, because restarting the changefeed
+// doesn't help until the role is changed.
+func (b *Barrier) SetWarningHandler(handler func(error)) {
+	b.warningHandler = handler
+}
+
 // HandleStatus handle the block status from dispatcher manager
 func (b *Barrier) HandleStatus(from node.ID,
 	request *heartbeatpb.BlockStatusRequest,
@@ -111,6 +140,10 @@ func (b *Barrier) HandleStatus(from node.ID,
 		// deal with block status, and check whether need to return action.
 		// we need to deal with the block status in order, otherwise scheduler may have problem
 		// e.g. TODO（truncate + create table)
+		if b.blockedByBDRRole(request.ChangefeedID, status) {
+			// do not ack the event, the dispatcher will resend it
+			continue
+		}
 		event, action := b.handleOneStatus(request.ChangefeedID, status)
 		if event == nil {
 			// should not happen
@@ -131,7 +164,7 @@ func (b *Barrier) HandleStatus(from node.ID,
 				InfluenceType: heartbeatpb.InfluenceType_Normal,
 				DispatcherIDs: dispatchers,
 			},
-			Ack: ackEvent(event.commitTs, event.isSyncPoint),
+			Ack: ackEvent(event.commitTs, event.isSyncPoint, event.isConfigBarrier),
 		})
 	}
 	for action := range actions {
@@ -163,7 +196,7 @@ func (b *Barrier) HandleBootstrapResponse(bootstrapRespMap map[node.ID]*heartbea
 			}
 
 			blockState := span.BlockState
-			key := getEventKey(blockState.BlockTs, blockState.IsSyncPoint)
+			key := getEventKey(blockState.BlockTs, blockState.IsSyncPoint, blockState.IsConfigBarrier)
 			event, ok := b.blockedEvents.Get(key)
 			if !ok {
 				event = NewBlockEvent(common.NewChangefeedIDFromPB(resp.ChangefeedID), common.NewDispatcherIDFromPB(span.ID), b.controller, blockState, b.splitTableEnabled)
@@ -311,7 +344,7 @@ func (b *Barrier) handleOneStatus(changefeedID *heartbeatpb.ChangefeedID, status
 }
 
 func (b *Barrier) handleEventDone(changefeedID common.ChangeFeedID, dispatcherID common.DispatcherID, status *heartbeatpb.TableSpanBlockStatus) *BarrierEvent {
-	key := getEventKey(status.State.BlockTs, status.State.IsSyncPoint)
+	key := getEventKey(status.State.BlockTs, status.State.IsSyncPoint, status.State.IsConfigBarrier)
 	event, ok := b.blockedEvents.Get(key)
 	if !ok {
 		// no block event found
@@ -342,7 +375,7 @@ func (b *Barrier) handleBlockState(changefeedID common.ChangeFeedID,
 ) (*BarrierEvent, *heartbeatpb.DispatcherStatus) {
 	blockState := status.State
 	if blockState.IsBlocked {
-		key := getEventKey(blockState.BlockTs, blockState.IsSyncPoint)
+		key := getEventKey(blockState.BlockTs, blockState.IsSyncPoint, blockState.IsConfigBarrier)
 		// insert an event, or get the old one event check if the event is already tracked
 		event := b.getOrInsertNewEvent(changefeedID, dispatcherID, key, blockState)
 		if dispatcherID == b.controller.ddlDispatcherID {
@@ -375,6 +408,34 @@ func (b *Barrier) handleBlockState(changefeedID common.ChangeFeedID,
 	return event, nil
 }
 
+// blockedByBDRRole returns true if the status carries a ddl which is not allowed in the
+// secondary cluster of bdr mode, the ddl is reported as a warning.
+func (b *Barrier) blockedByBDRRole(changefeedID *heartbeatpb.ChangefeedID, status *heartbeatpb.TableSpanBlockStatus) bool {
+	state := status.State
+	if b.bdrRole != config.BDRRoleSecondary || state == nil ||
+		!state.IsBlocked || state.IsSyncPoint || state.IsConfigBarrier || state.Stage == heartbeatpb.BlockStage_DONE {
+		return false
+	}
+	action := timodel.ActionType(state.DDLType)
+	if filter.IsBDRSafeDDL(action) {
+		return false
+	}
+	// the event is selected before the role is changed, let it go
+	if event, ok := b.blockedEvents.Get(getEventKey(state.BlockTs, state.IsSyncPoint, state.IsConfigBarrier)); ok && event.selected.Load() {
+		return false
+	}
+	log.Warn("the ddl is blocked in the secondary cluster of bdr mode",
+		zap.String("changefeed", changefeedID.GetName()),
+		zap.String("dispatcher", common.NewDispatcherIDFromPB(status.ID).String()),
+		zap.Uint64("commitTs", state.BlockTs),
+		zap.String("ddlType", action.String()))
+	if b.warningHandler != nil {
+		b.warningHandler(cerror.ErrBDRUnsafeDDLBlocked.GenWithStackByArgs(
+			action.String(), state.BlockTs, config.BDRRoleSecondary))
+	}
+	return true
+}
+
 // getOrInsertNewEvent get the block event from the map, if not found, create a new one
 func (b *Barrier) getOrInsertNewEvent(changefeedID common.ChangeFeedID, dispatcherID common.DispatcherID,
 	key eventKey, blockState *heartbeatpb.State,
@@ -398,22 +459,24 @@ func (b *Barrier) checkEventFinish(be *BarrierEvent) {
 			zap.String("changefeed", be.cfID.Name()),
 			zap.Uint64("committs", be.commitTs))
 		// already selected a dispatcher to write, now all dispatchers reported the block event
-		b.blockedEvents.Delete(getEventKey(be.commitTs, be.isSyncPoint))
+		b.blockedEvents.Delete(getEventKey(be.commitTs, be.isSyncPoint, be.isConfigBarrier))
 	}
 }
 
 // ackEvent creates an ack event
-func ackEvent(commitTs uint64, isSyncPoint bool) *heartbeatpb.ACK {
+func ackEvent(commitTs uint64, isSyncPoint, isConfigBarrier bool) *heartbeatpb.ACK {
 	return &heartbeatpb.ACK{
-		CommitTs:    commitTs,
-		IsSyncPoint: isSyncPoint,
+		CommitTs:        commitTs,
+		IsSyncPoint:     isSyncPoint,
+		IsConfigBarrier: isConfigBarrier,
 	}
 }
 
 // getEventKey returns the key of the block event
-func getEventKey(blockTs uint64, isSyncPoint bool) eventKey {
+func getEventKey(blockTs uint64, isSyncPoint, isConfigBarrier bool) eventKey {
 	return eventKey{
-		blockTs:     blockTs,
-		isSyncPoint: isSyncPoint,
+		blockTs:         blockTs,
+		isSyncPoint:     isSyncPoint,
+		isConfigBarrier: isConfigBarrier,
 	}
 }
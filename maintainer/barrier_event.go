@@ -45,6 +45,8 @@ type BarrierEvent struct {
 	newTables          []*heartbeatpb.Table
 	schemaIDChange     []*heartbeatpb.SchemaIDChange
 	isSyncPoint        bool
+	// isConfigBarrier is true if the event is the barrier for switching the hot-reloaded changefeed config
+	isConfigBarrier bool
 	// if the split table is enable for this changefeeed, if not we can use table id to check coverage
 	dynamicSplitEnabled bool
 
@@ -82,6 +84,7 @@ func NewBlockEvent(cfID common.ChangeFeedID,
 		lastResendTime:      time.Time{},
 		reportedDispatchers: make(map[common.DispatcherID]struct{}),
 		isSyncPoint:         status.IsSyncPoint,
+		isConfigBarrier:     status.IsConfigBarrier,
 		dynamicSplitEnabled: dynamicSplitEnabled,
 		lastWarningLogTime:  time.Now(),
 	}
@@ -116,6 +119,7 @@ func NewBlockEvent(cfID common.ChangeFeedID,
 		zap.String("changefeedID", cfID.Name()),
 		zap.Uint64("blockTs", event.commitTs),
 		zap.Bool("syncPoint", event.isSyncPoint),
+		zap.Bool("configBarrier", event.isConfigBarrier),
 		zap.Any("detail", status))
 	return event
 }
@@ -207,6 +211,10 @@ func (be *BarrierEvent) onAllDispatcherReportedBlockEvent(dispatcherID common.Di
 
 func (be *BarrierEvent) scheduleBlockEvent() {
 	log.Info("schedule block event", zap.Uint64("commitTs", be.commitTs))
+	if be.isConfigBarrier {
+		be.reloadTables()
+		return
+	}
 	// dispatcher notify us to drop some tables, by dispatcher ID or schema ID
 	if be.dropDispatchers != nil {
 		switch be.dropDispatchers.InfluenceType {
@@ -252,6 +260,37 @@ func (be *BarrierEvent) scheduleBlockEvent() {
 	}
 }
 
+// reloadTables applies the table filter rules of the hot-reloaded config at the config barrier,
+// the tables not matched anymore are removed, and the newly matched tables are added from the barrier.
+func (be *BarrierEvent) reloadTables() {
+	added, removed, err := be.controller.diffTables(be.commitTs)
+	if err != nil {
+		log.Error("reload tables at the config barrier failed, keep replicating the current tables",
+			zap.String("changefeed", be.cfID.Name()),
+			zap.Uint64("commitTs", be.commitTs),
+			zap.Error(err))
+		return
+	}
+	if len(removed) > 0 {
+		be.controller.RemoveTasksByTableIDs(removed...)
+		log.Info("remove tables by config barrier",
+			zap.String("changefeed", be.cfID.Name()),
+			zap.Uint64("commitTs", be.commitTs),
+			zap.Int64s("tables", removed))
+		// The removed dispatchers never report the event done, so only check the remaining ones.
+		be.createRangeCheckerForTypeAll()
+	}
+	// The added dispatchers start from the barrier, so they don't need to pass it.
+	for _, table := range added {
+		log.Info("add new table by config barrier",
+			zap.String("changefeed", be.cfID.Name()),
+			zap.Uint64("commitTs", be.commitTs),
+			zap.Int64("schema", table.SchemaID),
+			zap.Int64("table", table.TableID))
+		be.controller.AddNewTable(table, be.commitTs)
+	}
+}
+
 func (be *BarrierEvent) markTableDone(tableID int64) {
 	be.rangeChecker.AddSubRange(tableID, nil, nil)
 }
@@ -473,8 +512,9 @@ func (be *BarrierEvent) newPassActionMessage(capture node.ID) *messaging.TargetM
 
 func (be *BarrierEvent) action(action heartbeatpb.Action) *heartbeatpb.DispatcherAction {
 	return &heartbeatpb.DispatcherAction{
-		Action:      action,
-		CommitTs:    be.commitTs,
-		IsSyncPoint: be.isSyncPoint,
+		Action:          action,
+		CommitTs:        be.commitTs,
+		IsSyncPoint:     be.isSyncPoint,
+		IsConfigBarrier: be.isConfigBarrier,
 	}
 }
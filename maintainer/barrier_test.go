@@ -21,8 +21,11 @@ import (
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/maintainer/replica"
 	"github.com/pingcap/ticdc/pkg/common"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/node"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
@@ -806,6 +809,114 @@ func TestSyncPointBlock(t *testing.T) {
 	require.Len(t, barrier.blockedEvents.m, 0)
 }
 
+func TestConfigBarrierReloadTables(t *testing.T) {
+	nm := setNodeManagerAndMessageCenter()
+	nmap := nm.GetAliveNodes()
+	for key := range nmap {
+		delete(nmap, key)
+	}
+	nmap["node1"] = &node.Info{ID: "node1"}
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID,
+		tsoClient, heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 1}, 1)
+	controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 2}, 1)
+	var dispatcherIDs []*heartbeatpb.DispatcherID
+	absents := controller.replicationDB.GetAbsentForTest(make([]*replica.SpanReplication, 0), 10000)
+	for _, stm := range absents {
+		dispatcherIDs = append(dispatcherIDs, stm.ID.ToPB())
+		controller.replicationDB.BindSpanToNode("", "node1", stm)
+		controller.replicationDB.MarkSpanReplicating(stm)
+	}
+	table2DispatcherID := controller.GetTasksByTableID(2)[0].ID.ToPB()
+
+	// the new table filter rules match table 2 and table 3
+	controller.SetReplicaConfig(config.GetDefaultReplicaConfig())
+	appcontext.SetService(appcontext.SchemaStore, &mockSchemaStore{tables: []commonEvent.Table{
+		{SchemaID: 1, TableID: 2},
+		{SchemaID: 1, TableID: 3},
+	}})
+
+	barrier := NewBarrier(controller, false)
+	blockState := func() *heartbeatpb.State {
+		return &heartbeatpb.State{
+			IsBlocked: true,
+			BlockTs:   10,
+			BlockTables: &heartbeatpb.InfluencedTables{
+				InfluenceType: heartbeatpb.InfluenceType_All,
+			},
+			IsConfigBarrier: true,
+		}
+	}
+	msg := barrier.HandleStatus("node1", &heartbeatpb.BlockStatusRequest{
+		ChangefeedID: cfID.ToPB(),
+		BlockStatuses: []*heartbeatpb.TableSpanBlockStatus{
+			{ID: controller.ddlDispatcherID.ToPB(), State: blockState()},
+			{ID: dispatcherIDs[0], State: blockState()},
+			{ID: dispatcherIDs[1], State: blockState()},
+		},
+	})
+	resp := msg.Message[0].(*heartbeatpb.HeartBeatResponse)
+	require.Len(t, resp.DispatcherStatuses, 2)
+	require.True(t, resp.DispatcherStatuses[0].Ack.IsConfigBarrier)
+	require.Equal(t, heartbeatpb.Action_Write, resp.DispatcherStatuses[1].Action.Action)
+	require.True(t, resp.DispatcherStatuses[1].Action.IsConfigBarrier)
+	require.Equal(t, uint64(10), resp.DispatcherStatuses[1].Action.CommitTs)
+
+	// table 1 is removed and table 3 is added from the barrier
+	require.Empty(t, controller.GetTasksByTableID(1))
+	require.Len(t, controller.GetTasksByTableID(2), 1)
+	require.Len(t, controller.GetTasksByTableID(3), 1)
+	require.Equal(t, uint64(10), controller.GetTasksByTableID(3)[0].GetStatus().CheckpointTs)
+
+	key := eventKey{blockTs: 10, isConfigBarrier: true}
+	require.NotNil(t, barrier.blockedEvents.m[key])
+
+	// the writer passes the barrier
+	_ = barrier.HandleStatus("node1", &heartbeatpb.BlockStatusRequest{
+		ChangefeedID: cfID.ToPB(),
+		BlockStatuses: []*heartbeatpb.TableSpanBlockStatus{
+			{
+				ID: controller.ddlDispatcherID.ToPB(),
+				State: &heartbeatpb.State{
+					IsBlocked:       true,
+					BlockTs:         10,
+					Stage:           heartbeatpb.BlockStage_DONE,
+					IsConfigBarrier: true,
+				},
+			},
+		},
+	})
+	msgs := barrier.Resend()
+	require.Len(t, msgs, 1)
+	require.True(t, msgs[0].Message[0].(*heartbeatpb.HeartBeatResponse).DispatcherStatuses[0].Action.IsConfigBarrier)
+
+	// only the remaining dispatcher needs to pass the barrier
+	_ = barrier.HandleStatus("node1", &heartbeatpb.BlockStatusRequest{
+		ChangefeedID: cfID.ToPB(),
+		BlockStatuses: []*heartbeatpb.TableSpanBlockStatus{
+			{
+				ID: table2DispatcherID,
+				State: &heartbeatpb.State{
+					IsBlocked:       true,
+					BlockTs:         10,
+					Stage:           heartbeatpb.BlockStage_DONE,
+					IsConfigBarrier: true,
+				},
+			},
+		},
+	})
+	require.Len(t, barrier.blockedEvents.m, 0)
+}
+
 func TestNonBlocked(t *testing.T) {
 	setNodeManagerAndMessageCenter()
 	tableTriggerEventDispatcherID := common.NewDispatcherID()
@@ -855,6 +966,82 @@ func TestNonBlocked(t *testing.T) {
 	require.Equal(t, 2, barrier.controller.replicationDB.GetAbsentSize(), 2)
 }
 
+func TestBDRSecondaryBlock(t *testing.T) {
+	setNodeManagerAndMessageCenter()
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID,
+		tsoClient, heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	controller := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 1}, 1)
+	stm := controller.GetTasksByTableID(1)[0]
+	controller.replicationDB.BindSpanToNode("", "node1", stm)
+	controller.replicationDB.MarkSpanReplicating(stm)
+
+	barrier := NewBarrier(controller, false)
+	barrier.SetBDRRole(config.BDRRoleSecondary)
+	var warning error
+	reported := 0
+	barrier.SetWarningHandler(func(err error) {
+		warning = err
+		reported++
+	})
+
+	newRequest := func(blockTs uint64, ddlType timodel.ActionType) *heartbeatpb.BlockStatusRequest {
+		return &heartbeatpb.BlockStatusRequest{
+			ChangefeedID: cfID.ToPB(),
+			BlockStatuses: []*heartbeatpb.TableSpanBlockStatus{
+				{
+					ID: stm.ID.ToPB(),
+					State: &heartbeatpb.State{
+						IsBlocked: true,
+						BlockTs:   blockTs,
+						BlockTables: &heartbeatpb.InfluencedTables{
+							InfluenceType: heartbeatpb.InfluenceType_Normal,
+							TableIDs:      []int64{1},
+						},
+						DDLType: int32(ddlType),
+					},
+				},
+			},
+		}
+	}
+
+	// the unsafe ddl is blocked without ack, and reported as a warning on every resend
+	msg := barrier.HandleStatus("node1", newRequest(10, timodel.ActionDropColumn))
+	require.Nil(t, msg)
+	require.Equal(t, 1, reported)
+	require.Error(t, warning)
+	require.Len(t, barrier.blockedEvents.m, 0)
+	msg = barrier.HandleStatus("node1", newRequest(10, timodel.ActionDropColumn))
+	require.Nil(t, msg)
+	require.Equal(t, 2, reported)
+
+	// the safe ddl is handled as usual
+	msg = barrier.HandleStatus("node1", newRequest(5, timodel.ActionAddColumn))
+	require.NotNil(t, msg)
+	resp := msg.Message[0].(*heartbeatpb.HeartBeatResponse)
+	require.Len(t, resp.DispatcherStatuses, 2)
+	require.Equal(t, uint64(5), resp.DispatcherStatuses[0].Ack.CommitTs)
+	require.Equal(t, heartbeatpb.Action_Write, resp.DispatcherStatuses[1].Action.Action)
+	require.Equal(t, 2, reported)
+
+	// the blocked ddl goes on after the role is changed, and the warning is cleared
+	barrier.SetBDRRole(config.BDRRolePrimary)
+	require.NoError(t, warning)
+	msg = barrier.HandleStatus("node1", newRequest(10, timodel.ActionDropColumn))
+	require.NotNil(t, msg)
+	resp = msg.Message[0].(*heartbeatpb.HeartBeatResponse)
+	require.Equal(t, uint64(10), resp.DispatcherStatuses[0].Ack.CommitTs)
+	require.Equal(t, 3, reported)
+}
+
 func TestUpdateCheckpointTs(t *testing.T) {
 	setNodeManagerAndMessageCenter()
 	tableTriggerEventDispatcherID := common.NewDispatcherID()
@@ -968,7 +1155,7 @@ func TestHandleBlockBootstrapResponse(t *testing.T) {
 			},
 		},
 	})
-	event := barrier.blockedEvents.m[getEventKey(6, false)]
+	event := barrier.blockedEvents.m[getEventKey(6, false, false)]
 	require.NotNil(t, event)
 	require.False(t, event.selected.Load())
 	require.False(t, event.writerDispatcherAdvanced)
@@ -1007,7 +1194,7 @@ func TestHandleBlockBootstrapResponse(t *testing.T) {
 			},
 		},
 	})
-	event = barrier.blockedEvents.m[getEventKey(6, false)]
+	event = barrier.blockedEvents.m[getEventKey(6, false, false)]
 	require.NotNil(t, event)
 	require.True(t, event.selected.Load())
 	require.False(t, event.writerDispatcherAdvanced)
@@ -1045,7 +1232,7 @@ func TestHandleBlockBootstrapResponse(t *testing.T) {
 			},
 		},
 	})
-	event = barrier.blockedEvents.m[getEventKey(6, false)]
+	event = barrier.blockedEvents.m[getEventKey(6, false, false)]
 	require.NotNil(t, event)
 	require.True(t, event.selected.Load())
 	require.True(t, event.writerDispatcherAdvanced)
@@ -1074,7 +1261,7 @@ func TestHandleBlockBootstrapResponse(t *testing.T) {
 			},
 		},
 	})
-	event = barrier.blockedEvents.m[getEventKey(6, false)]
+	event = barrier.blockedEvents.m[getEventKey(6, false, false)]
 	require.Nil(t, event)
 }
 
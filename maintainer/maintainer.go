@@ -34,10 +34,12 @@ import (
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/pingcap/ticdc/pkg/scheduler"
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/ticdc/utils/chann"
 	"github.com/pingcap/ticdc/utils/threadpool"
+	predo "github.com/pingcap/tiflow/pkg/redo"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/atomic"
@@ -46,6 +48,12 @@ import (
 
 const (
 	periodEventInterval = time.Millisecond * 200
+	// updateConfigBarrierLag is how far the barrier ts of a hot-reloaded config
+	// is ahead of the current pd time.
+	updateConfigBarrierLag = time.Second * 5
+	// updateConfigResendInterval is the interval to resend the hot-reloaded config
+	// until the changefeed checkpoint passes the barrier ts.
+	updateConfigResendInterval = time.Second
 )
 
 // Maintainer is response for handle changefeed replication tasks. Maintainer should:
@@ -81,6 +89,11 @@ type Maintainer struct {
 
 	checkpointTsByCapture map[node.ID]heartbeatpb.Watermark
 
+	// redoResolvedTsByCapture is the min redo resolvedTs of the dispatchers on each node,
+	// redoResolvedTs is the global redo resolvedTs calculated from them, it's only used when redo log is enabled.
+	redoResolvedTsByCapture map[node.ID]uint64
+	redoResolvedTs          uint64
+
 	scheduleState atomic.Int32
 	bootstrapper  *bootstrap.Bootstrapper[heartbeatpb.MaintainerBootstrapResponse]
 
@@ -89,6 +102,10 @@ type Maintainer struct {
 	bootstrapped     atomic.Bool
 	postBootstrapMsg *heartbeatpb.MaintainerPostBootstrapRequest
 
+	// pendingConfig is the hot-reloaded config whose barrier is not passed yet,
+	// it's resent to the dispatcher managers until the checkpoint passes the barrier.
+	pendingConfig *pendingConfigUpdate
+
 	// startCheckpointTs is the initial checkpointTs when the maintainer is created.
 	// It is sent to dispatcher managers during bootstrap to initialize their
 	// checkpointTs.
@@ -136,6 +153,9 @@ type Maintainer struct {
 		sync.Mutex
 		m map[node.ID]*heartbeatpb.RunningError
 	}
+	// runningWarning is the warning which blocks the changefeed without restarting it,
+	// e.g. the ddl blocked by the bdr role.
+	runningWarning atomic.Pointer[heartbeatpb.RunningError]
 
 	cancelUpdateMetrics            context.CancelFunc
 	changefeedCheckpointTsGauge    prometheus.Gauge
@@ -172,9 +192,8 @@ func NewMaintainer(cfID common.ChangeFeedID,
 			CheckpointTs:    checkpointTs,
 		}, selfNode.ID)
 
-	// TODO: Retrieve the correct pdClock from the context once multiple upstreams are supported.
-	// For now, since there is only one upstream, using the default pdClock is enough.
-	pdClock := appcontext.GetService[pdutil.Clock](appcontext.DefaultPDClock)
+	upstreamID := cfg.GetNonDefaultUpstreamID()
+	pdClock := appcontext.GetService[pdutil.Clock](appcontext.PDClockName(upstreamID))
 	m := &Maintainer{
 		id:                cfID,
 		pdClock:           pdClock,
@@ -192,9 +211,10 @@ func NewMaintainer(cfID common.ChangeFeedID,
 		cascadeRemoving: false,
 		config:          cfg,
 
-		ddlSpan:               ddlSpan,
-		checkpointTsByCapture: make(map[node.ID]heartbeatpb.Watermark),
-		newChangefeed:         newChangefeed,
+		ddlSpan:                 ddlSpan,
+		checkpointTsByCapture:   make(map[node.ID]heartbeatpb.Watermark),
+		redoResolvedTsByCapture: make(map[node.ID]uint64),
+		newChangefeed:           newChangefeed,
 
 		changefeedCheckpointTsGauge:    metrics.ChangefeedCheckpointTsGauge.WithLabelValues(cfID.Namespace(), cfID.Name()),
 		changefeedCheckpointTsLagGauge: metrics.ChangefeedCheckpointTsLagGauge.WithLabelValues(cfID.Namespace(), cfID.Name()),
@@ -206,6 +226,7 @@ func NewMaintainer(cfID common.ChangeFeedID,
 		tableCountGauge:                metrics.TableGauge.WithLabelValues(cfID.Namespace(), cfID.Name()),
 		handleEventDuration:            metrics.MaintainerHandleEventDuration.WithLabelValues(cfID.Namespace(), cfID.Name()),
 	}
+	m.controller.upstreamID = upstreamID
 	m.nodeChanged.changed = false
 	m.runningErrors.m = make(map[node.ID]*heartbeatpb.RunningError)
 
@@ -328,16 +349,40 @@ func (m *Maintainer) GetMaintainerStatus() *heartbeatpb.MaintainerStatus {
 		m.runningErrors.m = make(map[node.ID]*heartbeatpb.RunningError)
 	}
 
+	drainingDispatcherCount, drainEpoch := m.getDrainingDispatcherCount()
+	// report the load of the maintainer node, the coordinator balances the maintainers with it
+	nodeLoad := m.controller.GetNodeLoad(m.selfNode.ID)
 	status := &heartbeatpb.MaintainerStatus{
-		ChangefeedID:  m.id.ToPB(),
-		State:         heartbeatpb.ComponentState(m.scheduleState.Load()),
-		CheckpointTs:  m.getWatermark().CheckpointTs,
-		Err:           runningErrors,
-		BootstrapDone: m.bootstrapped.Load(),
+		ChangefeedID:            m.id.ToPB(),
+		State:                   heartbeatpb.ComponentState(m.scheduleState.Load()),
+		CheckpointTs:            m.getWatermark().CheckpointTs,
+		Err:                     runningErrors,
+		BootstrapDone:           m.bootstrapped.Load(),
+		DrainingDispatcherCount: uint32(drainingDispatcherCount),
+		DrainEpoch:              drainEpoch,
+		EventSizePerSecond:      m.controller.GetEventSizePerSecond(),
+		Warning:                 m.runningWarning.Load(),
+		MemoryUsageRatio:        float32(nodeLoad.MemoryUsageRatio),
+		SinkLagMs:               uint64(nodeLoad.SinkLag.Milliseconds()),
 	}
 	return status
 }
 
+// getDrainingDispatcherCount returns the number of dispatchers which are still on the draining nodes,
+// and the drain epoch of the draining nodes. The epoch is 0 before the maintainer is bootstrapped,
+// because the dispatchers are not known yet, the coordinator treats the count as unknown.
+func (m *Maintainer) getDrainingDispatcherCount() (int, uint64) {
+	if !m.bootstrapped.Load() {
+		return 0, 0
+	}
+	ids, epoch := m.nodeManager.GetUnschedulableNodesWithEpoch()
+	count := 0
+	for _, id := range ids {
+		count += m.controller.GetTaskSizeByNodeID(id)
+	}
+	return count, epoch
+}
+
 func (m *Maintainer) initialize() error {
 	start := time.Now()
 	log.Info("start to initialize changefeed maintainer",
@@ -412,6 +457,7 @@ func (m *Maintainer) onInit() bool {
 // Coordinator:
 // - RemoveMaintainerRequest: Changefeed removal commands from coordinator
 // - CheckpointTsMessage: CheckpointTs from coordinator, need to send to all dispatcher managers
+// - UpdateMaintainerConfigRequest: Hot-reloaded changefeed config from coordinator
 func (m *Maintainer) onMessage(msg *messaging.TargetMessage) {
 	switch msg.Type {
 	case messaging.TypeHeartBeatRequest:
@@ -431,6 +477,9 @@ func (m *Maintainer) onMessage(msg *messaging.TargetMessage) {
 	case messaging.TypeCheckpointTsMessage:
 		req := msg.Message[0].(*heartbeatpb.CheckpointTsMessage)
 		m.onCheckpointTsPersisted(req)
+	case messaging.TypeUpdateMaintainerConfigRequest:
+		req := msg.Message[0].(*heartbeatpb.UpdateMaintainerConfigRequest)
+		m.onUpdateConfig(req)
 	default:
 		log.Panic("unexpected message type",
 			zap.String("changefeed", m.id.Name()),
@@ -459,14 +508,103 @@ func (m *Maintainer) onCheckpointTsPersisted(msg *heartbeatpb.CheckpointTsMessag
 	})
 }
 
+type pendingConfigUpdate struct {
+	barrierTs    uint64
+	config       []byte
+	lastSendTime time.Time
+}
+
+// onUpdateConfig applies the hot-reloaded changefeed config. The new config is broadcast
+// to all the dispatcher managers along with a barrier ts. Every dispatcher blocks at the
+// barrier ts and reports it as a block event, so the config is switched at the same commitTs
+// after all dispatchers reach it, and the table filter rules are re-evaluated by the barrier.
+func (m *Maintainer) onUpdateConfig(req *heartbeatpb.UpdateMaintainerConfigRequest) {
+	info := &config.ChangeFeedInfo{}
+	if err := json.Unmarshal(req.Config, info); err != nil {
+		log.Warn("decode changefeed config failed, ignore the update request",
+			zap.String("changefeed", m.id.Name()),
+			zap.Error(err))
+		return
+	}
+	// The following bootstrap requests will carry the new config.
+	m.config = info
+	if m.barrier != nil {
+		m.barrier.SetBDRRole(info.Config.BDRRole)
+	}
+	m.controller.SetReplicaConfig(info.Config)
+	cfgBytes, err := json.Marshal(info.ToChangefeedConfig())
+	if err != nil {
+		log.Panic("marshal changefeed config failed",
+			zap.String("changefeed", m.id.Name()),
+			zap.Error(err))
+	}
+	// The barrier ts is a little ahead of the current time, so that the events
+	// with larger commitTs are not scanned yet when the request arrives.
+	// If the previous barrier is not passed yet, the dispatcher managers apply
+	// the newest config at the previous barrier, and again at the new one.
+	barrierTs := oracle.GoTimeToTS(m.pdClock.CurrentTime().Add(updateConfigBarrierLag))
+	m.pendingConfig = &pendingConfigUpdate{
+		barrierTs: barrierTs,
+		config:    cfgBytes,
+	}
+	m.sendUpdateConfigRequest()
+}
+
+// sendUpdateConfigRequest broadcasts the pending config to all dispatcher managers,
+// it's cleared after the changefeed checkpoint passes the barrier ts.
+func (m *Maintainer) sendUpdateConfigRequest() {
+	pending := m.pendingConfig
+	if pending == nil {
+		return
+	}
+	if m.getWatermark().CheckpointTs >= pending.barrierTs {
+		log.Info("changefeed passed the config barrier",
+			zap.String("changefeed", m.id.Name()),
+			zap.Uint64("barrierTs", pending.barrierTs))
+		m.pendingConfig = nil
+		return
+	}
+	if time.Since(pending.lastSendTime) < updateConfigResendInterval {
+		return
+	}
+	pending.lastSendTime = time.Now()
+	msgs := make([]*messaging.TargetMessage, 0, len(m.bootstrapper.GetAllNodes()))
+	for id := range m.bootstrapper.GetAllNodes() {
+		msgs = append(msgs, messaging.NewSingleTargetMessage(id,
+			messaging.DispatcherManagerManagerTopic,
+			&heartbeatpb.MaintainerUpdateConfigRequest{
+				ChangefeedID: m.id.ToPB(),
+				Config:       pending.config,
+				BarrierTs:    pending.barrierTs,
+			}))
+	}
+	m.sendMessages(msgs)
+	log.Info("broadcast changefeed config to dispatcher managers",
+		zap.String("changefeed", m.id.Name()),
+		zap.Uint64("barrierTs", pending.barrierTs),
+		zap.Int("nodes", len(msgs)))
+}
+
 func (m *Maintainer) onNodeChanged() {
 	currentNodes := m.bootstrapper.GetAllNodes()
 
 	activeNodes := m.nodeManager.GetAliveNodes()
 	newNodes := make([]*node.Info, 0, len(activeNodes))
+	var restartedNodes []node.ID
 	for id, n := range activeNodes {
-		if _, ok := currentNodes[id]; !ok {
+		status, ok := currentNodes[id]
+		if !ok {
+			newNodes = append(newNodes, n)
+			continue
+		}
+		if status.IsRestarted(n) {
+			// the node is restarted with the same id, all states on it are lost,
+			// so clean up the states and bootstrap it again as a new node.
+			restartedNodes = append(restartedNodes, id)
 			newNodes = append(newNodes, n)
+			delete(m.checkpointTsByCapture, id)
+			delete(m.redoResolvedTsByCapture, id)
+			m.controller.RemoveNode(id)
 		}
 	}
 	var removedNodes []node.ID
@@ -474,12 +612,14 @@ func (m *Maintainer) onNodeChanged() {
 		if _, ok := activeNodes[id]; !ok {
 			removedNodes = append(removedNodes, id)
 			delete(m.checkpointTsByCapture, id)
+			delete(m.redoResolvedTsByCapture, id)
 			m.controller.RemoveNode(id)
 		}
 	}
 	log.Info("maintainer node changed", zap.String("id", m.id.String()),
 		zap.Int("new", len(newNodes)),
-		zap.Int("removed", len(removedNodes)))
+		zap.Int("removed", len(removedNodes)),
+		zap.Int("restarted", len(restartedNodes)))
 	m.sendMessages(m.bootstrapper.HandleNewNodes(newNodes))
 	cachedResponse := m.bootstrapper.HandleRemoveNodes(removedNodes)
 	if cachedResponse != nil {
@@ -541,6 +681,48 @@ func (m *Maintainer) calCheckpointTs() {
 	m.setWatermark(*newWatermark)
 }
 
+// calRedoResolvedTs calculates the global redo resolvedTs of the changefeed and broadcasts it to
+// the dispatcher managers. The dispatchers write the events to the downstream only after the
+// global redo resolvedTs reaches them, so the redo log is never behind the downstream for any table.
+// It uses the same conditions as the checkpointTs, because the new dispatchers must join the calculation.
+func (m *Maintainer) calRedoResolvedTs() {
+	consistent := m.config.Config.Consistent
+	if consistent == nil || !predo.IsConsistentEnabled(consistent.Level) {
+		return
+	}
+	if !m.bootstrapped.Load() || !m.controller.ScheduleFinished() || m.barrier.ShouldBlockCheckpointTs() {
+		return
+	}
+	resolvedTs := uint64(math.MaxUint64)
+	nodes := m.bootstrapper.GetAllNodes()
+	for id := range nodes {
+		if id != m.selfNode.ID && m.controller.GetTaskSizeByNodeID(id) <= 0 {
+			continue
+		}
+		ts, ok := m.redoResolvedTsByCapture[id]
+		if !ok {
+			return
+		}
+		resolvedTs = min(resolvedTs, ts)
+	}
+	if resolvedTs != math.MaxUint64 && resolvedTs > m.redoResolvedTs {
+		m.redoResolvedTs = resolvedTs
+	}
+	if m.redoResolvedTs == 0 {
+		return
+	}
+	// the message is sent periodically, so it's fine to lose some of them.
+	msgs := make([]*messaging.TargetMessage, 0, len(nodes))
+	for id := range nodes {
+		msgs = append(msgs, messaging.NewSingleTargetMessage(id, messaging.HeartbeatCollectorTopic,
+			&heartbeatpb.HeartBeatResponse{
+				ChangefeedID:   m.id.ToPB(),
+				RedoResolvedTs: m.redoResolvedTs,
+			}))
+	}
+	m.sendMessages(msgs)
+}
+
 func (m *Maintainer) updateMetrics() {
 	watermark := m.getWatermark()
 
@@ -582,7 +764,9 @@ func (m *Maintainer) onHeartBeatRequest(msg *messaging.TargetMessage) {
 		if !ok || req.Watermark.Seq >= old.Seq {
 			m.checkpointTsByCapture[msg.From] = *req.Watermark
 		}
+		m.redoResolvedTsByCapture[msg.From] = req.RedoResolvedTs
 	}
+	m.controller.UpdateNodeLoad(msg.From, m.getNodeLoad(msg.From, req))
 	m.controller.HandleStatus(msg.From, req.Statuses)
 	if req.Err != nil {
 		log.Warn("dispatcher report an error",
@@ -593,6 +777,19 @@ func (m *Maintainer) onHeartBeatRequest(msg *messaging.TargetMessage) {
 	}
 }
 
+// getNodeLoad returns the runtime load of the node, the sink lag is calculated
+// from the checkpointTs of the dispatchers of the changefeed on the node.
+func (m *Maintainer) getNodeLoad(from node.ID, req *heartbeatpb.HeartBeatRequest) scheduler.NodeLoad {
+	load := scheduler.NodeLoad{MemoryUsageRatio: float64(req.MemoryUsageRatio)}
+	if watermark, ok := m.checkpointTsByCapture[from]; ok {
+		lag := oracle.GetPhysical(m.pdClock.CurrentTime()) - oracle.ExtractPhysical(watermark.CheckpointTs)
+		if lag > 0 {
+			load.SinkLag = time.Duration(lag) * time.Millisecond
+		}
+	}
+	return load
+}
+
 func (m *Maintainer) onError(from node.ID, err *heartbeatpb.RunningError) {
 	err.Node = from.String()
 	if info, ok := m.nodeManager.GetAliveNodes()[from]; ok {
@@ -676,6 +873,8 @@ func (m *Maintainer) onBootstrapDone(cachedResp map[node.ID]*heartbeatpb.Maintai
 		m.handleError(err)
 		return
 	}
+	barrier.SetBDRRole(m.config.Config.BDRRole)
+	barrier.SetWarningHandler(m.handleWarning)
 	m.barrier = barrier
 	m.bootstrapped.Store(true)
 
@@ -718,6 +917,8 @@ func (m *Maintainer) handleResendMessage() {
 		// resend barrier ack messages
 		m.sendMessages(m.barrier.Resend())
 	}
+	// resend the hot-reloaded config until the barrier is passed
+	m.sendUpdateConfigRequest()
 }
 
 func (m *Maintainer) tryCloseChangefeed() bool {
@@ -779,6 +980,30 @@ func (m *Maintainer) handleError(err error) {
 	m.statusChanged.Store(true)
 }
 
+// handleWarning caches the warning, the warning is reported to coordinator in every status
+// until it's cleared by a nil error, and it doesn't make the coordinator restart the changefeed.
+func (m *Maintainer) handleWarning(err error) {
+	if err == nil {
+		if m.runningWarning.Swap(nil) != nil {
+			m.statusChanged.Store(true)
+		}
+		return
+	}
+	var code string
+	if rfcCode, ok := errors.RFCCode(err); ok {
+		code = string(rfcCode)
+	} else {
+		code = string(errors.ErrOwnerUnknown.RFCCode())
+	}
+	m.runningWarning.Store(&heartbeatpb.RunningError{
+		Time:    time.Now().String(),
+		Node:    m.selfNode.AdvertiseAddr,
+		Code:    code,
+		Message: err.Error(),
+	})
+	m.statusChanged.Store(true)
+}
+
 // createBootstrapMessageFactory returns a function that generates bootstrap messages
 // for initializing dispatcher managers. The returned function takes a node ID and
 // returns a message containing:
@@ -787,14 +1012,15 @@ func (m *Maintainer) handleError(err error) {
 // - Table trigger event dispatcher ID (only for dispatcher manager on same node)
 // - Flag indicating if this is a new changefeed
 func (m *Maintainer) createBootstrapMessageFactory() bootstrap.NewBootstrapMessageFn {
-	// cfgBytes only holds necessary fields to initialize a changefeed dispatcher manager.
-	cfgBytes, err := json.Marshal(m.config.ToChangefeedConfig())
-	if err != nil {
-		log.Panic("marshal changefeed config failed",
-			zap.String("changefeed", m.id.Name()),
-			zap.Error(err))
-	}
 	return func(id node.ID) *messaging.TargetMessage {
+		// cfgBytes only holds necessary fields to initialize a changefeed dispatcher manager.
+		// It's encoded every time, since the config may be updated by onUpdateConfig.
+		cfgBytes, err := json.Marshal(m.config.ToChangefeedConfig())
+		if err != nil {
+			log.Panic("marshal changefeed config failed",
+				zap.String("changefeed", m.id.Name()),
+				zap.Error(err))
+		}
 		msg := &heartbeatpb.MaintainerBootstrapRequest{
 			ChangefeedID:                  m.id.ToPB(),
 			Config:                        cfgBytes,
@@ -829,6 +1055,7 @@ func (m *Maintainer) onPeriodTask() {
 	m.handleResendMessage()
 	m.collectMetrics()
 	m.calCheckpointTs()
+	m.calRedoResolvedTs()
 	m.submitScheduledEvent(m.taskScheduler, &Event{
 		changefeedID: m.id,
 		eventType:    EventPeriod,
@@ -15,11 +15,13 @@ package maintainer
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/logservice/upstreamstore"
 	"github.com/pingcap/ticdc/maintainer/operator"
 	"github.com/pingcap/ticdc/maintainer/replica"
 	"github.com/pingcap/ticdc/maintainer/split"
@@ -60,9 +62,18 @@ type Controller struct {
 
 	cfConfig     *config.ReplicaConfig
 	changefeedID common.ChangeFeedID
+	// upstreamID is the cluster ID of the upstream the changefeed replicates from,
+	// it's 0 for the default upstream.
+	upstreamID uint64
 
 	taskScheduler threadpool.ThreadPool
 	taskHandlers  []*threadpool.TaskHandle
+
+	// nodeLoads is the runtime load of the nodes, reported by the dispatcher managers
+	nodeLoads struct {
+		sync.RWMutex
+		m map[node.ID]scheduler.NodeLoad
+	}
 }
 
 func NewController(changefeedID common.ChangeFeedID,
@@ -100,7 +111,8 @@ func NewController(changefeedID common.ChangeFeedID,
 		splitter:               splitter,
 		enableTableAcrossNodes: enableTableAcrossNodes,
 	}
-	s.schedulerController = NewScheduleController(changefeedID, batchSize, oc, replicaSetDB, nodeManager, balanceInterval, s.splitter)
+	s.nodeLoads.m = make(map[node.ID]scheduler.NodeLoad)
+	s.schedulerController = NewScheduleController(changefeedID, batchSize, oc, replicaSetDB, nodeManager, balanceInterval, s, s.splitter)
 	return s
 }
 
@@ -370,6 +382,40 @@ func (c *Controller) UpdateSchemaID(tableID, newSchemaID int64) {
 // RemoveNode is called when a node is removed
 func (c *Controller) RemoveNode(id node.ID) {
 	c.operatorController.OnNodeRemoved(id)
+	c.nodeLoads.Lock()
+	delete(c.nodeLoads.m, id)
+	c.nodeLoads.Unlock()
+}
+
+// UpdateNodeLoad updates the runtime load of the node
+func (c *Controller) UpdateNodeLoad(id node.ID, load scheduler.NodeLoad) {
+	c.nodeLoads.Lock()
+	defer c.nodeLoads.Unlock()
+	c.nodeLoads.m[id] = load
+}
+
+// GetNodeLoads returns the runtime load of all nodes, it implements scheduler.NodeLoadProvider
+func (c *Controller) GetNodeLoads() map[node.ID]scheduler.NodeLoad {
+	c.nodeLoads.RLock()
+	defer c.nodeLoads.RUnlock()
+	loads := make(map[node.ID]scheduler.NodeLoad, len(c.nodeLoads.m))
+	for id, load := range c.nodeLoads.m {
+		loads[id] = load
+	}
+	return loads
+}
+
+// GetNodeLoad returns the runtime load of the node, it's zero if the node doesn't report yet
+func (c *Controller) GetNodeLoad(id node.ID) scheduler.NodeLoad {
+	c.nodeLoads.RLock()
+	defer c.nodeLoads.RUnlock()
+	return c.nodeLoads.m[id]
+}
+
+// GetEventSizePerSecond returns the sum of the write throughput of all tasks,
+// it's cached by the replication db when the tasks report their status
+func (c *Controller) GetEventSizePerSecond() float32 {
+	return float32(c.replicationDB.GetEventSizePerSecond())
 }
 
 // ScheduleFinished return false if not all task are running in working state
@@ -401,6 +447,42 @@ func (c *Controller) addNewSpans(schemaID int64, tableSpans []*heartbeatpb.Table
 	}
 }
 
+// SetReplicaConfig replaces the replica config when the changefeed config is hot-reloaded,
+// the new table filter rules are applied when the changefeed passes the config barrier.
+func (c *Controller) SetReplicaConfig(cfConfig *config.ReplicaConfig) {
+	c.cfConfig = cfConfig
+}
+
+// diffTables compares the tables matched by the table filter rules at ts with the replicating tables,
+// it returns the tables need to be added and the ids of the tables need to be removed.
+func (c *Controller) diffTables(ts uint64) ([]commonEvent.Table, []int64, error) {
+	tables, err := c.loadTables(ts)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	matched := make(map[int64]struct{}, len(tables))
+	var added []commonEvent.Table
+	for _, table := range tables {
+		matched[table.TableID] = struct{}{}
+		if !c.replicationDB.IsTableExists(table.TableID) {
+			added = append(added, table)
+		}
+	}
+	removedSet := make(map[int64]struct{})
+	var removed []int64
+	for _, task := range c.replicationDB.GetAllTasks() {
+		tableID := task.Span.TableID
+		if _, ok := matched[tableID]; ok || tableID == heartbeatpb.DDLSpan.TableID {
+			continue
+		}
+		if _, ok := removedSet[tableID]; !ok {
+			removedSet[tableID] = struct{}{}
+			removed = append(removed, tableID)
+		}
+	}
+	return added, removed, nil
+}
+
 func (c *Controller) loadTables(startTs uint64) ([]commonEvent.Table, error) {
 	// Use a empty timezone because table filter does not need it.
 	f, err := filter.NewFilter(c.cfConfig.Filter, "", c.cfConfig.CaseSensitive, c.cfConfig.ForceReplicate)
@@ -408,12 +490,28 @@ func (c *Controller) loadTables(startTs uint64) ([]commonEvent.Table, error) {
 		return nil, errors.Cause(err)
 	}
 
-	schemaStore := appcontext.GetService[schemastore.SchemaStore](appcontext.SchemaStore)
+	schemaStore, err := c.getSchemaStore()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	tables, err := schemaStore.GetAllPhysicalTables(startTs, f)
 	log.Info("get table ids", zap.Int("count", len(tables)), zap.String("changefeed", c.changefeedID.Name()))
 	return tables, err
 }
 
+// getSchemaStore returns the schema store of the upstream the changefeed replicates from.
+func (c *Controller) getSchemaStore() (schemastore.SchemaStore, error) {
+	if c.upstreamID == 0 {
+		return appcontext.GetService[schemastore.SchemaStore](appcontext.SchemaStore), nil
+	}
+	upstreamStoreManager := appcontext.GetService[*upstreamstore.Manager](appcontext.UpstreamStoreManager)
+	stores, ok := upstreamStoreManager.GetStores(c.upstreamID)
+	if !ok {
+		return nil, errors.ErrUpstreamNotFound.GenWithStackByArgs(c.upstreamID)
+	}
+	return stores.SchemaStore, nil
+}
+
 // only for test
 // moveTable is used for inner api(which just for make test cases convience) to force move a table to a target node.
 // moveTable only works for the complete table, not for the table splited.
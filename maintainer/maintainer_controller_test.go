@@ -314,6 +314,77 @@ func TestBalance(t *testing.T) {
 	require.Equal(t, 100, s.replicationDB.GetTaskSizeByNodeID("node1"))
 }
 
+func TestDrainNode(t *testing.T) {
+	nodeManager := setNodeManagerAndMessageCenter()
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	nodeManager.GetAliveNodes()["node2"] = &node.Info{ID: "node2"}
+	nodeManager.GetAliveNodes()["node3"] = &node.Info{ID: "node3"}
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &replica.MockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID,
+		tsoClient,
+		heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node2")
+	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	for i := 0; i < 100; i++ {
+		sz := spanz.TableIDToComparableSpan(int64(i))
+		span := &heartbeatpb.TableSpan{TableID: sz.TableID, StartKey: sz.StartKey, EndKey: sz.EndKey}
+		dispatcherID := common.NewDispatcherID()
+		spanReplica := replica.NewReplicaSet(cfID, dispatcherID, tsoClient, 1, span, 1)
+		spanReplica.SetNodeID("node1")
+		s.replicationDB.AddReplicatingSpan(spanReplica)
+	}
+
+	// the node not alive is ignored
+	nodeManager.SetUnschedulableNodes([]node.ID{"node1", "node4"}, 2)
+	require.Equal(t, []node.ID{"node1"}, nodeManager.GetUnschedulableNodes())
+	// the stale request is ignored
+	nodeManager.SetUnschedulableNodes(nil, 1)
+	ids, epoch := nodeManager.GetUnschedulableNodesWithEpoch()
+	require.Equal(t, []node.ID{"node1"}, ids)
+	require.Equal(t, uint64(2), epoch)
+	require.Len(t, nodeManager.GetSchedulableNodes(), 2)
+
+	// balance is skipped while draining
+	s.schedulerController.GetScheduler(scheduler.BalanceScheduler).Execute()
+	require.Equal(t, 0, s.operatorController.OperatorSize())
+
+	s.schedulerController.GetScheduler(scheduler.DrainScheduler).Execute()
+	require.Equal(t, 100, s.operatorController.OperatorSize())
+	require.Equal(t, 100, s.replicationDB.GetSchedulingSize())
+	for _, span := range s.replicationDB.GetTasksBySchemaID(1) {
+		op := s.operatorController.GetOperator(span.ID)
+		require.NotNil(t, op)
+		_, ok := op.(*operator.MoveDispatcherOperator)
+		require.True(t, ok)
+	}
+
+	// new tables are never scheduled to the draining node
+	for i := 100; i < 110; i++ {
+		s.AddNewTable(commonEvent.Table{
+			SchemaID: 2,
+			TableID:  int64(i),
+		}, 1)
+	}
+	s.schedulerController.GetScheduler(scheduler.BasicScheduler).Execute()
+	for _, span := range s.replicationDB.GetTasksBySchemaID(2) {
+		op := s.operatorController.GetOperator(span.ID)
+		require.NotNil(t, op)
+		op.Start()
+		require.NotEqual(t, node.ID("node1"), span.GetNodeID())
+	}
+
+	// clear the drain mark
+	nodeManager.SetUnschedulableNodes(nil, 3)
+	require.False(t, nodeManager.IsNodeUnschedulable("node1"))
+	require.Len(t, nodeManager.GetSchedulableNodes(), 3)
+}
+
 func TestStoppedWhenMoving(t *testing.T) {
 	nodeManager := setNodeManagerAndMessageCenter()
 	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
@@ -21,13 +21,17 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/upstreamstore"
 	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/maintainer/split"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/ticdc/utils/threadpool"
 	"github.com/tikv/client-go/v2/tikv"
 	"go.uber.org/zap"
@@ -60,6 +64,13 @@ type Manager struct {
 	// msgCh is used to cache messages from coordinator
 	msgCh chan *messaging.TargetMessage
 
+	// changefeedID -> the add maintainer request waiting for the stores of its upstream,
+	// the stores are created asynchronously to not block the message loop.
+	// It's only accessed in the Run goroutine.
+	pendingUpstreams map[common.ChangeFeedID]*heartbeatpb.AddMaintainerRequest
+	// upstreamStoresCh receives the results of creating the upstream stores
+	upstreamStoresCh chan *upstreamStoresResult
+
 	taskScheduler threadpool.ThreadPool
 }
 
@@ -82,6 +93,9 @@ func NewMaintainerManager(selfNode *node.Info,
 		pdAPI:         pdAPI,
 		tsoClient:     pdClient,
 		regionCache:   regionCache,
+
+		pendingUpstreams: make(map[common.ChangeFeedID]*heartbeatpb.AddMaintainerRequest),
+		upstreamStoresCh: make(chan *upstreamStoresResult, 16),
 	}
 
 	mc.RegisterHandler(messaging.MaintainerManagerTopic, m.recvMessages)
@@ -123,6 +137,12 @@ func (m *Manager) recvMessages(ctx context.Context, msg *messaging.TargetMessage
 	case messaging.TypeCheckpointTsMessage:
 		req := msg.Message[0].(*heartbeatpb.CheckpointTsMessage)
 		return m.dispatcherMaintainerMessage(ctx, common.NewChangefeedIDFromPB(req.ChangefeedID), msg)
+	case messaging.TypeUpdateMaintainerConfigRequest:
+		req := msg.Message[0].(*heartbeatpb.UpdateMaintainerConfigRequest)
+		return m.dispatcherMaintainerMessage(ctx, common.NewChangefeedIDFromPB(req.Id), msg)
+	case messaging.TypeDrainNodeRequest:
+		m.onDrainNodeRequest(msg.Message[0].(*heartbeatpb.DrainNodeRequest))
+		return nil
 	default:
 		log.Panic("unknown message type", zap.Any("message", msg.Message))
 	}
@@ -142,7 +162,9 @@ func (m *Manager) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case msg := <-m.msgCh:
-			m.handleMessage(msg)
+			m.handleMessage(ctx, msg)
+		case result := <-m.upstreamStoresCh:
+			m.onUpstreamStoresCreated(result)
 		case <-ticker.C:
 			// 1.  try to send heartbeat to coordinator
 			m.sendHeartbeat()
@@ -151,6 +173,10 @@ func (m *Manager) Run(ctx context.Context) error {
 				cf := value.(*Maintainer)
 				if cf.removed.Load() {
 					cf.Close()
+					if cf.controller.upstreamID != 0 {
+						appcontext.GetService[*upstreamstore.Manager](appcontext.UpstreamStoreManager).
+							ReleaseStores(cf.controller.upstreamID)
+					}
 					log.Info("maintainer removed, remove it from dynamic stream",
 						zap.Stringer("changefeed", cf.id))
 					m.maintainers.Delete(key)
@@ -196,7 +222,9 @@ func (m *Manager) onCoordinatorBootstrapRequest(msg *messaging.TargetMessage) {
 	m.coordinatorID = msg.From
 	m.coordinatorVersion = req.Version
 
-	response := &heartbeatpb.CoordinatorBootstrapResponse{}
+	response := &heartbeatpb.CoordinatorBootstrapResponse{
+		NodeLabels: node.FormatLabels(config.GetGlobalServerConfig().Labels),
+	}
 	m.maintainers.Range(func(key, value interface{}) bool {
 		maintainer := value.(*Maintainer)
 		status := maintainer.GetMaintainerStatus()
@@ -215,12 +243,17 @@ func (m *Manager) onCoordinatorBootstrapRequest(msg *messaging.TargetMessage) {
 		zap.Int64("version", m.coordinatorVersion))
 }
 
-func (m *Manager) onAddMaintainerRequest(req *heartbeatpb.AddMaintainerRequest) *heartbeatpb.MaintainerStatus {
+func (m *Manager) onAddMaintainerRequest(
+	ctx context.Context, req *heartbeatpb.AddMaintainerRequest,
+) *heartbeatpb.MaintainerStatus {
 	cfID := common.NewChangefeedIDFromPB(req.Id)
 	_, ok := m.maintainers.Load(cfID)
 	if ok {
 		return nil
 	}
+	if _, ok = m.pendingUpstreams[cfID]; ok {
+		return nil
+	}
 
 	cfConfig := &config.ChangeFeedInfo{}
 	err := json.Unmarshal(req.Config, cfConfig)
@@ -233,16 +266,96 @@ func (m *Manager) onAddMaintainerRequest(req *heartbeatpb.AddMaintainerRequest)
 			zap.Uint64("checkpointTs", req.CheckpointTs),
 			zap.Any("config", cfConfig))
 	}
+	if cfConfig.UpstreamInfo != nil {
+		// connecting to a new upstream may take a long time,
+		// the maintainer is added after its stores are created.
+		m.pendingUpstreams[cfID] = req
+		go m.createUpstreamStores(ctx, req, cfConfig)
+		return nil
+	}
+	m.addMaintainer(cfID, req, cfConfig, m.pdAPI, m.tsoClient, m.regionCache)
+	return nil
+}
+
+// upstreamStoresResult is the result of creating the upstream stores for an add maintainer request.
+type upstreamStoresResult struct {
+	req      *heartbeatpb.AddMaintainerRequest
+	cfConfig *config.ChangeFeedInfo
+	stores   *upstreamstore.Stores
+	err      error
+}
+
+func (m *Manager) createUpstreamStores(
+	ctx context.Context, req *heartbeatpb.AddMaintainerRequest, cfConfig *config.ChangeFeedInfo,
+) {
+	upstreamStoreManager := appcontext.GetService[*upstreamstore.Manager](appcontext.UpstreamStoreManager)
+	stores, err := upstreamStoreManager.GetOrAddStores(ctx, cfConfig.UpstreamInfo)
+	select {
+	case <-ctx.Done():
+		if err == nil {
+			upstreamStoreManager.ReleaseStores(stores.UpstreamID)
+		}
+	case m.upstreamStoresCh <- &upstreamStoresResult{req: req, cfConfig: cfConfig, stores: stores, err: err}:
+	}
+}
+
+func (m *Manager) onUpstreamStoresCreated(result *upstreamStoresResult) {
+	cfID := common.NewChangefeedIDFromPB(result.req.Id)
+	if req, ok := m.pendingUpstreams[cfID]; !ok || req != result.req {
+		// the maintainer is removed when waiting for the stores
+		if result.err == nil {
+			appcontext.GetService[*upstreamstore.Manager](appcontext.UpstreamStoreManager).
+				ReleaseStores(result.stores.UpstreamID)
+		}
+		return
+	}
+	delete(m.pendingUpstreams, cfID)
+	if result.err != nil {
+		// report the error to coordinator, it will retry to add the maintainer later
+		log.Warn("get upstream stores failed",
+			zap.Stringer("changefeed", cfID),
+			zap.Uint64("upstreamID", result.cfConfig.UpstreamID),
+			zap.Error(result.err))
+		if m.isBootstrap() {
+			m.sendMessages(&heartbeatpb.MaintainerHeartbeat{
+				Statuses: []*heartbeatpb.MaintainerStatus{{
+					ChangefeedID: result.req.Id,
+					State:        heartbeatpb.ComponentState_Working,
+					CheckpointTs: result.req.CheckpointTs,
+					Err: []*heartbeatpb.RunningError{{
+						Time:    time.Now().String(),
+						Node:    m.selfNode.AdvertiseAddr,
+						Code:    string(errors.ErrUpstreamNotFound.RFCCode()),
+						Message: result.err.Error(),
+					}},
+				}},
+			})
+		}
+		return
+	}
+	stores := result.stores
+	m.addMaintainer(cfID, result.req, result.cfConfig, stores.PDAPIClient, stores.PDClient, stores.RegionCache)
+}
+
+func (m *Manager) addMaintainer(
+	cfID common.ChangeFeedID,
+	req *heartbeatpb.AddMaintainerRequest,
+	cfConfig *config.ChangeFeedInfo,
+	pdAPI pdutil.PDAPIClient,
+	tsoClient replica.TSOClient,
+	regionCache split.RegionCache,
+) {
 	maintainer := NewMaintainer(cfID, m.conf, cfConfig, m.selfNode, m.taskScheduler,
-		m.pdAPI, m.tsoClient, m.regionCache, req.CheckpointTs, req.IsNewChangefeed)
+		pdAPI, tsoClient, regionCache, req.CheckpointTs, req.IsNewChangefeed)
 	m.maintainers.Store(cfID, maintainer)
 	maintainer.pushEvent(&Event{changefeedID: cfID, eventType: EventInit})
-	return nil
 }
 
 func (m *Manager) onRemoveMaintainerRequest(msg *messaging.TargetMessage) *heartbeatpb.MaintainerStatus {
 	req := msg.Message[0].(*heartbeatpb.RemoveMaintainerRequest)
 	cfID := common.NewChangefeedIDFromPB(req.GetId())
+	// the stores of the upstream are released when they are created
+	delete(m.pendingUpstreams, cfID)
 	cf, ok := m.maintainers.Load(cfID)
 	if !ok {
 		if !req.Cascade {
@@ -272,7 +385,7 @@ func (m *Manager) onRemoveMaintainerRequest(msg *messaging.TargetMessage) *heart
 }
 
 func (m *Manager) onDispatchMaintainerRequest(
-	msg *messaging.TargetMessage,
+	ctx context.Context, msg *messaging.TargetMessage,
 ) *heartbeatpb.MaintainerStatus {
 	if m.coordinatorID != msg.From {
 		log.Warn("ignore invalid coordinator id",
@@ -284,7 +397,7 @@ func (m *Manager) onDispatchMaintainerRequest(
 	switch msg.Type {
 	case messaging.TypeAddMaintainerRequest:
 		req := msg.Message[0].(*heartbeatpb.AddMaintainerRequest)
-		return m.onAddMaintainerRequest(req)
+		return m.onAddMaintainerRequest(ctx, req)
 	case messaging.TypeRemoveMaintainerRequest:
 		return m.onRemoveMaintainerRequest(msg)
 	default:
@@ -293,6 +406,21 @@ func (m *Manager) onDispatchMaintainerRequest(
 	return nil
 }
 
+// onDrainNodeRequest marks the draining nodes as unschedulable, so the maintainers on this node
+// move the dispatchers away from them and do not schedule new dispatchers to them.
+func (m *Manager) onDrainNodeRequest(req *heartbeatpb.DrainNodeRequest) {
+	ids := make([]node.ID, 0, len(req.NodeIds))
+	for _, id := range req.NodeIds {
+		ids = append(ids, node.ID(id))
+	}
+	nodeManager := appcontext.GetService[*watcher.NodeManager](watcher.NodeManagerName)
+	nodeManager.SetUnschedulableNodes(ids, req.Epoch)
+	log.Info("received drain node request",
+		zap.Stringer("node", m.selfNode.ID),
+		zap.Strings("drainingNodes", req.NodeIds),
+		zap.Uint64("epoch", req.Epoch))
+}
+
 func (m *Manager) sendHeartbeat() {
 	if m.isBootstrap() {
 		response := &heartbeatpb.MaintainerHeartbeat{}
@@ -313,7 +441,7 @@ func (m *Manager) sendHeartbeat() {
 	}
 }
 
-func (m *Manager) handleMessage(msg *messaging.TargetMessage) {
+func (m *Manager) handleMessage(ctx context.Context, msg *messaging.TargetMessage) {
 	switch msg.Type {
 	case messaging.TypeCoordinatorBootstrapRequest:
 		log.Info("received coordinator bootstrap request", zap.String("from", msg.From.String()))
@@ -321,7 +449,7 @@ func (m *Manager) handleMessage(msg *messaging.TargetMessage) {
 	case messaging.TypeAddMaintainerRequest,
 		messaging.TypeRemoveMaintainerRequest:
 		if m.isBootstrap() {
-			status := m.onDispatchMaintainerRequest(msg)
+			status := m.onDispatchMaintainerRequest(ctx, msg)
 			if status == nil {
 				return
 			}
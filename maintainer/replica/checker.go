@@ -14,8 +14,10 @@
 package replica
 
 import (
+	"bytes"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -43,6 +45,9 @@ const (
 	HotSpanScoreThreshold = 3           // TODO: bump to 10 befroe release
 	DefaultScoreThreshold = 3
 
+	ColdSpanWriteThreshold = 128 * 1024 // 128KB per second
+	ColdSpanScoreThreshold = 10
+
 	defaultHardImbalanceThreshold = float64(1.35) // used to trigger the rebalance
 	clearTimeout                  = 300           // seconds
 )
@@ -81,7 +86,7 @@ func getNewGroupChecker(
 		case replica.GroupDefault:
 			return newHotSpanChecker(cfID)
 		case replica.GroupTable:
-			return newImbalanceChecker(cfID)
+			return newDynamicMergeSplitChecker(cfID)
 		}
 		log.Panic("unknown group type", zap.String("changefeed", cfID.Name()), zap.Int8("groupType", int8(groupType)))
 		return nil
@@ -365,9 +370,175 @@ func (s *rebalanceChecker) Stat() string {
 	return res.String()
 }
 
-// TODO: implement the dynamic merge and split checker
+// dynamicMergeSplitChecker is used to check the spans of a split table.
+// Besides the table level rebalance, it tracks the write throughput of every span,
+// splits the hot spans and merges the adjacent cold spans, so the number of dispatchers
+// of the table can shrink back after a write burst.
 type dynamicMergeSplitChecker struct {
-	changefeedID common.ChangeFeedID
-	allTasks     map[common.DispatcherID]*hotSpanStatus
-	nodeManager  *watcher.NodeManager
+	*rebalanceChecker
+
+	spanLoads map[common.DispatcherID]*spanLoadStatus
+
+	hotWriteThreshold  float32
+	hotScoreThreshold  int
+	coldWriteThreshold float32
+	coldScoreThreshold int
+}
+
+func newDynamicMergeSplitChecker(cfID common.ChangeFeedID) *dynamicMergeSplitChecker {
+	return &dynamicMergeSplitChecker{
+		rebalanceChecker:   newImbalanceChecker(cfID),
+		spanLoads:          make(map[common.DispatcherID]*spanLoadStatus),
+		hotWriteThreshold:  HotSpanWriteThreshold,
+		hotScoreThreshold:  HotSpanScoreThreshold,
+		coldWriteThreshold: ColdSpanWriteThreshold,
+		coldScoreThreshold: ColdSpanScoreThreshold,
+	}
+}
+
+func (s *dynamicMergeSplitChecker) Name() string {
+	return "dynamic merge and split checker"
+}
+
+func (s *dynamicMergeSplitChecker) AddReplica(replica *SpanReplication) {
+	s.rebalanceChecker.AddReplica(replica)
+	s.spanLoads[replica.ID] = &spanLoadStatus{
+		SpanReplication: replica,
+	}
+}
+
+func (s *dynamicMergeSplitChecker) RemoveReplica(replica *SpanReplication) {
+	s.rebalanceChecker.RemoveReplica(replica)
+	delete(s.spanLoads, replica.ID)
+}
+
+func (s *dynamicMergeSplitChecker) UpdateStatus(replica *SpanReplication) {
+	s.rebalanceChecker.UpdateStatus(replica)
+	span := s.spanLoads[replica.ID]
+
+	status := replica.GetStatus()
+	if status.ComponentStatus != heartbeatpb.ComponentState_Working {
+		span.hotScore, span.coldScore = 0, 0
+		return
+	}
+
+	if status.EventSizePerSecond >= s.hotWriteThreshold {
+		span.hotScore++
+	} else {
+		span.hotScore = max(span.hotScore-1, 0)
+	}
+	if status.EventSizePerSecond < s.coldWriteThreshold {
+		span.coldScore++
+	} else {
+		span.coldScore = 0
+	}
+}
+
+// Check returns the table level rebalance result first, if the table doesn't need to rebalance,
+// it returns the hot spans to split and the adjacent cold spans to merge.
+func (s *dynamicMergeSplitChecker) Check(batchSize int) replica.GroupCheckResult {
+	if ret, ok := s.rebalanceChecker.Check(batchSize).([]CheckResult); ok && len(ret) > 0 {
+		return ret
+	}
+
+	spans := make([]*spanLoadStatus, 0, len(s.spanLoads))
+	for _, span := range s.spanLoads {
+		if span.GetStatus().ComponentStatus != heartbeatpb.ComponentState_Working {
+			return nil
+		}
+		spans = append(spans, span)
+	}
+
+	results := s.checkSplit(spans, batchSize)
+	if len(results) < batchSize {
+		results = append(results, s.checkMerge(spans, batchSize-len(results))...)
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	return results
+}
+
+// checkSplit returns the spans which are hot for a period of time.
+func (s *dynamicMergeSplitChecker) checkSplit(spans []*spanLoadStatus, batchSize int) []CheckResult {
+	var results []CheckResult
+	for _, span := range spans {
+		if len(results) >= batchSize {
+			break
+		}
+		if span.hotScore >= s.hotScoreThreshold {
+			results = append(results, CheckResult{
+				OpType:       OpSplit,
+				Replications: []*SpanReplication{span.SpanReplication},
+			})
+		}
+	}
+	return results
+}
+
+// checkMerge returns the groups of adjacent spans which are cold for a period of time,
+// the total write throughput of each group is lower than the hot threshold,
+// so the merged span will not be split again immediately.
+func (s *dynamicMergeSplitChecker) checkMerge(spans []*spanLoadStatus, batchSize int) []CheckResult {
+	sort.Slice(spans, func(i, j int) bool {
+		return bytes.Compare(spans[i].Span.StartKey, spans[j].Span.StartKey) < 0
+	})
+
+	var results []CheckResult
+	var candidates []*SpanReplication
+	totalEventSizePerSecond := float32(0)
+	flush := func() {
+		if len(candidates) > 1 {
+			results = append(results, CheckResult{
+				OpType:       OpMerge,
+				Replications: candidates,
+			})
+		}
+		candidates = nil
+		totalEventSizePerSecond = 0
+	}
+	for _, span := range spans {
+		if len(results) >= batchSize {
+			break
+		}
+		if span.coldScore < s.coldScoreThreshold {
+			flush()
+			continue
+		}
+		eventSizePerSecond := span.GetStatus().EventSizePerSecond
+		if len(candidates) > 0 {
+			last := candidates[len(candidates)-1]
+			if !bytes.Equal(last.Span.EndKey, span.Span.StartKey) ||
+				totalEventSizePerSecond+eventSizePerSecond >= s.hotWriteThreshold {
+				flush()
+			}
+		}
+		candidates = append(candidates, span.SpanReplication)
+		totalEventSizePerSecond += eventSizePerSecond
+	}
+	if len(results) < batchSize {
+		flush()
+	}
+	return results
+}
+
+func (s *dynamicMergeSplitChecker) Stat() string {
+	hot, cold := 0, 0
+	for _, span := range s.spanLoads {
+		if span.hotScore >= s.hotScoreThreshold {
+			hot++
+		}
+		if span.coldScore >= s.coldScoreThreshold {
+			cold++
+		}
+	}
+	return fmt.Sprintf("%s; hot spans: %d; cold spans: %d", s.rebalanceChecker.Stat(), hot, cold)
+}
+
+type spanLoadStatus struct {
+	*SpanReplication
+	// hotScore add 1 when the eventSizePerSecond is larger than hotWriteThreshold, and minus 1 otherwise
+	hotScore int
+	// coldScore add 1 when the eventSizePerSecond is lower than coldWriteThreshold, and reset to 0 otherwise
+	coldScore int
 }
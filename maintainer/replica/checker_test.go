@@ -129,7 +129,7 @@ func TestRebalanceChecker(t *testing.T) {
 	for _, r := range allReplicas {
 		require.Equal(t, groupID, r.GetGroupID())
 	}
-	checker := db.GetGroupChecker(groupID).(*rebalanceChecker)
+	checker := db.GetGroupChecker(groupID).(*dynamicMergeSplitChecker).rebalanceChecker
 	require.Equal(t, 4, len(checker.allTasks))
 
 	// test update replica status
@@ -224,7 +224,7 @@ func TestSoftRebalanceChecker(t *testing.T) {
 		db.AddReplicatingSpan(replicating)
 	}
 
-	checker := db.GetGroupChecker(groupID).(*rebalanceChecker)
+	checker := db.GetGroupChecker(groupID).(*dynamicMergeSplitChecker).rebalanceChecker
 	require.Equal(t, 4, len(checker.allTasks))
 
 	// test soft rebalance
@@ -268,3 +268,84 @@ func TestSoftRebalanceChecker(t *testing.T) {
 	require.Equal(t, OpMerge, ret.OpType)
 	require.Equal(t, 4, len(ret.Replications))
 }
+
+// Not parallel because it will change the global node manager
+func TestDynamicMergeSplitChecker(t *testing.T) {
+	oldMinSpanNumberCoefficient := MinSpanNumberCoefficient
+	MinSpanNumberCoefficient = 1
+	defer func() {
+		MinSpanNumberCoefficient = oldMinSpanNumberCoefficient
+	}()
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	nodeManager.GetAliveNodes()["node0"] = &node.Info{ID: "node0"}
+	appcontext.SetService(watcher.NodeManagerName, nodeManager)
+
+	db := newDBWithCheckerForTest(t)
+	totalSpan := getTableSpanByID(4)
+	partialSpans := []*heartbeatpb.TableSpan{
+		{StartKey: totalSpan.StartKey, EndKey: appendNew(totalSpan.StartKey, 'a')},
+		{StartKey: appendNew(totalSpan.StartKey, 'a'), EndKey: appendNew(totalSpan.StartKey, 'b')},
+		{StartKey: appendNew(totalSpan.StartKey, 'b'), EndKey: appendNew(totalSpan.StartKey, 'c')},
+		{StartKey: appendNew(totalSpan.StartKey, 'c'), EndKey: totalSpan.EndKey},
+	}
+	allReplicas := make([]*SpanReplication, 0, len(partialSpans))
+	for _, span := range partialSpans {
+		replicating := NewWorkingReplicaSet(db.changefeedID, common.NewDispatcherID(), db.ddlSpan.tsoClient, 1, span,
+			&heartbeatpb.TableSpanStatus{
+				CheckpointTs:    9,
+				ComponentStatus: heartbeatpb.ComponentState_Working,
+			}, "node0")
+		allReplicas = append(allReplicas, replicating)
+		db.AddReplicatingSpan(replicating)
+	}
+	checker := db.GetGroupChecker(allReplicas[0].GetGroupID()).(*dynamicMergeSplitChecker)
+	require.Equal(t, 4, len(checker.spanLoads))
+
+	// the last span is hot, and the others are cold
+	updateStatus := func(times int) {
+		for i := 0; i < times; i++ {
+			for idx, r := range allReplicas {
+				eventSizePerSecond := float32(0)
+				if idx == len(allReplicas)-1 {
+					eventSizePerSecond = 4 * HotSpanWriteThreshold
+				}
+				db.UpdateStatus(r, &heartbeatpb.TableSpanStatus{
+					CheckpointTs:       9,
+					ComponentStatus:    heartbeatpb.ComponentState_Working,
+					EventSizePerSecond: eventSizePerSecond,
+				})
+			}
+		}
+	}
+
+	// test split hot span
+	updateStatus(HotSpanScoreThreshold)
+	rets := checker.Check(20).([]CheckResult)
+	require.Equal(t, 1, len(rets))
+	require.Equal(t, OpSplit, rets[0].OpType)
+	require.Equal(t, []*SpanReplication{allReplicas[3]}, rets[0].Replications)
+
+	// test merge adjacent cold spans
+	updateStatus(ColdSpanScoreThreshold - HotSpanScoreThreshold)
+	rets = checker.Check(20).([]CheckResult)
+	require.Equal(t, 2, len(rets))
+	require.Equal(t, OpSplit, rets[0].OpType)
+	require.Equal(t, OpMerge, rets[1].OpType)
+	require.Equal(t, allReplicas[:3], rets[1].Replications)
+	require.Equal(t, 1, len(checker.Check(1).([]CheckResult)))
+
+	// the cold spans are not adjacent any more
+	db.UpdateStatus(allReplicas[1], &heartbeatpb.TableSpanStatus{
+		CheckpointTs:       9,
+		ComponentStatus:    heartbeatpb.ComponentState_Working,
+		EventSizePerSecond: ColdSpanWriteThreshold,
+	})
+	rets = checker.Check(20).([]CheckResult)
+	require.Equal(t, 1, len(rets))
+	require.Equal(t, OpSplit, rets[0].OpType)
+
+	// test remove
+	db.ReplaceReplicaSet(allReplicas, nil, 10)
+	require.Equal(t, 0, len(checker.spanLoads))
+	require.Equal(t, 0, len(checker.allTasks))
+}
@@ -40,6 +40,9 @@ type ReplicationDB struct {
 	replica.ReplicationDB[common.DispatcherID, *SpanReplication]
 
 	ddlSpan *SpanReplication
+	// eventSizePerSecond is the sum of the write throughput of all tasks, it's
+	// updated when the tasks are added, removed or report their status
+	eventSizePerSecond float64
 	// LOCK protects the above maps
 	lock            sync.RWMutex
 	newGroupChecker func(groupID replica.GroupID) replica.GroupChecker[common.DispatcherID, *SpanReplication]
@@ -238,7 +241,7 @@ func (db *ReplicationDB) ReplaceReplicaSet(oldReplications []*SpanReplication, n
 func (db *ReplicationDB) AddReplicatingSpan(span *SpanReplication) {
 	db.lock.Lock()
 	defer db.lock.Unlock()
-	db.allTasks[span.ID] = span
+	db.putTaskUnLock(span)
 	db.addToSchemaAndTableMap(span)
 	db.AddReplicatingWithoutLock(span)
 }
@@ -320,14 +323,26 @@ func (db *ReplicationDB) UpdateSchemaID(tableID, newSchemaID int64) {
 }
 
 func (db *ReplicationDB) UpdateStatus(span *SpanReplication, status *heartbeatpb.TableSpanStatus) {
+	oldLoad := span.GetLoad()
 	span.UpdateStatus(status)
 	checker := db.GetGroupChecker(span.GetGroupID()) // Note: need RLock here
 
 	db.lock.Lock()
 	defer db.lock.Unlock()
+	// the span removed in the meantime is already subtracted with its latest load
+	if db.allTasks[span.ID] == span {
+		db.eventSizePerSecond += span.GetLoad() - oldLoad
+	}
 	checker.UpdateStatus(span)
 }
 
+// GetEventSizePerSecond returns the sum of the write throughput of all tasks
+func (db *ReplicationDB) GetEventSizePerSecond() float64 {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+	return max(db.eventSizePerSecond, 0)
+}
+
 // BindSpanToNode binds the span to new node, it will remove the span from the old node and add it to the new node
 // It also marks the span as scheduling.
 func (db *ReplicationDB) BindSpanToNode(old, new node.ID, span *SpanReplication) {
@@ -339,7 +354,7 @@ func (db *ReplicationDB) BindSpanToNode(old, new node.ID, span *SpanReplication)
 // addAbsentReplicaSetUnLock adds spans to absent map
 func (db *ReplicationDB) addAbsentReplicaSetUnLock(spans ...*SpanReplication) {
 	for _, span := range spans {
-		db.allTasks[span.ID] = span
+		db.putTaskUnLock(span)
 		db.AddAbsentWithoutLock(span)
 		db.addToSchemaAndTableMap(span)
 	}
@@ -360,10 +375,22 @@ func (db *ReplicationDB) removeSpanUnLock(spans ...*SpanReplication) {
 		if len(db.tableTasks[tableID]) == 0 {
 			delete(db.tableTasks, tableID)
 		}
-		delete(db.allTasks, span.ID)
+		if _, ok := db.allTasks[span.ID]; ok {
+			db.eventSizePerSecond -= span.GetLoad()
+			delete(db.allTasks, span.ID)
+		}
 	}
 }
 
+// putTaskUnLock puts the span to the allTasks map and counts its throughput
+func (db *ReplicationDB) putTaskUnLock(span *SpanReplication) {
+	if old, ok := db.allTasks[span.ID]; ok {
+		db.eventSizePerSecond -= old.GetLoad()
+	}
+	db.allTasks[span.ID] = span
+	db.eventSizePerSecond += span.GetLoad()
+}
+
 // addToSchemaAndTableMap adds the span to the schema and table map
 func (db *ReplicationDB) addToSchemaAndTableMap(span *SpanReplication) {
 	tableID := span.Span.TableID
@@ -411,13 +438,14 @@ func (db *ReplicationDB) reset() {
 	db.schemaTasks = make(map[int64]map[common.DispatcherID]*SpanReplication)
 	db.tableTasks = make(map[int64]map[common.DispatcherID]*SpanReplication)
 	db.allTasks = make(map[common.DispatcherID]*SpanReplication)
+	db.eventSizePerSecond = 0
 	db.ReplicationDB = replica.NewReplicationDB[common.DispatcherID, *SpanReplication](db.changefeedID.String(),
 		db.withRLock, db.newGroupChecker)
 }
 
 func (db *ReplicationDB) putDDLDispatcher(ddlSpan *SpanReplication) {
 	// we don't need to schedule the ddl span, but added it to the allTasks map, so we can query it by id
-	db.allTasks[ddlSpan.ID] = ddlSpan
+	db.putTaskUnLock(ddlSpan)
 	// dispatcher will report a block event with table ID 0,
 	// so we need to add it to the table map
 	db.tableTasks[ddlSpan.Span.TableID] = map[common.DispatcherID]*SpanReplication{
@@ -173,6 +173,42 @@ func TestForceRemove(t *testing.T) {
 	require.Len(t, db.GetAllTasks(), 1)
 }
 
+func TestEventSizePerSecond(t *testing.T) {
+	t.Parallel()
+
+	db := newDBWithCheckerForTest(t)
+	newStatus := func(id common.DispatcherID, checkpointTs uint64, eventSize float32) *heartbeatpb.TableSpanStatus {
+		return &heartbeatpb.TableSpanStatus{
+			ID:                 id.ToPB(),
+			ComponentStatus:    heartbeatpb.ComponentState_Working,
+			CheckpointTs:       checkpointTs,
+			EventSizePerSecond: eventSize,
+		}
+	}
+	span1ID, span2ID := common.NewDispatcherID(), common.NewDispatcherID()
+	span1 := NewWorkingReplicaSet(db.changefeedID, span1ID, db.ddlSpan.tsoClient, 1,
+		getTableSpanByID(3), newStatus(span1ID, 1, 100), "node1")
+	span2 := NewWorkingReplicaSet(db.changefeedID, span2ID, db.ddlSpan.tsoClient, 1,
+		getTableSpanByID(4), newStatus(span2ID, 1, 0), "node2")
+	db.AddReplicatingSpan(span1)
+	db.AddReplicatingSpan(span2)
+	require.Equal(t, 100.0, db.GetEventSizePerSecond())
+
+	db.UpdateStatus(span2, newStatus(span2ID, 2, 50))
+	require.Equal(t, 150.0, db.GetEventSizePerSecond())
+	db.UpdateStatus(span1, newStatus(span1ID, 2, 20))
+	require.Equal(t, 70.0, db.GetEventSizePerSecond())
+
+	// the removed span is not counted any more, even if it reports the status later
+	db.ForceRemove(span1ID)
+	require.Equal(t, 50.0, db.GetEventSizePerSecond())
+	db.UpdateStatus(span1, newStatus(span1ID, 3, 200))
+	require.Equal(t, 50.0, db.GetEventSizePerSecond())
+
+	db.TryRemoveAll()
+	require.Equal(t, 0.0, db.GetEventSizePerSecond())
+}
+
 func TestGetAbsents(t *testing.T) {
 	t.Parallel()
 
@@ -151,6 +151,11 @@ func (r *SpanReplication) GetStatus() *heartbeatpb.TableSpanStatus {
 	return r.status.Load()
 }
 
+// GetLoad returns the write throughput of the span, it's used by the load balance strategy.
+func (r *SpanReplication) GetLoad() float64 {
+	return float64(r.GetStatus().GetEventSizePerSecond())
+}
+
 func (r *SpanReplication) UpdateStatus(newStatus *heartbeatpb.TableSpanStatus) {
 	if newStatus != nil {
 		// we don't update the status when there exist block status and block status's checkpointTs is less than newStatus's checkpointTs
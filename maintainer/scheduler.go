@@ -14,6 +14,7 @@
 package maintainer
 
 import (
+	"bytes"
 	"context"
 	"time"
 
@@ -37,11 +38,13 @@ func NewScheduleController(changefeedID common.ChangeFeedID,
 	db *replica.ReplicationDB,
 	nodeM *watcher.NodeManager,
 	balanceInterval time.Duration,
+	nodeLoads scheduler.NodeLoadProvider,
 	splitter *split.Splitter,
 ) *scheduler.Controller {
 	schedulers := map[string]scheduler.Scheduler{
 		scheduler.BasicScheduler:   scheduler.NewBasicScheduler(changefeedID.String(), batchSize, oc, db, nodeM, oc.NewAddOperator),
-		scheduler.BalanceScheduler: scheduler.NewBalanceScheduler(changefeedID.String(), batchSize, oc, db, nodeM, balanceInterval, oc.NewMoveOperator),
+		scheduler.BalanceScheduler: scheduler.NewBalanceScheduler(changefeedID.String(), batchSize, oc, db, nodeM, balanceInterval, nodeLoads, oc.NewMoveOperator),
+		scheduler.DrainScheduler:   scheduler.NewDrainScheduler(changefeedID.String(), batchSize, oc, db, nodeM, oc.NewMoveOperator),
 	}
 	if splitter != nil {
 		schedulers[scheduler.SplitScheduler] = newSplitScheduler(changefeedID, batchSize, splitter, oc, db, nodeM, balanceInterval)
@@ -131,6 +134,9 @@ func (s *splitScheduler) doCheck(ret pkgReplica.GroupCheckResult, start time.Tim
 
 		switch ret.OpType {
 		case replica.OpMerge:
+			if !s.shouldMerge(totalSpan) {
+				continue
+			}
 			s.opController.AddMergeSplitOperator(ret.Replications, []*heartbeatpb.TableSpan{totalSpan})
 		case replica.OpSplit:
 			fallthrough
@@ -149,6 +155,7 @@ func (s *splitScheduler) doCheck(ret pkgReplica.GroupCheckResult, start time.Tim
 	return checkedIndex, false
 }
 
+// valid checks the check result and returns the span covered by all the replications of the result.
 func (s *splitScheduler) valid(c replica.CheckResult) (*heartbeatpb.TableSpan, bool) {
 	if c.OpType == replica.OpSplit && len(c.Replications) != 1 {
 		log.Panic("split operation should have only one replication",
@@ -156,37 +163,97 @@ func (s *splitScheduler) valid(c replica.CheckResult) (*heartbeatpb.TableSpan, b
 			zap.Int64("tableId", c.Replications[0].Span.TableID),
 			zap.Stringer("checkResult", c))
 	}
-	span := spanz.TableIDToComparableSpan(c.Replications[0].Span.TableID)
+	tableID := c.Replications[0].Span.TableID
+
+	if c.OpType == replica.OpSplit {
+		if len(s.db.GetTasksByTableID(tableID)) >= split.DefaultMaxSpanNumber {
+			log.Debug("skip split operation since the span number of the table is too large",
+				zap.String("changefeed", s.changefeedID.Name()),
+				zap.Int64("tableId", tableID), zap.Stringer("checkResult", c))
+			return nil, false
+		}
+		span := c.Replications[0].Span
+		return &heartbeatpb.TableSpan{
+			TableID:  span.TableID,
+			StartKey: span.StartKey,
+			EndKey:   span.EndKey,
+		}, true
+	}
+
+	if len(c.Replications) <= 1 {
+		log.Panic("invalid replication size",
+			zap.String("changefeed", s.changefeedID.Name()),
+			zap.Int64("tableId", tableID),
+			zap.Stringer("checkResult", c))
+	}
+	spanMap := utils.NewBtreeMap[*heartbeatpb.TableSpan, *replica.SpanReplication](heartbeatpb.LessTableSpan)
+	for _, r := range c.Replications {
+		spanMap.ReplaceOrInsert(r.Span, r)
+	}
+	span := spanz.TableIDToComparableSpan(tableID)
 	totalSpan := &heartbeatpb.TableSpan{
 		TableID:  span.TableID,
 		StartKey: span.StartKey,
 		EndKey:   span.EndKey,
 	}
-
-	if c.OpType == replica.OpMerge || c.OpType == replica.OpMergeAndSplit {
-		if len(c.Replications) <= 1 {
-			log.Panic("invalid replication size",
-				zap.String("changefeed", s.changefeedID.Name()),
-				zap.Int64("tableId", c.Replications[0].Span.TableID),
-				zap.Stringer("checkResult", c))
-		}
-		spanMap := utils.NewBtreeMap[*heartbeatpb.TableSpan, *replica.SpanReplication](heartbeatpb.LessTableSpan)
-		for _, r := range c.Replications {
-			spanMap.ReplaceOrInsert(r.Span, r)
+	if c.OpType == replica.OpMerge {
+		// The replications of a merge operation may only cover a part of the table,
+		// so we check the holes in the range from the first span to the last span.
+		var first, last *heartbeatpb.TableSpan
+		spanMap.Ascend(func(span *heartbeatpb.TableSpan, _ *replica.SpanReplication) bool {
+			if first == nil {
+				first = span
+			}
+			last = span
+			return true
+		})
+		totalSpan.StartKey, totalSpan.EndKey = first.StartKey, last.EndKey
+	}
+	holes := split.FindHoles(spanMap, totalSpan)
+	if len(holes) == 0 && c.OpType == replica.OpMerge {
+		// A partial merge is only safe when the whole table is covered,
+		// the missing spans may be in flight, e.g. being moved or split.
+		tableSpanMap := utils.NewBtreeMap[*heartbeatpb.TableSpan, *replica.SpanReplication](heartbeatpb.LessTableSpan)
+		for _, r := range s.db.GetTasksByTableID(tableID) {
+			tableSpanMap.ReplaceOrInsert(r.Span, r)
 		}
-		holes := split.FindHoles(spanMap, totalSpan)
+		holes = split.FindHoles(tableSpanMap, &heartbeatpb.TableSpan{
+			TableID:  span.TableID,
+			StartKey: span.StartKey,
+			EndKey:   span.EndKey,
+		})
+	}
+	if len(holes) != 0 {
 		log.Warn("skip merge operation since there are holes",
 			zap.String("changefeed", s.changefeedID.Name()),
-			zap.Int64("tableId", c.Replications[0].Span.TableID),
+			zap.Int64("tableId", tableID),
 			zap.Int("holes", len(holes)), zap.Stringer("checkResult", c))
-		return totalSpan, len(holes) == 0
+		return totalSpan, false
 	}
 
 	if c.OpType == replica.OpMergeAndSplit && len(c.Replications) >= split.DefaultMaxSpanNumber {
 		log.Debug("skip split operation since the replication number is too large",
 			zap.String("changefeed", s.changefeedID.Name()),
-			zap.Int64("tableId", c.Replications[0].Span.TableID), zap.Stringer("checkResult", c))
+			zap.Int64("tableId", tableID), zap.Stringer("checkResult", c))
 		return totalSpan, false
 	}
 	return totalSpan, true
 }
+
+// shouldMerge returns false if the merged span only covers a part of the table,
+// and it would be split again by the splitter, e.g. it covers too many regions.
+func (s *splitScheduler) shouldMerge(mergedSpan *heartbeatpb.TableSpan) bool {
+	tableSpan := spanz.TableIDToComparableSpan(mergedSpan.TableID)
+	if bytes.Equal(mergedSpan.StartKey, tableSpan.StartKey) && bytes.Equal(mergedSpan.EndKey, tableSpan.EndKey) {
+		return true
+	}
+	spans := s.splitter.SplitSpans(context.Background(), mergedSpan, len(s.nodeManager.GetAliveNodes()), 1)
+	if len(spans) > 1 {
+		log.Info("skip merge operation since the merged span will be split again",
+			zap.String("changefeed", s.changefeedID.Name()),
+			zap.String("span", mergedSpan.String()),
+			zap.Int("spanSize", len(spans)))
+		return false
+	}
+	return true
+}
@@ -15,6 +15,7 @@ package v2
 
 import (
 	"context"
+	"fmt"
 
 	v2 "github.com/pingcap/ticdc/api/v2"
 	"github.com/pingcap/ticdc/pkg/api/internal/rest"
@@ -29,6 +30,8 @@ type CapturesGetter interface {
 // We can also mock the capture operations by implement this interface.
 type CaptureInterface interface {
 	List(ctx context.Context) ([]v2.Capture, error)
+	Drain(ctx context.Context, captureID string) (*v2.DrainCaptureResponse, error)
+	Undrain(ctx context.Context, captureID string) error
 }
 
 // captures implements CaptureInterface
@@ -52,3 +55,22 @@ func (c *captures) List(ctx context.Context) ([]v2.Capture, error) {
 		Into(result)
 	return result.Items, err
 }
+
+// Drain marks a capture as unschedulable and moves its workload away
+func (c *captures) Drain(ctx context.Context, captureID string) (*v2.DrainCaptureResponse, error) {
+	result := &v2.DrainCaptureResponse{}
+	u := fmt.Sprintf("captures/%s/drain", captureID)
+	err := c.client.Put().
+		WithURI(u).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+// Undrain clears the unschedulable mark of a capture
+func (c *captures) Undrain(ctx context.Context, captureID string) error {
+	u := fmt.Sprintf("captures/%s/drain", captureID)
+	return c.client.Delete().
+		WithURI(u).
+		Do(ctx).Error()
+}
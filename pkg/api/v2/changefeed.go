@@ -48,6 +48,8 @@ type ChangefeedInterface interface {
 	Get(ctx context.Context, namespace string, name string) (*v2.ChangeFeedInfo, error)
 	// List lists all changefeeds
 	List(ctx context.Context, namespace string, state string) ([]v2.ChangefeedCommonInfo, error)
+	// SetBDRRole sets the bdr role of a changefeed
+	SetBDRRole(ctx context.Context, namespace string, name string, role string) (*v2.ChangeFeedInfo, error)
 	// Move Table to target node, it just for make test case now. **Not for public use.**
 	MoveTable(ctx context.Context, namespace string, name string, tableID int64, targetNode string) error
 }
@@ -161,6 +163,20 @@ func (c *changefeeds) List(ctx context.Context,
 	return result.Items, err
 }
 
+// SetBDRRole sets the bdr role of a changefeed
+func (c *changefeeds) SetBDRRole(ctx context.Context,
+	namespace string, name string, role string,
+) (*v2.ChangeFeedInfo, error) {
+	result := &v2.ChangeFeedInfo{}
+	u := fmt.Sprintf("changefeeds/%s/bdr_role?namespace=%s", name, namespace)
+	err := c.client.Put().
+		WithURI(u).
+		WithBody(&v2.BDRRoleConfig{BDRRole: role}).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
 // MoveTable to target node, it just for make test case now. **Not for public use.**
 func (c *changefeeds) MoveTable(ctx context.Context,
 	namespace string, name string, tableID int64, targetNode string,
@@ -0,0 +1,187 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/redo"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
+	"go.uber.org/zap"
+)
+
+// defaultMaxBatchEvents is the max number of dml events flushed to the downstream in one batch.
+const defaultMaxBatchEvents = 256
+
+// RedoApplierConfig is the configuration used by a redo log applier
+type RedoApplierConfig struct {
+	// SinkURI is the uri of the downstream, only mysql-compatible downstream is supported.
+	SinkURI string
+	// Storage is the uri of the redo log storage.
+	Storage string
+}
+
+// RedoApplier reads the redo logs and applies them to the downstream,
+// which restores the downstream to the consistent snapshot at the redo resolvedTs.
+type RedoApplier struct {
+	cfg *RedoApplierConfig
+	// newMysqlConfigAndDB creates the config and the connection of the downstream.
+	newMysqlConfigAndDB func(ctx context.Context, changefeedID common.ChangeFeedID,
+		sinkURI *url.URL, config *config.ChangefeedConfig) (*mysql.MysqlConfig, *sql.DB, error)
+}
+
+// NewRedoApplier creates a new RedoApplier instance
+func NewRedoApplier(cfg *RedoApplierConfig) *RedoApplier {
+	return &RedoApplier{cfg: cfg, newMysqlConfigAndDB: mysql.NewMysqlConfigAndDB}
+}
+
+// NewRedoApplierForTest creates a RedoApplier which applies the redo logs to the given db.
+func NewRedoApplierForTest(cfg *RedoApplierConfig, db *sql.DB) *RedoApplier {
+	ra := NewRedoApplier(cfg)
+	ra.newMysqlConfigAndDB = func(_ context.Context, changefeedID common.ChangeFeedID,
+		sinkURI *url.URL, config *config.ChangefeedConfig,
+	) (*mysql.MysqlConfig, *sql.DB, error) {
+		cfg, err := mysql.NewMySQLConfig(changefeedID, sinkURI, config)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cfg, db, nil
+	}
+	return ra
+}
+
+// Apply applies all the redo logs between checkpointTs and resolvedTs to the downstream.
+func (ra *RedoApplier) Apply(ctx context.Context) error {
+	extStorage, err := redo.NewExternalStorage(ctx, ra.cfg.Storage)
+	if err != nil {
+		return err
+	}
+	defer extStorage.Close()
+
+	reader, err := redo.NewLogReader(ctx, extStorage)
+	if err != nil {
+		return err
+	}
+	checkpointTs, resolvedTs := reader.ReadMeta()
+	log.Info("apply redo log starts",
+		zap.String("storage", ra.cfg.Storage),
+		zap.Uint64("checkpointTs", checkpointTs),
+		zap.Uint64("resolvedTs", resolvedTs))
+
+	logs, err := reader.ReadLogs(ctx)
+	if err != nil {
+		return err
+	}
+
+	writer, db, err := ra.newMysqlWriter(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		writer.Close()
+		db.Close()
+	}()
+
+	if err = applyLogs(writer, logs); err != nil {
+		return err
+	}
+	log.Info("apply redo log finishes",
+		zap.Uint64("checkpointTs", checkpointTs),
+		zap.Uint64("resolvedTs", resolvedTs),
+		zap.Int("count", len(logs)))
+	return nil
+}
+
+// ReadMeta reads the checkpointTs and the resolvedTs of the redo logs.
+func (ra *RedoApplier) ReadMeta(ctx context.Context) (checkpointTs uint64, resolvedTs uint64, err error) {
+	extStorage, err := redo.NewExternalStorage(ctx, ra.cfg.Storage)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer extStorage.Close()
+
+	reader, err := redo.NewLogReader(ctx, extStorage)
+	if err != nil {
+		return 0, 0, err
+	}
+	checkpointTs, resolvedTs = reader.ReadMeta()
+	return checkpointTs, resolvedTs, nil
+}
+
+func (ra *RedoApplier) newMysqlWriter(ctx context.Context) (*mysql.MysqlWriter, *sql.DB, error) {
+	sinkURI, err := url.Parse(ra.cfg.SinkURI)
+	if err != nil {
+		return nil, nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	changefeedID := common.NewChangeFeedIDWithName("redo-applier")
+	cfConfig := &config.ChangefeedConfig{
+		SinkURI:    ra.cfg.SinkURI,
+		SinkConfig: config.GetDefaultReplicaConfig().Sink,
+	}
+	cfg, db, err := ra.newMysqlConfigAndDB(ctx, changefeedID, sinkURI, cfConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the events between checkpointTs and resolvedTs may be partially written
+	// to the downstream, so they must be written in safe mode.
+	cfg.SafeMode = true
+	cfg.SkipDDLTs = true
+	statistics := metrics.NewStatistics(changefeedID, "RedoApplier")
+	writer := mysql.NewMysqlWriter(ctx, db, cfg, changefeedID, statistics, mysql.ShouldFormatVectorType(db, cfg))
+	return writer, db, nil
+}
+
+// applyLogs writes the logs to the downstream in order.
+// Consecutive dml events are written in batches, and the events with the
+// same commitTs are always in the same batch to keep the transaction atomic.
+func applyLogs(writer *mysql.MysqlWriter, logs []*redo.RedoLog) error {
+	batch := make([]*commonEvent.DMLEvent, 0, defaultMaxBatchEvents)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := writer.Flush(batch); err != nil {
+			return err
+		}
+		batch = batch[:0]
+		return nil
+	}
+	for _, l := range logs {
+		switch l.Type {
+		case redo.RedoLogTypeRow:
+			if len(batch) >= defaultMaxBatchEvents && batch[len(batch)-1].CommitTs != l.DML.CommitTs {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+			batch = append(batch, l.DML)
+		case redo.RedoLogTypeDDL:
+			if err := flush(); err != nil {
+				return err
+			}
+			if err := writer.FlushDDLEvent(l.DDL); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
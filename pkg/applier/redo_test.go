@@ -0,0 +1,117 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/redo"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	predo "github.com/pingcap/tiflow/pkg/redo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyRedoLogs(t *testing.T) {
+	ctx := context.Background()
+	extStorage, err := redo.NewExternalStorage(ctx, fmt.Sprintf("file://%s", t.TempDir()))
+	require.NoError(t, err)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32))")
+	require.NotNil(t, job)
+
+	changefeedID := common.NewChangefeedID4Test("test", "redo-applier")
+	rowWriter := redo.NewFileWriter("w", changefeedID, predo.RedoRowLogFileType, extStorage, 1, "")
+	defer rowWriter.Close()
+	ddlWriter := redo.NewFileWriter("w", changefeedID, predo.RedoDDLLogFileType, extStorage, 1, "")
+	defer ddlWriter.Close()
+
+	dml1 := helper.DML2Event("test", "t", "insert into t values (1, 'a')")
+	dml1.CommitTs = 110
+	dml2 := helper.DML2Event("test", "t", "insert into t values (2, 'b')")
+	dml2.CommitTs = 130
+	// the event after the resolvedTs is not applied.
+	dml3 := helper.DML2Event("test", "t", "insert into t values (3, 'c')")
+	dml3.CommitTs = 200
+	require.NoError(t, rowWriter.WriteLogs(ctx, []*redo.RedoLog{
+		redo.NewRedoLogFromDMLEvent(dml1),
+		redo.NewRedoLogFromDMLEvent(dml2),
+		redo.NewRedoLogFromDMLEvent(dml3),
+	}))
+	require.NoError(t, ddlWriter.WriteLogs(ctx, []*redo.RedoLog{
+		redo.NewRedoLogFromDDLEvent(&commonEvent.DDLEvent{
+			Type:       byte(job.Type),
+			SchemaName: "test",
+			TableName:  "t",
+			Query:      "alter table t add column age int",
+			TableInfo:  helper.GetTableInfo(job),
+			FinishedTs: 120,
+			BlockedTables: &commonEvent.InfluencedTables{
+				InfluenceType: commonEvent.InfluenceTypeNormal,
+				TableIDs:      []int64{1},
+			},
+		}),
+	}))
+	meta := &redo.LogMeta{CheckpointTs: 100, ResolvedTs: 150}
+	data, err := meta.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, extStorage.WriteFile(ctx, redo.GetMetaFileName("w", changefeedID, "uuid"), data))
+
+	reader, err := redo.NewLogReader(ctx, extStorage)
+	require.NoError(t, err)
+	logs, err := reader.ReadLogs(ctx)
+	require.NoError(t, err)
+	require.Len(t, logs, 3)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	cfg := &mysql.MysqlConfig{
+		MaxAllowedPacket: int64(variable.DefMaxAllowedPacket),
+		SafeMode:         true,
+		SkipDDLTs:        true,
+	}
+	writer := mysql.NewMysqlWriter(ctx, db, cfg, changefeedID,
+		metrics.NewStatistics(changefeedID, "RedoApplier"), false)
+	defer writer.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("REPLACE INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "a").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	// no ddl ts is written after the ddl is executed.
+	mock.ExpectBegin()
+	mock.ExpectExec("USE `test`;").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("alter table t add column age int").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("REPLACE INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(2, "b").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, applyLogs(writer, logs))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
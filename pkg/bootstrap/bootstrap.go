@@ -62,7 +62,8 @@ func NewBootstrapper[T any](id string, newBootstrapMsg NewBootstrapMessageFn) *B
 func (b *Bootstrapper[T]) HandleNewNodes(nodes []*node.Info) []*messaging.TargetMessage {
 	msgs := make([]*messaging.TargetMessage, 0, len(nodes))
 	for _, info := range nodes {
-		if _, ok := b.nodes[info.ID]; !ok {
+		// A node restarted with the same id is treated as a new node, since all states on it are lost.
+		if status, ok := b.nodes[info.ID]; !ok || status.IsRestarted(info) {
 			// A new node is found, send a bootstrap message to it.
 			b.nodes[info.ID] = NewNodeStatus[T](info)
 			log.Info("find a new node",
@@ -232,4 +233,10 @@ func NewNodeStatus[T any](node *node.Info) *NodeStatus[T] {
 	}
 }
 
+// IsRestarted returns true if the node is restarted with the same id,
+// info is the latest information of the node.
+func (s *NodeStatus[T]) IsRestarted(info *node.Info) bool {
+	return s.node.ID == info.ID && s.node.StartTimestamp != info.StartTimestamp
+}
+
 type NewBootstrapMessageFn func(id node.ID) *messaging.TargetMessage
@@ -77,6 +77,29 @@ func TestAddNewNode(t *testing.T) {
 	require.True(t, b.nodes["cd"].state == NodeStateUninitialized)
 }
 
+func TestAddRestartedNode(t *testing.T) {
+	b := NewBootstrapper[heartbeatpb.MaintainerBootstrapResponse]("test", func(id node.ID) *messaging.TargetMessage {
+		return &messaging.TargetMessage{}
+	})
+	msgs := b.HandleNewNodes([]*node.Info{{ID: "ab", StartTimestamp: 1}})
+	require.Len(t, msgs, 1)
+	cached := b.HandleBootstrapResponse("ab", &heartbeatpb.MaintainerBootstrapResponse{})
+	require.NotNil(t, cached)
+	require.True(t, b.CheckAllNodeInitialized())
+
+	// the same node is not bootstrapped again
+	msgs = b.HandleNewNodes([]*node.Info{{ID: "ab", StartTimestamp: 1}})
+	require.Len(t, msgs, 0)
+	require.False(t, b.nodes["ab"].IsRestarted(&node.Info{ID: "ab", StartTimestamp: 1}))
+
+	// the node restarted with the same id is bootstrapped again
+	require.True(t, b.nodes["ab"].IsRestarted(&node.Info{ID: "ab", StartTimestamp: 2}))
+	msgs = b.HandleNewNodes([]*node.Info{{ID: "ab", StartTimestamp: 2}})
+	require.Len(t, msgs, 1)
+	require.True(t, b.nodes["ab"].state == NodeStateUninitialized)
+	require.False(t, b.CheckAllNodeInitialized())
+}
+
 func TestHandleRemoveNodes(t *testing.T) {
 	b := NewBootstrapper[heartbeatpb.MaintainerBootstrapResponse]("test", func(id node.ID) *messaging.TargetMessage {
 		return &messaging.TargetMessage{}
@@ -14,6 +14,7 @@
 package context
 
 import (
+	"fmt"
 	"sync"
 )
 
@@ -34,9 +35,16 @@ const (
 	DispatcherDynamicStream = "DispatcherDynamicStream"
 	MaintainerManager       = "MaintainerManager"
 	DispatcherOrchestrator  = "DispatcherOrchestrator"
+	UpstreamStoreManager    = "UpstreamStoreManager"
 	DefaultPDClock          = "PDClock-0"
 )
 
+// PDClockName returns the service name of the pd clock of the upstream,
+// upstreamID is 0 for the default upstream.
+func PDClockName(upstreamID uint64) string {
+	return fmt.Sprintf("PDClock-%d", upstreamID)
+}
+
 // Put all the global instances here.
 type AppContext struct {
 	id         string
@@ -56,7 +64,19 @@ func SetID(id string) { GetGlobalContext().id = id }
 func GetID() string   { return GetGlobalContext().id }
 
 func SetService[T any](name string, t T) { GetGlobalContext().serviceMap.Store(name, t) }
+func RemoveService(name string)          { GetGlobalContext().serviceMap.Delete(name) }
 func GetService[T any](name string) T {
 	v, _ := GetGlobalContext().serviceMap.Load(name)
 	return v.(T)
 }
+
+// TryGetService returns the service and whether it's registered.
+func TryGetService[T any](name string) (T, bool) {
+	v, ok := GetGlobalContext().serviceMap.Load(name)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	t, ok := v.(T)
+	return t, ok
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"github.com/pingcap/ticdc/pkg/common"
+)
+
+// ConfigBarrierEvent blocks all the dispatchers of a changefeed at CommitTs when the changefeed config is hot-reloaded.
+// The events whose commitTs is not larger than CommitTs are handled by the old config,
+// and the events after it are handled by the new config.
+// It's created by the dispatcher locally, so it doesn't need to be marshaled.
+// Implement Event / FlushEvent / BlockEvent interface
+type ConfigBarrierEvent struct {
+	DispatcherID   common.DispatcherID
+	CommitTs       uint64
+	PostTxnFlushed []func()
+}
+
+func (e *ConfigBarrierEvent) GetType() int {
+	return TypeConfigBarrierEvent
+}
+
+func (e *ConfigBarrierEvent) GetDispatcherID() common.DispatcherID {
+	return e.DispatcherID
+}
+
+func (e *ConfigBarrierEvent) GetCommitTs() common.Ts {
+	return e.CommitTs
+}
+
+func (e *ConfigBarrierEvent) GetStartTs() common.Ts {
+	return e.CommitTs
+}
+
+func (e *ConfigBarrierEvent) GetSize() int64 {
+	return int64(e.DispatcherID.GetSize() + 8)
+}
+
+func (e *ConfigBarrierEvent) IsPaused() bool {
+	return false
+}
+
+func (e *ConfigBarrierEvent) GetSeq() uint64 {
+	// It's a fake seq.
+	return 0
+}
+
+func (e *ConfigBarrierEvent) GetBlockedTables() *InfluencedTables {
+	return &InfluencedTables{
+		InfluenceType: InfluenceTypeAll,
+	}
+}
+
+func (e *ConfigBarrierEvent) GetNeedDroppedTables() *InfluencedTables {
+	return nil
+}
+
+func (e *ConfigBarrierEvent) GetNeedAddedTables() []Table {
+	return nil
+}
+
+func (e *ConfigBarrierEvent) GetUpdatedSchemas() []SchemaIDChange {
+	return nil
+}
+
+func (e *ConfigBarrierEvent) PostFlush() {
+	for _, f := range e.PostTxnFlushed {
+		f()
+	}
+}
+
+func (e *ConfigBarrierEvent) AddPostFlushFunc(f func()) {
+	e.PostTxnFlushed = append(e.PostTxnFlushed, f)
+}
+
+func (e *ConfigBarrierEvent) PushFrontFlushFunc(f func()) {
+	e.PostTxnFlushed = append([]func(){f}, e.PostTxnFlushed...)
+}
+
+func (e *ConfigBarrierEvent) ClearPostFlushFunc() {
+	e.PostTxnFlushed = e.PostTxnFlushed[:0]
+}
@@ -71,6 +71,9 @@ type DDLEvent struct {
 	TableNameChange *TableNameChange `json:"table_name_change"`
 
 	TiDBOnly bool `json:"tidb_only"`
+	// IsBootstrap is true if the event is a table schema bootstrap event,
+	// which is only generated by the sink and never sent by the upstream.
+	IsBootstrap bool `json:"-"`
 	// Call when event flush is completed
 	PostTxnFlushed []func() `json:"-"`
 	// eventSize is the size of the event in bytes. It is set when it's unmarshaled.
@@ -18,6 +18,7 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"go.uber.org/zap"
 )
@@ -95,10 +96,20 @@ func NewDMLEvent(
 	}
 }
 
+// RowFilter is used to filter out the row changes of a DMLEvent when they are appended.
+type RowFilter interface {
+	// ShouldIgnoreDMLEvent returns true if the row change should not be sent to downstream.
+	ShouldIgnoreDMLEvent(row *RowChange, startTs uint64, tableInfo *common.TableInfo) (bool, error)
+}
+
+// AppendRow decodes the raw kv entry and appends it to the DMLEvent.
+// If the filter is not nil and it ignores the decoded row change,
+// the row change is dropped and the DMLEvent is left unchanged.
 func (t *DMLEvent) AppendRow(raw *common.RawKVEntry,
 	decode func(
 		rawKv *common.RawKVEntry,
 		tableInfo *common.TableInfo, chk *chunk.Chunk) (int, error),
+	filter RowFilter,
 ) error {
 	RowType := RowTypeInsert
 	if raw.OpType == common.OpTypeDelete {
@@ -111,6 +122,16 @@ func (t *DMLEvent) AppendRow(raw *common.RawKVEntry,
 	if err != nil {
 		return err
 	}
+	if filter != nil && count > 0 {
+		ignore, err := t.shouldIgnoreLastRow(filter, RowType, count)
+		if err != nil {
+			return err
+		}
+		if ignore {
+			t.Rows.TruncateTo(t.Rows.NumRows() - count)
+			return nil
+		}
+	}
 	if count == 1 {
 		t.RowTypes = append(t.RowTypes, RowType)
 	} else if count == 2 {
@@ -121,6 +142,51 @@ func (t *DMLEvent) AppendRow(raw *common.RawKVEntry,
 	return nil
 }
 
+// shouldIgnoreLastRow checks the last `count` rows just decoded into the chunk as a row change
+// of the given row type against the filter.
+func (t *DMLEvent) shouldIgnoreLastRow(filter RowFilter, rowType RowType, count int) (bool, error) {
+	offset := t.Rows.NumRows() - count
+	row := RowChange{RowType: rowType}
+	switch rowType {
+	case RowTypeInsert:
+		row.Row = t.Rows.GetRow(offset)
+	case RowTypeDelete:
+		row.PreRow = t.Rows.GetRow(offset)
+	case RowTypeUpdate:
+		row.PreRow = t.Rows.GetRow(offset)
+		row.Row = t.Rows.GetRow(offset + 1)
+	}
+	return filter.ShouldIgnoreDMLEvent(&row, t.StartTs, t.TableInfo)
+}
+
+// AppendRowDatums appends a row change whose column values are given as datums,
+// ordered the same as the columns of the table info.
+// It's used by the decoders which restore the DMLEvent from the encoded messages.
+// The preRow is only used by the delete and update row change,
+// and the row is only used by the insert and update row change.
+func (t *DMLEvent) AppendRowDatums(rowType RowType, preRow, row []types.Datum) {
+	appendDatums := func(datums []types.Datum) {
+		for i := range datums {
+			t.Rows.AppendDatum(i, &datums[i])
+		}
+	}
+	switch rowType {
+	case RowTypeInsert:
+		appendDatums(row)
+		t.RowTypes = append(t.RowTypes, rowType)
+	case RowTypeDelete:
+		appendDatums(preRow)
+		t.RowTypes = append(t.RowTypes, rowType)
+	case RowTypeUpdate:
+		appendDatums(preRow)
+		appendDatums(row)
+		t.RowTypes = append(t.RowTypes, rowType, rowType)
+	default:
+		log.Panic("DMLEvent.AppendRowDatums: invalid row type", zap.Int("rowType", int(rowType)))
+	}
+	t.Length += 1
+}
+
 func (t *DMLEvent) GetType() int {
 	return TypeDMLEvent
 }
@@ -193,6 +259,11 @@ func (t *DMLEvent) GetNextRow() (RowChange, bool) {
 	return RowChange{}, false
 }
 
+// Rewind resets the offset of the rows, so the rows can be iterated by `GetNextRow` again.
+func (t *DMLEvent) Rewind() {
+	t.offset = 0
+}
+
 // Len returns the number of row change events in the transaction.
 // Note: An update event is counted as 1 row.
 func (t *DMLEvent) Len() int32 {
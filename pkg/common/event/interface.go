@@ -67,6 +67,9 @@ const (
 	TypeReadyEvent
 	// TypeNotReusableEvent is the event type to indicate the event service has no data for reuse.
 	TypeNotReusableEvent
+	// TypeConfigBarrierEvent is the event type of the barrier for switching the hot-reloaded changefeed config.
+	// It's generated by the dispatcher itself, and never sent by the event service.
+	TypeConfigBarrierEvent
 )
 
 // fakeDispatcherID is a fake dispatcherID for batch resolvedTs.
@@ -119,6 +119,18 @@ func GetIntersectSpan(lhs heartbeatpb.TableSpan, rhs heartbeatpb.TableSpan) hear
 	}
 }
 
+// IsSubSpan returns true if the sub span is fully covered by the parent span.
+func IsSubSpan(sub heartbeatpb.TableSpan, parent heartbeatpb.TableSpan) bool {
+	return StartCompare(sub.StartKey, parent.StartKey) >= 0 &&
+		EndCompare(sub.EndKey, parent.EndKey) <= 0
+}
+
+// KeyInSpan returns true if the comparable key is in the span range.
+func KeyInSpan(key []byte, span heartbeatpb.TableSpan) bool {
+	return StartCompare(key, span.StartKey) >= 0 &&
+		EndCompare(key, span.EndKey) < 0
+}
+
 // IsEmptySpan returns true if the span is empty.
 // TODO: check whether need span.StartKey >= span.EndKey
 func IsEmptySpan(span heartbeatpb.TableSpan) bool {
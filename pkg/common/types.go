@@ -261,4 +261,6 @@ const (
 	MysqlSinkType SinkType = iota
 	KafkaSinkType
 	BlackHoleSinkType
+	PulsarSinkType
+	CloudStorageSinkType
 )
@@ -15,6 +15,7 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"math"
 	"net/url"
 	"time"
@@ -43,12 +44,27 @@ type ChangefeedConfig struct {
 	ForceReplicate bool          `json:"force_replicate" default:"false"`
 	Filter         *FilterConfig `toml:"filter" json:"filter"`
 	MemoryQuota    uint64        `toml:"memory-quota" json:"memory-quota"`
+	// UpstreamID is the cluster ID of the upstream TiDB cluster,
+	// it's 0 when the changefeed replicates from the default upstream.
+	UpstreamID uint64 `json:"upstream_id"`
+	// UpstreamInfo is nil when the changefeed replicates from the default upstream.
+	UpstreamInfo *UpstreamInfo `json:"upstream_info,omitempty"`
 	// sync point related
 	// TODO: Is syncPointRetention|default can be removed?
 	EnableSyncPoint    bool          `json:"enable_sync_point" default:"false"`
 	SyncPointInterval  time.Duration `json:"sync_point_interval" default:"1m"`
 	SyncPointRetention time.Duration `json:"sync_point_retention" default:"24h"`
 	SinkConfig         *SinkConfig   `json:"sink_config"`
+	// BDRMode is true if the changefeed is part of a bidirectional replication,
+	// the events written by TiCDC itself are not replicated to avoid replication loops.
+	BDRMode bool `json:"bdr_mode" default:"false"`
+	// BDRRole is the role of the upstream cluster in BDR mode, it can be changed on the fly.
+	BDRRole BDRRole `json:"bdr_role"`
+	// Priority is the scheduling priority of the changefeed, the scan tasks of
+	// the high priority changefeeds are preferred by the event service.
+	Priority ChangefeedPriority `json:"priority"`
+	// Consistent is used to enable redo log, it's nil when redo log is disabled.
+	Consistent *ConsistentConfig `json:"consistent"`
 }
 
 // ChangeFeedInfo describes the detail of a ChangeFeed
@@ -69,6 +85,9 @@ type ChangeFeedInfo struct {
 	// but can be fetched for backward compatibility
 	SortDir string `json:"sort-dir"`
 
+	// UpstreamInfo holds the pd endpoints and the security config of the upstream TiDB cluster.
+	// It's nil when the changefeed replicates from the default upstream, aka the TiDB cluster
+	// that the TiCDC cluster is deployed with.
 	UpstreamInfo *UpstreamInfo       `json:"upstream-info"`
 	Config       *ReplicaConfig      `json:"config"`
 	State        model.FeedState     `json:"state"`
@@ -78,6 +97,58 @@ type ChangeFeedInfo struct {
 	CreatorVersion string `json:"creator-version"`
 	// Epoch is the epoch of a changefeed, changes on every restart.
 	Epoch uint64 `json:"epoch"`
+
+	// Priority is the scheduling priority of the changefeed, the maintainers of
+	// the high priority changefeeds are scheduled before the others.
+	Priority ChangefeedPriority `json:"priority,omitempty"`
+	// NodeAffinity is the labels of the nodes the maintainer of the changefeed prefers
+	// to run on, a node matches only if it has all the labels.
+	NodeAffinity map[string]string `json:"node-affinity,omitempty"`
+}
+
+// ChangefeedPriority is the scheduling priority of a changefeed.
+type ChangefeedPriority string
+
+const (
+	// ChangefeedPriorityNormal is the default priority.
+	ChangefeedPriorityNormal ChangefeedPriority = "normal"
+	// ChangefeedPriorityHigh is the priority of the latency critical changefeeds.
+	ChangefeedPriorityHigh ChangefeedPriority = "high"
+)
+
+// Validate checks whether the priority is valid, the empty priority is the same as ChangefeedPriorityNormal.
+func (p ChangefeedPriority) Validate() error {
+	switch p {
+	case "", ChangefeedPriorityNormal, ChangefeedPriorityHigh:
+		return nil
+	}
+	return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+		fmt.Sprintf("invalid changefeed priority %q, must be one of %q and %q",
+			p, ChangefeedPriorityNormal, ChangefeedPriorityHigh))
+}
+
+// IsHighPriority returns true if the changefeed is latency critical.
+func (info *ChangeFeedInfo) IsHighPriority() bool {
+	return info.Priority == ChangefeedPriorityHigh
+}
+
+// MatchNodeLabels returns true if the node with the labels satisfies the node affinity of the changefeed.
+func (info *ChangeFeedInfo) MatchNodeLabels(labels map[string]string) bool {
+	for k, v := range info.NodeAffinity {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// GetNonDefaultUpstreamID returns the cluster ID of the upstream the changefeed replicates from,
+// it returns 0 if the changefeed replicates from the default upstream.
+func (info *ChangeFeedInfo) GetNonDefaultUpstreamID() uint64 {
+	if info.UpstreamInfo == nil {
+		return 0
+	}
+	return info.UpstreamID
 }
 
 func (info *ChangeFeedInfo) ToChangefeedConfig() *ChangefeedConfig {
@@ -86,6 +157,8 @@ func (info *ChangeFeedInfo) ToChangefeedConfig() *ChangefeedConfig {
 		StartTS:            info.StartTs,
 		TargetTS:           info.TargetTs,
 		SinkURI:            info.SinkURI,
+		UpstreamID:         info.GetNonDefaultUpstreamID(),
+		UpstreamInfo:       info.UpstreamInfo,
 		CaseSensitive:      info.Config.CaseSensitive,
 		ForceReplicate:     info.Config.ForceReplicate,
 		SinkConfig:         info.Config.Sink,
@@ -94,6 +167,10 @@ func (info *ChangeFeedInfo) ToChangefeedConfig() *ChangefeedConfig {
 		SyncPointInterval:  util.GetOrZero(info.Config.SyncPointInterval),
 		SyncPointRetention: util.GetOrZero(info.Config.SyncPointRetention),
 		MemoryQuota:        info.Config.MemoryQuota,
+		Consistent:         info.Config.Consistent,
+		BDRMode:            util.GetOrZero(info.Config.BDRMode),
+		BDRRole:            info.Config.BDRRole,
+		Priority:           info.Priority,
 		// other fields are not necessary for maintainer
 	}
 }
@@ -200,6 +277,51 @@ func (info *ChangeFeedInfo) Clone() (*ChangeFeedInfo, error) {
 	return cloned, err
 }
 
+// IsHotReloadable returns true if the changes from info to newInfo can be applied to
+// a running changefeed without recreating its dispatchers.
+// Only the table filter rules, the event filters, the ignored txn start ts, the column
+// selectors, the dispatch rules, the mysql sink worker count, the memory quota, the bdr role
+// and the node affinity can be changed on the fly, other changes require the changefeed
+// to be paused first.
+func (info *ChangeFeedInfo) IsHotReloadable(newInfo *ChangeFeedInfo) bool {
+	if info.SinkURI != newInfo.SinkURI ||
+		info.StartTs != newInfo.StartTs ||
+		info.TargetTs != newInfo.TargetTs ||
+		info.UpstreamID != newInfo.UpstreamID ||
+		info.Priority != newInfo.Priority {
+		return false
+	}
+	if info.Config == nil || newInfo.Config == nil {
+		return info.Config == newInfo.Config
+	}
+	configs := []*ReplicaConfig{info.Config.Clone(), newInfo.Config.Clone()}
+	strs := make([]string, 0, len(configs))
+	for _, cfg := range configs {
+		// Erase the hot-reloadable fields, the rest of the config must be identical.
+		cfg.MemoryQuota = 0
+		cfg.BDRRole = ""
+		if cfg.Filter != nil {
+			cfg.Filter.Rules = nil
+			cfg.Filter.EventFilters = nil
+			cfg.Filter.IgnoreTxnStartTs = nil
+		}
+		if cfg.Sink != nil {
+			cfg.Sink.DispatchRules = nil
+			cfg.Sink.ColumnSelectors = nil
+			if cfg.Sink.MySQLConfig != nil {
+				cfg.Sink.MySQLConfig.WorkerCount = nil
+			}
+		}
+		str, err := cfg.Marshal()
+		if err != nil {
+			log.Warn("failed to marshal replica config", zap.Error(err))
+			return false
+		}
+		strs = append(strs, str)
+	}
+	return strs[0] == strs[1]
+}
+
 // VerifyAndComplete verifies changefeed info and may fill in some fields.
 // If a required field is not provided, return an error.
 // If some necessary filed is missing but can use a default value, fill in it.
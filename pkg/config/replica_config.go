@@ -43,6 +43,32 @@ const (
 	DefaultTiDBSourceID = 1
 )
 
+// BDRRole is the role of the upstream TiDB cluster in BDR mode,
+// it decides which ddls are replicated to the other cluster.
+type BDRRole string
+
+const (
+	// BDRRoleNone means all ddls are replicated.
+	BDRRoleNone BDRRole = "none"
+	// BDRRolePrimary means only the ddls which are safe to be applied
+	// concurrently with the writes of the other cluster are replicated.
+	BDRRolePrimary BDRRole = "primary"
+	// BDRRoleSecondary means the ddls except the safe ones are blocked,
+	// since the ddls should be executed in the primary cluster.
+	BDRRoleSecondary BDRRole = "secondary"
+)
+
+// Validate checks whether the role is valid, the empty role is the same as BDRRoleNone.
+func (r BDRRole) Validate() error {
+	switch r {
+	case "", BDRRoleNone, BDRRolePrimary, BDRRoleSecondary:
+		return nil
+	}
+	return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+		fmt.Sprintf("invalid bdr role %q, must be one of %q, %q and %q",
+			r, BDRRoleNone, BDRRolePrimary, BDRRoleSecondary))
+}
+
 var defaultReplicaConfig = &ReplicaConfig{
 	MemoryQuota:        config.DefaultChangefeedMemoryQuota,
 	CaseSensitive:      false,
@@ -146,6 +172,8 @@ type replicaConfig struct {
 	// replicate data of same tables from TiDB-1 to TiDB-2 and vice versa.
 	// This feature is only available for TiDB.
 	BDRMode *bool `toml:"bdr-mode" json:"bdr-mode,omitempty"`
+	// BDRRole is the role of the upstream cluster, it's only available in BDR mode.
+	BDRRole BDRRole `toml:"bdr-role" json:"bdr-role,omitempty"`
 	// SyncPointInterval is only available when the downstream is DB.
 	SyncPointInterval *time.Duration `toml:"sync-point-interval" json:"sync-point-interval,omitempty"`
 	// SyncPointRetention is only available when the downstream is DB.
@@ -277,6 +305,13 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sin
 						minSyncPointRetention.String()))
 		}
 	}
+	if err := c.BDRRole.Validate(); err != nil {
+		return err
+	}
+	if c.BDRRole != "" && c.BDRRole != BDRRoleNone && !util.GetOrZero(c.BDRMode) {
+		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+			fmt.Sprintf("bdr role %q can only be set in bdr mode", c.BDRRole))
+	}
 	if c.MemoryQuota == uint64(0) {
 		c.FixMemoryQuota()
 	}
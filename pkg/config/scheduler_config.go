@@ -45,6 +45,13 @@ func (c *ChangefeedSchedulerConfig) Validate() error {
 	return nil
 }
 
+const (
+	// BalanceStrategyCount balances the tasks by the task count of each node.
+	BalanceStrategyCount = "count"
+	// BalanceStrategyLoad balances the tasks by the load of each node.
+	BalanceStrategyLoad = "load"
+)
+
 // SchedulerConfig configs TiCDC scheduler.
 type SchedulerConfig struct {
 	// HeartbeatTick is the number of owner tick to initial a heartbeat to captures.
@@ -63,6 +70,10 @@ type SchedulerConfig struct {
 	// When there are only 2 captures, and a large number of tables, this can be helpful to prevent
 	// oom caused by all tables dispatched to only one capture.
 	AddTableBatchSize int `toml:"add-table-batch-size" json:"add-table-batch-size"`
+	// BalanceStrategy is the strategy used by the `BalanceScheduler`,
+	// "count" balances the task count of each node, "load" balances the
+	// write throughput of the tasks and the memory and sink lag of each node.
+	BalanceStrategy string `toml:"balance-strategy" json:"balance-strategy"`
 
 	// ChangefeedSettings is setting by changefeed.
 	ChangefeedSettings *ChangefeedSchedulerConfig `toml:"-" json:"-"`
@@ -79,6 +90,7 @@ func NewDefaultSchedulerConfig() *SchedulerConfig {
 		// TODO: no need to check balance each minute, relax the interval.
 		CheckBalanceInterval: TomlDuration(time.Minute),
 		AddTableBatchSize:    1000,
+		BalanceStrategy:      BalanceStrategyCount,
 	}
 }
 
@@ -104,5 +116,13 @@ func (c *SchedulerConfig) ValidateAndAdjust() error {
 		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
 			"add-table-batch-size must be large than 0")
 	}
+	switch c.BalanceStrategy {
+	case "":
+		c.BalanceStrategy = BalanceStrategyCount
+	case BalanceStrategyCount, BalanceStrategyLoad:
+	default:
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"balance-strategy must be one of count and load")
+	}
 	return nil
 }
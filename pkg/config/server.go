@@ -156,6 +156,8 @@ type ServerConfig struct {
 	Debug                  *DebugConfig         `toml:"debug" json:"debug"`
 	ClusterID              string               `toml:"cluster-id" json:"cluster-id"`
 	GcTunerMemoryThreshold uint64               `toml:"gc-tuner-memory-threshold" json:"gc-tuner-memory-threshold"`
+	// Labels are the labels of the server, they are used to match the node affinity of the changefeeds.
+	Labels map[string]string `toml:"labels" json:"labels"`
 
 	// Deprecated: we don't use this field anymore.
 	PerTableMemoryQuota uint64 `toml:"per-table-memory-quota" json:"per-table-memory-quota"`
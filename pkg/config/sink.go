@@ -117,8 +117,9 @@ func (l AtomicityLevel) validate(scheme string) error {
 	case noneTxnAtomicity:
 		// Do nothing here to avoid modifying the persistence parameters.
 	case tableTxnAtomicity:
-		// MqSink only support `noneTxnAtomicity`.
-		if sink.IsMQScheme(scheme) {
+		// MqSink only support `noneTxnAtomicity`, except the kafka sink
+		// which sends the transactions of a table by the transactional producer.
+		if sink.IsMQScheme(scheme) && scheme != sink.KafkaScheme && scheme != sink.KafkaSSLScheme {
 			errMsg := fmt.Sprintf("%s level atomicity is not supported by %s scheme", l, scheme)
 			return cerror.ErrSinkURIInvalid.GenWithStackByArgs(errMsg)
 		}
@@ -44,6 +44,10 @@ var (
 		"cannot find mysql.tidb_ddl_job schema",
 		errors.RFCCodeText("CDC:ErrDDLSchemaNotFound"),
 	)
+	ErrBDRUnsafeDDLBlocked = errors.Normalize(
+		"ddl of type %s at commitTs %d is blocked, it's not allowed in the %s cluster of bdr mode",
+		errors.RFCCodeText("CDC:ErrBDRUnsafeDDLBlocked"),
+	)
 	ErrPDEtcdAPIError = errors.Normalize(
 		"etcd api call error",
 		errors.RFCCodeText("CDC:ErrPDEtcdAPIError"),
@@ -144,6 +148,30 @@ var (
 		"invalid topic expression",
 		errors.RFCCodeText("CDC:ErrPulsarTopicExprInvalid"),
 	)
+	ErrPulsarNewClient = errors.Normalize(
+		"new pulsar client",
+		errors.RFCCodeText("CDC:ErrPulsarNewClient"),
+	)
+	ErrPulsarNewProducer = errors.Normalize(
+		"new pulsar producer",
+		errors.RFCCodeText("CDC:ErrPulsarNewProducer"),
+	)
+	ErrPulsarProducerClosed = errors.Normalize(
+		"pulsar producer closed",
+		errors.RFCCodeText("CDC:ErrPulsarProducerClosed"),
+	)
+	ErrPulsarAsyncSendMessage = errors.Normalize(
+		"pulsar async send message failed",
+		errors.RFCCodeText("CDC:ErrPulsarAsyncSendMessage"),
+	)
+	ErrPulsarSendMessage = errors.Normalize(
+		"pulsar send message failed",
+		errors.RFCCodeText("CDC:ErrPulsarSendMessage"),
+	)
+	ErrPulsarInvalidConfig = errors.Normalize(
+		"pulsar config invalid %s",
+		errors.RFCCodeText("CDC:ErrPulsarInvalidConfig"),
+	)
 	ErrCodecInvalidConfig = errors.Normalize(
 		"Codec invalid config",
 		errors.RFCCodeText("CDC:ErrCodecInvalidConfig"),
@@ -209,6 +237,10 @@ var (
 		"canal encode failed",
 		errors.RFCCodeText("CDC:ErrCanalEncodeFailed"),
 	)
+	ErrCanalDecodeFailed = errors.Normalize(
+		"canal decode failed",
+		errors.RFCCodeText("CDC:ErrCanalDecodeFailed"),
+	)
 	ErrSinkInvalidConfig = errors.Normalize(
 		"sink config invalid",
 		errors.RFCCodeText("CDC:ErrSinkInvalidConfig"),
@@ -229,6 +261,18 @@ var (
 		"storage sink config invalid",
 		errors.RFCCodeText("CDC:ErrStorageSinkInvalidConfig"),
 	)
+	ErrStorageSinkInvalidFileName = errors.Normalize(
+		"filename in storage sink is invalid",
+		errors.RFCCodeText("CDC:ErrStorageSinkInvalidFileName"),
+	)
+	ErrCSVEncodeFailed = errors.Normalize(
+		"csv encode failed",
+		errors.RFCCodeText("CDC:ErrCSVEncodeFailed"),
+	)
+	ErrCSVDecodeFailed = errors.Normalize(
+		"csv decode failed",
+		errors.RFCCodeText("CDC:ErrCSVDecodeFailed"),
+	)
 	ErrURLFormatInvalid = errors.Normalize(
 		"url format is invalid",
 		errors.RFCCodeText("CDC:ErrURLFormatInvalid"),
@@ -308,6 +352,10 @@ var (
 		"invalid api parameter",
 		errors.RFCCodeText("CDC:ErrAPIInvalidParam"),
 	)
+	ErrAPIGetPDClientFailed = errors.Normalize(
+		"failed to get PDClient to connect PD, please recheck",
+		errors.RFCCodeText("CDC:ErrAPIGetPDClientFailed"),
+	)
 	ErrInternalServerError = errors.Normalize(
 		"internal server error",
 		errors.RFCCodeText("CDC:ErrInternalServerError"),
@@ -414,6 +462,10 @@ var (
 		"upstream missmatch,old: %d, new %d",
 		errors.RFCCodeText("CDC:ErrUpstreamMissMatch"),
 	)
+	ErrUpstreamClosed = errors.Normalize(
+		"upstream is closed",
+		errors.RFCCodeText("CDC:ErrUpstreamClosed"),
+	)
 
 	// cli error
 	ErrCliInvalidCheckpointTs = errors.Normalize(
@@ -469,6 +521,32 @@ var (
 		errors.RFCCodeText("DFLOW:ErrMetaOpFailed"),
 	)
 
+	// redo log related errors
+	ErrRedoConfigInvalid = errors.Normalize(
+		"redo log config invalid",
+		errors.RFCCodeText("CDC:ErrRedoConfigInvalid"),
+	)
+	ErrRedoFileOp = errors.Normalize(
+		"redo file operation",
+		errors.RFCCodeText("CDC:ErrRedoFileOp"),
+	)
+	ErrRedoMetaFileNotFound = errors.Normalize(
+		"no redo meta file found in dir: %s",
+		errors.RFCCodeText("CDC:ErrRedoMetaFileNotFound"),
+	)
+	ErrRedoMetaInitialize = errors.Normalize(
+		"initialize meta for redo log",
+		errors.RFCCodeText("CDC:ErrRedoMetaInitialize"),
+	)
+	ErrRedoWriterStopped = errors.Normalize(
+		"redo log writer stopped",
+		errors.RFCCodeText("CDC:ErrRedoWriterStopped"),
+	)
+
+	ErrInternalCheckFailed = errors.Normalize(
+		"internal check failed, %s",
+		errors.RFCCodeText("CDC:ErrInternalCheckFailed"),
+	)
 	ErrUnexpected = errors.Normalize(
 		"cdc met unexpected error: %s",
 		errors.RFCCodeText("CDC:ErrUnexpected"),
@@ -39,7 +39,11 @@ type dispatcherStat struct {
 	info        DispatcherInfo
 	// startTableInfo is the table info of the dispatcher when it is registered or reset.
 	startTableInfo atomic.Pointer[common.TableInfo]
-	filter         filter.Filter
+
+	// filterMu protects filter, it's replaced when the dispatcher is reset with a new filter config.
+	filterMu sync.RWMutex
+	filter   filter.Filter
+
 	// The reset ts send by the dispatcher.
 	// It is also the start ts of the dispatcher.
 	resetTs atomic.Uint64
@@ -53,7 +57,6 @@ type dispatcherStat struct {
 	sentResolvedTs atomic.Uint64
 	// checkpointTs is the ts that reported by the downstream dispatcher.
 	// events <= checkpointTs will not needed anymore, so we can inform eventStore to GC them.
-	// TODO: maintain it
 	checkpointTs atomic.Uint64
 
 	// The seq of the events that have been sent to the downstream dispatcher.
@@ -84,6 +87,10 @@ type dispatcherStat struct {
 	// isRemoved is used to indicate whether the dispatcher is removed.
 	// If so, we should ignore the errors related to this dispatcher.
 	isRemoved atomic.Bool
+	// isFailed is used to indicate whether the scan of the dispatcher meets an error,
+	// which has been reported to the dispatcher. The dispatcher is not scanned anymore
+	// until it is reset, or removed by the failed changefeed.
+	isFailed atomic.Bool
 }
 
 func newDispatcherStat(
@@ -142,9 +149,23 @@ func (a *dispatcherStat) resetState(resetTs uint64) {
 	a.resetTs.Store(resetTs)
 	a.seq.Store(0)
 	a.taskScanning.Store(false)
+	a.isFailed.Store(false)
 	a.isRunning.Store(true)
 }
 
+// setFilter replaces the filter of the dispatcher, it must be called before the dispatcher is reset.
+func (a *dispatcherStat) setFilter(f filter.Filter) {
+	a.filterMu.Lock()
+	defer a.filterMu.Unlock()
+	a.filter = f
+}
+
+func (a *dispatcherStat) getFilter() filter.Filter {
+	a.filterMu.RLock()
+	defer a.filterMu.RUnlock()
+	return a.filter
+}
+
 // onResolvedTs try to update the resolved ts of the dispatcher.
 func (a *dispatcherStat) onResolvedTs(resolvedTs uint64) bool {
 	if resolvedTs < a.eventStoreResolvedTs.Load() {
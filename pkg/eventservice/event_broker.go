@@ -21,18 +21,19 @@ import (
 	"time"
 
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/eventpb"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/logservice/eventstore"
 	"github.com/pingcap/ticdc/logservice/schemastore"
 	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
-	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	pevent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
@@ -45,6 +46,11 @@ const (
 
 	defaultMaxBatchSize            = 128
 	defaultFlushResolvedTsInterval = 25 * time.Millisecond
+	// reportDispatcherStatInterval is the interval to report the checkpointTs of dispatchers to the eventStore.
+	reportDispatcherStatInterval = 10 * time.Second
+	// maxConsecutivePriorityScans is the max number of the high priority scan tasks
+	// a scan worker handles in a row before it handles a normal one.
+	maxConsecutivePriorityScans = 8
 )
 
 var (
@@ -82,6 +88,9 @@ type eventBroker struct {
 	tableTriggerDispatchers sync.Map
 	// taskChan is used to send the scan tasks to the scan workers.
 	taskChan chan scanTask
+	// priorityTaskChan is used to send the scan tasks of the high priority dispatchers,
+	// the scan workers prefer the tasks in it, see nextScanTask.
+	priorityTaskChan chan scanTask
 
 	// sendMessageWorkerCount is the number of the send message workers to spawn.
 	sendMessageWorkerCount int
@@ -107,6 +116,7 @@ func newEventBroker(
 	id uint64,
 	eventStore eventstore.EventStore,
 	schemaStore schemastore.SchemaStore,
+	pdClock pdutil.Clock,
 	mc messaging.MessageSender,
 	tz *time.Location,
 ) *eventBroker {
@@ -123,10 +133,6 @@ func newEventBroker(
 	g, ctx := errgroup.WithContext(ctx)
 	ctx, cancel := context.WithCancel(ctx)
 
-	// TODO: Retrieve the correct pdClock from the context once multiple upstreams are supported.
-	// For now, since there is only one upstream, using the default pdClock is sufficient.
-	pdClock := appcontext.GetService[pdutil.Clock](appcontext.DefaultPDClock)
-
 	c := &eventBroker{
 		tidbClusterID:           id,
 		eventStore:              eventStore,
@@ -138,6 +144,7 @@ func newEventBroker(
 		tableTriggerDispatchers: sync.Map{},
 		msgSender:               mc,
 		taskChan:                make(chan scanTask, conf.ScanTaskQueueSize),
+		priorityTaskChan:        make(chan scanTask, conf.ScanTaskQueueSize),
 		sendMessageWorkerCount:  sendMessageWorkerCount,
 		messageCh:               make([]chan *wrapEvent, sendMessageWorkerCount),
 		scanWorkerCount:         scanWorkerCount,
@@ -233,14 +240,48 @@ func (c *eventBroker) getMessageCh(workerIndex int) chan *wrapEvent {
 }
 
 func (c *eventBroker) runScanWorker(ctx context.Context) {
+	priorityScans := 0
 	for {
-		select {
-		case <-ctx.Done():
+		task, ok := c.nextScanTask(ctx, &priorityScans)
+		if !ok {
 			return
+		}
+		c.doScan(ctx, task)
+	}
+}
+
+// nextScanTask returns the next scan task to handle, it returns false if the context is done.
+// The high priority tasks are handled first, so the latency critical changefeeds are not delayed
+// by the bulk ones. But after maxConsecutivePriorityScans high priority tasks in a row, a normal
+// task is handled if any, so the normal tasks are not starved by a constant high priority load.
+// priorityScans is the number of the high priority tasks handled in a row by the worker.
+func (c *eventBroker) nextScanTask(ctx context.Context, priorityScans *int) (scanTask, bool) {
+	if *priorityScans < maxConsecutivePriorityScans {
+		select {
+		case task := <-c.priorityTaskChan:
+			*priorityScans++
+			return task, true
+		default:
+		}
+	} else {
+		select {
 		case task := <-c.taskChan:
-			c.doScan(ctx, task)
+			*priorityScans = 0
+			return task, true
+		default:
 		}
 	}
+
+	select {
+	case <-ctx.Done():
+		return nil, false
+	case task := <-c.priorityTaskChan:
+		*priorityScans++
+		return task, true
+	case task := <-c.taskChan:
+		*priorityScans = 0
+		return task, true
+	}
 }
 
 // TODO: maybe event driven model is better. It is coupled with the detail implementation of
@@ -264,7 +305,7 @@ func (c *eventBroker) tickTableTriggerDispatchers(ctx context.Context) {
 				startTs := dispatcherStat.sentResolvedTs.Load()
 				remoteID := node.ID(dispatcherStat.info.GetServerID())
 				// TODO: maybe limit 1 is enough.
-				ddlEvents, endTs, err := c.schemaStore.FetchTableTriggerDDLEvents(dispatcherStat.filter, startTs, 100)
+				ddlEvents, endTs, err := c.schemaStore.FetchTableTriggerDDLEvents(dispatcherStat.getFilter(), startTs, 100)
 				if err != nil {
 					log.Panic("get table trigger events failed", zap.Error(err))
 				}
@@ -321,6 +362,19 @@ func (c *eventBroker) sendDDL(ctx context.Context, remoteID node.ID, e pevent.DD
 	}
 }
 
+// sendError reports the error met at commitTs to the dispatcher by a ddl event carrying it,
+// the dispatcher reports the error to the maintainer when it handles the event.
+// The dispatcher is not scanned anymore after the error is sent.
+func (c *eventBroker) sendError(ctx context.Context, remoteID node.ID, d *dispatcherStat, commitTs uint64, err error) {
+	d.isFailed.Store(true)
+	c.sendDDL(ctx, remoteID, pevent.DDLEvent{
+		Version:    pevent.DDLEventVersion,
+		TableID:    d.info.GetTableSpan().TableID,
+		FinishedTs: commitTs,
+		Err:        err,
+	}, d)
+}
+
 // checkNeedScan checks if the dispatcher needs to scan the event store.
 // If the dispatcher needs to scan the event store, it returns true.
 // If the dispatcher does not need to scan the event store, it send the watermark to the dispatcher
@@ -337,7 +391,7 @@ func (c *eventBroker) checkNeedScan(task scanTask, mustCheck bool) (bool, common
 	c.checkAndSendHandshake(task)
 
 	// Only check scan when the dispatcher is running.
-	if !task.IsRunning() {
+	if !task.IsRunning() || task.isFailed.Load() {
 		// If the dispatcher is not running, we also need to send the watermark to the dispatcher.
 		// And the resolvedTs should be the last sent watermark.
 		resolvedTs := task.sentResolvedTs.Load()
@@ -453,11 +507,13 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 		return
 	}
 
+	eventFilter := task.getFilter()
+
 	// TODO: distinguish only dml or only ddl scenario
 	ddlEvents, err := c.schemaStore.
 		FetchTableDDLEvents(
 			dataRange.Span.TableID,
-			task.filter,
+			eventFilter,
 			dataRange.StartTs,
 			dataRange.EndTs,
 		)
@@ -501,6 +557,10 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 		if dml == nil {
 			return true
 		}
+		// All rows of the transaction are filtered out, no need to send it.
+		if dml.Len() == 0 {
+			return true
+		}
 
 		// Check if the dispatcher is running.
 		// If not, we don't need to send the dml event.
@@ -577,7 +637,24 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 			}
 			dml = pevent.NewDMLEvent(dispatcherID, tableID, e.StartTs, e.CRTs, tableInfo)
 		}
-		dml.AppendRow(e, c.mounter.DecodeToChunk)
+		// Rows ignored by the event filter are dropped here,
+		// so they are never sent to the dispatcher.
+		// The row can't be appended if the filter expression fails to be evaluated,
+		// or the row doesn't match the table info, report it to the dispatcher
+		// to fail its changefeed, the other changefeeds are not affected.
+		if err = dml.AppendRow(e, c.mounter.DecodeToChunk, eventFilter); err != nil {
+			log.Warn("append row failed, report the error to the dispatcher",
+				zap.String("changefeed", task.info.GetChangefeedID().String()),
+				zap.String("dispatcher", task.id.String()),
+				zap.Uint64("commitTs", e.CRTs),
+				zap.Error(err))
+			for len(ddlEvents) > 0 && dml.CommitTs > ddlEvents[0].FinishedTs {
+				c.sendDDL(ctx, remoteID, ddlEvents[0], task)
+				ddlEvents = ddlEvents[1:]
+			}
+			c.sendError(ctx, remoteID, task, dml.CommitTs, err)
+			return
+		}
 	}
 }
 
@@ -743,15 +820,15 @@ func (c *eventBroker) updateMetrics(ctx context.Context) {
 			c.metricEventServiceResolvedTsLag.Set(lag)
 			lag = float64(oracle.GetPhysical(pdTime)-oracle.ExtractPhysical(sentMinWaterMark)) / 1e3
 			c.metricEventServiceSentResolvedTs.Set(lag)
-			metricEventBrokerPendingScanTaskCount.Set(float64(len(c.taskChan)))
+			metricEventBrokerPendingScanTaskCount.Set(float64(len(c.taskChan) + len(c.priorityTaskChan)))
 		}
 	}
 }
 
-// updateDispatcherSendTs updates the sendTs of the dispatcher periodically.
+// reportDispatcherStatToStore reports the checkpointTs of the dispatchers to the eventStore periodically.
 // The eventStore need to know this to GC the stale data.
 func (c *eventBroker) reportDispatcherStatToStore(ctx context.Context) {
-	ticker := time.NewTicker(time.Second * 120)
+	ticker := time.NewTicker(reportDispatcherStatInterval)
 	log.Info("update dispatcher send ts goroutine is started")
 	for {
 		select {
@@ -760,18 +837,24 @@ func (c *eventBroker) reportDispatcherStatToStore(ctx context.Context) {
 		case <-ticker.C:
 			c.dispatchers.Range(func(key, value interface{}) bool {
 				dispatcher := value.(*dispatcherStat)
-				// FIXME: use checkpointTs instead after checkpointTs is correctly updated
-				checkpointTs := dispatcher.sentResolvedTs.Load()
-				// TODO: when use checkpointTs, this check can be removed
-				if checkpointTs > 0 {
-					c.eventStore.UpdateDispatcherCheckpointTs(dispatcher.id, checkpointTs)
-				}
+				c.eventStore.UpdateDispatcherCheckpointTs(dispatcher.id, dispatcher.checkpointTs.Load())
 				return true
 			})
 		}
 	}
 }
 
+// handleDispatcherHeartbeat updates the checkpointTs of the dispatchers reported by the event collector.
+func (c *eventBroker) handleDispatcherHeartbeat(heartbeat *eventpb.DispatcherHeartbeat) {
+	for _, progress := range heartbeat.DispatcherProgresses {
+		stat, ok := c.getDispatcher(common.NewDispatcherIDFromPB(progress.DispatcherId))
+		if !ok {
+			continue
+		}
+		util.CompareAndMonotonicIncrease(&stat.checkpointTs, progress.CheckpointTs)
+	}
+}
+
 func (c *eventBroker) close() {
 	c.cancel()
 	_ = c.g.Wait()
@@ -784,7 +867,11 @@ func (c *eventBroker) onNotify(d *dispatcherStat, resolvedTs uint64, latestCommi
 		needScan, _ := c.checkNeedScan(d, false)
 		if needScan {
 			d.taskScanning.Store(true)
-			c.taskChan <- d
+			if d.info.IsHighPriority() {
+				c.priorityTaskChan <- d
+			} else {
+				c.taskChan <- d
+			}
 		}
 	}
 }
@@ -830,6 +917,7 @@ func (c *eventBroker) addDispatcher(info DispatcherInfo) {
 		info.GetStartTs(),
 		func(resolvedTs uint64, latestCommitTs uint64) { c.onNotify(dispatcher, resolvedTs, latestCommitTs) },
 		info.IsOnlyReuse(),
+		info.GetBDRMode(),
 	)
 	if err != nil {
 		log.Panic("register dispatcher to eventStore failed", zap.Error(err), zap.Any("dispatcherInfo", info))
@@ -913,7 +1001,14 @@ func (c *eventBroker) resetDispatcher(dispatcherInfo DispatcherInfo) {
 	if !ok {
 		return
 	}
-	stat.resetState(dispatcherInfo.GetStartTs())
+	// The filter config of the dispatcher may be changed when the changefeed config is hot-reloaded,
+	// the dispatcher resets itself to receive the events after the config barrier with the new filter.
+	if f := dispatcherInfo.GetFilter(); f != nil {
+		stat.setFilter(f)
+	}
+	// the events <= checkpointTs are already flushed by the dispatcher,
+	// and they may be deleted from the eventStore, so never scan from a ts smaller than it.
+	stat.resetState(max(dispatcherInfo.GetStartTs(), stat.checkpointTs.Load()))
 	log.Info("reset dispatcher",
 		zap.Int64("tableID", stat.info.GetTableSpan().TableID),
 		zap.Any("dispatcher", stat.id),
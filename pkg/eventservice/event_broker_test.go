@@ -22,8 +22,10 @@ import (
 	"github.com/pingcap/ticdc/eventpb"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
-	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerrors "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
@@ -40,11 +42,10 @@ func newTableSpan(tableID int64, start, end string) *heartbeatpb.TableSpan {
 
 func newEventBrokerForTest() (*eventBroker, *mockEventStore, *mockSchemaStore) {
 	mockPDClock := pdutil.NewClock4Test()
-	appcontext.SetService(appcontext.DefaultPDClock, mockPDClock)
 	es := newMockEventStore(100)
 	ss := newMockSchemaStore()
 	mc := newMockMessageCenter()
-	return newEventBroker(context.Background(), 1, es, ss, mc, time.UTC), es, ss
+	return newEventBroker(context.Background(), 1, es, ss, mockPDClock, mc, time.UTC), es, ss
 }
 
 func newMockDispatcherInfoForTest(t *testing.T) *mockDispatcherInfo {
@@ -163,6 +164,76 @@ func TestOnNotify(t *testing.T) {
 	log.Info("Pass case 5")
 }
 
+func TestOnNotifyHighPriority(t *testing.T) {
+	broker, _, _ := newEventBrokerForTest()
+	// Close the broker, so we can catch all message in the test.
+	broker.close()
+
+	disInfo := newMockDispatcherInfoForTest(t)
+	disInfo.highPriority = true
+	changefeedStatus := broker.getOrSetChangefeedStatus(disInfo.GetChangefeedID())
+
+	disp := newDispatcherStat(100, disInfo, nil, 0, changefeedStatus)
+	disp.resetState(100)
+	disp.isHandshaked.Store(true)
+
+	// The scan task of a high priority dispatcher goes to the priority queue.
+	broker.onNotify(disp, 102, 101)
+	require.True(t, disp.taskScanning.Load())
+	require.Len(t, broker.taskChan, 0)
+	task := <-broker.priorityTaskChan
+	require.Equal(t, task.id, disp.id)
+}
+
+func TestNextScanTaskNotStarveNormalTasks(t *testing.T) {
+	broker, _, _ := newEventBrokerForTest()
+	// Close the broker, so the scan workers don't consume the tasks.
+	broker.close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changefeedStatus := broker.getOrSetChangefeedStatus(newMockDispatcherInfoForTest(t).GetChangefeedID())
+	newTask := func(highPriority bool) scanTask {
+		disInfo := newMockDispatcherInfoForTest(t)
+		disInfo.highPriority = highPriority
+		return newDispatcherStat(100, disInfo, nil, 0, changefeedStatus)
+	}
+	normalTasks := make(map[common.DispatcherID]bool)
+	for i := 0; i < 3; i++ {
+		task := newTask(false)
+		normalTasks[task.id] = true
+		broker.taskChan <- task
+	}
+
+	// The priority queue is refilled all the time, the normal tasks still
+	// make progress after every maxConsecutivePriorityScans priority tasks.
+	priorityScans := 0
+	normalScanned := 0
+	for i := 0; i < 3*(maxConsecutivePriorityScans+1); i++ {
+		broker.priorityTaskChan <- newTask(true)
+		task, ok := broker.nextScanTask(ctx, &priorityScans)
+		require.True(t, ok)
+		if normalTasks[task.id] {
+			normalScanned++
+			require.Equal(t, maxConsecutivePriorityScans, i%(maxConsecutivePriorityScans+1))
+		}
+	}
+	require.Equal(t, 3, normalScanned)
+	require.Len(t, broker.taskChan, 0)
+
+	// The priority tasks are handled first if there is no normal task.
+	task, ok := broker.nextScanTask(ctx, &priorityScans)
+	require.True(t, ok)
+	require.True(t, task.info.IsHighPriority())
+
+	cancel()
+	for len(broker.priorityTaskChan) > 0 {
+		<-broker.priorityTaskChan
+	}
+	_, ok = broker.nextScanTask(ctx, &priorityScans)
+	require.False(t, ok)
+}
+
 func TestCURDDispatcher(t *testing.T) {
 	broker, _, _ := newEventBrokerForTest()
 	defer broker.close()
@@ -202,6 +273,39 @@ func TestCURDDispatcher(t *testing.T) {
 	require.Nil(t, disp)
 }
 
+func TestHandleDispatcherHeartbeat(t *testing.T) {
+	broker, _, _ := newEventBrokerForTest()
+	defer broker.close()
+
+	dispInfo := newMockDispatcherInfoForTest(t)
+	dispInfo.startTs = 1000
+	broker.addDispatcher(dispInfo)
+	disp, ok := broker.getDispatcher(dispInfo.GetID())
+	require.True(t, ok)
+	require.Equal(t, uint64(1000), disp.checkpointTs.Load())
+
+	// unknown dispatchers are ignored, and the checkpointTs never goes back
+	broker.handleDispatcherHeartbeat(&eventpb.DispatcherHeartbeat{
+		DispatcherProgresses: []*eventpb.DispatcherProgress{
+			{DispatcherId: common.NewDispatcherID().ToPB(), CheckpointTs: 2000},
+			{DispatcherId: dispInfo.GetID().ToPB(), CheckpointTs: 1005},
+		},
+	})
+	require.Equal(t, uint64(1005), disp.checkpointTs.Load())
+	broker.handleDispatcherHeartbeat(&eventpb.DispatcherHeartbeat{
+		DispatcherProgresses: []*eventpb.DispatcherProgress{
+			{DispatcherId: dispInfo.GetID().ToPB(), CheckpointTs: 1001},
+		},
+	})
+	require.Equal(t, uint64(1005), disp.checkpointTs.Load())
+
+	// the dispatcher never scans the events <= checkpointTs again after reset
+	dispInfo.startTs = 1002
+	broker.resetDispatcher(dispInfo)
+	require.Equal(t, uint64(1005), disp.resetTs.Load())
+	require.Equal(t, uint64(1005), disp.sentResolvedTs.Load())
+}
+
 func TestHandleResolvedTs(t *testing.T) {
 	broker, _, _ := newEventBrokerForTest()
 	defer broker.close()
@@ -228,3 +332,99 @@ func TestHandleResolvedTs(t *testing.T) {
 	msg := <-mc.messageCh
 	require.Equal(t, msg.Type, messaging.TypeBatchResolvedTs)
 }
+
+// errorFilter fails to evaluate every dml event, like a filter expression
+// which can't be evaluated on the rows.
+type errorFilter struct {
+	filter.Filter
+}
+
+func (f *errorFilter) ShouldIgnoreDMLEvent(
+	dml *event.RowChange, startTs uint64, tableInfo *common.TableInfo,
+) (bool, error) {
+	return false, cerrors.ErrFailedToFilterDML.GenWithStackByArgs("bad expression")
+}
+
+func TestDoScanFilterError(t *testing.T) {
+	broker, es, ss := newEventBrokerForTest()
+	// Close the broker, so we can catch all message in the test.
+	broker.close()
+
+	helper := event.NewEventTestHelper(t)
+	defer helper.Close()
+	ddlEvent, kvEvents := genEvents(helper, t, `create table test.t(id int primary key, c char(50))`,
+		`insert into test.t(id,c) values (0, "c0")`)
+	ss.AppendDDLEvent(ddlEvent.TableID, ddlEvent)
+	commitTs := kvEvents[0].CRTs
+
+	newDispatcher := func(changefeed string, f filter.Filter) *dispatcherStat {
+		info := newMockDispatcherInfo(t, common.NewDispatcherID(), ddlEvent.TableID, eventpb.ActionType_ACTION_TYPE_REGISTER)
+		info.changefeedID = common.NewChangefeedID4Test("default", changefeed)
+		info.startTs = ddlEvent.FinishedTs
+		_, err := es.RegisterDispatcher(info.id, info.span, info.startTs, func(uint64, uint64) {}, false, false)
+		require.NoError(t, err)
+		disp := newDispatcherStat(info.startTs, info, f, 0, broker.getOrSetChangefeedStatus(info.changefeedID))
+		disp.resetState(info.startTs)
+		disp.isHandshaked.Store(true)
+		disp.eventStoreResolvedTs.Store(commitTs + 1)
+		disp.latestCommitTs.Store(commitTs)
+		return disp
+	}
+	bad := newDispatcher("bad", &errorFilter{Filter: newMockDispatcherInfoForTest(t).GetFilter()})
+	good := newDispatcher("good", newMockDispatcherInfoForTest(t).GetFilter())
+	v, ok := es.spansMap.Load(ddlEvent.TableID)
+	require.True(t, ok)
+	v.(*mockSpanStats).update(commitTs+1, kvEvents...)
+
+	// Case 1: the filter error is reported to the dispatcher by a ddl event carrying it,
+	// instead of panicking the whole event service.
+	broker.doScan(context.TODO(), bad)
+	e := <-broker.messageCh[0]
+	require.Equal(t, event.TypeDDLEvent, e.msgType)
+	ddl := e.e.(*event.DDLEvent)
+	require.Equal(t, bad.id, ddl.DispatcherID)
+	require.Equal(t, commitTs, ddl.FinishedTs)
+	require.ErrorContains(t, ddl.GetError(), "bad expression")
+	require.True(t, bad.isFailed.Load())
+	require.Len(t, broker.messageCh[0], 0)
+
+	// Case 2: the failed dispatcher is not scanned anymore.
+	needScan, _ := broker.checkNeedScan(bad, true)
+	require.False(t, needScan)
+	e = <-broker.messageCh[0]
+	require.Equal(t, event.TypeResolvedEvent, e.msgType)
+
+	// Case 3: the dispatcher of another changefeed is not affected.
+	broker.doScan(context.TODO(), good)
+	e = <-broker.messageCh[0]
+	require.Equal(t, event.TypeDMLEvent, e.msgType)
+	dml := e.e.(*event.DMLEvent)
+	require.Equal(t, good.id, dml.DispatcherID)
+	require.Equal(t, int32(1), dml.Len())
+	require.False(t, good.isFailed.Load())
+}
+
+func TestResetDispatcherWithNewFilter(t *testing.T) {
+	broker, _, _ := newEventBrokerForTest()
+	// Close the broker, so we can catch all message in the test.
+	broker.close()
+
+	info := newMockDispatcherInfoForTest(t)
+	stat := newDispatcherStat(info.startTs, info, info.filter, 0, broker.getOrSetChangefeedStatus(info.GetChangefeedID()))
+	stat.isHandshaked.Store(true)
+	stat.updateSentResolvedTs(300)
+	broker.dispatchers.Store(info.GetID(), stat)
+
+	// The dispatcher is reset to the config barrier with a new filter.
+	newFilter, err := filter.NewFilter(config.NewDefaultFilterConfig(), "", false, false)
+	require.NoError(t, err)
+	resetInfo := newMockDispatcherInfo(t, info.GetID(), info.span.TableID, eventpb.ActionType_ACTION_TYPE_RESET)
+	resetInfo.startTs = 200
+	resetInfo.filter = newFilter
+	broker.resetDispatcher(resetInfo)
+
+	require.Same(t, newFilter, stat.getFilter())
+	require.False(t, stat.isHandshaked.Load())
+	require.Equal(t, uint64(200), stat.resetTs.Load())
+	require.Equal(t, uint64(200), stat.sentResolvedTs.Load())
+}
@@ -23,10 +23,12 @@ import (
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/logservice/eventstore"
 	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/logservice/upstreamstore"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/pdutil"
 	"go.uber.org/zap"
 )
 
@@ -49,6 +51,10 @@ type DispatcherInfo interface {
 	GetSyncPointInterval() time.Duration
 
 	IsOnlyReuse() bool
+	// GetBDRMode returns true if the events written by TiCDC itself should be filtered out.
+	GetBDRMode() bool
+	// IsHighPriority returns true if the scan tasks of the dispatcher should be preferred.
+	IsHighPriority() bool
 }
 
 // EventService accepts the requests of pulling events.
@@ -61,7 +67,11 @@ type eventService struct {
 	brokers map[uint64]*eventBroker
 
 	// TODO: use a better way to cache the acceptorInfos
-	dispatcherInfo chan DispatcherInfo
+	dispatcherInfo      chan DispatcherInfo
+	dispatcherHeartbeat chan *eventpb.DispatcherHeartbeat
+	// releasedStores receives the stores of the upstreams released by the upstream store manager,
+	// the brokers reading from them are closed.
+	releasedStores chan *upstreamstore.Stores
 	tz             *time.Location
 	wg             sync.WaitGroup
 }
@@ -69,12 +79,14 @@ type eventService struct {
 func New(eventStore eventstore.EventStore, schemaStore schemastore.SchemaStore) common.SubModule {
 	mc := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
 	es := &eventService{
-		mc:             mc,
-		eventStore:     eventStore,
-		schemaStore:    schemaStore,
-		brokers:        make(map[uint64]*eventBroker),
-		dispatcherInfo: make(chan DispatcherInfo, basicChannelSize*16),
-		tz:             time.Local, // FIXME use the timezone from the config
+		mc:                  mc,
+		eventStore:          eventStore,
+		schemaStore:         schemaStore,
+		brokers:             make(map[uint64]*eventBroker),
+		dispatcherInfo:      make(chan DispatcherInfo, basicChannelSize*16),
+		dispatcherHeartbeat: make(chan *eventpb.DispatcherHeartbeat, basicChannelSize),
+		releasedStores:      make(chan *upstreamstore.Stores, basicChannelSize),
+		tz:                  time.Local, // FIXME use the timezone from the config
 	}
 	es.mc.RegisterHandler(messaging.EventServiceTopic, es.handleMessage)
 	return es
@@ -89,6 +101,7 @@ func (s *eventService) Run(ctx context.Context) error {
 	defer func() {
 		log.Info("event service exited")
 	}()
+	s.watchReleasedStores(ctx)
 	for {
 		select {
 		case <-ctx.Done():
@@ -112,10 +125,32 @@ func (s *eventService) Run(ctx context.Context) error {
 			default:
 				log.Panic("invalid action type", zap.Any("info", info))
 			}
+		case heartbeat := <-s.dispatcherHeartbeat:
+			// the dispatcher ids are unique, so just let each broker handle the dispatchers it has
+			for _, c := range s.brokers {
+				c.handleDispatcherHeartbeat(heartbeat)
+			}
+		case stores := <-s.releasedStores:
+			s.removeBroker(stores)
 		}
 	}
 }
 
+// watchReleasedStores asks the upstream store manager to notify the released stores,
+// so that the brokers reading from them are closed.
+func (s *eventService) watchReleasedStores(ctx context.Context) {
+	manager, ok := appcontext.TryGetService[*upstreamstore.Manager](appcontext.UpstreamStoreManager)
+	if !ok {
+		return
+	}
+	manager.RegisterReleaseHandler(func(stores *upstreamstore.Stores) {
+		select {
+		case <-ctx.Done():
+		case s.releasedStores <- stores:
+		}
+	})
+}
+
 func (s *eventService) Close(_ context.Context) error {
 	log.Info("event service is closing")
 	for _, c := range s.brokers {
@@ -126,6 +161,16 @@ func (s *eventService) Close(_ context.Context) error {
 }
 
 func (s *eventService) handleMessage(ctx context.Context, msg *messaging.TargetMessage) error {
+	if msg.Type == messaging.TypeDispatcherHeartbeat {
+		for _, m := range msg.Message {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case s.dispatcherHeartbeat <- m.(*eventpb.DispatcherHeartbeat):
+			}
+		}
+		return nil
+	}
 	infos := msgToDispatcherInfo(msg)
 	for _, info := range infos {
 		select {
@@ -139,14 +184,60 @@ func (s *eventService) handleMessage(ctx context.Context, msg *messaging.TargetM
 
 func (s *eventService) registerDispatcher(ctx context.Context, info DispatcherInfo) {
 	clusterID := info.GetClusterID()
+	eventStore, schemaStore, pdClock, ok := s.getUpstreamStores(clusterID)
+	if !ok {
+		log.Warn("upstream not found, ignore the dispatcher",
+			zap.Uint64("clusterID", clusterID),
+			zap.Stringer("dispatcherID", info.GetID()))
+		return
+	}
 	c, ok := s.brokers[clusterID]
+	if ok && c.eventStore != eventStore {
+		// the upstream is released and added again before the release is handled,
+		// the broker still reads from the closed stores.
+		s.closeBroker(clusterID)
+		ok = false
+	}
 	if !ok {
-		c = newEventBroker(ctx, clusterID, s.eventStore, s.schemaStore, s.mc, s.tz)
+		c = newEventBroker(ctx, clusterID, eventStore, schemaStore, pdClock, s.mc, s.tz)
 		s.brokers[clusterID] = c
 	}
 	c.addDispatcher(info)
 }
 
+// removeBroker closes the broker which reads from the released stores of an upstream.
+func (s *eventService) removeBroker(stores *upstreamstore.Stores) {
+	c, ok := s.brokers[stores.UpstreamID]
+	if !ok || c.eventStore != stores.EventStore {
+		return
+	}
+	s.closeBroker(stores.UpstreamID)
+}
+
+func (s *eventService) closeBroker(clusterID uint64) {
+	s.brokers[clusterID].close()
+	delete(s.brokers, clusterID)
+	log.Info("event broker of the upstream is closed", zap.Uint64("clusterID", clusterID))
+}
+
+// getUpstreamStores returns the stores of the upstream which the dispatchers of the cluster read from,
+// clusterID is 0 for the default upstream.
+// The stores of other upstreams are created by the dispatcher manager on this node when
+// the changefeed is created, so they must exist if a dispatcher of the upstream is registered.
+func (s *eventService) getUpstreamStores(clusterID uint64) (
+	eventstore.EventStore, schemastore.SchemaStore, pdutil.Clock, bool,
+) {
+	if clusterID == 0 {
+		return s.eventStore, s.schemaStore, appcontext.GetService[pdutil.Clock](appcontext.DefaultPDClock), true
+	}
+	manager := appcontext.GetService[*upstreamstore.Manager](appcontext.UpstreamStoreManager)
+	stores, ok := manager.GetStores(clusterID)
+	if !ok {
+		return nil, nil, nil, false
+	}
+	return stores.EventStore, stores.SchemaStore, stores.PDClock, true
+}
+
 func (s *eventService) deregisterDispatcher(dispatcherInfo DispatcherInfo) {
 	clusterID := dispatcherInfo.GetClusterID()
 	c, ok := s.brokers[clusterID]
@@ -26,6 +26,7 @@ import (
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/logservice/eventstore"
 	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/logservice/upstreamstore"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
@@ -199,6 +200,67 @@ type mockEventStore struct {
 	spansMap                 sync.Map
 }
 
+func TestEventBrokerRebuiltAfterUpstreamReleased(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	manager := upstreamstore.NewManager(ctx, t.TempDir(), &upstreamstore.Stores{UpstreamID: 100})
+	defer manager.Close(ctx)
+	appcontext.SetService(appcontext.UpstreamStoreManager, manager)
+	defer appcontext.RemoveService(appcontext.UpstreamStoreManager)
+
+	mc := &mockMessageCenter{
+		messageCh: make(chan *messaging.TargetMessage, 100),
+	}
+	appcontext.SetService(appcontext.DefaultPDClock, pdutil.NewClock4Test())
+	appcontext.SetService(appcontext.MessageCenter, mc)
+	es := New(newMockEventStore(100), newMockSchemaStore()).(*eventService)
+	defer es.Close(ctx)
+	es.watchReleasedStores(ctx)
+
+	newStores := func() *upstreamstore.Stores {
+		return &upstreamstore.Stores{
+			UpstreamID:  200,
+			PDClock:     pdutil.NewClock4Test(),
+			EventStore:  newMockEventStore(100),
+			SchemaStore: newMockSchemaStore(),
+		}
+	}
+	newDispatcherInfo := func() *mockDispatcherInfo {
+		info := newMockDispatcherInfo(t, common.NewDispatcherID(), 1, eventpb.ActionType_ACTION_TYPE_REGISTER)
+		info.clusterID = 200
+		return info
+	}
+
+	// add the upstream, the broker reads from its stores
+	stores := newStores()
+	manager.AddStoresForTest(stores, 1)
+	es.registerDispatcher(ctx, newDispatcherInfo())
+	require.Same(t, stores.EventStore, es.brokers[200].eventStore)
+
+	// release the upstream, the broker is closed
+	manager.ReleaseStores(200)
+	es.removeBroker(<-es.releasedStores)
+	require.NotContains(t, es.brokers, uint64(200))
+
+	// add the upstream again, a new broker reads from the new stores
+	newUpstreamStores := newStores()
+	manager.AddStoresForTest(newUpstreamStores, 1)
+	es.registerDispatcher(ctx, newDispatcherInfo())
+	require.Same(t, newUpstreamStores.EventStore, es.brokers[200].eventStore)
+
+	// the upstream is added again before the release is handled,
+	// the stale broker is rebuilt when the dispatcher is registered.
+	manager.ReleaseStores(200)
+	lastStores := newStores()
+	manager.AddStoresForTest(lastStores, 1)
+	es.registerDispatcher(ctx, newDispatcherInfo())
+	require.Same(t, lastStores.EventStore, es.brokers[200].eventStore)
+	// the release of the old stores doesn't close the new broker
+	es.removeBroker(<-es.releasedStores)
+	require.Same(t, lastStores.EventStore, es.brokers[200].eventStore)
+}
+
 func newMockEventStore(resolvedTsUpdateInterval int) *mockEventStore {
 	return &mockEventStore{
 		resolvedTsUpdateInterval: time.Millisecond * time.Duration(resolvedTsUpdateInterval),
@@ -283,6 +345,7 @@ func (m *mockEventStore) RegisterDispatcher(
 	startTS common.Ts,
 	notifier eventstore.ResolvedTsNotifier,
 	onlyReuse bool,
+	bdrMode bool,
 ) (bool, error) {
 	log.Info("subscribe table span", zap.Any("span", span), zap.Uint64("startTs", uint64(startTS)))
 	spanStats := &mockSpanStats{
@@ -461,14 +524,16 @@ var _ DispatcherInfo = &mockDispatcherInfo{}
 
 // mockDispatcherInfo is a mock implementation of the AcceptorInfo interface
 type mockDispatcherInfo struct {
-	clusterID  uint64
-	serverID   string
-	id         common.DispatcherID
-	topic      string
-	span       *heartbeatpb.TableSpan
-	startTs    uint64
-	actionType eventpb.ActionType
-	filter     filter.Filter
+	clusterID    uint64
+	serverID     string
+	id           common.DispatcherID
+	changefeedID common.ChangeFeedID
+	topic        string
+	span         *heartbeatpb.TableSpan
+	startTs      uint64
+	actionType   eventpb.ActionType
+	filter       filter.Filter
+	highPriority bool
 }
 
 func newMockDispatcherInfo(t *testing.T, dispatcherID common.DispatcherID, tableID int64, actionType eventpb.ActionType) *mockDispatcherInfo {
@@ -476,10 +541,11 @@ func newMockDispatcherInfo(t *testing.T, dispatcherID common.DispatcherID, table
 	filter, err := filter.NewFilter(cfg, "", false, false)
 	require.NoError(t, err)
 	return &mockDispatcherInfo{
-		clusterID: 1,
-		serverID:  "server1",
-		id:        dispatcherID,
-		topic:     "topic1",
+		clusterID:    0,
+		serverID:     "server1",
+		id:           dispatcherID,
+		changefeedID: common.NewChangefeedID4Test("default", "test"),
+		topic:        "topic1",
 		span: &heartbeatpb.TableSpan{
 			TableID:  tableID,
 			StartKey: []byte("a"),
@@ -520,7 +586,7 @@ func (m *mockDispatcherInfo) GetActionType() eventpb.ActionType {
 }
 
 func (m *mockDispatcherInfo) GetChangefeedID() common.ChangeFeedID {
-	return common.NewChangefeedID4Test("default", "test")
+	return m.changefeedID
 }
 
 func (m *mockDispatcherInfo) GetFilterConfig() *tconfig.FilterConfig {
@@ -549,6 +615,14 @@ func (m *mockDispatcherInfo) IsOnlyReuse() bool {
 	return false
 }
 
+func (m *mockDispatcherInfo) GetBDRMode() bool {
+	return false
+}
+
+func (m *mockDispatcherInfo) IsHighPriority() bool {
+	return m.highPriority
+}
+
 func genEvents(helper *pevent.EventTestHelper, t *testing.T, ddl string, dmls ...string) (pevent.DDLEvent, []*common.RawKVEntry) {
 	job := helper.DDL2Job(ddl)
 	schema := job.SchemaName
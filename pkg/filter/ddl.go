@@ -171,3 +171,9 @@ func ShouldBlock(action timodel.ActionType) bool {
 		return true
 	}
 }
+
+// IsBDRSafeDDL returns true if the ddl only adds or relaxes schema objects,
+// so it can be applied concurrently with the writes of the other cluster in BDR mode.
+func IsBDRSafeDDL(action timodel.ActionType) bool {
+	return timodel.ActionBDRMap[action] == timodel.SafeDDL
+}
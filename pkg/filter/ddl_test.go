@@ -16,6 +16,7 @@ package filter
 import (
 	"testing"
 
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -34,3 +35,14 @@ func TestSingleTableDDL(t *testing.T) {
 	}
 	require.Equal(t, len(singleTableDDLs)+len(multiTableDDLs)+len(globalTableDDLs), len(ddlWhiteListMap))
 }
+
+func TestIsBDRSafeDDL(t *testing.T) {
+	require.True(t, IsBDRSafeDDL(timodel.ActionCreateTable))
+	require.True(t, IsBDRSafeDDL(timodel.ActionAddColumn))
+	require.True(t, IsBDRSafeDDL(timodel.ActionAddIndex))
+	require.False(t, IsBDRSafeDDL(timodel.ActionDropTable))
+	require.False(t, IsBDRSafeDDL(timodel.ActionTruncateTable))
+	require.False(t, IsBDRSafeDDL(timodel.ActionRenameTable))
+	require.False(t, IsBDRSafeDDL(timodel.ActionCreateResourceGroup))
+	require.False(t, IsBDRSafeDDL(timodel.ActionNone))
+}
@@ -19,16 +19,18 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/tidb/pkg/expression"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/sessionctx"
-	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
 	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
-	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/dm/pkg/utils"
 	"go.uber.org/zap"
 )
@@ -39,7 +41,7 @@ import (
 type dmlExprFilterRule struct {
 	mu sync.Mutex
 	// Cache tableInfos to check if the table was changed.
-	tables map[string]*model.TableInfo
+	tables map[string]*common.TableInfo
 
 	insertExprs    map[string]expression.Expression // tableName -> expr
 	updateOldExprs map[string]expression.Expression // tableName -> expr
@@ -63,7 +65,7 @@ func newExprFilterRule(
 	}
 
 	ret := &dmlExprFilterRule{
-		tables:         make(map[string]*model.TableInfo),
+		tables:         make(map[string]*common.TableInfo),
 		insertExprs:    make(map[string]expression.Expression),
 		updateOldExprs: make(map[string]expression.Expression),
 		updateNewExprs: make(map[string]expression.Expression),
@@ -79,7 +81,7 @@ func newExprFilterRule(
 // It should only be called in dmlExprFilter's verify method.
 // We ask users to set these expr only in default sql mode,
 // so we just need to  verify each expr in default sql mode
-func (r *dmlExprFilterRule) verify(tableInfos []*model.TableInfo) error {
+func (r *dmlExprFilterRule) verify(tableInfos []*common.TableInfo) error {
 	// verify expression filter rule syntax.
 	p := parser.New()
 	_, _, err := p.ParseSQL(completeExpression(r.config.IgnoreInsertValueExpr))
@@ -109,7 +111,7 @@ func (r *dmlExprFilterRule) verify(tableInfos []*model.TableInfo) error {
 	// verify expression filter rule.
 	for _, ti := range tableInfos {
 		tableName := ti.TableName.String()
-		if !r.tableMatcher.MatchTable(ti.TableName.Schema, ti.TableName.Table) {
+		if !r.tableMatcher.MatchTable(ti.GetSchemaName(), ti.GetTableName()) {
 			continue
 		}
 		if r.config.IgnoreInsertValueExpr != "" {
@@ -154,7 +156,7 @@ func (r *dmlExprFilterRule) resetExpr(tableName string) {
 
 // getInsertExprs returns the expression filter to filter INSERT events.
 // This function will lazy calculate expressions if not initialized.
-func (r *dmlExprFilterRule) getInsertExpr(ti *model.TableInfo) (
+func (r *dmlExprFilterRule) getInsertExpr(ti *common.TableInfo) (
 	expression.Expression, error,
 ) {
 	tableName := ti.TableName.String()
@@ -171,7 +173,7 @@ func (r *dmlExprFilterRule) getInsertExpr(ti *model.TableInfo) (
 	return r.insertExprs[tableName], nil
 }
 
-func (r *dmlExprFilterRule) getUpdateOldExpr(ti *model.TableInfo) (
+func (r *dmlExprFilterRule) getUpdateOldExpr(ti *common.TableInfo) (
 	expression.Expression, error,
 ) {
 	tableName := ti.TableName.String()
@@ -189,7 +191,7 @@ func (r *dmlExprFilterRule) getUpdateOldExpr(ti *model.TableInfo) (
 	return r.updateOldExprs[tableName], nil
 }
 
-func (r *dmlExprFilterRule) getUpdateNewExpr(ti *model.TableInfo) (
+func (r *dmlExprFilterRule) getUpdateNewExpr(ti *common.TableInfo) (
 	expression.Expression, error,
 ) {
 	tableName := ti.TableName.String()
@@ -207,7 +209,7 @@ func (r *dmlExprFilterRule) getUpdateNewExpr(ti *model.TableInfo) (
 	return r.updateNewExprs[tableName], nil
 }
 
-func (r *dmlExprFilterRule) getDeleteExpr(ti *model.TableInfo) (
+func (r *dmlExprFilterRule) getDeleteExpr(ti *common.TableInfo) (
 	expression.Expression, error,
 ) {
 	tableName := ti.TableName.String()
@@ -227,9 +229,17 @@ func (r *dmlExprFilterRule) getDeleteExpr(ti *model.TableInfo) (
 
 func (r *dmlExprFilterRule) getSimpleExprOfTable(
 	expr string,
-	ti *model.TableInfo,
+	ti *common.TableInfo,
 ) (expression.Expression, error) {
-	e, err := expression.ParseSimpleExprWithTableInfo(r.sessCtx.GetExprCtx(), expr, ti.TableInfo)
+	// The offsets of the columns are the same as the ones in the chunk rows of the DMLEvent,
+	// so the expression can be evaluated against the rows directly.
+	tableInfo := &timodel.TableInfo{
+		Name:       pmodel.NewCIStr(ti.GetTableName()),
+		Columns:    ti.GetColumns(),
+		Indices:    ti.GetIndices(),
+		PKIsHandle: ti.PKIsHandle(),
+	}
+	e, err := expression.ParseSimpleExprWithTableInfo(r.sessCtx.GetExprCtx(), expr, tableInfo)
 	if err != nil {
 		// If an expression contains an unknown column,
 		// we return an error and stop the changefeed.
@@ -247,9 +257,8 @@ func (r *dmlExprFilterRule) getSimpleExprOfTable(
 }
 
 func (r *dmlExprFilterRule) shouldSkipDML(
-	row *model.RowChangedEvent,
-	rawRow model.RowChangedDatums,
-	ti *model.TableInfo,
+	row *commonEvent.RowChange,
+	ti *common.TableInfo,
 ) (bool, error) {
 	tableName := ti.TableName.String()
 
@@ -259,25 +268,22 @@ func (r *dmlExprFilterRule) shouldSkipDML(
 	if oldTi, ok := r.tables[tableName]; ok {
 		// If one table's tableInfo was updated, we need to reset this rule
 		// and update the tableInfo in the cache.
-		if ti.Version != oldTi.Version {
-			r.tables[tableName] = ti.Clone()
-			r.resetExpr(ti.TableName.String())
+		if ti.UpdateTS() != oldTi.UpdateTS() {
+			r.tables[tableName] = ti
+			r.resetExpr(tableName)
 		}
 	} else {
-		r.tables[tableName] = ti.Clone()
+		r.tables[tableName] = ti
 	}
 
-	switch {
-	case row.IsInsert():
+	switch row.RowType {
+	case commonEvent.RowTypeInsert:
 		exprs, err := r.getInsertExpr(ti)
 		if err != nil {
 			return false, err
 		}
-		return r.skipDMLByExpression(
-			rawRow.RowDatums,
-			exprs,
-		)
-	case row.IsUpdate():
+		return r.skipDMLByExpression(row.Row, exprs)
+	case commonEvent.RowTypeUpdate:
 		oldExprs, err := r.getUpdateOldExpr(ti)
 		if err != nil {
 			return false, err
@@ -286,30 +292,21 @@ func (r *dmlExprFilterRule) shouldSkipDML(
 		if err != nil {
 			return false, err
 		}
-		ignoreOld, err := r.skipDMLByExpression(
-			rawRow.PreRowDatums,
-			oldExprs,
-		)
+		ignoreOld, err := r.skipDMLByExpression(row.PreRow, oldExprs)
 		if err != nil {
 			return false, err
 		}
-		ignoreNew, err := r.skipDMLByExpression(
-			rawRow.RowDatums,
-			newExprs,
-		)
+		ignoreNew, err := r.skipDMLByExpression(row.Row, newExprs)
 		if err != nil {
 			return false, err
 		}
 		return ignoreOld || ignoreNew, nil
-	case row.IsDelete():
+	case commonEvent.RowTypeDelete:
 		exprs, err := r.getDeleteExpr(ti)
 		if err != nil {
 			return false, err
 		}
-		return r.skipDMLByExpression(
-			rawRow.PreRowDatums,
-			exprs,
-		)
+		return r.skipDMLByExpression(row.PreRow, exprs)
 	default:
 		log.Warn("unknown row changed event type")
 		return false, nil
@@ -317,15 +314,13 @@ func (r *dmlExprFilterRule) shouldSkipDML(
 }
 
 func (r *dmlExprFilterRule) skipDMLByExpression(
-	rowData []types.Datum,
+	row chunk.Row,
 	expr expression.Expression,
 ) (bool, error) {
-	if len(rowData) == 0 || expr == nil {
+	if row.IsEmpty() || expr == nil {
 		return false, nil
 	}
 
-	row := chunk.MutRowFromDatums(rowData).ToRow()
-
 	d, err := expr.Eval(r.sessCtx.GetExprCtx().GetEvalCtx(), row)
 	if err != nil {
 		log.Error("failed to eval expression", zap.Error(err))
@@ -382,7 +377,7 @@ func (f *dmlExprFilter) addRule(
 }
 
 // verify checks if all rules in this filter is valid.
-func (f *dmlExprFilter) verify(tableInfos []*model.TableInfo) error {
+func (f *dmlExprFilter) verify(tableInfos []*common.TableInfo) error {
 	for _, rule := range f.rules {
 		err := rule.verify(tableInfos)
 		if err != nil {
@@ -405,25 +400,24 @@ func (f *dmlExprFilter) getRules(schema, table string) []*dmlExprFilterRule {
 
 // shouldSkipDML skips dml event by sql expression.
 func (f *dmlExprFilter) shouldSkipDML(
-	row *model.RowChangedEvent,
-	rawRow model.RowChangedDatums,
-	ti *model.TableInfo,
+	row *commonEvent.RowChange,
+	ti *common.TableInfo,
 ) (bool, error) {
 	if len(f.rules) == 0 {
 		return false, nil
 	}
 	// for defense purpose, normally the row and ti should not be nil.
-	if ti == nil || row == nil || rawRow.IsEmpty() {
+	if ti == nil || row == nil || (row.Row.IsEmpty() && row.PreRow.IsEmpty()) {
 		return false, nil
 	}
-	rules := f.getRules(row.TableInfo.GetSchemaName(), row.TableInfo.GetTableName())
+	rules := f.getRules(ti.GetSchemaName(), ti.GetTableName())
 	for _, rule := range rules {
-		ignore, err := rule.shouldSkipDML(row, rawRow, ti)
+		ignore, err := rule.shouldSkipDML(row, ti)
 		if err != nil {
 			if cerror.ShouldFailChangefeed(err) {
 				return false, err
 			}
-			return false, cerror.WrapError(cerror.ErrFailedToFilterDML, err, row)
+			return false, cerror.WrapError(cerror.ErrFailedToFilterDML, err, ti.TableName)
 		}
 		if ignore {
 			return true, nil
@@ -16,9 +16,11 @@ package filter
 import (
 	"sync"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/eventpb"
 	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
@@ -33,6 +35,8 @@ const (
 	SyncPointTable = "syncpoint_v1"
 	// DDLTsTable is the table name use to write ddl commitTs for each table when downstream is mysql-class
 	DDLTsTable = "ddl_ts_v1"
+	// DMLCheckpointTable is the table name use to write the dml progress of each dispatcher when downstream is mysql-class
+	DMLCheckpointTable = "dml_checkpoint_v1"
 
 	// TiCDCSystemSchema is the schema only use by TiCDC.
 	TiCDCSystemSchema = "tidb_cdc"
@@ -43,8 +47,8 @@ const (
 // Filter are safe for concurrent use.
 // TODO: find a better way to abstract this interface.
 type Filter interface {
-	// ShouldIgnoreDMLEvent returns true if the DML event should not be sent to downstream.
-	ShouldIgnoreDMLEvent(dml *model.RowChangedEvent, rawRow model.RowChangedDatums, tableInfo *model.TableInfo) (bool, error)
+	// ShouldIgnoreDMLEvent returns true if the row change should not be sent to downstream.
+	ShouldIgnoreDMLEvent(dml *commonEvent.RowChange, startTs uint64, tableInfo *common.TableInfo) (bool, error)
 	// ShouldIgnoreDDLEvent returns true if the DDL event should not be sent to downstream.
 	ShouldIgnoreDDLEvent(ddl *model.DDLEvent) (bool, error)
 	// ShouldDiscardDDL returns true if this DDL should be discarded.
@@ -57,7 +61,7 @@ type Filter interface {
 	ShouldIgnoreSchema(schema string) bool
 	// Verify should only be called by create changefeed OpenAPI.
 	// Its purpose is to verify the expression filter config.
-	Verify(tableInfos []*model.TableInfo) error
+	Verify(tableInfos []*common.TableInfo) error
 }
 
 // filter implements Filter.
@@ -164,32 +168,30 @@ func (f *filter) IsEligible(tableInfo *timodel.TableInfo) bool {
 	return false
 }
 
-// ShouldIgnoreDMLEvent checks if a DML event should be ignore by conditions below:
+// ShouldIgnoreDMLEvent checks if a row change should be ignore by conditions below:
 // 0. By startTs.
-// 1. By table name.
-// 2. By type.
-// 3. By columns value.
+// 1. By type.
+// 2. By columns value.
+//
+// The table name is not checked here, since the row changes only come from
+// the tables which are not ignored by ShouldIgnoreTable.
 func (f *filter) ShouldIgnoreDMLEvent(
-	dml *model.RowChangedEvent,
-	rawRow model.RowChangedDatums,
-	ti *model.TableInfo,
+	dml *commonEvent.RowChange,
+	startTs uint64,
+	ti *common.TableInfo,
 ) (bool, error) {
-	if f.shouldIgnoreStartTs(dml.StartTs) {
-		return true, nil
-	}
-
-	if f.ShouldIgnoreTable(dml.TableInfo.GetSchemaName(), dml.TableInfo.GetTableName(), nil) {
+	if f.shouldIgnoreStartTs(startTs) {
 		return true, nil
 	}
 
-	ignoreByEventType, err := f.sqlEventFilter.shouldSkipDML(dml)
+	ignoreByEventType, err := f.sqlEventFilter.shouldSkipDML(dml, ti)
 	if err != nil {
 		return false, err
 	}
 	if ignoreByEventType {
 		return true, nil
 	}
-	return f.dmlExprFilter.shouldSkipDML(dml, rawRow, ti)
+	return f.dmlExprFilter.shouldSkipDML(dml, ti)
 }
 
 // ShouldDiscardDDL checks if a DDL should be discarded by conditions below:
@@ -248,7 +250,7 @@ func (f *filter) ShouldIgnoreSchema(schema string) bool {
 	return IsSysSchema(schema) || !f.tableFilter.MatchSchema(schema)
 }
 
-func (f *filter) Verify(tableInfos []*model.TableInfo) error {
+func (f *filter) Verify(tableInfos []*common.TableInfo) error {
 	return f.dmlExprFilter.verify(tableInfos)
 }
 
@@ -284,14 +286,20 @@ var (
 
 type SharedFilterStorage struct {
 	// Each dispatcher in the same changefeed will share the same filter storage.
-	m     map[common.ChangeFeedID]Filter
+	m     map[common.ChangeFeedID]sharedFilter
 	mutex sync.Mutex
 }
 
+// sharedFilter is the filter of a changefeed and the config it's built from.
+type sharedFilter struct {
+	cfg    *eventpb.FilterConfig
+	filter Filter
+}
+
 func GetSharedFilterStorage() *SharedFilterStorage {
 	once.Do(func() {
 		storage = &SharedFilterStorage{
-			m: make(map[common.ChangeFeedID]Filter),
+			m: make(map[common.ChangeFeedID]sharedFilter),
 		}
 	})
 	return storage
@@ -305,8 +313,9 @@ func (s *SharedFilterStorage) GetOrSetFilter(
 ) (Filter, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	if f, ok := s.m[changeFeedID]; ok {
-		return f, nil
+	// The filter is rebuilt when the filter config of the changefeed is updated.
+	if f, ok := s.m[changeFeedID]; ok && proto.Equal(f.cfg, cfg) {
+		return f.filter, nil
 	}
 	// convert eventpb.FilterConfig to config.FilterConfig
 	filterCfg := &config.FilterConfig{
@@ -333,6 +342,6 @@ func (s *SharedFilterStorage) GetOrSetFilter(
 	if err != nil {
 		return nil, err
 	}
-	s.m[changeFeedID] = f
+	s.m[changeFeedID] = sharedFilter{cfg: cfg, filter: f}
 	return f, nil
 }
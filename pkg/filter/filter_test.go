@@ -0,0 +1,81 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	bf "github.com/pingcap/tiflow/pkg/binlog-filter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldIgnoreDMLEvent(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table test.t(id int primary key, name varchar(32), age int)")
+	tableInfo := helper.GetTableInfo(job)
+
+	cfg := config.NewDefaultFilterConfig()
+	cfg.EventFilters = []*config.EventFilterRule{
+		{
+			Matcher:     []string{"test.t"},
+			IgnoreEvent: []bf.EventType{bf.DeleteEvent},
+		},
+		{
+			Matcher:               []string{"test.t"},
+			IgnoreInsertValueExpr: "age > 18",
+		},
+	}
+	f, err := NewFilter(cfg, "", false, false)
+	require.NoError(t, err)
+	require.NoError(t, f.Verify([]*common.TableInfo{tableInfo}))
+
+	rawKvs := helper.DML2RawKv("test", "t",
+		"insert into test.t values (1, 'a', 10)",
+		"insert into test.t values (2, 'b', 20)",
+		"insert into test.t values (3, 'c', 18)",
+	)
+	mounter := commonEvent.NewMounter(time.Local)
+	dmlEvent := commonEvent.NewDMLEvent(common.NewDispatcherID(), tableInfo.TableName.TableID, rawKvs[0].StartTs, rawKvs[0].CRTs, tableInfo)
+	for _, rawKv := range rawKvs {
+		require.NoError(t, dmlEvent.AppendRow(rawKv, mounter.DecodeToChunk, f))
+	}
+	// The row with age 20 is ignored by the expression filter.
+	require.Equal(t, int32(2), dmlEvent.Len())
+	require.Equal(t, 2, dmlEvent.Rows.NumRows())
+	ids := make([]int64, 0, 2)
+	for {
+		row, ok := dmlEvent.GetNextRow()
+		if !ok {
+			break
+		}
+		ids = append(ids, row.Row.GetInt64(0))
+	}
+	require.Equal(t, []int64{1, 3}, ids)
+
+	// Delete events are ignored by the event type filter.
+	deleteRow := &commonEvent.RowChange{
+		PreRow:  dmlEvent.Rows.GetRow(0),
+		RowType: commonEvent.RowTypeDelete,
+	}
+	ignore, err := f.ShouldIgnoreDMLEvent(deleteRow, dmlEvent.StartTs, tableInfo)
+	require.NoError(t, err)
+	require.True(t, ignore)
+}
@@ -16,6 +16,8 @@ package filter
 import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
@@ -178,29 +180,29 @@ func (f *sqlEventFilter) shouldSkipDDL(ddl *model.DDLEvent) (skip bool, err erro
 }
 
 // shouldSkipDML skips dml event by its type.
-func (f *sqlEventFilter) shouldSkipDML(event *model.RowChangedEvent) (bool, error) {
+func (f *sqlEventFilter) shouldSkipDML(row *commonEvent.RowChange, tableInfo *common.TableInfo) (bool, error) {
 	if len(f.rules) == 0 {
 		return false, nil
 	}
 
 	var et bf.EventType
-	switch {
-	case event.IsInsert():
+	switch row.RowType {
+	case commonEvent.RowTypeInsert:
 		et = bf.InsertEvent
-	case event.IsUpdate():
+	case commonEvent.RowTypeUpdate:
 		et = bf.UpdateEvent
-	case event.IsDelete():
+	case commonEvent.RowTypeDelete:
 		et = bf.DeleteEvent
 	default:
 		// It should never happen.
 		log.Warn("unknown row changed event type")
 		return false, nil
 	}
-	rules := f.getRules(event.TableInfo.GetSchemaName(), event.TableInfo.GetTableName())
+	rules := f.getRules(tableInfo.GetSchemaName(), tableInfo.GetTableName())
 	for _, rule := range rules {
 		action, err := rule.bf.Filter(binlogFilterSchemaPlaceholder, binlogFilterTablePlaceholder, et, dmlQuery)
 		if err != nil {
-			return false, cerror.WrapError(cerror.ErrFailedToFilterDML, err, event)
+			return false, cerror.WrapError(cerror.ErrFailedToFilterDML, err, tableInfo.TableName)
 		}
 		if action == bf.Ignore {
 			return true, nil
@@ -82,6 +82,13 @@ const (
 	TypeMaintainerCloseResponse
 
 	TypeMessageHandShake
+
+	TypeUpdateMaintainerConfigRequest
+	TypeMaintainerUpdateConfigRequest
+
+	TypeDispatcherHeartbeat
+
+	TypeDrainNodeRequest
 )
 
 func (t IOType) String() string {
@@ -144,6 +151,14 @@ func (t IOType) String() string {
 		return "MessageHandShake"
 	case TypeCheckpointTsMessage:
 		return "CheckpointTsMessage"
+	case TypeUpdateMaintainerConfigRequest:
+		return "UpdateMaintainerConfigRequest"
+	case TypeMaintainerUpdateConfigRequest:
+		return "MaintainerUpdateConfigRequest"
+	case TypeDispatcherHeartbeat:
+		return "DispatcherHeartbeat"
+	case TypeDrainNodeRequest:
+		return "DrainNodeRequest"
 	default:
 	}
 	return "Unknown"
@@ -166,7 +181,7 @@ func (r RegisterDispatcherRequest) GetID() common.DispatcherID {
 }
 
 func (r RegisterDispatcherRequest) GetClusterID() uint64 {
-	return 0
+	return r.ClusterId
 }
 
 func (r RegisterDispatcherRequest) GetTopic() string {
@@ -214,6 +229,14 @@ func (r RegisterDispatcherRequest) IsOnlyReuse() bool {
 	return r.OnlyReuse
 }
 
+func (r RegisterDispatcherRequest) GetBDRMode() bool {
+	return r.BdrMode
+}
+
+func (r RegisterDispatcherRequest) IsHighPriority() bool {
+	return r.HighPriority
+}
+
 type IOTypeT interface {
 	Unmarshal(data []byte) error
 	Marshal() (data []byte, err error)
@@ -278,6 +301,14 @@ func decodeIOType(ioType IOType, value []byte) (IOTypeT, error) {
 		m = &heartbeatpb.MaintainerBootstrapRequest{}
 	case TypeCheckpointTsMessage:
 		m = &heartbeatpb.CheckpointTsMessage{}
+	case TypeUpdateMaintainerConfigRequest:
+		m = &heartbeatpb.UpdateMaintainerConfigRequest{}
+	case TypeMaintainerUpdateConfigRequest:
+		m = &heartbeatpb.MaintainerUpdateConfigRequest{}
+	case TypeDispatcherHeartbeat:
+		m = &eventpb.DispatcherHeartbeat{}
+	case TypeDrainNodeRequest:
+		m = &heartbeatpb.DrainNodeRequest{}
 	default:
 		log.Panic("Unimplemented IOType", zap.Stringer("Type", ioType))
 	}
@@ -365,6 +396,14 @@ func NewSingleTargetMessage(To node.ID, Topic string, Message IOTypeT, Group ...
 		ioType = TypeMaintainerCloseResponse
 	case *heartbeatpb.CheckpointTsMessage:
 		ioType = TypeCheckpointTsMessage
+	case *heartbeatpb.UpdateMaintainerConfigRequest:
+		ioType = TypeUpdateMaintainerConfigRequest
+	case *heartbeatpb.MaintainerUpdateConfigRequest:
+		ioType = TypeMaintainerUpdateConfigRequest
+	case *eventpb.DispatcherHeartbeat:
+		ioType = TypeDispatcherHeartbeat
+	case *heartbeatpb.DrainNodeRequest:
+		ioType = TypeDrainNodeRequest
 	default:
 		panic("unknown io type")
 	}
@@ -22,6 +22,20 @@ var (
 			Subsystem: "dynamic_stream",
 			Name:      "memory_usage",
 		}, []string{"component", "type"})
+	DynamicStreamStreamMemoryUsage = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "dynamic_stream",
+			Name:      "stream_memory_usage",
+			Help:      "The memory usage of pending events in each stream",
+		}, []string{"component", "stream"})
+	DynamicStreamStreamPendingQueueLen = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "dynamic_stream",
+			Name:      "stream_pending_queue_len",
+			Help:      "The number of pending events in each stream",
+		}, []string{"component", "stream"})
 	DynamicStreamEventChanSize = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "ticdc",
@@ -59,6 +73,8 @@ var (
 
 func InitDynamicStreamMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(DynamicStreamMemoryUsage)
+	registry.MustRegister(DynamicStreamStreamMemoryUsage)
+	registry.MustRegister(DynamicStreamStreamPendingQueueLen)
 	registry.MustRegister(DynamicStreamEventChanSize)
 	registry.MustRegister(DynamicStreamPendingQueueLen)
 	registry.MustRegister(DynamicStreamAddPathNum)
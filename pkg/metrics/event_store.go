@@ -142,6 +142,22 @@ var (
 			Help:      "The number of write requests received by event store.",
 		})
 
+	EventStoreOnDiskDataSizeGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "on_disk_data_size",
+			Help:      "The amount of disk space used by each db of event store.",
+		}, []string{"db"})
+
+	EventStoreCompactRangeCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "compact_range_count",
+			Help:      "The number of ranges compacted by event store after deleted.",
+		})
+
 	EventStoreReadDurationHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 		Namespace: "ticdc",
 		Subsystem: "event_store",
@@ -168,4 +184,6 @@ func InitEventStoreMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(EventStoreWriteBatchSizeHist)
 	registry.MustRegister(EventStoreWriteRequestsCount)
 	registry.MustRegister(EventStoreReadDurationHistogram)
+	registry.MustRegister(EventStoreOnDiskDataSizeGauge)
+	registry.MustRegister(EventStoreCompactRangeCount)
 }
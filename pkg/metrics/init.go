@@ -27,6 +27,7 @@ func InitMetrics(registry *prometheus.Registry) {
 	InitDispatcherMetrics(registry)
 	InitMessagingMetrics(registry)
 	InitSinkMetrics(registry)
+	InitRedoMetrics(registry)
 	InitPullerMetrics(registry)
 	InitEventStoreMetrics(registry)
 	InitSchemaStoreMetrics(registry)
@@ -0,0 +1,52 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// RedoWriteBytesGauge records the total number of bytes written to redo log.
+	RedoWriteBytesGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "ticdc",
+		Subsystem: "redo",
+		Name:      "write_bytes_total",
+		Help:      "Total number of bytes redo log written",
+	}, []string{"namespace", "changefeed", "type"})
+
+	// RedoFlushLogDurationHistogram records the latency distributions of flushing redo logs.
+	RedoFlushLogDurationHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "ticdc",
+		Subsystem: "redo",
+		Name:      "flush_log_duration_seconds",
+		Help:      "The latency distributions of flushing redo logs to storage",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2.0, 16),
+	}, []string{"namespace", "changefeed", "type"})
+
+	// RedoResolvedTsGauge records the resolved ts of the redo meta.
+	RedoResolvedTsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "ticdc",
+		Subsystem: "redo",
+		Name:      "resolved_ts",
+		Help:      "The resolved ts recorded in the redo meta",
+	}, []string{"namespace", "changefeed"})
+)
+
+// InitRedoMetrics registers all metrics in this file.
+func InitRedoMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(RedoWriteBytesGauge)
+	registry.MustRegister(RedoFlushLogDurationHistogram)
+	registry.MustRegister(RedoResolvedTsGauge)
+}
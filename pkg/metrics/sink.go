@@ -199,6 +199,56 @@ var (
 		}, []string{"namespace", "changefeed"})
 )
 
+// ---------- Metrics for cloud storage sink. ---------- //
+var (
+	// CloudStorageWriteBytesGauge records the total number of bytes written to cloud storage.
+	CloudStorageWriteBytesGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "cloud_storage_write_bytes_total",
+			Help:      "Total number of bytes written to cloud storage",
+		}, []string{"namespace", "changefeed"})
+
+	// CloudStorageFileCountGauge records the number of files generated by cloud storage sink.
+	CloudStorageFileCountGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "cloud_storage_file_count",
+			Help:      "Total number of files managed by a cloud storage sink",
+		}, []string{"namespace", "changefeed"})
+
+	// CloudStorageWriteDurationHistogram records the latency distributions of writing data files.
+	CloudStorageWriteDurationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "cloud_storage_write_duration_seconds",
+			Help:      "The latency distributions of write storage by a cloud storage sink",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2.0, 13),
+		}, []string{"namespace", "changefeed"})
+
+	// CloudStorageFlushDurationHistogram records the latency distributions of flushing files.
+	CloudStorageFlushDurationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "cloud_storage_flush_duration_seconds",
+			Help:      "The latency distributions of flush storage by a cloud storage sink",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2.0, 13),
+		}, []string{"namespace", "changefeed"})
+
+	// CloudStorageWorkerBusyRatioCounter records the busy ratio of cloud storage writers.
+	CloudStorageWorkerBusyRatioCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "cloud_storage_worker_busy_ratio",
+			Help:      "Busy ratio (X ms in 1s) for cloud storage sink dml worker.",
+		}, []string{"namespace", "changefeed", "id"})
+)
+
 // InitMetrics registers all metrics in this file.
 func InitSinkMetrics(registry *prometheus.Registry) {
 	// common sink metrics
@@ -225,4 +275,11 @@ func InitSinkMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(WorkerBatchDuration)
 	registry.MustRegister(CheckpointTsMessageDuration)
 	registry.MustRegister(CheckpointTsMessageCount)
+
+	// cloud storage sink metrics
+	registry.MustRegister(CloudStorageWriteBytesGauge)
+	registry.MustRegister(CloudStorageFileCountGauge)
+	registry.MustRegister(CloudStorageWriteDurationHistogram)
+	registry.MustRegister(CloudStorageFlushDurationHistogram)
+	registry.MustRegister(CloudStorageWorkerBusyRatioCounter)
 }
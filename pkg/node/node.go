@@ -15,6 +15,8 @@ package node
 
 import (
 	"encoding/json"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -98,3 +100,26 @@ func CaptureInfosToNodeInfos(captureInfos map[model.CaptureID]*model.CaptureInfo
 	}
 	return nodeInfos
 }
+
+// FormatLabels formats the labels of a node to the sorted `key=value` strings.
+func FormatLabels(labels map[string]string) []string {
+	res := make([]string, 0, len(labels))
+	for k, v := range labels {
+		res = append(res, k+"="+v)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// ParseLabels parses the `key=value` strings to the labels of a node, the invalid ones are ignored.
+func ParseLabels(labels []string) map[string]string {
+	res := make(map[string]string, len(labels))
+	for _, label := range labels {
+		k, v, ok := strings.Cut(label, "=")
+		if !ok {
+			continue
+		}
+		res[k] = v
+	}
+	return res
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+// RedoLogType is the type of a redo log record.
+type RedoLogType byte
+
+const (
+	// RedoLogTypeRow is the type of a redo log record which contains a DMLEvent.
+	RedoLogTypeRow RedoLogType = iota + 1
+	// RedoLogTypeDDL is the type of a redo log record which contains a DDLEvent.
+	RedoLogTypeDDL
+)
+
+// redoDMLHeader is the part of a DMLEvent that is persisted in redo log
+// besides the table info and the rows.
+type redoDMLHeader struct {
+	PhysicalTableID  int64                 `json:"physical_table_id"`
+	StartTs          uint64                `json:"start_ts"`
+	CommitTs         uint64                `json:"commit_ts"`
+	TableInfoVersion uint64                `json:"table_info_version"`
+	Length           int32                 `json:"length"`
+	RowTypes         []commonEvent.RowType `json:"row_types"`
+}
+
+// RedoLog is a single record in a redo log file.
+// Exactly one of DML and DDL is set according to the Type.
+type RedoLog struct {
+	Type RedoLogType
+	DML  *commonEvent.DMLEvent
+	DDL  *commonEvent.DDLEvent
+}
+
+// NewRedoLogFromDMLEvent creates a redo log record for the DMLEvent.
+func NewRedoLogFromDMLEvent(event *commonEvent.DMLEvent) *RedoLog {
+	return &RedoLog{Type: RedoLogTypeRow, DML: event}
+}
+
+// NewRedoLogFromDDLEvent creates a redo log record for the DDLEvent.
+func NewRedoLogFromDDLEvent(event *commonEvent.DDLEvent) *RedoLog {
+	return &RedoLog{Type: RedoLogTypeDDL, DDL: event}
+}
+
+// GetCommitTs returns the commitTs of the event in the redo log.
+func (r *RedoLog) GetCommitTs() uint64 {
+	switch r.Type {
+	case RedoLogTypeRow:
+		return r.DML.CommitTs
+	case RedoLogTypeDDL:
+		return r.DDL.FinishedTs
+	}
+	return 0
+}
+
+// Marshal encodes the redo log into bytes.
+// The layout of a row record is:
+// type | headerSize | header | tableInfoSize | tableInfo | rows
+// The layout of a ddl record is:
+// type | ddlEvent
+func (r *RedoLog) Marshal() ([]byte, error) {
+	switch r.Type {
+	case RedoLogTypeRow:
+		return marshalDMLEvent(r.DML)
+	case RedoLogTypeDDL:
+		data, err := r.DDL.Marshal()
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrMarshalFailed, err)
+		}
+		return append([]byte{byte(RedoLogTypeDDL)}, data...), nil
+	}
+	return nil, cerror.ErrUnexpected.GenWithStackByArgs("unknown redo log type")
+}
+
+// Unmarshal decodes the redo log from bytes.
+func (r *RedoLog) Unmarshal(data []byte) error {
+	if len(data) == 0 {
+		return cerror.ErrUnmarshalFailed.GenWithStack("empty redo log")
+	}
+	r.Type = RedoLogType(data[0])
+	switch r.Type {
+	case RedoLogTypeRow:
+		event, err := unmarshalDMLEvent(data[1:])
+		if err != nil {
+			return err
+		}
+		r.DML = event
+	case RedoLogTypeDDL:
+		event := &commonEvent.DDLEvent{}
+		if err := event.Unmarshal(data[1:]); err != nil {
+			return cerror.WrapError(cerror.ErrUnmarshalFailed, err)
+		}
+		r.DDL = event
+	default:
+		return cerror.ErrUnmarshalFailed.GenWithStack("unknown redo log type")
+	}
+	return nil
+}
+
+func marshalDMLEvent(event *commonEvent.DMLEvent) ([]byte, error) {
+	header, err := json.Marshal(&redoDMLHeader{
+		PhysicalTableID:  event.PhysicalTableID,
+		StartTs:          event.StartTs,
+		CommitTs:         event.CommitTs,
+		TableInfoVersion: event.TableInfoVersion,
+		Length:           event.Length,
+		RowTypes:         event.RowTypes,
+	})
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMarshalFailed, err)
+	}
+	tableInfo, err := event.TableInfo.Marshal()
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMarshalFailed, err)
+	}
+	rows := chunk.NewCodec(event.TableInfo.GetFieldSlice()).Encode(event.Rows)
+
+	data := make([]byte, 0, 1+4+len(header)+4+len(tableInfo)+len(rows))
+	data = append(data, byte(RedoLogTypeRow))
+	data = binary.BigEndian.AppendUint32(data, uint32(len(header)))
+	data = append(data, header...)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(tableInfo)))
+	data = append(data, tableInfo...)
+	data = append(data, rows...)
+	return data, nil
+}
+
+func unmarshalDMLEvent(data []byte) (*commonEvent.DMLEvent, error) {
+	readSection := func() ([]byte, error) {
+		if len(data) < 4 {
+			return nil, cerror.ErrUnmarshalFailed.GenWithStack("redo log is truncated")
+		}
+		size := int(binary.BigEndian.Uint32(data))
+		if len(data) < 4+size {
+			return nil, cerror.ErrUnmarshalFailed.GenWithStack("redo log is truncated")
+		}
+		section := data[4 : 4+size]
+		data = data[4+size:]
+		return section, nil
+	}
+
+	headerData, err := readSection()
+	if err != nil {
+		return nil, err
+	}
+	header := &redoDMLHeader{}
+	if err = json.Unmarshal(headerData, header); err != nil {
+		return nil, cerror.WrapError(cerror.ErrUnmarshalFailed, err)
+	}
+	tableInfoData, err := readSection()
+	if err != nil {
+		return nil, err
+	}
+	tableInfo, err := common.UnmarshalJSONToTableInfo(tableInfoData)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrUnmarshalFailed, err)
+	}
+	tableInfo.InitPrivateFields()
+	rows, _ := chunk.NewCodec(tableInfo.GetFieldSlice()).Decode(data)
+
+	return &commonEvent.DMLEvent{
+		Version:          commonEvent.DMLEventVersion,
+		PhysicalTableID:  header.PhysicalTableID,
+		StartTs:          header.StartTs,
+		CommitTs:         header.CommitTs,
+		TableInfoVersion: header.TableInfoVersion,
+		Length:           header.Length,
+		RowTypes:         header.RowTypes,
+		Rows:             rows,
+		TableInfo:        tableInfo,
+	}, nil
+}
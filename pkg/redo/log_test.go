@@ -0,0 +1,114 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	"testing"
+
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedoLogMarshalDML(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32), data blob)")
+	require.NotNil(t, job)
+
+	event := helper.DML2Event("test", "t",
+		"insert into t values (1, 'a', x'0102')",
+		"insert into t values (2, 'b', null)",
+		"insert into t values (3, 'c', x'03')")
+	event.StartTs = 100
+	event.CommitTs = 101
+	// turn the last two rows into an update row change.
+	event.RowTypes = []commonEvent.RowType{
+		commonEvent.RowTypeInsert, commonEvent.RowTypeUpdate, commonEvent.RowTypeUpdate,
+	}
+	event.Length = 2
+
+	data, err := NewRedoLogFromDMLEvent(event).Marshal()
+	require.NoError(t, err)
+
+	decoded := &RedoLog{}
+	require.NoError(t, decoded.Unmarshal(data))
+	require.Equal(t, RedoLogTypeRow, decoded.Type)
+	require.Equal(t, uint64(101), decoded.GetCommitTs())
+
+	dml := decoded.DML
+	require.Equal(t, event.PhysicalTableID, dml.PhysicalTableID)
+	require.Equal(t, event.StartTs, dml.StartTs)
+	require.Equal(t, event.TableInfoVersion, dml.TableInfoVersion)
+	require.Equal(t, event.Len(), dml.Len())
+	require.Equal(t, "test", dml.TableInfo.GetSchemaName())
+	require.Equal(t, "t", dml.TableInfo.GetTableName())
+
+	row, ok := dml.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, commonEvent.RowTypeInsert, row.RowType)
+	require.Equal(t, int64(1), row.Row.GetInt64(0))
+	require.Equal(t, "a", row.Row.GetString(1))
+	require.Equal(t, []byte{0x01, 0x02}, row.Row.GetBytes(2))
+
+	row, ok = dml.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, commonEvent.RowTypeUpdate, row.RowType)
+	require.Equal(t, int64(2), row.PreRow.GetInt64(0))
+	require.True(t, row.PreRow.IsNull(2))
+	require.Equal(t, int64(3), row.Row.GetInt64(0))
+	require.Equal(t, "c", row.Row.GetString(1))
+
+	_, ok = dml.GetNextRow()
+	require.False(t, ok)
+}
+
+func TestRedoLogMarshalDDL(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32))")
+	require.NotNil(t, job)
+
+	event := &commonEvent.DDLEvent{
+		Type:       byte(job.Type),
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		Query:      job.Query,
+		TableInfo:  helper.GetTableInfo(job),
+		FinishedTs: job.BinlogInfo.FinishedTS,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{0},
+		},
+	}
+
+	data, err := NewRedoLogFromDDLEvent(event).Marshal()
+	require.NoError(t, err)
+
+	decoded := &RedoLog{}
+	require.NoError(t, decoded.Unmarshal(data))
+	require.Equal(t, RedoLogTypeDDL, decoded.Type)
+	require.Equal(t, event.FinishedTs, decoded.GetCommitTs())
+	require.Equal(t, event.Query, decoded.DDL.Query)
+	require.Equal(t, event.SchemaName, decoded.DDL.SchemaName)
+	require.Equal(t, event.TableName, decoded.DDL.TableName)
+	require.Equal(t, event.BlockedTables, decoded.DDL.BlockedTables)
+	require.Equal(t, "t", decoded.DDL.TableInfo.GetTableName())
+
+	require.Error(t, decoded.Unmarshal(nil))
+	require.Error(t, decoded.Unmarshal([]byte{0xff}))
+}
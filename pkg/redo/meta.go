@@ -0,0 +1,88 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	predo "github.com/pingcap/tiflow/pkg/redo"
+)
+
+// LogMeta is the meta of the redo logs written by one redo writer.
+// All the events with commitTs <= CheckpointTs have been flushed to the downstream,
+// and all the events with commitTs <= ResolvedTs have been persisted in the redo logs.
+type LogMeta struct {
+	CheckpointTs uint64 `json:"checkpoint-ts"`
+	ResolvedTs   uint64 `json:"resolved-ts"`
+	// Compression is the compression algorithm of the log files.
+	Compression string `json:"compression,omitempty"`
+}
+
+// Marshal encodes the meta into bytes.
+func (m *LogMeta) Marshal() ([]byte, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMarshalFailed, err)
+	}
+	return data, nil
+}
+
+// Unmarshal decodes the meta from bytes.
+func (m *LogMeta) Unmarshal(data []byte) error {
+	if err := json.Unmarshal(data, m); err != nil {
+		return cerror.WrapError(cerror.ErrUnmarshalFailed, err)
+	}
+	return nil
+}
+
+// ParseMeta merges the metas written by different redo writers.
+// The resolvedTs written by every writer is the global redo resolvedTs of the
+// changefeed known by it, so the redo logs are complete up to the maximum resolvedTs.
+// Every writer only knows the checkpointTs of its own dispatchers, so the
+// downstream is flushed up to the minimum checkpointTs.
+func ParseMeta(metas []*LogMeta) *LogMeta {
+	if len(metas) == 0 {
+		return nil
+	}
+	result := &LogMeta{CheckpointTs: math.MaxUint64}
+	for _, meta := range metas {
+		result.CheckpointTs = min(result.CheckpointTs, meta.CheckpointTs)
+		result.ResolvedTs = max(result.ResolvedTs, meta.ResolvedTs)
+		if meta.Compression != "" {
+			result.Compression = meta.Compression
+		}
+	}
+	result.ResolvedTs = max(result.ResolvedTs, result.CheckpointTs)
+	return result
+}
+
+// GetLogFileName returns the name of a redo log file.
+// layout: writerID_namespace_changefeedID_fileType_maxEventCommitTs_uuid.log
+func GetLogFileName(
+	writerID string, changefeedID common.ChangeFeedID, fileType string, maxCommitTs uint64, uuid string,
+) string {
+	return fmt.Sprintf(predo.RedoLogFileFormatV2, writerID,
+		changefeedID.Namespace(), changefeedID.Name(), fileType, maxCommitTs, uuid, predo.LogEXT)
+}
+
+// GetMetaFileName returns the name of a redo meta file.
+// layout: writerID_namespace_changefeedID_meta_uuid.meta
+func GetMetaFileName(writerID string, changefeedID common.ChangeFeedID, uuid string) string {
+	return fmt.Sprintf(predo.RedoMetaFileFormat, writerID,
+		changefeedID.Namespace(), changefeedID.Name(), predo.RedoMetaFileType, uuid, predo.MetaEXT)
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	predo "github.com/pingcap/tiflow/pkg/redo"
+	"go.uber.org/zap"
+)
+
+// LogReader reads the redo logs and the redo meta from the external storage.
+type LogReader struct {
+	storage storage.ExternalStorage
+	meta    *LogMeta
+}
+
+// NewLogReader creates a LogReader and reads the redo meta from the storage.
+func NewLogReader(ctx context.Context, extStorage storage.ExternalStorage) (*LogReader, error) {
+	r := &LogReader{storage: extStorage}
+	if err := r.initMeta(ctx); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *LogReader) initMeta(ctx context.Context) error {
+	metas := make([]*LogMeta, 0)
+	err := r.storage.WalkDir(ctx, &storage.WalkOption{}, func(path string, size int64) error {
+		if filepath.Ext(path) != predo.MetaEXT {
+			return nil
+		}
+		data, err := r.storage.ReadFile(ctx, path)
+		if err != nil {
+			return err
+		}
+		if len(data) == 0 {
+			return nil
+		}
+		meta := &LogMeta{}
+		if err = meta.Unmarshal(data); err != nil {
+			return err
+		}
+		metas = append(metas, meta)
+		return nil
+	})
+	if err != nil {
+		return cerror.WrapError(cerror.ErrRedoMetaInitialize, err)
+	}
+	if len(metas) == 0 {
+		return cerror.ErrRedoMetaFileNotFound.GenWithStackByArgs(r.storage.URI())
+	}
+	r.meta = ParseMeta(metas)
+	return nil
+}
+
+// ReadMeta returns the checkpointTs and the resolvedTs of the redo logs.
+func (r *LogReader) ReadMeta() (checkpointTs, resolvedTs uint64) {
+	return r.meta.CheckpointTs, r.meta.ResolvedTs
+}
+
+// ReadLogs reads all the redo logs that need to be applied to the downstream,
+// and returns them sorted by commitTs.
+// DML events with commitTs in (checkpointTs, resolvedTs] are returned.
+// DDL events with commitTs in [checkpointTs, resolvedTs] are returned, because
+// the ddl at checkpointTs may not be executed in the downstream yet.
+// Duplicated DDL events are only returned once.
+func (r *LogReader) ReadLogs(ctx context.Context) ([]*RedoLog, error) {
+	checkpointTs, resolvedTs := r.ReadMeta()
+	logs := make([]*RedoLog, 0)
+	type ddlKey struct {
+		commitTs uint64
+		query    string
+	}
+	ddls := make(map[ddlKey]struct{})
+
+	err := r.storage.WalkDir(ctx, &storage.WalkOption{}, func(path string, size int64) error {
+		if !strings.HasSuffix(path, predo.LogEXT) {
+			return nil
+		}
+		maxCommitTs, fileType, err := predo.ParseLogFileName(filepath.Base(path))
+		if err != nil {
+			return cerror.WrapError(cerror.ErrRedoFileOp, err)
+		}
+		// all the events in the file are already flushed to the downstream.
+		if maxCommitTs < checkpointTs {
+			return nil
+		}
+		data, err := r.storage.ReadFile(ctx, path)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrRedoFileOp, err)
+		}
+		fileLogs, err := decodeLogFile(data, r.meta.Compression)
+		if err != nil {
+			return err
+		}
+		for _, l := range fileLogs {
+			commitTs := l.GetCommitTs()
+			if commitTs > resolvedTs {
+				continue
+			}
+			switch l.Type {
+			case RedoLogTypeRow:
+				if commitTs <= checkpointTs {
+					continue
+				}
+			case RedoLogTypeDDL:
+				if commitTs < checkpointTs {
+					continue
+				}
+				key := ddlKey{commitTs: commitTs, query: l.DDL.Query}
+				if _, ok := ddls[key]; ok {
+					continue
+				}
+				ddls[key] = struct{}{}
+			}
+			logs = append(logs, l)
+		}
+		log.Debug("redo log file read",
+			zap.String("file", path),
+			zap.String("fileType", fileType),
+			zap.Int("count", len(fileLogs)))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].GetCommitTs() < logs[j].GetCommitTs()
+	})
+	return logs, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/tiflow/pkg/compression"
+	predo "github.com/pingcap/tiflow/pkg/redo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMeta(t *testing.T) {
+	require.Nil(t, ParseMeta(nil))
+
+	meta := ParseMeta([]*LogMeta{
+		{CheckpointTs: 10, ResolvedTs: 20},
+		{CheckpointTs: 15, ResolvedTs: 18, Compression: compression.LZ4},
+	})
+	require.Equal(t, &LogMeta{CheckpointTs: 10, ResolvedTs: 20, Compression: compression.LZ4}, meta)
+
+	// resolvedTs is never less than checkpointTs.
+	meta = ParseMeta([]*LogMeta{
+		{CheckpointTs: 10, ResolvedTs: 5},
+	})
+	require.Equal(t, uint64(10), meta.ResolvedTs)
+}
+
+func TestWriteAndReadLogs(t *testing.T) {
+	ctx := context.Background()
+	extStorage, err := NewExternalStorage(ctx, fmt.Sprintf("file://%s", t.TempDir()))
+	require.NoError(t, err)
+
+	_, err = NewLogReader(ctx, extStorage)
+	require.Error(t, err)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32))")
+	require.NotNil(t, job)
+
+	newDML := func(commitTs uint64, sql string) *RedoLog {
+		event := helper.DML2Event("test", "t", sql)
+		event.CommitTs = commitTs
+		return NewRedoLogFromDMLEvent(event)
+	}
+	newDDL := func(commitTs uint64, query string) *RedoLog {
+		return NewRedoLogFromDDLEvent(&commonEvent.DDLEvent{
+			Type:       byte(job.Type),
+			SchemaName: "test",
+			TableName:  "t",
+			Query:      query,
+			TableInfo:  helper.GetTableInfo(job),
+			FinishedTs: commitTs,
+		})
+	}
+
+	changefeedID := common.NewChangefeedID4Test("test", "redo-reader")
+	rowWriter := NewFileWriter("w1", changefeedID, predo.RedoRowLogFileType, extStorage, 1, compression.LZ4)
+	defer rowWriter.Close()
+	ddlWriter := NewFileWriter("w1", changefeedID, predo.RedoDDLLogFileType, extStorage, 1, compression.LZ4)
+	defer ddlWriter.Close()
+
+	// events before the checkpointTs are skipped, and events after resolvedTs are skipped.
+	err = rowWriter.WriteLogs(ctx, []*RedoLog{
+		newDML(110, "insert into t values (1, 'a')"),
+		newDML(130, "insert into t values (3, 'c')"),
+		newDML(100, "insert into t values (0, 'z')"),
+	})
+	require.NoError(t, err)
+	err = rowWriter.WriteLogs(ctx, []*RedoLog{
+		newDML(120, "insert into t values (2, 'b')"),
+		newDML(200, "insert into t values (4, 'd')"),
+	})
+	require.NoError(t, err)
+	err = ddlWriter.WriteLogs(ctx, []*RedoLog{
+		newDDL(99, "create table t1 (id int primary key)"),
+		newDDL(100, "create table t2 (id int primary key)"),
+		newDDL(125, "create table t3 (id int primary key)"),
+	})
+	require.NoError(t, err)
+	// the same ddl may be written by different writers.
+	err = ddlWriter.WriteLogs(ctx, []*RedoLog{newDDL(125, "create table t3 (id int primary key)")})
+	require.NoError(t, err)
+
+	for i, meta := range []*LogMeta{
+		{CheckpointTs: 100, ResolvedTs: 140, Compression: compression.LZ4},
+		{CheckpointTs: 120, ResolvedTs: 150, Compression: compression.LZ4},
+	} {
+		data, err := meta.Marshal()
+		require.NoError(t, err)
+		name := GetMetaFileName(fmt.Sprintf("w%d", i), changefeedID, "uuid")
+		require.NoError(t, extStorage.WriteFile(ctx, name, data))
+	}
+
+	reader, err := NewLogReader(ctx, extStorage)
+	require.NoError(t, err)
+	checkpointTs, resolvedTs := reader.ReadMeta()
+	require.Equal(t, uint64(100), checkpointTs)
+	require.Equal(t, uint64(150), resolvedTs)
+
+	logs, err := reader.ReadLogs(ctx)
+	require.NoError(t, err)
+	commitTsList := make([]uint64, 0, len(logs))
+	types := make([]RedoLogType, 0, len(logs))
+	for _, l := range logs {
+		commitTsList = append(commitTsList, l.GetCommitTs())
+		types = append(types, l.Type)
+	}
+	require.Equal(t, []uint64{100, 110, 120, 125, 130}, commitTsList)
+	require.Equal(t, []RedoLogType{
+		RedoLogTypeDDL, RedoLogTypeRow, RedoLogTypeRow, RedoLogTypeDDL, RedoLogTypeRow,
+	}, types)
+	require.Equal(t, "create table t2 (id int primary key)", logs[0].DDL.Query)
+}
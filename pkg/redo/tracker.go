@@ -0,0 +1,73 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	"sync"
+
+	"github.com/pingcap/ticdc/pkg/common"
+)
+
+// ResolvedTsTracker tracks the redo resolvedTs of each dispatcher.
+// The redo resolvedTs of a dispatcher means all the events of the dispatcher
+// with commitTs <= resolvedTs have been persisted in the redo logs.
+type ResolvedTsTracker struct {
+	mu         sync.Mutex
+	resolvedTs map[common.DispatcherID]uint64
+}
+
+// NewResolvedTsTracker creates a ResolvedTsTracker.
+func NewResolvedTsTracker() *ResolvedTsTracker {
+	return &ResolvedTsTracker{
+		resolvedTs: make(map[common.DispatcherID]uint64),
+	}
+}
+
+// Add starts tracking the dispatcher from the startTs.
+func (t *ResolvedTsTracker) Add(id common.DispatcherID, startTs uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.resolvedTs[id] = startTs
+}
+
+// Remove stops tracking the dispatcher.
+func (t *ResolvedTsTracker) Remove(id common.DispatcherID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.resolvedTs, id)
+}
+
+// Update advances the resolvedTs of the dispatcher.
+// It's ignored if the dispatcher is not tracked.
+func (t *ResolvedTsTracker) Update(id common.DispatcherID, resolvedTs uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if ts, ok := t.resolvedTs[id]; ok && resolvedTs > ts {
+		t.resolvedTs[id] = resolvedTs
+	}
+}
+
+// Min returns the minimum resolvedTs of all the dispatchers.
+// ok is false if there is no dispatcher tracked.
+func (t *ResolvedTsTracker) Min() (resolvedTs uint64, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, ts := range t.resolvedTs {
+		if !ok || ts < resolvedTs {
+			resolvedTs = ts
+			ok = true
+		}
+	}
+	return resolvedTs, ok
+}
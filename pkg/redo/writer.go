@@ -0,0 +1,240 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/pkg/compression"
+	predo "github.com/pingcap/tiflow/pkg/redo"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// lengthFieldSize is the size of the length prefix of each record in a log file.
+	lengthFieldSize = 8
+	megabyte        = 1024 * 1024
+)
+
+// NewExternalStorage creates the external storage for redo logs from the uri.
+func NewExternalStorage(ctx context.Context, uri string) (storage.ExternalStorage, error) {
+	u, err := storage.ParseRawURL(uri)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrRedoConfigInvalid, err)
+	}
+	// "nfs" and "local" scheme are converted to "file" scheme
+	predo.FixLocalScheme(u)
+	// blackhole scheme is converted to "noop" scheme here, so we can use blackhole for testing
+	if predo.IsBlackholeStorage(u.Scheme) {
+		u, _ = storage.ParseRawURL("noop://")
+	}
+	return predo.InitExternalStorage(ctx, *u)
+}
+
+type logFile struct {
+	name        string
+	maxCommitTs uint64
+}
+
+// FileWriter writes redo logs of one file type into the external storage.
+// Every call of WriteLogs generates one or more log files,
+// and the size of each file is limited by maxLogSize.
+type FileWriter struct {
+	writerID     string
+	changefeedID common.ChangeFeedID
+	fileType     string
+	storage      storage.ExternalStorage
+	maxLogSize   int
+	compression  string
+
+	// files records the log files written by the writer, they are
+	// sorted by the written order, which is used to do garbage collection.
+	mu    sync.Mutex
+	files []logFile
+
+	metricWriteBytes    prometheus.Gauge
+	metricFlushDuration prometheus.Observer
+}
+
+// NewFileWriter creates a FileWriter.
+// maxLogSize is the max size of a log file in MiB.
+func NewFileWriter(
+	writerID string,
+	changefeedID common.ChangeFeedID,
+	fileType string,
+	extStorage storage.ExternalStorage,
+	maxLogSize int64,
+	compressionType string,
+) *FileWriter {
+	if maxLogSize <= 0 {
+		maxLogSize = predo.DefaultMaxLogSize
+	}
+	return &FileWriter{
+		writerID:     writerID,
+		changefeedID: changefeedID,
+		fileType:     fileType,
+		storage:      extStorage,
+		maxLogSize:   int(maxLogSize) * megabyte,
+		compression:  normalizeCompression(compressionType),
+		metricWriteBytes: metrics.RedoWriteBytesGauge.
+			WithLabelValues(changefeedID.Namespace(), changefeedID.Name(), fileType),
+		metricFlushDuration: metrics.RedoFlushLogDurationHistogram.
+			WithLabelValues(changefeedID.Namespace(), changefeedID.Name(), fileType),
+	}
+}
+
+// WriteLogs encodes the logs and writes them into the external storage.
+// It returns after all the logs are persisted.
+func (w *FileWriter) WriteLogs(ctx context.Context, logs []*RedoLog) error {
+	var (
+		buf         []byte
+		maxCommitTs uint64
+	)
+	for _, l := range logs {
+		data, err := l.Marshal()
+		if err != nil {
+			return err
+		}
+		if len(buf) > 0 && len(buf)+lengthFieldSize+len(data) > w.maxLogSize {
+			if err = w.writeFile(ctx, buf, maxCommitTs); err != nil {
+				return err
+			}
+			buf = buf[:0]
+			maxCommitTs = 0
+		}
+		buf = binary.LittleEndian.AppendUint64(buf, uint64(len(data)))
+		buf = append(buf, data...)
+		maxCommitTs = max(maxCommitTs, l.GetCommitTs())
+	}
+	if len(buf) == 0 {
+		return nil
+	}
+	return w.writeFile(ctx, buf, maxCommitTs)
+}
+
+func (w *FileWriter) writeFile(ctx context.Context, data []byte, maxCommitTs uint64) error {
+	start := time.Now()
+	data, err := compression.Encode(w.compression, data)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrRedoFileOp, err)
+	}
+	name := GetLogFileName(w.writerID, w.changefeedID, w.fileType, maxCommitTs, uuid.NewString())
+	if err = w.storage.WriteFile(ctx, name, data); err != nil {
+		return cerror.WrapError(cerror.ErrRedoFileOp, err)
+	}
+	w.mu.Lock()
+	w.files = append(w.files, logFile{name: name, maxCommitTs: maxCommitTs})
+	w.mu.Unlock()
+
+	w.metricWriteBytes.Add(float64(len(data)))
+	w.metricFlushDuration.Observe(time.Since(start).Seconds())
+	log.Debug("redo log file written",
+		zap.String("namespace", w.changefeedID.Namespace()),
+		zap.String("changefeed", w.changefeedID.Name()),
+		zap.String("file", name),
+		zap.Int("size", len(data)))
+	return nil
+}
+
+// GC removes the log files written by the writer whose events are all flushed
+// to the downstream, that is, the maxCommitTs of the file is less than the checkpointTs.
+func (w *FileWriter) GC(ctx context.Context, checkpointTs uint64) error {
+	return w.removeFiles(ctx, func(f logFile) bool {
+		return f.maxCommitTs < checkpointTs
+	})
+}
+
+// RemoveAll removes all the log files written by the writer.
+func (w *FileWriter) RemoveAll(ctx context.Context) error {
+	return w.removeFiles(ctx, func(logFile) bool { return true })
+}
+
+func (w *FileWriter) removeFiles(ctx context.Context, shouldRemove func(logFile) bool) error {
+	w.mu.Lock()
+	toRemove := make([]string, 0)
+	remained := w.files[:0]
+	for _, f := range w.files {
+		if shouldRemove(f) {
+			toRemove = append(toRemove, f.name)
+		} else {
+			remained = append(remained, f)
+		}
+	}
+	w.files = remained
+	w.mu.Unlock()
+
+	if len(toRemove) == 0 {
+		return nil
+	}
+	if err := w.storage.DeleteFiles(ctx, toRemove); err != nil {
+		return cerror.WrapError(cerror.ErrRedoFileOp, err)
+	}
+	log.Debug("redo log files removed",
+		zap.String("namespace", w.changefeedID.Namespace()),
+		zap.String("changefeed", w.changefeedID.Name()),
+		zap.Strings("files", toRemove))
+	return nil
+}
+
+// Close cleans the metrics of the writer.
+func (w *FileWriter) Close() {
+	metrics.RedoWriteBytesGauge.
+		DeleteLabelValues(w.changefeedID.Namespace(), w.changefeedID.Name(), w.fileType)
+	metrics.RedoFlushLogDurationHistogram.
+		DeleteLabelValues(w.changefeedID.Namespace(), w.changefeedID.Name(), w.fileType)
+}
+
+// normalizeCompression converts the empty compression to `none`.
+func normalizeCompression(compressionType string) string {
+	if compressionType == "" {
+		return compression.None
+	}
+	return compressionType
+}
+
+// decodeLogFile splits the content of a log file into redo logs.
+func decodeLogFile(data []byte, compressionType string) ([]*RedoLog, error) {
+	data, err := compression.Decode(normalizeCompression(compressionType), data)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrRedoFileOp, err)
+	}
+	logs := make([]*RedoLog, 0)
+	for len(data) > 0 {
+		if len(data) < lengthFieldSize {
+			return nil, cerror.ErrRedoFileOp.GenWithStack("redo log file is truncated")
+		}
+		size := int(binary.LittleEndian.Uint64(data))
+		data = data[lengthFieldSize:]
+		if len(data) < size {
+			return nil, cerror.ErrRedoFileOp.GenWithStack("redo log file is truncated")
+		}
+		l := &RedoLog{}
+		if err = l.Unmarshal(data[:size]); err != nil {
+			return nil, err
+		}
+		logs = append(logs, l)
+		data = data[size:]
+	}
+	return logs, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler/replica"
+	"github.com/pingcap/ticdc/server/watcher"
+	"go.uber.org/zap"
+)
+
+// NodeAffinity is optionally implemented by the replications which prefer to run on
+// the nodes with some specific labels, e.g. the latency critical changefeeds.
+type NodeAffinity interface {
+	HasNodeAffinity() bool
+	MatchNodeLabels(labels map[string]string) bool
+}
+
+// getNodeAffinity returns the node affinity of the replication, it returns false if the
+// replication does not care about which node it runs on.
+func getNodeAffinity[R any](r R) (NodeAffinity, bool) {
+	affinity, ok := any(r).(NodeAffinity)
+	if !ok || !affinity.HasNodeAffinity() {
+		return nil, false
+	}
+	return affinity, true
+}
+
+// pickAffinityNode returns the node with the least tasks among the candidates which match
+// the node affinity of the replication, it returns false if the replication has no affinity
+// or no candidate matches it.
+func pickAffinityNode[R any](r R, nodeManager *watcher.NodeManager, nodeTasks map[node.ID]int) (node.ID, bool) {
+	affinity, ok := getNodeAffinity(r)
+	if !ok {
+		return "", false
+	}
+	var target node.ID
+	found := false
+	for id, size := range nodeTasks {
+		if !affinity.MatchNodeLabels(nodeManager.GetNodeLabels(id)) {
+			continue
+		}
+		if !found || size < nodeTasks[target] || (size == nodeTasks[target] && id < target) {
+			target, found = id, true
+		}
+	}
+	return target, found
+}
+
+// isPinnedByAffinity returns true if the replication runs on a node matches its node affinity,
+// such replication should not be moved away by the balance.
+func isPinnedByAffinity[R any](r R, nodeManager *watcher.NodeManager, nodeID node.ID) bool {
+	affinity, ok := getNodeAffinity(r)
+	return ok && affinity.MatchNodeLabels(nodeManager.GetNodeLabels(nodeID))
+}
+
+// scheduleWithAffinity schedules the tasks with node affinity to the matched nodes with the least tasks,
+// and returns the rest tasks. A task is scheduled as a normal one if no node matches its affinity.
+func scheduleWithAffinity[T replica.ReplicationID, R replica.Replication[T]](
+	id string, tasks []R,
+	nodeManager *watcher.NodeManager,
+	nodeTasks map[node.ID]int,
+	schedule func(R, node.ID) bool,
+) []R {
+	rest := tasks[:0]
+	for _, task := range tasks {
+		if _, ok := getNodeAffinity(task); !ok {
+			rest = append(rest, task)
+			continue
+		}
+		target, ok := pickAffinityNode(task, nodeManager, nodeTasks)
+		if !ok {
+			log.Warn("scheduler: no node matches the affinity, schedule it to any node",
+				zap.String("id", id),
+				zap.String("replica", task.GetID().String()))
+			rest = append(rest, task)
+			continue
+		}
+		if schedule(task, target) {
+			nodeTasks[target]++
+		}
+	}
+	return rest
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/scheduler/operator"
 	"github.com/pingcap/ticdc/pkg/scheduler/replica"
@@ -48,6 +49,11 @@ type balanceScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R re
 	// It speeds up rebalance.
 	forceBalance bool
 
+	// strategy is the balance strategy, see config.BalanceStrategyCount and config.BalanceStrategyLoad.
+	strategy string
+	// nodeLoads provides the runtime load of the nodes for the load balance strategy, it can be nil.
+	nodeLoads NodeLoadProvider
+
 	newMoveOperator func(r R, source, target node.ID) operator.Operator[T, S]
 }
 
@@ -55,6 +61,7 @@ func NewBalanceScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R
 	id string, batchSize int,
 	oc operator.Controller[T, S], db replica.ScheduleGroup[T, R],
 	nodeManager *watcher.NodeManager, balanceInterval time.Duration,
+	nodeLoads NodeLoadProvider,
 	newMoveOperator func(R, node.ID, node.ID) operator.Operator[T, S],
 ) *balanceScheduler[T, S, R] {
 	return &balanceScheduler[T, S, R]{
@@ -66,6 +73,8 @@ func NewBalanceScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R
 		nodeManager:          nodeManager,
 		checkBalanceInterval: balanceInterval,
 		lastRebalanceTime:    time.Now(),
+		strategy:             config.GetGlobalServerConfig().Debug.Scheduler.BalanceStrategy,
+		nodeLoads:            nodeLoads,
 		newMoveOperator:      newMoveOperator,
 	}
 }
@@ -85,11 +94,26 @@ func (s *balanceScheduler[T, S, R]) Execute() time.Time {
 		return now.Add(s.checkBalanceInterval)
 	}
 
-	nodes := s.nodeManager.GetAliveNodes()
-	moved := s.schedulerGroup(nodes)
-	if moved == 0 {
-		// all groups are balanced, safe to do the global balance
-		moved = s.schedulerGlobal(nodes)
+	if s.isDraining() {
+		// the drain scheduler is moving the tasks away from the unschedulable nodes, skip balance
+		return now.Add(s.checkBalanceInterval)
+	}
+
+	nodes := s.nodeManager.GetSchedulableNodes()
+	// move the tasks to the nodes matching their affinity first
+	moved := s.schedulerAffinity(nodes)
+	if s.strategy == config.BalanceStrategyLoad {
+		if moved == 0 {
+			moved = s.schedulerLoad(nodes)
+		}
+	} else {
+		if moved == 0 {
+			moved = s.schedulerGroup(nodes)
+		}
+		if moved == 0 {
+			// all groups are balanced, safe to do the global balance
+			moved = s.schedulerGlobal(nodes)
+		}
 	}
 
 	s.forceBalance = moved >= s.batchSize
@@ -106,8 +130,15 @@ func (s *balanceScheduler[T, S, R]) schedulerGroup(nodes map[node.ID]*node.Info)
 			// no need to do the balance, skip
 			continue
 		}
+		// the tasks running on the nodes matching their affinity are not moved
 		replicas := s.db.GetReplicatingByGroup(group)
-		moveSize = Balance(availableSize, s.random, nodes, replicas, s.doMove)
+		movable := replicas[:0]
+		for _, r := range replicas {
+			if !isPinnedByAffinity(r, s.nodeManager, r.GetNodeID()) {
+				movable = append(movable, r)
+			}
+		}
+		moveSize = Balance(availableSize, s.random, nodes, movable, s.doMove)
 		totalMoved += moveSize
 		if totalMoved >= s.batchSize {
 			break
@@ -157,6 +188,9 @@ func (s *balanceScheduler[T, S, R]) schedulerGlobal(nodes map[node.ID]*node.Info
 		availableNodes, victims, nextVictim := []node.ID{}, []node.ID{}, 0
 		for id, task := range nodeTasks {
 			if task != zero && sizePerNode[id] > lowerLimitPerNode {
+				if isPinnedByAffinity(task, s.nodeManager, id) {
+					continue
+				}
 				victims = append(victims, id)
 			} else if task == zero && sizePerNode[id] < lowerLimitPerNode {
 				// Notice: do not handle equal case (sizePerNode[id] == lowerLimitPerNode).
@@ -185,6 +219,66 @@ func (s *balanceScheduler[T, S, R]) schedulerGlobal(nodes map[node.ID]*node.Info
 	return moved
 }
 
+// schedulerLoad balances the tasks by the load of each node, it replaces both the group
+// and the global balance, since the load of the tasks in a group is not even.
+func (s *balanceScheduler[T, S, R]) schedulerLoad(nodes map[node.ID]*node.Info) int {
+	var nodeLoads map[node.ID]NodeLoad
+	if s.nodeLoads != nil {
+		nodeLoads = s.nodeLoads.GetNodeLoads()
+	}
+	// the tasks running on the nodes matching their affinity are not moved
+	pinned := func(r R) bool { return isPinnedByAffinity(r, s.nodeManager, r.GetNodeID()) }
+	return BalanceByLoad(s.batchSize, nodes, s.db.GetReplicating(), nodeLoads, pinned, s.doMove)
+}
+
+// schedulerAffinity moves the tasks which are not running on the nodes matching their affinity,
+// e.g. the matched nodes are not online when the tasks are scheduled.
+func (s *balanceScheduler[T, S, R]) schedulerAffinity(nodes map[node.ID]*node.Info) int {
+	nodeSize := s.db.GetTaskSizePerNode()
+	for id := range nodeSize {
+		if _, ok := nodes[id]; !ok {
+			delete(nodeSize, id)
+		}
+	}
+	for id := range nodes {
+		if _, ok := nodeSize[id]; !ok {
+			nodeSize[id] = 0
+		}
+	}
+	moved := 0
+	for _, r := range s.db.GetReplicating() {
+		if _, ok := getNodeAffinity(r); !ok || isPinnedByAffinity(r, s.nodeManager, r.GetNodeID()) {
+			continue
+		}
+		target, ok := pickAffinityNode(r, s.nodeManager, nodeSize)
+		if !ok {
+			continue
+		}
+		if s.doMove(r, target) {
+			nodeSize[target]++
+			moved++
+			if moved >= s.batchSize {
+				break
+			}
+		}
+	}
+	if moved > 0 {
+		log.Info("scheduler: move tasks to the nodes matching the affinity",
+			zap.String("id", s.id), zap.Int("moved", moved))
+	}
+	return moved
+}
+
+// isDraining returns true if there are tasks on the unschedulable nodes
+func (s *balanceScheduler[T, S, R]) isDraining() bool {
+	for _, id := range s.nodeManager.GetUnschedulableNodes() {
+		if s.db.GetTaskSizeByNodeID(id) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *balanceScheduler[T, S, R]) doMove(replication R, id node.ID) bool {
 	op := s.newMoveOperator(replication, replication.GetNodeID(), id)
 	return s.operatorController.AddOperator(op)
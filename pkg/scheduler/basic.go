@@ -14,6 +14,7 @@
 package scheduler
 
 import (
+	"sort"
 	"time"
 
 	"github.com/pingcap/log"
@@ -68,7 +69,13 @@ func (s *basicScheduler[T, S, R]) Execute() time.Time {
 		return time.Now().Add(time.Millisecond * 100)
 	}
 
-	for _, id := range s.db.GetGroups() {
+	groups := s.db.GetGroups()
+	// schedule the high priority groups first
+	sort.SliceStable(groups, func(i, j int) bool {
+		return replica.GetGroupType(groups[i]) == replica.GroupHighPriority &&
+			replica.GetGroupType(groups[j]) != replica.GroupHighPriority
+	})
+	for _, id := range groups {
 		availableSize -= s.schedule(id, availableSize)
 		if availableSize <= 0 {
 			break
@@ -80,18 +87,30 @@ func (s *basicScheduler[T, S, R]) Execute() time.Time {
 func (s *basicScheduler[T, S, R]) schedule(id replica.GroupID, availableSize int) (scheduled int) {
 	absent := s.db.GetAbsentByGroup(id, availableSize)
 	nodeSize := s.db.GetTaskSizePerNodeByGroup(id)
+	schedulableNodes := s.nodeManager.GetSchedulableNodes()
+	// the unschedulable nodes are being drained, do not schedule new tasks to them
+	for id := range nodeSize {
+		if _, ok := schedulableNodes[id]; !ok {
+			delete(nodeSize, id)
+		}
+	}
 	// add the absent node to the node size map
-	for id := range s.nodeManager.GetAliveNodes() {
+	for id := range schedulableNodes {
 		if _, ok := nodeSize[id]; !ok {
 			nodeSize[id] = 0
 		}
 	}
+	scheduled = len(absent)
+	// the tasks with node affinity are scheduled to the matched nodes first
+	absent = scheduleWithAffinity(s.id, absent, s.nodeManager, nodeSize, func(replication R, id node.ID) bool {
+		op := s.newAddOperator(replication, id)
+		return s.operatorController.AddOperator(op)
+	})
 	// what happens if the some node removed when scheduling?
 	BasicSchedule(availableSize, absent, nodeSize, func(replication R, id node.ID) bool {
 		op := s.newAddOperator(replication, id)
 		return s.operatorController.AddOperator(op)
 	})
-	scheduled = len(absent)
 	s.absent = absent[:0]
 	return
 }
@@ -0,0 +1,125 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler/operator"
+	"github.com/pingcap/ticdc/pkg/scheduler/replica"
+	"github.com/pingcap/ticdc/server/watcher"
+	"go.uber.org/zap"
+)
+
+// drainScheduler moves the tasks away from the unschedulable nodes, which are being drained,
+// the tasks are moved to the schedulable nodes with the least tasks.
+type drainScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R replica.Replication[T]] struct {
+	id        string
+	batchSize int
+
+	operatorController operator.Controller[T, S]
+	db                 replica.ScheduleGroup[T, R]
+	nodeManager        *watcher.NodeManager
+
+	newMoveOperator func(r R, source, target node.ID) operator.Operator[T, S]
+}
+
+func NewDrainScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R replica.Replication[T]](
+	id string, batchSize int,
+	oc operator.Controller[T, S], db replica.ScheduleGroup[T, R],
+	nodeManager *watcher.NodeManager,
+	newMoveOperator func(R, node.ID, node.ID) operator.Operator[T, S],
+) *drainScheduler[T, S, R] {
+	return &drainScheduler[T, S, R]{
+		id:                 id,
+		batchSize:          batchSize,
+		operatorController: oc,
+		db:                 db,
+		nodeManager:        nodeManager,
+		newMoveOperator:    newMoveOperator,
+	}
+}
+
+func (s *drainScheduler[T, S, R]) Execute() time.Time {
+	now := time.Now()
+	drainingNodes := s.nodeManager.GetUnschedulableNodes()
+	if len(drainingNodes) == 0 {
+		return now.Add(time.Second)
+	}
+	availableSize := s.batchSize - s.operatorController.OperatorSize()
+	if availableSize <= 0 {
+		// too many running operators, wait for them to finish
+		return now.Add(time.Millisecond * 500)
+	}
+	schedulableNodes := s.nodeManager.GetSchedulableNodes()
+	if len(schedulableNodes) == 0 {
+		log.Warn("scheduler: no schedulable node to drain the tasks to, skip",
+			zap.String("id", s.id),
+			zap.Any("drainingNodes", drainingNodes))
+		return now.Add(time.Second)
+	}
+
+	nodeSize := make(map[node.ID]int, len(schedulableNodes))
+	for id, size := range s.db.GetTaskSizePerNode() {
+		if _, ok := schedulableNodes[id]; ok {
+			nodeSize[id] = size
+		}
+	}
+	for id := range schedulableNodes {
+		if _, ok := nodeSize[id]; !ok {
+			nodeSize[id] = 0
+		}
+	}
+
+	var victims []R
+	for _, id := range drainingNodes {
+		victims = append(victims, s.db.GetTaskByNodeID(id)...)
+		if len(victims) >= availableSize {
+			break
+		}
+	}
+	if len(victims) == 0 {
+		return now.Add(time.Second)
+	}
+	if len(victims) > availableSize {
+		victims = victims[:availableSize]
+	}
+	moved := 0
+	move := func(replication R, target node.ID) bool {
+		op := s.newMoveOperator(replication, replication.GetNodeID(), target)
+		if s.operatorController.AddOperator(op) {
+			moved++
+			return true
+		}
+		return false
+	}
+	victims = scheduleWithAffinity(s.id, victims, s.nodeManager, nodeSize, move)
+	if len(victims) > 0 {
+		BasicSchedule(availableSize-moved, victims, nodeSize, move)
+	}
+	if moved > 0 {
+		log.Info("scheduler: drain tasks from the unschedulable nodes",
+			zap.String("id", s.id),
+			zap.Any("drainingNodes", drainingNodes),
+			zap.Int("victims", len(victims)),
+			zap.Int("moved", moved))
+	}
+	return now.Add(time.Millisecond * 500)
+}
+
+func (s *drainScheduler[T, S, R]) Name() string {
+	return DrainScheduler
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler/replica"
+	"go.uber.org/zap"
+)
+
+const (
+	// taskBaseLoad is the load of an idle task, relative to a task writing at the
+	// average throughput, so the idle tasks are still spread among the nodes.
+	taskBaseLoad = 0.1
+	// loadImbalanceRatio is the tolerated ratio of the node load exceeding the average,
+	// the balance is skipped if the most loaded node is within it.
+	loadImbalanceRatio = 0.1
+	// sinkLagPressureThreshold is the sink lag which makes a node fully pressured.
+	sinkLagPressureThreshold = time.Minute
+)
+
+// LoadReplication is optionally implemented by the replications which report their
+// write throughput, it's used by the load balance strategy to weigh the tasks.
+type LoadReplication interface {
+	// GetLoad returns the write throughput of the replication, in bytes per second.
+	GetLoad() float64
+}
+
+// NodeLoad is the runtime load of a node besides the tasks running on it.
+type NodeLoad struct {
+	// MemoryUsageRatio is the used ratio of the event collector memory quota.
+	MemoryUsageRatio float64
+	// SinkLag is the checkpoint lag of the sinks.
+	SinkLag time.Duration
+}
+
+// pressure returns the pressure of the node in [0, 1], a fully pressured node
+// is treated as carrying twice of its task load.
+func (l NodeLoad) pressure() float64 {
+	p := l.MemoryUsageRatio
+	if lag := float64(l.SinkLag) / float64(sinkLagPressureThreshold); lag > p {
+		p = lag
+	}
+	return min(max(p, 0), 1)
+}
+
+// NodeLoadProvider provides the runtime load of the nodes.
+type NodeLoadProvider interface {
+	GetNodeLoads() map[node.ID]NodeLoad
+}
+
+// getTaskLoads returns the load of each task, the write throughput is normalized by the
+// average throughput, so a hot task weighs more than many cold tasks.
+func getTaskLoads[T replica.ReplicationID, R replica.Replication[T]](replicating []R) []float64 {
+	loads := make([]float64, len(replicating))
+	total := 0.0
+	for i, r := range replicating {
+		if lr, ok := any(r).(LoadReplication); ok && lr.GetLoad() > 0 {
+			loads[i] = lr.GetLoad()
+			total += loads[i]
+		}
+	}
+	avg := total / float64(len(replicating))
+	for i := range loads {
+		if avg > 0 {
+			loads[i] /= avg
+		}
+		loads[i] += taskBaseLoad
+	}
+	return loads
+}
+
+// BalanceByLoad balances the running tasks by the load of each node, the load of a node
+// is the sum of its task loads amplified by the node pressure. It keeps moving a task from
+// the most loaded node to the least loaded one while the move lowers the peak load.
+// The tasks of a non-default group are not moved to a node already holding more tasks of
+// the group than the source node, so the spans of a split table stay spread. The pinned
+// tasks count in the node load but are never moved.
+func BalanceByLoad[T replica.ReplicationID, R replica.Replication[T]](
+	batchSize int,
+	activeNodes map[node.ID]*node.Info,
+	replicating []R, nodeLoads map[node.ID]NodeLoad,
+	pinned func(R) bool, move func(R, node.ID) bool,
+) (movedSize int) {
+	if len(activeNodes) < 2 || len(replicating) == 0 {
+		return 0
+	}
+	type task struct {
+		r    R
+		load float64
+	}
+	taskLoads := getTaskLoads[T](replicating)
+	nodeTasks := make(map[node.ID][]task, len(activeNodes))
+	nodeSum := make(map[node.ID]float64, len(activeNodes))
+	groupSize := make(map[node.ID]map[replica.GroupID]int, len(activeNodes))
+	for id := range activeNodes {
+		groupSize[id] = make(map[replica.GroupID]int)
+	}
+	for i, r := range replicating {
+		id := r.GetNodeID()
+		if _, ok := activeNodes[id]; !ok {
+			continue
+		}
+		if !pinned(r) {
+			nodeTasks[id] = append(nodeTasks[id], task{r: r, load: taskLoads[i]})
+		}
+		nodeSum[id] += taskLoads[i]
+		groupSize[id][r.GetGroupID()]++
+	}
+	for id, tasks := range nodeTasks {
+		// try the heavy tasks first
+		sort.Slice(tasks, func(i, j int) bool { return tasks[i].load > tasks[j].load })
+		nodeTasks[id] = tasks
+	}
+	factor := func(id node.ID) float64 { return 1 + nodeLoads[id].pressure() }
+	loadOf := func(id node.ID) float64 { return nodeSum[id] * factor(id) }
+
+	for movedSize < batchSize {
+		var source, target node.ID
+		total, first := 0.0, true
+		for id := range activeNodes {
+			load := loadOf(id)
+			total += load
+			if first || load > loadOf(source) || (load == loadOf(source) && id < source) {
+				source = id
+			}
+			if first || load < loadOf(target) || (load == loadOf(target) && id < target) {
+				target = id
+			}
+			first = false
+		}
+		peak := loadOf(source)
+		if peak <= total/float64(len(activeNodes))*(1+loadImbalanceRatio) {
+			break
+		}
+		// pick the task which lowers the peak load the most
+		victim, best := -1, peak
+		for i, t := range nodeTasks[source] {
+			groupID := t.r.GetGroupID()
+			if groupID != replica.DefaultGroupID && groupSize[target][groupID] >= groupSize[source][groupID] {
+				continue
+			}
+			newPeak := max((nodeSum[source]-t.load)*factor(source), (nodeSum[target]+t.load)*factor(target))
+			if newPeak < best {
+				victim, best = i, newPeak
+			}
+		}
+		if victim < 0 {
+			break
+		}
+		t := nodeTasks[source][victim]
+		nodeTasks[source] = append(nodeTasks[source][:victim], nodeTasks[source][victim+1:]...)
+		if !move(t.r, target) {
+			continue
+		}
+		nodeSum[source] -= t.load
+		nodeSum[target] += t.load
+		groupSize[source][t.r.GetGroupID()]--
+		groupSize[target][t.r.GetGroupID()]++
+		movedSize++
+	}
+
+	if movedSize > 0 {
+		log.Info("scheduler: balance by load done", zap.Int("movedSize", movedSize))
+	}
+	return movedSize
+}
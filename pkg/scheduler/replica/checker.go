@@ -27,6 +27,8 @@ const DefaultGroupID GroupID = 0
 const (
 	GroupDefault GroupTpye = iota
 	GroupTable
+	// GroupHighPriority groups the high priority replications, they are scheduled first.
+	GroupHighPriority
 	// add more group strategy later
 	// groupHotLevel1
 )
@@ -85,6 +87,8 @@ func (gt GroupTpye) String() string {
 		return "default"
 	case GroupTable:
 		return "table"
+	case GroupHighPriority:
+		return "high-priority"
 	default:
 		// return "HotLevel" + strconv.Itoa(int(gt-groupHotLevel1))
 		panic("unreachable")
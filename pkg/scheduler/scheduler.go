@@ -25,6 +25,7 @@ const (
 	BasicScheduler   = "basic-scheduler"
 	BalanceScheduler = "balance-scheduler"
 	SplitScheduler   = "split-scheduler"
+	DrainScheduler   = "drain-scheduler"
 )
 
 type Scheduler interface {
@@ -14,9 +14,12 @@
 package scheduler
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler/replica"
 	"github.com/stretchr/testify/require"
 )
 
@@ -52,3 +55,95 @@ func TestCheckBalanceStatus(t *testing.T) {
 		"node3": {ID: "node3"},
 	}))
 }
+
+type testID string
+
+func (id testID) String() string { return string(id) }
+
+type testReplication struct {
+	id      testID
+	groupID replica.GroupID
+	nodeID  node.ID
+	load    float64
+}
+
+func (r *testReplication) GetID() testID               { return r.id }
+func (r *testReplication) GetGroupID() replica.GroupID { return r.groupID }
+func (r *testReplication) GetNodeID() node.ID          { return r.nodeID }
+func (r *testReplication) SetNodeID(id node.ID)        { r.nodeID = id }
+func (r *testReplication) ShouldRun() bool             { return true }
+func (r *testReplication) GetLoad() float64            { return r.load }
+
+func newTestReplications(prefix string, n int, nodeID node.ID, load float64) []*testReplication {
+	tasks := make([]*testReplication, 0, n)
+	for i := 0; i < n; i++ {
+		tasks = append(tasks, &testReplication{
+			id:      testID(fmt.Sprintf("%s-%d", prefix, i)),
+			groupID: replica.DefaultGroupID,
+			nodeID:  nodeID,
+			load:    load,
+		})
+	}
+	return tasks
+}
+
+func TestBalanceByLoad(t *testing.T) {
+	nodes := map[node.ID]*node.Info{
+		"node1": {ID: "node1"},
+		"node2": {ID: "node2"},
+	}
+	notPinned := func(*testReplication) bool { return false }
+	move := func(r *testReplication, id node.ID) bool {
+		r.SetNodeID(id)
+		return true
+	}
+	countOnNode := func(tasks []*testReplication, id node.ID) int {
+		count := 0
+		for _, r := range tasks {
+			if r.GetNodeID() == id {
+				count++
+			}
+		}
+		return count
+	}
+
+	// 5 hot tables on node1, 500 cold tables on node2, the count is balanced
+	// but node1 is overloaded, the hot tables should be spread.
+	hot := newTestReplications("hot", 5, "node1", 10*1024*1024)
+	cold := newTestReplications("cold", 500, "node2", 0)
+	tasks := append(append([]*testReplication{}, hot...), cold...)
+	moved := BalanceByLoad[testID](100, nodes, tasks, nil, notPinned, move)
+	require.Equal(t, 2, moved)
+	require.Equal(t, 3, countOnNode(hot, "node1"))
+	require.Equal(t, 2, countOnNode(hot, "node2"))
+	require.Equal(t, 500, countOnNode(cold, "node2"))
+	// balanced, nothing to move
+	require.Equal(t, 0, BalanceByLoad[testID](100, nodes, tasks, nil, notPinned, move))
+
+	// the idle tasks are balanced by count
+	idle := newTestReplications("idle", 10, "node1", 0)
+	moved = BalanceByLoad[testID](100, nodes, idle, nil, notPinned, move)
+	require.Equal(t, 5, moved)
+	require.Equal(t, 5, countOnNode(idle, "node2"))
+
+	// the pressured node takes less tasks
+	idle = newTestReplications("idle", 12, "node1", 0)
+	nodeLoads := map[node.ID]NodeLoad{"node2": {SinkLag: 2 * time.Minute}}
+	BalanceByLoad[testID](100, nodes, idle, nodeLoads, notPinned, move)
+	require.Equal(t, 4, countOnNode(idle, "node2"))
+
+	// the pinned tasks are not moved
+	idle = newTestReplications("idle", 10, "node1", 0)
+	pinned := func(*testReplication) bool { return true }
+	require.Equal(t, 0, BalanceByLoad[testID](100, nodes, idle, nil, pinned, move))
+
+	// the span of a split table is not moved to the node holding more spans of the table
+	groupID := replica.GenGroupID(replica.GroupTable, 1)
+	hotSpan := &testReplication{id: "hot-span", groupID: groupID, nodeID: "node1", load: 1024}
+	hotTable := &testReplication{id: "hot-table", groupID: replica.DefaultGroupID, nodeID: "node1", load: 1024}
+	coldSpan := &testReplication{id: "cold-span", groupID: groupID, nodeID: "node2", load: 0}
+	tasks = []*testReplication{hotSpan, hotTable, coldSpan}
+	require.Equal(t, 1, BalanceByLoad[testID](100, nodes, tasks, nil, notPinned, move))
+	require.Equal(t, node.ID("node1"), hotSpan.GetNodeID())
+	require.Equal(t, node.ID("node2"), hotTable.GetNodeID())
+}
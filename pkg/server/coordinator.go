@@ -18,6 +18,7 @@ import (
 
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/node"
 )
 
 // Coordinator is the master of the ticdc cluster,
@@ -44,4 +45,26 @@ type Coordinator interface {
 	ResumeChangefeed(ctx context.Context, id common.ChangeFeedID, newCheckpointTs uint64, overwriteCheckpointTs bool) error
 	// UpdateChangefeed updates a changefeed
 	UpdateChangefeed(ctx context.Context, change *config.ChangeFeedInfo) error
+	// DrainNode marks the node unschedulable and moves all the tasks away from it,
+	// it returns the progress of the draining
+	DrainNode(ctx context.Context, id node.ID) (*DrainProgress, error)
+	// UndrainNode clears the unschedulable mark of the node, so tasks can be scheduled to it again
+	UndrainNode(ctx context.Context, id node.ID) error
+}
+
+// DrainProgress is the progress of draining a node.
+type DrainProgress struct {
+	// RemainingMaintainers is the number of maintainers on the draining node.
+	RemainingMaintainers int
+	// RemainingDispatchers is the number of dispatchers on the draining nodes,
+	// only the statuses reported after the latest drain request are counted.
+	RemainingDispatchers int
+	// UnknownChangefeeds is the number of changefeeds which have not reported
+	// their status after the latest drain request, their dispatchers are unknown.
+	UnknownChangefeeds int
+}
+
+// Drained returns true if no task is left on the draining node.
+func (p *DrainProgress) Drained() bool {
+	return p.RemainingMaintainers == 0 && p.RemainingDispatchers == 0 && p.UnknownChangefeeds == 0
 }
@@ -0,0 +1,230 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func generateTableDef() (TableDefinition, *common.TableInfo) {
+	var columns []*timodel.ColumnInfo
+	ft := types.NewFieldType(mysql.TypeLong)
+	ft.SetFlag(mysql.PriKeyFlag | mysql.NotNullFlag)
+	col := &timodel.ColumnInfo{
+		ID:           1,
+		Name:         pmodel.NewCIStr("Id"),
+		State:        timodel.StatePublic,
+		FieldType:    *ft,
+		DefaultValue: 10,
+	}
+	columns = append(columns, col)
+
+	ft = types.NewFieldType(mysql.TypeVarchar)
+	ft.SetFlag(mysql.NotNullFlag)
+	ft.SetFlen(128)
+	col = &timodel.ColumnInfo{
+		ID:           2,
+		Name:         pmodel.NewCIStr("LastName"),
+		State:        timodel.StatePublic,
+		FieldType:    *ft,
+		DefaultValue: "Default LastName",
+	}
+	columns = append(columns, col)
+
+	ft = types.NewFieldType(mysql.TypeVarchar)
+	ft.SetFlen(64)
+	col = &timodel.ColumnInfo{
+		ID:           3,
+		Name:         pmodel.NewCIStr("FirstName"),
+		State:        timodel.StatePublic,
+		FieldType:    *ft,
+		DefaultValue: "Default FirstName",
+	}
+	columns = append(columns, col)
+
+	ft = types.NewFieldType(mysql.TypeDatetime)
+	col = &timodel.ColumnInfo{
+		ID:           4,
+		Name:         pmodel.NewCIStr("Birthday"),
+		State:        timodel.StatePublic,
+		FieldType:    *ft,
+		DefaultValue: 12345678,
+	}
+	columns = append(columns, col)
+
+	tableInfo := common.WrapTableInfo(1, "schema1", &timodel.TableInfo{
+		ID:      20,
+		Name:    pmodel.NewCIStr("table1"),
+		Columns: columns,
+	})
+
+	var def TableDefinition
+	def.FromTableInfo(tableInfo, 100, false)
+	return def, tableInfo
+}
+
+func TestTableDefinition(t *testing.T) {
+	t.Parallel()
+
+	def, tableInfo := generateTableDef()
+	encodedDef, err := json.MarshalIndent(def, "", "    ")
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"Table": "table1",
+		"Schema": "schema1",
+		"Version": 1,
+		"TableVersion": 100,
+		"Query": "",
+		"Type": 0,
+		"TableColumns": [
+			{
+				"ColumnName": "Id",
+				"ColumnType": "INT",
+				"ColumnPrecision": "11",
+				"ColumnDefault":10,
+				"ColumnNullable": "false",
+				"ColumnIsPk": "true"
+			},
+			{
+				"ColumnName": "LastName",
+				"ColumnType": "VARCHAR",
+				"ColumnDefault":"Default LastName",
+				"ColumnPrecision": "128",
+				"ColumnNullable": "false"
+			},
+			{
+				"ColumnName": "FirstName",
+				"ColumnDefault":"Default FirstName",
+				"ColumnType": "VARCHAR",
+				"ColumnPrecision": "64"
+			},
+			{
+				"ColumnName": "Birthday",
+				"ColumnDefault":1.2345678e+07,
+				"ColumnType": "DATETIME"
+			}
+		],
+		"TableColumnsTotal": 4
+	}`, string(encodedDef))
+
+	def = TableDefinition{}
+	event := &commonEvent.DDLEvent{
+		FinishedTs: 100,
+		Type:       byte(timodel.ActionAddColumn),
+		Query:      "alter table schema1.table1 add Birthday date",
+		TableInfo:  tableInfo,
+	}
+	def.FromDDLEvent(event, false)
+	encodedDef, err = json.MarshalIndent(def, "", "    ")
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"Table": "table1",
+		"Schema": "schema1",
+		"Version": 1,
+		"TableVersion": 100,
+		"Query": "alter table schema1.table1 add Birthday date",
+		"Type": 5,
+		"TableColumns": [
+			{
+				"ColumnName": "Id",
+				"ColumnType": "INT",
+				"ColumnPrecision": "11",
+				"ColumnDefault":10,
+				"ColumnNullable": "false",
+				"ColumnIsPk": "true"
+			},
+			{
+				"ColumnName": "LastName",
+				"ColumnType": "VARCHAR",
+				"ColumnDefault":"Default LastName",
+				"ColumnPrecision": "128",
+				"ColumnNullable": "false"
+			},
+			{
+				"ColumnName": "FirstName",
+				"ColumnDefault":"Default FirstName",
+				"ColumnType": "VARCHAR",
+				"ColumnPrecision": "64"
+			},
+			{
+				"ColumnName": "Birthday",
+				"ColumnDefault":1.2345678e+07,
+				"ColumnType": "DATETIME"
+			}
+		],
+		"TableColumnsTotal": 4
+	}`, string(encodedDef))
+
+	tableInfo, err = def.ToTableInfo()
+	require.NoError(t, err)
+	require.Len(t, tableInfo.GetColumns(), 4)
+
+	event, err = def.ToDDLEvent()
+	require.NoError(t, err)
+	require.Equal(t, timodel.ActionAddColumn, timodel.ActionType(event.Type))
+	require.Equal(t, uint64(100), event.FinishedTs)
+}
+
+func TestTableDefinitionGenFilePath(t *testing.T) {
+	t.Parallel()
+
+	schemaDef := &TableDefinition{
+		Schema:       "schema1",
+		Version:      defaultTableDefinitionVersion,
+		TableVersion: 100,
+	}
+	schemaPath, err := schemaDef.GenerateSchemaFilePath()
+	require.NoError(t, err)
+	require.Equal(t, "schema1/meta/schema_100_3233644819.json", schemaPath)
+
+	def, _ := generateTableDef()
+	tablePath, err := def.GenerateSchemaFilePath()
+	require.NoError(t, err)
+	require.Equal(t, "schema1/table1/meta/schema_100_3752767265.json", tablePath)
+}
+
+func TestTableDefinitionSum32(t *testing.T) {
+	t.Parallel()
+
+	def, _ := generateTableDef()
+	checksum1, err := def.Sum32(nil)
+	require.NoError(t, err)
+	checksum2, err := def.Sum32(nil)
+	require.NoError(t, err)
+	require.Equal(t, checksum1, checksum2)
+
+	n := len(def.Columns)
+	newCol := make([]TableCol, n)
+	copy(newCol, def.Columns)
+	newDef := def
+	newDef.Columns = newCol
+
+	for i := 0; i < n; i++ {
+		target := rand.Intn(n)
+		newDef.Columns[i], newDef.Columns[target] = newDef.Columns[target], newDef.Columns[i]
+		newChecksum, err := newDef.Sum32(nil)
+		require.NoError(t, err)
+		require.Equal(t, checksum1, newChecksum)
+	}
+}
@@ -32,7 +32,7 @@ import (
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/types"
-	"github.com/pingcap/tidb/pkg/util/rowcodec"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
@@ -49,7 +49,47 @@ type BatchEncoder struct {
 
 type avroEncodeInput struct {
 	columns  []*commonType.Column
-	colInfos []rowcodec.ColInfo
+	colInfos []*timodel.ColumnInfo
+}
+
+// newAvroEncodeInput collects the visible columns of the row.
+// If onlyHandleKey is true, only the handle key columns are collected,
+// otherwise the columns are filtered by the column selector.
+func newAvroEncodeInput(e *commonEvent.RowEvent, row *chunk.Row, onlyHandleKey bool) (*avroEncodeInput, error) {
+	tableInfo := e.TableInfo
+	flags := tableInfo.GetColumnFlags()
+	input := &avroEncodeInput{
+		columns:  make([]*commonType.Column, 0, len(tableInfo.GetColumns())),
+		colInfos: make([]*timodel.ColumnInfo, 0, len(tableInfo.GetColumns())),
+	}
+	for idx, colInfo := range tableInfo.GetColumns() {
+		if !commonType.IsColCDCVisible(colInfo) {
+			continue
+		}
+		flag := flags[colInfo.ID]
+		if onlyHandleKey {
+			if !flag.IsHandleKey() {
+				continue
+			}
+		} else if e.ColumnSelector != nil && !e.ColumnSelector.Select(colInfo) {
+			continue
+		}
+		value, err := commonType.FormatColVal(row, colInfo, idx)
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrAvroEncodeFailed, err)
+		}
+		input.columns = append(input.columns, &commonType.Column{
+			Name:      colInfo.Name.O,
+			Type:      colInfo.GetType(),
+			Charset:   colInfo.GetCharset(),
+			Collation: colInfo.GetCollate(),
+			Flag:      *flag,
+			Value:     value,
+			Default:   commonType.GetColumnDefaultValue(colInfo),
+		})
+		input.colInfos = append(input.colInfos, colInfo)
+	}
+	return input, nil
 }
 
 func (r *avroEncodeInput) Less(i, j int) bool {
@@ -74,18 +114,21 @@ type avroEncodeResult struct {
 }
 
 func (a *BatchEncoder) encodeKey(ctx context.Context, topic string, e *commonEvent.RowEvent) ([]byte, error) {
-	cols, colInfos := e.HandleKeyColInfos()
+	row := e.GetRows()
+	if e.IsDelete() {
+		row = e.GetPreRows()
+	}
+	keyColumns, err := newAvroEncodeInput(e, row, true)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	// result may be nil if the event has no handle key columns, this may happen in the force replicate mode.
 	// todo: disallow force replicate mode if using the avro.
-	if len(cols) == 0 {
+	if len(keyColumns.columns) == 0 {
 		return nil, nil
 	}
 
-	keyColumns := &avroEncodeInput{
-		columns:  cols,
-		colInfos: colInfos,
-	}
-	avroCodec, header, err := a.getKeySchemaCodec(ctx, topic, &e.TableInfo.TableName, e.TableInfo.Version, keyColumns)
+	avroCodec, header, err := a.getKeySchemaCodec(ctx, topic, &e.TableInfo.TableName, e.TableInfo.UpdateTS(), keyColumns)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -118,7 +161,7 @@ func topicName2SchemaSubjects(topicName, subjectSuffix string) string {
 }
 
 func (a *BatchEncoder) getValueSchemaCodec(
-	ctx context.Context, topic string, tableName *commonType.TableName, tableVersion uint16, input *avroEncodeInput,
+	ctx context.Context, topic string, tableName *commonType.TableName, tableVersion uint64, input *avroEncodeInput,
 ) (*goavro.Codec, []byte, error) {
 	schemaGen := func() (string, error) {
 		schema, err := a.value2AvroSchema(tableName, input)
@@ -138,7 +181,7 @@ func (a *BatchEncoder) getValueSchemaCodec(
 }
 
 func (a *BatchEncoder) getKeySchemaCodec(
-	ctx context.Context, topic string, tableName *commonType.TableName, tableVersion uint16, keyColumns *avroEncodeInput,
+	ctx context.Context, topic string, tableName *commonType.TableName, tableVersion uint64, keyColumns *avroEncodeInput,
 ) (*goavro.Codec, []byte, error) {
 	schemaGen := func() (string, error) {
 		schema, err := a.key2AvroSchema(tableName, keyColumns)
@@ -157,20 +200,20 @@ func (a *BatchEncoder) getKeySchemaCodec(
 	return avroCodec, header, nil
 }
 
-func (a *BatchEncoder) encodeValue(ctx context.Context, topic string, e *commonEvent.RowChangedEvent) ([]byte, error) {
+func (a *BatchEncoder) encodeValue(ctx context.Context, topic string, e *commonEvent.RowEvent) ([]byte, error) {
 	if e.IsDelete() {
 		return nil, nil
 	}
 
-	input := &avroEncodeInput{
-		columns:  e.GetColumns(),
-		colInfos: e.TableInfo.GetColInfosForRowChangedEvent(),
+	input, err := newAvroEncodeInput(e, e.GetRows(), false)
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
 	if len(input.columns) == 0 {
 		return nil, nil
 	}
 
-	avroCodec, header, err := a.getValueSchemaCodec(ctx, topic, &e.TableInfo.TableName, e.TableInfo.Version, input)
+	avroCodec, header, err := a.getValueSchemaCodec(ctx, topic, &e.TableInfo.TableName, e.TableInfo.UpdateTS(), input)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -207,7 +250,6 @@ func (a *BatchEncoder) AppendRowChangedEvent(
 	ctx context.Context,
 	topic string,
 	e *commonEvent.RowEvent,
-	callback func(),
 ) error {
 	topic = sanitizeTopic(topic)
 
@@ -224,7 +266,7 @@ func (a *BatchEncoder) AppendRowChangedEvent(
 	}
 
 	message := common.NewMsg(key, value)
-	message.Callback = callback
+	message.Callback = e.Callback
 	message.IncRowsCount()
 
 	if message.Length() > a.config.MaxMessageBytes {
@@ -269,26 +311,26 @@ type ddlEvent struct {
 // EncodeDDLEvent only encode DDL event if the watermark event is enabled
 // it's only used for the testing purpose.
 func (a *BatchEncoder) EncodeDDLEvent(e *commonEvent.DDLEvent) (*common.Message, error) {
-	// if a.config.EnableTiDBExtension && a.config.AvroEnableWatermark {
-	// 	buf := new(bytes.Buffer)
-	// 	_ = binary.Write(buf, binary.BigEndian, ddlByte)
-
-	// 	event := &ddlEvent{
-	// 		Query:    e.Query,
-	// 		Type:     e.Type,
-	// 		Schema:   e.TableInfo.TableName.Schema,
-	// 		Table:    e.TableInfo.TableName.Table,
-	// 		CommitTs: e.CommitTs,
-	// 	}
-	// 	data, err := json.Marshal(event)
-	// 	if err != nil {
-	// 		return nil, errors.WrapError(errors.ErrAvroToEnvelopeError, err)
-	// 	}
-	// 	buf.Write(data)
-
-	// 	value := buf.Bytes()
-	// 	return ticommon.NewDDLMsg(config.ProtocolAvro, nil, value, e), nil
-	// }
+	if a.config.EnableTiDBExtension && a.config.AvroEnableWatermark {
+		buf := new(bytes.Buffer)
+		_ = binary.Write(buf, binary.BigEndian, ddlByte)
+
+		event := &ddlEvent{
+			Query:    e.Query,
+			Type:     timodel.ActionType(e.Type),
+			Schema:   e.SchemaName,
+			Table:    e.TableName,
+			CommitTs: e.FinishedTs,
+		}
+		data, err := json.Marshal(event)
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrAvroToEnvelopeError, err)
+		}
+		buf.Write(data)
+
+		value := buf.Bytes()
+		return common.NewMsg(nil, value), nil
+	}
 
 	return nil, nil
 }
@@ -305,7 +347,7 @@ const (
 	updateOperation = "u"
 )
 
-func getOperation(e *commonEvent.RowChangedEvent) string {
+func getOperation(e *commonEvent.RowEvent) string {
 	if e.IsInsert() {
 		return insertOperation
 	} else if e.IsUpdate() {
@@ -316,17 +358,13 @@ func getOperation(e *commonEvent.RowChangedEvent) string {
 
 func (a *BatchEncoder) nativeValueWithExtension(
 	native map[string]interface{},
-	e *commonEvent.RowChangedEvent,
+	e *commonEvent.RowEvent,
 ) map[string]interface{} {
 	native[tidbOp] = getOperation(e)
 	native[tidbCommitTs] = int64(e.CommitTs)
 	native[tidbPhysicalTime] = oracle.ExtractPhysical(e.CommitTs)
-
-	if a.config.EnableRowChecksum && e.Checksum != nil {
-		native[tidbRowLevelChecksum] = strconv.FormatUint(uint64(e.Checksum.Current), 10)
-		native[tidbCorrupted] = e.Checksum.Corrupted
-		native[tidbChecksumVersion] = e.Checksum.Version
-	}
+	// the row level checksum is not carried by the row event yet,
+	// the checksum fields are filled with the default values of the schema.
 	return native
 }
 
@@ -563,7 +601,7 @@ func (a *BatchEncoder) columns2AvroSchema(
 		if col == nil {
 			continue
 		}
-		avroType, err := a.columnToAvroSchema(col, input.colInfos[i].Ft)
+		avroType, err := a.columnToAvroSchema(col, &input.colInfos[i].FieldType)
 		if err != nil {
 			return nil, err
 		}
@@ -572,7 +610,7 @@ func (a *BatchEncoder) columns2AvroSchema(
 
 		copied := *col
 		copied.Value = copied.Default
-		defaultValue, _, err := a.columnToAvroData(&copied, input.colInfos[i].Ft)
+		defaultValue, _, err := a.columnToAvroData(&copied, &input.colInfos[i].FieldType)
 		if err != nil {
 			log.Error("fail to get default value for avro schema")
 			return nil, errors.Trace(err)
@@ -660,7 +698,7 @@ func (a *BatchEncoder) columns2AvroData(
 		if col == nil {
 			continue
 		}
-		data, str, err := a.columnToAvroData(col, input.colInfos[i].Ft)
+		data, str, err := a.columnToAvroData(col, &input.colInfos[i].FieldType)
 		if err != nil {
 			return nil, err
 		}
@@ -968,6 +1006,9 @@ func (a *BatchEncoder) columnToAvroData(
 		}
 		return int32(col.Value.(int64)), "int", nil
 	case mysql.TypeTiDBVectorFloat32:
+		if v, ok := col.Value.(string); ok {
+			return v, "string", nil
+		}
 		if vec, ok := col.Value.(types.VectorFloat32); ok {
 			return vec.String(), "string", nil
 		}
@@ -0,0 +1,255 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConfluentRegistry is an in-process confluent schema registry for testing.
+type fakeConfluentRegistry struct {
+	mu       sync.Mutex
+	schemas  map[int]string
+	subjects map[string]int
+}
+
+func newFakeConfluentRegistry(t *testing.T) (*fakeConfluentRegistry, string) {
+	registry := &fakeConfluentRegistry{
+		schemas:  make(map[int]string),
+		subjects: make(map[string]int),
+	}
+	server := httptest.NewServer(http.HandlerFunc(registry.serveHTTP))
+	t.Cleanup(server.Close)
+	return registry, server.URL
+}
+
+func (r *fakeConfluentRegistry) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	path := req.URL.Path
+	switch {
+	case req.Method == http.MethodGet && path == "/":
+		_, _ = w.Write([]byte("{}"))
+	case req.Method == http.MethodPost && strings.HasPrefix(path, "/subjects/") && strings.HasSuffix(path, "/versions"):
+		subject := strings.TrimSuffix(strings.TrimPrefix(path, "/subjects/"), "/versions")
+		body, _ := io.ReadAll(req.Body)
+		var request registerRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		// the same schema gets the same id
+		id := 0
+		for schemaID, schema := range r.schemas {
+			if schema == request.Schema {
+				id = schemaID
+			}
+		}
+		if id == 0 {
+			id = len(r.schemas) + 1
+			r.schemas[id] = request.Schema
+		}
+		r.subjects[subject] = id
+		_ = json.NewEncoder(w).Encode(registerResponse{SchemaID: id})
+	case req.Method == http.MethodGet && strings.HasPrefix(path, "/schemas/ids/"):
+		id, err := strconv.Atoi(strings.TrimPrefix(path, "/schemas/ids/"))
+		schema, ok := r.schemas[id]
+		if err != nil || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(lookupResponse{SchemaID: id, Schema: schema})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (r *fakeConfluentRegistry) subjectCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.subjects)
+}
+
+// decodeConfluentMessage decodes the confluent wire format data into native avro data.
+func decodeConfluentMessage(
+	ctx context.Context, t *testing.T, schemaM SchemaManager, subject string, data []byte,
+) map[string]interface{} {
+	require.Equal(t, magicByte, data[0])
+	id := schemaID{confluentSchemaID: int(binary.BigEndian.Uint32(data[1:5]))}
+	codec, err := schemaM.Lookup(ctx, subject, id)
+	require.NoError(t, err)
+	native, _, err := codec.NativeFromBinary(data[5:])
+	require.NoError(t, err)
+	return native.(map[string]interface{})
+}
+
+func TestAvroEncodeWithConfluentRegistry(t *testing.T) {
+	ctx := context.Background()
+	registry, url := newFakeConfluentRegistry(t)
+
+	codecConfig := common.NewConfig(config.ProtocolAvro).
+		WithChangefeedID(commonType.NewChangefeedID4Test("default", "test"))
+	codecConfig.AvroConfluentSchemaRegistry = url
+	codecConfig.EnableTiDBExtension = true
+	encoder, err := NewAvroEncoder(ctx, codecConfig)
+	require.NoError(t, err)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(id int primary key, name varchar(32), price decimal(10, 2), data blob)`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, 'alice', 12.34, null)`)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	var called bool
+	err = encoder.AppendRowChangedEvent(ctx, "test.t", &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       100,
+		Event:          row,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		Callback:       func() { called = true },
+	})
+	require.NoError(t, err)
+
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Equal(t, 1, messages[0].GetRowsCount())
+	messages[0].Callback()
+	require.True(t, called)
+	// key and value schemas are registered under the sanitized topic.
+	require.Equal(t, 2, registry.subjectCount())
+
+	schemaM := encoder.(*BatchEncoder).schemaM
+	key := decodeConfluentMessage(ctx, t, schemaM, "test_t-key", messages[0].Key)
+	require.Equal(t, map[string]interface{}{"id": int32(1)}, key)
+
+	value := decodeConfluentMessage(ctx, t, schemaM, "test_t-value", messages[0].Value)
+	require.Equal(t, int32(1), value["id"])
+	require.Equal(t, map[string]interface{}{"string": "alice"}, value["name"])
+	require.Nil(t, value["data"])
+	require.Equal(t, insertOperation, value[tidbOp])
+	require.Equal(t, int64(100), value[tidbCommitTs])
+	price := value["price"].(map[string]interface{})["bytes.decimal"]
+	require.Equal(t, "12.34", price.(interface{ FloatString(int) string }).FloatString(2))
+
+	// the schema is cached, the same table version doesn't register again.
+	dmlEvent = helper.DML2Event("test", "t", `insert into test.t values (2, 'bob', 1.5, x'01')`)
+	row, ok = dmlEvent.GetNextRow()
+	require.True(t, ok)
+	err = encoder.AppendRowChangedEvent(ctx, "test.t", &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       101,
+		Event:          row,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+	})
+	require.NoError(t, err)
+	messages = encoder.Build()
+	require.Len(t, messages, 1)
+	value = decodeConfluentMessage(ctx, t, schemaM, "test_t-value", messages[0].Value)
+	require.Equal(t, map[string]interface{}{"bytes": []byte{0x01}}, value["data"])
+}
+
+func TestAvroEncodeDeleteEvent(t *testing.T) {
+	ctx := context.Background()
+	_, url := newFakeConfluentRegistry(t)
+
+	codecConfig := common.NewConfig(config.ProtocolAvro).
+		WithChangefeedID(commonType.NewChangefeedID4Test("default", "test"))
+	codecConfig.AvroConfluentSchemaRegistry = url
+	encoder, err := NewAvroEncoder(ctx, codecConfig)
+	require.NoError(t, err)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(id int primary key, name varchar(32))`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, 'alice')`)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	// turn the insert row into a delete row.
+	row.PreRow, row.Row = row.Row, row.PreRow
+	row.RowType = commonEvent.RowTypeDelete
+
+	err = encoder.AppendRowChangedEvent(ctx, "t", &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       100,
+		Event:          row,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+	})
+	require.NoError(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	// the delete event only has the key, which is a tombstone message.
+	require.Nil(t, messages[0].Value)
+	key := decodeConfluentMessage(ctx, t, encoder.(*BatchEncoder).schemaM, "t-key", messages[0].Key)
+	require.Equal(t, map[string]interface{}{"id": int32(1)}, key)
+}
+
+func TestGlueSchemaManager(t *testing.T) {
+	ctx := context.Background()
+	m := &glueSchemaManager{
+		registryName: "test",
+		client:       newMockGlueClientImpl(),
+		cache:        make(map[string]*schemaCacheEntry),
+		registryType: common.SchemaRegistryTypeGlue,
+	}
+	schema := `{"type":"record","name":"t","fields":[{"name":"id","type":"int"}]}`
+	codec, header, err := m.GetCachedOrRegister(ctx, "t-value", 1, func() (string, error) {
+		return schema, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, headerVersionByte, header[0])
+	require.Equal(t, compressionDefaultByte, header[1])
+
+	id, err := getGlueSchemaIDFromHeader(header)
+	require.NoError(t, err)
+	lookup, err := m.Lookup(ctx, "t-value", schemaID{glueSchemaID: id})
+	require.NoError(t, err)
+	require.Equal(t, codec.Schema(), lookup.Schema())
+
+	// the cached codec is returned without calling the schema generator.
+	cached, _, err := m.GetCachedOrRegister(ctx, "t-value", 1, func() (string, error) {
+		t.Fatal("schema should be cached")
+		return "", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, codec, cached)
+
+	native, err := goavro.NewCodec(schema)
+	require.NoError(t, err)
+	require.Equal(t, native.Schema(), codec.Schema())
+}
@@ -108,9 +108,9 @@ func (b *bootstrapWorker) run(ctx context.Context) error {
 func (b *bootstrapWorker) addEvent(
 	ctx context.Context,
 	key model.TopicPartitionKey,
-	row *commonEvent.RowChangedEvent,
+	row *commonEvent.RowEvent,
 ) error {
-	table, ok := b.activeTables.Load(row.GetTableID())
+	table, ok := b.activeTables.Load(row.TableInfo.TableName.TableID)
 	if !ok {
 		tb := newTableStatistic(key, row)
 		b.activeTables.Store(tb.id, tb)
@@ -119,9 +119,17 @@ func (b *bootstrapWorker) addEvent(
 		if err != nil {
 			return errors.Trace(err)
 		}
-	} else {
-		// If the table is already in the activeTables, update its status.
-		table.(*tableStatistic).update(row, key.TotalPartition)
+		return nil
+	}
+	// If the table is already in the activeTables, update its status.
+	tb := table.(*tableStatistic)
+	if tb.update(row, key.TotalPartition) {
+		// Send bootstrap message immediately when the table schema is changed,
+		// so the consumer can decode the following events by the new schema.
+		err := b.sendBootstrapMsg(ctx, tb)
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 	return nil
 }
@@ -154,10 +162,11 @@ func (b *bootstrapWorker) sendBootstrapMsg(ctx context.Context, table *tableStat
 
 func NewBootstrapDDLEvent(tableInfo *commonType.TableInfo) *commonEvent.DDLEvent {
 	return &commonEvent.DDLEvent{
-		// StartTs:  0,
-		FinishedTs: 0,
-		// TableInfo:   tableInfo,
-		// IsBootstrap: true,
+		FinishedTs:  0,
+		SchemaName:  tableInfo.GetSchemaName(),
+		TableName:   tableInfo.GetTableName(),
+		TableInfo:   tableInfo,
+		IsBootstrap: true,
 	}
 }
 
@@ -230,9 +239,9 @@ type tableStatistic struct {
 	tableInfo atomic.Value
 }
 
-func newTableStatistic(key model.TopicPartitionKey, row *commonEvent.RowChangedEvent) *tableStatistic {
+func newTableStatistic(key model.TopicPartitionKey, row *commonEvent.RowEvent) *tableStatistic {
 	res := &tableStatistic{
-		id:    row.GetTableID(),
+		id:    row.TableInfo.TableName.TableID,
 		topic: key.Topic,
 	}
 	res.totalPartition.Store(key.TotalPartition)
@@ -253,20 +262,27 @@ func (t *tableStatistic) shouldSendBootstrapMsg(
 		t.counter.Load() >= sendBootstrapMsgCountInterval
 }
 
-func (t *tableStatistic) update(row *commonEvent.RowChangedEvent, totalPartition int32) {
+// update updates the statistics by the received row event,
+// it returns true if the table schema is changed since last bootstrap message sent.
+func (t *tableStatistic) update(row *commonEvent.RowEvent, totalPartition int32) bool {
 	t.counter.Add(1)
 	t.lastMsgReceivedTime.Store(time.Now())
 
+	var schemaChanged bool
 	// Note(dongmen): Rename Table DDL is a special case,
 	// the TableInfo.Name is changed but the TableInfo.UpdateTs is not changed.
 	if t.version.Load() != row.TableInfo.UpdateTS() ||
 		t.tableInfo.Load().(*commonType.TableInfo).TableName.Table != row.TableInfo.TableName.Table {
 		t.version.Store(row.TableInfo.UpdateTS())
 		t.tableInfo.Store(row.TableInfo)
+		// make the bootstrap message be sent immediately.
+		t.lastSendTime.Store(time.Unix(0, 0))
+		schemaChanged = true
 	}
 	if t.totalPartition.Load() != totalPartition {
 		t.totalPartition.Store(totalPartition)
 	}
+	return schemaChanged
 }
 
 func (t *tableStatistic) isInactive(maxInactiveDuration time.Duration) bool {
@@ -0,0 +1,127 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func getMockTableStatus(
+	t *testing.T, helper *commonEvent.EventTestHelper, tableInfo *commonType.TableInfo, dml string,
+) (model.TopicPartitionKey, *commonEvent.RowEvent) {
+	dmlEvent := helper.DML2Event("test", "t", dml)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	key := model.TopicPartitionKey{
+		Topic:          "test.t",
+		Partition:      1,
+		TotalPartition: 3,
+	}
+	return key, &commonEvent.RowEvent{
+		TableInfo: tableInfo,
+		CommitTs:  1,
+		Event:     row,
+	}
+}
+
+func newTestBootstrapWorker(
+	t *testing.T, outCh chan *future, sendToAllPartition bool,
+) *bootstrapWorker {
+	encoder, err := NewEventEncoder(context.Background(), common.NewConfig(config.ProtocolSimple))
+	require.NoError(t, err)
+	return newBootstrapWorker(
+		commonType.NewChangefeedID4Test("default", "test"),
+		outCh,
+		encoder,
+		// the bootstrap message is not sent by time or message count in the test
+		int64(time.Hour/time.Second),
+		1000,
+		sendToAllPartition,
+		defaultMaxInactiveDuration,
+	)
+}
+
+func TestBootstrapWorker(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(id int primary key, name varchar(32))`)
+	tableInfo := helper.GetTableInfo(job)
+
+	ctx := context.Background()
+	outCh := make(chan *future, defaultInputChanSize)
+	worker := newTestBootstrapWorker(t, outCh, true)
+
+	// the bootstrap message is sent to all partitions for a new table.
+	key, row := getMockTableStatus(t, helper, tableInfo, `insert into test.t(id) values (1)`)
+	require.NoError(t, worker.addEvent(ctx, key, row))
+	require.Len(t, outCh, int(key.TotalPartition))
+	for i := int32(0); i < key.TotalPartition; i++ {
+		f := <-outCh
+		require.NoError(t, f.Ready(ctx))
+		require.Equal(t, key.Topic, f.Key.Topic)
+		require.Equal(t, i, f.Key.Partition)
+		require.Len(t, f.Messages, 1)
+	}
+
+	// the bootstrap message is not sent again if the table schema is not changed.
+	require.NoError(t, worker.addEvent(ctx, key, row))
+	require.Len(t, outCh, 0)
+
+	// the bootstrap message is sent immediately when the table schema is changed.
+	job = helper.DDL2Job(`alter table test.t add column age int`)
+	newTableInfo := helper.GetTableInfo(job)
+	require.NotEqual(t, tableInfo.UpdateTS(), newTableInfo.UpdateTS())
+	key, row = getMockTableStatus(t, helper, newTableInfo, `insert into test.t(id) values (2)`)
+	require.NoError(t, worker.addEvent(ctx, key, row))
+	require.Len(t, outCh, int(key.TotalPartition))
+	table, ok := worker.activeTables.Load(newTableInfo.TableName.TableID)
+	require.True(t, ok)
+	require.Equal(t, newTableInfo.UpdateTS(), table.(*tableStatistic).version.Load())
+}
+
+func TestBootstrapWorkerSendToFirstPartition(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(id int primary key, name varchar(32))`)
+	tableInfo := helper.GetTableInfo(job)
+
+	ctx := context.Background()
+	outCh := make(chan *future, defaultInputChanSize)
+	worker := newTestBootstrapWorker(t, outCh, false)
+
+	key, row := getMockTableStatus(t, helper, tableInfo, `insert into test.t(id) values (1)`)
+	require.NoError(t, worker.addEvent(ctx, key, row))
+	require.Len(t, outCh, 1)
+	f := <-outCh
+	require.Equal(t, int32(0), f.Key.Partition)
+
+	// the table is removed after it is inactive for a long time.
+	worker.maxInactiveDuration = 0
+	worker.gcInactiveTables()
+	_, ok := worker.activeTables.Load(tableInfo.TableName.TableID)
+	require.False(t, ok)
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canal
+
+import (
+	"bytes"
+
+	"github.com/goccy/go-json"
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/model"
+	"go.uber.org/zap"
+)
+
+// batchDecoder decodes the byte into the original message.
+type batchDecoder struct {
+	data []byte
+	msg  canalJSONMessageInterface
+
+	config *common.Config
+	// tableInfo is used to restore the column values of the row changed events.
+	tableInfo *commonType.TableInfo
+	// tableInfoCache caches the table info built from the messages,
+	// it's used when the tableInfo is not provided.
+	tableInfoCache map[string]*commonType.TableInfo
+}
+
+// NewBatchDecoder return a decoder for canal-json.
+// The tableInfo should be the one used to encode the row changed events if it's known,
+// such as the table definition stored alongside the data files in the cloud storage.
+// If the tableInfo is nil, it's built from the `mysqlType` and `pkNames` of each message.
+func NewBatchDecoder(
+	codecConfig *common.Config, tableInfo *commonType.TableInfo,
+) (common.RowEventDecoder, error) {
+	return &batchDecoder{
+		config:         codecConfig,
+		tableInfo:      tableInfo,
+		tableInfoCache: make(map[string]*commonType.TableInfo),
+	}, nil
+}
+
+// AddKeyValue implements the RowEventDecoder interface
+func (b *batchDecoder) AddKeyValue(_, value []byte) error {
+	value, err := common.Decompress(b.config.LargeMessageHandle.LargeMessageHandleCompression, value)
+	if err != nil {
+		log.Error("decompress data failed",
+			zap.String("compression", b.config.LargeMessageHandle.LargeMessageHandleCompression),
+			zap.Error(err))
+		return errors.Trace(err)
+	}
+	b.data = value
+	return nil
+}
+
+// HasNext implements the RowEventDecoder interface
+func (b *batchDecoder) HasNext() (model.MessageType, bool, error) {
+	if b.data == nil {
+		return model.MessageTypeUnknown, false, nil
+	}
+	var (
+		msg         canalJSONMessageInterface = &JSONMessage{}
+		encodedData []byte
+	)
+	if b.config.EnableTiDBExtension {
+		msg = &canalJSONMessageWithTiDBExtension{
+			JSONMessage: &JSONMessage{},
+			Extensions:  &tidbExtension{},
+		}
+	}
+
+	if len(b.config.Terminator) > 0 {
+		idx := bytes.Index(b.data, []byte(b.config.Terminator))
+		if idx >= 0 {
+			encodedData = b.data[:idx]
+			b.data = b.data[idx+len(b.config.Terminator):]
+		} else {
+			encodedData = b.data
+			b.data = nil
+		}
+	} else {
+		encodedData = b.data
+		b.data = nil
+	}
+
+	if len(encodedData) == 0 {
+		return model.MessageTypeUnknown, false, nil
+	}
+
+	if err := json.Unmarshal(encodedData, msg); err != nil {
+		log.Error("canal-json decoder unmarshal data failed",
+			zap.Error(err), zap.ByteString("data", encodedData))
+		return model.MessageTypeUnknown, false, errors.WrapError(errors.ErrCanalDecodeFailed, err)
+	}
+	b.msg = msg
+	return b.msg.messageType(), true, nil
+}
+
+// NextDMLEvent implements the RowEventDecoder interface
+// `HasNext` should be called before this.
+func (b *batchDecoder) NextDMLEvent() (*commonEvent.DMLEvent, error) {
+	if b.msg == nil || b.msg.messageType() != model.MessageTypeRow {
+		return nil, errors.ErrCanalDecodeFailed.
+			GenWithStack("not found row changed event message")
+	}
+
+	message, withExtension := b.msg.(*canalJSONMessageWithTiDBExtension)
+	if withExtension && (message.Extensions.OnlyHandleKey || message.Extensions.ClaimCheckLocation != "") {
+		return nil, errors.ErrCanalDecodeFailed.
+			GenWithStack("the handle key only or claim check message is not supported yet")
+	}
+
+	tableInfo := b.tableInfo
+	if tableInfo == nil {
+		tableInfo = b.getTableInfo(b.msg)
+	}
+	result, err := canalJSONMessage2DMLEvent(b.msg, tableInfo)
+	if err != nil {
+		return nil, err
+	}
+	b.msg = nil
+	return result, nil
+}
+
+// NextDDLEvent implements the RowEventDecoder interface
+// `HasNext` should be called before this.
+func (b *batchDecoder) NextDDLEvent() (*commonEvent.DDLEvent, error) {
+	if b.msg == nil || b.msg.messageType() != model.MessageTypeDDL {
+		return nil, errors.ErrCanalDecodeFailed.
+			GenWithStack("not found ddl event message")
+	}
+
+	result := canalJSONMessage2DDLEvent(b.msg)
+	b.msg = nil
+	// the table schema may be changed by the DDL, so the cached table info is dropped.
+	clear(b.tableInfoCache)
+	return result, nil
+}
+
+// getTableInfo returns the table info built from the message, it's cached until a DDL is received.
+func (b *batchDecoder) getTableInfo(msg canalJSONMessageInterface) *commonType.TableInfo {
+	key := commonType.QuoteSchema(*msg.getSchema(), *msg.getTable())
+	tableInfo, ok := b.tableInfoCache[key]
+	if !ok {
+		tableInfo = newTableInfo(msg)
+		b.tableInfoCache[key] = tableInfo
+	}
+	return tableInfo
+}
+
+// NextResolvedEvent implements the RowEventDecoder interface
+// `HasNext` should be called before this.
+func (b *batchDecoder) NextResolvedEvent() (uint64, error) {
+	if b.msg == nil || b.msg.messageType() != model.MessageTypeResolved {
+		return 0, errors.ErrCanalDecodeFailed.
+			GenWithStack("not found resolved event message")
+	}
+
+	withExtensionEvent, ok := b.msg.(*canalJSONMessageWithTiDBExtension)
+	if !ok {
+		log.Error("canal-json resolved event message should have tidb extension, but not found",
+			zap.Any("msg", b.msg))
+		return 0, errors.ErrCanalDecodeFailed.
+			GenWithStack("MessageTypeResolved tidb extension not found")
+	}
+	b.msg = nil
+	return withExtensionEvent.Extensions.WatermarkTs, nil
+}
@@ -0,0 +1,245 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	pevent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanalJSONDecodeRoundTrip(t *testing.T) {
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(
+		id bigint unsigned primary key, name varchar(32), bin varbinary(16), b bit(10),
+		e enum('a','b','c'), s set('x','y'), d decimal(10, 2), dt datetime(3),
+		tm time, j json, f double, n int)`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t",
+		`insert into test.t values (18446744073709551615, 'a,"b"', x'89504E470D0A1A0A', b'1000000001',
+			'b', 'x,y', 12.34, '2023-11-30 06:38:29.123', '01:02:03', '{"k": 1}', 1.5, null)`,
+		`insert into test.t values (2, 'c', null, null, 'c', 'y', null, null, null, null, null, 3)`)
+	insertRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	newRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	events := []*pevent.RowEvent{
+		{Event: insertRow},
+		{Event: pevent.RowChange{PreRow: insertRow.Row, Row: newRow.Row, RowType: pevent.RowTypeUpdate}},
+		{Event: pevent.RowChange{PreRow: newRow.Row, RowType: pevent.RowTypeDelete}},
+	}
+
+	codecConfig := common.NewConfig(config.ProtocolCanalJSON)
+	codecConfig.EnableTiDBExtension = true
+	codecConfig.Terminator = "\r\n"
+	var content []byte
+	expected := make([][]byte, 0, len(events))
+	for i, e := range events {
+		e.TableInfo = tableInfo
+		e.CommitTs = uint64(100 + i)
+		e.ColumnSelector = columnselector.NewDefaultColumnSelector()
+		value, err := newJSONMessageForDML(e, codecConfig, false, "")
+		require.NoError(t, err)
+		content = append(content, value...)
+		content = append(content, codecConfig.Terminator...)
+		expected = append(expected, value)
+	}
+
+	decoder, err := NewBatchDecoder(codecConfig, tableInfo)
+	require.NoError(t, err)
+	require.NoError(t, decoder.AddKeyValue(nil, content))
+	for i, e := range events {
+		tp, hasNext, err := decoder.HasNext()
+		require.NoError(t, err)
+		require.True(t, hasNext)
+		require.Equal(t, model.MessageTypeRow, tp)
+		decoded, err := decoder.NextDMLEvent()
+		require.NoError(t, err)
+		require.Equal(t, e.CommitTs, decoded.CommitTs)
+
+		row, ok := decoded.GetNextRow()
+		require.True(t, ok)
+		require.Equal(t, e.Event.RowType, row.RowType)
+
+		// encode the decoded event again, it must be the same as the original one.
+		value, err := newJSONMessageForDML(&pevent.RowEvent{
+			TableInfo:      tableInfo,
+			CommitTs:       decoded.CommitTs,
+			Event:          row,
+			ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		}, codecConfig, false, "")
+		require.NoError(t, err)
+		require.JSONEq(t, removeBuildTime(t, expected[i]), removeBuildTime(t, value))
+	}
+	_, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.False(t, hasNext)
+}
+
+// removeBuildTime removes the build time of the message, which is different for each encoding.
+func removeBuildTime(t *testing.T, value []byte) string {
+	var msg canalJSONMessageWithTiDBExtension
+	require.NoError(t, json.Unmarshal(value, &msg))
+	msg.BuildTime = 0
+	result, err := json.Marshal(msg)
+	require.NoError(t, err)
+	return string(result)
+}
+
+func TestCanalJSONDecodeDDLAndResolved(t *testing.T) {
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(id int primary key)`)
+	tableInfo := helper.GetTableInfo(job)
+
+	codecConfig := common.NewConfig(config.ProtocolCanalJSON)
+	codecConfig.EnableTiDBExtension = true
+	encoder, err := NewJSONRowEventEncoder(context.Background(), codecConfig)
+	require.NoError(t, err)
+	decoder, err := NewBatchDecoder(codecConfig, tableInfo)
+	require.NoError(t, err)
+
+	m, err := encoder.EncodeDDLEvent(&pevent.DDLEvent{
+		Type:       byte(timodel.ActionCreateTable),
+		SchemaName: "test",
+		TableName:  "t",
+		Query:      job.Query,
+		TableInfo:  tableInfo,
+		FinishedTs: 200,
+	})
+	require.NoError(t, err)
+	require.NoError(t, decoder.AddKeyValue(m.Key, m.Value))
+	tp, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeDDL, tp)
+	ddl, err := decoder.NextDDLEvent()
+	require.NoError(t, err)
+	require.Equal(t, uint64(200), ddl.FinishedTs)
+	require.Equal(t, "test", ddl.SchemaName)
+	require.Equal(t, "t", ddl.TableName)
+	require.Equal(t, job.Query, ddl.Query)
+
+	m, err = encoder.EncodeCheckpointEvent(300)
+	require.NoError(t, err)
+	require.NoError(t, decoder.AddKeyValue(m.Key, m.Value))
+	tp, hasNext, err = decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeResolved, tp)
+	ts, err := decoder.NextResolvedEvent()
+	require.NoError(t, err)
+	require.Equal(t, uint64(300), ts)
+}
+
+func TestCanalJSONDecodeWithoutTableInfo(t *testing.T) {
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(
+		id bigint unsigned, name varchar(32), bin varbinary(16), b bit(10),
+		e enum('a','b','c'), s set('x','y'), d decimal(10, 2), dt datetime(3),
+		tm time, j json, f double, n int, primary key(id, name))`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t",
+		`insert into test.t values (1, 'a,"b"', x'89504E470D0A1A0A', b'1000000001',
+			'b', 'x,y', 12.34, '2023-11-30 06:38:29.123', '01:02:03', '{"k": 1}', 1.5, null)`,
+		`insert into test.t values (18446744073709551615, 'c', null, null, 'c', 'y', null, null, null, null, null, 3)`)
+	insertRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	newRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	events := []*pevent.RowEvent{
+		{Event: insertRow},
+		{Event: pevent.RowChange{PreRow: insertRow.Row, Row: newRow.Row, RowType: pevent.RowTypeUpdate}},
+		{Event: pevent.RowChange{PreRow: newRow.Row, RowType: pevent.RowTypeDelete}},
+	}
+
+	for _, contentCompatible := range []bool{true, false} {
+		codecConfig := common.NewConfig(config.ProtocolCanalJSON)
+		codecConfig.EnableTiDBExtension = true
+		codecConfig.ContentCompatible = contentCompatible
+		decoder, err := NewBatchDecoder(codecConfig, nil)
+		require.NoError(t, err)
+
+		for i, e := range events {
+			e.TableInfo = tableInfo
+			e.CommitTs = uint64(100 + i)
+			e.ColumnSelector = columnselector.NewDefaultColumnSelector()
+			value, err := newJSONMessageForDML(e, codecConfig, false, "")
+			require.NoError(t, err)
+
+			require.NoError(t, decoder.AddKeyValue(nil, value))
+			tp, hasNext, err := decoder.HasNext()
+			require.NoError(t, err)
+			require.True(t, hasNext)
+			require.Equal(t, model.MessageTypeRow, tp)
+			decoded, err := decoder.NextDMLEvent()
+			require.NoError(t, err)
+			require.Equal(t, e.CommitTs, decoded.CommitTs)
+			require.Equal(t, []string{"id", "name"}, decoded.TableInfo.GetPrimaryKeyColumnNames())
+
+			row, ok := decoded.GetNextRow()
+			require.True(t, ok)
+			require.Equal(t, e.Event.RowType, row.RowType)
+			// the values written to the downstream must be the same as the original ones.
+			// the precision of the time types is unknown if only the basic mysql type is encoded.
+			skipped := map[string]struct{}{}
+			if !contentCompatible {
+				skipped = map[string]struct{}{"dt": {}, "tm": {}}
+			}
+			requireSameColumnValues(t, tableInfo, decoded.TableInfo, &e.Event.PreRow, &row.PreRow, skipped)
+			requireSameColumnValues(t, tableInfo, decoded.TableInfo, &e.Event.Row, &row.Row, skipped)
+		}
+	}
+}
+
+func requireSameColumnValues(
+	t *testing.T, expectedTableInfo, actualTableInfo *commonType.TableInfo,
+	expected, actual *chunk.Row, skipped map[string]struct{},
+) {
+	require.Equal(t, expected.IsEmpty(), actual.IsEmpty())
+	if expected.IsEmpty() {
+		return
+	}
+	actualColumns := actualTableInfo.GetColumns()
+	require.Len(t, actualColumns, len(expectedTableInfo.GetColumns()))
+	for i, col := range expectedTableInfo.GetColumns() {
+		if _, ok := skipped[col.Name.O]; ok {
+			continue
+		}
+		expectedValue, err := commonType.FormatColVal(expected, col, i)
+		require.NoError(t, err)
+		offset := actualTableInfo.GetColumnsOffset()[actualTableInfo.ForceGetColumnIDByName(col.Name.O)]
+		actualValue, err := commonType.FormatColVal(actual, actualColumns[offset], offset)
+		require.NoError(t, err)
+		require.Equal(t, expectedValue, actualValue, col.Name.O)
+	}
+}
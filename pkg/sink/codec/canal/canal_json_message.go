@@ -13,27 +13,43 @@
 
 package canal
 
-// import (
-// 	"github.com/pingcap/tiflow/cdc/model"
-// 	canal "github.com/pingcap/tiflow/proto/canal"
-// )
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	ptypes "github.com/pingcap/tidb/pkg/parser/types"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/sink/codec/utils"
+	canal "github.com/pingcap/tiflow/proto/canal"
+	"golang.org/x/text/encoding/charmap"
+)
 
 const tidbWaterMarkType = "TIDB_WATERMARK"
 
-// // The TiCDC Canal-JSON implementation extend the official format with a TiDB extension field.
-// // canalJSONMessageInterface is used to support this without affect the original format.
+// The TiCDC Canal-JSON implementation extend the official format with a TiDB extension field.
+// canalJSONMessageInterface is used to support this without affect the original format.
 type canalJSONMessageInterface interface {
-	// getSchema() *string
-	// getTable() *string
-	// getCommitTs() uint64
-	// getQuery() string
-	// getOld() map[string]interface{}
-	// getData() map[string]interface{}
-	// getMySQLType() map[string]string
-	// getJavaSQLType() map[string]int32
-	// messageType() model.MessageType
-	// eventType() canal.EventType
-	// pkNameSet() map[string]struct{}
+	getSchema() *string
+	getTable() *string
+	getCommitTs() uint64
+	getQuery() string
+	getOld() map[string]interface{}
+	getData() map[string]interface{}
+	getMySQLType() map[string]string
+	getJavaSQLType() map[string]int32
+	messageType() model.MessageType
+	eventType() canal.EventType
+	pkNameSet() map[string]struct{}
 }
 
 // JSONMessage adapted from https://github.com/alibaba/canal/blob/b54bea5e3337c9597c427a53071d214ff04628d1/protocol/src/main/java/com/alibaba/otter/canal/protocol/FlatMessage.java#L1
@@ -60,68 +76,68 @@ type JSONMessage struct {
 	Old  []map[string]interface{} `json:"old"`
 }
 
-// func (c *JSONMessage) getSchema() *string {
-// 	return &c.Schema
-// }
-
-// func (c *JSONMessage) getTable() *string {
-// 	return &c.Table
-// }
-
-// // for JSONMessage, we lost the commitTs.
-// func (c *JSONMessage) getCommitTs() uint64 {
-// 	return 0
-// }
-
-// func (c *JSONMessage) getQuery() string {
-// 	return c.Query
-// }
-
-// func (c *JSONMessage) getOld() map[string]interface{} {
-// 	if c.Old == nil {
-// 		return nil
-// 	}
-// 	return c.Old[0]
-// }
-
-// func (c *JSONMessage) getData() map[string]interface{} {
-// 	if c.Data == nil {
-// 		return nil
-// 	}
-// 	return c.Data[0]
-// }
-
-// func (c *JSONMessage) getMySQLType() map[string]string {
-// 	return c.MySQLType
-// }
-
-// func (c *JSONMessage) getJavaSQLType() map[string]int32 {
-// 	return c.SQLType
-// }
-
-// func (c *JSONMessage) messageType() model.MessageType {
-// 	if c.IsDDL {
-// 		return model.MessageTypeDDL
-// 	}
-
-// 	if c.EventType == tidbWaterMarkType {
-// 		return model.MessageTypeResolved
-// 	}
-
-// 	return model.MessageTypeRow
-// }
-
-// func (c *JSONMessage) eventType() canal.EventType {
-// 	return canal.EventType(canal.EventType_value[c.EventType])
-// }
-
-// func (c *JSONMessage) pkNameSet() map[string]struct{} {
-// 	result := make(map[string]struct{}, len(c.PKNames))
-// 	for _, item := range c.PKNames {
-// 		result[item] = struct{}{}
-// 	}
-// 	return result
-// }
+func (c *JSONMessage) getSchema() *string {
+	return &c.Schema
+}
+
+func (c *JSONMessage) getTable() *string {
+	return &c.Table
+}
+
+// for JSONMessage, we lost the commitTs.
+func (c *JSONMessage) getCommitTs() uint64 {
+	return 0
+}
+
+func (c *JSONMessage) getQuery() string {
+	return c.Query
+}
+
+func (c *JSONMessage) getOld() map[string]interface{} {
+	if c.Old == nil {
+		return nil
+	}
+	return c.Old[0]
+}
+
+func (c *JSONMessage) getData() map[string]interface{} {
+	if c.Data == nil {
+		return nil
+	}
+	return c.Data[0]
+}
+
+func (c *JSONMessage) getMySQLType() map[string]string {
+	return c.MySQLType
+}
+
+func (c *JSONMessage) getJavaSQLType() map[string]int32 {
+	return c.SQLType
+}
+
+func (c *JSONMessage) messageType() model.MessageType {
+	if c.IsDDL {
+		return model.MessageTypeDDL
+	}
+
+	if c.EventType == tidbWaterMarkType {
+		return model.MessageTypeResolved
+	}
+
+	return model.MessageTypeRow
+}
+
+func (c *JSONMessage) eventType() canal.EventType {
+	return canal.EventType(canal.EventType_value[c.EventType])
+}
+
+func (c *JSONMessage) pkNameSet() map[string]struct{} {
+	result := make(map[string]struct{}, len(c.PKNames))
+	for _, item := range c.PKNames {
+		result[item] = struct{}{}
+	}
+	return result
+}
 
 type tidbExtension struct {
 	CommitTs           uint64 `json:"commitTs,omitempty"`
@@ -139,6 +155,287 @@ type canalJSONMessageWithTiDBExtension struct {
 	Extensions *tidbExtension `json:"_tidb"`
 }
 
-// func (c *canalJSONMessageWithTiDBExtension) getCommitTs() uint64 {
-// 	return c.Extensions.CommitTs
-// }
+func (c *canalJSONMessageWithTiDBExtension) getCommitTs() uint64 {
+	return c.Extensions.CommitTs
+}
+
+// canalJSONMessage2DMLEvent converts the canal-json message to a dml event which contains only one row change,
+// the values are restored according to the given table info.
+func canalJSONMessage2DMLEvent(
+	msg canalJSONMessageInterface, tableInfo *commonType.TableInfo,
+) (*commonEvent.DMLEvent, error) {
+	// The physical table id and start ts are lost in the canal-json message.
+	result := commonEvent.NewDMLEvent(commonType.DispatcherID{}, 0, 0, msg.getCommitTs(), tableInfo)
+	switch msg.eventType() {
+	case canal.EventType_DELETE:
+		// for `DELETE` event, `data` contain the old data.
+		preRow, err := canalJSONColumnMap2Datums(msg.getData(), nil, tableInfo)
+		if err != nil {
+			return nil, err
+		}
+		result.AppendRowDatums(commonEvent.RowTypeDelete, preRow, nil)
+	case canal.EventType_INSERT:
+		row, err := canalJSONColumnMap2Datums(msg.getData(), nil, tableInfo)
+		if err != nil {
+			return nil, err
+		}
+		result.AppendRowDatums(commonEvent.RowTypeInsert, nil, row)
+	case canal.EventType_UPDATE:
+		row, err := canalJSONColumnMap2Datums(msg.getData(), nil, tableInfo)
+		if err != nil {
+			return nil, err
+		}
+		// `old` may only contain the updated columns,
+		// the unchanged columns are filled by `data`.
+		preRow, err := canalJSONColumnMap2Datums(msg.getOld(), msg.getData(), tableInfo)
+		if err != nil {
+			return nil, err
+		}
+		result.AppendRowDatums(commonEvent.RowTypeUpdate, preRow, row)
+	default:
+		return nil, errors.ErrCanalDecodeFailed.GenWithStack(
+			"unknown event type %s of the row changed event", msg.eventType())
+	}
+	return result, nil
+}
+
+// canalJSONColumnMap2Datums converts the column values to the datums ordered the same as
+// the columns of the table info. If a column is not found in the cols, it's looked up in
+// the fallback, and the null value is used if it's still not found.
+func canalJSONColumnMap2Datums(
+	cols map[string]interface{}, fallback map[string]interface{}, tableInfo *commonType.TableInfo,
+) ([]types.Datum, error) {
+	columns := tableInfo.GetColumns()
+	datums := make([]types.Datum, 0, len(columns))
+	for _, col := range columns {
+		value, ok := cols[col.Name.O]
+		if !ok {
+			value = fallback[col.Name.O]
+		}
+		d, err := canalJSONFormatColumn(value, col, tableInfo.ForceGetColumnFlagType(col.ID))
+		if err != nil {
+			return nil, err
+		}
+		datums = append(datums, d)
+	}
+	return datums, nil
+}
+
+// canalJSONFormatColumn converts the column value encoded by `formatColumnValue` to the datum.
+func canalJSONFormatColumn(
+	value interface{}, colInfo *timodel.ColumnInfo, flag *commonType.ColumnFlagType,
+) (types.Datum, error) {
+	var d types.Datum
+	if value == nil {
+		return d, nil
+	}
+	data, ok := value.(string)
+	if !ok {
+		return d, errors.ErrCanalDecodeFailed.GenWithStack(
+			"canal-json encoded value of column %s should be string, but got %T", colInfo.Name.O, value)
+	}
+
+	var err error
+	ft := &colInfo.FieldType
+	switch ft.GetType() {
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
+		mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		if !flag.IsBinary() {
+			d.SetString(data, ft.GetCollate())
+			break
+		}
+		// when encoding the binary value, use `ISO8859_1` decoder, now reverse it back.
+		var bytesValue []byte
+		bytesValue, err = charmap.ISO8859_1.NewEncoder().Bytes([]byte(data))
+		d.SetBytes(bytesValue)
+	case mysql.TypeBit:
+		var bitValue uint64
+		bitValue, err = strconv.ParseUint(data, 10, 64)
+		byteSize := (ft.GetFlen() + 7) >> 3
+		d.SetMysqlBit(types.NewBinaryLiteralFromUint(bitValue, byteSize))
+	case mysql.TypeEnum:
+		var (
+			enumValue uint64
+			enumVar   types.Enum
+		)
+		enumValue, err = strconv.ParseUint(data, 10, 64)
+		enumVar.Value = enumValue
+		// the elements are unknown if the table info is built from the basic mysql type.
+		if err == nil && len(ft.GetElems()) != 0 {
+			enumVar, err = types.ParseEnumValue(ft.GetElems(), enumValue)
+		}
+		d.SetMysqlEnum(enumVar, ft.GetCollate())
+	case mysql.TypeSet:
+		var (
+			setValue uint64
+			setVar   types.Set
+		)
+		setValue, err = strconv.ParseUint(data, 10, 64)
+		setVar.Value = setValue
+		if err == nil && len(ft.GetElems()) != 0 {
+			setVar, err = types.ParseSetValue(ft.GetElems(), setValue)
+		}
+		d.SetMysqlSet(setVar, ft.GetCollate())
+	default:
+		strDatum := types.NewStringDatum(data)
+		d, err = strDatum.ConvertTo(types.DefaultStmtNoWarningContext, ft)
+	}
+	if err != nil {
+		return d, errors.WrapError(errors.ErrCanalDecodeFailed, err)
+	}
+	return d, nil
+}
+
+// newTableInfo builds the table info from the `mysqlType` and `pkNames` of the message,
+// the columns are sorted by the name, since the original order is lost in the message.
+func newTableInfo(msg canalJSONMessageInterface) *commonType.TableInfo {
+	mysqlType := msg.getMySQLType()
+	names := make([]string, 0, len(mysqlType))
+	for name := range mysqlType {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pkNames := msg.pkNameSet()
+	columns := make([]*timodel.ColumnInfo, 0, len(names))
+	for _, name := range names {
+		col := newColumnInfo(name, mysqlType[name])
+		if _, ok := pkNames[name]; ok {
+			col.AddFlag(mysql.PriKeyFlag | mysql.NotNullFlag)
+		}
+		columns = append(columns, col)
+	}
+	return common.NewTableInfo4Decoder(*msg.getSchema(), *msg.getTable(), columns)
+}
+
+// newColumnInfo builds the column info from the mysql type of the column,
+// it may be the full type such as `decimal(10,2) unsigned` and `enum('a','b')`,
+// or only the basic type such as `decimal unsigned`, depends on the content compatible option.
+func newColumnInfo(name, mysqlType string) *timodel.ColumnInfo {
+	var (
+		basicType  = mysqlType
+		args       string
+		attributes string
+	)
+	if start := strings.IndexByte(mysqlType, '('); start >= 0 {
+		end := strings.LastIndexByte(mysqlType, ')')
+		if end < start {
+			end = len(mysqlType)
+		}
+		basicType = mysqlType[:start]
+		args = mysqlType[start+1 : end]
+		if end < len(mysqlType) {
+			attributes = mysqlType[end+1:]
+		}
+	} else if idx := strings.IndexByte(mysqlType, ' '); idx >= 0 {
+		basicType = mysqlType[:idx]
+		attributes = mysqlType[idx:]
+	}
+
+	col := &timodel.ColumnInfo{Name: pmodel.NewCIStr(name)}
+	col.FieldType = *types.NewFieldType(ptypes.StrToType(basicType))
+	if strings.Contains(attributes, "unsigned") {
+		col.AddFlag(mysql.UnsignedFlag)
+	}
+	if strings.Contains(attributes, "zerofill") {
+		col.AddFlag(mysql.ZerofillFlag)
+	}
+
+	switch col.GetType() {
+	case mysql.TypeEnum, mysql.TypeSet:
+		col.SetElems(parseElems(args))
+	case mysql.TypeDatetime, mysql.TypeTimestamp, mysql.TypeDuration:
+		// keep the fractional seconds if the precision is not known.
+		col.SetDecimal(types.MaxFsp)
+		if fsp, err := strconv.Atoi(strings.TrimSpace(args)); err == nil {
+			col.SetDecimal(fsp)
+		}
+	default:
+		if args != "" {
+			parts := strings.Split(args, ",")
+			if flen, err := strconv.Atoi(strings.TrimSpace(parts[0])); err == nil {
+				col.SetFlen(flen)
+			}
+			if len(parts) > 1 {
+				if decimal, err := strconv.Atoi(strings.TrimSpace(parts[1])); err == nil {
+					col.SetDecimal(decimal)
+				}
+			}
+		}
+	}
+
+	switch col.GetType() {
+	case mysql.TypeBit:
+		// the bit value is encoded as uint64, so it can hold 64 bits at most.
+		if col.GetFlen() == types.UnspecifiedLength {
+			col.SetFlen(64)
+		}
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
+		mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		if utils.IsBinaryMySQLType(basicType) {
+			col.SetCharset(charset.CharsetBin)
+			col.SetCollate(charset.CollationBin)
+			col.AddFlag(mysql.BinaryFlag)
+		} else {
+			col.SetCharset(mysql.DefaultCharset)
+			col.SetCollate(mysql.DefaultCollationName)
+		}
+	default:
+	}
+	return col
+}
+
+// parseElems parses the elements of the enum or set type, such as `'a','b'`.
+func parseElems(args string) []string {
+	var (
+		result  []string
+		elem    strings.Builder
+		inQuote bool
+	)
+	for i := 0; i < len(args); i++ {
+		c := args[i]
+		switch {
+		case c == '\'' && inQuote && i+1 < len(args) && args[i+1] == '\'':
+			// the quote is escaped by another quote
+			elem.WriteByte(c)
+			i++
+		case c == '\'':
+			inQuote = !inQuote
+			if !inQuote {
+				result = append(result, elem.String())
+				elem.Reset()
+			}
+		case inQuote:
+			elem.WriteByte(c)
+		default:
+		}
+	}
+	return result
+}
+
+func canalJSONMessage2DDLEvent(msg canalJSONMessageInterface) *commonEvent.DDLEvent {
+	query := msg.getQuery()
+	return &commonEvent.DDLEvent{
+		// we lost the startTs from the message
+		FinishedTs: msg.getCommitTs(),
+		SchemaName: *msg.getSchema(),
+		TableName:  *msg.getTable(),
+		Query:      query,
+		// we lost DDL type from canal json format, only got the DDL SQL.
+		Type: byte(getDDLActionType(query)),
+	}
+}
+
+// return DDL ActionType by the prefix
+// see https://github.com/pingcap/tidb/blob/6dbf2de2f/parser/model/ddl.go#L101-L102
+func getDDLActionType(query string) timodel.ActionType {
+	query = strings.ToLower(query)
+	if strings.HasPrefix(query, "create schema") || strings.HasPrefix(query, "create database") {
+		return timodel.ActionCreateSchema
+	}
+	if strings.HasPrefix(query, "drop schema") || strings.HasPrefix(query, "drop database") {
+		return timodel.ActionDropSchema
+	}
+
+	return timodel.ActionNone
+}
@@ -113,6 +113,11 @@ func formatColumnValue(row *chunk.Row, idx int, columnInfo *timodel.ColumnInfo,
 		}
 	case mysql.TypeEnum:
 		javaType = internal.JavaSQLTypeINTEGER
+		// the fixed length value of the null column is undefined, check it before reading.
+		if row.IsNull(idx) {
+			value = "null"
+			break
+		}
 		enumValue := row.GetEnum(idx).Value
 		if enumValue == 0 {
 			value = "null"
@@ -121,6 +126,10 @@ func formatColumnValue(row *chunk.Row, idx int, columnInfo *timodel.ColumnInfo,
 		}
 	case mysql.TypeSet:
 		javaType = internal.JavaSQLTypeBIT
+		if row.IsNull(idx) {
+			value = "null"
+			break
+		}
 		bitValue := row.GetEnum(idx).Value
 		if bitValue == 0 {
 			value = "null"
@@ -129,6 +138,10 @@ func formatColumnValue(row *chunk.Row, idx int, columnInfo *timodel.ColumnInfo,
 		}
 	case mysql.TypeDate, mysql.TypeNewDate:
 		javaType = internal.JavaSQLTypeDATE
+		if row.IsNull(idx) {
+			value = "null"
+			break
+		}
 		timeValue := row.GetTime(idx)
 		if timeValue.IsZero() {
 			value = "null"
@@ -137,6 +150,10 @@ func formatColumnValue(row *chunk.Row, idx int, columnInfo *timodel.ColumnInfo,
 		}
 	case mysql.TypeDatetime, mysql.TypeTimestamp:
 		javaType = internal.JavaSQLTypeTIMESTAMP
+		if row.IsNull(idx) {
+			value = "null"
+			break
+		}
 		timeValue := row.GetTime(idx)
 		if timeValue.IsZero() {
 			value = "null"
@@ -145,6 +162,10 @@ func formatColumnValue(row *chunk.Row, idx int, columnInfo *timodel.ColumnInfo,
 		}
 	case mysql.TypeDuration:
 		javaType = internal.JavaSQLTypeTIME
+		if row.IsNull(idx) {
+			value = "null"
+			break
+		}
 		durationValue := row.GetDuration(idx, 0)
 		if durationValue.ToNumber().IsZero() {
 			value = "null"
@@ -166,6 +187,10 @@ func formatColumnValue(row *chunk.Row, idx int, columnInfo *timodel.ColumnInfo,
 		}
 	case mysql.TypeNewDecimal:
 		javaType = internal.JavaSQLTypeDECIMAL
+		if row.IsNull(idx) {
+			value = "null"
+			break
+		}
 		decimalValue := row.GetMyDecimal(idx)
 		if decimalValue.IsZero() {
 			value = "null"
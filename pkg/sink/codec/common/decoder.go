@@ -14,11 +14,16 @@
 package common
 
 import (
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
 )
 
-// RowEventDecoder is an abstraction for events decoder
-// this interface is only for testing now
+// RowEventDecoder is an abstraction for events decoder,
+// it's used by the consumers to restore the events from the encoded messages.
 type RowEventDecoder interface {
 	// AddKeyValue add the received key and values to the decoder,
 	// should be called before `HasNext`
@@ -32,8 +37,43 @@ type RowEventDecoder interface {
 	HasNext() (model.MessageType, bool, error)
 	// NextResolvedEvent returns the next resolved event if exists
 	NextResolvedEvent() (uint64, error)
-	// NextRowChangedEvent returns the next row changed event if exists
-	// NextRowChangedEvent() (*common.RowChangedEvent, error)
+	// NextDMLEvent returns the next dml event if exists
+	NextDMLEvent() (*commonEvent.DMLEvent, error)
 	// NextDDLEvent returns the next DDL event if exists
-	NextDDLEvent() (*model.DDLEvent, error)
+	NextDDLEvent() (*commonEvent.DDLEvent, error)
+}
+
+// NewTableInfo4Decoder creates the table info for the protocols which do not carry
+// the table schema, the columns are built from the information found in the message.
+// The columns with the primary key flag make up the primary key,
+// which is used to locate the row in the downstream.
+func NewTableInfo4Decoder(schema, table string, columns []*timodel.ColumnInfo) *commonType.TableInfo {
+	tidbTableInfo := &timodel.TableInfo{
+		Name:    pmodel.NewCIStr(table),
+		Columns: columns,
+	}
+	var pkColumns []*timodel.IndexColumn
+	for i, col := range columns {
+		col.ID = int64(i + 1)
+		col.Offset = i
+		col.State = timodel.StatePublic
+		if mysql.HasPriKeyFlag(col.GetFlag()) {
+			pkColumns = append(pkColumns, &timodel.IndexColumn{
+				Name:   col.Name,
+				Offset: i,
+				Length: -1,
+			})
+		}
+	}
+	if len(pkColumns) != 0 {
+		tidbTableInfo.Indices = []*timodel.IndexInfo{{
+			ID:      1,
+			Name:    pmodel.NewCIStr("primary"),
+			Columns: pkColumns,
+			Unique:  true,
+			Primary: true,
+			State:   timodel.StatePublic,
+		}}
+	}
+	return commonType.WrapTableInfo(0, schema, tidbTableInfo)
 }
@@ -35,6 +35,14 @@ type EventEncoder interface {
 	Clean()
 }
 
+// TxnEventEncoder is an abstraction for txn events encoder.
+type TxnEventEncoder interface {
+	// AppendTxnEvent append a txn event into the buffer.
+	AppendTxnEvent(*commonEvent.DMLEvent) error
+	// Build builds the batch messages from AppendTxnEvent and returns the messages.
+	Build() []*Message
+}
+
 // IsColumnValueEqual checks whether the preValue and updatedValue are equal.
 func IsColumnValueEqual(preValue, updatedValue interface{}) bool {
 	if preValue == nil || updatedValue == nil {
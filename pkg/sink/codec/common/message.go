@@ -45,6 +45,9 @@ type Message struct {
 	Value     []byte
 	rowsCount int    // rows in one Message
 	Callback  func() // Callback function will be called when the message is sent to the sink.
+
+	// PartitionKey for pulsar, route messages to one or different partitions
+	PartitionKey *string
 }
 
 // Length returns the expected size of the Kafka message
@@ -54,6 +57,14 @@ func (m *Message) Length() int {
 	return len(m.Key) + len(m.Value) + MaxRecordOverhead
 }
 
+// GetPartitionKey returns the partition key of the message
+func (m *Message) GetPartitionKey() string {
+	if m.PartitionKey == nil {
+		return ""
+	}
+	return *m.PartitionKey
+}
+
 // GetRowsCount returns the number of rows batched in one Message
 func (m *Message) GetRowsCount() int {
 	return m.rowsCount
@@ -0,0 +1,144 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csv
+
+import (
+	"context"
+	"io"
+
+	"github.com/pingcap/errors"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	lconfig "github.com/pingcap/tidb/pkg/lightning/config"
+	"github.com/pingcap/tidb/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/pkg/lightning/worker"
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+const defaultIOConcurrency = 1
+
+type batchDecoder struct {
+	codecConfig *common.Config
+	parser      *mydump.CSVParser
+	msg         *csvMessage
+	tableInfo   *commonType.TableInfo
+	closed      bool
+}
+
+// NewBatchDecoder creates a new csv decoder, which decodes the content of a csv file
+// into the dml events. The tableInfo must be the one used to encode the csv file.
+func NewBatchDecoder(
+	ctx context.Context,
+	codecConfig *common.Config,
+	tableInfo *commonType.TableInfo,
+	value []byte,
+) (common.RowEventDecoder, error) {
+	cfg := &lconfig.CSVConfig{
+		Separator:  codecConfig.Delimiter,
+		Delimiter:  codecConfig.Quote,
+		Terminator: codecConfig.Terminator,
+		Null:       []string{codecConfig.NullString},
+	}
+	// if quote is not set in config, the csv columns are escaped by
+	// backslash, so we should unescape them when parsing csv columns.
+	if len(codecConfig.Quote) == 0 {
+		cfg.BackslashEscape = true
+		cfg.EscapedBy = `\`
+	}
+	csvParser, err := mydump.NewCSVParser(ctx, cfg,
+		mydump.NewStringReader(string(value)),
+		int64(lconfig.ReadBlockSize),
+		worker.NewPool(ctx, defaultIOConcurrency, "io"), false, nil)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrCSVDecodeFailed, err)
+	}
+	return &batchDecoder{
+		codecConfig: codecConfig,
+		tableInfo:   tableInfo,
+		msg:         newCSVMessage(codecConfig),
+		parser:      csvParser,
+	}, nil
+}
+
+// AddKeyValue implements the RowEventDecoder interface.
+func (b *batchDecoder) AddKeyValue(_, _ []byte) error {
+	return nil
+}
+
+// HasNext implements the RowEventDecoder interface.
+func (b *batchDecoder) HasNext() (model.MessageType, bool, error) {
+	hasNext, err := b.readRow()
+	if err != nil || !hasNext {
+		return model.MessageTypeUnknown, false, err
+	}
+	// When the old value is output, an update event is split into a delete row
+	// and an insert row, both of them are marked by the is-updated column.
+	if !b.msg.isUpdated || b.msg.opType != operationDelete {
+		return model.MessageTypeRow, true, nil
+	}
+	preColumns := b.msg.columns
+	hasNext, err = b.readRow()
+	if err != nil {
+		return model.MessageTypeUnknown, false, err
+	}
+	if !hasNext || !b.msg.isUpdated || b.msg.opType != operationInsert {
+		return model.MessageTypeUnknown, false, cerror.WrapError(cerror.ErrCSVDecodeFailed,
+			errors.New("the old value of the update event is not followed by the new value"))
+	}
+	b.msg.opType = operationUpdate
+	b.msg.preColumns = preColumns
+	return model.MessageTypeRow, true, nil
+}
+
+func (b *batchDecoder) readRow() (bool, error) {
+	err := b.parser.ReadRow()
+	if err != nil {
+		b.closed = true
+		if errors.Cause(err) == io.EOF {
+			return false, nil
+		}
+		return false, cerror.WrapError(cerror.ErrCSVDecodeFailed, err)
+	}
+	b.msg.preColumns = nil
+	row := b.parser.LastRow()
+	if err = b.msg.decode(row.Row); err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+// NextResolvedEvent implements the RowEventDecoder interface.
+func (b *batchDecoder) NextResolvedEvent() (uint64, error) {
+	return 0, nil
+}
+
+// NextDMLEvent implements the RowEventDecoder interface.
+func (b *batchDecoder) NextDMLEvent() (*commonEvent.DMLEvent, error) {
+	if b.closed {
+		return nil, cerror.WrapError(cerror.ErrCSVDecodeFailed, errors.New("no csv row can be found"))
+	}
+
+	e, err := csvMsg2DMLEvent(b.codecConfig, b.msg, b.tableInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return e, nil
+}
+
+// NextDDLEvent implements the RowEventDecoder interface.
+func (b *batchDecoder) NextDDLEvent() (*commonEvent.DDLEvent, error) {
+	return nil, nil
+}
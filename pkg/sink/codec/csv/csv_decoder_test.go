@@ -0,0 +1,146 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csv
+
+import (
+	"context"
+	"testing"
+
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVDecodeRoundTrip(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(
+		id bigint unsigned primary key, name varchar(32), bin varbinary(16), b bit(10),
+		e enum('a','b','c'), s set('x','y'), d decimal(10, 2), dt datetime(3),
+		tm time, j json, f double, n int)`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t",
+		`insert into test.t values (18446744073709551615, 'a,"b"\n\\', x'0102', b'1000000001',
+			'b', 'x,y', 12.34, '2023-11-30 06:38:29.123', '01:02:03', '{"k": 1}', 1.5, null)`,
+		`insert into test.t values (2, 'c', null, null, 'c', 'y', null, null, null, null, null, 3)`)
+	insertRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	newRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	events := []*commonEvent.RowEvent{
+		{TableInfo: tableInfo, CommitTs: 100, Event: insertRow},
+		{TableInfo: tableInfo, CommitTs: 101, Event: commonEvent.RowChange{
+			PreRow: insertRow.Row, Row: newRow.Row, RowType: commonEvent.RowTypeUpdate,
+		}},
+		{TableInfo: tableInfo, CommitTs: 102, Event: commonEvent.RowChange{
+			PreRow: newRow.Row, RowType: commonEvent.RowTypeDelete,
+		}},
+	}
+
+	ctx := context.Background()
+	for _, quote := range []string{"\"", ""} {
+		for _, outputOldValue := range []bool{true, false} {
+			codecConfig := newCSVCodecConfig()
+			codecConfig.Quote = quote
+			codecConfig.IncludeCommitTs = true
+			codecConfig.OutputOldValue = outputOldValue
+			codecConfig.BinaryEncodingMethod = config.BinaryEncodingHex
+
+			var content []byte
+			expected := make([]string, 0, len(events))
+			for _, e := range events {
+				msg, err := rowChangedEvent2CSVMsg(codecConfig, e)
+				require.NoError(t, err)
+				value := msg.encode()
+				content = append(content, value...)
+				expected = append(expected, string(value))
+			}
+
+			decoder, err := NewBatchDecoder(ctx, codecConfig, tableInfo, content)
+			require.NoError(t, err)
+			for i, e := range events {
+				tp, hasNext, err := decoder.HasNext()
+				require.NoError(t, err)
+				require.True(t, hasNext)
+				require.Equal(t, model.MessageTypeRow, tp)
+				decoded, err := decoder.NextDMLEvent()
+				require.NoError(t, err)
+				require.Equal(t, e.CommitTs, decoded.CommitTs)
+				require.Equal(t, int32(1), decoded.Len())
+
+				row, ok := decoded.GetNextRow()
+				require.True(t, ok)
+				expectedType := e.Event.RowType
+				if e.IsUpdate() && !outputOldValue {
+					// the update event without old value can only be restored as an insert event.
+					expectedType = commonEvent.RowTypeInsert
+				}
+				require.Equal(t, expectedType, row.RowType)
+
+				// encode the decoded event again, it must be the same as the original one.
+				msg, err := rowChangedEvent2CSVMsg(codecConfig, &commonEvent.RowEvent{
+					TableInfo: tableInfo, CommitTs: decoded.CommitTs, Event: row,
+				})
+				require.NoError(t, err)
+				if e.IsUpdate() && !outputOldValue {
+					msg.opType = operationUpdate
+				}
+				require.Equal(t, expected[i], string(msg.encode()))
+			}
+			_, hasNext, err := decoder.HasNext()
+			require.NoError(t, err)
+			require.False(t, hasNext)
+		}
+	}
+}
+
+func TestCSVDecodeInvalidRow(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(id int primary key, name varchar(32))`)
+	tableInfo := helper.GetTableInfo(job)
+
+	ctx := context.Background()
+	codecConfig := newCSVCodecConfig()
+	codecConfig.OutputOldValue = true
+
+	// the old value of the update event must be followed by the new value.
+	decoder, err := NewBatchDecoder(ctx, codecConfig, tableInfo,
+		[]byte("\"D\",\"t\",\"test\",true,1,\"a\"\n"))
+	require.NoError(t, err)
+	_, _, err = decoder.HasNext()
+	require.Error(t, err)
+
+	// the column count mismatches the table.
+	decoder, err = NewBatchDecoder(ctx, codecConfig, tableInfo,
+		[]byte("\"I\",\"t\",\"test\",false,1\n"))
+	require.NoError(t, err)
+	_, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	_, err = decoder.NextDMLEvent()
+	require.Error(t, err)
+
+	// unknown operation type.
+	decoder, err = NewBatchDecoder(ctx, codecConfig, tableInfo,
+		[]byte("\"X\",\"t\",\"test\",false,1,\"a\"\n"))
+	require.NoError(t, err)
+	_, _, err = decoder.HasNext()
+	require.Error(t, err)
+}
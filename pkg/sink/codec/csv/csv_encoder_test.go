@@ -0,0 +1,102 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csv
+
+import (
+	"testing"
+
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newCSVCodecConfig() *common.Config {
+	codecConfig := common.NewConfig(config.ProtocolCsv)
+	codecConfig.Delimiter = ","
+	codecConfig.Quote = "\""
+	codecConfig.Terminator = "\n"
+	codecConfig.NullString = "\\N"
+	codecConfig.BinaryEncodingMethod = config.BinaryEncodingBase64
+	return codecConfig
+}
+
+func TestCSVBatchEncoder(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job(`create table test.t(
+		id int primary key, name varchar(32), bin varbinary(16),
+		e enum('a','b','c'), s set('x','y'), n int)`)
+	dmlEvent := helper.DML2Event("test", "t",
+		`insert into test.t values (1, 'a,"b"', x'0102', 'b', 'x,y', null)`,
+		`insert into test.t values (2, 'c', null, 'c', 'y', 3)`)
+	require.NotNil(t, dmlEvent)
+	dmlEvent.CommitTs = 100
+
+	codecConfig := newCSVCodecConfig()
+	codecConfig.IncludeCommitTs = true
+	encoder := NewTxnEventEncoder(codecConfig)
+	require.NoError(t, encoder.AppendTxnEvent(dmlEvent))
+
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Equal(t, 2, messages[0].GetRowsCount())
+	require.Equal(t,
+		"\"I\",\"t\",\"test\",100,1,\"a,\"\"b\"\"\",\"AQI=\",\"b\",\"x,y\",\\N\n"+
+			"\"I\",\"t\",\"test\",100,2,\"c\",\\N,\"c\",\"y\",3\n",
+		string(messages[0].Value))
+
+	// the encoder is reset after build.
+	require.Nil(t, encoder.Build())
+}
+
+func TestCSVOutputOldValue(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(id int primary key, name varchar(32))`)
+	dmlEvent := helper.DML2Event("test", "t",
+		`insert into test.t values (1, 'a')`,
+		`insert into test.t values (2, 'b')`)
+	require.NotNil(t, dmlEvent)
+	before, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	after, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	rowEvent := &commonEvent.RowEvent{
+		TableInfo: helper.GetTableInfo(job),
+		CommitTs:  100,
+		Event: commonEvent.RowChange{
+			PreRow:  before.Row,
+			Row:     after.Row,
+			RowType: commonEvent.RowTypeUpdate,
+		},
+	}
+
+	codecConfig := newCSVCodecConfig()
+	msg, err := rowChangedEvent2CSVMsg(codecConfig, rowEvent)
+	require.NoError(t, err)
+	require.Equal(t, "\"U\",\"t\",\"test\",2,\"b\"\n", string(msg.encode()))
+
+	codecConfig.OutputOldValue = true
+	msg, err = rowChangedEvent2CSVMsg(codecConfig, rowEvent)
+	require.NoError(t, err)
+	require.Equal(t,
+		"\"D\",\"t\",\"test\",true,1,\"a\"\n\"I\",\"t\",\"test\",true,2,\"b\"\n",
+		string(msg.encode()))
+}
@@ -24,10 +24,14 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	ticommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/hack"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/tikv/client-go/v2/oracle"
@@ -40,17 +44,30 @@ type dbzCodec struct {
 	nowFunc   func() time.Time
 }
 
+// selectedColumn returns whether the column should be written to the message.
+// Virtual generated columns are never written.
+func selectedColumn(selector columnselector.Selector, col *timodel.ColumnInfo) bool {
+	if !common.IsColCDCVisible(col) {
+		return false
+	}
+	return selector == nil || selector.Select(col)
+}
+
 func (c *dbzCodec) writeDebeziumFieldValues(
 	writer *util.JSONWriter,
 	fieldName string,
-	cols []*common.Column,
+	row *chunk.Row,
 	tableInfo *common.TableInfo,
+	selector columnselector.Selector,
 ) error {
 	var err error
-	colInfos := tableInfo.GetColInfosForRowChangedEvent()
+	flags := tableInfo.GetColumnFlags()
 	writer.WriteObjectField(fieldName, func() {
-		for i, col := range cols {
-			err = c.writeDebeziumFieldValue(writer, col, colInfos[i].Ft)
+		for idx, col := range tableInfo.GetColumns() {
+			if !selectedColumn(selector, col) {
+				continue
+			}
+			err = c.writeDebeziumFieldValue(writer, col, flags[col.ID], row, idx)
 			if err != nil {
 				break
 			}
@@ -61,17 +78,18 @@ func (c *dbzCodec) writeDebeziumFieldValues(
 
 func (c *dbzCodec) writeDebeziumFieldSchema(
 	writer *util.JSONWriter,
-	col *common.Column,
-	ft *types.FieldType,
+	col *timodel.ColumnInfo,
 ) {
-	switch col.Type {
+	ft := &col.FieldType
+	colName := col.Name.O
+	switch col.GetType() {
 	case mysql.TypeBit:
 		n := ft.GetFlen()
 		if n == 1 {
 			writer.WriteObjectElement(func() {
 				writer.WriteStringField("type", "boolean")
 				writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-				writer.WriteStringField("field", col.Name)
+				writer.WriteStringField("field", colName)
 			})
 		} else {
 			writer.WriteObjectElement(func() {
@@ -82,7 +100,7 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 				writer.WriteObjectField("parameters", func() {
 					writer.WriteStringField("length", fmt.Sprintf("%d", n))
 				})
-				writer.WriteStringField("field", col.Name)
+				writer.WriteStringField("field", colName)
 			})
 		}
 
@@ -91,7 +109,7 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 		writer.WriteObjectElement(func() {
 			writer.WriteStringField("type", "string")
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 
 	case mysql.TypeEnum:
@@ -103,7 +121,7 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 			writer.WriteObjectField("parameters", func() {
 				writer.WriteStringField("allowed", strings.Join(ft.GetElems(), ","))
 			})
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 
 	case mysql.TypeSet:
@@ -115,14 +133,14 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 			writer.WriteObjectField("parameters", func() {
 				writer.WriteStringField("allowed", strings.Join(ft.GetElems(), ","))
 			})
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 
 	case mysql.TypeNewDecimal:
 		writer.WriteObjectElement(func() {
 			writer.WriteStringField("type", "double")
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 
 	case mysql.TypeDate, mysql.TypeNewDate:
@@ -131,7 +149,7 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
 			writer.WriteStringField("name", "io.debezium.time.Date")
 			writer.WriteIntField("version", 1)
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 
 	case mysql.TypeDatetime:
@@ -144,7 +162,7 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 				writer.WriteStringField("name", "io.debezium.time.MicroTimestamp")
 			}
 			writer.WriteIntField("version", 1)
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 
 	case mysql.TypeTimestamp:
@@ -153,7 +171,7 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
 			writer.WriteStringField("name", "io.debezium.time.ZonedTimestamp")
 			writer.WriteIntField("version", 1)
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 
 	case mysql.TypeDuration:
@@ -162,7 +180,7 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
 			writer.WriteStringField("name", "io.debezium.time.MicroTime")
 			writer.WriteIntField("version", 1)
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 
 	case mysql.TypeJSON:
@@ -171,14 +189,14 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
 			writer.WriteStringField("name", "io.debezium.data.Json")
 			writer.WriteIntField("version", 1)
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 
 	case mysql.TypeTiny: // TINYINT
 		writer.WriteObjectElement(func() {
 			writer.WriteStringField("type", "int16")
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 
 	case mysql.TypeShort: // SMALLINT
@@ -189,14 +207,14 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 				writer.WriteStringField("type", "int16")
 			}
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 
 	case mysql.TypeInt24: // MEDIUMINT
 		writer.WriteObjectElement(func() {
 			writer.WriteStringField("type", "int32")
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 
 	case mysql.TypeLong: // INT
@@ -207,28 +225,28 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 				writer.WriteStringField("type", "int32")
 			}
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 
 	case mysql.TypeLonglong: // BIGINT
 		writer.WriteObjectElement(func() {
 			writer.WriteStringField("type", "int64")
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 
 	case mysql.TypeFloat:
 		writer.WriteObjectElement(func() {
 			writer.WriteStringField("type", "float")
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 
 	case mysql.TypeDouble:
 		writer.WriteObjectElement(func() {
 			writer.WriteStringField("type", "double")
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 
 	case mysql.TypeYear:
@@ -237,7 +255,7 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
 			writer.WriteStringField("name", "io.debezium.time.Year")
 			writer.WriteIntField("version", 1)
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 	case mysql.TypeTiDBVectorFloat32:
 		writer.WriteObjectElement(func() {
@@ -245,13 +263,13 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
 			writer.WriteStringField("name", "io.debezium.data.TiDBVectorFloat32")
 			writer.WriteIntField("version", 1)
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", colName)
 		})
 	default:
 		log.Warn(
 			"meet unsupported field type",
-			zap.Any("fieldType", col.Type),
-			zap.Any("column", col.Name),
+			zap.Any("fieldType", col.GetType()),
+			zap.String("column", colName),
 		)
 	}
 }
@@ -261,21 +279,25 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 //revive:disable indent-error-flow
 func (c *dbzCodec) writeDebeziumFieldValue(
 	writer *util.JSONWriter,
-	col *common.Column,
-	ft *types.FieldType,
+	col *timodel.ColumnInfo,
+	flag *common.ColumnFlagType,
+	row *chunk.Row,
+	idx int,
 ) error {
-	if col.Value == nil {
-		writer.WriteNullField(col.Name)
+	colName := col.Name.O
+	if row.IsNull(idx) {
+		writer.WriteNullField(colName)
 		return nil
 	}
-	switch col.Type {
+	ft := &col.FieldType
+	switch col.GetType() {
 	case mysql.TypeBit:
-		v, ok := col.Value.(uint64)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for bit column %s",
-				col.Value,
-				col.Name)
+		d := row.GetDatum(idx, ft)
+		v, err := d.GetBinaryLiteral().ToInt(types.DefaultStmtNoWarningContext)
+		if err != nil {
+			return cerror.WrapError(
+				cerror.ErrDebeziumEncodeFailed,
+				err)
 		}
 
 		// Debezium behavior:
@@ -284,7 +306,7 @@ func (c *dbzCodec) writeDebeziumFieldValue(
 		//						contain the specified number of bits.
 		n := ft.GetFlen()
 		if n == 1 {
-			writer.WriteBoolField(col.Name, v != 0)
+			writer.WriteBoolField(colName, v != 0)
 			return nil
 		} else {
 			var buf [8]byte
@@ -293,108 +315,73 @@ func (c *dbzCodec) writeDebeziumFieldValue(
 			if n%8 != 0 {
 				numBytes += 1
 			}
-			c.writeBinaryField(writer, col.Name, buf[:numBytes])
+			c.writeBinaryField(writer, colName, buf[:numBytes])
 			return nil
 		}
 
 	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
 		mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
-		v, ok := col.Value.([]byte)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for string column %s",
-				col.Value,
-				col.Name)
-		}
-
-		if col.Flag.IsBinary() {
-			c.writeBinaryField(writer, col.Name, v)
+		v := row.GetBytes(idx)
+		if flag.IsBinary() {
+			c.writeBinaryField(writer, colName, v)
 			return nil
 		} else {
-			writer.WriteStringField(col.Name, string(hack.String(v)))
+			writer.WriteStringField(colName, string(hack.String(v)))
 			return nil
 		}
 
 	case mysql.TypeEnum:
-		v, ok := col.Value.(uint64)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for enum column %s",
-				col.Value,
-				col.Name)
-		}
-
+		v := row.GetEnum(idx).Value
 		enumVar, err := types.ParseEnumValue(ft.GetElems(), v)
 		if err != nil {
 			// Invalid enum value inserted in non-strict mode.
-			writer.WriteStringField(col.Name, "")
+			writer.WriteStringField(colName, "")
 			return nil
 		}
 
-		writer.WriteStringField(col.Name, enumVar.Name)
+		writer.WriteStringField(colName, enumVar.Name)
 		return nil
 
 	case mysql.TypeSet:
-		v, ok := col.Value.(uint64)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for set column %s",
-				col.Value,
-				col.Name)
-		}
-
+		v := row.GetSet(idx).Value
 		setVar, err := types.ParseSetValue(ft.GetElems(), v)
 		if err != nil {
 			// Invalid enum value inserted in non-strict mode.
-			writer.WriteStringField(col.Name, "")
+			writer.WriteStringField(colName, "")
 			return nil
 		}
 
-		writer.WriteStringField(col.Name, setVar.Name)
+		writer.WriteStringField(colName, setVar.Name)
 		return nil
 
 	case mysql.TypeNewDecimal:
-		v, ok := col.Value.(string)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for decimal column %s",
-				col.Value,
-				col.Name)
-		}
-
-		floatV, err := strconv.ParseFloat(v, 64)
+		v := row.GetMyDecimal(idx)
+		floatV, err := strconv.ParseFloat(v.String(), 64)
 		if err != nil {
 			return cerror.WrapError(
 				cerror.ErrDebeziumEncodeFailed,
 				err)
 		}
 
-		writer.WriteFloat64Field(col.Name, floatV)
+		writer.WriteFloat64Field(colName, floatV)
 		return nil
 
 	case mysql.TypeDate, mysql.TypeNewDate:
-		v, ok := col.Value.(string)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for date column %s",
-				col.Value,
-				col.Name)
-		}
-
+		v := row.GetTime(idx).String()
 		t, err := time.Parse("2006-01-02", v)
 		if err != nil {
 			// For example, time may be invalid like 1000-00-00
 			// return nil, nil
 			if mysql.HasNotNullFlag(ft.GetFlag()) {
-				writer.WriteInt64Field(col.Name, 0)
+				writer.WriteInt64Field(colName, 0)
 				return nil
 			} else {
-				writer.WriteNullField(col.Name)
+				writer.WriteNullField(colName)
 				return nil
 			}
 		}
 
-		writer.WriteInt64Field(col.Name, t.Unix()/60/60/24)
+		writer.WriteInt64Field(colName, t.Unix()/60/60/24)
 		return nil
 
 	case mysql.TypeDatetime:
@@ -403,31 +390,24 @@ func (c *dbzCodec) writeDebeziumFieldValue(
 		// > column's precision by using UTC.
 
 		// TODO: For Default Value = CURRENT_TIMESTAMP, the result is incorrect.
-		v, ok := col.Value.(string)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for datetime column %s",
-				col.Value,
-				col.Name)
-		}
-
+		v := row.GetTime(idx).String()
 		t, err := time.Parse("2006-01-02 15:04:05.999999", v)
 		if err != nil {
 			// For example, time may be 1000-00-00
 			if mysql.HasNotNullFlag(ft.GetFlag()) {
-				writer.WriteInt64Field(col.Name, 0)
+				writer.WriteInt64Field(colName, 0)
 				return nil
 			} else {
-				writer.WriteNullField(col.Name)
+				writer.WriteNullField(colName)
 				return nil
 			}
 		}
 
 		if ft.GetDecimal() <= 3 {
-			writer.WriteInt64Field(col.Name, t.UnixMilli())
+			writer.WriteInt64Field(colName, t.UnixMilli())
 			return nil
 		} else {
-			writer.WriteInt64Field(col.Name, t.UnixMicro())
+			writer.WriteInt64Field(colName, t.UnixMicro())
 			return nil
 		}
 
@@ -441,21 +421,14 @@ func (c *dbzCodec) writeDebeziumFieldValue(
 		// > based on the server (or session's) current time zone. The time zone will be queried from
 		// > the server by default. If this fails, it must be specified explicitly by the database
 		// > connectionTimeZone MySQL configuration option.
-		v, ok := col.Value.(string)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for timestamp column %s",
-				col.Value,
-				col.Name)
-		}
-
+		v := row.GetTime(idx).String()
 		t, err := time.ParseInLocation("2006-01-02 15:04:05.999999", v, c.config.TimeZone)
 		if err != nil {
 			// For example, time may be invalid like 1000-00-00
 			if mysql.HasNotNullFlag(ft.GetFlag()) {
 				t = time.Unix(0, 0)
 			} else {
-				writer.WriteNullField(col.Name)
+				writer.WriteNullField(colName)
 				return nil
 			}
 		}
@@ -468,53 +441,36 @@ func (c *dbzCodec) writeDebeziumFieldValue(
 		}
 		str += "Z"
 
-		writer.WriteStringField(col.Name, str)
+		writer.WriteStringField(colName, str)
 		return nil
 
 	case mysql.TypeDuration:
 		// Debezium behavior from doc:
 		// > Represents the time value in microseconds and does not include
 		// > time zone information. MySQL allows M to be in the range of 0-6.
-		v, ok := col.Value.(string)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for time column %s",
-				col.Value,
-				col.Name)
-		}
-
-		d, _, _, err := types.StrToDuration(types.DefaultStmtNoWarningContext, v, ft.GetDecimal())
-		if err != nil {
-			return cerror.WrapError(
-				cerror.ErrDebeziumEncodeFailed,
-				err)
-		}
+		d := row.GetDuration(idx, ft.GetDecimal())
+		writer.WriteInt64Field(colName, d.Microseconds())
+		return nil
 
-		writer.WriteInt64Field(col.Name, d.Microseconds())
+	case mysql.TypeJSON:
+		writer.WriteStringField(colName, row.GetJSON(idx).String())
 		return nil
 
 	case mysql.TypeLonglong:
-		if col.Flag.IsUnsigned() {
+		if flag.IsUnsigned() {
 			// Handle with BIGINT UNSIGNED.
 			// Debezium always produce INT64 instead of UINT64 for BIGINT.
-			v, ok := col.Value.(uint64)
-			if !ok {
-				return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-					"unexpected column value type %T for unsigned bigint column %s",
-					col.Value,
-					col.Name)
-			}
-
-			writer.WriteInt64Field(col.Name, int64(v))
+			writer.WriteInt64Field(colName, int64(row.GetUint64(idx)))
 			return nil
 		}
 	case mysql.TypeTiDBVectorFloat32:
-		v := col.Value.(types.VectorFloat32).String()
-		writer.WriteStringField(col.Name, v)
+		v := row.GetVectorFloat32(idx).String()
+		writer.WriteStringField(colName, v)
 		return nil
 	}
 
-	writer.WriteAnyField(col.Name, col.Value)
+	d := row.GetDatum(idx, ft)
+	writer.WriteAnyField(colName, d.GetValue())
 	return nil
 }
 
@@ -524,7 +480,7 @@ func (c *dbzCodec) writeBinaryField(writer *util.JSONWriter, fieldName string, v
 }
 
 func (c *dbzCodec) EncodeRowChangedEvent(
-	e *common.RowChangedEvent,
+	e *commonEvent.RowEvent,
 	dest io.Writer,
 ) error {
 	jWriter := util.BorrowJSONWriter(dest)
@@ -584,18 +540,18 @@ func (c *dbzCodec) EncodeRowChangedEvent(
 				// after: An optional field that specifies the state of the row after the event occurred.
 				// Optional field that specifies the state of the row after the event occurred.
 				// In a delete event value, the after field is null, signifying that the row no longer exists.
-				err = c.writeDebeziumFieldValues(jWriter, "after", e.GetColumns(), e.TableInfo)
+				err = c.writeDebeziumFieldValues(jWriter, "after", e.GetRows(), e.TableInfo, e.ColumnSelector)
 			} else if e.IsDelete() {
 				jWriter.WriteStringField("op", "d")
 				jWriter.WriteNullField("after")
-				err = c.writeDebeziumFieldValues(jWriter, "before", e.GetPreColumns(), e.TableInfo)
+				err = c.writeDebeziumFieldValues(jWriter, "before", e.GetPreRows(), e.TableInfo, e.ColumnSelector)
 			} else if e.IsUpdate() {
 				jWriter.WriteStringField("op", "u")
 				if c.config.DebeziumOutputOldValue {
-					err = c.writeDebeziumFieldValues(jWriter, "before", e.GetPreColumns(), e.TableInfo)
+					err = c.writeDebeziumFieldValues(jWriter, "before", e.GetPreRows(), e.TableInfo, e.ColumnSelector)
 				}
 				if err == nil {
-					err = c.writeDebeziumFieldValues(jWriter, "after", e.GetColumns(), e.TableInfo)
+					err = c.writeDebeziumFieldValues(jWriter, "after", e.GetRows(), e.TableInfo, e.ColumnSelector)
 				}
 			}
 		})
@@ -616,17 +572,10 @@ func (c *dbzCodec) EncodeRowChangedEvent(
 					{
 						fieldsBuf := &bytes.Buffer{}
 						fieldsWriter := util.BorrowJSONWriter(fieldsBuf)
-						var validCols []*common.Column
-						if e.IsInsert() {
-							validCols = e.GetColumns()
-						} else if e.IsDelete() {
-							validCols = e.GetPreColumns()
-						} else if e.IsUpdate() {
-							validCols = e.GetColumns()
-						}
-						colInfos := e.TableInfo.GetColInfosForRowChangedEvent()
-						for i, col := range validCols {
-							c.writeDebeziumFieldSchema(fieldsWriter, col, colInfos[i].Ft)
+						for _, col := range e.TableInfo.GetColumns() {
+							if selectedColumn(e.ColumnSelector, col) {
+								c.writeDebeziumFieldSchema(fieldsWriter, col)
+							}
 						}
 						util.ReturnJSONWriter(fieldsWriter)
 						fieldsJSON = fieldsBuf.String()
@@ -0,0 +1,270 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCodec() *dbzCodec {
+	codecConfig := common.NewConfig(config.ProtocolDebezium)
+	codecConfig.TimeZone = time.UTC
+	return &dbzCodec{
+		config:    codecConfig,
+		clusterID: "test_cluster",
+		nowFunc:   func() time.Time { return time.Unix(1701326309, 0) },
+	}
+}
+
+func TestEncodeInsert(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(tiny tinyint primary key, name varchar(32))`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, 'alice')`)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	e := &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       1,
+		Event:          row,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+	}
+
+	codec := newTestCodec()
+	codec.config.DebeziumDisableSchema = true
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, codec.EncodeRowChangedEvent(e, buf))
+	require.JSONEq(t, `
+	{
+		"payload": {
+			"before": null,
+			"after": {
+				"tiny": 1,
+				"name": "alice"
+			},
+			"op": "c",
+			"source": {
+				"cluster_id": "test_cluster",
+				"name": "test_cluster",
+				"commit_ts": 1,
+				"connector": "TiCDC",
+				"db": "test",
+				"table": "t",
+				"ts_ms": 0,
+				"file": "",
+				"gtid": null,
+				"pos": 0,
+				"query": null,
+				"row": 0,
+				"server_id": 0,
+				"snapshot": "false",
+				"thread": 0,
+				"version": "2.4.0.Final"
+			},
+			"ts_ms": 1701326309000,
+			"transaction": null
+		}
+	}
+	`, buf.String())
+
+	codec.config.DebeziumDisableSchema = false
+	buf.Reset()
+	require.NoError(t, codec.EncodeRowChangedEvent(e, buf))
+	var message struct {
+		Schema struct {
+			Name   string `json:"name"`
+			Fields []struct {
+				Field  string `json:"field"`
+				Fields []struct {
+					Field    string `json:"field"`
+					Type     string `json:"type"`
+					Optional bool   `json:"optional"`
+				} `json:"fields"`
+			} `json:"fields"`
+		} `json:"schema"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &message))
+	require.Equal(t, "test_cluster.test.t.Envelope", message.Schema.Name)
+	require.Equal(t, "before", message.Schema.Fields[0].Field)
+	require.Equal(t, "after", message.Schema.Fields[1].Field)
+	after := message.Schema.Fields[1].Fields
+	require.Len(t, after, 2)
+	require.Equal(t, "tiny", after[0].Field)
+	require.Equal(t, "int16", after[0].Type)
+	require.False(t, after[0].Optional)
+	require.Equal(t, "name", after[1].Field)
+	require.Equal(t, "string", after[1].Type)
+	require.True(t, after[1].Optional)
+}
+
+func TestEncodeUpdateAndDelete(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(id int primary key, name varchar(32))`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t",
+		`insert into test.t values (1, 'alice')`, `insert into test.t values (2, 'bob')`)
+	insertRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	row.PreRow = insertRow.Row
+	row.RowType = commonEvent.RowTypeUpdate
+
+	codec := newTestCodec()
+	codec.config.DebeziumDisableSchema = true
+	e := &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       1,
+		Event:          row,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+	}
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, codec.EncodeRowChangedEvent(e, buf))
+	var message struct {
+		Payload struct {
+			Op     string                 `json:"op"`
+			Before map[string]interface{} `json:"before"`
+			After  map[string]interface{} `json:"after"`
+		} `json:"payload"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &message))
+	require.Equal(t, "u", message.Payload.Op)
+	require.Equal(t, map[string]interface{}{"id": float64(1), "name": "alice"}, message.Payload.Before)
+	require.Equal(t, map[string]interface{}{"id": float64(2), "name": "bob"}, message.Payload.After)
+
+	// the before value is not written if the old value is disabled.
+	codec.config.DebeziumOutputOldValue = false
+	buf.Reset()
+	message.Payload.Before = nil
+	require.NoError(t, codec.EncodeRowChangedEvent(e, buf))
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &message))
+	require.Nil(t, message.Payload.Before)
+	require.NotContains(t, buf.String(), `"before"`)
+
+	// delete event
+	row.PreRow, row.Row = row.Row, insertRow.PreRow
+	row.RowType = commonEvent.RowTypeDelete
+	e.Event = row
+	buf.Reset()
+	message.Payload.After = nil
+	require.NoError(t, codec.EncodeRowChangedEvent(e, buf))
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &message))
+	require.Equal(t, "d", message.Payload.Op)
+	require.Equal(t, map[string]interface{}{"id": float64(2), "name": "bob"}, message.Payload.Before)
+	require.Nil(t, message.Payload.After)
+}
+
+func TestEncodeDataTypes(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(
+		id bigint unsigned primary key,
+		b1 bit(1), b10 bit(10),
+		e enum('a', 'b'), s set('a', 'b'),
+		d decimal(10, 2), dt date, dtm datetime(6), ts timestamp(3), tm time(2),
+		j json, bin varbinary(8), v bigint unsigned as (id - 1) virtual)`)
+	tableInfo := helper.GetTableInfo(job)
+	helper.Tk().MustExec("set time_zone = '+00:00'")
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t(id, b1, b10, e, s, d, dt, dtm, ts, tm, j, bin) values (
+		18446744073709551615, 1, b'1000000001', 'b', 'a,b', 12.34, '2023-11-30',
+		'2023-11-30 06:38:29.123456', '2023-11-30 06:38:29.123', '01:02:03.45', '{"k":1}', x'0102')`)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	codec := newTestCodec()
+	codec.config.DebeziumDisableSchema = true
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, codec.EncodeRowChangedEvent(&commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       1,
+		Event:          row,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+	}, buf))
+	var message struct {
+		Payload struct {
+			After map[string]interface{} `json:"after"`
+		} `json:"payload"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &message))
+	require.Equal(t, map[string]interface{}{
+		"id":  float64(-1),
+		"b1":  true,
+		"b10": "AQI=",
+		"e":   "b",
+		"s":   "a,b",
+		"d":   12.34,
+		"dt":  float64(19691),
+		"dtm": float64(1701326309123456),
+		"ts":  "2023-11-30T06:38:29.123Z",
+		"tm":  float64(3723450000),
+		"j":   `{"k": 1}`,
+		"bin": "AQI=",
+	}, message.Payload.After)
+}
+
+func TestBatchEncoder(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(id int primary key)`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1)`)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	encoder := NewBatchEncoder(common.NewConfig(config.ProtocolDebezium), "test_cluster")
+	var called bool
+	err := encoder.AppendRowChangedEvent(context.Background(), "test", &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       1,
+		Event:          row,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		Callback:       func() { called = true },
+	})
+	require.NoError(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Nil(t, messages[0].Key)
+	require.Equal(t, 1, messages[0].GetRowsCount())
+	messages[0].Callback()
+	require.True(t, called)
+	require.Nil(t, encoder.Build())
+
+	// ddl and checkpoint events are not sent.
+	msg, err := encoder.EncodeCheckpointEvent(1)
+	require.NoError(t, err)
+	require.Nil(t, msg)
+	msg, err = encoder.EncodeDDLEvent(&commonEvent.DDLEvent{Query: "create table t1(id int)"})
+	require.NoError(t, err)
+	require.Nil(t, msg)
+}
@@ -41,8 +41,7 @@ func (d *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error)
 func (d *BatchEncoder) AppendRowChangedEvent(
 	_ context.Context,
 	_ string,
-	e *commonEvent.RowChangedEvent,
-	callback func(),
+	e *commonEvent.RowEvent,
 ) error {
 	valueBuf := bytes.Buffer{}
 	err := d.codec.EncodeRowChangedEvent(e, &valueBuf)
@@ -61,7 +60,7 @@ func (d *BatchEncoder) AppendRowChangedEvent(
 	m := &common.Message{
 		Key:      nil,
 		Value:    value,
-		Callback: callback,
+		Callback: e.Callback,
 	}
 	m.IncRowsCount()
 
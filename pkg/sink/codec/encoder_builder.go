@@ -18,24 +18,40 @@ import (
 
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/avro"
 	"github.com/pingcap/ticdc/pkg/sink/codec/canal"
 	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/sink/codec/csv"
+	"github.com/pingcap/ticdc/pkg/sink/codec/debezium"
 	"github.com/pingcap/ticdc/pkg/sink/codec/open"
+	"github.com/pingcap/ticdc/pkg/sink/codec/simple"
 )
 
 func NewEventEncoder(ctx context.Context, cfg *common.Config) (common.EventEncoder, error) {
 	switch cfg.Protocol {
 	case config.ProtocolDefault, config.ProtocolOpen:
 		return open.NewBatchEncoder(ctx, cfg)
-	// case config.ProtocolAvro:
-	// 	return avro.NewAvroEncoder(ctx, cfg)
+	case config.ProtocolAvro:
+		return avro.NewAvroEncoder(ctx, cfg)
 	case config.ProtocolCanalJSON:
 		return canal.NewJSONRowEventEncoder(ctx, cfg)
-	// case config.ProtocolDebezium:
-	// 	return debezium.NewBatchEncoder(cfg, config.GetGlobalServerConfig().ClusterID), nil
-	// case config.ProtocolSimple:
-	// 	return simple.NewEncoder(ctx, cfg)
+	case config.ProtocolDebezium:
+		return debezium.NewBatchEncoder(cfg, config.GetGlobalServerConfig().ClusterID), nil
+	case config.ProtocolSimple:
+		return simple.NewEncoder(ctx, cfg)
 	default:
 		return nil, errors.ErrSinkUnknownProtocol.GenWithStackByArgs(cfg.Protocol)
 	}
 }
+
+// NewTxnEventEncoder returns an TxnEventEncoder.
+func NewTxnEventEncoder(c *common.Config) (common.TxnEventEncoder, error) {
+	switch c.Protocol {
+	case config.ProtocolCsv:
+		return csv.NewTxnEventEncoder(c), nil
+	case config.ProtocolCanalJSON:
+		return canal.NewJSONTxnEventEncoder(c), nil
+	default:
+		return nil, errors.ErrSinkUnknownProtocol.GenWithStackByArgs(c.Protocol)
+	}
+}
@@ -43,8 +43,14 @@ type EncoderGroup interface {
 	// AddEvents add events into the group and encode them by one of the encoders in the group.
 	// Note: The caller should make sure all events should belong to the same topic and partition.
 	AddEvents(ctx context.Context, key model.TopicPartitionKey, events ...*commonEvent.RowEvent) error
+	// EndTxn marks that all the events of the current transaction have been added,
+	// the consumer of the output channel receives a future with TxnEnd set after all of them.
+	EndTxn(ctx context.Context) error
 	// Output returns a channel produce futures
 	Output() <-chan *future
+	// Close cleans up the encoders of the group.
+	// Run cleans them up when it exits, so it is only needed if the group is never run.
+	Close()
 }
 
 type encoderGroup struct {
@@ -172,12 +178,14 @@ func (g *encoderGroup) AddEvents(
 	events ...*commonEvent.RowEvent,
 ) error {
 	// bootstrapWorker only not nil when the protocol is simple
-	// if g.bootstrapWorker != nil {
-	// 	err := g.bootstrapWorker.addEvent(ctx, key, events[0].Event)
-	// 	if err != nil {
-	// 		return errors.Trace(err)
-	// 	}
-	// }
+	if g.bootstrapWorker != nil {
+		for _, event := range events {
+			err := g.bootstrapWorker.addEvent(ctx, key, event)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
 
 	future := newFuture(key, events...)
 	index := atomic.AddUint64(&g.index, 1) % uint64(g.concurrency)
@@ -196,10 +204,28 @@ func (g *encoderGroup) AddEvents(
 	return nil
 }
 
+func (g *encoderGroup) EndTxn(ctx context.Context) error {
+	future := &future{
+		TxnEnd: true,
+		done:   make(chan struct{}),
+	}
+	close(future.done)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case g.outputCh <- future:
+	}
+	return nil
+}
+
 func (g *encoderGroup) Output() <-chan *future {
 	return g.outputCh
 }
 
+func (g *encoderGroup) Close() {
+	g.cleanMetrics()
+}
+
 func (g *encoderGroup) cleanMetrics() {
 	encoderGroupInputChanSizeGauge.DeleteLabelValues(g.changefeedID.Namespace(), g.changefeedID.Name())
 	for _, encoder := range g.rowEventEncoders {
@@ -214,7 +240,9 @@ type future struct {
 	Key      model.TopicPartitionKey
 	events   []*commonEvent.RowEvent
 	Messages []*common.Message
-	done     chan struct{}
+	// TxnEnd indicates the future carries no messages but marks the end of a transaction.
+	TxnEnd bool
+	done   chan struct{}
 }
 
 func newFuture(key model.TopicPartitionKey,
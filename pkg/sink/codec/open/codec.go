@@ -372,7 +372,9 @@ func writeColumnFieldValueIfUpdated(
 		return
 	}
 	if !preRow.IsNull(idx) && row.IsNull(idx) {
-		writeColumnFieldValue(writer, col, preRow, idx, tableInfo)
+		writer.WriteObjectField(col.Name.O, func() {
+			writeColumnFieldValue(writer, col, preRow, idx, tableInfo)
+		})
 		return
 	}
 
@@ -383,7 +385,7 @@ func writeColumnFieldValueIfUpdated(
 		// Encode bits as integers to avoid pingcap/tidb#10988 (which also affects MySQL itself)
 		rowValue, _ := rowDatumPoint.GetBinaryLiteral().ToInt(types.DefaultStmtNoWarningContext)
 
-		preRowDatum := row.GetDatum(idx, &col.FieldType)
+		preRowDatum := preRow.GetDatum(idx, &col.FieldType)
 		preRowDatumPoint := &preRowDatum
 		// Encode bits as integers to avoid pingcap/tidb#10988 (which also affects MySQL itself)
 		preRowValue, _ := preRowDatumPoint.GetBinaryLiteral().ToInt(types.DefaultStmtNoWarningContext)
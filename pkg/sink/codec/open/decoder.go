@@ -0,0 +1,443 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package open
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// messageKey is the key of the open protocol message.
+type messageKey struct {
+	Ts                 uint64             `json:"ts"`
+	Schema             string             `json:"scm,omitempty"`
+	Table              string             `json:"tbl,omitempty"`
+	Type               common.MessageType `json:"t"`
+	OnlyHandleKey      bool               `json:"ohk,omitempty"`
+	ClaimCheckLocation string             `json:"ccl,omitempty"`
+}
+
+// messageColumn is the column value of the row changed event, encoded by `writeColumnFieldValue`.
+type messageColumn struct {
+	Type        byte                      `json:"t"`
+	WhereHandle bool                      `json:"h,omitempty"`
+	Flag        commonType.ColumnFlagType `json:"f"`
+	Value       interface{}               `json:"v"`
+}
+
+// messageRow is the value of the row changed event.
+type messageRow struct {
+	Update     map[string]messageColumn `json:"u,omitempty"`
+	PreColumns map[string]messageColumn `json:"p,omitempty"`
+	Delete     map[string]messageColumn `json:"d,omitempty"`
+}
+
+// messageDDL is the value of the DDL event.
+type messageDDL struct {
+	Query string `json:"q"`
+	Type  byte   `json:"t"`
+}
+
+// BatchDecoder decodes the messages encoded by the BatchEncoder,
+// each message may contain multiple events.
+type BatchDecoder struct {
+	keyBytes   []byte
+	valueBytes []byte
+
+	nextKey   *messageKey
+	nextValue []byte
+
+	config *common.Config
+	// tableInfoCache caches the table info built from the messages until a DDL is received.
+	tableInfoCache map[string]*commonType.TableInfo
+}
+
+// NewBatchDecoder creates a new BatchDecoder.
+// The table schema is not carried by the open protocol, so the table info of the
+// row changed event is built from the type and flag of each column in the message.
+func NewBatchDecoder(config *common.Config) common.RowEventDecoder {
+	return &BatchDecoder{
+		config:         config,
+		tableInfoCache: make(map[string]*commonType.TableInfo),
+	}
+}
+
+// AddKeyValue implements the RowEventDecoder interface
+func (b *BatchDecoder) AddKeyValue(key, value []byte) error {
+	if len(b.keyBytes) != 0 || len(b.valueBytes) != 0 {
+		return errors.ErrOpenProtocolCodecInvalidData.
+			GenWithStack("decoder key and value not nil")
+	}
+	if len(key) < 8 {
+		return errors.ErrOpenProtocolCodecInvalidData.
+			GenWithStack("the key is too short, length: %d", len(key))
+	}
+	version := binary.BigEndian.Uint64(key[:8])
+	if version != batchVersion1 {
+		return errors.ErrOpenProtocolCodecInvalidData.
+			GenWithStack("unexpected key format version %d", version)
+	}
+	b.keyBytes = key[8:]
+	b.valueBytes = value
+	return nil
+}
+
+// HasNext implements the RowEventDecoder interface
+func (b *BatchDecoder) HasNext() (model.MessageType, bool, error) {
+	if len(b.keyBytes) == 0 {
+		return model.MessageTypeUnknown, false, nil
+	}
+	key, err := readLengthPrefixed(&b.keyBytes)
+	if err != nil {
+		return model.MessageTypeUnknown, false, err
+	}
+	value, err := readLengthPrefixed(&b.valueBytes)
+	if err != nil {
+		return model.MessageTypeUnknown, false, err
+	}
+
+	msgKey := new(messageKey)
+	if err = json.Unmarshal(key, msgKey); err != nil {
+		return model.MessageTypeUnknown, false, errors.WrapError(errors.ErrOpenProtocolCodecInvalidData, err)
+	}
+	b.nextKey = msgKey
+	b.nextValue = value
+	return model.MessageType(msgKey.Type), true, nil
+}
+
+// readLengthPrefixed reads the next data prefixed by its length, and moves the buf forward.
+func readLengthPrefixed(buf *[]byte) ([]byte, error) {
+	if len(*buf) < 8 {
+		return nil, errors.ErrOpenProtocolCodecInvalidData.
+			GenWithStack("the length of the data is not found")
+	}
+	length := binary.BigEndian.Uint64((*buf)[:8])
+	if uint64(len(*buf)-8) < length {
+		return nil, errors.ErrOpenProtocolCodecInvalidData.
+			GenWithStack("the data is truncated, expected length: %d, actual: %d", length, len(*buf)-8)
+	}
+	data := (*buf)[8 : 8+length]
+	*buf = (*buf)[8+length:]
+	return data, nil
+}
+
+// NextResolvedEvent implements the RowEventDecoder interface
+func (b *BatchDecoder) NextResolvedEvent() (uint64, error) {
+	if b.nextKey == nil || b.nextKey.Type != common.MessageTypeResolved {
+		return 0, errors.ErrOpenProtocolCodecInvalidData.
+			GenWithStack("not found resolved event message")
+	}
+	ts := b.nextKey.Ts
+	b.nextKey = nil
+	return ts, nil
+}
+
+// NextDDLEvent implements the RowEventDecoder interface
+func (b *BatchDecoder) NextDDLEvent() (*commonEvent.DDLEvent, error) {
+	if b.nextKey == nil || b.nextKey.Type != common.MessageTypeDDL {
+		return nil, errors.ErrOpenProtocolCodecInvalidData.
+			GenWithStack("not found ddl event message")
+	}
+	value, err := common.Decompress(b.config.LargeMessageHandle.LargeMessageHandleCompression, b.nextValue)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrOpenProtocolCodecInvalidData, err)
+	}
+	msg := new(messageDDL)
+	if err = json.Unmarshal(value, msg); err != nil {
+		return nil, errors.WrapError(errors.ErrOpenProtocolCodecInvalidData, err)
+	}
+	result := &commonEvent.DDLEvent{
+		Type:       msg.Type,
+		SchemaName: b.nextKey.Schema,
+		TableName:  b.nextKey.Table,
+		Query:      msg.Query,
+		FinishedTs: b.nextKey.Ts,
+	}
+	b.nextKey = nil
+	// the table schema may be changed by the DDL, so the cached table info is dropped.
+	clear(b.tableInfoCache)
+	return result, nil
+}
+
+// NextDMLEvent implements the RowEventDecoder interface
+func (b *BatchDecoder) NextDMLEvent() (*commonEvent.DMLEvent, error) {
+	if b.nextKey == nil || b.nextKey.Type != common.MessageTypeRow {
+		return nil, errors.ErrOpenProtocolCodecInvalidData.
+			GenWithStack("not found row changed event message")
+	}
+	if b.nextKey.OnlyHandleKey || b.nextKey.ClaimCheckLocation != "" {
+		return nil, errors.ErrOpenProtocolCodecInvalidData.
+			GenWithStack("the handle key only or claim check message is not supported yet")
+	}
+	value, err := common.Decompress(b.config.LargeMessageHandle.LargeMessageHandleCompression, b.nextValue)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrOpenProtocolCodecInvalidData, err)
+	}
+	msg := new(messageRow)
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	// keep the precision of the numeric values.
+	decoder.UseNumber()
+	if err = decoder.Decode(msg); err != nil {
+		return nil, errors.WrapError(errors.ErrOpenProtocolCodecInvalidData, err)
+	}
+
+	result, err := b.messageRow2DMLEvent(b.nextKey, msg)
+	if err != nil {
+		return nil, err
+	}
+	b.nextKey = nil
+	return result, nil
+}
+
+// messageRow2DMLEvent converts the message to a dml event which contains only one row change.
+func (b *BatchDecoder) messageRow2DMLEvent(key *messageKey, msg *messageRow) (*commonEvent.DMLEvent, error) {
+	var (
+		rowType commonEvent.RowType
+		columns map[string]messageColumn
+	)
+	switch {
+	case len(msg.Delete) != 0:
+		rowType = commonEvent.RowTypeDelete
+		columns = msg.Delete
+	case len(msg.PreColumns) != 0:
+		rowType = commonEvent.RowTypeUpdate
+		columns = msg.Update
+	default:
+		rowType = commonEvent.RowTypeInsert
+		columns = msg.Update
+	}
+	tableInfo := b.getTableInfo(key, columns)
+
+	// The physical table id and start ts are lost in the open protocol message.
+	result := commonEvent.NewDMLEvent(commonType.DispatcherID{}, 0, 0, key.Ts, tableInfo)
+	switch rowType {
+	case commonEvent.RowTypeDelete:
+		preRow, err := columns2Datums(msg.Delete, nil, tableInfo)
+		if err != nil {
+			return nil, err
+		}
+		result.AppendRowDatums(rowType, preRow, nil)
+	case commonEvent.RowTypeInsert:
+		row, err := columns2Datums(msg.Update, nil, tableInfo)
+		if err != nil {
+			return nil, err
+		}
+		result.AppendRowDatums(rowType, nil, row)
+	default:
+		row, err := columns2Datums(msg.Update, nil, tableInfo)
+		if err != nil {
+			return nil, err
+		}
+		// the pre columns may only contain the updated columns,
+		// the unchanged columns are filled by the update columns.
+		preRow, err := columns2Datums(msg.PreColumns, msg.Update, tableInfo)
+		if err != nil {
+			return nil, err
+		}
+		result.AppendRowDatums(rowType, preRow, row)
+	}
+	return result, nil
+}
+
+// getTableInfo returns the table info built from the columns, it's cached until a DDL is received.
+func (b *BatchDecoder) getTableInfo(key *messageKey, columns map[string]messageColumn) *commonType.TableInfo {
+	cacheKey := commonType.QuoteSchema(key.Schema, key.Table)
+	tableInfo, ok := b.tableInfoCache[cacheKey]
+	if !ok {
+		tableInfo = newTableInfo(key.Schema, key.Table, columns)
+		b.tableInfoCache[cacheKey] = tableInfo
+	}
+	return tableInfo
+}
+
+// newTableInfo builds the table info from the columns of the message,
+// the columns are sorted by the name, since the original order is lost in the message.
+func newTableInfo(schema, table string, columns map[string]messageColumn) *commonType.TableInfo {
+	names := make([]string, 0, len(columns))
+	for name, col := range columns {
+		// the generated columns can not be written to the downstream.
+		if col.Flag.IsGeneratedColumn() {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	columnInfos := make([]*timodel.ColumnInfo, 0, len(names))
+	for _, name := range names {
+		col := columns[name]
+		columnInfos = append(columnInfos, newColumnInfo(name, col.Type, col.Flag))
+	}
+	return common.NewTableInfo4Decoder(schema, table, columnInfos)
+}
+
+// newColumnInfo builds the column info from the type and flag of the column,
+// the handle key columns are regarded as the primary key to locate the row.
+func newColumnInfo(name string, tp byte, flag commonType.ColumnFlagType) *timodel.ColumnInfo {
+	col := &timodel.ColumnInfo{
+		Name:      pmodel.NewCIStr(name),
+		FieldType: *types.NewFieldType(tp),
+	}
+	if flag.IsUnsigned() {
+		col.AddFlag(mysql.UnsignedFlag)
+	}
+	if flag.IsHandleKey() {
+		col.AddFlag(mysql.PriKeyFlag | mysql.NotNullFlag)
+	}
+	switch tp {
+	case mysql.TypeBit:
+		// the bit value is encoded as uint64.
+		col.SetFlen(64)
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
+		mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		if flag.IsBinary() {
+			col.SetCharset(charset.CharsetBin)
+			col.SetCollate(charset.CollationBin)
+			col.AddFlag(mysql.BinaryFlag)
+		} else {
+			col.SetCharset(mysql.DefaultCharset)
+			col.SetCollate(mysql.DefaultCollationName)
+		}
+	default:
+	}
+	return col
+}
+
+// columns2Datums converts the column values to the datums ordered the same as
+// the columns of the table info. If a column is not found in the columns, it's looked up in
+// the fallback, and the null value is used if it's still not found.
+func columns2Datums(
+	columns map[string]messageColumn, fallback map[string]messageColumn, tableInfo *commonType.TableInfo,
+) ([]types.Datum, error) {
+	datums := make([]types.Datum, 0, len(tableInfo.GetColumns()))
+	for _, colInfo := range tableInfo.GetColumns() {
+		col, ok := columns[colInfo.Name.O]
+		if !ok {
+			col = fallback[colInfo.Name.O]
+		}
+		d, err := formatColumn(col.Value, colInfo)
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrOpenProtocolCodecInvalidData, err)
+		}
+		datums = append(datums, d)
+	}
+	return datums, nil
+}
+
+// formatColumn converts the column value encoded by `writeColumnFieldValue` to the datum.
+func formatColumn(value interface{}, colInfo *timodel.ColumnInfo) (types.Datum, error) {
+	var d types.Datum
+	if value == nil {
+		return d, nil
+	}
+
+	var err error
+	ft := &colInfo.FieldType
+	switch ft.GetType() {
+	case mysql.TypeBit:
+		var bitValue uint64
+		bitValue, err = strconv.ParseUint(stringValue(value), 10, 64)
+		byteSize := (ft.GetFlen() + 7) >> 3
+		d.SetMysqlBit(types.NewBinaryLiteralFromUint(bitValue, byteSize))
+	case mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		var bytesValue []byte
+		bytesValue, err = base64.StdEncoding.DecodeString(stringValue(value))
+		if mysql.HasBinaryFlag(ft.GetFlag()) {
+			d.SetBytes(bytesValue)
+		} else {
+			d.SetString(string(bytesValue), ft.GetCollate())
+		}
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString:
+		if !mysql.HasBinaryFlag(ft.GetFlag()) {
+			d.SetString(stringValue(value), ft.GetCollate())
+			break
+		}
+		// the binary value is quoted when encoding, now reverse it back.
+		var str string
+		str, err = strconv.Unquote("\"" + stringValue(value) + "\"")
+		d.SetBytes([]byte(str))
+	case mysql.TypeEnum:
+		var enumValue uint64
+		enumValue, err = strconv.ParseUint(stringValue(value), 10, 64)
+		d.SetMysqlEnum(types.Enum{Value: enumValue}, ft.GetCollate())
+	case mysql.TypeSet:
+		var setValue uint64
+		setValue, err = strconv.ParseUint(stringValue(value), 10, 64)
+		d.SetMysqlSet(types.Set{Value: setValue}, ft.GetCollate())
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeNewDate, mysql.TypeTimestamp:
+		// the precision is unknown, so use the one of the value.
+		str := stringValue(value)
+		var t types.Time
+		t, err = types.ParseTime(types.DefaultStmtNoWarningContext, str, ft.GetType(), types.GetFsp(str))
+		d.SetMysqlTime(t)
+	case mysql.TypeDuration:
+		str := stringValue(value)
+		var duration types.Duration
+		duration, _, err = types.ParseDuration(types.DefaultStmtNoWarningContext, str, types.GetFsp(str))
+		d.SetMysqlDuration(duration)
+	case mysql.TypeNewDecimal:
+		dec := new(types.MyDecimal)
+		err = dec.FromString([]byte(stringValue(value)))
+		d.SetMysqlDecimal(dec)
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeYear:
+		if mysql.HasUnsignedFlag(ft.GetFlag()) {
+			var uintValue uint64
+			uintValue, err = strconv.ParseUint(stringValue(value), 10, 64)
+			d.SetUint64(uintValue)
+			break
+		}
+		var intValue int64
+		intValue, err = strconv.ParseInt(stringValue(value), 10, 64)
+		d.SetInt64(intValue)
+	case mysql.TypeFloat:
+		var floatValue float64
+		floatValue, err = strconv.ParseFloat(stringValue(value), 32)
+		d.SetFloat32(float32(floatValue))
+	case mysql.TypeDouble:
+		var floatValue float64
+		floatValue, err = strconv.ParseFloat(stringValue(value), 64)
+		d.SetFloat64(floatValue)
+	default:
+		strDatum := types.NewStringDatum(stringValue(value))
+		d, err = strDatum.ConvertTo(types.DefaultStmtNoWarningContext, ft)
+	}
+	return d, err
+}
+
+// stringValue returns the string representation of the value decoded with `UseNumber`.
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case json.Number:
+		return v.String()
+	case string:
+		return v
+	default:
+		return ""
+	}
+}
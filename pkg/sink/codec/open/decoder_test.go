@@ -0,0 +1,158 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package open
+
+import (
+	"context"
+	"testing"
+
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	pevent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeDMLEvent(t *testing.T) {
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(
+		id bigint unsigned, name varchar(32), bin varbinary(16), b bit(10), e enum('a','b','c'),
+		s set('x','y'), d decimal(10, 2), dt datetime(3), tm time, j json, f float, dd double,
+		txt text, blb blob, y year, n int, primary key(id, name))`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t",
+		`insert into test.t(id, name, bin, b, e, s, d, dt, tm, j, f, dd, txt, blb, y, n) values
+			(1, 'a,"b"', x'89504E470D0A1A0A', b'1000000001', 'b', 'x,y', 12.34,
+			'2023-11-30 06:38:29.123', '01:02:03', '{"k": 1}', 1.5, 2.25, '测试', x'0102', 2024, -1)`,
+		`insert into test.t(id, name, e, s, n) values (18446744073709551615, 'c', 'c', 'y', 3)`)
+	insertRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	newRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	events := []*pevent.RowEvent{
+		{Event: insertRow},
+		{Event: pevent.RowChange{PreRow: insertRow.Row, Row: newRow.Row, RowType: pevent.RowTypeUpdate}},
+		{Event: pevent.RowChange{PreRow: newRow.Row, RowType: pevent.RowTypeDelete}},
+	}
+
+	ctx := context.Background()
+	for _, onlyOutputUpdatedColumns := range []bool{false, true} {
+		codecConfig := common.NewConfig(config.ProtocolOpen)
+		codecConfig.OnlyOutputUpdatedColumns = onlyOutputUpdatedColumns
+		encoder, err := NewBatchEncoder(ctx, codecConfig)
+		require.NoError(t, err)
+		for i, e := range events {
+			e.TableInfo = tableInfo
+			e.CommitTs = uint64(100 + i)
+			e.ColumnSelector = columnselector.NewDefaultColumnSelector()
+			e.Callback = func() {}
+			require.NoError(t, encoder.AppendRowChangedEvent(ctx, "", e))
+		}
+		// all events are batched into one message.
+		messages := encoder.Build()
+		require.Len(t, messages, 1)
+
+		decoder := NewBatchDecoder(codecConfig)
+		require.NoError(t, decoder.AddKeyValue(messages[0].Key, messages[0].Value))
+		for _, e := range events {
+			tp, hasNext, err := decoder.HasNext()
+			require.NoError(t, err)
+			require.True(t, hasNext)
+			require.Equal(t, model.MessageTypeRow, tp)
+			decoded, err := decoder.NextDMLEvent()
+			require.NoError(t, err)
+			require.Equal(t, e.CommitTs, decoded.CommitTs)
+			require.Equal(t, "test", decoded.TableInfo.GetSchemaName())
+			require.Equal(t, "t", decoded.TableInfo.GetTableName())
+			require.Equal(t, []string{"id", "name"}, decoded.TableInfo.GetPrimaryKeyColumnNames())
+
+			row, ok := decoded.GetNextRow()
+			require.True(t, ok)
+			require.Equal(t, e.Event.RowType, row.RowType)
+			requireSameColumnValues(t, tableInfo, decoded.TableInfo, &e.Event.PreRow, &row.PreRow)
+			requireSameColumnValues(t, tableInfo, decoded.TableInfo, &e.Event.Row, &row.Row)
+		}
+		_, hasNext, err := decoder.HasNext()
+		require.NoError(t, err)
+		require.False(t, hasNext)
+	}
+}
+
+func requireSameColumnValues(
+	t *testing.T, expectedTableInfo, actualTableInfo *commonType.TableInfo, expected, actual *chunk.Row,
+) {
+	require.Equal(t, expected.IsEmpty(), actual.IsEmpty())
+	if expected.IsEmpty() {
+		return
+	}
+	actualColumns := actualTableInfo.GetColumns()
+	for i, col := range expectedTableInfo.GetColumns() {
+		expectedValue, err := commonType.FormatColVal(expected, col, i)
+		require.NoError(t, err)
+		offset := actualTableInfo.GetColumnsOffset()[actualTableInfo.ForceGetColumnIDByName(col.Name.O)]
+		actualValue, err := commonType.FormatColVal(actual, actualColumns[offset], offset)
+		require.NoError(t, err)
+		require.Equal(t, expectedValue, actualValue, col.Name.O)
+	}
+}
+
+func TestDecodeDDLAndResolvedEvent(t *testing.T) {
+	ctx := context.Background()
+	codecConfig := common.NewConfig(config.ProtocolOpen)
+	encoder, err := NewBatchEncoder(ctx, codecConfig)
+	require.NoError(t, err)
+	decoder := NewBatchDecoder(codecConfig)
+
+	m, err := encoder.EncodeDDLEvent(&pevent.DDLEvent{
+		Type:       byte(timodel.ActionCreateTable),
+		SchemaName: "test",
+		TableName:  "t",
+		Query:      "create table test.t(id int primary key)",
+		FinishedTs: 200,
+	})
+	require.NoError(t, err)
+	require.NoError(t, decoder.AddKeyValue(m.Key, m.Value))
+	tp, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeDDL, tp)
+	ddl, err := decoder.NextDDLEvent()
+	require.NoError(t, err)
+	require.Equal(t, uint64(200), ddl.FinishedTs)
+	require.Equal(t, byte(timodel.ActionCreateTable), ddl.Type)
+	require.Equal(t, "test", ddl.SchemaName)
+	require.Equal(t, "t", ddl.TableName)
+	require.Equal(t, "create table test.t(id int primary key)", ddl.Query)
+
+	m, err = encoder.EncodeCheckpointEvent(300)
+	require.NoError(t, err)
+	require.NoError(t, decoder.AddKeyValue(m.Key, m.Value))
+	tp, hasNext, err = decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeResolved, tp)
+	ts, err := decoder.NextResolvedEvent()
+	require.NoError(t, err)
+	require.Equal(t, uint64(300), ts)
+
+	// the message encoded by other versions is rejected.
+	require.Error(t, decoder.AddKeyValue([]byte{0, 0, 0, 0, 0, 0, 0, 2}, nil))
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	ticommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
 	"github.com/pingcap/tidb/br/pkg/storage"
@@ -47,8 +48,8 @@ type Decoder struct {
 
 	// cachedMessages is used to store the messages which does not have received corresponding table info yet.
 	cachedMessages *list.List
-	// CachedRowChangedEvents are events just decoded from the cachedMessages
-	CachedRowChangedEvents []*common.RowChangedEvent
+	// CachedDMLEvents are events just decoded from the cachedMessages
+	CachedDMLEvents []*commonEvent.DMLEvent
 }
 
 // NewDecoder returns a new Decoder
@@ -132,7 +133,7 @@ func (d *Decoder) NextResolvedEvent() (uint64, error) {
 }
 
 // NextRowChangedEvent returns the next row changed event if exists
-func (d *Decoder) NextRowChangedEvent() (*common.RowChangedEvent, error) {
+func (d *Decoder) NextRowChangedEvent() (*commonEvent.RowChangedEvent, error) {
 	if d.msg == nil || (d.msg.Data == nil && d.msg.Old == nil) {
 		return nil, cerror.ErrCodecDecode.GenWithStack(
 			"invalid row changed event message")
@@ -165,7 +166,18 @@ func (d *Decoder) NextRowChangedEvent() (*common.RowChangedEvent, error) {
 	return event, err
 }
 
-func (d *Decoder) assembleClaimCheckRowChangedEvent(claimCheckLocation string) (*common.RowChangedEvent, error) {
+// NextDMLEvent returns the next dml event if exists,
+// nil is returned if the table info of the event is not received yet,
+// the event is cached and can be fetched by `GetCachedEvents` after the table info is received.
+func (d *Decoder) NextDMLEvent() (*commonEvent.DMLEvent, error) {
+	row, err := d.NextRowChangedEvent()
+	if err != nil || row == nil {
+		return nil, err
+	}
+	return rowChangedEvent2DMLEvent(row)
+}
+
+func (d *Decoder) assembleClaimCheckRowChangedEvent(claimCheckLocation string) (*commonEvent.RowChangedEvent, error) {
 	_, claimCheckFileName := filepath.Split(claimCheckLocation)
 	data, err := d.storage.ReadFile(context.Background(), claimCheckFileName)
 	if err != nil {
@@ -194,7 +206,7 @@ func (d *Decoder) assembleClaimCheckRowChangedEvent(claimCheckLocation string) (
 	return d.NextRowChangedEvent()
 }
 
-func (d *Decoder) assembleHandleKeyOnlyRowChangedEvent(m *message) (*common.RowChangedEvent, error) {
+func (d *Decoder) assembleHandleKeyOnlyRowChangedEvent(m *message) (*commonEvent.RowChangedEvent, error) {
 	tableInfo := d.memo.Read(m.Schema, m.Table, m.SchemaVersion)
 	if tableInfo == nil {
 		log.Debug("table info not found for the event, "+
@@ -207,8 +219,8 @@ func (d *Decoder) assembleHandleKeyOnlyRowChangedEvent(m *message) (*common.RowC
 		return nil, nil
 	}
 
-	fieldTypeMap := make(map[string]*types.FieldType, len(tableInfo.Columns))
-	for _, col := range tableInfo.Columns {
+	fieldTypeMap := make(map[string]*types.FieldType, len(tableInfo.GetColumns()))
+	for _, col := range tableInfo.GetColumns() {
 		fieldTypeMap[col.Name.O] = &col.FieldType
 	}
 
@@ -260,36 +272,36 @@ func (d *Decoder) buildData(
 }
 
 // NextDDLEvent returns the next DDL event if exists
-func (d *Decoder) NextDDLEvent() (*model.DDLEvent, error) {
+func (d *Decoder) NextDDLEvent() (*commonEvent.DDLEvent, error) {
 	if d.msg == nil {
 		return nil, cerror.ErrCodecDecode.GenWithStack(
 			"no message found when decode DDL event")
 	}
 	ddl := newDDLEvent(d.msg)
 	d.msg = nil
-	// TODO:
-	// d.memo.Write(ddl.TableInfo)
-	// d.memo.Write(ddl.PreTableInfo)
+	d.memo.Write(ddl.TableInfo)
 
-	for ele := d.cachedMessages.Front(); ele != nil; {
+	// the messages whose table info is still not found are pushed back to the cache,
+	// so only visit the messages cached before.
+	for count := d.cachedMessages.Len(); count > 0; count-- {
+		ele := d.cachedMessages.Front()
+		d.cachedMessages.Remove(ele)
 		d.msg = ele.Value.(*message)
-		event, err := d.NextRowChangedEvent()
+		event, err := d.NextDMLEvent()
 		if err != nil {
 			return nil, err
 		}
-		d.CachedRowChangedEvents = append(d.CachedRowChangedEvents, event)
-
-		next := ele.Next()
-		d.cachedMessages.Remove(ele)
-		ele = next
+		if event != nil {
+			d.CachedDMLEvents = append(d.CachedDMLEvents, event)
+		}
 	}
 	return ddl, nil
 }
 
 // GetCachedEvents returns the cached events
-func (d *Decoder) GetCachedEvents() []*common.RowChangedEvent {
-	result := d.CachedRowChangedEvents
-	d.CachedRowChangedEvents = nil
+func (d *Decoder) GetCachedEvents() []*commonEvent.DMLEvent {
+	result := d.CachedDMLEvents
+	d.CachedDMLEvents = nil
 	return result
 }
 
@@ -319,7 +331,7 @@ func (m *memoryTableInfoProvider) Write(info *common.TableInfo) {
 	key := tableSchemaKey{
 		schema:  info.TableName.Schema,
 		table:   info.TableName.Table,
-		version: info.UpdateTS,
+		version: info.UpdateTS(),
 	}
 
 	_, ok := m.memo[key]
@@ -327,7 +339,7 @@ func (m *memoryTableInfoProvider) Write(info *common.TableInfo) {
 		log.Debug("table info not stored, since it already exists",
 			zap.String("schema", info.TableName.Schema),
 			zap.String("table", info.TableName.Table),
-			zap.Uint64("version", info.UpdateTS))
+			zap.Uint64("version", info.UpdateTS()))
 		return
 	}
 
@@ -335,7 +347,7 @@ func (m *memoryTableInfoProvider) Write(info *common.TableInfo) {
 	log.Info("table info stored",
 		zap.String("schema", info.TableName.Schema),
 		zap.String("table", info.TableName.Table),
-		zap.Uint64("version", info.UpdateTS))
+		zap.Uint64("version", info.UpdateTS()))
 }
 
 // Read returns the table info with the exact (schema, table, version)
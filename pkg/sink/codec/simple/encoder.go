@@ -140,27 +140,27 @@ func (e *Encoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error) {
 
 // EncodeDDLEvent implement the DDLEventBatchEncoder interface
 func (e *Encoder) EncodeDDLEvent(event *commonEvent.DDLEvent) (*common.Message, error) {
-	// value, err := e.marshaller.MarshalDDLEvent(event)
-	// if err != nil {
-	// 	return nil, err
-	// }
-
-	// value, err = ticommon.Compress(e.config.ChangefeedID,
-	// 	e.config.LargeMessageHandle.LargeMessageHandleCompression, value)
-	// if err != nil {
-	// 	return nil, err
-	// }
-	// result := ticommon.NewDDLMsg(config.ProtocolSimple, nil, value, event)
-
-	// if result.Length() > e.config.MaxMessageBytes {
-	// 	log.Error("DDL message is too large for simple",
-	// 		zap.Int("maxMessageBytes", e.config.MaxMessageBytes),
-	// 		zap.Int("length", result.Length()),
-	// 		zap.Any("table", event.TableInfo.TableName))
-	// 	return nil, cerror.ErrMessageTooLarge.GenWithStackByArgs()
-	// }
-	// return result, nil
-	return nil, nil
+	value, err := e.marshaller.MarshalDDLEvent(event)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err = common.Compress(e.config.ChangefeedID,
+		e.config.LargeMessageHandle.LargeMessageHandleCompression, value)
+	if err != nil {
+		return nil, err
+	}
+	result := common.NewMsg(nil, value)
+
+	if result.Length() > e.config.MaxMessageBytes {
+		log.Error("DDL message is too large for simple",
+			zap.Int("maxMessageBytes", e.config.MaxMessageBytes),
+			zap.Int("length", result.Length()),
+			zap.String("schema", event.SchemaName),
+			zap.String("table", event.TableName))
+		return nil, errors.ErrMessageTooLarge.GenWithStackByArgs()
+	}
+	return result, nil
 }
 
 // CleanMetrics implement the RowEventEncoderBuilder interface
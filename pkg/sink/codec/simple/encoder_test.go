@@ -0,0 +1,259 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simple
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeAndDecode(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(
+		id int primary key, name varchar(32), price decimal(10, 2),
+		b bit(10), e enum('a', 'b'), ts timestamp, data blob)`)
+	tableInfo := helper.GetTableInfo(job)
+	helper.Tk().MustExec("set time_zone = '+00:00'")
+	dmlEvent := helper.DML2Event("test", "t",
+		`insert into test.t values (1, 'alice', 12.34, b'1000000001', 'b', '2023-11-30 06:38:29', x'0102')`,
+		`insert into test.t values (2, 'bob', null, null, null, null, null)`)
+	insertRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	updateRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	updateRow.PreRow = insertRow.Row
+	updateRow.RowType = commonEvent.RowTypeUpdate
+
+	ctx := context.Background()
+	for _, format := range []common.EncodingFormatType{
+		common.EncodingFormatJSON,
+		common.EncodingFormatAvro,
+	} {
+		codecConfig := common.NewConfig(config.ProtocolSimple)
+		codecConfig.EncodingFormat = format
+		codecConfig.TimeZone = time.UTC
+		encoder, err := NewEncoder(ctx, codecConfig)
+		require.NoError(t, err)
+		decoder, err := NewDecoder(ctx, codecConfig, nil)
+		require.NoError(t, err)
+
+		// the bootstrap message carries the table schema.
+		m, err := encoder.EncodeDDLEvent(&commonEvent.DDLEvent{TableInfo: tableInfo, IsBootstrap: true})
+		require.NoError(t, err)
+		require.NoError(t, decoder.AddKeyValue(m.Key, m.Value))
+		messageType, hasNext, err := decoder.HasNext()
+		require.NoError(t, err)
+		require.True(t, hasNext)
+		require.Equal(t, model.MessageTypeDDL, messageType)
+		require.Equal(t, MessageTypeBootstrap, decoder.msg.Type)
+		ddl, err := decoder.NextDDLEvent()
+		require.NoError(t, err)
+		require.Equal(t, "test", ddl.SchemaName)
+		require.Equal(t, "t", ddl.TableName)
+		require.Len(t, ddl.TableInfo.GetColumns(), len(tableInfo.GetColumns()))
+
+		var called bool
+		err = encoder.AppendRowChangedEvent(ctx, "", &commonEvent.RowEvent{
+			TableInfo:      tableInfo,
+			CommitTs:       100,
+			Event:          updateRow,
+			ColumnSelector: columnselector.NewDefaultColumnSelector(),
+			Callback:       func() { called = true },
+		})
+		require.NoError(t, err)
+		messages := encoder.Build()
+		require.Len(t, messages, 1)
+		messages[0].Callback()
+		require.True(t, called)
+
+		require.NoError(t, decoder.AddKeyValue(messages[0].Key, messages[0].Value))
+		messageType, hasNext, err = decoder.HasNext()
+		require.NoError(t, err)
+		require.True(t, hasNext)
+		require.Equal(t, model.MessageTypeRow, messageType)
+		require.Equal(t, DMLTypeUpdate, decoder.msg.Type)
+		row, err := decoder.NextRowChangedEvent()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Equal(t, uint64(100), row.CommitTs)
+
+		values := make(map[string]interface{}, len(row.Columns))
+		for _, col := range row.Columns {
+			values[col.Name] = col.Value
+		}
+		require.Len(t, values, 7)
+		require.EqualValues(t, 2, values["id"])
+		require.Equal(t, "bob", values["name"])
+		require.Nil(t, values["price"])
+		require.Nil(t, values["data"])
+
+		oldValues := make(map[string]interface{}, len(row.PreColumns))
+		for _, col := range row.PreColumns {
+			oldValues[col.Name] = col.Value
+		}
+		require.EqualValues(t, 1, oldValues["id"])
+		require.Equal(t, "alice", oldValues["name"])
+		require.Equal(t, "12.34", oldValues["price"])
+		require.EqualValues(t, 513, oldValues["b"])
+		require.EqualValues(t, 2, oldValues["e"])
+		require.Equal(t, "2023-11-30 06:38:29", oldValues["ts"])
+		require.Equal(t, []byte{0x01, 0x02}, oldValues["data"])
+	}
+}
+
+func TestEncodeDDLEvent(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(id int primary key, name varchar(32), key idx_name(name))`)
+	tableInfo := helper.GetTableInfo(job)
+
+	ctx := context.Background()
+	codecConfig := common.NewConfig(config.ProtocolSimple)
+	encoder, err := NewEncoder(ctx, codecConfig)
+	require.NoError(t, err)
+	m, err := encoder.EncodeDDLEvent(&commonEvent.DDLEvent{
+		Type:       byte(timodel.ActionCreateTable),
+		SchemaName: "test",
+		TableName:  "t",
+		Query:      job.Query,
+		TableInfo:  tableInfo,
+		FinishedTs: 200,
+	})
+	require.NoError(t, err)
+	require.Nil(t, m.Key)
+
+	var msg message
+	require.NoError(t, newJSONMarshaller(codecConfig).Unmarshal(m.Value, &msg))
+	require.Equal(t, DDLTypeCreate, msg.Type)
+	require.Equal(t, uint64(200), msg.CommitTs)
+	require.Equal(t, job.Query, msg.SQL)
+	require.Equal(t, "t", msg.TableSchema.Table)
+	require.Equal(t, tableInfo.TableName.TableID, msg.TableSchema.TableID)
+	require.Equal(t, tableInfo.UpdateTS(), msg.TableSchema.Version)
+	require.Len(t, msg.TableSchema.Indexes, 2)
+	require.Nil(t, msg.PreTableSchema)
+
+	// the message is too large
+	codecConfig.MaxMessageBytes = 10
+	_, err = encoder.EncodeDDLEvent(&commonEvent.DDLEvent{TableInfo: tableInfo, IsBootstrap: true})
+	require.Error(t, err)
+
+	checkpoint, err := encoder.EncodeCheckpointEvent(300)
+	require.NoError(t, err)
+	msg = message{}
+	require.NoError(t, newJSONMarshaller(codecConfig).Unmarshal(checkpoint.Value, &msg))
+	require.Equal(t, MessageTypeWatermark, msg.Type)
+	require.Equal(t, uint64(300), msg.CommitTs)
+}
+
+func TestDecodeDMLEvent(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(
+		id bigint unsigned primary key, name varchar(32), price decimal(10, 2), f float,
+		b bit(10), e enum('a', 'b'), s set('x', 'y'), ts timestamp, dt datetime(3), tm time(2), data blob, j json)`)
+	tableInfo := helper.GetTableInfo(job)
+	helper.Tk().MustExec("set time_zone = '+00:00'")
+	dmlEvent := helper.DML2Event("test", "t",
+		`insert into test.t values (18446744073709551615, 'alice', 12.34, 1.5, b'1000000001', 'b', 'x,y',
+			'2023-11-30 06:38:29', '2023-11-30 06:38:29.123', '01:02:03.45', x'0102', '{"k": 1}')`,
+		`insert into test.t(id) values (2)`)
+	insertRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	newRow, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	updateRow := commonEvent.RowChange{PreRow: insertRow.Row, Row: newRow.Row, RowType: commonEvent.RowTypeUpdate}
+
+	ctx := context.Background()
+	for _, format := range []common.EncodingFormatType{
+		common.EncodingFormatJSON,
+		common.EncodingFormatAvro,
+	} {
+		codecConfig := common.NewConfig(config.ProtocolSimple)
+		codecConfig.EncodingFormat = format
+		codecConfig.TimeZone = time.UTC
+		encoder, err := NewEncoder(ctx, codecConfig)
+		require.NoError(t, err)
+		decoder, err := NewDecoder(ctx, codecConfig, nil)
+		require.NoError(t, err)
+
+		err = encoder.AppendRowChangedEvent(ctx, "", &commonEvent.RowEvent{
+			TableInfo:      tableInfo,
+			CommitTs:       100,
+			Event:          updateRow,
+			ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		})
+		require.NoError(t, err)
+		messages := encoder.Build()
+		require.Len(t, messages, 1)
+
+		// the table info is not received yet, the event is cached.
+		require.NoError(t, decoder.AddKeyValue(messages[0].Key, messages[0].Value))
+		messageType, hasNext, err := decoder.HasNext()
+		require.NoError(t, err)
+		require.True(t, hasNext)
+		require.Equal(t, model.MessageTypeRow, messageType)
+		event, err := decoder.NextDMLEvent()
+		require.NoError(t, err)
+		require.Nil(t, event)
+
+		m, err := encoder.EncodeDDLEvent(&commonEvent.DDLEvent{TableInfo: tableInfo, IsBootstrap: true})
+		require.NoError(t, err)
+		require.NoError(t, decoder.AddKeyValue(m.Key, m.Value))
+		_, hasNext, err = decoder.HasNext()
+		require.NoError(t, err)
+		require.True(t, hasNext)
+		_, err = decoder.NextDDLEvent()
+		require.NoError(t, err)
+
+		cached := decoder.GetCachedEvents()
+		require.Len(t, cached, 1)
+		event = cached[0]
+		require.Equal(t, uint64(100), event.CommitTs)
+		require.Equal(t, tableInfo.TableName.TableID, event.PhysicalTableID)
+		row, ok := event.GetNextRow()
+		require.True(t, ok)
+		require.Equal(t, commonEvent.RowTypeUpdate, row.RowType)
+
+		// the values written to the downstream must be the same as the original ones.
+		for _, pair := range [][2]*chunk.Row{{&insertRow.Row, &row.PreRow}, {&newRow.Row, &row.Row}} {
+			for i, col := range tableInfo.GetColumns() {
+				expected, err := commonType.FormatColVal(pair[0], col, i)
+				require.NoError(t, err)
+				offset := event.TableInfo.GetColumnsOffset()[event.TableInfo.ForceGetColumnIDByName(col.Name.O)]
+				actual, err := commonType.FormatColVal(pair[1], event.TableInfo.GetColumns()[offset], offset)
+				require.NoError(t, err)
+				require.Equal(t, expected, actual, col.Name.O)
+			}
+		}
+	}
+}
@@ -18,10 +18,9 @@ import (
 	"encoding/json"
 
 	"github.com/linkedin/goavro/v2"
-	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/errors"
 	ticommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
-	"github.com/pingcap/tiflow/cdc/model"
 )
 
 //go:embed message.json
@@ -32,10 +31,10 @@ type marshaller interface {
 	MarshalCheckpoint(ts uint64) ([]byte, error)
 
 	// MarshalDDLEvent marshals the DDL event into bytes.
-	MarshalDDLEvent(event *model.DDLEvent) ([]byte, error)
+	MarshalDDLEvent(event *commonEvent.DDLEvent) ([]byte, error)
 
 	// MarshalRowChangedEvent marshals the row changed event into bytes.
-	MarshalRowChangedEvent(event *common.RowChangedEvent,
+	MarshalRowChangedEvent(event *commonEvent.RowEvent,
 		handleKeyOnly bool, claimCheckFileName string) ([]byte, error)
 
 	// Unmarshal the bytes into the given value.
@@ -74,7 +73,7 @@ func (m *JSONMarshaller) MarshalCheckpoint(ts uint64) ([]byte, error) {
 }
 
 // MarshalDDLEvent implement the marshaller interface
-func (m *JSONMarshaller) MarshalDDLEvent(event *model.DDLEvent) ([]byte, error) {
+func (m *JSONMarshaller) MarshalDDLEvent(event *commonEvent.DDLEvent) ([]byte, error) {
 	var msg *message
 	if event.IsBootstrap {
 		msg = newBootstrapMessage(event.TableInfo)
@@ -87,10 +86,13 @@ func (m *JSONMarshaller) MarshalDDLEvent(event *model.DDLEvent) ([]byte, error)
 
 // MarshalRowChangedEvent implement the marshaller interface
 func (m *JSONMarshaller) MarshalRowChangedEvent(
-	event *common.RowChangedEvent,
+	event *commonEvent.RowEvent,
 	handleKeyOnly bool, claimCheckFileName string,
 ) ([]byte, error) {
-	msg := m.newDMLMessage(event, handleKeyOnly, claimCheckFileName)
+	msg, err := m.newDMLMessage(event, handleKeyOnly, claimCheckFileName)
+	if err != nil {
+		return nil, err
+	}
 	value, err := json.Marshal(msg)
 	return value, errors.WrapError(errors.ErrEncodeFailed, err)
 }
@@ -121,7 +123,7 @@ func (m *avroMarshaller) MarshalCheckpoint(ts uint64) ([]byte, error) {
 }
 
 // MarshalDDLEvent implement the marshaller interface
-func (m *avroMarshaller) MarshalDDLEvent(event *model.DDLEvent) ([]byte, error) {
+func (m *avroMarshaller) MarshalDDLEvent(event *commonEvent.DDLEvent) ([]byte, error) {
 	var msg map[string]interface{}
 	if event.IsBootstrap {
 		msg = newBootstrapMessageMap(event.TableInfo)
@@ -134,10 +136,13 @@ func (m *avroMarshaller) MarshalDDLEvent(event *model.DDLEvent) ([]byte, error)
 
 // MarshalRowChangedEvent implement the marshaller interface
 func (m *avroMarshaller) MarshalRowChangedEvent(
-	event *common.RowChangedEvent,
+	event *commonEvent.RowEvent,
 	handleKeyOnly bool, claimCheckFileName string,
 ) ([]byte, error) {
-	msg := m.newDMLMessageMap(event, handleKeyOnly, claimCheckFileName)
+	msg, err := m.newDMLMessageMap(event, handleKeyOnly, claimCheckFileName)
+	if err != nil {
+		return nil, err
+	}
 	value, err := m.codec.BinaryFromNative(nil, msg)
 	recycleMap(msg)
 	return value, errors.WrapError(errors.ErrEncodeFailed, err)
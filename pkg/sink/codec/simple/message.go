@@ -23,14 +23,15 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
-	commonNew "github.com/pingcap/ticdc/pkg/sink/codec/common"
 	ticommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/types"
 	tiTypes "github.com/pingcap/tidb/pkg/types"
-	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tiflow/pkg/integrity"
 	"github.com/pingcap/tiflow/pkg/sink/codec/utils"
 	"go.uber.org/zap"
@@ -146,7 +147,7 @@ func newColumnSchema(col *timodel.ColumnInfo) *columnSchema {
 		tp.Decimal = col.GetDecimal()
 	}
 
-	defaultValue := model.GetColumnDefaultValue(col)
+	defaultValue := common.GetColumnDefaultValue(col)
 	if defaultValue != nil && col.GetType() == mysql.TypeBit {
 		defaultValue = ticommon.MustBinaryLiteralToInt([]byte(defaultValue.(string)))
 	}
@@ -164,7 +165,7 @@ func newTiColumnInfo(
 ) *timodel.ColumnInfo {
 	col := new(timodel.ColumnInfo)
 	col.ID = colID
-	col.Name = timodel.NewCIStr(column.Name)
+	col.Name = pmodel.NewCIStr(column.Name)
 
 	col.FieldType = *types.NewFieldType(types.StrToType(column.DataType.MySQLType))
 	col.SetCharset(column.DataType.Charset)
@@ -258,14 +259,14 @@ func newTiIndexInfo(indexSchema *IndexSchema, columns []*timodel.ColumnInfo, ind
 			}
 		}
 		indexColumns[i] = &timodel.IndexColumn{
-			Name:   timodel.NewCIStr(col),
+			Name:   pmodel.NewCIStr(col),
 			Offset: offset,
 		}
 	}
 
 	return &timodel.IndexInfo{
 		ID:      indexID,
-		Name:    timodel.NewCIStr(indexSchema.Name),
+		Name:    pmodel.NewCIStr(indexSchema.Name),
 		Columns: indexColumns,
 		Unique:  indexSchema.Unique,
 		Primary: indexSchema.Primary,
@@ -282,11 +283,12 @@ type TableSchema struct {
 	Indexes []*IndexSchema  `json:"indexes"`
 }
 
-func newTableSchema(tableInfo *model.TableInfo) *TableSchema {
+func newTableSchema(tableInfo *common.TableInfo) *TableSchema {
 	pkInIndexes := false
-	indexes := make([]*IndexSchema, 0, len(tableInfo.Indices))
-	for _, idx := range tableInfo.Indices {
-		index := newIndexSchema(idx, tableInfo.Columns)
+	indices := tableInfo.GetIndices()
+	indexes := make([]*IndexSchema, 0, len(indices))
+	for _, idx := range indices {
+		index := newIndexSchema(idx, tableInfo.GetColumns())
 		if index.Primary {
 			pkInIndexes = true
 		}
@@ -308,12 +310,8 @@ func newTableSchema(tableInfo *model.TableInfo) *TableSchema {
 		}
 	}
 
-	sort.SliceStable(tableInfo.Columns, func(i, j int) bool {
-		return tableInfo.Columns[i].ID < tableInfo.Columns[j].ID
-	})
-
-	columns := make([]*columnSchema, 0, len(tableInfo.Columns))
-	for _, col := range tableInfo.Columns {
+	columns := make([]*columnSchema, 0, len(tableInfo.GetColumns()))
+	for _, col := range sortedColumns(tableInfo) {
 		colSchema := newColumnSchema(col)
 		columns = append(columns, colSchema)
 	}
@@ -321,27 +319,34 @@ func newTableSchema(tableInfo *model.TableInfo) *TableSchema {
 	return &TableSchema{
 		Schema:  tableInfo.TableName.Schema,
 		Table:   tableInfo.TableName.Table,
-		TableID: tableInfo.ID,
-		Version: tableInfo.UpdateTS,
+		TableID: tableInfo.TableName.TableID,
+		Version: tableInfo.UpdateTS(),
 		Columns: columns,
 		Indexes: indexes,
 	}
 }
 
+// sortedColumns returns the columns of the table sorted by the column ID.
+// the columns are shared by all table infos with the same schema, so sort a copy of them.
+func sortedColumns(tableInfo *common.TableInfo) []*timodel.ColumnInfo {
+	columns := make([]*timodel.ColumnInfo, len(tableInfo.GetColumns()))
+	copy(columns, tableInfo.GetColumns())
+	sort.SliceStable(columns, func(i, j int) bool {
+		return columns[i].ID < columns[j].ID
+	})
+	return columns
+}
+
 // newTableInfo converts from TableSchema to TableInfo.
-func newTableInfo(m *TableSchema) *model.TableInfo {
-	var (
-		database      string
-		schemaVersion uint64
-	)
+func newTableInfo(m *TableSchema) *common.TableInfo {
+	var database string
 
 	tidbTableInfo := &timodel.TableInfo{}
 	if m != nil {
 		database = m.Schema
-		schemaVersion = m.Version
 
 		tidbTableInfo.ID = m.TableID
-		tidbTableInfo.Name = timodel.NewCIStr(m.Table)
+		tidbTableInfo.Name = pmodel.NewCIStr(m.Table)
 		tidbTableInfo.UpdateTS = m.Version
 
 		nextMockID := int64(100)
@@ -358,34 +363,26 @@ func newTableInfo(m *TableSchema) *model.TableInfo {
 			mockIndexID += 1
 		}
 	}
-	return model.WrapTableInfo(100, database, schemaVersion, tidbTableInfo)
+	return common.WrapTableInfo(100, database, tidbTableInfo)
 }
 
 // newDDLEvent converts from message to DDLEvent.
-func newDDLEvent(msg *message) *model.DDLEvent {
-	var (
-		tableInfo    *model.TableInfo
-		preTableInfo *model.TableInfo
-	)
-
-	tableInfo = newTableInfo(msg.TableSchema)
-	if msg.PreTableSchema != nil {
-		preTableInfo = newTableInfo(msg.PreTableSchema)
-	}
-	return &model.DDLEvent{
-		StartTs:      msg.CommitTs,
-		CommitTs:     msg.CommitTs,
-		TableInfo:    tableInfo,
-		PreTableInfo: preTableInfo,
-		Query:        msg.SQL,
+func newDDLEvent(msg *message) *commonEvent.DDLEvent {
+	tableInfo := newTableInfo(msg.TableSchema)
+	return &commonEvent.DDLEvent{
+		FinishedTs: msg.CommitTs,
+		SchemaName: tableInfo.GetSchemaName(),
+		TableName:  tableInfo.GetTableName(),
+		TableInfo:  tableInfo,
+		Query:      msg.SQL,
 	}
 }
 
 // buildRowChangedEvent converts from message to RowChangedEvent.
 func buildRowChangedEvent(
 	msg *message, tableInfo *common.TableInfo, enableRowChecksum bool, db *sql.DB,
-) (*common.RowChangedEvent, error) {
-	result := &common.RowChangedEvent{
+) (*commonEvent.RowChangedEvent, error) {
+	result := &commonEvent.RowChangedEvent{
 		CommitTs:        msg.CommitTs,
 		PhysicalTableID: msg.TableID,
 		TableInfo:       tableInfo,
@@ -401,7 +398,7 @@ func buildRowChangedEvent(
 			Version:   msg.Checksum.Version,
 		}
 
-		err := commonNew.VerifyChecksum(result, db)
+		err := ticommon.VerifyChecksum(result, db)
 		if err != nil || msg.Checksum.Corrupted {
 			log.Warn("consumer detect checksum corrupted",
 				zap.String("schema", msg.Schema), zap.String("table", msg.Table))
@@ -422,6 +419,121 @@ func buildRowChangedEvent(
 	return result, nil
 }
 
+// rowChangedEvent2DMLEvent converts the row changed event to a dml event which contains only one row change.
+func rowChangedEvent2DMLEvent(row *commonEvent.RowChangedEvent) (*commonEvent.DMLEvent, error) {
+	tableInfo := row.TableInfo
+	// the start ts is lost in the message.
+	result := commonEvent.NewDMLEvent(common.DispatcherID{}, row.PhysicalTableID, 0, row.CommitTs, tableInfo)
+	switch {
+	case row.IsUpdate():
+		preRow, err := columns2Datums(row.PreColumns, tableInfo)
+		if err != nil {
+			return nil, err
+		}
+		datums, err := columns2Datums(row.Columns, tableInfo)
+		if err != nil {
+			return nil, err
+		}
+		result.AppendRowDatums(commonEvent.RowTypeUpdate, preRow, datums)
+	case row.IsDelete():
+		preRow, err := columns2Datums(row.PreColumns, tableInfo)
+		if err != nil {
+			return nil, err
+		}
+		result.AppendRowDatums(commonEvent.RowTypeDelete, preRow, nil)
+	default:
+		datums, err := columns2Datums(row.Columns, tableInfo)
+		if err != nil {
+			return nil, err
+		}
+		result.AppendRowDatums(commonEvent.RowTypeInsert, nil, datums)
+	}
+	return result, nil
+}
+
+// columns2Datums converts the decoded columns to the datums ordered the same as the columns of the table info,
+// the null value is used for the columns not found, such as the generated columns.
+func columns2Datums(columns []*common.Column, tableInfo *common.TableInfo) ([]tiTypes.Datum, error) {
+	values := make(map[string]interface{}, len(columns))
+	for _, col := range columns {
+		values[col.Name] = col.Value
+	}
+	datums := make([]tiTypes.Datum, 0, len(tableInfo.GetColumns()))
+	for _, col := range tableInfo.GetColumns() {
+		d, err := value2Datum(values[col.Name.O], &col.FieldType)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrDecodeFailed, err)
+		}
+		datums = append(datums, d)
+	}
+	return datums, nil
+}
+
+// value2Datum converts the column value returned by `decodeColumn` to the datum.
+func value2Datum(value interface{}, ft *types.FieldType) (tiTypes.Datum, error) {
+	var d tiTypes.Datum
+	if value == nil {
+		return d, nil
+	}
+	var err error
+	switch ft.GetType() {
+	case mysql.TypeBit:
+		bitValue, ok := value.(uint64)
+		if !ok {
+			return d, fmt.Errorf("unexpected bit value %v", value)
+		}
+		byteSize := (ft.GetFlen() + 7) >> 3
+		d.SetMysqlBit(tiTypes.NewBinaryLiteralFromUint(bitValue, byteSize))
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp, mysql.TypeDuration:
+		str, ok := value.(string)
+		if !ok {
+			return d, fmt.Errorf("unexpected time value %v", value)
+		}
+		// the precision is not encoded by avro, so use the one of the value.
+		fsp := tiTypes.GetFsp(str)
+		if ft.GetType() == mysql.TypeDuration {
+			var duration tiTypes.Duration
+			duration, _, err = tiTypes.ParseDuration(tiTypes.DefaultStmtNoWarningContext, str, fsp)
+			d.SetMysqlDuration(duration)
+			break
+		}
+		var t tiTypes.Time
+		t, err = tiTypes.ParseTime(tiTypes.DefaultStmtNoWarningContext, str, ft.GetType(), fsp)
+		d.SetMysqlTime(t)
+	case mysql.TypeEnum, mysql.TypeSet:
+		// avro encoding, enum is decoded as `int64`.
+		d = tiTypes.NewDatum(value)
+		d, err = d.ConvertTo(tiTypes.DefaultStmtNoWarningContext, ft)
+	default:
+		switch v := value.(type) {
+		case []byte:
+			// the value of the binary column is restored as it's.
+			d.SetBytes(v)
+		case string:
+			if tiTypes.IsString(ft.GetType()) {
+				d.SetString(v, ft.GetCollate())
+				break
+			}
+			strDatum := tiTypes.NewStringDatum(v)
+			d, err = strDatum.ConvertTo(tiTypes.DefaultStmtNoWarningContext, ft)
+		case int64, uint64, float32:
+			// the numeric value is decoded by the column type, use it directly,
+			// since the converting may round it by the length of the column.
+			d = tiTypes.NewDatum(v)
+		case float64:
+			if ft.GetType() == mysql.TypeFloat {
+				d.SetFloat32(float32(v))
+				break
+			}
+			d.SetFloat64(v)
+		default:
+			d = tiTypes.NewDatum(v)
+			d, err = d.ConvertTo(tiTypes.DefaultStmtNoWarningContext, ft)
+		}
+	}
+	return d, err
+}
+
 func adjustTimestampValue(column *common.Column, flag types.FieldType) {
 	if flag.GetType() != mysql.TypeTimestamp {
 		return
@@ -445,7 +557,7 @@ func decodeColumns(
 		return nil
 	}
 	var result []*common.Column
-	for _, info := range tableInfo.Columns {
+	for _, info := range tableInfo.GetColumns() {
 		value, ok := rawData[info.Name.O]
 		if !ok {
 			log.Warn("cannot found the value for the column, "+
@@ -459,6 +571,11 @@ func decodeColumns(
 			log.Panic("cannot decode column",
 				zap.String("name", info.Name.O), zap.Any("data", value))
 		}
+		col.Name = info.Name.O
+		col.Type = info.GetType()
+		col.Charset = info.GetCharset()
+		col.Collation = info.GetCollate()
+		col.Flag = *tableInfo.ForceGetColumnFlagType(columnID)
 
 		result = append(result, col)
 	}
@@ -513,7 +630,7 @@ func newResolvedMessage(ts uint64) *message {
 	}
 }
 
-func newBootstrapMessage(tableInfo *model.TableInfo) *message {
+func newBootstrapMessage(tableInfo *common.TableInfo) *message {
 	schema := newTableSchema(tableInfo)
 	msg := &message{
 		Version:     defaultVersion,
@@ -524,85 +641,89 @@ func newBootstrapMessage(tableInfo *model.TableInfo) *message {
 	return msg
 }
 
-func newDDLMessage(ddl *model.DDLEvent) *message {
-	var (
-		schema    *TableSchema
-		preSchema *TableSchema
-	)
+func newDDLMessage(ddl *commonEvent.DDLEvent) *message {
+	var schema *TableSchema
 	// the tableInfo maybe nil if the DDL is `drop database`
-	if ddl.TableInfo != nil && ddl.TableInfo.TableInfo != nil {
+	if ddl.TableInfo != nil {
 		schema = newTableSchema(ddl.TableInfo)
 	}
-	// `PreTableInfo` may not exist for some DDL, such as `create table`
-	if ddl.PreTableInfo != nil && ddl.PreTableInfo.TableInfo != nil {
-		preSchema = newTableSchema(ddl.PreTableInfo)
-	}
+	// the DDL event does not carry the table info before the DDL executed,
+	// so the `PreTableSchema` is not set.
 	msg := &message{
-		Version:        defaultVersion,
-		Type:           getDDLType(ddl.Type),
-		CommitTs:       ddl.CommitTs,
-		BuildTs:        time.Now().UnixMilli(),
-		SQL:            ddl.Query,
-		TableSchema:    schema,
-		PreTableSchema: preSchema,
+		Version:     defaultVersion,
+		Type:        getDDLType(timodel.ActionType(ddl.Type)),
+		CommitTs:    ddl.FinishedTs,
+		BuildTs:     time.Now().UnixMilli(),
+		SQL:         ddl.Query,
+		TableSchema: schema,
 	}
 	return msg
 }
 
 func (a *JSONMarshaller) newDMLMessage(
-	event *common.RowChangedEvent,
+	event *commonEvent.RowEvent,
 	onlyHandleKey bool, claimCheckFileName string,
-) *message {
+) (*message, error) {
 	m := &message{
 		Version:            defaultVersion,
 		Schema:             event.TableInfo.GetSchemaName(),
 		Table:              event.TableInfo.GetTableName(),
-		TableID:            event.TableInfo.ID,
+		TableID:            event.TableInfo.TableName.TableID,
 		CommitTs:           event.CommitTs,
 		BuildTs:            time.Now().UnixMilli(),
-		SchemaVersion:      event.TableInfo.UpdateTS,
+		SchemaVersion:      event.TableInfo.UpdateTS(),
 		HandleKeyOnly:      onlyHandleKey,
 		ClaimCheckLocation: claimCheckFileName,
 	}
+	var err error
 	if event.IsInsert() {
 		m.Type = DMLTypeInsert
-		m.Data = a.formatColumns(event.Columns, event.TableInfo, onlyHandleKey)
+		m.Data, err = a.formatColumns(event, event.GetRows(), onlyHandleKey)
 	} else if event.IsDelete() {
 		m.Type = DMLTypeDelete
-		m.Old = a.formatColumns(event.PreColumns, event.TableInfo, onlyHandleKey)
+		m.Old, err = a.formatColumns(event, event.GetPreRows(), onlyHandleKey)
 	} else if event.IsUpdate() {
 		m.Type = DMLTypeUpdate
-		m.Data = a.formatColumns(event.Columns, event.TableInfo, onlyHandleKey)
-		m.Old = a.formatColumns(event.PreColumns, event.TableInfo, onlyHandleKey)
-	}
-	if a.config.EnableRowChecksum && event.Checksum != nil {
-		m.Checksum = &checksum{
-			Version:   event.Checksum.Version,
-			Corrupted: event.Checksum.Corrupted,
-			Current:   event.Checksum.Current,
-			Previous:  event.Checksum.Previous,
+		m.Data, err = a.formatColumns(event, event.GetRows(), onlyHandleKey)
+		if err != nil {
+			return nil, err
 		}
+		m.Old, err = a.formatColumns(event, event.GetPreRows(), onlyHandleKey)
 	}
-
-	return m
+	return m, err
 }
 
 func (a *JSONMarshaller) formatColumns(
-	columns []*common.Column, tableInfo *common.TableInfo, onlyHandleKey bool,
-) map[string]interface{} {
-	result := make(map[string]interface{}, len(columns))
-	colInfos := tableInfo.GetColInfosForRowChangedEvent()
-	for i, col := range columns {
-		if col != nil {
-			flag := col.Flag
-			if onlyHandleKey && !flag.IsHandleKey() {
-				continue
-			}
-			value := encodeValue(col.Value, colInfos[i].Ft, a.config.TimeZone.String())
-			result[col.Name] = value
+	event *commonEvent.RowEvent, row *chunk.Row, onlyHandleKey bool,
+) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(event.TableInfo.GetColumns()))
+	err := forEachColumn(event, row, onlyHandleKey, func(col *timodel.ColumnInfo, value interface{}) {
+		result[col.Name.O] = encodeValue(value, &col.FieldType, a.config.TimeZone.String())
+	})
+	return result, err
+}
+
+// forEachColumn calls fn with the value of each column which should be sent to the downstream.
+// only the handle key columns are visited if onlyHandleKey is true.
+func forEachColumn(
+	event *commonEvent.RowEvent, row *chunk.Row, onlyHandleKey bool,
+	fn func(col *timodel.ColumnInfo, value interface{}),
+) error {
+	tableInfo := event.TableInfo
+	for idx, col := range tableInfo.GetColumns() {
+		if !common.IsColCDCVisible(col) || !event.ColumnSelector.Select(col) {
+			continue
+		}
+		if onlyHandleKey && !tableInfo.GetColumnFlags()[col.ID].IsHandleKey() {
+			continue
+		}
+		value, err := common.FormatColVal(row, col, idx)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrEncodeFailed, err)
 		}
+		fn(col, value)
 	}
-	return result
+	return nil
 }
 
 func (a *avroMarshaller) encodeValue4Avro(
@@ -81,6 +81,20 @@ type AsyncProducer interface {
 	AsyncRunCallback(ctx context.Context) error
 }
 
+// TxnAsyncProducer is the kafka async producer which sends messages in kafka transactions.
+// The messages sent between BeginTxn and CommitTxn are visible to the consumers
+// with `read_committed` isolation level only after the transaction is committed.
+type TxnAsyncProducer interface {
+	AsyncProducer
+
+	// BeginTxn begins a new transaction.
+	BeginTxn() error
+	// CommitTxn flushes all the messages sent in the transaction and commits it.
+	CommitTxn() error
+	// AbortTxn aborts the ongoing transaction.
+	AbortTxn() error
+}
+
 type saramaSyncProducer struct {
 	id       commonType.ChangeFeedID
 	client   sarama.Client
@@ -271,3 +285,19 @@ func (p *saramaAsyncProducer) AsyncSend(
 	}
 	return nil
 }
+
+type saramaTxnAsyncProducer struct {
+	*saramaAsyncProducer
+}
+
+func (p *saramaTxnAsyncProducer) BeginTxn() error {
+	return cerror.WrapError(cerror.ErrKafkaAsyncSendMessage, p.producer.BeginTxn())
+}
+
+func (p *saramaTxnAsyncProducer) CommitTxn() error {
+	return cerror.WrapError(cerror.ErrKafkaAsyncSendMessage, p.producer.CommitTxn())
+}
+
+func (p *saramaTxnAsyncProducer) AbortTxn() error {
+	return cerror.WrapError(cerror.ErrKafkaAsyncSendMessage, p.producer.AbortTxn())
+}
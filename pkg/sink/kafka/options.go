@@ -170,6 +170,13 @@ type Options struct {
 	DialTimeout  time.Duration
 	WriteTimeout time.Duration
 	ReadTimeout  time.Duration
+
+	// Transactional indicates whether to send the DML messages by the transactional producer,
+	// it is enabled when the `transaction-atomicity` of the changefeed is `table`.
+	Transactional bool
+	// TransactionalID is the `transactional.id` of the transactional producer,
+	// it's only set when Transactional is true.
+	TransactionalID string
 }
 
 // NewOptions returns a default Kafka configuration
@@ -308,6 +315,18 @@ func (o *Options) Apply(changefeedID common.ChangeFeedID,
 		o.RequiredAcks = r
 	}
 
+	if sinkConfig != nil && sinkConfig.TxnAtomicity != nil {
+		o.Transactional = !sinkConfig.TxnAtomicity.ShouldSplitTxn()
+	}
+	if o.Transactional {
+		transactionalID, err := NewKafkaTransactionalID(
+			config.GetGlobalServerConfig().AdvertiseAddr, changefeedID)
+		if err != nil {
+			return err
+		}
+		o.TransactionalID = transactionalID
+	}
+
 	err = o.applySASL(urlParameter, sinkConfig)
 	if err != nil {
 		return err
@@ -565,6 +584,29 @@ func NewKafkaClientID(captureAddr string,
 	return
 }
 
+// NewKafkaTransactionalID generates the kafka transactional id of the sink
+// which runs on the capture for the changefeed.
+//
+// The kafka broker fences the producers with the same transactional id, the ongoing
+// transaction of the old producer is aborted once a new producer with the same id is
+// initialized. So the id only depends on the changefeed and the advertised address of
+// the capture, but not the configured client id which may be shared by changefeeds:
+//   - the sinks of one changefeed on different captures never fence each other,
+//   - the sink restarted on the same capture fences the zombie producer left behind,
+//     so the uncommitted messages of the zombie are never visible to the consumers.
+func NewKafkaTransactionalID(captureAddr string,
+	changefeedID common.ChangeFeedID,
+) (string, error) {
+	transactionalID := fmt.Sprintf("TiCDC_txn_%s_%s_%s",
+		changefeedID.Namespace(), changefeedID.ID(), captureAddr)
+	transactionalID = commonInvalidChar.ReplaceAllString(transactionalID, "_")
+	if !validClientID.MatchString(transactionalID) {
+		return "", cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"invalid kafka transactional id %s", transactionalID)
+	}
+	return transactionalID, nil
+}
+
 // AdjustOptions adjust the `Options` and `sarama.Config` by condition.
 func AdjustOptions(
 	ctx context.Context,
@@ -0,0 +1,60 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionalIDWithConfiguredClientID(t *testing.T) {
+	sinkURI, err := url.Parse("kafka://127.0.0.1:9092/test?kafka-client-id=shared-client")
+	require.NoError(t, err)
+	atomicity := config.AtomicityLevel("table")
+	sinkConfig := &config.SinkConfig{TxnAtomicity: &atomicity}
+
+	changefeedID1 := common.NewChangefeedID4Test("default", "test1")
+	o1 := NewOptions()
+	require.NoError(t, o1.Apply(changefeedID1, sinkURI, sinkConfig))
+	require.True(t, o1.Transactional)
+	require.Equal(t, "shared-client", o1.ClientID)
+
+	changefeedID2 := common.NewChangefeedID4Test("default", "test2")
+	o2 := NewOptions()
+	require.NoError(t, o2.Apply(changefeedID2, sinkURI, sinkConfig))
+	require.Equal(t, "shared-client", o2.ClientID)
+
+	// the changefeeds sharing the client id must not fence each other.
+	require.NotEqual(t, o1.ClientID, o1.TransactionalID)
+	require.NotEqual(t, o1.TransactionalID, o2.TransactionalID)
+
+	// the transactional id is stable for the changefeed on the same capture.
+	expected, err := NewKafkaTransactionalID(
+		config.GetGlobalServerConfig().AdvertiseAddr, changefeedID1)
+	require.NoError(t, err)
+	require.Equal(t, expected, o1.TransactionalID)
+	o3 := NewOptions()
+	require.NoError(t, o3.Apply(changefeedID1, sinkURI, sinkConfig))
+	require.Equal(t, o1.TransactionalID, o3.TransactionalID)
+
+	// the transactional id is not set without table atomicity.
+	o4 := NewOptions()
+	require.NoError(t, o4.Apply(changefeedID1, sinkURI, &config.SinkConfig{}))
+	require.False(t, o4.Transactional)
+	require.Empty(t, o4.TransactionalID)
+}
@@ -144,6 +144,22 @@ func NewSaramaConfig(ctx context.Context, o *Options) (*sarama.Config, error) {
 	return config, nil
 }
 
+// newTransactionalConfig returns a copy of the config which is used by the transactional producer.
+func newTransactionalConfig(config *sarama.Config, transactionalID string) *sarama.Config {
+	txnConfig := *config
+	// The transactional producer must be idempotent, the broker de-duplicates the retried
+	// messages by the sequence number, so it's safe to retry without reordering messages.
+	txnConfig.Producer.Idempotent = true
+	txnConfig.Producer.Transaction.ID = transactionalID
+	txnConfig.Producer.RequiredAcks = sarama.WaitForAll
+	txnConfig.Producer.Retry.Max = 3
+	txnConfig.Net.MaxOpenRequests = 1
+	if !txnConfig.Version.IsAtLeast(sarama.V0_11_0_0) {
+		txnConfig.Version = sarama.V0_11_0_0
+	}
+	return &txnConfig
+}
+
 func completeSaramaSASLConfig(ctx context.Context, config *sarama.Config, o *Options) error {
 	if o.SASL != nil && o.SASL.SASLMechanism != "" {
 		config.Net.SASL.Enable = true
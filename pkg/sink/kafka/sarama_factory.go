@@ -29,6 +29,9 @@ type saramaFactory struct {
 	changefeedID common.ChangeFeedID
 	config       *sarama.Config
 	endpoints    []string
+
+	// transactionalID is not empty if the async producer should be transactional.
+	transactionalID string
 }
 
 // NewSaramaFactory constructs a Factory with sarama implementation.
@@ -49,11 +52,15 @@ func NewSaramaFactory(
 	}
 	saramaConfig.MetricRegistry = metrics.NewRegistry()
 
-	return &saramaFactory{
+	f := &saramaFactory{
 		changefeedID: changefeedID,
 		endpoints:    o.BrokerEndpoints,
 		config:       saramaConfig,
-	}, nil
+	}
+	if o.Transactional {
+		f.transactionalID = o.TransactionalID
+	}
+	return f, nil
 }
 
 func (f *saramaFactory) AdminClient() (ClusterAdminClient, error) {
@@ -106,8 +113,13 @@ func (f *saramaFactory) SyncProducer() (SyncProducer, error) {
 
 // AsyncProducer return an Async Producer,
 // it should be the caller's responsibility to close the producer
+// If the factory is transactional, the returned producer is a TxnAsyncProducer.
 func (f *saramaFactory) AsyncProducer(_ context.Context) (AsyncProducer, error) {
-	client, err := sarama.NewClient(f.endpoints, f.config)
+	config := f.config
+	if f.transactionalID != "" {
+		config = newTransactionalConfig(f.config, f.transactionalID)
+	}
+	client, err := sarama.NewClient(f.endpoints, config)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -115,12 +127,20 @@ func (f *saramaFactory) AsyncProducer(_ context.Context) (AsyncProducer, error)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &saramaAsyncProducer{
+	producer := &saramaAsyncProducer{
 		client:       client,
 		producer:     p,
 		changefeedID: f.changefeedID,
 		failpointCh:  make(chan error, 1),
-	}, nil
+	}
+	if f.transactionalID != "" {
+		log.Info("kafka async producer is transactional",
+			zap.String("namespace", f.changefeedID.Namespace()),
+			zap.String("changefeed", f.changefeedID.Name()),
+			zap.String("transactionalID", f.transactionalID))
+		return &saramaTxnAsyncProducer{saramaAsyncProducer: producer}, nil
+	}
+	return producer, nil
 }
 
 func (f *saramaFactory) MetricsCollector(
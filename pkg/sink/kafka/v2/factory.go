@@ -46,11 +46,14 @@ type factory struct {
 	options      *pkafka.Options
 
 	writer *kafka.Writer
+	// txnFactory is not nil if the async producer should be transactional,
+	// it creates the transactional producer instead of kafka-go.
+	txnFactory pkafka.Factory
 }
 
 // NewFactory returns a factory implemented based on kafka-go
 func NewFactory(
-	_ context.Context,
+	ctx context.Context,
 	options *pkafka.Options,
 	changefeedID commonType.ChangeFeedID,
 ) (pkafka.Factory, error) {
@@ -58,12 +61,22 @@ func NewFactory(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &factory{
+	f := &factory{
 		transport:    transport,
 		changefeedID: changefeedID,
 		options:      options,
 		writer:       &kafka.Writer{},
-	}, nil
+	}
+	if options.Transactional {
+		// kafka-go always writes the record batches without the producer id, epoch
+		// and sequence, which are required by the broker to accept the records of
+		// a transaction, so the transactional producer is implemented by sarama.
+		f.txnFactory, err = pkafka.NewSaramaFactory(ctx, options, changefeedID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return f, nil
 }
 
 func newClient(brokerEndpoints []string, transport *kafka.Transport) *kafka.Client {
@@ -220,9 +233,13 @@ func (f *factory) SyncProducer() (pkafka.SyncProducer, error) {
 }
 
 // AsyncProducer creates an async producer to writer message to kafka
+// If the factory is transactional, the returned producer is a TxnAsyncProducer.
 func (f *factory) AsyncProducer(
 	ctx context.Context,
 ) (pkafka.AsyncProducer, error) {
+	if f.txnFactory != nil {
+		return f.txnFactory.AsyncProducer(ctx)
+	}
 	w := f.newWriter(true)
 	// assume each message is 1KB,
 	// and set batch timeout to 5ms to avoid waste too much time on waiting for messages.
@@ -0,0 +1,40 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"testing"
+
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	pkafka "github.com/pingcap/ticdc/pkg/sink/kafka"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransactionalFactory(t *testing.T) {
+	changefeedID := commonType.NewChangefeedID4Test("default", "test")
+	options := pkafka.NewOptions()
+	options.BrokerEndpoints = []string{"127.0.0.1:9092"}
+	options.ClientID = "test-client"
+
+	f, err := NewFactory(context.Background(), options, changefeedID)
+	require.NoError(t, err)
+	require.Nil(t, f.(*factory).txnFactory)
+
+	options.Transactional = true
+	options.TransactionalID = "test-txn"
+	f, err = NewFactory(context.Background(), options, changefeedID)
+	require.NoError(t, err)
+	require.NotNil(t, f.(*factory).txnFactory)
+}
@@ -116,6 +116,12 @@ type MysqlConfig struct {
 	CachePrepStmts  bool
 	// DryRun is used to enable dry-run mode. In dry-run mode, the writer will not write data to the downstream.
 	DryRun bool
+	// SkipDDLTs is used to skip recording the ddl ts in the downstream after ddl is executed.
+	// It's used when applying redo logs, which doesn't need to resume from the ddl ts.
+	SkipDDLTs bool
+	// EnableDMLCheckpoint is used to record the dml progress of each dispatcher in the downstream,
+	// in the same transaction as the rows, so the dispatchers can skip the applied events after restarting.
+	EnableDMLCheckpoint bool
 
 	// sync point
 	SyncPointRetention time.Duration
@@ -163,7 +169,7 @@ func (c *MysqlConfig) Apply(
 		return cerror.ErrMySQLInvalidConfig.GenWithStack("can't create MySQL sink with unsupported scheme: %s", scheme)
 	}
 	query := sinkURI.Query()
-	if err = getWorkerCount(query, &c.WorkerCount); err != nil {
+	if c.WorkerCount, err = GetWorkerCount(sinkURI, config); err != nil {
 		return err
 	}
 	if err = getMaxTxnRow(query, &c.MaxTxnRow); err != nil {
@@ -202,6 +208,9 @@ func (c *MysqlConfig) Apply(
 	if err = getMultiStmtEnable(query, &c.MultiStmtEnable); err != nil {
 		return err
 	}
+	if err = getEnableDMLCheckpoint(query, &c.EnableDMLCheckpoint); err != nil {
+		return err
+	}
 
 	// c.EnableOldValue = config.EnableOldValue
 	c.ForceReplicate = config.ForceReplicate
@@ -242,6 +251,12 @@ func NewMysqlConfigAndDB(ctx context.Context, changefeedID common.ChangeFeedID,
 	if err != nil {
 		return nil, nil, err
 	}
+	// In BDR mode, the writes must be tagged with the source ID,
+	// otherwise they are replicated back to the upstream.
+	if config.BDRMode && !cfg.IsWriteSourceExisted {
+		return nil, nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+			"the downstream doesn't support bdr mode, it must be TiDB v6.5.0 or later")
+	}
 
 	// By default, cache-prep-stmts=true, an LRU cache is used for prepared statements,
 	// two connections are required to process a transaction.
@@ -321,6 +336,23 @@ func IsSinkSafeMode(sinkURI *url.URL, replicaConfig *config.ReplicaConfig) (bool
 	return safeMode, nil
 }
 
+// GetWorkerCount returns the dml worker count of the mysql sink, the worker count
+// in the sink config takes precedence over the one in the sink uri, since the former
+// is hot-reloadable.
+func GetWorkerCount(sinkURI *url.URL, config *config.ChangefeedConfig) (int, error) {
+	workerCount := DefaultWorkerCount
+	if err := getWorkerCount(sinkURI.Query(), &workerCount); err != nil {
+		return 0, err
+	}
+	if config != nil && config.SinkConfig != nil && config.SinkConfig.MySQLConfig != nil &&
+		config.SinkConfig.MySQLConfig.WorkerCount != nil {
+		if err := checkWorkerCount(*config.SinkConfig.MySQLConfig.WorkerCount, &workerCount); err != nil {
+			return 0, err
+		}
+	}
+	return workerCount, nil
+}
+
 func getWorkerCount(values url.Values, workerCount *int) error {
 	s := values.Get("worker-count")
 	if len(s) == 0 {
@@ -331,6 +363,10 @@ func getWorkerCount(values url.Values, workerCount *int) error {
 	if err != nil {
 		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
 	}
+	return checkWorkerCount(c, workerCount)
+}
+
+func checkWorkerCount(c int, workerCount *int) error {
 	if c <= 0 {
 		return cerror.WrapError(cerror.ErrMySQLInvalidConfig,
 			fmt.Errorf("invalid worker-count %d, which must be greater than 0", c))
@@ -454,32 +490,6 @@ func getSSLCA(values url.Values, changefeedID common.ChangeFeedID, tls *string)
 	return nil
 }
 
-// func getBatchReplaceEnable(values url.Values, batchReplaceEnabled *bool, batchReplaceSize *int) error {
-// 	s := values.Get("batch-replace-enable")
-// 	if len(s) > 0 {
-// 		enable, err := strconv.ParseBool(s)
-// 		if err != nil {
-// 			return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
-// 		}
-// 		*batchReplaceEnabled = enable
-// 	}
-
-// 	if !*batchReplaceEnabled {
-// 		return nil
-// 	}
-
-// 	s = values.Get("batch-replace-size")
-// 	if len(s) == 0 {
-// 		return nil
-// 	}
-// 	size, err := strconv.Atoi(s)
-// 	if err != nil {
-// 		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
-// 	}
-// 	*batchReplaceSize = size
-// 	return nil
-// }
-
 func getSafeMode(values url.Values, safeMode *bool) error {
 	s := values.Get("safe-mode")
 	if len(s) == 0 {
@@ -563,3 +573,15 @@ func getMultiStmtEnable(values url.Values, multiStmtEnable *bool) error {
 	}
 	return nil
 }
+
+func getEnableDMLCheckpoint(values url.Values, enableDMLCheckpoint *bool) error {
+	s := values.Get("enable-dml-checkpoint")
+	if len(s) > 0 {
+		enable, err := strconv.ParseBool(s)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+		}
+		*enableDMLCheckpoint = enable
+	}
+	return nil
+}
@@ -190,16 +190,16 @@ func TestApplySinkURIParamsToConfig(t *testing.T) {
 	expected.MaxTxnRow = 20
 	expected.MaxMultiUpdateRowCount = 80
 	expected.MaxMultiUpdateRowSize = 512
-	// expected.BatchReplaceEnabled = true
-	// expected.BatchReplaceSize = 50
+	expected.BatchDMLEnable = false
 	expected.SafeMode = false
 	expected.Timezone = `"UTC"`
 	expected.tidbTxnMode = "pessimistic"
+	expected.EnableDMLCheckpoint = true
 	// expected.EnableOldValue = true
 	uriStr := "mysql://127.0.0.1:3306/?time-zone=UTC&worker-count=64&max-txn-row=20" +
 		"&max-multi-update-row=80&max-multi-update-row-size=512" +
-		"&batch-replace-enable=true&batch-replace-size=50&safe-mode=false" +
-		"&tidb-txn-mode=pessimistic"
+		"&batch-dml-enable=false&safe-mode=false" +
+		"&tidb-txn-mode=pessimistic&enable-dml-checkpoint=true"
 	uri, err := url.Parse(uriStr)
 	require.Nil(t, err)
 	cfg := NewMysqlConfig()
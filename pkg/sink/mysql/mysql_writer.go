@@ -49,6 +49,8 @@ type MysqlWriter struct {
 	ddlTsTableInit   bool
 	tableSchemaStore *util.TableSchemaStore
 
+	dmlCheckpointTableInit bool
+
 	// asyncDDLState is used to store the state of async ddl.
 	// key: tableID, value: state(0: unknown state , 1: executing, 2: no executing ddl)
 	asyncDDLState sync.Map
@@ -106,7 +108,7 @@ func (w *MysqlWriter) FlushDDLEvent(event *commonEvent.DDLEvent) error {
 			return errors.Trace(err)
 		}
 	} else if !(event.TiDBOnly && !w.cfg.IsTiDB) {
-		if w.cfg.IsTiDB {
+		if w.cfg.IsTiDB && !w.cfg.SkipDDLTs {
 			// if downstream is tidb, we write ddl ts before ddl first, and update the ddl ts item after ddl executed,
 			// to ensure the atomic with ddl writing when server is restarted.
 			w.FlushDDLTsPre(event)
@@ -124,9 +126,11 @@ func (w *MysqlWriter) FlushDDLEvent(event *commonEvent.DDLEvent) error {
 		// We make Flush ddl ts before callback(), in order to make sure the ddl ts is flushed
 		// before new checkpointTs will report to maintainer. Therefore, when the table checkpointTs is forward,
 		// we can ensure the ddl and ddl ts are both flushed downstream successfully.
-		err = w.FlushDDLTs(event)
-		if err != nil {
-			return err
+		if !w.cfg.SkipDDLTs {
+			err = w.FlushDDLTs(event)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -32,7 +32,7 @@ import (
 
 func (w *MysqlWriter) asyncExecAddIndexDDLIfTimeout(event *commonEvent.DDLEvent) error {
 	var tableIDs []int64
-	switch event.GetBlockedTables().InfluenceType {
+	switch getInfluenceType(event) {
 	// only normal type may have ddl need to async exec
 	case commonEvent.InfluenceTypeNormal:
 		tableIDs = event.GetBlockedTables().TableIDs
@@ -121,7 +121,7 @@ func (w *MysqlWriter) execDDL(event *commonEvent.DDLEvent) error {
 	}
 
 	// exchange partition is not Idempotent, so we need to check ddl_ts_table whether the ddl is executed before.
-	if timodel.ActionType(event.Type) == timodel.ActionExchangeTablePartition {
+	if timodel.ActionType(event.Type) == timodel.ActionExchangeTablePartition && !w.cfg.SkipDDLTs {
 		tableID := event.BlockedTables.TableIDs[0]
 		ddlTs := event.GetCommitTs()
 		flag, err := w.isDDLExecuted(tableID, ddlTs)
@@ -229,7 +229,7 @@ func (w *MysqlWriter) waitAsyncDDLDone(event *commonEvent.DDLEvent) {
 	}
 
 	var relatedTableIDs []int64
-	switch event.GetBlockedTables().InfluenceType {
+	switch getInfluenceType(event) {
 	case commonEvent.InfluenceTypeNormal:
 		relatedTableIDs = event.GetBlockedTables().TableIDs
 	// db-class, all-class ddl with not affect by async ddl, just return
@@ -352,6 +352,16 @@ func (w *MysqlWriter) checkAndWaitAsyncDDLDoneDownstream(tableID int64) error {
 	}
 }
 
+// getInfluenceType returns the influence type of the blocked tables of the DDL event.
+// The DDL events decoded by the consumers don't carry the blocked tables,
+// InfluenceTypeDB is returned for them, so no table level async DDL is tracked.
+func getInfluenceType(event *commonEvent.DDLEvent) commonEvent.InfluenceType {
+	if event.GetBlockedTables() == nil {
+		return commonEvent.InfluenceTypeDB
+	}
+	return event.GetBlockedTables().InfluenceType
+}
+
 func needWaitAsyncExecDone(t timodel.ActionType) bool {
 	switch t {
 	case timodel.ActionCreateTable, timodel.ActionCreateTables:
@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/retry"
 	"github.com/pingcap/ticdc/pkg/util"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -40,26 +41,48 @@ func (w *MysqlWriter) prepareDMLs(events []*commonEvent.DMLEvent) (*preparedDMLs
 	dmls := dmlsPool.Get().(*preparedDMLs)
 	dmls.reset()
 
-	for _, event := range events {
+	for i := 0; i < len(events); i++ {
+		event := events[i]
 		if event.Len() == 0 {
 			continue
 		}
+		dmls.appendEvent(event)
 
-		dmls.rowCount += int(event.Len())
-		dmls.approximateSize += event.GetRowsSize()
-
-		if len(dmls.startTs) == 0 || dmls.startTs[len(dmls.startTs)-1] != event.StartTs {
-			dmls.startTs = append(dmls.startTs, event.StartTs)
-		}
-
-		inSafeMode := !w.cfg.SafeMode && event.CommitTs > event.ReplicatingTs
+		// A row can be translated in to INSERT, when it was committed after
+		// the table it belongs to been replicating by TiCDC, which means it must not be
+		// replicated before, and there is no such row in downstream MySQL.
+		translateToInsert := w.translateToInsert(event)
 
-		log.Debug("inSafeMode",
-			zap.Bool("inSafeMode", inSafeMode),
+		log.Debug("translate to insert",
+			zap.Bool("translateToInsert", translateToInsert),
 			zap.Uint64("firstRowCommitTs", event.CommitTs),
 			zap.Uint64("firstRowReplicatingTs", event.ReplicatingTs),
 			zap.Bool("safeMode", w.cfg.SafeMode))
 
+		// only use batch dml when the table has a handle key,
+		// the rows in the multi-row statements are identified by it.
+		if w.cfg.BatchDMLEnable && hasHandleKey(event.TableInfo) {
+			batch, err := w.collectBatchEvents(events[i:], translateToInsert)
+			if err != nil {
+				dmlsPool.Put(dmls) // Return to pool on error
+				return nil, errors.Trace(err)
+			}
+			if len(batch) > 1 || event.Len() > 1 {
+				for _, e := range batch[1:] {
+					dmls.appendEvent(e)
+				}
+				i += len(batch) - 1
+				sqls, values, err := w.batchDmls(batch, translateToInsert)
+				if err != nil {
+					dmlsPool.Put(dmls) // Return to pool on error
+					return nil, errors.Trace(err)
+				}
+				dmls.sqls = append(dmls.sqls, sqls...)
+				dmls.values = append(dmls.values, values...)
+				continue
+			}
+		}
+
 		for {
 			row, ok := event.GetNextRow()
 			if !ok {
@@ -72,7 +95,7 @@ func (w *MysqlWriter) prepareDMLs(events []*commonEvent.DMLEvent) (*preparedDMLs
 
 			switch row.RowType {
 			case commonEvent.RowTypeUpdate:
-				if inSafeMode {
+				if translateToInsert {
 					query, args, err = buildUpdate(event.TableInfo, row, w.cfg.ForceReplicate)
 				} else {
 					query, args, err = buildDelete(event.TableInfo, row, w.cfg.ForceReplicate)
@@ -84,12 +107,12 @@ func (w *MysqlWriter) prepareDMLs(events []*commonEvent.DMLEvent) (*preparedDMLs
 						dmls.sqls = append(dmls.sqls, query)
 						dmls.values = append(dmls.values, args)
 					}
-					query, args, err = buildInsert(event.TableInfo, row, inSafeMode)
+					query, args, err = buildInsert(event.TableInfo, row, translateToInsert)
 				}
 			case commonEvent.RowTypeDelete:
 				query, args, err = buildDelete(event.TableInfo, row, w.cfg.ForceReplicate)
 			case commonEvent.RowTypeInsert:
-				query, args, err = buildInsert(event.TableInfo, row, inSafeMode)
+				query, args, err = buildInsert(event.TableInfo, row, translateToInsert)
 			}
 
 			if err != nil {
@@ -104,6 +127,13 @@ func (w *MysqlWriter) prepareDMLs(events []*commonEvent.DMLEvent) (*preparedDMLs
 		}
 	}
 
+	// The dml checkpoint is updated in the same transaction as the rows.
+	if w.cfg.EnableDMLCheckpoint && dmls.rowCount > 0 {
+		query, args := w.genDMLCheckpointSQL(events)
+		dmls.sqls = append(dmls.sqls, query)
+		dmls.values = append(dmls.values, args)
+	}
+
 	// Pre-check log level to avoid dmls.String() being called unnecessarily
 	// This method is expensive, so we only log it when the log level is debug.
 	if log.GetLevel() == zapcore.DebugLevel {
@@ -113,6 +143,209 @@ func (w *MysqlWriter) prepareDMLs(events []*commonEvent.DMLEvent) (*preparedDMLs
 	return dmls, nil
 }
 
+func (w *MysqlWriter) translateToInsert(event *commonEvent.DMLEvent) bool {
+	return !w.cfg.SafeMode && event.CommitTs > event.ReplicatingTs
+}
+
+// collectBatchEvents returns the leading events which can be batched with events[0].
+// They are consecutive events of the same table with the same translateToInsert decision,
+// and no handle key is changed by more than one of them, so that reordering their rows
+// by type doesn't change the final result.
+func (w *MysqlWriter) collectBatchEvents(
+	events []*commonEvent.DMLEvent, translateToInsert bool,
+) ([]*commonEvent.DMLEvent, error) {
+	first := events[0]
+	batch := events[:1]
+	// the handle keys are only collected when there is an event to batch with.
+	var keys map[string]struct{}
+	for _, event := range events[1:] {
+		if event.Len() == 0 {
+			break
+		}
+		if event.PhysicalTableID != first.PhysicalTableID ||
+			event.TableInfo.UpdateTS() != first.TableInfo.UpdateTS() ||
+			w.translateToInsert(event) != translateToInsert {
+			break
+		}
+		if keys == nil {
+			keys = make(map[string]struct{})
+			if _, err := w.addHandleKeys(first, keys); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		conflict, err := w.addHandleKeys(event, keys)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if conflict {
+			break
+		}
+		batch = append(batch, event)
+	}
+	return batch, nil
+}
+
+// addHandleKeys adds the handle keys changed by the event to keys,
+// it returns true and leaves keys unchanged if any of them is already in keys.
+func (w *MysqlWriter) addHandleKeys(event *commonEvent.DMLEvent, keys map[string]struct{}) (bool, error) {
+	defer event.Rewind()
+	eventKeys := make([]string, 0, event.Len())
+	appendKey := func(row *chunk.Row) error {
+		_, args, err := whereSlice(row, event.TableInfo, w.cfg.ForceReplicate)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		eventKeys = append(eventKeys, fmt.Sprint(args...))
+		return nil
+	}
+	for {
+		row, ok := event.GetNextRow()
+		if !ok {
+			break
+		}
+		if row.RowType != commonEvent.RowTypeInsert {
+			if err := appendKey(&row.PreRow); err != nil {
+				return false, errors.Trace(err)
+			}
+		}
+		if row.RowType != commonEvent.RowTypeDelete {
+			if err := appendKey(&row.Row); err != nil {
+				return false, errors.Trace(err)
+			}
+		}
+	}
+	for _, key := range eventKeys {
+		if _, ok := keys[key]; ok {
+			return true, nil
+		}
+	}
+	for _, key := range eventKeys {
+		keys[key] = struct{}{}
+	}
+	return false, nil
+}
+
+// batchDmls builds multi-row statements for the rows of the events of one table.
+// The rows are grouped by type, and the deletes are executed before the updates
+// and the inserts, it's safe because each row is identified by the handle key,
+// which is changed at most once in one transaction, and by at most one of the events.
+func (w *MysqlWriter) batchDmls(
+	events []*commonEvent.DMLEvent, translateToInsert bool,
+) (sqls []string, values [][]interface{}, err error) {
+	tableInfo := events[0].TableInfo
+	insertRows, updateRows, deleteRows := w.groupRowsByType(events, translateToInsert)
+
+	for _, rows := range deleteRows {
+		sql, value, err := buildBatchDelete(tableInfo, rows, w.cfg.ForceReplicate)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if sql != "" {
+			sqls = append(sqls, sql)
+			values = append(values, value)
+		}
+	}
+
+	// The behavior of update statement differs between TiDB and MySQL.
+	// So we don't use batch update statement when downstream is MySQL.
+	// Ref:https://docs.pingcap.com/tidb/stable/sql-statement-update#mysql-compatibility
+	// The multi-row update statement is also not efficient for the large rows.
+	var rowsSize, rowCount int64
+	for _, event := range events {
+		rowsSize += event.GetRowsSize()
+		rowCount += int64(event.Len())
+	}
+	batchUpdate := w.cfg.IsTiDB &&
+		rowsSize < int64(w.cfg.MaxMultiUpdateRowSize)*rowCount
+	for _, rows := range updateRows {
+		if batchUpdate {
+			sql, value, err := buildBatchUpdate(tableInfo, rows, w.cfg.ForceReplicate)
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			if sql != "" {
+				sqls = append(sqls, sql)
+				values = append(values, value)
+			}
+			continue
+		}
+		for _, row := range rows {
+			sql, value, err := buildUpdate(tableInfo, row, w.cfg.ForceReplicate)
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			if sql != "" {
+				sqls = append(sqls, sql)
+				values = append(values, value)
+			}
+		}
+	}
+
+	for _, rows := range insertRows {
+		sql, value, err := buildBatchInsert(tableInfo, rows, translateToInsert)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if sql != "" {
+			sqls = append(sqls, sql)
+			values = append(values, value)
+		}
+	}
+	return sqls, values, nil
+}
+
+// groupRowsByType splits the rows of the events into batches of inserts, updates and deletes.
+// If the rows can't be translated to insert, the update is split into a delete and a replace,
+// which is the same as the non-batch way.
+func (w *MysqlWriter) groupRowsByType(
+	events []*commonEvent.DMLEvent, translateToInsert bool,
+) (insertRows, updateRows, deleteRows [][]commonEvent.RowChange) {
+	var insertRow, updateRow, deleteRow []commonEvent.RowChange
+	appendRow := func(batch []commonEvent.RowChange, batches [][]commonEvent.RowChange,
+		row commonEvent.RowChange, limit int,
+	) ([]commonEvent.RowChange, [][]commonEvent.RowChange) {
+		batch = append(batch, row)
+		if limit > 0 && len(batch) >= limit {
+			batches = append(batches, batch)
+			batch = nil
+		}
+		return batch, batches
+	}
+	for _, event := range events {
+		for {
+			row, ok := event.GetNextRow()
+			if !ok {
+				break
+			}
+			switch row.RowType {
+			case commonEvent.RowTypeInsert:
+				insertRow, insertRows = appendRow(insertRow, insertRows, row, w.cfg.MaxTxnRow)
+			case commonEvent.RowTypeDelete:
+				deleteRow, deleteRows = appendRow(deleteRow, deleteRows, row, w.cfg.MaxTxnRow)
+			case commonEvent.RowTypeUpdate:
+				if translateToInsert {
+					updateRow, updateRows = appendRow(updateRow, updateRows, row, w.cfg.MaxMultiUpdateRowCount)
+					continue
+				}
+				deleteRow, deleteRows = appendRow(deleteRow, deleteRows,
+					commonEvent.RowChange{PreRow: row.PreRow, RowType: commonEvent.RowTypeDelete}, w.cfg.MaxTxnRow)
+				insertRow, insertRows = appendRow(insertRow, insertRows,
+					commonEvent.RowChange{Row: row.Row, RowType: commonEvent.RowTypeInsert}, w.cfg.MaxTxnRow)
+			}
+		}
+	}
+	if len(insertRow) > 0 {
+		insertRows = append(insertRows, insertRow)
+	}
+	if len(updateRow) > 0 {
+		updateRows = append(updateRows, updateRow)
+	}
+	if len(deleteRow) > 0 {
+		deleteRows = append(deleteRows, deleteRow)
+	}
+	return
+}
+
 func (w *MysqlWriter) execDMLWithMaxRetries(dmls *preparedDMLs) error {
 	if len(dmls.sqls) != len(dmls.values) {
 		return cerror.ErrUnexpected.FastGenByArgs(fmt.Sprintf("unexpected number of sqls and values, sqls is %s, values is %s", dmls.sqls, dmls.values))
@@ -59,6 +59,13 @@ func (w *MysqlWriter) FlushDDLTs(event commonEvent.BlockEvent) error {
 	if err != nil {
 		return err
 	}
+	if w.cfg.EnableDMLCheckpoint && event.GetNeedDroppedTables() != nil {
+		// the dml checkpoint items of the dropped tables are removed with the ddl ts items.
+		err = w.createDMLCheckpointTableIfNotExist()
+		if err != nil {
+			return err
+		}
+	}
 	err = w.SendDDLTs(event)
 	return errors.Trace(err)
 }
@@ -198,6 +205,21 @@ func (w *MysqlWriter) SendDDLTs(event commonEvent.BlockEvent) error {
 			}
 			return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("failed to delete ddl ts item; Query is %s", query)))
 		}
+
+		if w.cfg.EnableDMLCheckpoint {
+			query = dropDMLCheckpointItemQuery(dropTableIds, ticdcClusterID, changefeedID)
+			log.Debug("send dml checkpoint table query", zap.String("query", query))
+
+			_, err = tx.Exec(query)
+			if err != nil {
+				log.Error("failed to delete dml checkpoint item", zap.Error(err))
+				err2 := tx.Rollback()
+				if err2 != nil {
+					log.Error("failed to delete dml checkpoint item", zap.Error(err2))
+				}
+				return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("failed to delete dml checkpoint item; Query is %s", query)))
+			}
+		}
 	}
 
 	err = tx.Commit()
@@ -414,6 +436,12 @@ func (w *MysqlWriter) queryDDLJobs(tableID int64) (time.Time, bool) {
 }
 
 func (w *MysqlWriter) RemoveDDLTsItem() error {
+	if w.cfg.EnableDMLCheckpoint {
+		if err := w.removeDMLCheckpointItem(); err != nil {
+			return err
+		}
+	}
+
 	tx, err := w.db.BeginTx(w.ctx, nil)
 	if err != nil {
 		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "select ddl ts table: begin Tx fail;"))
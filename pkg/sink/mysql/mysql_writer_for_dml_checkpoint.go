@@ -0,0 +1,373 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/apperror"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/config"
+	"go.uber.org/zap"
+)
+
+// DMLCheckpoint is the progress of the dml events of a dispatcher recorded in the downstream.
+//
+// The dml checkpoint of each dispatcher is written in the same transaction as the rows,
+// so when the dispatcher restarts or moves to another node, it knows exactly which events have been applied.
+// A transaction may be split into several dml events with the same commitTs,
+// so the events whose commitTs equals to AppliedTs can't be skipped, they are written in safe mode instead.
+type DMLCheckpoint struct {
+	// AppliedTs indicates the dml events whose commitTs is less than it have been written to downstream.
+	AppliedTs uint64
+	// ReplicatingTs indicates the dml events whose commitTs is not larger than it
+	// may have been written to downstream, so they should be written in safe mode.
+	ReplicatingTs uint64
+}
+
+// dmlCheckpointRecord is a row of the dml checkpoint table.
+type dmlCheckpointRecord struct {
+	dispatcherID  string
+	tableSpan     heartbeatpb.TableSpan
+	appliedTs     uint64
+	replicatingTs uint64
+}
+
+// GetDMLCheckpoints returns the dml checkpoint for each dispatcher, and registers the new dispatchers
+// in the dml checkpoint table.
+// For each dispatcher,
+//  1. If the dispatcher has its own row, the dml checkpoint is read from the row.
+//  2. Else if the rows of the other dispatchers of the same table cover the whole table span,
+//     which means the dispatcher is split or merged from them, AppliedTs is the minimum appliedTs of the rows,
+//     and ReplicatingTs is the maximum progress of the rows.
+//  3. Else, nothing is known about the progress, AppliedTs is 0 and ReplicatingTs is the given replicatingTs.
+func (w *MysqlWriter) GetDMLCheckpoints(
+	dispatcherIDs []common.DispatcherID,
+	tableSpans []*heartbeatpb.TableSpan,
+	replicatingTsList []uint64,
+) ([]DMLCheckpoint, error) {
+	checkpoints := make([]DMLCheckpoint, len(dispatcherIDs))
+	if len(dispatcherIDs) == 0 {
+		return checkpoints, nil
+	}
+	if err := w.createDMLCheckpointTableIfNotExist(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	changefeedID := w.ChangefeedID.String()
+	ticdcClusterID := config.GetGlobalServerConfig().ClusterID
+
+	tableIDs := make([]int64, 0, len(tableSpans))
+	tableIDSet := make(map[int64]struct{}, len(tableSpans))
+	for _, span := range tableSpans {
+		if _, ok := tableIDSet[span.TableID]; !ok {
+			tableIDSet[span.TableID] = struct{}{}
+			tableIDs = append(tableIDs, span.TableID)
+		}
+	}
+
+	query := selectDMLCheckpointQuery(tableIDs, ticdcClusterID, changefeedID)
+	rows, err := w.db.Query(query)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("failed to check dml checkpoint table; Query is %s", query)))
+	}
+	defer rows.Close()
+
+	recordsByTable := make(map[int64][]dmlCheckpointRecord)
+	recordsByDispatcher := make(map[string]dmlCheckpointRecord)
+	for rows.Next() {
+		var record dmlCheckpointRecord
+		err = rows.Scan(&record.dispatcherID, &record.tableSpan.TableID,
+			&record.tableSpan.StartKey, &record.tableSpan.EndKey,
+			&record.appliedTs, &record.replicatingTs)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("failed to check dml checkpoint table; Query is %s", query)))
+		}
+		recordsByTable[record.tableSpan.TableID] = append(recordsByTable[record.tableSpan.TableID], record)
+		recordsByDispatcher[record.dispatcherID] = record
+	}
+	if err = rows.Err(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("failed to check dml checkpoint table; Query is %s", query)))
+	}
+
+	newRecords := make([]dmlCheckpointRecord, 0, len(dispatcherIDs))
+	for idx, id := range dispatcherIDs {
+		// table trigger event dispatcher doesn't deal with dml events.
+		if tableSpans[idx].Equal(heartbeatpb.DDLSpan) {
+			checkpoints[idx] = DMLCheckpoint{AppliedTs: 0, ReplicatingTs: replicatingTsList[idx]}
+			continue
+		}
+		if record, ok := recordsByDispatcher[id.String()]; ok {
+			checkpoints[idx] = DMLCheckpoint{
+				AppliedTs:     record.appliedTs,
+				ReplicatingTs: max(record.appliedTs, record.replicatingTs),
+			}
+			continue
+		}
+		checkpoints[idx] = calculateDMLCheckpoint(*tableSpans[idx], recordsByTable[tableSpans[idx].TableID], replicatingTsList[idx])
+		newRecords = append(newRecords, dmlCheckpointRecord{
+			dispatcherID:  id.String(),
+			tableSpan:     *tableSpans[idx],
+			appliedTs:     checkpoints[idx].AppliedTs,
+			replicatingTs: checkpoints[idx].ReplicatingTs,
+		})
+	}
+	log.Info("get dml checkpoints",
+		zap.String("namespace", w.ChangefeedID.Namespace()),
+		zap.String("changefeed", w.ChangefeedID.Name()),
+		zap.Any("dispatcherIDs", dispatcherIDs),
+		zap.Any("checkpoints", checkpoints))
+
+	if len(newRecords) == 0 {
+		return checkpoints, nil
+	}
+	query = insertDMLCheckpointQuery(newRecords, ticdcClusterID, changefeedID)
+	if _, err = w.db.ExecContext(w.ctx, query); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("failed to write dml checkpoint table; Query is %s", query)))
+	}
+	return checkpoints, nil
+}
+
+// calculateDMLCheckpoint calculates the dml checkpoint of the span from the records of the other dispatchers.
+func calculateDMLCheckpoint(span heartbeatpb.TableSpan, records []dmlCheckpointRecord, replicatingTs uint64) DMLCheckpoint {
+	overlapped := make([]dmlCheckpointRecord, 0, len(records))
+	for _, record := range records {
+		if !common.IsEmptySpan(common.GetIntersectSpan(span, record.tableSpan)) {
+			overlapped = append(overlapped, record)
+		}
+	}
+	unknown := DMLCheckpoint{AppliedTs: 0, ReplicatingTs: replicatingTs}
+	if len(overlapped) == 0 {
+		return unknown
+	}
+	sort.Slice(overlapped, func(i, j int) bool {
+		return common.StartCompare(overlapped[i].tableSpan.StartKey, overlapped[j].tableSpan.StartKey) < 0
+	})
+
+	// check whether the records cover the whole span.
+	if common.StartCompare(overlapped[0].tableSpan.StartKey, span.StartKey) > 0 {
+		return unknown
+	}
+	checkpoint := DMLCheckpoint{AppliedTs: overlapped[0].appliedTs}
+	coveredEnd := overlapped[0].tableSpan.EndKey
+	for _, record := range overlapped {
+		// there is a gap between the records.
+		if len(record.tableSpan.StartKey) != 0 && common.EndCompare(record.tableSpan.StartKey, coveredEnd) > 0 {
+			return unknown
+		}
+		if common.EndCompare(record.tableSpan.EndKey, coveredEnd) > 0 {
+			coveredEnd = record.tableSpan.EndKey
+		}
+		checkpoint.AppliedTs = min(checkpoint.AppliedTs, record.appliedTs)
+		checkpoint.ReplicatingTs = max(checkpoint.ReplicatingTs, record.appliedTs, record.replicatingTs)
+	}
+	if common.EndCompare(coveredEnd, span.EndKey) < 0 {
+		return unknown
+	}
+	return checkpoint
+}
+
+// genDMLCheckpointSQL returns the sql to update the dml checkpoint of the dispatchers of the events,
+// it's executed in the same transaction as the rows.
+func (w *MysqlWriter) genDMLCheckpointSQL(events []*commonEvent.DMLEvent) (string, []interface{}) {
+	changefeedID := w.ChangefeedID.String()
+	ticdcClusterID := config.GetGlobalServerConfig().ClusterID
+
+	dispatcherIDs := make([]common.DispatcherID, 0, 1)
+	progress := make(map[common.DispatcherID]*commonEvent.DMLEvent)
+	for _, event := range events {
+		last, ok := progress[event.DispatcherID]
+		if !ok {
+			dispatcherIDs = append(dispatcherIDs, event.DispatcherID)
+		}
+		if !ok || event.CommitTs > last.CommitTs {
+			progress[event.DispatcherID] = event
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString("INSERT INTO ")
+	builder.WriteString(filter.TiCDCSystemSchema)
+	builder.WriteString(".")
+	builder.WriteString(filter.DMLCheckpointTable)
+	builder.WriteString(" (ticdc_cluster_id, changefeed, dispatcher_id, table_id, applied_ts) VALUES ")
+	args := make([]interface{}, 0, len(dispatcherIDs)*5)
+	for idx, id := range dispatcherIDs {
+		event := progress[id]
+		builder.WriteString("(?,?,?,?,?)")
+		if idx < len(dispatcherIDs)-1 {
+			builder.WriteString(",")
+		}
+		args = append(args, ticdcClusterID, changefeedID, id.String(), event.PhysicalTableID, event.CommitTs)
+	}
+	builder.WriteString(" ON DUPLICATE KEY UPDATE applied_ts=GREATEST(applied_ts, VALUES(applied_ts))")
+	return builder.String(), args
+}
+
+func selectDMLCheckpointQuery(tableIDs []int64, ticdcClusterID string, changefeedID string) string {
+	var builder strings.Builder
+	builder.WriteString("SELECT dispatcher_id, table_id, start_key, end_key, applied_ts, replicating_ts FROM ")
+	builder.WriteString(filter.TiCDCSystemSchema)
+	builder.WriteString(".")
+	builder.WriteString(filter.DMLCheckpointTable)
+	builder.WriteString(" WHERE (ticdc_cluster_id, changefeed, table_id) IN (")
+
+	for idx, tableID := range tableIDs {
+		builder.WriteString("('")
+		builder.WriteString(ticdcClusterID)
+		builder.WriteString("', '")
+		builder.WriteString(changefeedID)
+		builder.WriteString("', ")
+		builder.WriteString(strconv.FormatInt(tableID, 10))
+		builder.WriteString(")")
+		if idx < len(tableIDs)-1 {
+			builder.WriteString(", ")
+		}
+	}
+	builder.WriteString(")")
+	return builder.String()
+}
+
+func insertDMLCheckpointQuery(records []dmlCheckpointRecord, ticdcClusterID string, changefeedID string) string {
+	var builder strings.Builder
+	builder.WriteString("INSERT INTO ")
+	builder.WriteString(filter.TiCDCSystemSchema)
+	builder.WriteString(".")
+	builder.WriteString(filter.DMLCheckpointTable)
+	builder.WriteString(" (ticdc_cluster_id, changefeed, dispatcher_id, table_id, start_key, end_key, applied_ts, replicating_ts) VALUES ")
+
+	for idx, record := range records {
+		builder.WriteString("('")
+		builder.WriteString(ticdcClusterID)
+		builder.WriteString("', '")
+		builder.WriteString(changefeedID)
+		builder.WriteString("', '")
+		builder.WriteString(record.dispatcherID)
+		builder.WriteString("', ")
+		builder.WriteString(strconv.FormatInt(record.tableSpan.TableID, 10))
+		builder.WriteString(", x'")
+		builder.WriteString(hex.EncodeToString(record.tableSpan.StartKey))
+		builder.WriteString("', x'")
+		builder.WriteString(hex.EncodeToString(record.tableSpan.EndKey))
+		builder.WriteString("', ")
+		builder.WriteString(strconv.FormatUint(record.appliedTs, 10))
+		builder.WriteString(", ")
+		builder.WriteString(strconv.FormatUint(record.replicatingTs, 10))
+		builder.WriteString(")")
+		if idx < len(records)-1 {
+			builder.WriteString(", ")
+		}
+	}
+	builder.WriteString(" ON DUPLICATE KEY UPDATE table_id=VALUES(table_id), start_key=VALUES(start_key), end_key=VALUES(end_key), applied_ts=VALUES(applied_ts), replicating_ts=VALUES(replicating_ts)")
+	return builder.String()
+}
+
+func dropDMLCheckpointItemQuery(dropTableIds []int64, ticdcClusterID string, changefeedID string) string {
+	var builder strings.Builder
+	builder.WriteString("DELETE FROM ")
+	builder.WriteString(filter.TiCDCSystemSchema)
+	builder.WriteString(".")
+	builder.WriteString(filter.DMLCheckpointTable)
+	builder.WriteString(" WHERE (ticdc_cluster_id, changefeed, table_id) IN (")
+
+	for idx, tableId := range dropTableIds {
+		builder.WriteString("('")
+		builder.WriteString(ticdcClusterID)
+		builder.WriteString("', '")
+		builder.WriteString(changefeedID)
+		builder.WriteString("', ")
+		builder.WriteString(strconv.FormatInt(tableId, 10))
+		builder.WriteString(")")
+		if idx < len(dropTableIds)-1 {
+			builder.WriteString(", ")
+		}
+	}
+
+	builder.WriteString(")")
+	return builder.String()
+}
+
+// removeDMLCheckpointItem removes all the dml checkpoint items of the changefeed.
+func (w *MysqlWriter) removeDMLCheckpointItem() error {
+	changefeedID := w.ChangefeedID.String()
+	ticdcClusterID := config.GetGlobalServerConfig().ClusterID
+
+	var builder strings.Builder
+	builder.WriteString("DELETE FROM ")
+	builder.WriteString(filter.TiCDCSystemSchema)
+	builder.WriteString(".")
+	builder.WriteString(filter.DMLCheckpointTable)
+	builder.WriteString(" WHERE (ticdc_cluster_id, changefeed) IN (('")
+	builder.WriteString(ticdcClusterID)
+	builder.WriteString("', '")
+	builder.WriteString(changefeedID)
+	builder.WriteString("'))")
+	query := builder.String()
+
+	_, err := w.db.ExecContext(w.ctx, query)
+	if err != nil {
+		if apperror.IsTableNotExistsErr(err) {
+			log.Info("dml checkpoint table is not found when removeDMLCheckpointItem",
+				zap.String("namespace", w.ChangefeedID.Namespace()),
+				zap.String("changefeedID", w.ChangefeedID.Name()),
+				zap.Error(err))
+			return nil
+		}
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("failed to delete dml checkpoint item; Query is %s", query)))
+	}
+	return nil
+}
+
+func (w *MysqlWriter) createDMLCheckpointTable() error {
+	database := filter.TiCDCSystemSchema
+	query := `CREATE TABLE IF NOT EXISTS %s
+	(
+		ticdc_cluster_id varchar (255),
+		changefeed varchar(255),
+		dispatcher_id varchar(64),
+		table_id bigint(21),
+		start_key blob,
+		end_key blob,
+		applied_ts bigint unsigned NOT NULL DEFAULT 0,
+		replicating_ts bigint unsigned NOT NULL DEFAULT 0,
+		updated_at datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		INDEX (ticdc_cluster_id, changefeed, table_id),
+		PRIMARY KEY (ticdc_cluster_id, changefeed, dispatcher_id)
+	);`
+	query = fmt.Sprintf(query, filter.DMLCheckpointTable)
+
+	return w.createTable(database, filter.DMLCheckpointTable, query)
+}
+
+func (w *MysqlWriter) createDMLCheckpointTableIfNotExist() error {
+	if !w.dmlCheckpointTableInit {
+		// create dml checkpoint table if not exist
+		err := w.createDMLCheckpointTable()
+		if err != nil {
+			return err
+		}
+		w.dmlCheckpointTableInit = true
+	}
+	return nil
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -101,6 +102,65 @@ func TestMysqlWriter_FlushDML(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMysqlWriter_FlushDMLWithDMLCheckpoint(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+	writer.cfg.EnableDMLCheckpoint = true
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	createTableSQL := "create table t (id int primary key, name varchar(32));"
+	job := helper.DDL2Job(createTableSQL)
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')")
+	dmlEvent.CommitTs = 2
+	dmlEvent.ReplicatingTs = 1
+
+	dmlEvent2 := helper.DML2Event("test", "t", "insert into t values (2, 'test2');")
+	dmlEvent2.DispatcherID = dmlEvent.DispatcherID
+	dmlEvent2.CommitTs = 3
+	dmlEvent2.ReplicatingTs = 1
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);"+
+		"INSERT INTO tidb_cdc.dml_checkpoint_v1 (ticdc_cluster_id, changefeed, dispatcher_id, table_id, applied_ts) VALUES (?,?,?,?,?) "+
+		"ON DUPLICATE KEY UPDATE applied_ts=GREATEST(applied_ts, VALUES(applied_ts))").
+		WithArgs(1, "test", 2, "test2", "default", writer.ChangefeedID.String(), dmlEvent.DispatcherID.String(), dmlEvent.PhysicalTableID, uint64(3)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := writer.Flush([]*commonEvent.DMLEvent{dmlEvent, dmlEvent2})
+	require.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err)
+}
+
+func TestCalculateDMLCheckpoint(t *testing.T) {
+	span := heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("b"), EndKey: []byte("e")}
+	records := []dmlCheckpointRecord{
+		{tableSpan: heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("c"), EndKey: []byte("f")}, appliedTs: 20, replicatingTs: 5},
+		{tableSpan: heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("c")}, appliedTs: 10, replicatingTs: 25},
+		{tableSpan: heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("f"), EndKey: []byte("g")}, appliedTs: 1, replicatingTs: 1},
+	}
+	// the records cover the whole span.
+	checkpoint := calculateDMLCheckpoint(span, records, 100)
+	require.Equal(t, DMLCheckpoint{AppliedTs: 10, ReplicatingTs: 25}, checkpoint)
+
+	// there is a gap between the records.
+	checkpoint = calculateDMLCheckpoint(span, records[:1], 100)
+	require.Equal(t, DMLCheckpoint{AppliedTs: 0, ReplicatingTs: 100}, checkpoint)
+	checkpoint = calculateDMLCheckpoint(span, []dmlCheckpointRecord{records[1], records[2]}, 100)
+	require.Equal(t, DMLCheckpoint{AppliedTs: 0, ReplicatingTs: 100}, checkpoint)
+
+	// no records.
+	checkpoint = calculateDMLCheckpoint(span, nil, 100)
+	require.Equal(t, DMLCheckpoint{AppliedTs: 0, ReplicatingTs: 100}, checkpoint)
+}
+
 // Test flush ddl event
 // Ensure the ddl query will be write to the databases
 // and the ddl_ts_v1 table will be updated with the ddl_ts and table_id
@@ -475,3 +535,72 @@ func TestMysqlWriter_AsyncDDL(t *testing.T) {
 	err = mock.ExpectationsWereMet()
 	require.NoError(t, err)
 }
+
+func TestMysqlWriter_FlushBatchDML(t *testing.T) {
+	writer, db, mock := newTestMysqlWriterForTiDB(t)
+	defer db.Close()
+	writer.cfg.BatchDMLEnable = true
+	writer.cfg.MaxTxnRow = 2
+	writer.cfg.MaxMultiUpdateRowCount = 2
+	writer.cfg.MaxMultiUpdateRowSize = 1024
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t",
+		"insert into t values (1, 'a');",
+		"insert into t values (2, 'b');",
+		"insert into t values (3, 'c');")
+	dmlEvent.CommitTs = 2
+	dmlEvent.ReplicatingTs = 1
+
+	// the rows are split by the max txn row.
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?),(?,?);"+
+		"INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "a", 2, "b", 3, "c").
+		WillReturnResult(sqlmock.NewResult(3, 3))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{dmlEvent}))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	newRow := func(id int64, name string) []types.Datum {
+		return []types.Datum{types.NewIntDatum(id), types.NewStringDatum(name)}
+	}
+	dmlEvent = commonEvent.NewDMLEvent(dmlEvent.DispatcherID, dmlEvent.PhysicalTableID, 3, 4, dmlEvent.TableInfo)
+	dmlEvent.ReplicatingTs = 1
+	dmlEvent.AppendRowDatums(commonEvent.RowTypeDelete, newRow(1, "a"), nil)
+	dmlEvent.AppendRowDatums(commonEvent.RowTypeUpdate, newRow(2, "b"), newRow(2, "bb"))
+	dmlEvent.AppendRowDatums(commonEvent.RowTypeUpdate, newRow(3, "c"), newRow(3, "cc"))
+	dmlEvent.AppendRowDatums(commonEvent.RowTypeInsert, nil, newRow(4, "d"))
+
+	// the deletes are executed first, then the updates and the inserts.
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM `test`.`t` WHERE (`id`) IN ((?));"+
+		"UPDATE `test`.`t` SET `id` = CASE WHEN `id` = ? THEN ? WHEN `id` = ? THEN ? END, "+
+		"`name` = CASE WHEN `id` = ? THEN ? WHEN `id` = ? THEN ? END WHERE (`id`) IN ((?),(?));"+
+		"INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, 2, 2, 3, 3, 2, "bb", 3, "cc", 2, 3, 4, "d").
+		WillReturnResult(sqlmock.NewResult(4, 4))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{dmlEvent}))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// in safe mode, the update is split into a delete and a replace.
+	writer.cfg.SafeMode = true
+	dmlEvent.Rewind()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM `test`.`t` WHERE (`id`) IN ((?),(?));"+
+		"DELETE FROM `test`.`t` WHERE (`id`) IN ((?));"+
+		"REPLACE INTO `test`.`t` (`id`,`name`) VALUES (?,?),(?,?);"+
+		"REPLACE INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, 2, 3, 2, "bb", 3, "cc", 4, "d").
+		WillReturnResult(sqlmock.NewResult(4, 4))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{dmlEvent}))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
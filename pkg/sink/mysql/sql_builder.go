@@ -65,6 +65,15 @@ func (d *preparedDMLs) reset() {
 	d.approximateSize = 0
 }
 
+// appendEvent records the row count, the size and the startTs of the event.
+func (d *preparedDMLs) appendEvent(event *commonEvent.DMLEvent) {
+	d.rowCount += int(event.Len())
+	d.approximateSize += event.GetRowsSize()
+	if len(d.startTs) == 0 || d.startTs[len(d.startTs)-1] != event.StartTs {
+		d.startTs = append(d.startTs, event.StartTs)
+	}
+}
+
 // prepareReplace builds a parametrics REPLACE statement as following
 // sql: `REPLACE INTO `test`.`t` VALUES (?,?,?)`
 func buildInsert(
@@ -217,3 +226,193 @@ func whereSlice(row *chunk.Row, tableInfo *common.TableInfo, forceReplicate bool
 	}
 	return colNames, args, nil
 }
+
+// buildBatchInsert builds a multi-row INSERT or REPLACE statement as following
+// sql: `INSERT INTO `test`.`t` (`a`,`b`) VALUES (?,?),(?,?)`
+func buildBatchInsert(
+	tableInfo *common.TableInfo,
+	rows []commonEvent.RowChange,
+	translateToInsert bool,
+) (string, []interface{}, error) {
+	var builder strings.Builder
+	if translateToInsert {
+		builder.WriteString(tableInfo.GetPreInsertSQL())
+	} else {
+		builder.WriteString(tableInfo.GetPreReplaceSQL())
+	}
+
+	var args []interface{}
+	for i, row := range rows {
+		rowArgs, err := getArgs(&row.Row, tableInfo)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		if len(rowArgs) == 0 {
+			return "", nil, nil
+		}
+		// the placeholders of the first row are already in the pre sql.
+		if i > 0 {
+			builder.WriteString(",(")
+			builder.WriteString(placeHolder(len(rowArgs)))
+			builder.WriteString(")")
+		}
+		args = append(args, rowArgs...)
+	}
+	return builder.String(), args, nil
+}
+
+// buildBatchDelete builds a multi-row DELETE statement as following
+// sql: `DELETE FROM `test`.`t` WHERE (`a`,`b`) IN ((?,?),(?,?))`
+// The rows must be identified by the handle key, which is never null.
+func buildBatchDelete(
+	tableInfo *common.TableInfo, rows []commonEvent.RowChange, forceReplicate bool,
+) (string, []interface{}, error) {
+	var builder strings.Builder
+	builder.WriteString("DELETE FROM ")
+	builder.WriteString(tableInfo.TableName.QuoteString())
+	builder.WriteString(" WHERE ")
+
+	colNames, args, err := batchWhereSlice(rows, tableInfo, forceReplicate)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	if len(args) == 0 {
+		return "", nil, nil
+	}
+	writeBatchWhere(&builder, colNames, len(rows))
+	return builder.String(), args, nil
+}
+
+// buildBatchUpdate builds a multi-row UPDATE statement as following
+// sql: `UPDATE `test`.`t` SET `a` = CASE WHEN `a` = ? THEN ? WHEN `a` = ? THEN ? END,
+// `b` = CASE WHEN `a` = ? THEN ? WHEN `a` = ? THEN ? END WHERE (`a`) IN ((?),(?))`
+// The rows must be identified by the handle key, which is never null.
+func buildBatchUpdate(
+	tableInfo *common.TableInfo, rows []commonEvent.RowChange, forceReplicate bool,
+) (string, []interface{}, error) {
+	colNames, whereArgs, err := batchWhereSlice(rows, tableInfo, forceReplicate)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	if len(whereArgs) == 0 {
+		return "", nil, nil
+	}
+	rowArgs := make([][]interface{}, 0, len(rows))
+	for _, row := range rows {
+		args, err := getArgs(&row.Row, tableInfo)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		if len(args) == 0 {
+			return "", nil, nil
+		}
+		rowArgs = append(rowArgs, args)
+	}
+
+	var when strings.Builder
+	for i, name := range colNames {
+		if i > 0 {
+			when.WriteString(" AND ")
+		}
+		when.WriteString(quotes.QuoteName(name))
+		when.WriteString(" = ?")
+	}
+	whenCondition := when.String()
+
+	var builder strings.Builder
+	builder.WriteString("UPDATE ")
+	builder.WriteString(tableInfo.TableName.QuoteString())
+	builder.WriteString(" SET ")
+	args := make([]interface{}, 0, len(rowArgs[0])*len(rows)*(len(colNames)+1)+len(whereArgs))
+	offset := 0
+	for _, col := range tableInfo.GetColumns() {
+		if col == nil || tableInfo.GetColumnFlags()[col.ID].IsGeneratedColumn() {
+			continue
+		}
+		if offset > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(quotes.QuoteName(col.Name.O))
+		builder.WriteString(" = CASE")
+		for i := range rows {
+			builder.WriteString(" WHEN ")
+			builder.WriteString(whenCondition)
+			builder.WriteString(" THEN ?")
+			args = append(args, whereArgs[i*len(colNames):(i+1)*len(colNames)]...)
+			args = append(args, rowArgs[i][offset])
+		}
+		builder.WriteString(" END")
+		offset++
+	}
+	builder.WriteString(" WHERE ")
+	writeBatchWhere(&builder, colNames, len(rows))
+	args = append(args, whereArgs...)
+	return builder.String(), args, nil
+}
+
+// batchWhereSlice returns the column names of the WHERE clause and the values of all rows.
+func batchWhereSlice(
+	rows []commonEvent.RowChange, tableInfo *common.TableInfo, forceReplicate bool,
+) ([]string, []interface{}, error) {
+	var (
+		colNames []string
+		args     []interface{}
+	)
+	for _, row := range rows {
+		names, whereArgs, err := whereSlice(&row.PreRow, tableInfo, forceReplicate)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if len(whereArgs) == 0 {
+			return nil, nil, nil
+		}
+		colNames = names
+		args = append(args, whereArgs...)
+	}
+	return colNames, args, nil
+}
+
+// writeBatchWhere writes `(`a`,`b`) IN ((?,?),(?,?))` to the builder.
+func writeBatchWhere(builder *strings.Builder, colNames []string, rowCount int) {
+	builder.WriteString("(")
+	for i, name := range colNames {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString(quotes.QuoteName(name))
+	}
+	builder.WriteString(") IN (")
+	for i := 0; i < rowCount; i++ {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString("(")
+		builder.WriteString(placeHolder(len(colNames)))
+		builder.WriteString(")")
+	}
+	builder.WriteString(")")
+}
+
+// placeHolder returns a string with n placeholders separated by commas
+func placeHolder(n int) string {
+	var builder strings.Builder
+	builder.Grow(n * 2)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString("?")
+	}
+	return builder.String()
+}
+
+// hasHandleKey returns whether the table has a handle key,
+// which is the primary key or a not null unique key.
+func hasHandleKey(tableInfo *common.TableInfo) bool {
+	for _, col := range tableInfo.GetColumns() {
+		if col != nil && tableInfo.GetColumnFlags()[col.ID].IsHandleKey() {
+			return true
+		}
+	}
+	return false
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/common/event"
 	pevent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -371,3 +372,110 @@ func TestBuildUpdate(t *testing.T) {
 	require.Len(t, args, 5)
 	require.Equal(t, expectedArgs, args)
 }
+
+func TestBuildBatchDML(t *testing.T) {
+	helper := event.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int, name varchar(32) not null, age int not null, unique key (age, name));")
+	require.NotNil(t, job)
+
+	event := helper.DML2Event("test", "t",
+		"insert into t values (1, 'a', 10);",
+		"insert into t values (2, 'b', 20);")
+	require.NotNil(t, event)
+	require.True(t, hasHandleKey(event.TableInfo))
+	row1, ok := event.GetNextRow()
+	require.True(t, ok)
+	row2, ok := event.GetNextRow()
+	require.True(t, ok)
+
+	sql, args, err := buildBatchInsert(event.TableInfo, []pevent.RowChange{row1, row2}, true)
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO `test`.`t` (`id`,`name`,`age`) VALUES (?,?,?),(?,?,?)", sql)
+	require.Equal(t, []interface{}{int64(1), "a", int64(10), int64(2), "b", int64(20)}, args)
+
+	sql, args, err = buildBatchInsert(event.TableInfo, []pevent.RowChange{row1, row2}, false)
+	require.NoError(t, err)
+	require.Equal(t, "REPLACE INTO `test`.`t` (`id`,`name`,`age`) VALUES (?,?,?),(?,?,?)", sql)
+	require.Equal(t, []interface{}{int64(1), "a", int64(10), int64(2), "b", int64(20)}, args)
+
+	deleteRows := []pevent.RowChange{
+		{PreRow: row1.Row, RowType: pevent.RowTypeDelete},
+		{PreRow: row2.Row, RowType: pevent.RowTypeDelete},
+	}
+	sql, args, err = buildBatchDelete(event.TableInfo, deleteRows, false)
+	require.NoError(t, err)
+	require.Equal(t, "DELETE FROM `test`.`t` WHERE (`name`,`age`) IN ((?,?),(?,?))", sql)
+	require.Equal(t, []interface{}{"a", int64(10), "b", int64(20)}, args)
+
+	// swap the values of the two rows.
+	updateRows := []pevent.RowChange{
+		{PreRow: row1.Row, Row: row2.Row, RowType: pevent.RowTypeUpdate},
+		{PreRow: row2.Row, Row: row1.Row, RowType: pevent.RowTypeUpdate},
+	}
+	sql, args, err = buildBatchUpdate(event.TableInfo, updateRows, false)
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE `test`.`t` SET "+
+		"`id` = CASE WHEN `name` = ? AND `age` = ? THEN ? WHEN `name` = ? AND `age` = ? THEN ? END, "+
+		"`name` = CASE WHEN `name` = ? AND `age` = ? THEN ? WHEN `name` = ? AND `age` = ? THEN ? END, "+
+		"`age` = CASE WHEN `name` = ? AND `age` = ? THEN ? WHEN `name` = ? AND `age` = ? THEN ? END "+
+		"WHERE (`name`,`age`) IN ((?,?),(?,?))", sql)
+	require.Equal(t, []interface{}{
+		"a", int64(10), int64(2), "b", int64(20), int64(1),
+		"a", int64(10), "b", "b", int64(20), "a",
+		"a", int64(10), int64(20), "b", int64(20), int64(10),
+		"a", int64(10), "b", int64(20),
+	}, args)
+
+	// the rows of consecutive events of the same table are batched together,
+	// until an event changes a handle key which is already changed in the batch.
+	cfg := NewMysqlConfig()
+	cfg.BatchDMLEnable = true
+	cfg.IsTiDB = true
+	writer := &MysqlWriter{cfg: cfg}
+	event.Rewind()
+	event.CommitTs, event.ReplicatingTs = 10, 1
+	event2 := helper.DML2Event("test", "t", "insert into t values (3, 'c', 30);")
+	event2.CommitTs, event2.ReplicatingTs = 11, 1
+	event3 := pevent.NewDMLEvent(event.DispatcherID, event.PhysicalTableID, 12, 13, event.TableInfo)
+	event3.ReplicatingTs = 1
+	event3.AppendRowDatums(pevent.RowTypeDelete, []types.Datum{
+		types.NewIntDatum(1), types.NewStringDatum("a"), types.NewIntDatum(10),
+	}, nil)
+	event3.AppendRowDatums(pevent.RowTypeInsert, nil, []types.Datum{
+		types.NewIntDatum(4), types.NewStringDatum("d"), types.NewIntDatum(40),
+	})
+	dmls, err := writer.prepareDMLs([]*pevent.DMLEvent{event, event2, event3})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"INSERT INTO `test`.`t` (`id`,`name`,`age`) VALUES (?,?,?),(?,?,?),(?,?,?)",
+		"DELETE FROM `test`.`t` WHERE (`name`,`age`) IN ((?,?))",
+		"INSERT INTO `test`.`t` (`id`,`name`,`age`) VALUES (?,?,?)",
+	}, dmls.sqls)
+	require.Equal(t, [][]interface{}{
+		{int64(1), "a", int64(10), int64(2), "b", int64(20), int64(3), "c", int64(30)},
+		{"a", int64(10)},
+		{int64(4), "d", int64(40)},
+	}, dmls.values)
+	require.Equal(t, 5, dmls.rowCount)
+
+	// the events with different safe mode decisions are not batched together.
+	for _, e := range []*pevent.DMLEvent{event, event2, event3} {
+		e.Rewind()
+	}
+	event2.ReplicatingTs = 20
+	dmls, err = writer.prepareDMLs([]*pevent.DMLEvent{event, event2})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"INSERT INTO `test`.`t` (`id`,`name`,`age`) VALUES (?,?,?),(?,?,?)",
+		"REPLACE INTO `test`.`t` (`id`,`name`,`age`) VALUES (?,?,?)",
+	}, dmls.sqls)
+
+	// the table without handle key can't be batched.
+	job = helper.DDL2Job("create table t2 (id int, name varchar(32));")
+	require.NotNil(t, job)
+	event = helper.DML2Event("test", "t2", "insert into t2 values (1, 'a');")
+	require.False(t, hasHandleKey(event.TableInfo))
+}
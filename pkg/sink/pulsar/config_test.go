@@ -0,0 +1,89 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsar
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPulsarConfig(t *testing.T) {
+	compression := config.PulsarCompressionType("lz4")
+	p := &config.PulsarConfig{
+		CompressionType:  &compression,
+		OperationTimeout: config.NewTimeSec(998),
+		SendTimeout:      config.NewTimeSec(123),
+	}
+
+	tests := []struct {
+		name    string
+		sinkURI string
+		wantErr bool
+	}{
+		{
+			name:    "valid sinkURI",
+			sinkURI: "pulsar://127.0.0.1:6650/persistent://tenant/namespace/test-topic?protocol=canal-json",
+			wantErr: false,
+		},
+		{
+			name:    "empty host",
+			sinkURI: "pulsar://?protocol=canal-json",
+			wantErr: true,
+		},
+		{
+			name:    "invalid scheme",
+			sinkURI: "pulsar+htp://127.0.0.1:6650/test?protocol=canal-json",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sinkURI, err := url.Parse(tt.sinkURI)
+			require.NoError(t, err)
+
+			cfg, err := NewPulsarConfig(sinkURI, p)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, pulsar.LZ4, cfg.CompressionType.Value())
+			require.Equal(t, "pulsar://127.0.0.1:6650", cfg.BrokerURL)
+			require.Equal(t, sinkURI, cfg.SinkURI)
+			require.Equal(t, defaultConnectionTimeout*time.Second, cfg.ConnectionTimeout.Duration())
+			require.Equal(t, 998*time.Second, cfg.OperationTimeout.Duration())
+			require.Equal(t, defaultBatchingMaxSize, *cfg.BatchingMaxMessages)
+			require.Equal(t, defaultBatchingMaxPublishDelay*time.Millisecond, cfg.BatchingMaxPublishDelay.Duration())
+			require.Equal(t, 123*time.Second, cfg.SendTimeout.Duration())
+			require.Equal(t, "persistent://tenant/namespace/test-topic", cfg.GetDefaultTopicName())
+		})
+	}
+}
+
+func TestNewPulsarConfigWithoutUserConfig(t *testing.T) {
+	sinkURI, err := url.Parse("pulsar+http://localhost:8080/test")
+	require.NoError(t, err)
+
+	cfg, err := NewPulsarConfig(sinkURI, nil)
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:8080", cfg.BrokerURL)
+	require.Equal(t, "test", cfg.GetDefaultTopicName())
+	require.Equal(t, defaultSendTimeout*time.Second, cfg.SendTimeout.Duration())
+}
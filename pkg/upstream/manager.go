@@ -154,6 +154,20 @@ func (m *Manager) Get(upstreamID uint64) (*Upstream, bool) {
 	return up, true
 }
 
+// Remove closes the upstream and removes it from the manager,
+// it's used to drop an upstream which failed to initialize so that it can be added again,
+// or an upstream which is not used anymore.
+func (m *Manager) Remove(upstreamID uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.ups.LoadAndDelete(upstreamID)
+	if !ok {
+		return
+	}
+	v.(*Upstream).Close()
+	log.Info("upstream is removed", zap.Uint64("id", upstreamID))
+}
+
 // Close closes all upstreams.
 // Please make sure it will only be called once when capture exits.
 func (m *Manager) Close() {
@@ -123,7 +123,14 @@ func CreateTiStore(urls string, credential *security.Credential) (tidbkv.Storage
 
 // init initializes the upstream
 func initUpstream(ctx context.Context, up *Upstream) error {
+	up.mu.Lock()
+	if status := atomic.LoadInt32(&up.status); status == closing || status == closed {
+		// the upstream is removed before it's initialized
+		up.mu.Unlock()
+		return errors.ErrUpstreamClosed.GenWithStackByArgs()
+	}
 	ctx, up.cancel = context.WithCancel(ctx)
+	up.mu.Unlock()
 	grpcTLSOption, err := up.SecurityConfig.ToGRPCDialOption()
 	if err != nil {
 		up.err.Store(err)
@@ -229,7 +236,10 @@ func initGlobalConfig(secCfg *security.Credential) {
 func (up *Upstream) Close() {
 	up.mu.Lock()
 	defer up.mu.Unlock()
-	up.cancel()
+	// cancel is nil if the upstream is closed before it's initialized
+	if up.cancel != nil {
+		up.cancel()
+	}
 	if atomic.LoadInt32(&up.status) == closed ||
 		atomic.LoadInt32(&up.status) == closing {
 		return
@@ -285,6 +295,26 @@ func (up *Upstream) IsNormal() bool {
 	return atomic.LoadInt32(&up.status) == normal && up.err.Load() == nil
 }
 
+// WaitReady blocks until the upstream is initialized,
+// it returns the error if the initialization failed.
+func (up *Upstream) WaitReady(ctx context.Context) error {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		if err := up.Error(); err != nil {
+			return errors.Trace(err)
+		}
+		if up.IsNormal() {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
 // IsClosed returns true if the upstream is closed.
 func (up *Upstream) IsClosed() bool {
 	return atomic.LoadInt32(&up.status) == closed
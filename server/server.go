@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/ticdc/logservice/logpuller"
 	"github.com/pingcap/ticdc/logservice/schemastore"
 	"github.com/pingcap/ticdc/logservice/txnutil"
+	"github.com/pingcap/ticdc/logservice/upstreamstore"
 	"github.com/pingcap/ticdc/maintainer"
 	"github.com/pingcap/ticdc/pkg/common"
 	appctx "github.com/pingcap/ticdc/pkg/common/context"
@@ -144,11 +145,22 @@ func (c *server) initialize(ctx context.Context) error {
 	)
 	schemaStore := schemastore.New(ctx, conf.DataDir, subscriptionClient, c.pdClient, c.PDClock, c.KVStorage)
 	eventStore := eventstore.New(ctx, conf.DataDir, subscriptionClient, c.PDClock)
+	upstreamStoreManager := upstreamstore.NewManager(ctx, conf.DataDir, &upstreamstore.Stores{
+		UpstreamID:         c.pdClient.GetClusterID(ctx),
+		PDClient:           c.pdClient,
+		PDAPIClient:        c.pdAPIClient,
+		RegionCache:        c.RegionCache,
+		PDClock:            c.PDClock,
+		SubscriptionClient: subscriptionClient,
+		SchemaStore:        schemaStore,
+		EventStore:         eventStore,
+	})
 	eventService := eventservice.New(eventStore, schemaStore)
 	c.subModules = []common.SubModule{
 		nodeManager,
 		subscriptionClient,
 		schemaStore,
+		upstreamStoreManager,
 		NewElector(c),
 		NewHttpServer(c, c.tcpServer.HTTP1Listener()),
 		NewGrpcServer(c.tcpServer.GrpcListener()),
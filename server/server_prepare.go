@@ -23,6 +23,7 @@ import (
 
 	"github.com/dustin/go-humanize"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/logservice/eventstore"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/etcd"
@@ -141,8 +142,13 @@ func (c *server) prepare(ctx context.Context) error {
 	if err != nil {
 		deployPath = ""
 	}
-	// TODO: Get id from disk after restart.
 	c.info = node.NewInfo(conf.AdvertiseAddr, deployPath)
+	// Reuse the id before restart if the event store has data to reuse,
+	// the other nodes detect the restart by the changed start timestamp.
+	if id := eventstore.LoadNodeID(conf.DataDir); id != "" {
+		log.Info("reuse the node id persisted before restart", zap.Stringer("id", id))
+		c.info.ID = id
+	}
 	c.session = session
 	return nil
 }
@@ -44,6 +44,23 @@ type NodeManager struct {
 	coordinatorID atomic.Value
 	nodes         atomic.Pointer[map[node.ID]*node.Info]
 
+	// unschedulable records the nodes which are being drained, no new task is scheduled to them.
+	// The value is the start timestamp of the node, the mark is cleared once the node is restarted.
+	// The epoch is bumped by the coordinator every time the nodes are drained or undrained,
+	// it's used to tell whether a status is produced after the latest drain request.
+	unschedulable struct {
+		sync.RWMutex
+		m     map[node.ID]int64
+		epoch uint64
+	}
+
+	// labels records the labels of the nodes, they are reported by the nodes
+	// and used to match the node affinity of the changefeeds.
+	labels struct {
+		sync.RWMutex
+		m map[node.ID]map[string]string
+	}
+
 	nodeChangeHandlers struct {
 		sync.RWMutex
 		m map[node.ID]NodeChangeHandler
@@ -70,6 +87,15 @@ func NewNodeManager(
 			sync.RWMutex
 			m map[string]OwnerChangeHandler
 		}{m: make(map[string]OwnerChangeHandler)},
+		unschedulable: struct {
+			sync.RWMutex
+			m     map[node.ID]int64
+			epoch uint64
+		}{m: make(map[node.ID]int64)},
+		labels: struct {
+			sync.RWMutex
+			m map[node.ID]map[string]string
+		}{m: make(map[node.ID]map[string]string)},
 	}
 	m.nodes.Store(&map[node.ID]*node.Info{})
 	m.coordinatorID.Store("")
@@ -112,12 +138,15 @@ func (c *NodeManager) Tick(
 	}
 
 	for _, capture := range state.Captures {
-		if _, exist := oldMap[node.ID(capture.ID)]; !exist {
+		// a node restarted with the same id has a different start timestamp
+		if old, exist := oldMap[node.ID(capture.ID)]; !exist || old.StartTimestamp != capture.StartTimestamp {
 			changed = true
 		}
 		allNodes[node.ID(capture.ID)] = node.CaptureInfoToNodeInfo(capture)
 	}
 	c.nodes.Store(&allNodes)
+	c.pruneUnschedulableNodes(allNodes)
+	c.pruneNodeLabels(allNodes)
 
 	if changed {
 		log.Info("server change detected")
@@ -150,6 +179,149 @@ func (c *NodeManager) GetNodeInfo(id node.ID) *node.Info {
 	return (*c.nodes.Load())[id]
 }
 
+// GetSchedulableNodes returns the alive nodes which are not being drained.
+func (c *NodeManager) GetSchedulableNodes() map[node.ID]*node.Info {
+	aliveNodes := c.GetAliveNodes()
+	c.unschedulable.RLock()
+	defer c.unschedulable.RUnlock()
+	if len(c.unschedulable.m) == 0 {
+		return aliveNodes
+	}
+	nodes := make(map[node.ID]*node.Info, len(aliveNodes))
+	for id, info := range aliveNodes {
+		if _, ok := c.unschedulable.m[id]; !ok {
+			nodes[id] = info
+		}
+	}
+	return nodes
+}
+
+// SetNodeUnschedulable marks the alive node as unschedulable, it returns false if the node is not alive.
+// The drain epoch is bumped if the node is not marked before.
+func (c *NodeManager) SetNodeUnschedulable(id node.ID) bool {
+	info := c.GetNodeInfo(id)
+	if info == nil {
+		return false
+	}
+	c.unschedulable.Lock()
+	defer c.unschedulable.Unlock()
+	if _, ok := c.unschedulable.m[id]; !ok {
+		c.bumpDrainEpoch()
+		log.Info("mark node unschedulable", zap.Stringer("node", id),
+			zap.Uint64("epoch", c.unschedulable.epoch))
+	}
+	c.unschedulable.m[id] = info.StartTimestamp
+	return true
+}
+
+// SetNodeSchedulable clears the unschedulable mark of the node, it returns false if the node is not marked.
+// The drain epoch is bumped if the mark is cleared.
+func (c *NodeManager) SetNodeSchedulable(id node.ID) bool {
+	c.unschedulable.Lock()
+	defer c.unschedulable.Unlock()
+	if _, ok := c.unschedulable.m[id]; !ok {
+		return false
+	}
+	delete(c.unschedulable.m, id)
+	c.bumpDrainEpoch()
+	log.Info("mark node schedulable", zap.Stringer("node", id),
+		zap.Uint64("epoch", c.unschedulable.epoch))
+	return true
+}
+
+// bumpDrainEpoch increases the drain epoch, the epoch is based on the current time,
+// so it keeps increasing even if the coordinator is changed.
+// The caller must hold the lock of unschedulable.
+func (c *NodeManager) bumpDrainEpoch() {
+	c.unschedulable.epoch = max(c.unschedulable.epoch+1, uint64(time.Now().UnixNano()))
+}
+
+// SetUnschedulableNodes replaces all the unschedulable nodes, the nodes not alive are ignored.
+// The request carrying an epoch older than the current one is ignored.
+func (c *NodeManager) SetUnschedulableNodes(ids []node.ID, epoch uint64) {
+	aliveNodes := c.GetAliveNodes()
+	unschedulable := make(map[node.ID]int64, len(ids))
+	for _, id := range ids {
+		if info, ok := aliveNodes[id]; ok {
+			unschedulable[id] = info.StartTimestamp
+		}
+	}
+	c.unschedulable.Lock()
+	defer c.unschedulable.Unlock()
+	if epoch < c.unschedulable.epoch {
+		log.Info("ignore the stale unschedulable nodes",
+			zap.Uint64("epoch", epoch),
+			zap.Uint64("currentEpoch", c.unschedulable.epoch))
+		return
+	}
+	c.unschedulable.m = unschedulable
+	c.unschedulable.epoch = epoch
+}
+
+// IsNodeUnschedulable returns true if the node is being drained.
+func (c *NodeManager) IsNodeUnschedulable(id node.ID) bool {
+	c.unschedulable.RLock()
+	defer c.unschedulable.RUnlock()
+	_, ok := c.unschedulable.m[id]
+	return ok
+}
+
+// GetUnschedulableNodes returns all the nodes which are being drained.
+func (c *NodeManager) GetUnschedulableNodes() []node.ID {
+	ids, _ := c.GetUnschedulableNodesWithEpoch()
+	return ids
+}
+
+// GetUnschedulableNodesWithEpoch returns all the nodes which are being drained
+// and the drain epoch they belong to.
+func (c *NodeManager) GetUnschedulableNodesWithEpoch() ([]node.ID, uint64) {
+	c.unschedulable.RLock()
+	defer c.unschedulable.RUnlock()
+	ids := make([]node.ID, 0, len(c.unschedulable.m))
+	for id := range c.unschedulable.m {
+		ids = append(ids, id)
+	}
+	return ids, c.unschedulable.epoch
+}
+
+// pruneUnschedulableNodes clears the mark of the nodes which are offline or restarted.
+func (c *NodeManager) pruneUnschedulableNodes(allNodes map[node.ID]*node.Info) {
+	c.unschedulable.Lock()
+	defer c.unschedulable.Unlock()
+	for id, startTs := range c.unschedulable.m {
+		if info, ok := allNodes[id]; !ok || info.StartTimestamp != startTs {
+			log.Info("node is offline or restarted, clear the unschedulable mark",
+				zap.Stringer("node", id))
+			delete(c.unschedulable.m, id)
+		}
+	}
+}
+
+// SetNodeLabels records the labels reported by the node.
+func (c *NodeManager) SetNodeLabels(id node.ID, labels map[string]string) {
+	c.labels.Lock()
+	defer c.labels.Unlock()
+	c.labels.m[id] = labels
+}
+
+// GetNodeLabels returns the labels of the node, the caller mustn't modify the returned map.
+func (c *NodeManager) GetNodeLabels(id node.ID) map[string]string {
+	c.labels.RLock()
+	defer c.labels.RUnlock()
+	return c.labels.m[id]
+}
+
+// pruneNodeLabels clears the labels of the nodes which are offline.
+func (c *NodeManager) pruneNodeLabels(allNodes map[node.ID]*node.Info) {
+	c.labels.Lock()
+	defer c.labels.Unlock()
+	for id := range c.labels.m {
+		if _, ok := allNodes[id]; !ok {
+			delete(c.labels.m, id)
+		}
+	}
+}
+
 func (c *NodeManager) Run(ctx context.Context) error {
 	cfg := config.GetGlobalServerConfig()
 	watcher := NewEtcdWatcher(c.etcdClient,
@@ -34,6 +34,7 @@ type eventQueue[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]] struct {
 	// Signal queue is used to decide which path's events should be popped.
 	signalQueue        *deque.Deque[eventSignal[A, P, T, D, H]]
 	totalPendingLength *atomic.Int64 // The total signal count in the queue.
+	totalPendingSize   *atomic.Int64 // The total size(bytes) of pending events of the paths in the queue.
 }
 
 func newEventQueue[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]](option Option, handler H) eventQueue[A, P, T, D, H] {
@@ -43,6 +44,7 @@ func newEventQueue[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]](optio
 		eventBlockAlloc:    deque.NewBlockAllocator[eventWrap[A, P, T, D, H]](32, 1024),
 		signalQueue:        deque.NewDeque(1024, deque.NewBlockAllocator[eventSignal[A, P, T, D, H]](1024, 32)),
 		totalPendingLength: &atomic.Int64{},
+		totalPendingSize:   &atomic.Int64{},
 	}
 
 	return eq
@@ -66,10 +68,16 @@ func (q *eventQueue[A, P, T, D, H]) appendEvent(event eventWrap[A, P, T, D, H])
 	}
 
 	if path.appendEvent(event, q.handler) {
+		q.totalPendingSize.Add(int64(event.eventSize))
 		addSignal()
 	}
 }
 
+// removePath is called after the path is removed, the pending events of the path will never be popped.
+func (q *eventQueue[A, P, T, D, H]) removePath(path *pathInfo[A, P, T, D, H]) {
+	q.totalPendingSize.Add(-int64(path.pendingSize.Load()))
+}
+
 func (q *eventQueue[A, P, T, D, H]) blockPath(path *pathInfo[A, P, T, D, H]) {
 	path.blocking = true
 }
@@ -87,7 +95,9 @@ func (q *eventQueue[A, P, T, D, H]) popEvents(buf []T) ([]T, *pathInfo[A, P, T,
 	// Append the event to the buffer
 	appendToBuf := func(event *eventWrap[A, P, T, D, H], path *pathInfo[A, P, T, D, H]) {
 		buf = append(buf, event.event)
-		path.popEvent()
+		if e, ok := path.popEvent(); ok {
+			q.totalPendingSize.Add(-int64(e.eventSize))
+		}
 	}
 
 	for {
@@ -316,4 +316,12 @@ type Metrics struct {
 		UsedMemory int64
 		MaxMemory  int64
 	}
+
+	// Streams is the metrics of each stream, indexed by the stream id.
+	Streams []StreamMetrics
+}
+
+type StreamMetrics struct {
+	PendingQueueLen int
+	UsedMemory      int64 // The total size(bytes) of pending events in the stream.
 }
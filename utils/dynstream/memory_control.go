@@ -127,12 +127,13 @@ func (as *areaMemStat[A, P, T, D, H]) updateAreaPauseState(path *pathInfo[A, P,
 		as.lastSendFeedbackTime.Store(time.Now())
 	}
 
+	// The area is shared by the paths in different streams, so the pause state may be updated concurrently.
+	// Use CAS to make sure only one stream sends the feedback when the state is changed.
 	prevPaused := as.paused.Load()
 	if prevPaused != shouldPause &&
-		time.Since(as.lastSendFeedbackTime.Load().(time.Time)) >= as.settings.Load().FeedbackInterval {
-		as.paused.Store(shouldPause)
+		time.Since(as.lastSendFeedbackTime.Load().(time.Time)) >= as.settings.Load().FeedbackInterval &&
+		as.paused.CompareAndSwap(prevPaused, shouldPause) {
 		sendFeedback(shouldPause)
-		return
 	}
 }
 
@@ -210,6 +211,7 @@ func (m *memControl[A, P, T, D, H]) addPathToArea(path *pathInfo[A, P, T, D, H],
 	path.areaMemStat = area
 	area.pathCount++
 	// Update the settings
+	settings.fix()
 	area.settings.Store(&settings)
 }
 
@@ -227,7 +229,8 @@ func (m *memControl[A, P, T, D, H]) removePathFromArea(path *pathInfo[A, P, T, D
 	}
 }
 
-// FIXME/TODO: We use global metric here, which is not good for multiple streams.
+// getMetrics returns the memory usage and the max pending size of all areas.
+// The areas are shared by all streams, see stream.getPendingMemory for the memory usage of each stream.
 func (m *memControl[A, P, T, D, H]) getMetrics() (usedMemory int64, maxMemory int64) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -34,7 +34,9 @@ type parallelDynamicStream[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D
 	pathMap    map[P]*pathInfo[A, P, T, D, H]
 
 	eventExtraSize int
-	memControl     *memControl[A, P, T, D, H] // TODO: implement memory control
+	// memControl is shared by all streams, so the memory usage of an area is
+	// accounted across the streams its paths are distributed to.
+	memControl *memControl[A, P, T, D, H]
 
 	mutex sync.RWMutex
 
@@ -162,7 +164,7 @@ func (s *parallelDynamicStream[A, P, T, D, H]) RemovePath(path P) error {
 	if s.memControl != nil {
 		s.memControl.removePathFromArea(pi)
 	}
-	pi.stream.in() <- eventWrap[A, P, T, D, H]{pathInfo: pi}
+	pi.stream.in() <- eventWrap[A, P, T, D, H]{pathInfo: pi, removePath: true}
 	delete(s.pathMap, path)
 
 	s._statRemovePathCount.Add(1)
@@ -176,10 +178,16 @@ func (s *parallelDynamicStream[A, P, T, D, H]) SetAreaSettings(area A, settings
 }
 
 func (s *parallelDynamicStream[A, P, T, D, H]) GetMetrics() Metrics {
-	metrics := Metrics{}
+	metrics := Metrics{
+		Streams: make([]StreamMetrics, 0, len(s.streams)),
+	}
 	for _, ds := range s.streams {
 		size := ds.getPendingSize()
 		metrics.PendingQueueLen += size
+		metrics.Streams = append(metrics.Streams, StreamMetrics{
+			PendingQueueLen: size,
+			UsedMemory:      ds.getPendingMemory(),
+		})
 	}
 	metrics.AddPath = int(s._statAddPathCount.Load())
 	metrics.RemovePath = int(s._statRemovePathCount.Load())
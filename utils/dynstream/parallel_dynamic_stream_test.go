@@ -134,6 +134,120 @@ func TestParallelDynamicStreamMemoryControl(t *testing.T) {
 	require.Equal(t, int64(1), inc.Load())
 }
 
+type blockWork struct {
+	ch chan struct{}
+}
+
+func (w *blockWork) Do() { <-w.ch }
+
+func TestParallelDynamicStreamMemoryControlAcrossStreams(t *testing.T) {
+	handler := &mockHandler{}
+	option := Option{
+		StreamCount:         2,
+		EnableMemoryControl: true,
+	}
+	stream := newParallelDynamicStream(mockHasher, handler, option)
+	stream.Start()
+	defer stream.Close()
+
+	// The paths are in the same area but different streams.
+	settings := AreaSettings{MaxPendingSize: 10 * stream.eventExtraSize, FeedbackInterval: time.Millisecond}
+	paths := []string{"p1", "p01"}
+	for _, path := range paths {
+		require.NoError(t, stream.AddPath(path, path, settings))
+	}
+	require.NotEqual(t, stream.pathMap["p1"].stream, stream.pathMap["p01"].stream)
+	require.Equal(t, stream.pathMap["p1"].areaMemStat, stream.pathMap["p01"].areaMemStat)
+
+	// Block both streams, so that the following events are pending.
+	block1 := &blockWork{ch: make(chan struct{})}
+	block2 := &blockWork{ch: make(chan struct{})}
+	startNotify1 := &sync.WaitGroup{}
+	startNotify2 := &sync.WaitGroup{}
+	doneNotify := &sync.WaitGroup{}
+	for _, path := range paths {
+		stream.Push(path, newMockEvent(0, path, 0, block1, startNotify1, doneNotify))
+	}
+	startNotify1.Wait()
+	for _, path := range paths {
+		stream.Push(path, newMockEvent(1, path, 0, block2, startNotify2, doneNotify))
+		for i := 2; i <= 5; i++ {
+			stream.Push(path, newMockEvent(i, path, 0, nil, nil, doneNotify))
+		}
+	}
+	// Each stream appends 5 events and then blocks on the first one,
+	// so the area is paused when the memory usage of both streams exceeds 80%.
+	close(block1.ch)
+	startNotify2.Wait()
+	metrics := stream.GetMetrics()
+	require.Len(t, metrics.Streams, 2)
+	for _, streamMetrics := range metrics.Streams {
+		require.Equal(t, int64(4*stream.eventExtraSize), streamMetrics.UsedMemory)
+	}
+	require.Equal(t, int64(8*stream.eventExtraSize), metrics.MemoryControl.UsedMemory)
+	close(block2.ch)
+	doneNotify.Wait()
+
+	// The area is paused only once, though the memory usage is reported by both streams.
+	pausedPaths := make(map[string]bool)
+	pauseAreaCount := 0
+	timer := time.After(100 * time.Millisecond)
+Loop:
+	for {
+		select {
+		case fb := <-stream.Feedback():
+			if fb.IsAreaFeedback() {
+				require.True(t, fb.IsPauseArea())
+				pauseAreaCount++
+			} else if fb.IsPausePath() {
+				pausedPaths[fb.Path] = true
+			}
+		case <-timer:
+			break Loop
+		}
+	}
+	require.Equal(t, 1, pauseAreaCount)
+	require.Equal(t, map[string]bool{"p1": true, "p01": true}, pausedPaths)
+
+	metrics = stream.GetMetrics()
+	for _, streamMetrics := range metrics.Streams {
+		require.Equal(t, int64(0), streamMetrics.UsedMemory)
+	}
+	require.Equal(t, int64(0), metrics.MemoryControl.UsedMemory)
+	require.Equal(t, int64(10*stream.eventExtraSize), metrics.MemoryControl.MaxMemory)
+}
+
+func TestParallelDynamicStreamRemovePathWithPendingEvents(t *testing.T) {
+	handler := &mockHandler{}
+	option := Option{StreamCount: 1}
+	stream := newParallelDynamicStream(mockHasher, handler, option)
+	stream.Start()
+	defer stream.Close()
+
+	require.NoError(t, stream.AddPath("p1", "d1"))
+	require.NoError(t, stream.AddPath("p2", "d2"))
+
+	// Block the stream, so that the following events are pending.
+	block := &blockWork{ch: make(chan struct{})}
+	startNotify := &sync.WaitGroup{}
+	doneNotify := &sync.WaitGroup{}
+	stream.Push("p2", newMockEvent(0, "p2", 0, block, startNotify, doneNotify))
+	startNotify.Wait()
+	for i := 1; i <= 4; i++ {
+		stream.Push("p1", newMockEvent(i, "p1", 0, nil, nil, nil))
+	}
+	stream.Push("p2", newMockEvent(5, "p2", 0, nil, nil, doneNotify))
+
+	// The pending events of p1 are released by the handle goroutine exactly once.
+	require.NoError(t, stream.RemovePath("p1"))
+	close(block.ch)
+	doneNotify.Wait()
+	require.Eventually(t, func() bool {
+		return stream.GetMetrics().Streams[0].UsedMemory == 0
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, int64(0), stream.GetMetrics().Streams[0].UsedMemory)
+}
+
 func TestFeedBack(t *testing.T) {
 	fb1 := Feedback[int, string, any]{
 		FeedbackType: 0,
@@ -90,6 +90,11 @@ func (s *stream[A, P, T, D, H]) getPendingSize() int {
 	}
 }
 
+// getPendingMemory returns the total size(bytes) of the pending events in this stream.
+func (s *stream[A, P, T, D, H]) getPendingMemory() int64 {
+	return s.eventQueue.totalPendingSize.Load()
+}
+
 func (s *stream[A, P, T, D, H]) in() chan eventWrap[A, P, T, D, H] {
 	if s.option.UseBuffer {
 		return s.inChan
@@ -180,6 +185,9 @@ func (s *stream[A, P, T, D, H]) handleLoop() {
 			s.eventQueue.wakePath(e.pathInfo)
 		case e.newPath:
 			s.eventQueue.initPath(e.pathInfo)
+		case e.removePath:
+			// Release the pending size in the handle goroutine, so that it doesn't race with popEvents.
+			s.eventQueue.removePath(e.pathInfo)
 		case e.pathInfo.removed.Load():
 			// The path is removed, so we don't need to handle its events.
 			return
@@ -330,6 +338,7 @@ func (pi *pathInfo[A, P, T, D, H]) appendEvent(event eventWrap[A, P, T, D, H], h
 		return false
 	} else {
 		pi.pendingQueue.PushBack(event)
+		pi.pendingSize.Add(uint32(event.eventSize))
 		return true
 	}
 }
@@ -350,9 +359,10 @@ func (pi *pathInfo[A, P, T, D, H]) popEvent() (eventWrap[A, P, T, D, H], bool) {
 // eventWrap contains the event and the path info.
 // It can be a event or a wake signal.
 type eventWrap[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]] struct {
-	event   T
-	wake    bool
-	newPath bool
+	event      T
+	wake       bool
+	newPath    bool
+	removePath bool
 
 	pathInfo *pathInfo[A, P, T, D, H]
 